@@ -10,6 +10,7 @@ import (
 	model "github.com/mattermost/mattermost-server/v5/model"
 	url "net/url"
 	reflect "reflect"
+	time "time"
 )
 
 // MockClient is a mock of Client interface
@@ -114,22 +115,6 @@ func (mr *MockClientMockRecorder) GetUserProfile(arg0, arg1 string) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockClient)(nil).GetUserProfile), arg0, arg1)
 }
 
-// GetApprovalDetails mocks base method
-func (m *MockClient) GetApprovalDetails(arg0, arg1, arg2 string, arg3 int) (*serializers.PipelineApprovalDetails, int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetApprovalDetails", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*serializers.PipelineApprovalDetails)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
-}
-
-// GetApprovalDetails indicates an expected call of GetApprovalDetails
-func (mr *MockClientMockRecorder) GetApprovalDetails(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalDetails", reflect.TypeOf((*MockClient)(nil).GetApprovalDetails), arg0, arg1, arg2, arg3)
-}
-
 // GetBuildDetails mocks base method
 func (m *MockClient) GetBuildDetails(arg0, arg1, arg2, arg3 string) (*serializers.BuildDetails, int, error) {
 	m.ctrl.T.Helper()
@@ -257,33 +242,542 @@ func (mr *MockClientMockRecorder) OpenDialogRequest(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDialogRequest", reflect.TypeOf((*MockClient)(nil).OpenDialogRequest), arg0, arg1)
 }
 
-// UpdatePipelineApprovalRequest mocks base method
-func (m *MockClient) UpdatePipelineApprovalRequest(arg0 *serializers.PipelineApproveRequest, arg1, arg2, arg3 string, arg4 int) (int, error) {
+// UpdatePipelineRunApprovalRequest mocks base method
+func (m *MockClient) UpdatePipelineRunApprovalRequest(arg0 []*serializers.PipelineApproveRequest, arg1, arg2, arg3 string) (*serializers.PipelineRunApproveResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePipelineRunApprovalRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.PipelineRunApproveResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdatePipelineRunApprovalRequest indicates an expected call of UpdatePipelineRunApprovalRequest
+func (mr *MockClientMockRecorder) UpdatePipelineRunApprovalRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineRunApprovalRequest", reflect.TypeOf((*MockClient)(nil).UpdatePipelineRunApprovalRequest), arg0, arg1, arg2, arg3)
+}
+
+// GetWorkItemTypeStates mocks base method
+func (m *MockClient) GetWorkItemTypeStates(arg0, arg1, arg2, arg3 string) ([]*serializers.WorkItemTypeState, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkItemTypeStates", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*serializers.WorkItemTypeState)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkItemTypeStates indicates an expected call of GetWorkItemTypeStates
+func (mr *MockClientMockRecorder) GetWorkItemTypeStates(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkItemTypeStates", reflect.TypeOf((*MockClient)(nil).GetWorkItemTypeStates), arg0, arg1, arg2, arg3)
+}
+
+// QueryWorkItems mocks base method
+func (m *MockClient) QueryWorkItems(arg0, arg1, arg2, arg3 string) ([]*serializers.WorkItemReference, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipelineApprovalRequest", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "QueryWorkItems", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*serializers.WorkItemReference)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryWorkItems indicates an expected call of QueryWorkItems
+func (mr *MockClientMockRecorder) QueryWorkItems(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryWorkItems", reflect.TypeOf((*MockClient)(nil).QueryWorkItems), arg0, arg1, arg2, arg3)
+}
+
+// GetWorkItemsBatch mocks base method
+func (m *MockClient) GetWorkItemsBatch(arg0, arg1 string, arg2 []int, arg3 []string, arg4 string) ([]*serializers.TaskValue, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkItemsBatch", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*serializers.TaskValue)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkItemsBatch indicates an expected call of GetWorkItemsBatch
+func (mr *MockClientMockRecorder) GetWorkItemsBatch(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkItemsBatch", reflect.TypeOf((*MockClient)(nil).GetWorkItemsBatch), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetCurrentIteration mocks base method
+func (m *MockClient) GetCurrentIteration(arg0, arg1, arg2, arg3 string) (*serializers.Iteration, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentIteration", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.Iteration)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCurrentIteration indicates an expected call of GetCurrentIteration
+func (mr *MockClientMockRecorder) GetCurrentIteration(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentIteration", reflect.TypeOf((*MockClient)(nil).GetCurrentIteration), arg0, arg1, arg2, arg3)
+}
+
+// GetWorkItem mocks base method
+func (m *MockClient) GetWorkItem(arg0, arg1, arg2, arg3 string) (*serializers.TaskValue, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkItem", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.TaskValue)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkItem indicates an expected call of GetWorkItem
+func (mr *MockClientMockRecorder) GetWorkItem(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkItem", reflect.TypeOf((*MockClient)(nil).GetWorkItem), arg0, arg1, arg2, arg3)
+}
+
+// GetGitRepository mocks base method
+func (m *MockClient) GetGitRepository(arg0, arg1, arg2, arg3 string) (*serializers.GitRepository, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitRepository", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.GitRepository)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGitRepository indicates an expected call of GetGitRepository
+func (mr *MockClientMockRecorder) GetGitRepository(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitRepository", reflect.TypeOf((*MockClient)(nil).GetGitRepository), arg0, arg1, arg2, arg3)
+}
+
+// GetReleaseApproval mocks base method
+func (m *MockClient) GetReleaseApproval(arg0, arg1 string, arg2 int, arg3 string) (*serializers.ReleaseApproval, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReleaseApproval", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.ReleaseApproval)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReleaseApproval indicates an expected call of GetReleaseApproval
+func (mr *MockClientMockRecorder) GetReleaseApproval(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleaseApproval", reflect.TypeOf((*MockClient)(nil).GetReleaseApproval), arg0, arg1, arg2, arg3)
+}
+
+// SetReleaseApproval mocks base method
+func (m *MockClient) SetReleaseApproval(arg0, arg1 string, arg2 int, arg3, arg4, arg5 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReleaseApproval", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdatePipelineApprovalRequest indicates an expected call of UpdatePipelineApprovalRequest
-func (mr *MockClientMockRecorder) UpdatePipelineApprovalRequest(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// SetReleaseApproval indicates an expected call of SetReleaseApproval
+func (mr *MockClientMockRecorder) SetReleaseApproval(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineApprovalRequest", reflect.TypeOf((*MockClient)(nil).UpdatePipelineApprovalRequest), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReleaseApproval", reflect.TypeOf((*MockClient)(nil).SetReleaseApproval), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
-// UpdatePipelineRunApprovalRequest mocks base method
-func (m *MockClient) UpdatePipelineRunApprovalRequest(arg0 []*serializers.PipelineApproveRequest, arg1, arg2, arg3 string) (*serializers.PipelineRunApproveResponse, int, error) {
+// GetQueries mocks base method
+func (m *MockClient) GetQueries(arg0, arg1, arg2, arg3 string) ([]*serializers.Query, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipelineRunApprovalRequest", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*serializers.PipelineRunApproveResponse)
+	ret := m.ctrl.Call(m, "GetQueries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*serializers.Query)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// UpdatePipelineRunApprovalRequest indicates an expected call of UpdatePipelineRunApprovalRequest
-func (mr *MockClientMockRecorder) UpdatePipelineRunApprovalRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetQueries indicates an expected call of GetQueries
+func (mr *MockClientMockRecorder) GetQueries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineRunApprovalRequest", reflect.TypeOf((*MockClient)(nil).UpdatePipelineRunApprovalRequest), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueries", reflect.TypeOf((*MockClient)(nil).GetQueries), arg0, arg1, arg2, arg3)
+}
+
+// RunSharedQuery mocks base method
+func (m *MockClient) RunSharedQuery(arg0, arg1, arg2, arg3 string) (*serializers.WorkItemQueryResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSharedQuery", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.WorkItemQueryResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunSharedQuery indicates an expected call of RunSharedQuery
+func (mr *MockClientMockRecorder) RunSharedQuery(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSharedQuery", reflect.TypeOf((*MockClient)(nil).RunSharedQuery), arg0, arg1, arg2, arg3)
+}
+
+// GetPullRequestsByCreator mocks base method
+func (m *MockClient) GetPullRequestsByCreator(arg0, arg1, arg2, arg3 string) ([]*serializers.PullRequest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestsByCreator", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*serializers.PullRequest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPullRequestsByCreator indicates an expected call of GetPullRequestsByCreator
+func (mr *MockClientMockRecorder) GetPullRequestsByCreator(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestsByCreator", reflect.TypeOf((*MockClient)(nil).GetPullRequestsByCreator), arg0, arg1, arg2, arg3)
+}
+
+// GenerateDeviceCode mocks base method
+func (m *MockClient) GenerateDeviceCode(arg0 url.Values) (*serializers.DeviceCodeResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDeviceCode", arg0)
+	ret0, _ := ret[0].(*serializers.DeviceCodeResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateDeviceCode indicates an expected call of GenerateDeviceCode
+func (mr *MockClientMockRecorder) GenerateDeviceCode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDeviceCode", reflect.TypeOf((*MockClient)(nil).GenerateDeviceCode), arg0)
+}
+
+// GenerateDeviceCodeToken mocks base method
+func (m *MockClient) GenerateDeviceCodeToken(arg0 url.Values) (*serializers.DeviceCodeTokenResponse, string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDeviceCodeToken", arg0)
+	ret0, _ := ret[0].(*serializers.DeviceCodeTokenResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GenerateDeviceCodeToken indicates an expected call of GenerateDeviceCodeToken
+func (mr *MockClientMockRecorder) GenerateDeviceCodeToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDeviceCodeToken", reflect.TypeOf((*MockClient)(nil).GenerateDeviceCodeToken), arg0)
+}
+
+// GetPullRequestWorkItems mocks base method
+func (m *MockClient) GetPullRequestWorkItems(arg0, arg1, arg2 string, arg3 int, arg4 string) ([]*serializers.ResourceRef, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestWorkItems", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*serializers.ResourceRef)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPullRequestWorkItems indicates an expected call of GetPullRequestWorkItems
+func (mr *MockClientMockRecorder) GetPullRequestWorkItems(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestWorkItems", reflect.TypeOf((*MockClient)(nil).GetPullRequestWorkItems), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PingURL mocks base method
+func (m *MockClient) PingURL(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingURL", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PingURL indicates an expected call of PingURL
+func (mr *MockClientMockRecorder) PingURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingURL", reflect.TypeOf((*MockClient)(nil).PingURL), arg0)
+}
+
+// GetProcess mocks base method
+func (m *MockClient) GetProcess(arg0, arg1, arg2 string) (*serializers.Process, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProcess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*serializers.Process)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProcess indicates an expected call of GetProcess
+func (mr *MockClientMockRecorder) GetProcess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcess", reflect.TypeOf((*MockClient)(nil).GetProcess), arg0, arg1, arg2)
+}
+
+// DeleteWorkItem mocks base method
+func (m *MockClient) DeleteWorkItem(arg0, arg1 string, arg2 int, arg3 bool, arg4 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkItem", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkItem indicates an expected call of DeleteWorkItem
+func (mr *MockClientMockRecorder) DeleteWorkItem(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkItem", reflect.TypeOf((*MockClient)(nil).DeleteWorkItem), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetRecycleBin mocks base method
+func (m *MockClient) GetRecycleBin(arg0, arg1, arg2 string) ([]*serializers.DeletedWorkItem, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecycleBin", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*serializers.DeletedWorkItem)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecycleBin indicates an expected call of GetRecycleBin
+func (mr *MockClientMockRecorder) GetRecycleBin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecycleBin", reflect.TypeOf((*MockClient)(nil).GetRecycleBin), arg0, arg1, arg2)
+}
+
+// RestoreWorkItem mocks base method
+func (m *MockClient) RestoreWorkItem(arg0, arg1 string, arg2 int, arg3 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWorkItem", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreWorkItem indicates an expected call of RestoreWorkItem
+func (mr *MockClientMockRecorder) RestoreWorkItem(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWorkItem", reflect.TypeOf((*MockClient)(nil).RestoreWorkItem), arg0, arg1, arg2, arg3)
+}
+
+// ValidateWIQL mocks base method
+func (m *MockClient) ValidateWIQL(arg0, arg1, arg2, arg3 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateWIQL", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateWIQL indicates an expected call of ValidateWIQL
+func (mr *MockClientMockRecorder) ValidateWIQL(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateWIQL", reflect.TypeOf((*MockClient)(nil).ValidateWIQL), arg0, arg1, arg2, arg3)
+}
+
+// GetProjectPullRequests mocks base method
+func (m *MockClient) GetProjectPullRequests(arg0, arg1, arg2 string) ([]*serializers.PullRequest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectPullRequests", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*serializers.PullRequest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProjectPullRequests indicates an expected call of GetProjectPullRequests
+func (mr *MockClientMockRecorder) GetProjectPullRequests(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectPullRequests", reflect.TypeOf((*MockClient)(nil).GetProjectPullRequests), arg0, arg1, arg2)
+}
+
+// GetGitRepositories mocks base method
+func (m *MockClient) GetGitRepositories(arg0, arg1, arg2 string) ([]*serializers.GitRepository, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitRepositories", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*serializers.GitRepository)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGitRepositories indicates an expected call of GetGitRepositories
+func (mr *MockClientMockRecorder) GetGitRepositories(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitRepositories", reflect.TypeOf((*MockClient)(nil).GetGitRepositories), arg0, arg1, arg2)
+}
+
+// GetPushes mocks base method
+func (m *MockClient) GetPushes(arg0, arg1, arg2 string, arg3 time.Time, arg4 string) ([]*serializers.Push, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushes", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*serializers.Push)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPushes indicates an expected call of GetPushes
+func (mr *MockClientMockRecorder) GetPushes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushes", reflect.TypeOf((*MockClient)(nil).GetPushes), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateSubscriptionWebhookURL mocks base method
+func (m *MockClient) UpdateSubscriptionWebhookURL(arg0, arg1, arg2, arg3 string) (string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionWebhookURL", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateSubscriptionWebhookURL indicates an expected call of UpdateSubscriptionWebhookURL
+func (mr *MockClientMockRecorder) UpdateSubscriptionWebhookURL(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionWebhookURL", reflect.TypeOf((*MockClient)(nil).UpdateSubscriptionWebhookURL), arg0, arg1, arg2, arg3)
+}
+
+// QueueBuild mocks base method
+func (m *MockClient) QueueBuild(arg0, arg1 string, arg2 int, arg3, arg4 string) (*serializers.BuildDetails, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueBuild", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*serializers.BuildDetails)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueueBuild indicates an expected call of QueueBuild
+func (mr *MockClientMockRecorder) QueueBuild(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueBuild", reflect.TypeOf((*MockClient)(nil).QueueBuild), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetWorkItemExpanded mocks base method
+func (m *MockClient) GetWorkItemExpanded(arg0, arg1 string, arg2 int, arg3 string) (*serializers.WorkItemExpanded, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkItemExpanded", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*serializers.WorkItemExpanded)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkItemExpanded indicates an expected call of GetWorkItemExpanded
+func (mr *MockClientMockRecorder) GetWorkItemExpanded(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkItemExpanded", reflect.TypeOf((*MockClient)(nil).GetWorkItemExpanded), arg0, arg1, arg2, arg3)
+}
+
+// UpdateTask mocks base method
+func (m *MockClient) UpdateTask(arg0, arg1, arg2 string, arg3 serializers.UpdateTaskFieldValue, arg4 string) (*serializers.TaskValue, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*serializers.TaskValue)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateTask indicates an expected call of UpdateTask
+func (mr *MockClientMockRecorder) UpdateTask(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockClient)(nil).UpdateTask), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetWorkItems mocks base method
+func (m *MockClient) GetWorkItems(arg0, arg1 string, arg2 serializers.WorkItemQuery, arg3 string) ([]*serializers.TaskValue, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkItems", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*serializers.TaskValue)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkItems indicates an expected call of GetWorkItems
+func (mr *MockClientMockRecorder) GetWorkItems(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkItems", reflect.TypeOf((*MockClient)(nil).GetWorkItems), arg0, arg1, arg2, arg3)
+}
+
+// GetPullRequests mocks base method
+func (m *MockClient) GetPullRequests(arg0, arg1, arg2, arg3 string) ([]*serializers.PullRequest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequests", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*serializers.PullRequest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPullRequests indicates an expected call of GetPullRequests
+func (mr *MockClientMockRecorder) GetPullRequests(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequests", reflect.TypeOf((*MockClient)(nil).GetPullRequests), arg0, arg1, arg2, arg3)
+}
+
+// ListSubscriptions mocks base method
+func (m *MockClient) ListSubscriptions(arg0, arg1 string) ([]serializers.SubscriptionValue, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", arg0, arg1)
+	ret0, _ := ret[0].([]serializers.SubscriptionValue)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions
+func (mr *MockClientMockRecorder) ListSubscriptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockClient)(nil).ListSubscriptions), arg0, arg1)
+}
+
+// SearchWorkItems mocks base method
+func (m *MockClient) SearchWorkItems(arg0, arg1, arg2 string, arg3 int, arg4 string) ([]*serializers.WorkItemSearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchWorkItems", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*serializers.WorkItemSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchWorkItems indicates an expected call of SearchWorkItems
+func (mr *MockClientMockRecorder) SearchWorkItems(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchWorkItems", reflect.TypeOf((*MockClient)(nil).SearchWorkItems), arg0, arg1, arg2, arg3, arg4)
+}
+
+// AddWorkItemComment mocks base method
+func (m *MockClient) AddWorkItemComment(arg0, arg1, arg2, arg3, arg4 string) (*serializers.WorkItemComment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkItemComment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*serializers.WorkItemComment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddWorkItemComment indicates an expected call of AddWorkItemComment
+func (mr *MockClientMockRecorder) AddWorkItemComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkItemComment", reflect.TypeOf((*MockClient)(nil).AddWorkItemComment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetSubscription mocks base method
+func (m *MockClient) GetSubscription(arg0, arg1, arg2 string) (*serializers.SubscriptionValue, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*serializers.SubscriptionValue)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSubscription indicates an expected call of GetSubscription
+func (mr *MockClientMockRecorder) GetSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockClient)(nil).GetSubscription), arg0, arg1, arg2)
 }
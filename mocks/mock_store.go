@@ -9,6 +9,7 @@ import (
 	serializers "github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 	store "github.com/mattermost/mattermost-plugin-azure-devops/server/store"
 	reflect "reflect"
+	time "time"
 )
 
 // MockKVStore is a mock of KVStore interface
@@ -293,3 +294,626 @@ func (mr *MockKVStoreMockRecorder) DeleteSubscriptionAndChannelIDMap(arg0 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionAndChannelIDMap", reflect.TypeOf((*MockKVStore)(nil).DeleteSubscriptionAndChannelIDMap), arg0)
 }
+
+// StoreSubscriptionTemplate mocks base method
+func (m *MockKVStore) StoreSubscriptionTemplate(arg0 *serializers.SubscriptionTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSubscriptionTemplate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSubscriptionTemplate indicates an expected call of StoreSubscriptionTemplate
+func (mr *MockKVStoreMockRecorder) StoreSubscriptionTemplate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSubscriptionTemplate", reflect.TypeOf((*MockKVStore)(nil).StoreSubscriptionTemplate), arg0)
+}
+
+// GetSubscriptionTemplates mocks base method
+func (m *MockKVStore) GetSubscriptionTemplates(arg0 string) ([]*serializers.SubscriptionTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionTemplates", arg0)
+	ret0, _ := ret[0].([]*serializers.SubscriptionTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionTemplates indicates an expected call of GetSubscriptionTemplates
+func (mr *MockKVStoreMockRecorder) GetSubscriptionTemplates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionTemplates", reflect.TypeOf((*MockKVStore)(nil).GetSubscriptionTemplates), arg0)
+}
+
+// DeleteSubscriptionTemplate mocks base method
+func (m *MockKVStore) DeleteSubscriptionTemplate(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptionTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptionTemplate indicates an expected call of DeleteSubscriptionTemplate
+func (mr *MockKVStoreMockRecorder) DeleteSubscriptionTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionTemplate", reflect.TypeOf((*MockKVStore)(nil).DeleteSubscriptionTemplate), arg0, arg1)
+}
+
+// AddRetryOperation mocks base method
+func (m *MockKVStore) AddRetryOperation(arg0 *serializers.RetryOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRetryOperation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRetryOperation indicates an expected call of AddRetryOperation
+func (mr *MockKVStoreMockRecorder) AddRetryOperation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRetryOperation", reflect.TypeOf((*MockKVStore)(nil).AddRetryOperation), arg0)
+}
+
+// GetRetryOperations mocks base method
+func (m *MockKVStore) GetRetryOperations() ([]*serializers.RetryOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetryOperations")
+	ret0, _ := ret[0].([]*serializers.RetryOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetryOperations indicates an expected call of GetRetryOperations
+func (mr *MockKVStoreMockRecorder) GetRetryOperations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetryOperations", reflect.TypeOf((*MockKVStore)(nil).GetRetryOperations))
+}
+
+// UpdateRetryOperation mocks base method
+func (m *MockKVStore) UpdateRetryOperation(arg0 *serializers.RetryOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRetryOperation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRetryOperation indicates an expected call of UpdateRetryOperation
+func (mr *MockKVStoreMockRecorder) UpdateRetryOperation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetryOperation", reflect.TypeOf((*MockKVStore)(nil).UpdateRetryOperation), arg0)
+}
+
+// DeleteRetryOperation mocks base method
+func (m *MockKVStore) DeleteRetryOperation(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetryOperation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRetryOperation indicates an expected call of DeleteRetryOperation
+func (mr *MockKVStoreMockRecorder) DeleteRetryOperation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetryOperation", reflect.TypeOf((*MockKVStore)(nil).DeleteRetryOperation), arg0)
+}
+
+// SetChannelNotificationPrefs mocks base method
+func (m *MockKVStore) SetChannelNotificationPrefs(arg0 *serializers.ChannelNotificationPrefs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannelNotificationPrefs", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChannelNotificationPrefs indicates an expected call of SetChannelNotificationPrefs
+func (mr *MockKVStoreMockRecorder) SetChannelNotificationPrefs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelNotificationPrefs", reflect.TypeOf((*MockKVStore)(nil).SetChannelNotificationPrefs), arg0)
+}
+
+// GetChannelNotificationPrefs mocks base method
+func (m *MockKVStore) GetChannelNotificationPrefs(arg0 string) (*serializers.ChannelNotificationPrefs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelNotificationPrefs", arg0)
+	ret0, _ := ret[0].(*serializers.ChannelNotificationPrefs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelNotificationPrefs indicates an expected call of GetChannelNotificationPrefs
+func (mr *MockKVStoreMockRecorder) GetChannelNotificationPrefs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelNotificationPrefs", reflect.TypeOf((*MockKVStore)(nil).GetChannelNotificationPrefs), arg0)
+}
+
+// StoreNotificationThread mocks base method
+func (m *MockKVStore) StoreNotificationThread(arg0, arg1 string, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreNotificationThread", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreNotificationThread indicates an expected call of StoreNotificationThread
+func (mr *MockKVStoreMockRecorder) StoreNotificationThread(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreNotificationThread", reflect.TypeOf((*MockKVStore)(nil).StoreNotificationThread), arg0, arg1, arg2, arg3)
+}
+
+// GetNotificationThread mocks base method
+func (m *MockKVStore) GetNotificationThread(arg0, arg1 string, arg2 int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationThread", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationThread indicates an expected call of GetNotificationThread
+func (mr *MockKVStoreMockRecorder) GetNotificationThread(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationThread", reflect.TypeOf((*MockKVStore)(nil).GetNotificationThread), arg0, arg1, arg2)
+}
+
+// AddNotificationBurstWorkItem mocks base method
+func (m *MockKVStore) AddNotificationBurstWorkItem(arg0 string, arg1 int, arg2 string) (*serializers.NotificationBurst, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotificationBurstWorkItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*serializers.NotificationBurst)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNotificationBurstWorkItem indicates an expected call of AddNotificationBurstWorkItem
+func (mr *MockKVStoreMockRecorder) AddNotificationBurstWorkItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotificationBurstWorkItem", reflect.TypeOf((*MockKVStore)(nil).AddNotificationBurstWorkItem), arg0, arg1, arg2)
+}
+
+// SetNotificationBurstSummaryPost mocks base method
+func (m *MockKVStore) SetNotificationBurstSummaryPost(arg0, arg1, arg2 string) (*serializers.NotificationBurst, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationBurstSummaryPost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*serializers.NotificationBurst)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNotificationBurstSummaryPost indicates an expected call of SetNotificationBurstSummaryPost
+func (mr *MockKVStoreMockRecorder) SetNotificationBurstSummaryPost(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationBurstSummaryPost", reflect.TypeOf((*MockKVStore)(nil).SetNotificationBurstSummaryPost), arg0, arg1, arg2)
+}
+
+// StartDeviceCodeFlow mocks base method
+func (m *MockKVStore) StartDeviceCodeFlow(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartDeviceCodeFlow", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartDeviceCodeFlow indicates an expected call of StartDeviceCodeFlow
+func (mr *MockKVStoreMockRecorder) StartDeviceCodeFlow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDeviceCodeFlow", reflect.TypeOf((*MockKVStore)(nil).StartDeviceCodeFlow), arg0)
+}
+
+// DeleteDeviceCodeFlow mocks base method
+func (m *MockKVStore) DeleteDeviceCodeFlow(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeviceCodeFlow", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeviceCodeFlow indicates an expected call of DeleteDeviceCodeFlow
+func (mr *MockKVStoreMockRecorder) DeleteDeviceCodeFlow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeviceCodeFlow", reflect.TypeOf((*MockKVStore)(nil).DeleteDeviceCodeFlow), arg0)
+}
+
+// StoreLastNotification mocks base method
+func (m *MockKVStore) StoreLastNotification(arg0 *serializers.SubscriptionNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreLastNotification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreLastNotification indicates an expected call of StoreLastNotification
+func (mr *MockKVStoreMockRecorder) StoreLastNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreLastNotification", reflect.TypeOf((*MockKVStore)(nil).StoreLastNotification), arg0)
+}
+
+// GetLastNotification mocks base method
+func (m *MockKVStore) GetLastNotification(arg0 string) (*serializers.SubscriptionNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastNotification", arg0)
+	ret0, _ := ret[0].(*serializers.SubscriptionNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastNotification indicates an expected call of GetLastNotification
+func (mr *MockKVStoreMockRecorder) GetLastNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastNotification", reflect.TypeOf((*MockKVStore)(nil).GetLastNotification), arg0)
+}
+
+// DeleteLastNotification mocks base method
+func (m *MockKVStore) DeleteLastNotification(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLastNotification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLastNotification indicates an expected call of DeleteLastNotification
+func (mr *MockKVStoreMockRecorder) DeleteLastNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastNotification", reflect.TypeOf((*MockKVStore)(nil).DeleteLastNotification), arg0)
+}
+
+// IncrementWeeklySummaryCount mocks base method
+func (m *MockKVStore) IncrementWeeklySummaryCount(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementWeeklySummaryCount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementWeeklySummaryCount indicates an expected call of IncrementWeeklySummaryCount
+func (mr *MockKVStoreMockRecorder) IncrementWeeklySummaryCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementWeeklySummaryCount", reflect.TypeOf((*MockKVStore)(nil).IncrementWeeklySummaryCount), arg0, arg1)
+}
+
+// GetWeeklySummary mocks base method
+func (m *MockKVStore) GetWeeklySummary(arg0 string) (*serializers.WeeklySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeeklySummary", arg0)
+	ret0, _ := ret[0].(*serializers.WeeklySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeeklySummary indicates an expected call of GetWeeklySummary
+func (mr *MockKVStoreMockRecorder) GetWeeklySummary(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklySummary", reflect.TypeOf((*MockKVStore)(nil).GetWeeklySummary), arg0)
+}
+
+// ResetWeeklySummary mocks base method
+func (m *MockKVStore) ResetWeeklySummary(arg0 string, arg1 int64) (*serializers.WeeklySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetWeeklySummary", arg0, arg1)
+	ret0, _ := ret[0].(*serializers.WeeklySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetWeeklySummary indicates an expected call of ResetWeeklySummary
+func (mr *MockKVStoreMockRecorder) ResetWeeklySummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetWeeklySummary", reflect.TypeOf((*MockKVStore)(nil).ResetWeeklySummary), arg0, arg1)
+}
+
+// GetAllConnectedMattermostUserIDs mocks base method
+func (m *MockKVStore) GetAllConnectedMattermostUserIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllConnectedMattermostUserIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllConnectedMattermostUserIDs indicates an expected call of GetAllConnectedMattermostUserIDs
+func (mr *MockKVStoreMockRecorder) GetAllConnectedMattermostUserIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllConnectedMattermostUserIDs", reflect.TypeOf((*MockKVStore)(nil).GetAllConnectedMattermostUserIDs))
+}
+
+// DedupeProjects mocks base method
+func (m *MockKVStore) DedupeProjects(arg0 string) ([]store.MergedProject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DedupeProjects", arg0)
+	ret0, _ := ret[0].([]store.MergedProject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DedupeProjects indicates an expected call of DedupeProjects
+func (mr *MockKVStoreMockRecorder) DedupeProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedupeProjects", reflect.TypeOf((*MockKVStore)(nil).DedupeProjects), arg0)
+}
+
+// RepointSubscriptions mocks base method
+func (m *MockKVStore) RepointSubscriptions(arg0 string, arg1 []serializers.ProjectDetails) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepointSubscriptions", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepointSubscriptions indicates an expected call of RepointSubscriptions
+func (mr *MockKVStoreMockRecorder) RepointSubscriptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepointSubscriptions", reflect.TypeOf((*MockKVStore)(nil).RepointSubscriptions), arg0, arg1)
+}
+
+// StoreCoalescedPost mocks base method
+func (m *MockKVStore) StoreCoalescedPost(arg0, arg1, arg2, arg3 string, arg4 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCoalescedPost", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCoalescedPost indicates an expected call of StoreCoalescedPost
+func (mr *MockKVStoreMockRecorder) StoreCoalescedPost(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCoalescedPost", reflect.TypeOf((*MockKVStore)(nil).StoreCoalescedPost), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetCoalescedPost mocks base method
+func (m *MockKVStore) GetCoalescedPost(arg0, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoalescedPost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoalescedPost indicates an expected call of GetCoalescedPost
+func (mr *MockKVStoreMockRecorder) GetCoalescedPost(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoalescedPost", reflect.TypeOf((*MockKVStore)(nil).GetCoalescedPost), arg0, arg1, arg2)
+}
+
+// UpdateProject mocks base method
+func (m *MockKVStore) UpdateProject(arg0 *serializers.ProjectDetails) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject
+func (mr *MockKVStoreMockRecorder) UpdateProject(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockKVStore)(nil).UpdateProject), arg0)
+}
+
+// AddDeliveryLogEntry mocks base method
+func (m *MockKVStore) AddDeliveryLogEntry(arg0 string, arg1 *serializers.DeliveryLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveryLogEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeliveryLogEntry indicates an expected call of AddDeliveryLogEntry
+func (mr *MockKVStoreMockRecorder) AddDeliveryLogEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveryLogEntry", reflect.TypeOf((*MockKVStore)(nil).AddDeliveryLogEntry), arg0, arg1)
+}
+
+// GetDeliveryLog mocks base method
+func (m *MockKVStore) GetDeliveryLog(arg0 string) ([]*serializers.DeliveryLogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryLog", arg0)
+	ret0, _ := ret[0].([]*serializers.DeliveryLogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryLog indicates an expected call of GetDeliveryLog
+func (mr *MockKVStoreMockRecorder) GetDeliveryLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryLog", reflect.TypeOf((*MockKVStore)(nil).GetDeliveryLog), arg0)
+}
+
+// AddPendingWebhookDeletion mocks base method
+func (m *MockKVStore) AddPendingWebhookDeletion(arg0 *serializers.PendingWebhookDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPendingWebhookDeletion", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPendingWebhookDeletion indicates an expected call of AddPendingWebhookDeletion
+func (mr *MockKVStoreMockRecorder) AddPendingWebhookDeletion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPendingWebhookDeletion", reflect.TypeOf((*MockKVStore)(nil).AddPendingWebhookDeletion), arg0)
+}
+
+// GetPendingWebhookDeletions mocks base method
+func (m *MockKVStore) GetPendingWebhookDeletions() ([]*serializers.PendingWebhookDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingWebhookDeletions")
+	ret0, _ := ret[0].([]*serializers.PendingWebhookDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingWebhookDeletions indicates an expected call of GetPendingWebhookDeletions
+func (mr *MockKVStoreMockRecorder) GetPendingWebhookDeletions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingWebhookDeletions", reflect.TypeOf((*MockKVStore)(nil).GetPendingWebhookDeletions))
+}
+
+// RemovePendingWebhookDeletion mocks base method
+func (m *MockKVStore) RemovePendingWebhookDeletion(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePendingWebhookDeletion", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePendingWebhookDeletion indicates an expected call of RemovePendingWebhookDeletion
+func (mr *MockKVStoreMockRecorder) RemovePendingWebhookDeletion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePendingWebhookDeletion", reflect.TypeOf((*MockKVStore)(nil).RemovePendingWebhookDeletion), arg0)
+}
+
+// StoreSubscriptionsPause mocks base method
+func (m *MockKVStore) StoreSubscriptionsPause(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSubscriptionsPause", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSubscriptionsPause indicates an expected call of StoreSubscriptionsPause
+func (mr *MockKVStoreMockRecorder) StoreSubscriptionsPause(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSubscriptionsPause", reflect.TypeOf((*MockKVStore)(nil).StoreSubscriptionsPause), arg0, arg1)
+}
+
+// GetSubscriptionsPause mocks base method
+func (m *MockKVStore) GetSubscriptionsPause(arg0 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsPause", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsPause indicates an expected call of GetSubscriptionsPause
+func (mr *MockKVStoreMockRecorder) GetSubscriptionsPause(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsPause", reflect.TypeOf((*MockKVStore)(nil).GetSubscriptionsPause), arg0)
+}
+
+// DeleteSubscriptionsPause mocks base method
+func (m *MockKVStore) DeleteSubscriptionsPause(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptionsPause", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptionsPause indicates an expected call of DeleteSubscriptionsPause
+func (mr *MockKVStoreMockRecorder) DeleteSubscriptionsPause(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionsPause", reflect.TypeOf((*MockKVStore)(nil).DeleteSubscriptionsPause), arg0)
+}
+
+// StoreTaskPostMapping mocks base method
+func (m *MockKVStore) StoreTaskPostMapping(arg0, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreTaskPostMapping", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreTaskPostMapping indicates an expected call of StoreTaskPostMapping
+func (mr *MockKVStoreMockRecorder) StoreTaskPostMapping(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTaskPostMapping", reflect.TypeOf((*MockKVStore)(nil).StoreTaskPostMapping), arg0, arg1, arg2, arg3)
+}
+
+// GetPostIDForTask mocks base method
+func (m *MockKVStore) GetPostIDForTask(arg0, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostIDForTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostIDForTask indicates an expected call of GetPostIDForTask
+func (mr *MockKVStoreMockRecorder) GetPostIDForTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostIDForTask", reflect.TypeOf((*MockKVStore)(nil).GetPostIDForTask), arg0, arg1, arg2)
+}
+
+// LockJob mocks base method
+func (m *MockKVStore) LockJob(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockJob", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockJob indicates an expected call of LockJob
+func (mr *MockKVStoreMockRecorder) LockJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockJob", reflect.TypeOf((*MockKVStore)(nil).LockJob), arg0)
+}
+
+// UnlockJob mocks base method
+func (m *MockKVStore) UnlockJob(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockJob indicates an expected call of UnlockJob
+func (mr *MockKVStoreMockRecorder) UnlockJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockJob", reflect.TypeOf((*MockKVStore)(nil).UnlockJob), arg0)
+}
+
+// StoreJobLastFinished mocks base method
+func (m *MockKVStore) StoreJobLastFinished(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreJobLastFinished", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreJobLastFinished indicates an expected call of StoreJobLastFinished
+func (mr *MockKVStoreMockRecorder) StoreJobLastFinished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreJobLastFinished", reflect.TypeOf((*MockKVStore)(nil).StoreJobLastFinished), arg0, arg1)
+}
+
+// GetJobLastFinished mocks base method
+func (m *MockKVStore) GetJobLastFinished(arg0 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobLastFinished", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobLastFinished indicates an expected call of GetJobLastFinished
+func (mr *MockKVStoreMockRecorder) GetJobLastFinished(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobLastFinished", reflect.TypeOf((*MockKVStore)(nil).GetJobLastFinished), arg0)
+}
+
+// StoreOrganizationConnection mocks base method
+func (m *MockKVStore) StoreOrganizationConnection(arg0 string, arg1 *serializers.OrganizationConnection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreOrganizationConnection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreOrganizationConnection indicates an expected call of StoreOrganizationConnection
+func (mr *MockKVStoreMockRecorder) StoreOrganizationConnection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOrganizationConnection", reflect.TypeOf((*MockKVStore)(nil).StoreOrganizationConnection), arg0, arg1)
+}
+
+// DeleteOrganizationConnection mocks base method
+func (m *MockKVStore) DeleteOrganizationConnection(arg0 string, arg1 *serializers.OrganizationConnection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganizationConnection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganizationConnection indicates an expected call of DeleteOrganizationConnection
+func (mr *MockKVStoreMockRecorder) DeleteOrganizationConnection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationConnection", reflect.TypeOf((*MockKVStore)(nil).DeleteOrganizationConnection), arg0, arg1)
+}
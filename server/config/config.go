@@ -1,10 +1,16 @@
 package config
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
 )
 
 // Configuration captures the plugin's external configuration as exposed in the Mattermost server
@@ -19,13 +25,54 @@ import (
 // If you add non-reference types to your configuration struct, be sure to rewrite Clone as a deep
 // copy appropriate for your types.
 type Configuration struct {
-	AzureDevopsAPIBaseURL        string `json:"azureDevopsAPIBaseURL"`
-	AzureDevopsOAuthAppID        string `json:"azureDevopsOAuthAppID"`
-	AzureDevopsOAuthClientSecret string `json:"azureDevopsOAuthClientSecret"`
-	EncryptionSecret             string `json:"EncryptionSecret"`
-	MattermostSiteURL            string
+	AzureDevopsAPIBaseURL         string `json:"azureDevopsAPIBaseURL"`
+	AzureDevopsAPIVersion         string `json:"azureDevopsAPIVersion"`
+	AzureDevopsOAuthAppID         string `json:"azureDevopsOAuthAppID"`
+	AzureDevopsOAuthClientSecret  string `json:"azureDevopsOAuthClientSecret"`
+	EncryptionSecret              string `json:"EncryptionSecret"`
+	DefaultOrganization           string `json:"defaultOrganization"`
+	OrganizationDefaultChannels   string `json:"organizationDefaultChannels"`
+	EnableRetryQueue              bool   `json:"enableRetryQueue"`
+	MaxConcurrentRequests         int    `json:"maxConcurrentRequests"`
+	MaxRequestRetries             int    `json:"maxRequestRetries"`
+	ProjectListCacheTTL           int    `json:"projectListCacheTTL"`
+	CACertificates                string `json:"caCertificates"`
+	InsecureSkipVerify            bool   `json:"insecureSkipVerify"`
+	WorkItemsBatchSize            int    `json:"workItemsBatchSize"`
+	MaxDescriptionLength          int    `json:"maxDescriptionLength"`
+	RequiredTaskFields            string `json:"requiredTaskFields"`
+	NotificationTitleLength       int    `json:"notificationTitleLength"`
+	NotificationDescriptionLength int    `json:"notificationDescriptionLength"`
+	NotificationCommentLength     int    `json:"notificationCommentLength"`
+	NotificationCoalescingWindow  int    `json:"notificationCoalescingWindow"`
+	NotificationEmojis            string `json:"notificationEmojis"`
+	EventTypeAliases              string `json:"eventTypeAliases"`
+	NotificationLanguage          string `json:"notificationLanguage"`
+	ExcludeServiceAccounts        bool   `json:"excludeServiceAccounts"`
+	ServiceAccountPatterns        string `json:"serviceAccountPatterns"`
+	IgnoredNotificationAuthors    string `json:"ignoredNotificationAuthors"`
+	AutoLinkSubscriptionProjects  bool   `json:"autoLinkSubscriptionProjects"`
+	MaxLinkedProjectsPerUser      int    `json:"maxLinkedProjectsPerUser"`
+	MaxSubscriptionsPerUser       int    `json:"maxSubscriptionsPerUser"`
+	WriteRequestRateLimit         int    `json:"writeRequestRateLimit"`
+	WriteRequestBurst             int    `json:"writeRequestBurst"`
+	WebhookDeletionGracePeriod    int    `json:"webhookDeletionGracePeriod"`
+	ReconciliationInterval        int    `json:"reconciliationInterval"`
+	WebhookPathPrefix             string `json:"webhookPathPrefix"`
+	DeviceCodeClientID            string `json:"deviceCodeClientID"`
+	DeviceCodeTenant              string `json:"deviceCodeTenant"`
+	MattermostSiteURL             string
 }
 
+var (
+	organizationNameRegex  = regexp.MustCompile(constants.OrganizationNameRegex)
+	webhookPathPrefixRegex = regexp.MustCompile(constants.WebhookPathPrefixRegex)
+	deviceCodeTenantRegex  = regexp.MustCompile(constants.DeviceCodeTenantRegex)
+	apiVersionRegex        = regexp.MustCompile(constants.APIVersionRegex)
+	channelIDRegex         = regexp.MustCompile(constants.ChannelIDRegex)
+	eventTypeAliasRegex    = regexp.MustCompile(constants.EventTypeAliasRegex)
+)
+
 // Clone shallow copies the configuration. Your implementation may require a deep copy if
 // your configuration has reference types.
 func (c *Configuration) Clone() *Configuration {
@@ -36,17 +83,33 @@ func (c *Configuration) Clone() *Configuration {
 // ProcessConfiguration used for post-processing on the configuration.
 func (c *Configuration) ProcessConfiguration() error {
 	c.AzureDevopsAPIBaseURL = strings.TrimRight(strings.TrimSpace(c.AzureDevopsAPIBaseURL), "/")
+	c.AzureDevopsAPIVersion = strings.TrimSpace(c.AzureDevopsAPIVersion)
 	c.AzureDevopsOAuthAppID = strings.TrimSpace(c.AzureDevopsOAuthAppID)
 	c.AzureDevopsOAuthClientSecret = strings.TrimSpace(c.AzureDevopsOAuthClientSecret)
 	c.EncryptionSecret = strings.TrimSpace(c.EncryptionSecret)
+	c.DefaultOrganization = strings.ToLower(strings.TrimSpace(c.DefaultOrganization))
+	c.OrganizationDefaultChannels = strings.TrimSpace(c.OrganizationDefaultChannels)
+	c.NotificationEmojis = strings.TrimSpace(c.NotificationEmojis)
+	c.EventTypeAliases = strings.TrimSpace(c.EventTypeAliases)
+	c.NotificationLanguage = strings.ToLower(strings.TrimSpace(c.NotificationLanguage))
+	c.ServiceAccountPatterns = strings.TrimSpace(c.ServiceAccountPatterns)
+	c.IgnoredNotificationAuthors = strings.TrimSpace(c.IgnoredNotificationAuthors)
+	c.RequiredTaskFields = strings.TrimSpace(c.RequiredTaskFields)
+	c.WebhookPathPrefix = strings.Trim(strings.TrimSpace(c.WebhookPathPrefix), "/")
+	c.DeviceCodeClientID = strings.TrimSpace(c.DeviceCodeClientID)
+	c.DeviceCodeTenant = strings.TrimSpace(c.DeviceCodeTenant)
+	c.CACertificates = strings.TrimSpace(c.CACertificates)
 
 	return nil
 }
 
 // Used for config validations.
 func (c *Configuration) IsValid() error {
-	if c.AzureDevopsAPIBaseURL == "" {
-		return errors.New(constants.EmptyAzureDevopsAPIBaseURLError)
+	if c.AzureDevopsAPIBaseURL != "" && !isValidBaseURL(c.AzureDevopsAPIBaseURL) {
+		return errors.New(constants.InvalidAzureDevopsAPIBaseURLError)
+	}
+	if c.AzureDevopsAPIVersion != "" && !apiVersionRegex.MatchString(c.AzureDevopsAPIVersion) {
+		return errors.New(constants.InvalidAzureDevopsAPIVersionError)
 	}
 	if c.AzureDevopsOAuthAppID == "" {
 		return errors.New(constants.EmptyAzureDevopsOAuthAppIDError)
@@ -57,6 +120,369 @@ func (c *Configuration) IsValid() error {
 	if c.EncryptionSecret == "" {
 		return errors.New(constants.EmptyEncryptionSecretError)
 	}
+	if c.DefaultOrganization != "" && !organizationNameRegex.MatchString(c.DefaultOrganization) {
+		return errors.New(constants.InvalidDefaultOrganizationError)
+	}
+	if c.MaxDescriptionLength < 0 {
+		return errors.New(constants.InvalidMaxDescriptionLengthError)
+	}
+	if c.MaxConcurrentRequests < -1 {
+		return errors.New(constants.InvalidMaxConcurrentRequestsError)
+	}
+	if c.MaxRequestRetries < 0 || c.MaxRequestRetries > constants.MaxRequestRetriesLimit {
+		return fmt.Errorf(constants.InvalidMaxRequestRetriesError, constants.MaxRequestRetriesLimit)
+	}
+	if c.ProjectListCacheTTL < 0 || c.ProjectListCacheTTL > constants.ProjectListCacheMaxTTL {
+		return fmt.Errorf(constants.InvalidProjectListCacheTTLError, constants.ProjectListCacheMaxTTL)
+	}
+	if c.MaxLinkedProjectsPerUser < 0 || c.MaxSubscriptionsPerUser < 0 {
+		return errors.New(constants.InvalidMaxPerUserError)
+	}
+	if c.WriteRequestRateLimit < 0 || c.WriteRequestBurst < 0 {
+		return errors.New(constants.InvalidWriteRequestRateLimitError)
+	}
+	if c.WorkItemsBatchSize < 0 || c.WorkItemsBatchSize > constants.WorkItemsBatchMaxSize {
+		return fmt.Errorf(constants.InvalidWorkItemsBatchSizeError, constants.WorkItemsBatchMaxSize)
+	}
+	if c.NotificationTitleLength < 0 || c.NotificationDescriptionLength < 0 || c.NotificationCommentLength < 0 {
+		return errors.New(constants.InvalidNotificationTruncationError)
+	}
+	if c.NotificationCoalescingWindow < 0 || c.NotificationCoalescingWindow > constants.NotificationCoalescingMaxWindow {
+		return fmt.Errorf(constants.InvalidCoalescingWindowError, constants.NotificationCoalescingMaxWindow)
+	}
+	if c.WebhookDeletionGracePeriod < 0 || c.WebhookDeletionGracePeriod > constants.WebhookDeletionMaxGracePeriod {
+		return fmt.Errorf(constants.InvalidWebhookDeletionGracePeriodError, constants.WebhookDeletionMaxGracePeriod)
+	}
+	if c.ReconciliationInterval < 0 || c.ReconciliationInterval > constants.SubscriptionReconciliationMaxInterval {
+		return fmt.Errorf(constants.InvalidReconciliationIntervalError, constants.SubscriptionReconciliationMaxInterval)
+	}
+	if c.WebhookPathPrefix != "" && !webhookPathPrefixRegex.MatchString(c.WebhookPathPrefix) {
+		return errors.New(constants.InvalidWebhookPathPrefixError)
+	}
+	if c.DeviceCodeTenant != "" && !deviceCodeTenantRegex.MatchString(c.DeviceCodeTenant) {
+		return errors.New(constants.InvalidDeviceCodeTenantError)
+	}
+	if _, err := c.GetRequiredTaskFields(); err != nil {
+		return err
+	}
+	if _, _, err := c.GetCACertPool(); err != nil {
+		return err
+	}
+	if _, err := c.GetOrganizationDefaultChannels(); err != nil {
+		return err
+	}
+	if _, err := c.GetNotificationEmojis(); err != nil {
+		return err
+	}
+	if _, err := c.GetEventTypeAliases(); err != nil {
+		return err
+	}
+	if c.NotificationLanguage != "" && !i18n.IsSupportedLocale(c.NotificationLanguage) {
+		return fmt.Errorf(constants.UnsupportedNotificationLanguageError, c.NotificationLanguage, strings.Join(i18n.GetSupportedLocales(), ", "))
+	}
 
 	return nil
 }
+
+// GetNotificationEmojis returns the emoji prefixed to the subscription notifications mapped by their status.
+// The default emoji are overridden by the comma separated "status=emoji" pairs of the setting, and an empty emoji removes the prefix.
+func (c *Configuration) GetNotificationEmojis() (map[string]string, error) {
+	emojis := make(map[string]string, len(constants.DefaultNotificationEmojis))
+	for status, emoji := range constants.DefaultNotificationEmojis {
+		emojis[status] = emoji
+	}
+
+	for _, pair := range strings.Split(c.NotificationEmojis, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		status := strings.TrimSpace(parts[0])
+		if _, ok := constants.DefaultNotificationEmojis[status]; !ok || len(parts) != 2 {
+			return nil, fmt.Errorf(constants.InvalidNotificationEmojisError, strings.TrimSpace(pair))
+		}
+
+		emojis[status] = strings.TrimSpace(parts[1])
+	}
+
+	return emojis, nil
+}
+
+// GetRequiredTaskFields returns the fields required while creating a task mapped by the lowercase name of the work item type.
+// The setting contains semicolon separated "type=fields" pairs, where the fields are comma separated, e.g. "Bug=description,areaPath; User Story=description".
+func (c *Configuration) GetRequiredTaskFields() (map[string][]string, error) {
+	requiredFields := map[string][]string{}
+	for _, pair := range strings.Split(c.RequiredTaskFields, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		taskType := strings.ToLower(strings.TrimSpace(parts[0]))
+		if taskType == "" || len(parts) != 2 {
+			return nil, fmt.Errorf(constants.InvalidRequiredTaskFieldsError, strings.TrimSpace(pair))
+		}
+
+		var fields []string
+		for _, field := range strings.Split(parts[1], ",") {
+			if strings.TrimSpace(field) == "" {
+				continue
+			}
+
+			fieldName, ok := constants.TaskFields[strings.ToLower(strings.TrimSpace(field))]
+			if !ok {
+				return nil, fmt.Errorf(constants.InvalidRequiredTaskFieldsError, strings.TrimSpace(pair))
+			}
+			fields = append(fields, fieldName)
+		}
+
+		if len(fields) == 0 {
+			return nil, fmt.Errorf(constants.InvalidRequiredTaskFieldsError, strings.TrimSpace(pair))
+		}
+
+		requiredFields[taskType] = append(requiredFields[taskType], fields...)
+	}
+
+	return requiredFields, nil
+}
+
+// GetOrganizationDefaultChannels returns the channel IDs used for the new subscriptions of an organization when no channel is provided, mapped by the lowercase organization name.
+// The setting contains comma separated "organization=channelID" pairs.
+func (c *Configuration) GetOrganizationDefaultChannels() (map[string]string, error) {
+	defaultChannels := map[string]string{}
+	for _, pair := range strings.Split(c.OrganizationDefaultChannels, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf(constants.InvalidDefaultChannelsError, strings.TrimSpace(pair))
+		}
+
+		organization := strings.ToLower(strings.TrimSpace(parts[0]))
+		channelID := strings.TrimSpace(parts[1])
+		if !organizationNameRegex.MatchString(organization) || !channelIDRegex.MatchString(channelID) {
+			return nil, fmt.Errorf(constants.InvalidDefaultChannelsError, strings.TrimSpace(pair))
+		}
+
+		defaultChannels[organization] = channelID
+	}
+
+	return defaultChannels, nil
+}
+
+// GetEventTypeAliases returns the built-in event type aliases along with the ones defined in the comma separated "alias=eventType" pairs of the setting.
+// An alias can't be redefined for a different event type, so that the same alias never means two things for the users.
+func (c *Configuration) GetEventTypeAliases() (map[string]string, error) {
+	aliases := make(map[string]string, len(constants.DefaultEventTypeAliases))
+	for alias, eventType := range constants.DefaultEventTypeAliases {
+		aliases[alias] = eventType
+	}
+
+	for _, pair := range strings.Split(c.EventTypeAliases, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf(constants.InvalidEventTypeAliasesError, strings.TrimSpace(pair))
+		}
+
+		alias := strings.TrimSpace(parts[0])
+		eventType := strings.TrimSpace(parts[1])
+		if !eventTypeAliasRegex.MatchString(alias) || !isValidEventType(eventType) {
+			return nil, fmt.Errorf(constants.InvalidEventTypeAliasesError, strings.TrimSpace(pair))
+		}
+
+		if existingEventType, ok := aliases[alias]; ok && existingEventType != eventType {
+			return nil, fmt.Errorf(constants.EventTypeAliasCollisionError, alias, existingEventType)
+		}
+
+		aliases[alias] = eventType
+	}
+
+	return aliases, nil
+}
+
+func isValidEventType(eventType string) bool {
+	return constants.ValidSubscriptionEventsForBoards[eventType] || constants.ValidSubscriptionEventsForRepos[eventType] || constants.ValidSubscriptionEventsForPipelines[eventType]
+}
+
+// GetNotificationLanguage returns the language of the static texts in the notifications of the channels which have not set their own, it's English by default
+func (c *Configuration) GetNotificationLanguage() string {
+	if c.NotificationLanguage == "" {
+		return constants.DefaultNotificationLocale
+	}
+
+	return c.NotificationLanguage
+}
+
+// GetServiceAccountPatterns returns the patterns of the service accounts whose notifications can be dropped.
+// The comma separated patterns of the setting replace the default ones.
+func (c *Configuration) GetServiceAccountPatterns() []string {
+	var patterns []string
+	for _, pattern := range strings.Split(c.ServiceAccountPatterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	if len(patterns) == 0 {
+		return constants.DefaultServiceAccountPatterns
+	}
+
+	return patterns
+}
+
+// GetIgnoredNotificationAuthors returns the display names and unique names of the authors whose notifications are never posted
+func (c *Configuration) GetIgnoredNotificationAuthors() []string {
+	var authors []string
+	for _, author := range strings.Split(c.IgnoredNotificationAuthors, ",") {
+		if author = strings.TrimSpace(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+
+	return authors
+}
+
+// GetSubscriptionNotificationsPath returns the path of the plugin API registered as the webhook of new subscriptions
+func (c *Configuration) GetSubscriptionNotificationsPath() string {
+	if c.WebhookPathPrefix == "" {
+		return constants.PathSubscriptionNotifications
+	}
+
+	return fmt.Sprintf("/%s%s", c.WebhookPathPrefix, constants.PathSubscriptionNotifications)
+}
+
+// GetAzureDevopsAPIBaseURL returns the base URL of the Azure DevOps API, it's the one of Azure DevOps Services unless the URL of an Azure DevOps Server is set
+func (c *Configuration) GetAzureDevopsAPIBaseURL() string {
+	if c.AzureDevopsAPIBaseURL == "" {
+		return constants.DefaultAzureDevopsAPIBaseURL
+	}
+
+	return c.AzureDevopsAPIBaseURL
+}
+
+// IsAzureDevopsServer checks if the base URL is the one of an Azure DevOps Server, which serves the OAuth and release APIs from the same host as the others
+func (c *Configuration) IsAzureDevopsServer() bool {
+	baseURL, err := url.Parse(c.GetAzureDevopsAPIBaseURL())
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(baseURL.Hostname())
+	return host != constants.AzureDevopsServicesHost && !strings.HasSuffix(host, constants.AzureDevopsServicesLegacyHostSuffix)
+}
+
+// GetOAuthBaseURL returns the base URL of the OAuth endpoints, Azure DevOps Services authorizes the apps from a host of its own
+func (c *Configuration) GetOAuthBaseURL() string {
+	if c.IsAzureDevopsServer() {
+		return c.GetAzureDevopsAPIBaseURL()
+	}
+
+	return constants.BaseOauthURL
+}
+
+// GetReleaseAPIBaseURL returns the base URL of the release APIs, which are served from the "vsrm." subdomain of Azure DevOps Services
+func (c *Configuration) GetReleaseAPIBaseURL() string {
+	if c.IsAzureDevopsServer() {
+		return c.GetAzureDevopsAPIBaseURL()
+	}
+
+	return strings.Replace(c.GetAzureDevopsAPIBaseURL(), "://", "://vsrm.", 1)
+}
+
+// GetSearchAPIBaseURL returns the base URL of the search APIs, which are served from the "almsearch." subdomain of Azure DevOps Services
+func (c *Configuration) GetSearchAPIBaseURL() string {
+	if c.IsAzureDevopsServer() {
+		return c.GetAzureDevopsAPIBaseURL()
+	}
+
+	return strings.Replace(c.GetAzureDevopsAPIBaseURL(), "://", "://almsearch.", 1)
+}
+
+// GetDeviceCodeTenant returns the Microsoft Entra ID tenant used for the device code flow, any work or school account is allowed by default
+func (c *Configuration) GetDeviceCodeTenant() string {
+	if c.DeviceCodeTenant == "" {
+		return constants.DeviceCodeDefaultTenant
+	}
+
+	return c.DeviceCodeTenant
+}
+
+// GetMaxConcurrentRequests returns the maximum number of requests sent to Azure DevOps at the same time, or 0 if they are not limited.
+// The setting is 0 for the installations which predate it, so they get the default limit, and it's -1 to not limit the requests.
+func (c *Configuration) GetMaxConcurrentRequests() int {
+	switch c.MaxConcurrentRequests {
+	case 0:
+		return constants.DefaultMaxConcurrentRequests
+	case -1:
+		return 0
+	}
+
+	return c.MaxConcurrentRequests
+}
+
+// GetWorkItemsBatchSize returns the number of work items fetched in a single request, it's the most Azure DevOps allows by default
+func (c *Configuration) GetWorkItemsBatchSize() int {
+	if c.WorkItemsBatchSize == 0 {
+		return constants.WorkItemsBatchMaxSize
+	}
+
+	return c.WorkItemsBatchSize
+}
+
+// GetCACertPool returns the system certificate pool along with the CA certificates of the PEM bundle, and the subjects of the added certificates.
+// The pool is nil if there are no CA certificates, so that the system pool is used as is.
+func (c *Configuration) GetCACertPool() (*x509.CertPool, []string, error) {
+	if c.CACertificates == "" {
+		return nil, nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	var subjects []string
+	rest := []byte(c.CACertificates)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != constants.PEMBlockTypeCertificate {
+			return nil, nil, fmt.Errorf(constants.InvalidCACertificatesError, fmt.Sprintf("unexpected PEM block %q", block.Type))
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf(constants.InvalidCACertificatesError, err.Error())
+		}
+
+		pool.AddCert(certificate)
+		subjects = append(subjects, certificate.Subject.String())
+	}
+
+	if len(subjects) == 0 || strings.TrimSpace(string(rest)) != "" {
+		return nil, nil, fmt.Errorf(constants.InvalidCACertificatesError, "the bundle contains text which is not a PEM encoded certificate")
+	}
+
+	return pool, subjects, nil
+}
+
+// isValidBaseURL checks if a base URL is an absolute http or https URL without a query, so that the paths of the requests can be appended to it
+func isValidBaseURL(baseURL string) bool {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+
+	return (parsedURL.Scheme == "https" || parsedURL.Scheme == "http") && parsedURL.Host != "" && parsedURL.RawQuery == "" && parsedURL.Fragment == ""
+}
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,7 +22,7 @@ func TestIsValid(t *testing.T) {
 		{
 			description: "configuration: valid",
 			config: &Configuration{
-				AzureDevopsAPIBaseURL:        "mockAzureDevopsAPIBaseURL",
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
 				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
 				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
 				EncryptionSecret:             "mockEncryptionSecret",
@@ -32,12 +36,42 @@ func TestIsValid(t *testing.T) {
 				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
 				EncryptionSecret:             "mockEncryptionSecret",
 			},
-			errMsg: constants.EmptyAzureDevopsAPIBaseURLError,
+		},
+		{
+			description: "configuration: AzureDevopsAPIBaseURL of an Azure DevOps Server",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://tfs.example.com/tfs/DefaultCollection",
+				AzureDevopsAPIVersion:        "6.0",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+			},
+		},
+		{
+			description: "configuration: invalid AzureDevopsAPIBaseURL",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "tfs.example.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+			},
+			errMsg: constants.InvalidAzureDevopsAPIBaseURLError,
+		},
+		{
+			description: "configuration: invalid AzureDevopsAPIVersion",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://tfs.example.com/tfs",
+				AzureDevopsAPIVersion:        "v6",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+			},
+			errMsg: constants.InvalidAzureDevopsAPIVersionError,
 		},
 		{
 			description: "configuration: empty AzureDevopsOAuthAppID",
 			config: &Configuration{
-				AzureDevopsAPIBaseURL:        "mockAzureDevopsAPIBaseURL",
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
 				AzureDevopsOAuthAppID:        "",
 				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
 				EncryptionSecret:             "mockEncryptionSecret",
@@ -47,7 +81,7 @@ func TestIsValid(t *testing.T) {
 		{
 			description: "configuration: empty AzureDevopsOAuthClientSecret",
 			config: &Configuration{
-				AzureDevopsAPIBaseURL:        "mockAzureDevopsAPIBaseURL",
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
 				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
 				AzureDevopsOAuthClientSecret: "",
 				EncryptionSecret:             "mockEncryptionSecret",
@@ -57,13 +91,276 @@ func TestIsValid(t *testing.T) {
 		{
 			description: "configuration: empty EncryptionSecret",
 			config: &Configuration{
-				AzureDevopsAPIBaseURL:        "mockAzureDevopsAPIBaseURL",
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
 				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
 				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
 				EncryptionSecret:             "",
 			},
 			errMsg: constants.EmptyEncryptionSecretError,
 		},
+		{
+			description: "configuration: valid DefaultOrganization",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				DefaultOrganization:          "mock-organization",
+			},
+		},
+		{
+			description: "configuration: invalid DefaultOrganization",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				DefaultOrganization:          "mock/organization",
+			},
+			errMsg: constants.InvalidDefaultOrganizationError,
+		},
+		{
+			description: "configuration: negative MaxDescriptionLength",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				MaxDescriptionLength:         -1,
+			},
+			errMsg: constants.InvalidMaxDescriptionLengthError,
+		},
+		{
+			description: "configuration: negative MaxConcurrentRequests",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				MaxConcurrentRequests:        -2,
+			},
+			errMsg: constants.InvalidMaxConcurrentRequestsError,
+		},
+		{
+			description: "configuration: too many MaxRequestRetries",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				MaxRequestRetries:            constants.MaxRequestRetriesLimit + 1,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidMaxRequestRetriesError, constants.MaxRequestRetriesLimit),
+		},
+		{
+			description: "configuration: negative ProjectListCacheTTL",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				ProjectListCacheTTL:          -1,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidProjectListCacheTTLError, constants.ProjectListCacheMaxTTL),
+		},
+		{
+			description: "configuration: negative MaxSubscriptionsPerUser",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				MaxSubscriptionsPerUser:      -1,
+			},
+			errMsg: constants.InvalidMaxPerUserError,
+		},
+		{
+			description: "configuration: WorkItemsBatchSize over the limit of Azure DevOps",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				WorkItemsBatchSize:           201,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidWorkItemsBatchSizeError, constants.WorkItemsBatchMaxSize),
+		},
+		{
+			description: "configuration: CACertificates is not a PEM bundle",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				CACertificates:               "mockCertificate",
+			},
+			errMsg: fmt.Sprintf(constants.InvalidCACertificatesError, "the bundle contains text which is not a PEM encoded certificate"),
+		},
+		{
+			description: "configuration: negative NotificationCoalescingWindow",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				NotificationCoalescingWindow: -1,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidCoalescingWindowError, constants.NotificationCoalescingMaxWindow),
+		},
+		{
+			description: "configuration: NotificationCoalescingWindow over the limit",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				NotificationCoalescingWindow: constants.NotificationCoalescingMaxWindow + 1,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidCoalescingWindowError, constants.NotificationCoalescingMaxWindow),
+		},
+		{
+			description: "configuration: negative WebhookDeletionGracePeriod",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				WebhookDeletionGracePeriod:   -1,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidWebhookDeletionGracePeriodError, constants.WebhookDeletionMaxGracePeriod),
+		},
+		{
+			description: "configuration: negative WriteRequestBurst",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				WriteRequestRateLimit:        30,
+				WriteRequestBurst:            -1,
+			},
+			errMsg: constants.InvalidWriteRequestRateLimitError,
+		},
+		{
+			description: "configuration: ReconciliationInterval longer than a week",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				ReconciliationInterval:       constants.SubscriptionReconciliationMaxInterval + 1,
+			},
+			errMsg: fmt.Sprintf(constants.InvalidReconciliationIntervalError, constants.SubscriptionReconciliationMaxInterval),
+		},
+		{
+			description: "configuration: unknown field in RequiredTaskFields",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				RequiredTaskFields:           "Bug=description; Task=priority",
+			},
+			errMsg: fmt.Sprintf(constants.InvalidRequiredTaskFieldsError, "Task=priority"),
+		},
+		{
+			description: "configuration: invalid channel ID in OrganizationDefaultChannels",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				OrganizationDefaultChannels:  "mockOrganization=mockChannelID",
+			},
+			errMsg: fmt.Sprintf(constants.InvalidDefaultChannelsError, "mockOrganization=mockChannelID"),
+		},
+		{
+			description: "configuration: negative NotificationCommentLength",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				NotificationTitleLength:      150,
+				NotificationCommentLength:    -1,
+			},
+			errMsg: constants.InvalidNotificationTruncationError,
+		},
+		{
+			description: "configuration: valid NotificationEmojis",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				NotificationEmojis:           "created=✨, failed=❌,",
+			},
+		},
+		{
+			description: "configuration: invalid NotificationEmojis",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				NotificationEmojis:           "created=✨, deployed=🚀",
+			},
+			errMsg: fmt.Sprintf(constants.InvalidNotificationEmojisError, "deployed=🚀"),
+		},
+		{
+			description: "configuration: event type alias collides with a built-in alias",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				EventTypeAliases:             "pr-created=git.pullrequest.merged",
+			},
+			errMsg: fmt.Sprintf(constants.EventTypeAliasCollisionError, "pr-created", constants.SubscriptionEventPullRequestCreated),
+		},
+		{
+			description: "configuration: valid WebhookPathPrefix",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				WebhookPathPrefix:            "mock_azure/hooks-v2",
+			},
+		},
+		{
+			description: "configuration: invalid WebhookPathPrefix",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				WebhookPathPrefix:            "mock//hooks?",
+			},
+			errMsg: constants.InvalidWebhookPathPrefixError,
+		},
+		{
+			description: "configuration: invalid DeviceCodeTenant",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				DeviceCodeTenant:             "mock/tenant",
+			},
+			errMsg: constants.InvalidDeviceCodeTenantError,
+		},
+		{
+			description: "configuration: unsupported NotificationLanguage",
+			config: &Configuration{
+				AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+				AzureDevopsOAuthAppID:        "mockAzureDevopsOAuthAppID",
+				AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
+				EncryptionSecret:             "mockEncryptionSecret",
+				NotificationLanguage:         "xx",
+			},
+			errMsg: fmt.Sprintf(constants.UnsupportedNotificationLanguageError, "xx", "de, en, es"),
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			err := testCase.config.IsValid()
@@ -120,6 +417,35 @@ func TestProcessConfiguration(t *testing.T) {
 				EncryptionSecret: "mockEncryptionSecret",
 			},
 		},
+		{
+			description: "ProcessConfiguration: valid DefaultOrganization",
+			config: &Configuration{
+				DefaultOrganization: "  MockOrganization  ",
+			},
+			afterProcessConfig: &Configuration{
+				DefaultOrganization: "mockorganization",
+			},
+		},
+		{
+			description: "ProcessConfiguration: valid WebhookPathPrefix",
+			config: &Configuration{
+				WebhookPathPrefix: "  /mock/hooks/  ",
+			},
+			afterProcessConfig: &Configuration{
+				WebhookPathPrefix: "mock/hooks",
+			},
+		},
+		{
+			description: "ProcessConfiguration: valid device code settings",
+			config: &Configuration{
+				DeviceCodeClientID: "  mockClientID  ",
+				DeviceCodeTenant:   "  contoso.onmicrosoft.com  ",
+			},
+			afterProcessConfig: &Configuration{
+				DeviceCodeClientID: "mockClientID",
+				DeviceCodeTenant:   "contoso.onmicrosoft.com",
+			},
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			err := testCase.config.ProcessConfiguration()
@@ -151,3 +477,299 @@ func TestCloneConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNotificationEmojis(t *testing.T) {
+	config := &Configuration{NotificationEmojis: "failed=❌, updated="}
+
+	emojis, err := config.GetNotificationEmojis()
+
+	require.NoError(t, err)
+	assert.Equal(t, "❌", emojis[constants.NotificationStatusFailed])
+	assert.Equal(t, "", emojis[constants.NotificationStatusUpdated])
+	assert.Equal(t, constants.DefaultNotificationEmojis[constants.NotificationStatusCreated], emojis[constants.NotificationStatusCreated])
+	assert.Equal(t, "🔴", constants.DefaultNotificationEmojis[constants.NotificationStatusFailed])
+}
+
+func TestGetRequiredTaskFields(t *testing.T) {
+	for _, testCase := range []struct {
+		description            string
+		requiredTaskFields     string
+		expectedRequiredFields map[string][]string
+		expectedError          string
+	}{
+		{
+			description:            "GetRequiredTaskFields: no required fields",
+			expectedRequiredFields: map[string][]string{},
+		},
+		{
+			description:        "GetRequiredTaskFields: required fields are mapped by the lowercase work item type",
+			requiredTaskFields: "Bug = Description, AREAPATH; User Story=description,;",
+			expectedRequiredFields: map[string][]string{
+				"bug":        {constants.TaskFieldDescription, constants.TaskFieldAreaPath},
+				"user story": {constants.TaskFieldDescription},
+			},
+		},
+		{
+			description:        "GetRequiredTaskFields: pair without fields",
+			requiredTaskFields: "Bug=",
+			expectedError:      fmt.Sprintf(constants.InvalidRequiredTaskFieldsError, "Bug="),
+		},
+		{
+			description:        "GetRequiredTaskFields: pair without a work item type",
+			requiredTaskFields: "description",
+			expectedError:      fmt.Sprintf(constants.InvalidRequiredTaskFieldsError, "description"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			requiredFields, err := (&Configuration{RequiredTaskFields: testCase.requiredTaskFields}).GetRequiredTaskFields()
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedRequiredFields, requiredFields)
+		})
+	}
+}
+
+func TestGetOrganizationDefaultChannels(t *testing.T) {
+	for _, testCase := range []struct {
+		description                 string
+		organizationDefaultChannels string
+		expectedDefaultChannels     map[string]string
+		expectedError               string
+	}{
+		{
+			description:             "GetOrganizationDefaultChannels: no default channels",
+			expectedDefaultChannels: map[string]string{},
+		},
+		{
+			description:                 "GetOrganizationDefaultChannels: channels are mapped by the lowercase organization",
+			organizationDefaultChannels: "MockOrganization = qwertyuiopasdfghjklzxcvbnm, other-org=mnbvcxzlkjhgfdsapoiuytrewq,",
+			expectedDefaultChannels: map[string]string{
+				"mockorganization": "qwertyuiopasdfghjklzxcvbnm",
+				"other-org":        "mnbvcxzlkjhgfdsapoiuytrewq",
+			},
+		},
+		{
+			description:                 "GetOrganizationDefaultChannels: pair without a channel",
+			organizationDefaultChannels: "mockOrganization",
+			expectedError:               fmt.Sprintf(constants.InvalidDefaultChannelsError, "mockOrganization"),
+		},
+		{
+			description:                 "GetOrganizationDefaultChannels: invalid organization",
+			organizationDefaultChannels: "mock organization=qwertyuiopasdfghjklzxcvbnm",
+			expectedError:               fmt.Sprintf(constants.InvalidDefaultChannelsError, "mock organization=qwertyuiopasdfghjklzxcvbnm"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			defaultChannels, err := (&Configuration{OrganizationDefaultChannels: testCase.organizationDefaultChannels}).GetOrganizationDefaultChannels()
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedDefaultChannels, defaultChannels)
+		})
+	}
+}
+
+func TestGetEventTypeAliases(t *testing.T) {
+	for _, testCase := range []struct {
+		description      string
+		eventTypeAliases string
+		expectedAliases  map[string]string
+		expectedError    string
+	}{
+		{
+			description:     "GetEventTypeAliases: only the built-in aliases",
+			expectedAliases: constants.DefaultEventTypeAliases,
+		},
+		{
+			description:      "GetEventTypeAliases: aliases are added to the built-in ones",
+			eventTypeAliases: "pr-done = git.pullrequest.merged, wi-new=workitem.created,",
+			expectedAliases: map[string]string{
+				"pr-done":          constants.SubscriptionEventPullRequestMerged,
+				"wi-new":           constants.SubscriptionEventWorkItemCreated,
+				"pr-merged":        constants.SubscriptionEventPullRequestMerged,
+				"workitem-created": constants.SubscriptionEventWorkItemCreated,
+			},
+		},
+		{
+			description:      "GetEventTypeAliases: built-in alias is redefined for the same event type",
+			eventTypeAliases: "pr-created=git.pullrequest.created",
+			expectedAliases:  map[string]string{"pr-created": constants.SubscriptionEventPullRequestCreated},
+		},
+		{
+			description:      "GetEventTypeAliases: alias collides with a built-in alias",
+			eventTypeAliases: "code-pushed=git.pullrequest.created",
+			expectedError:    fmt.Sprintf(constants.EventTypeAliasCollisionError, "code-pushed", constants.SubscriptionEventCodePushed),
+		},
+		{
+			description:      "GetEventTypeAliases: alias is defined twice for different event types",
+			eventTypeAliases: "pr-done=git.pullrequest.merged, pr-done=git.pullrequest.updated",
+			expectedError:    fmt.Sprintf(constants.EventTypeAliasCollisionError, "pr-done", constants.SubscriptionEventPullRequestMerged),
+		},
+		{
+			description:      "GetEventTypeAliases: unknown event type",
+			eventTypeAliases: "deployed=release.deployed",
+			expectedError:    fmt.Sprintf(constants.InvalidEventTypeAliasesError, "deployed=release.deployed"),
+		},
+		{
+			description:      "GetEventTypeAliases: alias is an event type",
+			eventTypeAliases: "git.push=git.pullrequest.created",
+			expectedError:    fmt.Sprintf(constants.InvalidEventTypeAliasesError, "git.push=git.pullrequest.created"),
+		},
+		{
+			description:      "GetEventTypeAliases: uppercase alias",
+			eventTypeAliases: "PR-Done=git.pullrequest.merged",
+			expectedError:    fmt.Sprintf(constants.InvalidEventTypeAliasesError, "PR-Done=git.pullrequest.merged"),
+		},
+		{
+			description:      "GetEventTypeAliases: pair without an event type",
+			eventTypeAliases: "pr-done",
+			expectedError:    fmt.Sprintf(constants.InvalidEventTypeAliasesError, "pr-done"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			aliases, err := (&Configuration{EventTypeAliases: testCase.eventTypeAliases}).GetEventTypeAliases()
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			for alias, eventType := range testCase.expectedAliases {
+				assert.Equal(t, eventType, aliases[alias])
+			}
+		})
+	}
+
+	t.Run("GetEventTypeAliases: built-in aliases are not modified", func(t *testing.T) {
+		_, err := (&Configuration{EventTypeAliases: "pr-done=git.pullrequest.merged"}).GetEventTypeAliases()
+
+		require.NoError(t, err)
+		assert.NotContains(t, constants.DefaultEventTypeAliases, "pr-done")
+	})
+}
+
+func TestGetSubscriptionNotificationsPath(t *testing.T) {
+	assert.Equal(t, constants.PathSubscriptionNotifications, (&Configuration{}).GetSubscriptionNotificationsPath())
+	assert.Equal(t, "/mock/hooks/notification", (&Configuration{WebhookPathPrefix: "mock/hooks"}).GetSubscriptionNotificationsPath())
+}
+
+func TestGetAzureDevopsAPIBaseURL(t *testing.T) {
+	assert.Equal(t, constants.DefaultAzureDevopsAPIBaseURL, (&Configuration{}).GetAzureDevopsAPIBaseURL())
+	assert.Equal(t, "https://tfs.example.com/tfs", (&Configuration{AzureDevopsAPIBaseURL: "https://tfs.example.com/tfs"}).GetAzureDevopsAPIBaseURL())
+}
+
+func TestIsAzureDevopsServer(t *testing.T) {
+	assert.False(t, (&Configuration{}).IsAzureDevopsServer())
+	assert.False(t, (&Configuration{AzureDevopsAPIBaseURL: "https://Dev.Azure.com"}).IsAzureDevopsServer())
+	assert.False(t, (&Configuration{AzureDevopsAPIBaseURL: "https://mock-organization.visualstudio.com"}).IsAzureDevopsServer())
+	assert.True(t, (&Configuration{AzureDevopsAPIBaseURL: "https://tfs.example.com/tfs"}).IsAzureDevopsServer())
+}
+
+func TestGetOAuthBaseURL(t *testing.T) {
+	assert.Equal(t, constants.BaseOauthURL, (&Configuration{}).GetOAuthBaseURL())
+	assert.Equal(t, "https://tfs.example.com/tfs", (&Configuration{AzureDevopsAPIBaseURL: "https://tfs.example.com/tfs"}).GetOAuthBaseURL())
+}
+
+func TestGetReleaseAPIBaseURL(t *testing.T) {
+	assert.Equal(t, "https://vsrm.dev.azure.com", (&Configuration{}).GetReleaseAPIBaseURL())
+	assert.Equal(t, "https://tfs.example.com/tfs", (&Configuration{AzureDevopsAPIBaseURL: "https://tfs.example.com/tfs"}).GetReleaseAPIBaseURL())
+}
+
+func TestGetSearchAPIBaseURL(t *testing.T) {
+	assert.Equal(t, "https://almsearch.dev.azure.com", (&Configuration{}).GetSearchAPIBaseURL())
+	assert.Equal(t, "https://tfs.example.com/tfs", (&Configuration{AzureDevopsAPIBaseURL: "https://tfs.example.com/tfs"}).GetSearchAPIBaseURL())
+}
+
+func TestGetDeviceCodeTenant(t *testing.T) {
+	assert.Equal(t, constants.DeviceCodeDefaultTenant, (&Configuration{}).GetDeviceCodeTenant())
+	assert.Equal(t, "contoso.onmicrosoft.com", (&Configuration{DeviceCodeTenant: "contoso.onmicrosoft.com"}).GetDeviceCodeTenant())
+}
+
+func TestGetNotificationLanguage(t *testing.T) {
+	assert.Equal(t, constants.DefaultNotificationLocale, (&Configuration{}).GetNotificationLanguage())
+	assert.Equal(t, "de", (&Configuration{NotificationLanguage: "de"}).GetNotificationLanguage())
+}
+
+func TestGetServiceAccountPatterns(t *testing.T) {
+	assert.Equal(t, constants.DefaultServiceAccountPatterns, (&Configuration{}).GetServiceAccountPatterns())
+	assert.Equal(t, constants.DefaultServiceAccountPatterns, (&Configuration{ServiceAccountPatterns: " , "}).GetServiceAccountPatterns())
+	assert.Equal(t, []string{"Release Bot", "svc.*"}, (&Configuration{ServiceAccountPatterns: "Release Bot, svc.* ,"}).GetServiceAccountPatterns())
+}
+
+func TestGetIgnoredNotificationAuthors(t *testing.T) {
+	assert.Empty(t, (&Configuration{}).GetIgnoredNotificationAuthors())
+	assert.Empty(t, (&Configuration{IgnoredNotificationAuthors: " , "}).GetIgnoredNotificationAuthors())
+	assert.Equal(t, []string{"CI Bot", "ci@example.com"}, (&Configuration{IgnoredNotificationAuthors: "CI Bot, ci@example.com ,"}).GetIgnoredNotificationAuthors())
+}
+
+func TestGetMaxConcurrentRequests(t *testing.T) {
+	assert.Equal(t, constants.DefaultMaxConcurrentRequests, (&Configuration{}).GetMaxConcurrentRequests())
+	assert.Equal(t, 0, (&Configuration{MaxConcurrentRequests: -1}).GetMaxConcurrentRequests())
+	assert.Equal(t, 3, (&Configuration{MaxConcurrentRequests: 3}).GetMaxConcurrentRequests())
+}
+
+func TestGetWorkItemsBatchSize(t *testing.T) {
+	assert.Equal(t, constants.WorkItemsBatchMaxSize, (&Configuration{}).GetWorkItemsBatchSize())
+	assert.Equal(t, 50, (&Configuration{WorkItemsBatchSize: 50}).GetWorkItemsBatchSize())
+}
+
+func TestGetCACertPool(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	certificatePEM := string(pem.EncodeToMemory(&pem.Block{Type: constants.PEMBlockTypeCertificate, Bytes: server.Certificate().Raw}))
+
+	for _, testCase := range []struct {
+		description      string
+		caCertificates   string
+		expectedSubjects []string
+		expectedErr      bool
+	}{
+		{
+			description: "GetCACertPool: no CA certificates",
+		},
+		{
+			description:      "GetCACertPool: bundle of certificates",
+			caCertificates:   certificatePEM + "\n" + certificatePEM,
+			expectedSubjects: []string{server.Certificate().Subject.String(), server.Certificate().Subject.String()},
+		},
+		{
+			description:    "GetCACertPool: PEM block which is not a certificate",
+			caCertificates: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("mockKey")})),
+			expectedErr:    true,
+		},
+		{
+			description:    "GetCACertPool: certificate which can't be parsed",
+			caCertificates: string(pem.EncodeToMemory(&pem.Block{Type: constants.PEMBlockTypeCertificate, Bytes: []byte("mockCertificate")})),
+			expectedErr:    true,
+		},
+		{
+			description:    "GetCACertPool: text after the certificates",
+			caCertificates: certificatePEM + "mockCertificate",
+			expectedErr:    true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			pool, subjects, err := (&Configuration{CACertificates: testCase.caCertificates}).GetCACertPool()
+
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, pool)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedSubjects, subjects)
+			assert.Equal(t, testCase.caCertificates != "", pool != nil)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 const (
 	// Bot configs
 	BotUsername    = "azuredevops"
@@ -12,29 +14,58 @@ const (
 	HeaderMattermostUserID = "Mattermost-User-ID"
 
 	// Command configs
-	CommandTriggerName = "azuredevops"
-	HelpText           = "###### Mattermost Azure DevOps Plugin - Slash Command Help\n" +
-		"* `/azuredevops connect` - Connect your Mattermost account to your Azure DevOps account.\n" +
-		"* `/azuredevops disconnect` - Disconnect your Mattermost account from your Azure DevOps account.\n" +
-		"* `/azuredevops link [projectURL]` - Link your project to a current channel.\n" +
-		"* `/azuredevops boards create [title] [description]` - Create a new task for your project.\n" +
-		"* `/azuredevops boards/repos/pipelines subscription add` - Add a new Boards/Repos/Pipelines subscription for your linked projects.\n" +
-		"* `/azuredevops boards/repos/pipelines subscription list [me or anyone] [all_channels]` - View Boards/Repos/Pipelines subscriptions.\n" +
-		"* `/azuredevops boards/repos/pipelines subscription delete [subscription id]` - Delete a Boards/Repos/Pipelines subscription"
-	InvalidCommand      = "Invalid command.\n\n"
-	CommandHelp         = "help"
-	CommandConnect      = "connect"
-	CommandDisconnect   = "disconnect"
-	CommandLink         = "link"
-	CommandBoards       = "boards"
-	CommandRepos        = "repos"
-	CommandPipelines    = "pipelines"
-	CommandCreate       = "create"
-	CommandWorkitem     = "workitem"
-	CommandSubscription = "subscription"
-	CommandAdd          = "add"
-	CommandList         = "list"
-	CommandDelete       = "delete"
+	CommandTriggerName   = "azuredevops"
+	HelpTitle            = "###### Mattermost Azure DevOps Plugin - Slash Command Help"
+	InvalidCommand       = "Invalid command.\n\n"
+	CommandHelp          = "help"
+	CommandConnect       = "connect"
+	CommandConnectDevice = "connect-device"
+	CommandDisconnect    = "disconnect"
+	CommandLink          = "link"
+	CommandBoards        = "boards"
+	CommandRepos         = "repos"
+	CommandPipelines     = "pipelines"
+	CommandCreate        = "create"
+	CommandWorkitem      = "workitem"
+	CommandSubscription  = "subscription"
+	CommandAdd           = "add"
+	CommandList          = "list"
+	CommandDelete        = "delete"
+	CommandSubscriptions = "subscriptions"
+	CommandApplyTemplate = "apply-template"
+	CommandDeleteProject = "delete-project"
+	CommandSprint        = "sprint"
+	CommandShow          = "show"
+	CommandRestore       = "restore"
+	CommandQuery         = "query"
+	CommandMyPRs         = "my-prs"
+	CommandPreferences   = "preferences"
+	CommandAdmin         = "admin"
+	CommandProjectAccess = "project-access"
+	CommandDiagnose      = "diagnose"
+	CommandConnections   = "connections"
+	CommandDeliveryLog   = "delivery-log"
+	CommandLast          = "last"
+	CommandMove          = "move"
+	CommandSet           = "set"
+	CommandPageFlag      = "--page"
+	CommandChannelFlag   = "--channel"
+	CommandAllFlag       = "--all"
+	CommandDestroyFlag   = "--destroy"
+	CommandDefaultQuery  = "default-query"
+	CommandRun           = "run"
+	CommandBlockers      = "blockers"
+	CommandProject       = "project"
+	CommandDedupe        = "dedupe"
+	CommandActivity      = "activity"
+	CommandSync          = "sync"
+	CommandHoursFlag     = "--hours"
+	CommandOldestFlag    = "--oldest"
+	CommandReset         = "reset"
+	CommandPermissions   = "permissions"
+	CommandPause         = "pause"
+	CommandResume        = "resume"
+	CommandProjects      = "projects"
 
 	// Regex to verify task link
 	TaskLinkRegex = `http(s)?:\/\/dev.azure.com\/[a-zA-Z0-9!@#$%^&*()_+\-=\[\]{};':"\\|,.<>\/?]*\/[a-zA-Z0-9!@#$%^&*()_+\-=\[\]{};':"\\|,.<>\/?]*\/_workitems\/edit\/[1-9][0-9]*`
@@ -48,8 +79,39 @@ const (
 	// Regex to verify pipeline release details link
 	ReleaseDetailsLinkRegex = `http(s)?:\/\/dev.azure.com\/[a-zA-Z0-9!@#$%^&*()_+\-=\[\]{};':"\\|,.<>\/?]*\/[a-zA-Z0-9!@#$%^&*()_+\-=\[\]{};':"\\|,.<>\/?]*\/_releaseProgress\?_a=release-pipeline-progress&releaseId=[a-zA-Z0-9!@#$%^&*()_+\-=\[\]{};':"\\|,.<>\/?]+`
 
+	// Regex to verify an organization name
+	OrganizationNameRegex = `^[a-zA-Z0-9][a-zA-Z0-9-]*$`
+
+	// Regex to verify the path prefix of the subscription notifications webhook
+	WebhookPathPrefixRegex = `^[a-zA-Z0-9_-]+(/[a-zA-Z0-9_-]+)*$`
+	DeviceCodeTenantRegex  = `^[a-zA-Z0-9.-]+$`
+	APIVersionRegex        = `^[0-9]+\.[0-9]+(-preview(\.[0-9]+)?)?$`
+	// The API version of a request, matched along with its separator so that the rest of the query is kept as it is
+	APIVersionQueryRegex = `([?&]api-version=)[^&]*`
+
+	// Regex to verify the ID of a Mattermost channel
+	ChannelIDRegex = `^[a-z0-9]{26}$`
+
+	// Regex to verify an event type alias, the aliases are lowercase words separated by hyphens so that they can't be mistaken for an event type
+	EventTypeAliasRegex = `^[a-z0-9]+(-[a-z0-9]+)*$`
+
 	WorkItemCommentedOnMarkdownRegex = ` commented on by [a-zA-Z0-9!@#$%^&*()_+\-=\[\]{};':"|,.<>\/? ]*`
 
+	// Regexes of the API URLs of the resources in the notifications, the first group is the URL of the organization or project
+	WorkItemAPIURLRegex    = `(?i)^(https?://.+?)/_apis/wit/workitems/(\d+)`
+	PullRequestAPIURLRegex = `(?i)^(https?://.+?)/_apis/git/repositories/([^/?]+)/pullrequests/(\d+)`
+	RepositoryAPIURLRegex  = `(?i)^(https?://.+?)/_apis/git/repositories/([^/?]+)`
+	BuildAPIURLRegex       = `(?i)^(https?://.+?)/_apis/build/builds/(\d+)`
+	// The release APIs are served from the "vsrm." subdomain of Azure DevOps Services, but not from Azure DevOps Server
+	ReleaseAPIURLRegex = `(?i)^(https?://)(?:vsrm\.)?(.+?)/_apis/release/releases/(\d+)`
+
+	// Azure DevOps marks the part of a WIQL query causing an error like «[System.Foo]», which is looked for in the clauses of the query
+	WIQLErrorCauseRegex = `«([^»]+)»`
+	WIQLClauseRegex     = `(?i)\b(SELECT|FROM|WHERE|ORDER\s+BY|ASOF|MODE)\b`
+
+	// Prefix of the errors returned with the message of an Azure DevOps API
+	AzureDevopsErrorMessagePrefix = "errorMessage "
+
 	// Azure API Versions
 	CreateTaskAPIVersion = "7.1-preview.3"
 	TasksIDAPIVersion    = "5.1"
@@ -83,19 +145,42 @@ const (
 	SubscriptionEventRunStateChanged                    = "ms.vss-pipelines.run-state-changed-event"
 
 	// Path params
-	PathParamTeamID       = "team_id"
-	PathParamOrganization = "organization"
-	PathParamProject      = "project"
-	PathParamRepository   = "repository"
+	PathParamTeamID        = "team_id"
+	PathParamOrganization  = "organization"
+	PathParamProject       = "project"
+	PathParamRepository    = "repository"
+	PathParamTemplateName  = "template_name"
+	PathParamProjectID     = "project_id"
+	PathParamWebhookPrefix = "webhook_prefix"
+	PathParamTaskID        = "task_id"
 
 	// URL query params constants
-	QueryParamProject     = "project"
-	QueryParamChannelID   = "channel_id"
-	QueryParamCreatedBy   = "created_by"
-	QueryParamServiceType = "service_type"
-	QueryParamEventType   = "event_type"
-	QueryParamPage        = "page"
-	QueryParamPerPage     = "per_page"
+	QueryParamProject      = "project"
+	QueryParamChannelID    = "channel_id"
+	QueryParamCreatedBy    = "created_by"
+	QueryParamServiceType  = "service_type"
+	QueryParamEventType    = "event_type"
+	QueryParamPage         = "page"
+	QueryParamPerPage      = "per_page"
+	QueryParamOrganization = "organization"
+	QueryParamSort         = "sort"
+	QueryParamState        = "state"
+	QueryParamAssignedTo   = "assigned_to"
+	QueryParamRepository   = "repository"
+	QueryParamText         = "text"
+	QueryParamTop          = "top"
+
+	// Order of the listed subscriptions, the newest are listed first unless the oldest are requested.
+	// The subscriptions created before their creation time was stored have an unknown one and are the oldest.
+	SubscriptionSortOldest       = "oldest"
+	SubscriptionCreatedAtFormat  = "Jan 2, 2006 15:04 MST"
+	SubscriptionCreatedAtUnknown = "Unknown"
+
+	// Names of the channels of the listed subscriptions, the ID of a channel is shown when it's deleted or can't be fetched
+	ChannelNameWithTeam      = "%s (%s)"
+	ChannelNameDirectMessage = "Direct message"
+	ChannelNameDeleted       = "%s (deleted)"
+	ChannelNameInaccessible  = "%s (inaccessible)"
 
 	// Filters
 	FilterCreatedByMe          = "me"
@@ -116,6 +201,7 @@ const (
 	// Authorization constants
 	Bearer        = "Bearer"
 	Authorization = "Authorization"
+	RetryAfter    = "Retry-After"
 
 	GetTasksID  = "/%s/_apis/wit/wiql"
 	GetTasks    = "/%s/_apis/wit/workitems"
@@ -133,11 +219,14 @@ const (
 	WSEventConnect             = "connect"
 	WSEventDisconnect          = "disconnect"
 	WSEventSubscriptionDeleted = "subscription_deleted"
+	WSEventNotificationPosted  = "notification_posted"
 
 	// Colors
 	IconColorRepos     = "#d74f27"
 	IconColorBoards    = "#53bba1"
 	IconColorPipelines = "#4275E4"
+	IconColorSucceeded = "#3db887"
+	IconColorFailed    = "#d24b4e"
 
 	SubscriptionEventTypeDummy = "dummy"
 	FileNameGitBranchIcon      = "git-branch-icon.svg"
@@ -171,7 +260,258 @@ const (
 
 	DialogFieldNameComment = "comment"
 
+	// Context of the buttons confirming the deletion of a work item
+	DeleteWorkItemContextOrganization = "organization"
+	DeleteWorkItemContextProjectName  = "projectName"
+	DeleteWorkItemContextWorkItemID   = "workItemId"
+	DeleteWorkItemContextDestroy      = "destroy"
+	DeleteWorkItemContextAction       = "action"
+	DeleteWorkItemActionConfirm       = "confirm"
+	DeleteWorkItemActionCancel        = "cancel"
+
+	// Button showing the subscription which produced a notification, the ID of the subscription is stored in the props of the notification post.
+	// A post combining related notifications contains the notifications of several subscriptions, so the ID is also in the context of the button.
+	ShowNotificationSubscriptionActionID = "showNotificationSubscription"
+	ShowSubscriptionContextID            = "subscriptionId"
+	PostPropSubscriptionID               = "azure_devops_subscription_id"
+
+	// Button re-running the failed build of a notification, the definition of the build is queued again on the same branch
+	RerunBuildActionID            = "rerunBuild"
+	RerunBuildContextOrganization = "organization"
+	RerunBuildContextProjectName  = "projectName"
+	RerunBuildContextDefinitionID = "definitionId"
+	RerunBuildContextSourceBranch = "sourceBranch"
+
+	// Results of the completed builds, the notifications of a subscription can be limited to some of them
+	BuildResultSucceeded          = "succeeded"
+	BuildResultPartiallySucceeded = "partiallySucceeded"
+	BuildResultFailed             = "failed"
+	BuildResultCanceled           = "canceled"
+
+	// Context of the buttons confirming the reset of the plugin state of a user, which always applies to the user clicking it
+	ResetUserContextAction = "action"
+	ResetUserActionConfirm = "confirm"
+	ResetUserActionCancel  = "cancel"
+
+	// Context of the buttons confirming the disconnection of an account, which always applies to the user clicking it
+	DisconnectUserContextAction = "action"
+	DisconnectUserActionConfirm = "confirm"
+	DisconnectUserActionCancel  = "cancel"
+
 	MaxBytesSizeForReadingResponseBody = 1000000
+	// The expanded work items include all their fields and relations, e.g. the long HTML fields and hundreds of links
+	MaxBytesSizeForReadingExpandedWorkItem = 5000000
+
+	// Type of the PEM blocks of the CA certificates trusted for the requests to Azure DevOps
+	PEMBlockTypeCertificate = "CERTIFICATE"
+
+	// Work item field changes
+	WorkItemFieldChangeFormat         = "**%s**: %s → %s"
+	WorkItemFieldOldValue             = "oldValue"
+	WorkItemFieldNewValue             = "newValue"
+	MaxWorkItemFieldChangeValueLength = 100
+
+	// Retry queue operation types
+	RetryOperationTypeCreateTask         = "create_task"
+	RetryOperationTypeCreateSubscription = "create_subscription"
+
+	// WIQL query to find a work item created by the current user, used to avoid creating duplicates while retrying
+	QueryWorkItemCreatedByMe = "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.WorkItemType] = '%s' AND [System.Title] = '%s' AND [System.CreatedBy] = @Me AND [System.CreatedDate] >= '%s'"
+
+	// Sprint summary
+	QueryWorkItemsInIteration = "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.IterationPath] = '%s'"
+	WorkItemsBatchMaxSize     = 200
+	WorkItemsBatchWorkers     = 4
+	FieldWorkItemType         = "System.WorkItemType"
+	FieldState                = "System.State"
+	FieldRemainingWork        = "Microsoft.VSTS.Scheduling.RemainingWork"
+	SprintStateToDo           = "To Do"
+	SprintStateInProgress     = "In Progress"
+	SprintStateDone           = "Done"
+
+	// Channel notification preferences
+	ChannelPrefColor        = "color"
+	ChannelPrefHTML         = "html"
+	ChannelPrefEmoji        = "emoji"
+	ChannelPrefTimezone     = "timezone"
+	ChannelPrefLanguage     = "language"
+	ChannelPrefSummary      = "summary"
+	ChannelPrefSummaryDay   = "summary-day"
+	ChannelPrefSummaryHour  = "summary-hour"
+	ChannelPrefValueDefault = "default"
+
+	// Language of the static texts of the notifications, the other languages are added as catalogs in the i18n package
+	DefaultNotificationLocale = "en"
+
+	// Weekly summaries of the notifications of a channel
+	WeeklySummaryDefaultDay  = "monday"
+	WeeklySummaryDefaultHour = 9
+	WeeklySummaryDateFormat  = "Jan 2, 2006"
+
+	// Saved queries
+	QueriesSearchMaxResults = 50
+	SharedQueryMaxResults   = 200
+	SharedQueryPageSize     = 20
+	QueryResultTypeLink     = "workItemLink"
+	FieldTitle              = "System.Title"
+	FieldAssignedTo         = "System.AssignedTo"
+	WorkItemEditLink        = "%s/%s/%s/_workitems/edit/%d"
+
+	// Default query of a linked project, the active work items assigned to the user are listed if the project has none
+	DefaultProjectQuery      = "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.AssignedTo] = @Me AND [System.State] NOT IN ('Closed', 'Done', 'Removed') ORDER BY [System.ChangedDate] DESC"
+	DefaultQueryValueDefault = "default"
+	DefaultQueryMaxResults   = 20
+
+	// Work items of a linked project listed in the webapp, the most recently changed are listed first
+	QueryProjectWorkItems             = "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project"
+	QueryProjectWorkItemsState        = " AND [System.State] = '%s'"
+	QueryProjectWorkItemsAssignedTo   = " AND [System.AssignedTo] = '%s'"
+	QueryProjectWorkItemsAssignedToMe = " AND [System.AssignedTo] = @Me"
+	QueryProjectWorkItemsOrder        = " ORDER BY [System.ChangedDate] DESC"
+	ProjectWorkItemsAssignedToMe      = "me"
+	ProjectWorkItemsMaxResults        = 50
+
+	// Work items of a linked project found by the text typed in the webapp, the results are capped by the "top" query param
+	WorkItemSearchDefaultResults = 10
+	WorkItemSearchMaxResults     = 100
+
+	// Connections of the users listed to the system admins
+	ConnectionsPageSize = 20
+
+	// Recycle bin of the work items
+	RecycleBinMaxWorkItems = 200
+	RecycleBinPageSize     = 20
+
+	// Pull requests of a user
+	PullRequestsMaxResults                = 100
+	PullRequestVoteApproved               = 10
+	PullRequestVoteApprovedWithSuggestion = 5
+	PullRequestVoteWaitingForAuthor       = -5
+	PullRequestVoteRejected               = -10
+	PullRequestMergeStatusConflicts       = "conflicts"
+	PullRequestLink                       = "%s/%s/%s/_git/%s/pullrequest/%d"
+
+	// Work items linked to the pull requests in their notifications, mentioned like "AB#123" in the title or description
+	PullRequestWorkItemMentionRegex = `(?i)\bAB#(\d+)\b`
+	PullRequestWorkItemsMaxCount    = 10
+
+	// Recent activity of a project, the pushes are only fetched for its first few repositories
+	ProjectActivityDefaultHours    = 24
+	ProjectActivityMaxHours        = 168
+	ProjectActivityMaxResults      = 30
+	ProjectActivityMaxRepositories = 10
+	ProjectActivityTimeFormat      = "Jan 2 15:04 MST"
+	ProjectActivityWorkItems       = "work items"
+	ProjectActivityPullRequests    = "pull requests"
+	ProjectActivityPushes          = "pushes"
+	QueryChangedWorkItems          = "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.ChangedDate] >= '%s' ORDER BY [System.ChangedDate] DESC"
+	FieldChangedDate               = "System.ChangedDate"
+	PullRequestStatusCompleted     = "completed"
+	PullRequestStatusAbandoned     = "abandoned"
+
+	// Summary of the work items created in quick succession for a subscription
+	NotificationBurstThreshold      = 5
+	NotificationBurstMaxWorkItemIDs = 200
+	NotificationBurstMaxIterations  = 2
+	NotificationBurstWorkItemsQuery = "SELECT [System.Id], [System.WorkItemType], [System.Title], [System.State] FROM workitems WHERE [System.Id] IN (%s)"
+	WorkItemQueryLink               = "%s/%s/%s/_queries/query/?wiql=%s"
+
+	// Notifications of related events combined into a single post, e.g. the updates of a pull request and its work items when it's completed
+	NotificationCoalescingMaxWindow      = 300
+	NotificationCoalescingMaxAttachments = 10
+	CorrelationKeyPullRequest            = "pullrequest_%d"
+	CorrelationKeyWorkItem               = "workitem_%d"
+
+	// The webhook of a deleted subscription is kept for at most a day in case a matching subscription is created again
+	WebhookDeletionMaxGracePeriod = 24 * 60 * 60
+
+	// The subscriptions are compared with the ones in Azure DevOps at least once a week when the reconciliation is enabled
+	SubscriptionReconciliationMaxInterval = 7 * 24 * 60
+
+	// Pause of the notifications of the subscriptions created by a user
+	SubscriptionsPauseDefaultDuration = time.Hour
+	SubscriptionsPauseMaxDuration     = 30 * 24 * time.Hour
+	SubscriptionsPauseTimeFormat      = "Jan 2, 2006 15:04 MST"
+
+	// Maximum length of the label prefixed to the notifications of a subscription
+	SubscriptionLabelMaxLength = 20
+
+	// Visibility of the notifications of a subscription, ephemeral notifications are only shown to the creator of the subscription
+	SubscriptionVisibilityChannel   = "channel"
+	SubscriptionVisibilityEphemeral = "ephemeral"
+
+	// Formats of the messages requested in the payloads of the webhooks of a subscription, the notifications are rendered from Markdown by default
+	SubscriptionMessageFormatMarkdown = "markdown"
+	SubscriptionMessageFormatText     = "text"
+	SubscriptionMessageFormatHTML     = "html"
+
+	// Branch filters of the subscriptions, a filter prefixed with "!" excludes the matching branches
+	GitBranchRefPrefix    = "refs/heads/"
+	BranchFilterNegation  = "!"
+	BranchFiltersMaxCount = 20
+
+	// Service accounts whose notifications can be dropped, "*" in their patterns matches any characters
+	FieldChangedBy                = "System.ChangedBy"
+	ServiceAccountPatternWildcard = "*"
+
+	// Fields of the task creation request which can be required per work item type
+	TaskFieldTitle       = "title"
+	TaskFieldDescription = "description"
+	TaskFieldAreaPath    = "areaPath"
+
+	// Estimates of the task creation request, set in the scheduling fields of the work item types they apply to
+	TaskFieldStoryPoints   = "storyPoints"
+	TaskFieldEffort        = "effort"
+	TaskFieldRemainingWork = "remainingWork"
+	FieldStoryPoints       = "Microsoft.VSTS.Scheduling.StoryPoints"
+	FieldEffort            = "Microsoft.VSTS.Scheduling.Effort"
+
+	// Commits listed in the push notifications
+	PushNotificationMaxCommits = 5
+	CommitShortIDLength        = 8
+	GitEmptyObjectID           = "0000000000000000000000000000000000000000"
+	CommitLink                 = "%s/commit/%s"
+	BranchCommitsLink          = "%s/commits?itemVersion=GB%s"
+	BranchCompareLink          = "%s/branchCompare?baseVersion=GC%s&targetVersion=GC%s"
+
+	// Truncation of the long texts in the subscription notifications
+	FieldDescription               = "System.Description"
+	NotificationTruncationEllipsis = "…"
+	NotificationViewMoreFormat     = "%s [view more](%s)"
+
+	// Categories of the work item states
+	StateCategoryProposed   = "Proposed"
+	StateCategoryInProgress = "InProgress"
+	StateCategoryResolved   = "Resolved"
+	StateCategoryCompleted  = "Completed"
+	StateCategoryRemoved    = "Removed"
+
+	// Code links of the work items
+	RelationArtifactLink      = "ArtifactLink"
+	ArtifactPullRequestPrefix = "vstfs:///Git/PullRequestId/"
+	ArtifactBranchPrefix      = "vstfs:///Git/Ref/"
+	BranchRefPrefix           = "GB"
+
+	// Relation and field of the expanded work items counting their attachments and comments
+	RelationAttachedFile = "AttachedFile"
+	FieldCommentCount    = "System.CommentCount"
+
+	// Work items blocking a work item, linked as its predecessors
+	RelationPredecessor  = "System.LinkTypes.Dependency-Reverse"
+	BlockersMaxDepth     = 3
+	BlockersMaxWorkItems = 50
+
+	// Statuses of the subscription notifications, used to choose the emoji prefixed to them
+	NotificationStatusCreated     = "created"
+	NotificationStatusUpdated     = "updated"
+	NotificationStatusClosed      = "closed"
+	NotificationStatusFailed      = "failed"
+	NotificationStatusSucceeded   = "succeeded"
+	NotificationStatusPullRequest = "pullRequest"
+
+	// Customization types of the processes
+	ProcessCustomizationSystem    = "system"
+	ProcessCustomizationInherited = "inherited"
 )
 
 var (
@@ -182,6 +522,8 @@ var (
 		SubscriptionEventWorkItemCommented: true,
 	}
 
+	BuildResults = []string{BuildResultSucceeded, BuildResultPartiallySucceeded, BuildResultFailed, BuildResultCanceled}
+
 	ValidSubscriptionEventsForRepos = map[string]bool{
 		SubscriptionEventPullRequestCreated:   true,
 		SubscriptionEventPullRequestMerged:    true,
@@ -211,6 +553,158 @@ var (
 		SubscriptionEventRunStateChanged:            true,
 	}
 
+	// Sprint state groups of the work items whose state category is not known, mapped by their lower cased state names
+	DefaultSprintStateGroups = map[string]string{
+		"new":      SprintStateToDo,
+		"to do":    SprintStateToDo,
+		"proposed": SprintStateToDo,
+		"approved": SprintStateToDo,
+		"done":     SprintStateDone,
+		"closed":   SprintStateDone,
+		"removed":  "",
+	}
+
+	// Fields which are updated on every revision of a work item and are not shown in the list of changes
+	IgnoredWorkItemFieldChanges = map[string]bool{
+		"System.Rev":            true,
+		"System.AuthorizedDate": true,
+		"System.RevisedDate":    true,
+		"System.ChangedDate":    true,
+		"System.ChangedBy":      true,
+		"System.Watermark":      true,
+		"System.PersonId":       true,
+		"System.AuthorizedAs":   true,
+	}
+
+	// Fields of the task creation request mapped by their lowercase names, used to parse the required task fields
+	TaskFields = map[string]string{
+		"title":       TaskFieldTitle,
+		"description": TaskFieldDescription,
+		"areapath":    TaskFieldAreaPath,
+	}
+
+	// The build services of the organization and of the projects, and the other service identities of Azure DevOps
+	DefaultServiceAccountPatterns = []string{
+		"Project Collection Build Service*",
+		"* Build Service (*)",
+		"Microsoft.VisualStudio.Services.TFS",
+		"svc.*",
+	}
+
+	// Estimates which apply to the work item types of the system processes mapped by their lowercase names, the estimates of the other types are not checked
+	TaskEstimatesByType = map[string][]string{
+		"user story":           {TaskFieldStoryPoints},
+		"product backlog item": {TaskFieldEffort},
+		"feature":              {TaskFieldEffort},
+		"epic":                 {TaskFieldEffort},
+		"bug":                  {TaskFieldStoryPoints, TaskFieldEffort},
+		"task":                 {TaskFieldRemainingWork},
+	}
+
+	// Unicode characters are used instead of the emoji names so that they are also shown in the push notifications
+	DefaultNotificationEmojis = map[string]string{
+		NotificationStatusCreated:     "🟢",
+		NotificationStatusUpdated:     "🔵",
+		NotificationStatusClosed:      "🔴",
+		NotificationStatusFailed:      "🔴",
+		NotificationStatusSucceeded:   "🟢",
+		NotificationStatusPullRequest: "🟣",
+	}
+
+	// Notification statuses of the results of builds, pipeline runs and release deployments
+	NotificationResultStatuses = map[string]string{
+		"succeeded": NotificationStatusSucceeded,
+		"failed":    NotificationStatusFailed,
+		"canceled":  NotificationStatusFailed,
+		"rejected":  NotificationStatusFailed,
+	}
+
+	// Colors of the notifications of builds, pipeline runs and release deployments by the notification statuses of their results
+	NotificationResultColors = map[string]string{
+		NotificationStatusSucceeded: IconColorSucceeded,
+		NotificationStatusFailed:    IconColorFailed,
+	}
+
+	// Names of the system processes mapped by their type IDs, which are the same in every organization
+	SystemProcessNames = map[string]string{
+		"adcc42ab-9882-485e-a3ed-7678f01f66bc": "Agile",
+		"6b724908-ef14-45cf-84f8-768b5384da45": "Scrum",
+		"27450541-8e31-4150-9947-dc59f998fc01": "CMMI",
+		"b8a3a935-7e91-48b8-a94c-606d37c3e9f2": "Basic",
+	}
+
+	// Names of the event types shown to the users
+	EventTypeDisplayNames = map[string]string{
+		SubscriptionEventWorkItemCreated:                    "Work Item Created",
+		SubscriptionEventWorkItemUpdated:                    "Work Item Updated",
+		SubscriptionEventWorkItemDeleted:                    "Work Item Deleted",
+		SubscriptionEventWorkItemCommented:                  "Work Item Commented",
+		SubscriptionEventPullRequestCreated:                 "Pull Request Created",
+		SubscriptionEventPullRequestUpdated:                 "Pull Request Updated",
+		SubscriptionEventPullRequestMerged:                  "Pull Request Merge Attempted",
+		SubscriptionEventPullRequestCommented:               "Pull Requested Commented",
+		SubscriptionEventCodePushed:                         "Code Pushed",
+		SubscriptionEventBuildCompleted:                     "Build Completed",
+		SubscriptionEventReleaseAbandoned:                   "Release Abandoned",
+		SubscriptionEventReleaseCreated:                     "Release Created",
+		SubscriptionEventReleaseDeploymentApprovalCompleted: "Release Deployment Approval Completed",
+		SubscriptionEventReleaseDeploymentCompleted:         "Release Deployment Completed",
+		SubscriptionEventReleaseDeploymentEventPending:      "Release Deployment Event Pending",
+		SubscriptionEventReleaseDeploymentStarted:           "Release Deployment Started",
+		SubscriptionEventRunStageApprovalCompleted:          "Run Stage Approval Completed",
+		SubscriptionEventRunStageStateChanged:               "Run Stage State Changed",
+		SubscriptionEventRunStageWaitingForApproval:         "Run Stage Waiting For Approval",
+		SubscriptionEventRunStateChanged:                    "Run State Changed",
+	}
+
+	// Resource versions of the payloads of each event type which are parsed by the plugin, the first one is requested by default
+	SubscriptionResourceVersions = map[string][]string{
+		SubscriptionEventWorkItemCreated:                    {"1.0", "1.0-preview.1"},
+		SubscriptionEventWorkItemUpdated:                    {"1.0", "1.0-preview.1"},
+		SubscriptionEventWorkItemDeleted:                    {"1.0", "1.0-preview.1"},
+		SubscriptionEventWorkItemCommented:                  {"1.0", "1.0-preview.1"},
+		SubscriptionEventPullRequestCreated:                 {"1.0", "1.0-preview.1"},
+		SubscriptionEventPullRequestUpdated:                 {"1.0", "1.0-preview.1"},
+		SubscriptionEventPullRequestMerged:                  {"1.0", "1.0-preview.1"},
+		SubscriptionEventPullRequestCommented:               {"2.0"},
+		SubscriptionEventCodePushed:                         {"1.0", "1.0-preview.1"},
+		SubscriptionEventBuildCompleted:                     {"1.0", "1.0-preview.1"},
+		SubscriptionEventReleaseAbandoned:                   {"3.0-preview.1"},
+		SubscriptionEventReleaseCreated:                     {"3.0-preview.1"},
+		SubscriptionEventReleaseDeploymentApprovalCompleted: {"3.0-preview.1"},
+		SubscriptionEventReleaseDeploymentCompleted:         {"3.0-preview.1"},
+		SubscriptionEventReleaseDeploymentEventPending:      {"3.0-preview.1"},
+		SubscriptionEventReleaseDeploymentStarted:           {"3.0-preview.1"},
+		SubscriptionEventRunStageApprovalCompleted:          {"5.1-preview.1"},
+		SubscriptionEventRunStageStateChanged:               {"5.1-preview.1"},
+		SubscriptionEventRunStageWaitingForApproval:         {"5.1-preview.1"},
+		SubscriptionEventRunStateChanged:                    {"5.1-preview.1"},
+	}
+
+	// Built-in aliases of the event types usable while creating a subscription, the admins can define more of them in the plugin settings
+	DefaultEventTypeAliases = map[string]string{
+		"pr-created":                   SubscriptionEventPullRequestCreated,
+		"pr-updated":                   SubscriptionEventPullRequestUpdated,
+		"pr-commented":                 SubscriptionEventPullRequestCommented,
+		"pr-merged":                    SubscriptionEventPullRequestMerged,
+		"code-pushed":                  SubscriptionEventCodePushed,
+		"workitem-created":             SubscriptionEventWorkItemCreated,
+		"workitem-updated":             SubscriptionEventWorkItemUpdated,
+		"workitem-deleted":             SubscriptionEventWorkItemDeleted,
+		"workitem-commented":           SubscriptionEventWorkItemCommented,
+		"build-completed":              SubscriptionEventBuildCompleted,
+		"release-abandoned":            SubscriptionEventReleaseAbandoned,
+		"release-created":              SubscriptionEventReleaseCreated,
+		"release-approval-completed":   SubscriptionEventReleaseDeploymentApprovalCompleted,
+		"release-approval-pending":     SubscriptionEventReleaseDeploymentEventPending,
+		"release-deployment-completed": SubscriptionEventReleaseDeploymentCompleted,
+		"release-deployment-started":   SubscriptionEventReleaseDeploymentStarted,
+		"run-approval-completed":       SubscriptionEventRunStageApprovalCompleted,
+		"run-approval-pending":         SubscriptionEventRunStageWaitingForApproval,
+		"run-stage-changed":            SubscriptionEventRunStageStateChanged,
+		"run-state-changed":            SubscriptionEventRunStateChanged,
+	}
+
 	PipelineRequestUpdateEmoji = map[string]string{
 		PipelineRequestIDApproved: "&#9989;",
 		PipelineRequestIDRejected: "&#10060;",
@@ -2,48 +2,119 @@ package constants
 
 const (
 	// Generic
-	GenericErrorMessage            = "Something went wrong, please try again later"
-	SessionExpiredMessage          = "Session expired. Please connect your Azure DevOps account again"
-	ConnectAccount                 = "[Click here to connect your Azure DevOps account](%s%s)"
-	ConnectAccountFirst            = "Your Azure DevOps account is not connected \n%s"
-	UserConnected                  = "Your Azure DevOps account is successfully connected!"
-	MattermostUserAlreadyConnected = "Your Azure DevOps account is already connected"
-	UserDisconnected               = "Your Azure DevOps account is now disconnected"
-	CreatedTask                    = "Work item [#%d: \"%s\"](%s) of type \"%s\" was successfully created by %s."
-	TaskTitle                      = "[%s #%d: %s](%s)"
-	PullRequestTitle               = "[#%d: %s](%s)"
-	BuildDetailsTitle              = "[#%s](%s): %s"
-	PipelineDetailsTitle           = "[%s](%s): %s"
-	AlreadyLinkedProject           = "This project is already linked."
-	NoProjectLinked                = "No project is linked, please link a project."
-	PipelinesRequestBeingProcessed = "Your approval/rejection request is being processed."
-	PipelinesRequestProcessed      = "Your approval/rejection request is processed."
+	GenericErrorMessage              = "Something went wrong, please try again later"
+	SessionExpiredMessage            = "Session expired. Please connect your Azure DevOps account again"
+	ConnectAccount                   = "[Click here to connect your Azure DevOps account](%s%s)"
+	ConnectAccountFirst              = "Your Azure DevOps account is not connected \n%s"
+	UserConnected                    = "Your Azure DevOps account is successfully connected!"
+	UserConnectedWithOrganization    = "You can now link the projects of the organization **%s** using `/azuredevops link %s/%s/[project]`"
+	MattermostUserAlreadyConnected   = "Your Azure DevOps account is already connected"
+	OrganizationAlreadyConnected     = "Your Azure DevOps account is already connected to the organization **%s**"
+	OrganizationConnected            = "The organization **%s** is now connected with the Azure DevOps account %s. You can link its projects using `/azuredevops link %s/%s/[project]`"
+	DeviceCodeNotEnabled             = "Connecting with a device code is not enabled, please ask a system admin to set the device code client ID in the plugin settings or use `/azuredevops connect`"
+	DeviceCodeInstructions           = "To connect your Azure DevOps account, open %s on any device and enter the code **%s**. The code expires in %d minutes, you will get a direct message once your account is connected."
+	DeviceCodeAlreadyInProgress      = "Connecting with a device code is already in progress, please enter the code shown earlier or wait for it to expire"
+	DeviceCodeExpired                = "The device code to connect your Azure DevOps account has expired before it was entered, please run `/azuredevops connect-device` again"
+	DeviceCodeDeclined               = "Connecting your Azure DevOps account was declined"
+	UserDisconnected                 = "Your Azure DevOps account is now disconnected"
+	CreatedTask                      = "Work item [#%d: \"%s\"](%s) of type \"%s\" was successfully created by %s."
+	TaskTitle                        = "[%s #%d: %s](%s)"
+	PullRequestTitle                 = "[#%d: %s](%s)"
+	BuildDetailsTitle                = "[#%s](%s): %s"
+	PipelineDetailsTitle             = "[%s](%s): %s"
+	AlreadyLinkedProject             = "This project is already linked."
+	NoProjectLinked                  = "No project is linked, please link a project."
+	PipelinesRequestBeingProcessed   = "Your approval/rejection request is being processed."
+	PipelinesRequestProcessed        = "Your approval/rejection request is processed."
+	ReleaseApprovalAlreadyProcessed  = "This deployment approval pending request has already been processed."
+	ReleaseApprovalNotAllowed        = "You are not allowed to approve or reject this deployment."
+	ReleaseApprovalApprover          = "It can be approved by %s."
+	ReleaseApprovalLink              = "[Review the deployment in Azure DevOps](%s)"
+	RetryOperationQueued             = "Azure DevOps is currently unavailable. The request to %s is queued and will be retried automatically, you will be notified once it's completed."
+	RetryOperationSucceeded          = "The request to %s, which failed earlier because Azure DevOps was unavailable, is now completed."
+	RetryOperationFailed             = "The request to %s could not be completed after %d attempt(s): %s"
+	RetryOperationCreateTask         = "create the work item \"%s\""
+	RetryOperationCreateSubscription = "create the subscription for the event \"%s\" of project \"%s\""
+	EphemeralNotificationDM          = "Notification of your subscription in ~%s, sent here as you were offline"
+	TaskEstimatesNotSet              = "The estimate(s) %s were not set as they don't apply to work items of type \"%s\"."
 
 	// Validations Errors
-	OrganizationRequired            = "organization is required"
-	ProjectRequired                 = "project is required"
-	TaskTypeRequired                = "task type is required"
-	TaskTitleRequired               = "task title is required"
-	EventTypeRequired               = "event type is required"
-	ServiceTypeRequired             = "service type is required"
-	ChannelIDRequired               = "channel ID is required"
-	WebhookSecretRequired           = "webhook secret is required"
-	MMUserIDRequired                = "mattermsot user ID is required"
-	EmptyAzureDevopsAPIBaseURLError = "azure devops API base URL should not be empty"
-	EmptyAzureDevopsOAuthAppIDError = "azure devops OAuth app id should not be empty"
+	OrganizationRequired              = "organization is required"
+	ProjectRequired                   = "project is required"
+	TaskTypeRequired                  = "task type is required"
+	TaskTitleRequired                 = "task title is required"
+	TaskUpdateFieldsRequired          = "at least one field to update is required"
+	TaskCommentRequired               = "comment is required"
+	InvalidTaskID                     = "task ID should be a number"
+	DescriptionTooLong                = "description is too long (%d characters), the maximum allowed length is %d characters"
+	RequiredTaskFieldsMissing         = "the work item type %q requires %s"
+	InvalidTaskEstimate               = "%s should be a non-negative number"
+	EventTypeRequired                 = "event type is required"
+	ServiceTypeRequired               = "service type is required"
+	ChannelIDRequired                 = "channel ID is required"
+	SubscriptionLabelTooLong          = "label is too long (%d characters), the maximum allowed length is %d characters"
+	TooManyBranchFilters              = "too many branch filters (%d), the maximum allowed is %d"
+	InvalidSubscriptionVisibility     = "visibility %q should be \"channel\" or \"ephemeral\""
+	InvalidResourceVersion            = "resource version %q is not supported for the event type %q, the supported versions are %s"
+	InvalidMessageFormat              = "message format %q should be \"markdown\", \"text\" or \"html\""
+	InvalidBranchFilter               = "branch filter %q should be a glob pattern like \"release/*\", optionally prefixed with \"!\" to exclude the matching branches"
+	InvalidTruncationLength           = "maximum %s length of the notifications should not be negative"
+	InvalidBuildResult                = "build result %q should be one of %s"
+	WorkItemTypeFilterNotAllowed      = "work item type can only be set for the subscriptions of Boards events"
+	WebhookSecretRequired             = "webhook secret is required"
+	MMUserIDRequired                  = "mattermsot user ID is required"
+	InvalidAzureDevopsAPIBaseURLError = "azure devops API base URL should be an http or https URL like https://dev.azure.com or https://tfs.example.com/tfs"
+	InvalidAzureDevopsAPIVersionError = "azure devops API version should be like 6.0 or 7.1-preview.1"
+	EmptyAzureDevopsOAuthAppIDError   = "azure devops OAuth app id should not be empty"
 
 	// #nosec G101 -- This is a false positive. The below line is not a hardcoded credential
 	EmptyAzureDevopsOAuthClientSecretError = "azure devops OAuth client secret should not be empty"
 	EmptyEncryptionSecretError             = "encryption secret should not be empty"
 	ProjectIDRequired                      = "project ID is required"
+	InvalidDefaultOrganizationError        = "default organization should only contain letters, numbers and hyphens"
+	InvalidMaxDescriptionLengthError       = "maximum description length should not be negative"
+	InvalidMaxConcurrentRequestsError      = "maximum concurrent requests should be -1 or more"
+	InvalidMaxRequestRetriesError          = "maximum request retries should be between 0 and %d"
+	InvalidProjectListCacheTTLError        = "project list cache TTL should be between 0 and %d seconds"
+	InvalidMaxPerUserError                 = "maximum linked projects and subscriptions per user should not be negative"
+	InvalidWorkItemsBatchSizeError         = "work items batch size should not be negative or more than %d"
+	InvalidNotificationTruncationError     = "maximum title, description and comment lengths of the notifications should not be negative"
+	InvalidCoalescingWindowError           = "notification coalescing window should not be negative or more than %d seconds"
+	InvalidCACertificatesError             = "CA certificates should be a bundle of PEM encoded certificates: %s"
+	InvalidWebhookDeletionGracePeriodError = "webhook deletion grace period should not be negative or more than %d seconds"
+	InvalidReconciliationIntervalError     = "subscription reconciliation interval should not be negative or more than %d minutes"
+	InvalidWriteRequestRateLimitError      = "write request rate limit and burst should not be negative"
+	InvalidWebhookPathPrefixError          = "webhook path prefix should only contain letters, numbers, hyphens and underscores separated by slashes"
+	InvalidDeviceCodeTenantError           = "device code tenant should be a tenant ID, a domain name, \"organizations\" or \"common\""
+	InvalidRequiredTaskFieldsError         = "required task fields should be semicolon separated pairs of a work item type and comma separated fields like \"Bug=description,areaPath\", the fields can be title, description and areaPath, invalid pair %q"
+	InvalidDefaultChannelsError            = "organization default channels should be comma separated pairs of an organization and a channel ID like \"organization=channelID\", invalid pair %q"
+	DefaultChannelNotFoundError            = "default channel %q of organization %q does not exist"
+	InvalidNotificationEmojisError         = "notification emojis should be comma separated pairs of a status and an emoji like \"failed=❌\", invalid pair %q"
+	InvalidEventTypeAliasesError           = "event type aliases should be comma separated pairs of a lowercase alias and an event type like \"pr-created=git.pullrequest.created\", invalid pair %q"
+	EventTypeAliasCollisionError           = "event type alias %q is already used for the event type %q"
+	UnsupportedNotificationLanguageError   = "notification language %q is not supported, it should be one of %s"
 	FiltersRequired                        = "filters required"
+	TemplateNameRequired                   = "template name is required"
+	InvalidTemplateName                    = "template name should not contain any whitespace"
+	TemplateEventsRequired                 = "template should contain at least one event"
+	InvalidChannelPref                     = "unknown preference %q, it should be one of color, html, emoji, timezone, language, summary, summary-day and summary-hour"
+	InvalidChannelPrefColor                = "color should be a hex color like #0078d4"
+	InvalidChannelPrefBool                 = "%s should be true or false"
+	InvalidChannelPrefTimezone             = "unknown timezone %q, it should be like America/New_York"
+	InvalidChannelPrefDay                  = "unknown day %q, it should be a day of the week like monday"
+	InvalidChannelPrefHour                 = "invalid hour %q, it should be from 0 to 23"
+	InvalidChannelPrefLanguage             = "unsupported language %q, it should be one of %s"
+	InvalidTemplateEventType               = "event type %s is not supported"
 )
 
 const (
 	// Error messages
 	Error                                          = "Error"
 	NotAuthorized                                  = "Not authorized"
+	TooManyWriteRequests                           = "Too many requests, please try again in %d seconds"
 	UnableToDisconnectUser                         = "Unable to disconnect user"
+	ErrorRefreshAccessToken                        = "Error in refreshing the access token rejected by Azure DevOps"
+	ErrorNoRefreshToken                            = "no refresh token is stored for the user"
 	UnableToCheckIfAlreadyConnected                = "Unable to check if user account is already connected"
 	UnableToStoreOauthState                        = "Unable to store oAuth state for the userID %s"
 	UnableToCompleteOAuth                          = "Unable to complete oAuth"
@@ -51,8 +122,18 @@ const (
 	InvalidAuthState                               = "Invalid oauth state, please try again"
 	GetProjectListError                            = "Error in getting project list"
 	ErrorFetchProjectList                          = "Error in fetching project list"
+	ErrorFetchProcess                              = "Error in fetching the process of the project"
+	ErrorFetchProjectWorkItems                     = "Error in fetching the work items of the project"
+	ErrorFetchProjectPullRequests                  = "Error in fetching the pull requests of the project"
+	ErrorSearchProjectWorkItems                    = "Error in searching the work items of the project"
+	WorkItemSearchTextRequired                     = "Text to search the work items is required"
 	ErrorDecodingBody                              = "Error in decoding body"
 	ErrorCreateTask                                = "Error in creating task"
+	ErrorUpdateTask                                = "Error in updating task"
+	ErrorAddTaskComment                            = "Error in adding a comment to the task"
+	ErrorEchoTaskComment                           = "Error in echoing the comment of the task in its post"
+	TaskCommentEcho                                = "Comment added to the work item:\n%s"
+	ErrorStoreTaskPost                             = "Error in storing the post of the created task"
 	ErrorCreateSubscription                        = "Error in creating subscription"
 	ErrorLinkProject                               = "Error in linking the project"
 	FetchSubscriptionListError                     = "Error in fetching subscription list"
@@ -68,6 +149,7 @@ const (
 	ProjectNotFound                                = "Requested project does not exist"
 	ErrorUnlinkProject                             = "Error in unlinking the project"
 	InvalidChannelID                               = "Invalid channel ID"
+	ChannelNotFound                                = "channel does not exist"
 	DeleteSubscriptionError                        = "Error in deleting subscription"
 	GetChannelError                                = "Error in getting channels for team and user"
 	GetUserError                                   = "Error in getting Mattermost user details"
@@ -77,6 +159,14 @@ const (
 	ErrorMessageForAdmin                           = "There is no registered handler for the service hooks event type %s"
 	AccessDenied                                   = "Access Denied"
 	ErrorOrganizationOrProjectQueryParam           = "Invalid organization or project name"
+	InvalidConnectOrganization                     = "Organization name should only contain letters, numbers and hyphens"
+	ConnectOrganizationNotAllowed                  = "Only the organization %q can be used"
+	ErrorOrganizationNotConnected                  = "your Azure DevOps account is not connected to the organization %q, please run `/azuredevops connect %s` to connect it"
+	ErrorOrganizationConnectionExpired             = "the connection of the organization %q has expired, please run `/azuredevops connect %s` to connect it again"
+	ErrorRefreshOrganizationConnection             = "Error in refreshing the token of an organization connection"
+	ErrorLockScheduledJob                          = "Error in locking the scheduled job"
+	ErrorUnlockScheduledJob                        = "Error in unlocking the scheduled job"
+	ErrorScheduledJobLastFinished                  = "Error in getting or storing the last run of the scheduled job"
 	ErrorRepositoryPathParam                       = "Invalid organization, project or repository params"
 	ErrorInvalidOrganizationOrProject              = "Invalid organization or project name"
 	ErrorUpdatingPipelineApprovalRequest           = "Failed to update pipeline approval request"
@@ -85,4 +175,186 @@ const (
 	ErrorFetchSubscriptionFilterPossibleValues     = "Error in fetching subscription filter possible values"
 	ErrorUnauthorisedSubscriptionsWebhookRequest   = "missing or invalid webhook secret for subscriptions notification"
 	ErrorMessageAzureDevopsAccountAlreadyConnected = "azure devops account for %s is already connected"
+	ErrorNotATeamMember                            = "you are not a member of the requested team"
+	ErrorGetTeamMember                             = "Error in getting the team member"
+	ErrorNotAChannelMember                         = "you are not allowed to create subscription for the provided channel"
+	ErrorChannelTypeForSubscription                = "subscription can only be created for a public or private channel, not for a direct or group message"
+	ErrorArchivedChannelForSubscription            = "subscription can't be created for an archived channel"
+	ErrorNoPostPermissionForSubscription           = "you are not allowed to post in the provided channel"
+	ErrorMissingScope                              = "your connection lacks %s (granted scopes: %s), please reconnect your Azure DevOps account"
+	ErrorFetchSubscriptionTemplates                = "Error in fetching subscription templates"
+	ErrorStoreSubscriptionTemplate                 = "Error in storing subscription template"
+	ErrorDeleteSubscriptionTemplate                = "Error in deleting subscription template"
+	SubscriptionTemplateNotFound                   = "Subscription template %q does not exist"
+	ProjectNotLinkedWithName                       = "Project %q is not linked, please link it first"
+	InvalidWorkItemID                              = "Invalid work item ID %q"
+	UnknownEventTypeAlias                          = "Unknown event type alias %q, valid aliases are %s"
+	WorkItemNotFound                               = "Work item %s does not exist in project %q"
+	DeleteWorkItemConfirmation                     = "Are you sure you want to delete this work item? It will be moved to the recycle bin of project %q, from where it can be restored."
+	DestroyWorkItemConfirmation                    = "Are you sure you want to permanently destroy this work item? It can't be restored."
+	WorkItemDeleted                                = "Work item %d has been moved to the recycle bin."
+	WorkItemDestroyed                              = "Work item %d has been permanently destroyed."
+	WorkItemDeleteCanceled                         = "Deleting work item %d has been canceled."
+	WorkItemDeleteForbidden                        = "You are not allowed to delete work item %d. Deleting a work item requires the \"Delete and restore work items\" permission in Azure DevOps, and destroying it also requires the \"Permanently delete work items\" permission."
+	ErrorDeleteWorkItem                            = "Error in deleting the work item"
+	WorkItemRestored                               = "Work item %d has been restored."
+	WorkItemNotInRecycleBin                        = "Work item %s is not in the recycle bin of project %q. Deleted work items are destroyed permanently once they have been in the recycle bin for longer than its retention period, after which they can't be restored."
+	WorkItemRestoreForbidden                       = "You are not allowed to restore work item %s. Restoring a work item requires the \"Delete and restore work items\" permission in Azure DevOps."
+	RecycleBinEmpty                                = "The recycle bin of project %q is empty"
+	ErrorRestoreWorkItem                           = "Error in restoring the work item"
+	ErrorFetchRecycleBin                           = "Error in fetching the recycle bin"
+	ErrorFetchWorkItemDetails                      = "Error in fetching the work item details"
+	NoWorkItemBlockers                             = "Work item %s is not blocked by any work item"
+	WorkItemBlockersOpen                           = "%d of the %d blocking work item(s) are still open"
+	WorkItemBlockersDone                           = "All the %d blocking work item(s) are done"
+	WorkItemBlockersMaxDepth                       = "Only the blockers up to %d levels deep are shown"
+	WorkItemBlockersMaxCount                       = "Only the first %d blocking work items are shown"
+	WorkItemBlockersCircular                       = "The dependencies are circular, work item %d is blocked by a work item it blocks"
+	ErrorFetchWorkItemBlockers                     = "Error in fetching the blocking work items"
+	NoCurrentSprint                                = "No current sprint is found for the team, please check the team name and its sprint settings"
+	ErrorFetchSprintSummary                        = "Error in fetching the sprint summary"
+	SharedQueryNotFound                            = "Query %q does not exist in project %q"
+	MultipleSharedQueriesWithName                  = "Multiple queries are named %q, please use the path of one of them instead:\n%s"
+	NoSharedQueryResults                           = "Query %q did not return any work items"
+	InvalidSharedQueryPage                         = "Invalid page %q"
+	SharedQueryPageNotFound                        = "Page %d does not exist, the query has %d page(s)"
+	ErrorFetchSharedQueryResults                   = "Error in fetching the query results"
+	InvalidDefaultQuery                            = "Invalid default query: %s"
+	DefaultQueryNotFlat                            = "only flat queries of work items are supported, the query should select FROM WorkItems"
+	WIQLErrorClause                                = "%s (in `%s`)"
+	DefaultQuerySet                                = "Default query of project %q is set"
+	DefaultQueryReset                              = "Default query of project %q is reset to your active work items"
+	NoDefaultQueryResults                          = "Default query of project %q did not return any work items"
+	ErrorRunDefaultQuery                           = "Error in running the default query"
+	ErrorSetDefaultQuery                           = "Error in setting the default query"
+	ErrorFetchChannelPrefs                         = "Error in fetching the notification preferences of the channel"
+	ErrorStoreChannelPrefs                         = "Error in storing the notification preferences of the channel"
+	ChannelPrefsNotAllowed                         = "Only the users who can manage this channel can change its notification preferences"
+	ChannelPrefUpdated                             = "Notification preference %q of this channel is updated"
+	WeeklySummaryTitle                             = "###### Weekly Azure DevOps summary"
+	WeeklySummaryNotifications                     = "%d notification(s) were posted in this channel from %s to %s"
+	WeeklySummaryNoNotifications                   = "No notifications were posted in this channel from %s to %s"
+	ErrorPostWeeklySummary                         = "Error in posting the weekly summary of the channel"
+	PushNoCommits                                  = "None"
+	PushNoNewCommits                               = "No new commits, the branch now points to [%s](%s)"
+	PushBranchDeleted                              = "The branch was deleted"
+	PushMergeCommit                                = " (merge commit)"
+	PushCommitAuthor                               = " by %s"
+	PushViewAllCommits                             = "[View all %d commits](%s)"
+	PushMoreCommits                                = "…and %d more commit(s)"
+	NoProjectSubscriptions                         = "No subscriptions created by you exist for project %q"
+	ChannelNotFoundWithName                        = "Channel %q does not exist in this team"
+	ErrorDeleteProjectSubscriptions                = "Error in deleting the subscriptions of the project"
+	NoOpenPullRequests                             = "You don't have any open pull requests in %s"
+	ErrorFetchPullRequests                         = "Error in fetching the pull requests"
+	ProjectNotLinkedByAnyUser                      = "Project %q is not linked by any user"
+	ErrorProjectAccessPermission                   = "Only system admins and users who have linked project %q can view who has access to it"
+	ErrorFetchProjectAccess                        = "Error in fetching the users who have linked the project"
+	MultipleProjectsWithName                       = "Project %q is linked for multiple organizations, please specify it as organization/project"
+	SubscriptionNotFoundWithID                     = "Subscription %q does not exist"
+	ErrorLastNotificationPermission                = "Only the members of the channel of subscription %q can view its notifications"
+	NoLastNotification                             = "Subscription %q has not sent any notifications yet"
+	ErrorFetchLastNotification                     = "Error in fetching the last notification of the subscription"
+	SubscriptionsPaused                            = "The notifications of the subscriptions you created are paused until %s. The notifications of the subscriptions created by other users are still posted in their channels. Run `/azuredevops subscriptions resume` to resume them earlier."
+	SubscriptionsResumed                           = "The notifications of the subscriptions you created are resumed"
+	SubscriptionsNotPaused                         = "The notifications of the subscriptions you created are not paused"
+	InvalidPauseDuration                           = "Invalid duration %q, it should be like 30m, 4h or 2d and at most 30 days"
+	ErrorPauseSubscriptions                        = "Error in pausing the notifications of the subscriptions"
+	ErrorResumeSubscriptions                       = "Error in resuming the notifications of the subscriptions"
+	ErrorDiagnosticsPermission                     = "Only system admins can run the plugin diagnostics"
+	ErrorConnectionsPermission                     = "Only system admins can view the connections of the users"
+	NoConnectedUsers                               = "No users have connected their Azure DevOps accounts"
+	ConnectionsPageNotFound                        = "Page %d does not exist, the report has %d page(s)"
+	ErrorFetchConnections                          = "Error in fetching the connections of the users"
+	ErrorDeliveryLogPermission                     = "Only system admins can view the notifications delivered to the channels"
+	NoDeliveryLog                                  = "No notifications have been delivered to ~%s yet"
+	ErrorFetchDeliveryLog                          = "Error in fetching the notifications delivered to the channel"
+	NoDuplicateProjects                            = "None of your linked projects are duplicated"
+	SubscriptionsRepointed                         = "%d of your subscription(s) now refer to the kept projects"
+	ErrorDedupeProjects                            = "Error in merging the duplicate projects"
+	NoLinkedProjects                               = "You haven't linked any projects yet. Link a project with `/azuredevops link [projectURL]` or from the Azure DevOps sidebar."
+	LinkedProjectsTitle                            = "###### Your linked projects"
+	ErrorListProjects                              = "Error in listing the linked projects"
+	InvalidProjectActivityHours                    = "Invalid number of hours %q, it should be between 1 and %d"
+	NoProjectActivity                              = "No activity is found in project %q in the last %d hour(s)"
+	ProjectActivityTitle                           = "###### Activity in project %q in the last %d hour(s)"
+	ProjectActivityWorkItem                        = "[%s %d](%s): %s was changed to %s by %s"
+	ProjectActivityPullRequest                     = "Pull request [!%d: %s](%s) in %s was %s by %s"
+	ProjectActivityPush                            = "%s pushed to %s in [%s](%s)"
+	ProjectActivityMaxCount                        = "Only the latest %d of the %d activities are shown"
+	ProjectActivitySourceFailed                    = "The %s of the project could not be fetched"
+	ErrorFetchProjectActivity                      = "Error in fetching the activity of the project"
+	NoRenamedProjects                              = "All your linked projects already have their current names"
+	ProjectsRenamed                                = "%d of your linked project(s) were renamed and %d of your subscription(s) now refer to their current names"
+	SyncProjectsNotFound                           = "The following projects no longer exist or you can't access them anymore, you can unlink them from the Azure DevOps sidebar:"
+	SyncProjectsFailed                             = "The names of the following projects could not be fetched, please try again later:"
+	ErrorFetchProjectName                          = "Error in fetching the current name of the project"
+	ErrorSyncProjects                              = "Error in syncing the names of the linked projects"
+	AutoLinkProjectFailed                          = "Project %q is not linked and could not be linked: %s"
+	ErrorAutoLinkProject                           = "Error in linking the project of the subscription"
+	LinkedProjectsLimitReached                     = "You can't link more than %d projects, please unlink a project before linking another one"
+	SubscriptionsLimitReached                      = "You can't create more than %d subscriptions, please delete a subscription before creating another one"
+	SameMoveChannels                               = "The subscriptions can't be moved to the channel they are in"
+	MoveSubscriptionsNotAllowed                    = "You can't move the subscriptions to ~%s as you can't post in it"
+	NoSubscriptionsToMove                          = "You don't have any subscriptions in ~%s"
+	SubscriptionsMoved                             = "%d of %d subscription(s) moved to ~%s and %d deleted as they were already present in it"
+	ErrorMoveSubscriptions                         = "Error in moving the subscriptions"
+	ErrorMoveSubscription                          = "Error in moving the subscription"
+	ResetUserConfirmation                          = "Are you sure you want to reset your Azure DevOps plugin state? This can't be undone."
+	NothingToReset                                 = "You don't have any Azure DevOps plugin state to reset"
+	ResetUserCanceled                              = "Resetting your Azure DevOps plugin state has been canceled."
+	ResetUserCompleted                             = "Your Azure DevOps plugin state has been reset."
+	ResetUserWebhooksNotDeleted                    = "The webhooks of subscription(s) %s could not be deleted in Azure DevOps, please delete them from the service hooks of their projects."
+	ErrorResetUser                                 = "Error in resetting the plugin state of the user"
+	DisconnectUserConfirmation                     = "Are you sure you want to disconnect your Azure DevOps account? Your subscriptions and linked projects are kept, connect your account again to manage them."
+	NothingToDisconnect                            = "You don't have any Azure DevOps account connected"
+	DisconnectUserCanceled                         = "Disconnecting your Azure DevOps account has been canceled."
+	CommandPermissionsTitle                        = "#### Permissions required to run the commands"
+	CommandPermissionDenied                        = "You can't run this command as it requires the following permission: %s"
+	PermissionNone                                 = "None"
+	PermissionConnectedAccount                     = "Connected Azure DevOps account"
+	PermissionSystemAdmin                          = "System admin"
+	PermissionManageChannel                        = "Manage the current channel"
+	PermissionPostInTargetChannel                  = "Post in the channel the subscriptions are moved to"
+	PermissionProjectAccess                        = "System admin or linked the project"
+	NotificationWithoutSubscription                = "The subscription which produced this post is not known, the post may have been created before the subscriptions were recorded in the notifications."
+	NotificationSubscriptionDeleted                = "This notification was produced by the subscription `%s`, which has been deleted since."
+	NotificationSubscriptionDetails                = "This notification was produced by the subscription `%s`:\n* Project: %s (%s)\n* Event type: %s\n* Created by: %s\n* Created at: %s"
+	BuildQueued                                    = "Build %s of %s has been queued on `%s`."
+	BuildQueueForbidden                            = "You are not allowed to re-run the builds of %s. Queuing a build requires the \"Queue builds\" permission on its pipeline in Azure DevOps."
+	BuildDefinitionNotFound                        = "The pipeline of the build is not found, it may have been deleted."
+	ErrorQueueBuild                                = "Error in queuing the build"
+	ErrorNotificationSubscription                  = "Error in fetching the subscription which produced the notification"
+	WorkItemFetchFailed                            = "_Error in fetching the work item_"
+	ErrorFetchSprintWorkItems                      = "Error in fetching some of the work items of the sprint"
+	DiagnosticCheckOAuthSettings                   = "OAuth settings"
+	DiagnosticCheckEncryptionSecret                = "Encryption secret"
+	DiagnosticCheckSiteURL                         = "Site URL"
+	DiagnosticCheckBotAccount                      = "Bot account"
+	DiagnosticCheckAuthenticatedCall               = "Authenticated call"
+	DiagnosticMissingOAuthSettings                 = "%s not set"
+	DiagnosticMissingOAuthSettingsHint             = "Set them in **System Console > Plugins > Azure DevOps**"
+	DiagnosticOAuthCallbackURL                     = "Callback URL is `%s`"
+	DiagnosticOAuthCallbackURLHint                 = "Make sure it's the callback URL of the OAuth app registered in Azure DevOps"
+	DiagnosticEncryptionSecretConfigured           = "Configured"
+	DiagnosticMissingEncryptionSecret              = "Not set"
+	DiagnosticMissingEncryptionSecretHint          = "Regenerate it in **System Console > Plugins > Azure DevOps**"
+	DiagnosticMissingSiteURL                       = "Not set"
+	DiagnosticMissingSiteURLHint                   = "Set it in **System Console > Environment > Web Server**"
+	DiagnosticSiteURLReachable                     = "`%s` is reachable"
+	DiagnosticSiteURLUnreachable                   = "`%s` is not reachable from the Mattermost server: %s"
+	DiagnosticSiteURLUnreachableHint               = "Make sure the Site URL is correct, the OAuth callback and the subscription notifications are sent to it"
+	DiagnosticBotAccountHealthy                    = "@%s is active"
+	DiagnosticBotAccountNotFound                   = "The bot account is not found: %s"
+	DiagnosticBotAccountNotFoundHint               = "Disable and enable the plugin to recreate it"
+	DiagnosticBotAccountDeactivated                = "@%s is deactivated"
+	DiagnosticBotAccountDeactivatedHint            = "Enable it in **System Console > Integrations > Bot Accounts**"
+	DiagnosticUserNotConnected                     = "Your account is not connected"
+	DiagnosticUserNotConnectedHint                 = "Connect it with `/azuredevops connect` to check the calls to Azure DevOps"
+	DiagnosticInvalidAccessToken                   = "Your access token could not be decrypted: %s"
+	DiagnosticInvalidAccessTokenHint               = "Reconnect your account, the token may have been stored with a previous encryption secret"
+	DiagnosticAuthenticatedCallSucceeded           = "Connected as %s"
+	DiagnosticAuthenticatedCallFailed              = "Fetching your Azure DevOps profile failed with status %d: %s"
+	DiagnosticAuthenticatedCallUnauthorizedHint    = "Reconnect your account, the access token may be expired or revoked"
+	DiagnosticAuthenticatedCallFailedHint          = "Check that Azure DevOps is reachable from the Mattermost server"
 )
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 const (
 	ResponseType        = "Assertion"
 	Scopes              = "vso.build_execute vso.code_full vso.release_manage vso.work_full"
@@ -9,6 +11,11 @@ const (
 
 	// URL
 	BaseOauthURL = "https://app.vssps.visualstudio.com"
+	// Base URL of the API of Azure DevOps Services, used when the base URL of an Azure DevOps Server is not configured
+	DefaultAzureDevopsAPIBaseURL = "https://dev.azure.com"
+	// Hosts of Azure DevOps Services, the organizations created before dev.azure.com are served from subdomains of visualstudio.com
+	AzureDevopsServicesHost             = "dev.azure.com"
+	AzureDevopsServicesLegacyHostSuffix = ".visualstudio.com"
 
 	// Paths
 	PathAuth = "/oauth2/authorize"
@@ -17,4 +24,46 @@ const (
 	PathUserProfile = "/_apis/profile/profiles/%s"
 
 	CurrentAzureDevopsUserProfileID = "me"
+
+	// Device code flow of Microsoft Entra ID, used to connect without the browser redirect
+	BaseDeviceCodeOAuthURL = "https://login.microsoftonline.com"
+	PathDeviceCode         = "/%s/oauth2/v2.0/devicecode"
+	// #nosec G101 -- This is a false positive
+	PathDeviceCodeToken = "/%s/oauth2/v2.0/token"
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+	// Scope of the Azure DevOps resource, along with "offline_access" to get a refresh token
+	DeviceCodeScopes                = "499b84ac-1321-427f-aa17-267ca6975798/.default offline_access"
+	DeviceCodeDefaultTenant         = "organizations"
+	DeviceCodeDefaultInterval       = 5 * time.Second
+	DeviceCodeSlowDownInterval      = 5 * time.Second
+	DeviceCodeAuthorizationPending  = "authorization_pending"
+	DeviceCodeSlowDown              = "slow_down"
+	DeviceCodeExpiredToken          = "expired_token"
+	DeviceCodeAuthorizationDeclined = "authorization_declined"
+	DeviceCodeAccessDenied          = "access_denied"
+	AuthTypeDeviceCode              = "device_code"
+
+	// Scopes
+	ScopeBuild          = "vso.build"
+	ScopeBuildExecute   = "vso.build_execute"
+	ScopeCode           = "vso.code"
+	ScopeCodeWrite      = "vso.code_write"
+	ScopeCodeFull       = "vso.code_full"
+	ScopeRelease        = "vso.release"
+	ScopeReleaseExecute = "vso.release_execute"
+	ScopeReleaseManage  = "vso.release_manage"
+	ScopeWork           = "vso.work"
+	ScopeWorkWrite      = "vso.work_write"
+	ScopeWorkFull       = "vso.work_full"
 )
+
+// ImpliedScopes contains the scopes which are also granted along with a broader scope
+var ImpliedScopes = map[string][]string{
+	ScopeBuildExecute:   {ScopeBuild},
+	ScopeCodeWrite:      {ScopeCode},
+	ScopeCodeFull:       {ScopeCode, ScopeCodeWrite},
+	ScopeReleaseExecute: {ScopeRelease},
+	ScopeReleaseManage:  {ScopeRelease, ScopeReleaseExecute},
+	ScopeWorkWrite:      {ScopeWork},
+	ScopeWorkFull:       {ScopeWork, ScopeWorkWrite},
+}
@@ -6,19 +6,37 @@ const (
 	WildRoute                               = "{anything:.*}"
 	PathOAuthConnect                        = "/oauth/connect"
 	PathOAuthCallback                       = "/oauth/complete"
+	PathMattermostPing                      = "/api/v4/system/ping"
 	PathLinkedProjects                      = "/project/link"
 	PathGetAllLinkedProjects                = "/project/link"
 	PathUnlinkProject                       = "/project/unlink"
+	PathUnlinkAllProjects                   = "/project/unlink/all"
 	PathUser                                = "/user"
 	PathCreateTasks                         = "/tasks"
+	PathUpdateTask                          = "/tasks/{task_id}"
+	PathAddTaskComment                      = "/tasks/{task_id}/comments"
 	PathLinkProject                         = "/link"
 	PathSubscriptions                       = "/subscriptions"
 	PathGetSubscriptions                    = "/subscriptions/{team_id:[A-Za-z0-9]+}/{organization:[A-Za-z0-9-]+}/{project:.+}"
 	PathSubscriptionNotifications           = "/notification"
+	PathPrefixedSubscriptionNotifications   = "/{webhook_prefix:.+}/notification"
 	PathPipelineReleaseRequest              = "/pipeline-release-request"
 	PathPipelineRunRequest                  = "/pipeline-run-request"
 	PathGetSubscriptionFilterPossibleValues = "/subscriptions/filters"
 	PathPipelineCommentModal                = "/pipeline-comment-modal"
+	PathGetUserChannels                     = "/channels"
+	PathGetUserChannelsForTeam              = "/channels/{team_id:[A-Za-z0-9]+}"
+	PathSubscriptionTemplates               = "/subscription-templates"
+	PathDeleteSubscriptionTemplate          = "/subscription-templates/{template_name:[^/]+}"
+	PathDeleteWorkItem                      = "/workitems/delete"
+	PathResetUser                           = "/reset"
+	PathDisconnectUser                      = "/disconnect"
+	PathNotificationSubscription            = "/notifications/subscription"
+	PathRerunBuild                          = "/notifications/rerun-build"
+	PathGetProjectProcess                   = "/project/{organization:[A-Za-z0-9-]+}/{project_id:[A-Za-z0-9-]+}/process"
+	PathGetProjectWorkItems                 = "/project/{project_id:[A-Za-z0-9-]+}/workitems"
+	PathGetProjectPullRequests              = "/project/{project_id:[A-Za-z0-9-]+}/pullrequests"
+	PathSearchProjectWorkItems              = "/project/{project_id:[A-Za-z0-9-]+}/search"
 
 	// Mattermost API paths
 	PathOpenCommentModal = "/api/v4/actions/dialogs/open"
@@ -26,16 +44,46 @@ const (
 	// Azure API paths
 	CreateTask                          = "/%s/%s/_apis/wit/workitems/$%s?api-version=7.1-preview.3"
 	GetTask                             = "%s/%s/_apis/wit/workitems/%s?api-version=7.1-preview.3"
+	AddWorkItemComment                  = "/%s/%s/_apis/wit/workItems/%s/comments?format=markdown&api-version=7.1-preview.4"
+	UpdateTask                          = "/%s/%s/_apis/wit/workitems/%s?api-version=7.1-preview.3"
+	GetWorkItem                         = "/%s/%s/_apis/wit/workitems/%s?$expand=relations&api-version=7.1-preview.3"
+	GetWorkItemExpanded                 = "/%s/%s/_apis/wit/workitems/%d?$expand=all&api-version=7.1-preview.3"
+	DeleteWorkItem                      = "/%s/%s/_apis/wit/workitems/%d?destroy=%t&api-version=7.1-preview.3"
+	GetRecycleBin                       = "/%s/%s/_apis/wit/recyclebin?api-version=7.1-preview.2"
+	GetRecycleBinWorkItems              = "/%s/%s/_apis/wit/recyclebin?ids=%s&api-version=7.1-preview.2"
+	RestoreWorkItem                     = "/%s/%s/_apis/wit/recyclebin/%d?api-version=7.1-preview.2"
 	GetPullRequest                      = "%s/%s/_apis/git/pullrequests/%s?api-version=6.0"
+	GetPullRequestsByCreator            = "/%s/%s/_apis/git/pullrequests?searchCriteria.creatorId=%s&searchCriteria.status=active&$top=%d&api-version=6.0"
+	GetProjectPullRequests              = "/%s/%s/_apis/git/pullrequests?searchCriteria.status=all&$top=%d&api-version=6.0"
+	GetActivePullRequests               = "/%s/%s/_apis/git/pullrequests?searchCriteria.status=active&$top=%d&api-version=6.0"
+	GetRepositoryActivePullRequests     = "/%s/%s/_apis/git/repositories/%s/pullrequests?searchCriteria.status=active&$top=%d&api-version=6.0"
+	GetPullRequestWorkItems             = "/%s/%s/_apis/git/repositories/%s/pullRequests/%d/workitems?api-version=6.0"
 	GetBuildDetails                     = "%s/%s/_apis/build/builds/%s?api-version=6.0"
+	QueueBuild                          = "%s/%s/_apis/build/builds?api-version=6.0"
 	GetReleaseDetails                   = "%s/%s/_apis/release/releases/%s?api-version=6.0"
 	GetGitRepositories                  = "%s/%s/_apis/git/repositories?api-version=6.0"
+	GetGitRepository                    = "/%s/%s/_apis/git/repositories/%s?api-version=6.0"
+	GetPushes                           = "/%s/%s/_apis/git/repositories/%s/pushes?searchCriteria.fromDate=%s&searchCriteria.includeRefUpdates=true&$top=%d&api-version=6.0"
 	GetGitRepositoryBranches            = "%s/%s/_apis/git/repositories/%s/refs?filter=heads"
 	GetSubscriptionFilterPossibleValues = "%s/_apis/hooks/inputValuesQuery?api-version=6.0"
 	PipelineApproveRequest              = "%s/%s/_apis/release/approvals/%d?api-version=6.0"
 	PipelineRunApproveDetails           = "/%s/%s/_apis/pipelines/approvals/%s?$expand=steps&api-version=7.0-preview.1"
 	PipelineRunApproveRequest           = "%s/%s/_apis/pipelines/approvals?api-version=7.0-preview.1"
 	GetProject                          = "/%s/_apis/projects/%s?api-version=7.1-preview.4"
+	GetWorkItemTypeStates               = "/%s/%s/_apis/wit/workitemtypes/%s/states?api-version=7.1-preview.1"
+	GetProjectCapabilities              = "/%s/_apis/projects/%s?includeCapabilities=true&api-version=7.1-preview.4"
+	GetProcess                          = "/%s/_apis/work/processes/%s?api-version=7.1-preview.2"
+	GetProcessWorkItemTypes             = "/%s/_apis/work/processes/%s/workitemtypes?api-version=7.1-preview.2"
+	QueryWorkItems                      = "/%s/%s/_apis/wit/wiql?timePrecision=true&api-version=7.1-preview.2"
+	ValidateWIQL                        = "/%s/%s/_apis/wit/wiql?$top=1&api-version=7.1-preview.2"
+	GetWorkItemsBatch                   = "/%s/%s/_apis/wit/workitemsbatch?api-version=7.1-preview.1"
+	SearchWorkItems                     = "/%s/%s/_apis/search/workitemsearchresults?api-version=7.1-preview.1"
+	GetCurrentIteration                 = "/%s/%s/_apis/work/teamsettings/iterations?$timeframe=current&api-version=7.1-preview.1"
+	GetQueries                          = "/%s/%s/_apis/wit/queries?$filter=%s&$top=%d&api-version=7.1-preview.2"
+	RunSavedQuery                       = "/%s/%s/_apis/wit/wiql/%s?$top=%d&api-version=7.1-preview.2"
 	CreateSubscription                  = "/%s/_apis/hooks/subscriptions?api-version=6.0"
 	DeleteSubscription                  = "/%s/_apis/hooks/subscriptions/%s?api-version=6.0"
+	GetSubscription                     = "/%s/_apis/hooks/subscriptions/%s?api-version=6.0"
+	UpdateSubscription                  = "/%s/_apis/hooks/subscriptions/%s?api-version=6.0"
+	ListSubscriptions                   = "/%s/_apis/hooks/subscriptions?consumerId=%s&api-version=6.0"
 )
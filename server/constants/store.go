@@ -3,11 +3,55 @@ package constants
 import "time"
 
 const (
-	AtomicRetryLimit                     = 5
-	AtomicRetryWait                      = 30 * time.Millisecond
-	TTLSecondsForOAuthState        int64 = 60
-	TokenExpiryTimeBufferInMinutes       = 5
-	UsersPerPage                         = 100
+	AtomicRetryLimit                      = 5
+	AtomicRetryWait                       = 30 * time.Millisecond
+	TTLSecondsForOAuthState         int64 = 60
+	TokenExpiryTimeBufferInMinutes        = 5
+	ConnectionExpiringSoonDuration        = 24 * time.Hour
+	UsersPerPage                          = 100
+	TTLSecondsForNotificationThread int64 = 7 * 24 * 60 * 60
+	TTLSecondsForNotificationBurst  int64 = 60
+	TTLSecondsForDeviceCodeFlow     int64 = 15 * 60
+	TTLSecondsForTaskPost           int64 = 90 * 24 * 60 * 60
+	LastNotificationMaxSize               = 256 * 1024
+	ProcessCacheDuration                  = time.Hour
+	WorkItemTypeStatesCacheMaxSize        = 1000
+	WorkItemTypeStatesCacheTTL            = time.Hour
+	ProjectListCacheMaxSize               = 1000
+	ProjectListCacheMaxTTL                = 3600
+	DeliveryLogMaxEntries                 = 100
+
+	// Scheduled jobs run on one server of the cluster at a time, a server which stopped while running a job frees it once its lock expires
+	TTLSecondsForJobLock  int64 = 15 * 60
+	ScheduledJobLockRetry       = time.Minute
+
+	// Retry queue configs
+	RetryQueueMaxSize        = 100
+	RetryQueueMaxAttempts    = 5
+	RetryQueueInitialBackoff = time.Minute
+	RetryQueueJobInterval    = time.Minute
+
+	// Requests sent to Azure DevOps at the same time
+	DefaultMaxConcurrentRequests = 10
+	RequestLimiterMaxWait        = 30 * time.Second
+
+	// Retries of the requests to Azure DevOps failing due to a transient error
+	MaxRequestRetriesLimit     = 10
+	RequestRetryInitialBackoff = 500 * time.Millisecond
+	RequestRetryMaxBackoff     = 30 * time.Second
+	RequestRetriesTimeout      = 5 * time.Minute
+
+	WeeklySummaryJobInterval = 10 * time.Minute
+
+	// Webhooks of the deleted subscriptions waiting for the end of the grace period
+	PendingWebhookDeletionsMaxSize     = 100
+	PendingWebhookDeletionsJobInterval = time.Minute
+
+	// Token buckets of the write requests, the ones which have been refilled are removed once per interval
+	WriteRateLimiterSweepInterval = 10 * time.Minute
+
+	// The reconciliation of the subscriptions checks whether it's enabled again after this interval while it's disabled
+	SubscriptionReconciliationDisabledJobInterval = 10 * time.Minute
 
 	// KV store prefix keys
 	OAuthPrefix           = "oAuth_%s"
@@ -16,4 +60,26 @@ const (
 	SubscriptionPrefix    = "subscription_list"
 	UserIDPrefix          = "oAuth"
 	AzureDevOpsUserPrefix = "azd_userID_%s"
+	TemplatePrefix        = "subscription_templates_%s"
+	RetryQueueKey         = "retry_queue"
+	RetryQueueJobKey      = "retry_queue_job"
+	ChannelPrefsPrefix    = "channel_notification_prefs_%s"
+	NotificationThreadKey = "notification_thread_%s_%s_%d"
+	NotificationBurstKey  = "notification_burst_%s"
+	CoalescedPostKey      = "coalesced_post_%s_%s_%s"
+	DeviceCodeFlowKey     = "device_code_flow_%s"
+	LastNotificationKey   = "last_notification_%s"
+	WeeklySummaryKey      = "weekly_summary_%s"
+	WeeklySummaryJobKey   = "weekly_summary_job"
+	DeliveryLogKey        = "delivery_log_%s"
+	SubscriptionsPauseKey = "subscriptions_pause_%s"
+	TaskPostKey           = "task_post_%s_%s_%s"
+
+	PendingWebhookDeletionsKey    = "pending_webhook_deletions"
+	PendingWebhookDeletionsJobKey = "pending_webhook_deletions_job"
+
+	SubscriptionReconciliationJobKey = "subscription_reconciliation_job"
+
+	JobLockKey         = "job_lock_%s"
+	JobLastFinishedKey = "job_last_finished_%s"
 )
@@ -0,0 +1,88 @@
+// Package i18n translates the static texts of the subscription notifications, the contents coming from Azure DevOps are never translated.
+// The English texts are used as the message IDs, and every other language is a JSON file in the locales directory
+// mapping the English texts to their translations, so a language is added without changing the code.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs contains the translations of the messages mapped by their locales, English has no catalog as its messages are the message IDs
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	fileNames, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	catalogs := map[string]map[string]string{}
+	for _, fileName := range fileNames {
+		data, err := localeFiles.ReadFile(path.Join("locales", fileName.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("invalid catalog %s: %s", fileName.Name(), err.Error()))
+		}
+		catalogs[strings.ToLower(strings.TrimSuffix(fileName.Name(), path.Ext(fileName.Name())))] = messages
+	}
+
+	return catalogs
+}
+
+// Localizer translates the messages into a single language
+type Localizer struct {
+	messages map[string]string
+}
+
+// NewLocalizer returns the localizer of a locale, the messages are not translated for English or an unsupported locale
+func NewLocalizer(locale string) *Localizer {
+	return &Localizer{
+		messages: catalogs[strings.ToLower(locale)],
+	}
+}
+
+// Localize returns the translation of a message, or the message itself if it has not been translated
+func (l *Localizer) Localize(message string) string {
+	if translation, ok := l.messages[message]; ok && translation != "" {
+		return translation
+	}
+
+	return message
+}
+
+// Localizef translates a format string before formatting it with the given arguments
+func (l *Localizer) Localizef(format string, args ...interface{}) string {
+	return fmt.Sprintf(l.Localize(format), args...)
+}
+
+// IsSupportedLocale checks if the notifications can be rendered in a locale
+func IsSupportedLocale(locale string) bool {
+	locale = strings.ToLower(locale)
+	_, ok := catalogs[locale]
+	return ok || locale == constants.DefaultNotificationLocale
+}
+
+// GetSupportedLocales returns the sorted list of the locales the notifications can be rendered in
+func GetSupportedLocales() []string {
+	locales := []string{constants.DefaultNotificationLocale}
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+func TestLocalizer(t *testing.T) {
+	t.Run("Localizer: message is translated", func(t *testing.T) {
+		assert.Equal(t, "Ziel-Branch", NewLocalizer("de").Localize("Target Branch"))
+		assert.Equal(t, "Rama de destino", NewLocalizer("ES").Localize("Target Branch"))
+	})
+
+	t.Run("Localizer: format is translated before formatting", func(t *testing.T) {
+		assert.Equal(t, "Genehmigende (beliebige 2)", NewLocalizer("de").Localizef("Approvers (any %d)", 2))
+	})
+
+	t.Run("Localizer: English message is used for a missing translation", func(t *testing.T) {
+		assert.Equal(t, "mock message", NewLocalizer("de").Localize("mock message"))
+	})
+
+	t.Run("Localizer: messages are not translated for English or an unsupported locale", func(t *testing.T) {
+		assert.Equal(t, "Target Branch", NewLocalizer(constants.DefaultNotificationLocale).Localize("Target Branch"))
+		assert.Equal(t, "Target Branch", NewLocalizer("xx").Localize("Target Branch"))
+	})
+}
+
+func TestSupportedLocales(t *testing.T) {
+	assert.Equal(t, []string{"de", "en", "es"}, GetSupportedLocales())
+	assert.True(t, IsSupportedLocale("en"))
+	assert.True(t, IsSupportedLocale("DE"))
+	assert.False(t, IsSupportedLocale("xx"))
+	assert.False(t, IsSupportedLocale(""))
+}
+
+func TestCatalogs(t *testing.T) {
+	verbRegex := regexp.MustCompile(`%[a-z]`)
+	for locale, messages := range catalogs {
+		for message, translation := range messages {
+			// A translation with different verbs than its message would be formatted wrongly
+			assert.Equal(t, verbRegex.FindAllString(message, -1), verbRegex.FindAllString(translation, -1), "%s: %q", locale, message)
+		}
+	}
+}
@@ -5,20 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"runtime/debug"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 )
 
@@ -41,20 +43,39 @@ func (p *Plugin) InitRoutes() {
 	// OAuth
 	s.HandleFunc(constants.PathOAuthConnect, p.handleAuthRequired(p.OAuthConnect)).Methods(http.MethodGet)
 	s.HandleFunc(constants.PathOAuthCallback, p.handleAuthRequired(p.OAuthComplete)).Methods(http.MethodGet)
-	// Plugin APIs
-	s.HandleFunc(constants.PathCreateTasks, p.handleAuthRequired(p.checkOAuth(p.handleCreateTask))).Methods(http.MethodPost)
-	s.HandleFunc(constants.PathLinkProject, p.handleAuthRequired(p.checkOAuth(p.handleLink))).Methods(http.MethodPost)
+	// Plugin APIs, the ones changing data are rate limited per user
+	s.HandleFunc(constants.PathCreateTasks, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleCreateTask)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathUpdateTask, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleUpdateTask)))).Methods(http.MethodPatch)
+	s.HandleFunc(constants.PathAddTaskComment, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleAddTaskComment)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathLinkProject, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleLink)))).Methods(http.MethodPost)
 	s.HandleFunc(constants.PathGetAllLinkedProjects, p.handleAuthRequired(p.checkOAuth(p.handleGetAllLinkedProjects))).Methods(http.MethodGet)
-	s.HandleFunc(constants.PathUnlinkProject, p.handleAuthRequired(p.checkOAuth(p.handleUnlinkProject))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathGetProjectProcess, p.handleAuthRequired(p.checkOAuth(p.handleGetProjectProcess))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathGetProjectWorkItems, p.handleAuthRequired(p.checkOAuth(p.handleGetProjectWorkItems))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathGetProjectPullRequests, p.handleAuthRequired(p.checkOAuth(p.handleGetProjectPullRequests))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathSearchProjectWorkItems, p.handleAuthRequired(p.checkOAuth(p.handleSearchProjectWorkItems))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathUnlinkProject, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleUnlinkProject)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathUnlinkAllProjects, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleUnlinkAllProjects)))).Methods(http.MethodPost)
 	s.HandleFunc(constants.PathUser, p.handleAuthRequired(p.checkOAuth(p.handleGetUserAccountDetails))).Methods(http.MethodGet)
-	s.HandleFunc(constants.PathSubscriptions, p.handleAuthRequired(p.checkOAuth(p.handleCreateSubscription))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathSubscriptions, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleCreateSubscription)))).Methods(http.MethodPost)
 	s.HandleFunc(constants.PathGetSubscriptions, p.handleAuthRequired(p.checkOAuth(p.handleGetSubscriptions))).Methods(http.MethodGet)
+	// The route without a prefix is kept for the subscriptions created before a prefix is configured
 	s.HandleFunc(constants.PathSubscriptionNotifications, p.handleSubscriptionNotifications).Methods(http.MethodPost)
-	s.HandleFunc(constants.PathSubscriptions, p.handleAuthRequired(p.checkOAuth(p.handleDeleteSubscriptions))).Methods(http.MethodDelete)
-	s.HandleFunc(constants.PathPipelineReleaseRequest, p.handleAuthRequired(p.checkOAuth(p.handlePipelineApproveOrRejectReleaseRequest))).Methods(http.MethodPost)
-	s.HandleFunc(constants.PathPipelineRunRequest, p.handleAuthRequired(p.checkOAuth(p.handlePipelineApproveOrRejectRunRequest))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathPrefixedSubscriptionNotifications, p.checkWebhookPathPrefix(p.handleSubscriptionNotifications)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathSubscriptions, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleDeleteSubscriptions)))).Methods(http.MethodDelete)
+	s.HandleFunc(constants.PathPipelineReleaseRequest, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handlePipelineApproveOrRejectReleaseRequest)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathPipelineRunRequest, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handlePipelineApproveOrRejectRunRequest)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathDeleteWorkItem, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleDeleteWorkItem)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathResetUser, p.handleAuthRequired(p.handleResetUser)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathDisconnectUser, p.handleAuthRequired(p.handleDisconnectUser)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathNotificationSubscription, p.handleAuthRequired(p.handleShowNotificationSubscription)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathRerunBuild, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleRerunBuild)))).Methods(http.MethodPost)
 	s.HandleFunc(constants.PathPipelineCommentModal, p.handleAuthRequired(p.checkOAuth(p.handlePipelineCommentModal))).Methods(http.MethodPost)
 	s.HandleFunc(constants.PathGetSubscriptionFilterPossibleValues, p.handleAuthRequired(p.checkOAuth(p.handleGetSubscriptionFilterPossibleValues))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathGetUserChannels, p.handleAuthRequired(p.checkOAuth(p.handleGetUserChannels))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathGetUserChannelsForTeam, p.handleAuthRequired(p.checkOAuth(p.handleGetUserChannelsForTeam))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathSubscriptionTemplates, p.handleAuthRequired(p.checkOAuth(p.handleGetSubscriptionTemplates))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathSubscriptionTemplates, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleStoreSubscriptionTemplate)))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathDeleteSubscriptionTemplate, p.handleAuthRequired(p.checkWriteRateLimit(p.checkOAuth(p.handleDeleteSubscriptionTemplate)))).Methods(http.MethodDelete)
 }
 
 // API to create task of a project in an organization.
@@ -68,27 +89,121 @@ func (p *Plugin) handleCreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body.Organization = p.getOrganization(body.Organization)
+	body.Fields.Description = strings.TrimRightFunc(body.Fields.Description, unicode.IsSpace)
+
 	if validationErr := body.IsValid(); validationErr != nil {
 		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: validationErr.Error()})
 		return
 	}
 
+	// Descriptions exceeding the configured length are rejected here instead of failing on Azure DevOps with an unclear error
+	if maxDescriptionLength := p.getConfiguration().MaxDescriptionLength; maxDescriptionLength > 0 {
+		if descriptionLength := utf8.RuneCountInString(body.Fields.Description); descriptionLength > maxDescriptionLength {
+			p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf(constants.DescriptionTooLong, descriptionLength, maxDescriptionLength)})
+			return
+		}
+	}
+
+	// The configuration is validated when it's saved, so the error is not expected here
+	if requiredFields, requiredFieldsErr := p.getConfiguration().GetRequiredTaskFields(); requiredFieldsErr == nil {
+		if missingFields := body.GetMissingFields(requiredFields[strings.ToLower(body.Type)]); len(missingFields) > 0 {
+			p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf(constants.RequiredTaskFieldsMissing, body.Type, strings.Join(missingFields, ", "))})
+			return
+		}
+	}
+
+	// The estimates which don't apply to the work item type would make Azure DevOps reject the whole work item
+	removedEstimates := body.RemoveInapplicableEstimates()
+
 	task, statusCode, err := p.Client.CreateTask(body, mattermostUserID)
 	if err != nil {
+		if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+			if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeWorkWrite); scopeErr != nil {
+				err = scopeErr
+			}
+		}
+
 		p.API.LogError(constants.ErrorCreateTask)
+		if p.enqueueRetryOperation(constants.RetryOperationTypeCreateTask, mattermostUserID, statusCode, body) {
+			returnStatusWithMessage(w, http.StatusAccepted, ErrQueuedForRetry.Error())
+			return
+		}
+
 		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
 		return
 	}
 
-	p.writeJSON(w, task)
-	message := fmt.Sprintf(constants.CreatedTask, task.ID, task.Fields.Title, task.Link.HTML.Href, task.Fields.Type, task.Fields.CreatedBy.DisplayName)
+	// The link is built instead of using the one returned by Azure DevOps, so it's escaped for the project names containing spaces
+	workItemURL := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), body.Organization, url.PathEscape(body.Project), task.ID)
+	p.writeJSON(w, &serializers.CreatedTaskResponse{TaskValue: task, WorkItemURL: workItemURL})
+	message := fmt.Sprintf(constants.CreatedTask, task.ID, task.Fields.Title, workItemURL, task.Fields.Type, task.Fields.CreatedBy.DisplayName)
+	if len(removedEstimates) > 0 {
+		message = fmt.Sprintf("%s %s", message, fmt.Sprintf(constants.TaskEstimatesNotSet, strings.Join(removedEstimates, ", "), body.Type))
+	}
 
-	// Send message to DM.
-	if _, DMErr := p.DM(mattermostUserID, message, true); DMErr != nil {
+	// Send message to DM, the message is not used as the format as the escaped link and the title can contain "%"
+	postID, DMErr := p.DM(mattermostUserID, "%s", true, message)
+	if DMErr != nil {
 		p.API.LogError("Failed to DM", "Error", DMErr.Error())
+		return
+	}
+
+	if err := p.Store.StoreTaskPostMapping(body.Organization, body.Project, strconv.Itoa(task.ID), postID); err != nil {
+		p.API.LogError(constants.ErrorStoreTaskPost, "Error", err.Error())
 	}
 }
 
+// API to update the fields of an existing task, only the fields provided in the request are updated
+func (p *Plugin) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	taskID := mux.Vars(r)[constants.PathParamTaskID]
+	if _, err := strconv.Atoi(taskID); err != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: constants.InvalidTaskID})
+		return
+	}
+
+	body, err := serializers.UpdateTaskRequestPayloadFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	body.Organization = p.getOrganization(body.Organization)
+	if body.Fields.Description != nil {
+		description := strings.TrimRightFunc(*body.Fields.Description, unicode.IsSpace)
+		body.Fields.Description = &description
+	}
+
+	if validationErr := body.IsValid(); validationErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: validationErr.Error()})
+		return
+	}
+
+	if maxDescriptionLength := p.getConfiguration().MaxDescriptionLength; maxDescriptionLength > 0 && body.Fields.Description != nil {
+		if descriptionLength := utf8.RuneCountInString(*body.Fields.Description); descriptionLength > maxDescriptionLength {
+			p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf(constants.DescriptionTooLong, descriptionLength, maxDescriptionLength)})
+			return
+		}
+	}
+
+	task, statusCode, err := p.Client.UpdateTask(body.Organization, body.Project, taskID, body.Fields, mattermostUserID)
+	if err != nil {
+		if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+			if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeWorkWrite); scopeErr != nil {
+				err = scopeErr
+			}
+		}
+
+		p.API.LogError(constants.ErrorUpdateTask, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	p.writeJSON(w, task)
+}
+
 // API to link a project and an organization to a user.
 func (p *Plugin) handleLink(w http.ResponseWriter, r *http.Request) {
 	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
@@ -100,6 +215,9 @@ func (p *Plugin) handleLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body.Organization, body.Project = normalizeProjectNames(body.Organization, body.Project)
+	body.Organization = p.getOrganization(body.Organization)
+
 	if linkValidationErr := body.IsLinkPayloadValid(); linkValidationErr != nil {
 		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: linkValidationErr.Error()})
 		return
@@ -112,22 +230,42 @@ func (p *Plugin) handleLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, isProjectLinked := p.IsProjectLinked(projectList, serializers.ProjectDetails{OrganizationName: strings.ToLower(body.Organization), ProjectName: cases.Title(language.Und).String(body.Project)}); isProjectLinked {
+	if _, isProjectLinked := p.IsProjectLinked(projectList, serializers.ProjectDetails{OrganizationName: body.Organization, ProjectName: body.Project}); isProjectLinked {
 		returnStatusWithMessage(w, http.StatusOK, constants.AlreadyLinkedProject)
 		return
 	}
 
-	response, statusCode, err := p.Client.Link(body, mattermostUserID)
+	response, statusCode, err := p.getAzureDevopsProject(mattermostUserID, body.Organization, body.Project)
 	if err != nil {
 		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
 		return
 	}
 
+	// The project may be linked under a previous name, its ID in Azure DevOps doesn't change
+	if _, isProjectLinked := getLinkedProjectByKey(projectList, body.Organization, response.ID); isProjectLinked {
+		returnStatusWithMessage(w, http.StatusOK, constants.AlreadyLinkedProject)
+		return
+	}
+
+	if limitErr := p.checkLinkedProjectsLimit(projectList); limitErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: limitErr.Error()})
+		return
+	}
+
+	defaultQuery := strings.TrimSpace(body.DefaultQuery)
+	if defaultQuery != "" {
+		if statusCode, queryErr := p.validateDefaultQuery(body.Organization, body.Project, defaultQuery, mattermostUserID); queryErr != nil {
+			p.handleError(w, r, &serializers.Error{Code: statusCode, Message: queryErr.Error()})
+			return
+		}
+	}
+
 	project := serializers.ProjectDetails{
 		MattermostUserID: mattermostUserID,
 		ProjectID:        response.ID,
-		ProjectName:      cases.Title(language.Und).String(body.Project),
+		ProjectName:      response.Name,
 		OrganizationName: strings.ToLower(body.Organization),
+		DefaultQuery:     defaultQuery,
 	}
 
 	if storeErr := p.Store.StoreProject(&project); storeErr != nil {
@@ -148,6 +286,10 @@ func (p *Plugin) handleGetAllLinkedProjects(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if organization := strings.TrimSpace(r.URL.Query().Get(constants.QueryParamOrganization)); organization != "" {
+		projectList = filterProjectsByOrganization(projectList, organization)
+	}
+
 	w.Header().Add("Content-Type", "application/json")
 
 	if len(projectList) == 0 {
@@ -161,6 +303,121 @@ func (p *Plugin) handleGetAllLinkedProjects(w http.ResponseWriter, r *http.Reque
 	p.writeJSON(w, projectList)
 }
 
+// handleGetProjectProcess returns the process of a linked project along with its enabled work item types
+func (p *Plugin) handleGetProjectProcess(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	pathParams := mux.Vars(r)
+	organization := pathParams[constants.PathParamOrganization]
+	projectID := pathParams[constants.PathParamProjectID]
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectList, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	if !isProjectIDLinked(projectList, organization, projectID) {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.ProjectNotLinked})
+		return
+	}
+
+	process, statusCode, err := p.getProjectProcess(organization, projectID, mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProcess, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	p.writeJSON(w, process)
+}
+
+// handleGetProjectWorkItems returns the most recently changed work items of a linked project, filtered by their state and assignee
+func (p *Plugin) handleGetProjectWorkItems(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	projectID := mux.Vars(r)[constants.PathParamProjectID]
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectList, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	project, isProjectLinked := getLinkedProjectByID(projectList, projectID)
+	if !isProjectLinked {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.ProjectNotLinked})
+		return
+	}
+
+	query := serializers.WorkItemQuery{
+		State:      strings.TrimSpace(r.URL.Query().Get(constants.QueryParamState)),
+		AssignedTo: strings.TrimSpace(r.URL.Query().Get(constants.QueryParamAssignedTo)),
+	}
+	workItems, statusCode, err := p.Client.GetWorkItems(project.OrganizationName, project.ProjectName, query, mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectWorkItems, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	workItemSummaries := make([]*serializers.WorkItemSummary, 0, len(workItems))
+	for _, workItem := range workItems {
+		workItemSummaries = append(workItemSummaries, &serializers.WorkItemSummary{
+			ID:         workItem.ID,
+			Title:      workItem.Fields.Title,
+			Type:       workItem.Fields.Type,
+			State:      workItem.Fields.State,
+			AssignedTo: workItem.Fields.AssignedTo.DisplayName,
+			UpdatedAt:  workItem.Fields.UpdatedAt,
+			Link:       fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), workItem.ID),
+		})
+	}
+
+	p.writeJSON(w, workItemSummaries)
+}
+
+// handleGetProjectPullRequests returns the open pull requests of a linked project, from all its repositories unless one is given
+func (p *Plugin) handleGetProjectPullRequests(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	projectID := mux.Vars(r)[constants.PathParamProjectID]
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectList, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	project, isProjectLinked := getLinkedProjectByID(projectList, projectID)
+	if !isProjectLinked {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.ProjectNotLinked})
+		return
+	}
+
+	repositoryID := strings.TrimSpace(r.URL.Query().Get(constants.QueryParamRepository))
+	pullRequests, statusCode, err := p.Client.GetPullRequests(project.OrganizationName, project.ProjectName, repositoryID, mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectPullRequests, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	pullRequestSummaries := make([]*serializers.PullRequestSummary, 0, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		pullRequestSummaries = append(pullRequestSummaries, &serializers.PullRequestSummary{
+			ID:         pullRequest.PullRequestID,
+			Title:      pullRequest.Title,
+			Author:     pullRequest.CreatedBy.DisplayName,
+			Status:     pullRequest.Status,
+			Repository: pullRequest.Repository.Name,
+			URL:        fmt.Sprintf(constants.PullRequestLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), url.PathEscape(pullRequest.Repository.Name), pullRequest.PullRequestID),
+		})
+	}
+
+	p.writeJSON(w, pullRequestSummaries)
+}
+
 // handleUnlinkProject unlinks a project
 func (p *Plugin) handleUnlinkProject(w http.ResponseWriter, r *http.Request) {
 	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
@@ -184,14 +441,17 @@ func (p *Plugin) handleUnlinkProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, isProjectLinked := p.IsProjectLinked(projectList, *project); !isProjectLinked {
+	project.OrganizationName, project.ProjectName = normalizeProjectNames(project.OrganizationName, project.ProjectName)
+	linkedProject, isProjectLinked := p.IsProjectLinked(projectList, *project)
+	if !isProjectLinked {
 		p.API.LogError(constants.ProjectNotFound, "Error")
 		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.ProjectNotFound})
 		return
 	}
 
+	// The linked project is deleted by the ID stored when it was linked, which is the one of Azure DevOps
 	if project.DeleteSubscriptions {
-		if statusCode, err := p.handleDeleteAllSubscriptions(mattermostUserID, project.ProjectID); err != nil {
+		if statusCode, err := p.handleDeleteAllSubscriptions(mattermostUserID, linkedProject.ProjectID); err != nil {
 			p.API.LogError("Error deleting the project subscriptions", "Error", err.Error())
 			p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
 			return
@@ -200,9 +460,9 @@ func (p *Plugin) handleUnlinkProject(w http.ResponseWriter, r *http.Request) {
 
 	if deleteErr := p.Store.DeleteProject(&serializers.ProjectDetails{
 		MattermostUserID: mattermostUserID,
-		ProjectID:        project.ProjectID,
-		ProjectName:      project.ProjectName,
-		OrganizationName: project.OrganizationName,
+		ProjectID:        linkedProject.ProjectID,
+		ProjectName:      linkedProject.ProjectName,
+		OrganizationName: linkedProject.OrganizationName,
 	}); deleteErr != nil {
 		p.API.LogError(constants.ErrorUnlinkProject, "Error", deleteErr.Error())
 		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: deleteErr.Error()})
@@ -244,22 +504,30 @@ func (p *Plugin) handleCreateSubscription(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	body.Organization, body.Project = normalizeProjectNames(body.Organization, body.Project)
+	body.Organization = p.getOrganization(body.Organization)
+	body.ChannelID = normalizeChannelID(body.ChannelID)
+	// A channel provided in the request takes precedence over the default channel of the organization
+	if body.ChannelID == "" {
+		body.ChannelID = p.getOrganizationDefaultChannel(body.Organization)
+	}
+
+	eventType, err := p.resolveEventType(body.EventType)
+	if err != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	body.EventType = eventType
+
 	if validationErr := body.IsSubscriptionRequestPayloadValid(); validationErr != nil {
 		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: validationErr.Error()})
 		return
 	}
 
+	// The channel is checked before calling Azure DevOps, so that no subscription is created for a channel the user can't post in
 	if statusCode, channelAccessErr := p.CheckValidChannelForSubscription(body.ChannelID, mattermostUserID); channelAccessErr != nil {
 		p.API.LogError(constants.ErrorCreateSubscription, "Error", channelAccessErr.Error())
-
-		message := channelAccessErr.Error()
-		responseStatusCode := statusCode
-		if statusCode == http.StatusNotFound {
-			message = "you are not allowed to create subscription for the provided channel"
-			responseStatusCode = http.StatusForbidden
-		}
-
-		p.handleError(w, r, &serializers.Error{Code: responseStatusCode, Message: message})
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: channelAccessErr.Error()})
 		return
 	}
 
@@ -271,12 +539,23 @@ func (p *Plugin) handleCreateSubscription(w http.ResponseWriter, r *http.Request
 	}
 
 	project, isProjectLinked := p.IsProjectLinked(projectList, serializers.ProjectDetails{OrganizationName: body.Organization, ProjectName: body.Project})
-	if !isProjectLinked {
+	if !isProjectLinked && !p.getConfiguration().AutoLinkSubscriptionProjects {
 		p.API.LogError(constants.ProjectNotFound, "Error")
 		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.ProjectNotLinked})
 		return
 	}
 
+	// The project is linked before anything is created for the subscription, so that a project which can't be linked fails the request early
+	if !isProjectLinked {
+		linkedProject, statusCode, linkErr := p.autoLinkProject(mattermostUserID, body.Organization, body.Project, projectList)
+		if linkErr != nil {
+			p.API.LogError(constants.ErrorAutoLinkProject, "Error", linkErr.Error())
+			p.handleError(w, r, &serializers.Error{Code: statusCode, Message: fmt.Sprintf(constants.AutoLinkProjectFailed, body.Project, linkErr.Error())})
+			return
+		}
+		project = linkedProject
+	}
+
 	subscriptionList, err := p.Store.GetAllSubscriptions(mattermostUserID)
 	if err != nil {
 		p.API.LogError(constants.FetchSubscriptionListError, "Error", err.Error())
@@ -284,135 +563,79 @@ func (p *Plugin) handleCreateSubscription(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if _, isSubscriptionPresent := p.IsSubscriptionPresent(subscriptionList, &serializers.SubscriptionDetails{
-		OrganizationName: body.Organization,
-		ProjectName:      body.Project,
-		ChannelID:        body.ChannelID,
-		EventType:        body.EventType,
-		// Below all are filters that could be present on different categories of subscriptions from Boards, Repos and Pipelines
-		Repository:                   body.Repository,
-		TargetBranch:                 body.TargetBranch,
-		PullRequestCreatedBy:         body.PullRequestCreatedBy,
-		PullRequestReviewersContains: body.PullRequestReviewersContains,
-		PushedBy:                     body.PushedBy,
-		MergeResult:                  body.MergeResult,
-		NotificationType:             body.NotificationType,
-		AreaPath:                     body.AreaPath,
-		BuildStatus:                  body.BuildStatus,
-		BuildPipeline:                body.BuildPipeline,
-		StageName:                    body.StageName,
-		ReleasePipeline:              body.ReleasePipeline,
-		ReleaseStatus:                body.ReleaseStatus,
-		ApprovalType:                 body.ApprovalType,
-		ApprovalStatus:               body.ApprovalStatus,
-		RunPipeline:                  body.RunPipeline,
-		RunStageName:                 body.RunStageName,
-		RunEnvironmentName:           body.RunEnvironmentName,
-		RunStageNameID:               body.RunStageNameID,
-		RunStageStateID:              body.RunStageStateID,
-		RunStageResultID:             body.RunStageResultID,
-		RunStateID:                   body.RunStateID,
-		RunResultID:                  body.RunResultID,
-	}); isSubscriptionPresent {
+	if _, isSubscriptionPresent := p.IsSubscriptionPresent(subscriptionList, getSubscriptionDetailsFromPayload(body)); isSubscriptionPresent {
 		p.API.LogError(constants.SubscriptionAlreadyPresent, "Error")
 		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: constants.SubscriptionAlreadyPresent})
 		return
 	}
 
-	uniqueWebhookSecret := uuid.New().String()
-	subscription, statusCode, err := p.Client.CreateSubscription(body, project, body.ChannelID, p.GetPluginURL(), mattermostUserID, uniqueWebhookSecret)
+	if limitErr := p.checkSubscriptionsLimit(subscriptionList); limitErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: limitErr.Error()})
+		return
+	}
+
+	subscription, statusCode, err := p.createSubscription(mattermostUserID, body, project)
+	if errors.Is(err, ErrQueuedForRetry) {
+		returnStatusWithMessage(w, statusCode, err.Error())
+		return
+	}
 	if err != nil {
-		p.API.LogError(constants.CreateSubscriptionError, "Error", err.Error())
 		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
 		return
 	}
 
-	if err := p.Store.StoreSubscriptionAndChannelIDMap(subscription.ID, uniqueWebhookSecret, body.ChannelID); err != nil {
-		p.API.LogError("Error storing channel ID for subscription", "Error", err.Error())
+	p.writeJSON(w, subscription)
+}
+
+// handleGetSubscriptionTemplates returns the subscription templates of the user
+func (p *Plugin) handleGetSubscriptionTemplates(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	templates, err := p.Store.GetSubscriptionTemplates(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchSubscriptionTemplates, "Error", err.Error())
 		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
 		return
 	}
 
-	channel, channelErr := p.API.GetChannel(body.ChannelID)
-	if channelErr != nil {
-		p.API.LogError(constants.GetChannelError, "Error", channelErr.Error())
-		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: constants.GetChannelError})
+	p.writeJSON(w, templates)
+}
+
+// handleStoreSubscriptionTemplate creates a subscription template or replaces an existing one with the same name
+func (p *Plugin) handleStoreSubscriptionTemplate(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	template, err := serializers.SubscriptionTemplateFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError("Error in decoding the body for storing subscription template", "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
 		return
 	}
 
-	user, userErr := p.API.GetUser(mattermostUserID)
-	if userErr != nil {
-		p.API.LogError(constants.GetUserError, "Error", userErr.Error())
-		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: constants.GetUserError})
+	if validationErr := template.IsValid(); validationErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: validationErr.Error()})
 		return
 	}
 
-	createdByDisplayName := user.Username
-
-	showFullName := p.API.GetConfig().PrivacySettings.ShowFullName
-	// If "PrivacySettings.ShowFullName" is true then show the user's first/last name
-	// If the user's first/last name doesn't exist then show the username as fallback
-	if showFullName != nil && *showFullName && (user.FirstName != "" || user.LastName != "") {
-		createdByDisplayName = fmt.Sprintf("%s %s", user.FirstName, user.LastName)
+	template.MattermostUserID = mattermostUserID
+	if err := p.Store.StoreSubscriptionTemplate(template); err != nil {
+		p.API.LogError(constants.ErrorStoreSubscriptionTemplate, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
 	}
 
-	if storeErr := p.Store.StoreSubscription(&serializers.SubscriptionDetails{
-		MattermostUserID: mattermostUserID,
-		ProjectName:      body.Project,
-		ProjectID:        project.ProjectID,
-		OrganizationName: body.Organization,
-		EventType:        body.EventType,
-		ServiceType:      body.ServiceType,
-		ChannelID:        body.ChannelID,
-		SubscriptionID:   subscription.ID,
-		ChannelName:      channel.DisplayName,
-		ChannelType:      channel.Type,
-		CreatedBy:        strings.TrimSpace(createdByDisplayName),
-		// Below all are filters that could be present on different categories of subscriptions from Boards, Repos and Pipelines
-		Repository:                       body.Repository,
-		TargetBranch:                     body.TargetBranch,
-		RepositoryName:                   body.RepositoryName,
-		PullRequestCreatedBy:             body.PullRequestCreatedBy,
-		PullRequestReviewersContains:     body.PullRequestReviewersContains,
-		PullRequestCreatedByName:         body.PullRequestCreatedByName,
-		PullRequestReviewersContainsName: body.PullRequestReviewersContainsName,
-		PushedBy:                         body.PushedBy,
-		PushedByName:                     body.PushedByName,
-		MergeResult:                      body.MergeResult,
-		MergeResultName:                  body.MergeResultName,
-		NotificationType:                 body.NotificationType,
-		NotificationTypeName:             body.NotificationTypeName,
-		AreaPath:                         body.AreaPath,
-		BuildStatus:                      body.BuildStatus,
-		BuildPipeline:                    body.BuildPipeline,
-		StageName:                        body.StageName,
-		ReleasePipeline:                  body.ReleasePipeline,
-		ReleaseStatus:                    body.ReleaseStatus,
-		ApprovalType:                     body.ApprovalType,
-		ApprovalStatus:                   body.ApprovalStatus,
-		BuildStatusName:                  body.BuildStatusName,
-		StageNameValue:                   body.StageNameValue,
-		ReleasePipelineName:              body.ReleasePipelineName,
-		ReleaseStatusName:                body.ReleaseStatusName,
-		ApprovalTypeName:                 body.ApprovalTypeName,
-		ApprovalStatusName:               body.ApprovalStatusName,
-		RunPipeline:                      body.RunPipeline,
-		RunPipelineName:                  body.RunPipelineName,
-		RunStageName:                     body.RunStageName,
-		RunEnvironmentName:               body.RunEnvironmentName,
-		RunStageNameID:                   body.RunStageNameID,
-		RunStageStateID:                  body.RunStageStateID,
-		RunStageStateIDName:              body.RunStageStateIDName,
-		RunStageResultID:                 body.RunStageResultID,
-		RunStateID:                       body.RunStateID,
-		RunStateIDName:                   body.RunStateIDName,
-		RunResultID:                      body.RunResultID,
-	}); storeErr != nil {
-		p.API.LogError("Error in creating a subscription", "Error", storeErr.Error())
-		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: storeErr.Error()})
+	p.writeJSON(w, template)
+}
+
+// handleDeleteSubscriptionTemplate deletes a subscription template of the user
+func (p *Plugin) handleDeleteSubscriptionTemplate(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	templateName := mux.Vars(r)[constants.PathParamTemplateName]
+	if err := p.Store.DeleteSubscriptionTemplate(mattermostUserID, templateName); err != nil {
+		p.API.LogError(constants.ErrorDeleteSubscriptionTemplate, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
 	}
 
-	p.writeJSON(w, subscription)
+	returnStatusOK(w)
 }
 
 func (p *Plugin) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
@@ -435,12 +658,11 @@ func (p *Plugin) handleGetSubscriptions(w http.ResponseWriter, r *http.Request)
 
 	organization := pathParams[constants.PathParamOrganization]
 	project := pathParams[constants.PathParamProject]
-	organizationName := strings.ToLower(organization)
-	projectName := cases.Title(language.Und).String(project)
-	if _, isProjectLinked := p.IsProjectLinked(projectList, serializers.ProjectDetails{
-		OrganizationName: organizationName,
-		ProjectName:      projectName,
-	}); !isProjectLinked {
+	linkedProject, isProjectLinked := p.IsProjectLinked(projectList, serializers.ProjectDetails{
+		OrganizationName: organization,
+		ProjectName:      project,
+	})
+	if !isProjectLinked {
 		p.API.LogWarn(fmt.Sprintf("Project %s is not linked", project))
 		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: "requested project is not linked"})
 		return
@@ -462,13 +684,22 @@ func (p *Plugin) handleGetSubscriptions(w http.ResponseWriter, r *http.Request)
 	}
 
 	offset, limit := p.GetOffsetAndLimitFromQueryParams(r)
-	channelID := r.URL.Query().Get(constants.QueryParamChannelID)
+	channelID := normalizeChannelID(r.URL.Query().Get(constants.QueryParamChannelID))
+	if channelID != "" {
+		if _, statusCode, channelErr := p.getValidChannel(channelID); channelErr != nil {
+			p.handleError(w, r, &serializers.Error{Code: statusCode, Message: channelErr.Error()})
+			return
+		}
+	}
 	serviceType := r.URL.Query().Get(constants.QueryParamServiceType)
 	eventType := r.URL.Query().Get(constants.QueryParamEventType)
+	if resolvedEventType, ok := p.getEventTypeAliases()[eventType]; ok {
+		eventType = resolvedEventType
+	}
 
 	subscriptionByProject := []*serializers.SubscriptionDetails{}
 	for _, subscription := range subscriptionList {
-		if subscription.ProjectName == project {
+		if isSubscriptionOfProject(subscription, linkedProject) {
 			if channelID == "" || subscription.ChannelID == channelID {
 				switch serviceType {
 				case "", constants.FilterAll:
@@ -511,9 +742,7 @@ func (p *Plugin) handleGetSubscriptions(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	sort.Slice(subscriptionByProject, func(i, j int) bool {
-		return subscriptionByProject[i].CreatedAt.After(subscriptionByProject[j].CreatedAt)
-	})
+	sortSubscriptionsByCreation(subscriptionByProject, r.URL.Query().Get(constants.QueryParamSort) == constants.SubscriptionSortOldest)
 
 	filteredSubscriptionList, filteredSubscriptionErr := p.GetSubscriptionsForAccessibleChannelsOrProjects(subscriptionByProject, teamID, mattermostUserID, constants.FilterCreatedByAnyone)
 	if filteredSubscriptionErr != nil {
@@ -522,20 +751,28 @@ func (p *Plugin) handleGetSubscriptions(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// The current names of the channels are shown, as the stored ones are the names the channels had when the subscriptions were created
+	// The total count is of all the subscriptions matching the filters, so the clients can tell if there are more pages
 	paginatedSubscriptions := []*serializers.SubscriptionDetails{}
+	channelNames := p.newChannelNameResolver()
 	for index, subscription := range filteredSubscriptionList {
 		if len(paginatedSubscriptions) == limit {
 			break
 		}
 		if index >= offset {
-			paginatedSubscriptions = append(paginatedSubscriptions, subscription)
+			paginatedSubscription := *subscription
+			paginatedSubscription.ChannelName = channelNames.getChannelName(subscription.ChannelID)
+			paginatedSubscriptions = append(paginatedSubscriptions, &paginatedSubscription)
 		}
 	}
 
-	p.writeJSON(w, paginatedSubscriptions)
+	p.writeJSON(w, &serializers.SubscriptionListResponse{
+		Subscriptions: paginatedSubscriptions,
+		TotalCount:    len(filteredSubscriptionList),
+	})
 }
 
-func (p *Plugin) getReviewersListString(reviewersList []serializers.Reviewer) string {
+func (p *Plugin) getReviewersListString(reviewersList []serializers.Reviewer, localizer *i18n.Localizer) string {
 	reviewers := ""
 	for i := 0; i < len(reviewersList); i++ {
 		if i != len(reviewersList)-1 {
@@ -546,7 +783,7 @@ func (p *Plugin) getReviewersListString(reviewersList []serializers.Reviewer) st
 	}
 
 	if reviewers == "" {
-		return "None" // When no reviewers are added
+		return localizer.Localize("None") // When no reviewers are added
 	}
 	return reviewers
 }
@@ -589,45 +826,242 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// The channel of a subscription can be deleted after the subscription is created
+	channel, statusCode, channelErr := p.getValidChannel(channelID)
+	if channelErr != nil {
+		p.API.LogError("Invalid channel for the subscription notification", "Error", channelErr.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: channelErr.Error()})
+		return
+	}
+
+	subscriptions := p.getNotificationSubscriptions(body.SubscriptionID)
+	convertNotificationMessagesToMarkdown(subscriptions[0], body)
+
+	if author := p.getIgnoredNotificationAuthor(body); author != "" {
+		p.API.LogDebug("Notification of a change made by an ignored author is not posted", "SubscriptionID", body.SubscriptionID, "EventType", body.EventType, "Author", author)
+		returnStatusOK(w)
+		return
+	}
+
+	// The users sharing the webhook have their own options, the notification is posted once in the channel if any of their subscriptions lets it through
+	var subscription *serializers.SubscriptionDetails
+	var ephemeralSubscriptions []*serializers.SubscriptionDetails
+	isPostedInChannel := false
+	for _, sharedSubscription := range subscriptions {
+		if !p.isNotificationAllowed(sharedSubscription, body) {
+			continue
+		}
+
+		if sharedSubscription.IsEphemeral() {
+			ephemeralSubscriptions = append(ephemeralSubscriptions, sharedSubscription)
+			continue
+		}
+
+		subscription = sharedSubscription
+		isPostedInChannel = true
+		break
+	}
+
+	if !isPostedInChannel && len(ephemeralSubscriptions) == 0 {
+		returnStatusOK(w)
+		return
+	}
+
+	if err := p.Store.StoreLastNotification(body); err != nil {
+		p.API.LogDebug("Error in storing the last notification of the subscription", "Error", err.Error())
+	}
+
+	prefs := p.getChannelNotificationPrefs(channelID)
+
+	// The notifications shown only to the creators of the subscriptions are neither counted, summarized nor threaded, as the channel doesn't see them
+	if !isPostedInChannel {
+		for _, ephemeralSubscription := range ephemeralSubscriptions {
+			attachment, err := p.getSubscriptionNotificationAttachment(ephemeralSubscription, body, prefs)
+			if err != nil {
+				p.API.LogError(err.Error())
+				p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+				return
+			}
+
+			post := &model.Post{
+				UserId:    p.botUserID,
+				ChannelId: channelID,
+			}
+			model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+			p.sendEphemeralNotification(post, ephemeralSubscription, channel)
+		}
+		returnStatusOK(w)
+		return
+	}
+
+	attachment, err := p.getSubscriptionNotificationAttachment(subscription, body, prefs)
+	if err != nil {
+		p.API.LogError(err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	p.countWeeklySummaryNotification(channelID, body.EventType, prefs)
+
+	if p.addNotificationToBurst(channelID, subscription, body, prefs) {
+		p.logNotificationDelivery(channelID, body, "")
+		p.publishNotificationPostedEvent(channelID, body, "")
+		returnStatusOK(w)
+		return
+	}
+
+	p.addShowSubscriptionAction(attachment, body.SubscriptionID, p.getNotificationLocalizer(prefs))
+	correlationKeys := getNotificationCorrelationKeys(body)
+	if coalescedPostID := p.addNotificationToCoalescedPost(channelID, subscription, correlationKeys, attachment); coalescedPostID != "" {
+		p.logNotificationDelivery(channelID, body, coalescedPostID)
+		p.publishNotificationPostedEvent(channelID, body, coalescedPostID)
+		returnStatusOK(w)
+		return
+	}
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: channelID,
+	}
+
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	post.AddProp(constants.PostPropSubscriptionID, body.SubscriptionID)
+	createdPost, appErr := p.createNotificationPost(post, subscription, body)
+	if appErr != nil {
+		p.API.LogError("Error in creating post", "Error", appErr.Error())
+		returnStatusOK(w)
+		return
+	}
+
+	p.storeCoalescedPost(channelID, subscription, correlationKeys, createdPost.Id, int64(p.getConfiguration().NotificationCoalescingWindow))
+	p.logNotificationDelivery(channelID, body, createdPost.Id)
+	p.publishNotificationPostedEvent(channelID, body, createdPost.Id)
+
+	returnStatusOK(w)
+}
+
+// isNotificationAllowed checks if a notification passes the filters of a subscription and if the subscription is not paused
+func (p *Plugin) isNotificationAllowed(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) bool {
+	if subscription != nil && !isBranchMatchingFilters(subscription.BranchFilters, getNotificationBranch(body)) {
+		return false
+	}
+
+	if !p.isPullRequestTargetBranchMatching(subscription, body) {
+		return false
+	}
+
+	if !isBuildResultSelected(subscription, body) {
+		return false
+	}
+
+	// Azure DevOps filters the work items of the subscription as well, the filters are checked again in case it sends broader notifications
+	if !isWorkItemMatchingFilters(subscription, body) {
+		p.API.LogDebug("Notification of a work item not matching the filters of the subscription is not posted", "SubscriptionID", body.SubscriptionID, "EventType", body.EventType)
+		return false
+	}
+
+	if p.isServiceAccountNotificationExcluded(subscription, body) {
+		p.API.LogDebug("Notification of a change made by a service account is not posted", "SubscriptionID", body.SubscriptionID, "EventType", body.EventType)
+		return false
+	}
+
+	if p.isSubscriptionPaused(subscription) {
+		p.API.LogDebug("Notification of a paused subscription is not posted", "SubscriptionID", body.SubscriptionID, "EventType", body.EventType)
+		return false
+	}
+
+	return true
+}
+
+// publishNotificationPostedEvent lets the webapp of the members of a channel refresh what they show once a notification is posted in it.
+// The post ID is empty for the notifications added to the summary of a burst.
+func (p *Plugin) publishNotificationPostedEvent(channelID string, body *serializers.SubscriptionNotification, postID string) {
+	p.API.PublishWebSocketEvent(
+		constants.WSEventNotificationPosted,
+		map[string]interface{}{
+			"channelID":      channelID,
+			"eventType":      body.EventType,
+			"subscriptionID": body.SubscriptionID,
+			"postID":         postID,
+		},
+		&model.WebsocketBroadcast{ChannelId: channelID},
+	)
+}
+
+// convertNotificationMessagesToMarkdown fills the Markdown of the messages of a notification from the format requested by its subscription,
+// as the notifications are rendered from Markdown
+func convertNotificationMessagesToMarkdown(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) {
+	if subscription == nil {
+		return
+	}
+
+	for _, message := range []*serializers.DetailedMessage{&body.Message, &body.DetailedMessage} {
+		switch {
+		case message.Markdown != "":
+			continue
+		case subscription.MessageFormat == constants.SubscriptionMessageFormatText:
+			message.Markdown = message.Text
+		case subscription.MessageFormat == constants.SubscriptionMessageFormatHTML:
+			message.Markdown = convertHTMLToMarkdown(message.HTML)
+		}
+	}
+}
+
+// getSubscriptionNotificationAttachment renders the notification of a subscription.
+// The notifications of the event types which are not rendered are posted as their Markdown.
+func (p *Plugin) getSubscriptionNotificationAttachment(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification, prefs *serializers.ChannelNotificationPrefs) (*model.SlackAttachment, error) {
+	truncation := p.getNotificationTruncation(subscription, body)
+	localizer := p.getNotificationLocalizer(prefs)
 	var attachment *model.SlackAttachment
 	switch body.EventType {
 	case constants.SubscriptionEventWorkItemCreated, constants.SubscriptionEventWorkItemDeleted:
 		attachment = &model.SlackAttachment{
 			AuthorName: constants.SlackAttachmentAuthorNameBoards,
 			AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameBoardsIcon),
-			Color:      constants.IconColorBoards,
+			Color:      p.getWorkItemStateColorForSubscription(body.SubscriptionID, body.Resource.Fields.ProjectName, body.Resource.Fields.WorkItemType, body.Resource.Fields.State),
 			Pretext:    body.Message.Markdown,
 			Title:      body.Resource.Fields.Title.(string),
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Area Path",
+					Title: localizer.Localize("Area Path"),
 					Value: body.Resource.Fields.AreaPath,
 					Short: true,
 				},
 				{
-					Title: "State",
+					Title: localizer.Localize("State"),
 					Value: body.Resource.Fields.State,
 					Short: true,
 				},
 				{
-					Title: "Workitem Type",
+					Title: localizer.Localize("Workitem Type"),
 					Value: body.Resource.Fields.WorkItemType,
 				},
 			},
 			Footer:     body.Resource.Fields.ProjectName.(string),
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 		}
+
+		if description, ok := body.Resource.Fields.All[constants.FieldDescription].(string); ok && body.EventType == constants.SubscriptionEventWorkItemCreated {
+			if !shouldKeepRawHTML(subscription, prefs) {
+				description = convertHTMLToMarkdown(description)
+			}
+			attachment.Text = truncation.truncateDescription(strings.TrimSpace(description))
+		}
 	case constants.SubscriptionEventWorkItemCommented:
 		reg := regexp.MustCompile(constants.WorkItemCommentedOnMarkdownRegex)
 		comment := reg.Split(body.DetailedMessage.Markdown, -1)
+		commentText := strings.TrimSpace(comment[len(comment)-1])
+		if !shouldKeepRawHTML(subscription, prefs) {
+			commentText = convertHTMLToMarkdown(commentText)
+		}
 
 		attachment = &model.SlackAttachment{
 			AuthorName: constants.SlackAttachmentAuthorNameBoards,
 			AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameBoardsIcon),
 			Color:      constants.IconColorBoards,
 			Pretext:    body.Message.Markdown,
-			Title:      "Comment",
-			Text:       strings.TrimSpace(comment[len(comment)-1]),
+			Title:      localizer.Localize("Comment"),
+			Text:       truncation.truncateComment(commentText),
 			Footer:     body.Resource.Fields.ProjectName.(string),
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 		}
@@ -635,30 +1069,37 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 		attachment = &model.SlackAttachment{
 			AuthorName: constants.SlackAttachmentAuthorNameBoards,
 			AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameBoardsIcon),
-			Color:      constants.IconColorBoards,
+			Color:      p.getWorkItemStateColorForSubscription(body.SubscriptionID, body.Resource.Revision.Fields.ProjectName, body.Resource.Revision.Fields.WorkItemType, body.Resource.Revision.Fields.State),
 			Pretext:    body.Message.Markdown,
 			Title:      body.Resource.Revision.Fields.Title.(string),
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Area Path",
+					Title: localizer.Localize("Area Path"),
 					Value: body.Resource.Revision.Fields.AreaPath,
 					Short: true,
 				},
 				{
-					Title: "State",
+					Title: localizer.Localize("State"),
 					Value: body.Resource.Revision.Fields.State,
 					Short: true,
 				},
 				{
-					Title: "Workitem Type",
+					Title: localizer.Localize("Workitem Type"),
 					Value: body.Resource.Revision.Fields.WorkItemType,
 				},
 			},
 			Footer:     body.Resource.Revision.Fields.ProjectName.(string),
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 		}
+
+		if changes := getWorkItemFieldChanges(body.Resource.Fields.All); changes != "" {
+			attachment.Fields = append(attachment.Fields, &model.SlackAttachmentField{
+				Title: localizer.Localize("Changes"),
+				Value: changes,
+			})
+		}
 	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
-		reviewers := p.getReviewersListString(body.Resource.Reviewers)
+		reviewers := p.getReviewersListString(body.Resource.Reviewers, localizer)
 
 		var targetBranchName, sourceBranchName string
 		if len(strings.Split(body.Resource.TargetRefName, "/")) == 3 {
@@ -677,25 +1118,29 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Title:      fmt.Sprintf("%d: %s", body.Resource.PullRequestID, body.Resource.Title),
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Target Branch",
+					Title: localizer.Localize("Target Branch"),
 					Value: targetBranchName,
 					Short: true,
 				},
 				{
-					Title: "Source Branch",
+					Title: localizer.Localize("Source Branch"),
 					Value: sourceBranchName,
 					Short: true,
 				},
 				{
-					Title: "Reviewer(s)",
+					Title: localizer.Localize("Reviewer(s)"),
 					Value: reviewers,
 				},
 			},
 			Footer:     body.Resource.Repository.Name,
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 		}
+
+		if body.EventType == constants.SubscriptionEventPullRequestCreated {
+			attachment.Text = truncation.truncateDescription(strings.TrimSpace(body.Resource.Description))
+		}
 	case constants.SubscriptionEventPullRequestCommented:
-		reviewers := p.getReviewersListString(body.Resource.PullRequest.Reviewers)
+		reviewers := p.getReviewersListString(body.Resource.PullRequest.Reviewers, localizer)
 
 		var targetBranchName, sourceBranchName string
 		if len(strings.Split(body.Resource.PullRequest.TargetRefName, "/")) == 3 {
@@ -709,17 +1154,13 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 		// Convert map to json string
 		jsonBytes, err := json.Marshal(body.Resource.Comment)
 		if err != nil {
-			p.API.LogError(err.Error())
-			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
-			return
+			return nil, err
 		}
 
 		// Convert json string to struct
 		var comment *serializers.Comment
 		if err := json.Unmarshal(jsonBytes, &comment); err != nil {
-			p.API.LogError(err.Error())
-			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
-			return
+			return nil, err
 		}
 
 		attachment = &model.SlackAttachment{
@@ -730,60 +1171,47 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Title:      fmt.Sprintf("%d: %s", body.Resource.PullRequest.PullRequestID, body.Resource.PullRequest.Title),
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Target Branch",
+					Title: localizer.Localize("Target Branch"),
 					Value: targetBranchName,
 					Short: true,
 				},
 				{
-					Title: "Source Branch",
+					Title: localizer.Localize("Source Branch"),
 					Value: sourceBranchName,
 					Short: true,
 				},
 				{
-					Title: "Reviewer(s)",
+					Title: localizer.Localize("Reviewer(s)"),
 					Value: reviewers,
 				},
 				{
-					Title: "Comment",
-					Value: comment.Content,
+					Title: localizer.Localize("Comment"),
+					Value: truncation.truncateComment(comment.Content),
 				},
 			},
 			Footer:     body.Resource.PullRequest.Repository.Name,
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 		}
 	case constants.SubscriptionEventCodePushed:
-		commits := ""
-		for i := 0; i < len(body.Resource.Commits); i++ {
-			commits += fmt.Sprintf("\n[%s](%s): **%s**", body.Resource.Commits[i].CommitID[0:8], body.Resource.Commits[i].URL, body.Resource.Commits[i].Comment)
-		}
-
-		if commits == "" {
-			commits = "None" // When no commits are present
-		}
-
 		attachment = &model.SlackAttachment{
 			Pretext:    body.Message.Markdown,
 			AuthorName: constants.SlackAttachmentAuthorNameRepos,
 			AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameReposIcon),
 			Color:      constants.IconColorRepos,
-			Title:      "Commit(s)",
-			Text:       commits,
-			Footer:     fmt.Sprintf("%s | %s", strings.Split(body.Resource.RefUpdates[0].Name, "/")[2], body.Resource.Repository.Name),
+			Title:      localizer.Localize("Commit(s)"),
+			Text:       getPushCommitsText(&body.Resource, localizer),
+			Footer:     fmt.Sprintf("%s | %s", getNotificationBranch(body), body.Resource.Repository.Name),
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameGitBranchIcon),
 		}
 	case constants.SubscriptionEventBuildCompleted:
 		startTime, err := time.Parse(constants.DateTimeLayout, strings.Split(body.Resource.StartTime, ".")[0])
 		if err != nil {
-			p.API.LogError(err.Error())
-			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
-			return
+			return nil, err
 		}
 
 		finishTime, err := time.Parse(constants.DateTimeLayout, strings.Split(body.Resource.FinishTime, ".")[0])
 		if err != nil {
-			p.API.LogError(err.Error())
-			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
-			return
+			return nil, err
 		}
 
 		attachment = &model.SlackAttachment{
@@ -793,22 +1221,22 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Build pipeline",
+					Title: localizer.Localize("Build pipeline"),
 					Value: body.Resource.Definition.Name,
 					Short: true,
 				},
 				{
-					Title: "Branch",
+					Title: localizer.Localize("Branch"),
 					Value: body.Resource.SourceBranch,
 					Short: true,
 				},
 				{
-					Title: "Requested for",
+					Title: localizer.Localize("Requested for"),
 					Value: body.Resource.RequestedFor.Name,
 					Short: true,
 				},
 				{
-					Title: "Duration",
+					Title: localizer.Localize("Duration"),
 					Value: time.Time{}.Add(finishTime.Sub(startTime)).Format(constants.TimeLayout),
 					Short: true,
 				},
@@ -826,7 +1254,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 		}
 
 		if artifacts == "" {
-			artifacts = "No artifacts"
+			artifacts = localizer.Localize("No artifacts")
 		}
 
 		attachment = &model.SlackAttachment{
@@ -836,22 +1264,22 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Release pipeline",
+					Title: localizer.Localize("Release pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Release.ReleaseDefinition.Name, body.Resource.Release.ReleaseDefinition.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Created by",
+					Title: localizer.Localize("Created by"),
 					Value: body.Resource.Release.CreatedBy.DisplayName,
 					Short: true,
 				},
 				{
-					Title: "Trigger reason",
+					Title: localizer.Localize("Trigger reason"),
 					Value: cases.Title(language.Und).String(body.Resource.Release.Reason),
 					Short: true,
 				},
 				{
-					Title: "Artifacts",
+					Title: localizer.Localize("Artifacts"),
 					Value: artifacts,
 					Short: true,
 				},
@@ -862,9 +1290,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 	case constants.SubscriptionEventReleaseAbandoned:
 		abandonTime, err := time.Parse(constants.DateTimeLayout, strings.Split(body.Resource.Release.ModifiedOn, ".")[0])
 		if err != nil {
-			p.API.LogError(err.Error())
-			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
-			return
+			return nil, err
 		}
 
 		attachment = &model.SlackAttachment{
@@ -874,18 +1300,18 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Release pipeline",
+					Title: localizer.Localize("Release pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Release.ReleaseDefinition.Name, body.Resource.Release.ReleaseDefinition.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Abandoned by",
+					Title: localizer.Localize("Abandoned by"),
 					Value: body.Resource.Release.ModifiedBy.DisplayName,
 					Short: true,
 				},
 				{
-					Title: "Abandoned on",
-					Value: abandonTime.Format(constants.DateTimeFormat),
+					Title: localizer.Localize("Abandoned on"),
+					Value: abandonTime.In(prefs.GetLocation()).Format(constants.DateTimeFormat),
 				},
 			},
 			Footer:     body.Resource.Project.Name,
@@ -899,12 +1325,12 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Release pipeline",
+					Title: localizer.Localize("Release pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Release.ReleaseDefinition.Name, body.Resource.Release.ReleaseDefinition.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Release",
+					Title: localizer.Localize("Release"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Release.Name, body.Resource.Release.Links.Web.Href),
 					Short: true,
 				},
@@ -915,7 +1341,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 	case constants.SubscriptionEventReleaseDeploymentCompleted:
 		comment := body.Resource.Comment.(string)
 		if comment == "" {
-			comment = "No comments"
+			comment = localizer.Localize("No comments")
 		}
 
 		attachment = &model.SlackAttachment{
@@ -925,18 +1351,18 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Release pipeline",
+					Title: localizer.Localize("Release pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Environment.ReleaseDefinition.Name, body.Resource.Environment.ReleaseDefinition.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Release",
+					Title: localizer.Localize("Release"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Environment.Release.Name, body.Resource.Environment.Release.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Comment",
-					Value: comment,
+					Title: localizer.Localize("Comment"),
+					Value: truncation.truncateComment(comment),
 				},
 			},
 			Footer:     body.Resource.Project.Name,
@@ -950,7 +1376,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Pipeline",
+					Title: localizer.Localize("Pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Pipeline.Name, body.Resource.Stage.Links.PipelineWeb.Href),
 					Short: true,
 				},
@@ -963,11 +1389,11 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			organization = webLinkPaths[3]
 		}
 
-		approverTitle := "Approver(s)"
+		approverTitle := localizer.Localize("Approver(s)")
 		if body.Resource.Approval.ExecutionOrder == "inSequence" {
-			approverTitle = "Approver(s) in sequence"
+			approverTitle = localizer.Localize("Approver(s) in sequence")
 		} else if body.Resource.Approval.MinRequiredApprovers > 0 && len(body.Resource.Approval.Steps) > body.Resource.Approval.MinRequiredApprovers {
-			approverTitle = fmt.Sprintf("Approvers (any %d)", body.Resource.Approval.MinRequiredApprovers)
+			approverTitle = localizer.Localizef("Approvers (any %d)", body.Resource.Approval.MinRequiredApprovers)
 		}
 
 		approvers := ""
@@ -982,12 +1408,12 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Run pipeline",
+					Title: localizer.Localize("Run pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Pipeline.Name, body.Resource.Pipeline.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Stage",
+					Title: localizer.Localize("Stage"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Stage.Name, body.Resource.Stage.Links.Web.Href),
 					Short: true,
 				},
@@ -1000,7 +1426,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 				{
 					Id:    constants.PipelineRequestIDApproved,
 					Type:  model.POST_ACTION_TYPE_BUTTON,
-					Name:  "Approve",
+					Name:  localizer.Localize("Approve"),
 					Style: "primary",
 					Integration: &model.PostActionIntegration{
 						URL: fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathPipelineCommentModal),
@@ -1016,7 +1442,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 				{
 					Id:    constants.PipelineRequestIDRejected,
 					Type:  model.POST_ACTION_TYPE_BUTTON,
-					Name:  "Reject",
+					Name:  localizer.Localize("Reject"),
 					Style: "danger",
 					Integration: &model.PostActionIntegration{
 						URL: fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathPipelineCommentModal),
@@ -1041,7 +1467,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 		}
 
 		if artifacts == "" {
-			artifacts = "No artifacts"
+			artifacts = localizer.Localize("No artifacts")
 		}
 
 		organization := ""
@@ -1056,25 +1482,29 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Release pipeline",
+					Title: localizer.Localize("Release pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Release.Name, body.Resource.Release.ReleaseDefinition.Links.Web.Href),
 					Short: true,
 				},
 				{
-					Title: "Artifacts",
+					Title: localizer.Localize("Artifacts"),
 					Value: artifacts,
 					Short: true,
 				},
 				{
-					Title: "Approver(s)",
+					Title: localizer.Localize("Approver(s)"),
 					Value: body.Resource.Approval.Approver.DisplayName,
 				},
+				{
+					Title: localizer.Localize("Approval"),
+					Value: fmt.Sprintf(constants.ReleaseApprovalLink, body.Resource.Release.Links.Web.Href),
+				},
 			},
 			Actions: []*model.PostAction{
 				{
 					Id:    constants.PipelineRequestIDApproved,
 					Type:  model.POST_ACTION_TYPE_BUTTON,
-					Name:  "Approve",
+					Name:  localizer.Localize("Approve"),
 					Style: "primary",
 					Integration: &model.PostActionIntegration{
 						URL: fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathPipelineCommentModal),
@@ -1090,7 +1520,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 				{
 					Id:    constants.PipelineRequestIDRejected,
 					Type:  model.POST_ACTION_TYPE_BUTTON,
-					Name:  "Reject",
+					Name:  localizer.Localize("Reject"),
 					Style: "danger",
 					Integration: &model.PostActionIntegration{
 						URL: fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathPipelineCommentModal),
@@ -1113,7 +1543,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Release pipeline",
+					Title: localizer.Localize("Release pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Release.Name, body.Resource.Release.Links.Web.Href),
 					Short: true,
 				},
@@ -1129,7 +1559,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Pipeline",
+					Title: localizer.Localize("Pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Pipeline.Name, body.Resource.Run.Links.PipelineWeb.Href),
 					Short: true,
 				},
@@ -1143,7 +1573,7 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Color:      constants.IconColorPipelines,
 			Fields: []*model.SlackAttachmentField{
 				{
-					Title: "Pipeline",
+					Title: localizer.Localize("Pipeline"),
 					Value: fmt.Sprintf("[%s](%s)", body.Resource.Pipeline.Name, body.Resource.Pipeline.Links.Web.Href),
 					Short: true,
 				},
@@ -1151,19 +1581,31 @@ func (p *Plugin) handleSubscriptionNotifications(w http.ResponseWriter, r *http.
 			Footer:     body.Resource.Project.Name,
 			FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 		}
+	default:
+		attachment = getNotificationMarkdownAttachment(body)
 	}
 
-	post := &model.Post{
-		UserId:    p.botUserID,
-		ChannelId: channelID,
-	}
-
-	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
-	if _, err := p.API.CreatePost(post); err != nil {
-		p.API.LogError("Error in creating post", "Error", err.Error())
+	if attachment != nil {
+		styleNotificationAttachment(attachment, body, localizer)
+		if workItemsField := p.getPullRequestWorkItemsField(subscription, body); workItemsField != nil {
+			workItemsField.Title = localizer.Localize(workItemsField.Title)
+			attachment.Fields = append(attachment.Fields, workItemsField)
+		}
+		truncation.truncateTitle(attachment)
+		p.addRerunBuildAction(attachment, subscription, body, localizer)
+		addOpenInAzureDevopsLink(attachment, body, localizer)
+		if subscription != nil {
+			addSubscriptionLabel(attachment, subscription.Label)
+		}
+		if prefs.IsEmojiShown() {
+			p.addNotificationEmoji(attachment, body)
+		}
+		if prefs.Color != "" {
+			attachment.Color = prefs.Color
+		}
 	}
 
-	returnStatusOK(w)
+	return attachment, nil
 }
 
 func (p *Plugin) handlePipelineCommentModal(w http.ResponseWriter, r *http.Request) {
@@ -1226,6 +1668,42 @@ func (p *Plugin) handlePipelineCommentModal(w http.ResponseWriter, r *http.Reque
 	p.returnPostActionIntegrationResponse(w, &model.PostActionIntegrationResponse{})
 }
 
+// handleDeleteWorkItem handles the buttons confirming or canceling the deletion of a work item and replaces the confirmation with the result
+func (p *Plugin) handleDeleteWorkItem(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	requestContext := postActionIntegrationRequest.Context
+	organization, _ := requestContext[constants.DeleteWorkItemContextOrganization].(string)
+	projectName, _ := requestContext[constants.DeleteWorkItemContextProjectName].(string)
+	workItemID, _ := requestContext[constants.DeleteWorkItemContextWorkItemID].(float64)
+	destroy, _ := requestContext[constants.DeleteWorkItemContextDestroy].(bool)
+	action, _ := requestContext[constants.DeleteWorkItemContextAction].(string)
+	if organization == "" || projectName == "" || workItemID <= 0 {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: "invalid work item"})
+		return
+	}
+
+	message := fmt.Sprintf(constants.WorkItemDeleteCanceled, int(workItemID))
+	if action == constants.DeleteWorkItemActionConfirm {
+		message = p.deleteWorkItem(mattermostUserID, organization, projectName, int(workItemID), destroy)
+	}
+
+	p.returnPostActionIntegrationResponse(w, &model.PostActionIntegrationResponse{
+		Update: &model.Post{
+			Id:        postActionIntegrationRequest.PostId,
+			UserId:    p.botUserID,
+			ChannelId: postActionIntegrationRequest.ChannelId,
+			Message:   message,
+		},
+	})
+}
+
 func (p *Plugin) handleDeleteSubscriptions(w http.ResponseWriter, r *http.Request) {
 	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
 	body, err := serializers.DeleteSubscriptionRequestPayloadFromJSON(r.Body)
@@ -1235,12 +1713,18 @@ func (p *Plugin) handleDeleteSubscriptions(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	body.ChannelID = normalizeChannelID(body.ChannelID)
 	if validationErr := body.IsSubscriptionRequestPayloadValid(); validationErr != nil {
 		p.API.LogDebug("Request payload is not valid", "Error", validationErr.Error())
 		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: validationErr.Error()})
 		return
 	}
 
+	if _, statusCode, channelErr := p.getValidChannel(body.ChannelID); channelErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: channelErr.Error()})
+		return
+	}
+
 	subscriptionList, err := p.Store.GetAllSubscriptions(body.MMUserID)
 	if err != nil {
 		p.API.LogError(constants.FetchSubscriptionListError, "Error", err.Error())
@@ -1248,7 +1732,36 @@ func (p *Plugin) handleDeleteSubscriptions(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	subscription, isSubscriptionPresent := p.IsSubscriptionPresent(subscriptionList, &serializers.SubscriptionDetails{
+	subscription, isSubscriptionPresent := p.findSubscriptionToDelete(subscriptionList, body)
+	if !isSubscriptionPresent {
+		p.API.LogError(constants.SubscriptionNotFound)
+		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.SubscriptionNotFound})
+		return
+	}
+
+	statusCode, deleteErr := p.deleteSubscription(subscription, mattermostUserID)
+	if deleteErr != nil {
+		p.API.LogError(constants.DeleteSubscriptionError, "Error", deleteErr.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: deleteErr.Error()})
+		return
+	}
+
+	returnStatusOK(w)
+}
+
+// findSubscriptionToDelete finds the subscription by the Azure DevOps subscription ID stored with it when the ID is sent,
+// so that it's found even if its other fields stored in the KV store no longer match the ones in Azure DevOps
+func (p *Plugin) findSubscriptionToDelete(subscriptionList []*serializers.SubscriptionDetails, body *serializers.DeleteSubscriptionRequestPayload) (*serializers.SubscriptionDetails, bool) {
+	if body.SubscriptionID != "" {
+		for _, subscription := range subscriptionList {
+			if subscription.SubscriptionID == body.SubscriptionID && subscription.ChannelID == body.ChannelID {
+				return subscription, true
+			}
+		}
+		return nil, false
+	}
+
+	return p.IsSubscriptionPresent(subscriptionList, &serializers.SubscriptionDetails{
 		OrganizationName:             body.Organization,
 		ProjectName:                  body.Project,
 		ChannelID:                    body.ChannelID,
@@ -1261,6 +1774,7 @@ func (p *Plugin) handleDeleteSubscriptions(w http.ResponseWriter, r *http.Reques
 		MergeResult:                  body.MergeResult,
 		NotificationType:             body.NotificationType,
 		AreaPath:                     body.AreaPath,
+		WorkItemType:                 body.WorkItemType,
 		BuildStatus:                  body.BuildStatus,
 		BuildPipeline:                body.BuildPipeline,
 		StageName:                    body.StageName,
@@ -1277,20 +1791,6 @@ func (p *Plugin) handleDeleteSubscriptions(w http.ResponseWriter, r *http.Reques
 		RunStateID:                   body.RunStateID,
 		RunResultID:                  body.RunResultID,
 	})
-	if !isSubscriptionPresent {
-		p.API.LogError(constants.SubscriptionNotFound)
-		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.SubscriptionNotFound})
-		return
-	}
-
-	statusCode, deleteErr := p.deleteSubscription(subscription, mattermostUserID)
-	if deleteErr != nil {
-		p.API.LogError(constants.DeleteSubscriptionError, "Error", deleteErr.Error())
-		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: deleteErr.Error()})
-		return
-	}
-
-	returnStatusOK(w)
 }
 
 func (p *Plugin) checkOAuth(handler http.HandlerFunc) http.HandlerFunc {
@@ -1351,6 +1851,20 @@ func (p *Plugin) handleAuthRequired(handleFunc http.HandlerFunc) http.HandlerFun
 	}
 }
 
+// checkWebhookPathPrefix verifies that the prefix of the notification path is the configured one.
+// The prefix is checked on each request as the configuration can change after the routes are registered.
+func (p *Plugin) checkWebhookPathPrefix(handleFunc http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhookPathPrefix := p.getConfiguration().WebhookPathPrefix
+		if webhookPathPrefix == "" || mux.Vars(r)[constants.PathParamWebhookPrefix] != webhookPathPrefix {
+			http.NotFound(w, r)
+			return
+		}
+
+		handleFunc(w, r)
+	}
+}
+
 func (p *Plugin) handleError(w http.ResponseWriter, r *http.Request, error *serializers.Error) {
 	w.Header().Add("Content-Type", "application/json")
 	w.WriteHeader(error.Code)
@@ -1393,7 +1907,30 @@ func (p *Plugin) handleGetUserAccountDetails(w http.ResponseWriter, r *http.Requ
 		&model.WebsocketBroadcast{UserId: mattermostUserID},
 	)
 
-	p.writeJSON(w, &userDetails)
+	p.writeJSON(w, p.getUserAccountDetails(mattermostUserID, userDetails))
+}
+
+// getUserAccountDetails adds the organizations connected, and the number of projects linked and subscriptions created by a user to the user details without their tokens.
+// The user details are still returned if the counts can't be loaded, with a flag set instead.
+func (p *Plugin) getUserAccountDetails(mattermostUserID string, user *serializers.User) *serializers.UserAccountDetails {
+	accountDetails := user.GetAccountDetails()
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		p.API.LogWarn(constants.ErrorFetchProjectList, "Error", err.Error())
+		accountDetails.CountsUnavailable = true
+		return accountDetails
+	}
+
+	subscriptionList, err := p.Store.GetAllSubscriptions(mattermostUserID)
+	if err != nil {
+		p.API.LogWarn(constants.FetchSubscriptionListError, "Error", err.Error())
+		accountDetails.CountsUnavailable = true
+		return accountDetails
+	}
+
+	accountDetails.LinkedProjectCount = len(projectList)
+	accountDetails.SubscriptionCount = len(subscriptionList)
+	return accountDetails
 }
 
 func (p *Plugin) handlePipelineApproveOrRejectReleaseRequest(w http.ResponseWriter, r *http.Request) {
@@ -1435,12 +1972,7 @@ func (p *Plugin) handlePipelineApproveOrRejectReleaseRequest(w http.ResponseWrit
 		comments = submitRequest.Submission[constants.DialogFieldNameComment].(string)
 	}
 
-	pipelineApproveRequestPayload := &serializers.PipelineApproveRequest{
-		Status:   requestType,
-		Comments: comments,
-	}
-
-	statusCode, updatePipelineApprovalRequestErr := p.Client.UpdatePipelineApprovalRequest(pipelineApproveRequestPayload, organization, projectName, mattermostUserID, int(approvalID))
+	statusCode, updatePipelineApprovalRequestErr := p.Client.SetReleaseApproval(organization, projectName, int(approvalID), requestType, comments, mattermostUserID)
 	switch statusCode {
 	case http.StatusOK:
 		if err := p.UpdatePipelineReleaseApprovalPost(requestType, submitRequest.CallbackId, mattermostUserID); err != nil {
@@ -1449,14 +1981,14 @@ func (p *Plugin) handlePipelineApproveOrRejectReleaseRequest(w http.ResponseWrit
 			return
 		}
 	case http.StatusBadRequest:
-		pipelineApprovalDetails, statusCode, err := p.Client.GetApprovalDetails(organization, projectName, mattermostUserID, int(approvalID))
+		releaseApproval, statusCode, err := p.Client.GetReleaseApproval(organization, projectName, int(approvalID), mattermostUserID)
 		if err != nil {
 			p.handlePipelineApprovalRequestUpdateError(constants.ErrorUpdatingPipelineApprovalRequest, mattermostUserID, err)
 			p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
 			return
 		}
 
-		if err := p.UpdatePipelineReleaseApprovalPost(pipelineApprovalDetails.Status, submitRequest.CallbackId, mattermostUserID); err != nil {
+		if err := p.UpdatePipelineReleaseApprovalPost(releaseApproval.Status, submitRequest.CallbackId, mattermostUserID); err != nil {
 			p.handlePipelineApprovalRequestUpdateError(constants.ErrorUpdatingPipelineApprovalRequest, mattermostUserID, err)
 			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
 			return
@@ -1465,9 +1997,24 @@ func (p *Plugin) handlePipelineApproveOrRejectReleaseRequest(w http.ResponseWrit
 		alreadyUpdatedInformationPost := &model.Post{
 			UserId:    p.botUserID,
 			ChannelId: submitRequest.ChannelId,
-			Message:   "This deployment approval pending request has already been processed.",
+			Message:   constants.ReleaseApprovalAlreadyProcessed,
 		}
 		_ = p.API.SendEphemeralPost(mattermostUserID, alreadyUpdatedInformationPost)
+	case http.StatusForbidden:
+		// The buttons are shown to everyone in the channel, so the users who are not approvers are told who can approve instead
+		message := constants.ReleaseApprovalNotAllowed
+		if releaseApproval, _, err := p.Client.GetReleaseApproval(organization, projectName, int(approvalID), mattermostUserID); err != nil {
+			p.API.LogDebug("Error in getting the release approval", "Error", err.Error())
+		} else if releaseApproval.Approver.DisplayName != "" {
+			message = fmt.Sprintf("%s %s", message, fmt.Sprintf(constants.ReleaseApprovalApprover, releaseApproval.Approver.DisplayName))
+		}
+
+		notAllowedPost := &model.Post{
+			UserId:    p.botUserID,
+			ChannelId: submitRequest.ChannelId,
+			Message:   message,
+		}
+		_ = p.API.SendEphemeralPost(mattermostUserID, notAllowedPost)
 
 	default:
 		p.handlePipelineApprovalRequestUpdateError(constants.GenericErrorMessage, mattermostUserID, updatePipelineApprovalRequestErr)
@@ -1605,6 +2152,39 @@ func (p *Plugin) handleGetSubscriptionFilterPossibleValues(w http.ResponseWriter
 	p.writeJSON(w, filterwiseResponse)
 }
 
+// handleGetUserChannelsForTeam returns the channels of a team in which the user can create a subscription
+func (p *Plugin) handleGetUserChannelsForTeam(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	teamID := mux.Vars(r)[constants.PathParamTeamID]
+	if !model.IsValidId(teamID) {
+		p.API.LogWarn("Invalid team id")
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	channels, statusCode, err := p.getUserChannelsForTeam(teamID, mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.GetChannelError, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	p.writeJSON(w, filterChannelsForSubscription(channels))
+}
+
+// handleGetUserChannels returns the channels from all the teams of the user in which the user can create a subscription
+func (p *Plugin) handleGetUserChannels(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	channels, statusCode, err := p.getUserChannelsForAllTeams(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.GetChannelError, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	p.writeJSON(w, filterChannelsForSubscription(channels))
+}
+
 func (p *Plugin) writeJSON(w http.ResponseWriter, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	b, err := json.Marshal(v)
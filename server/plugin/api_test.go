@@ -9,11 +9,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"bou.ke/monkey"
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
@@ -52,6 +55,65 @@ func TestInitRoutes(t *testing.T) {
 	p.InitRoutes()
 }
 
+func TestSubscriptionNotificationsRoutes(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	p.InitRoutes()
+	for _, testCase := range []struct {
+		description        string
+		webhookPathPrefix  string
+		path               string
+		expectedStatusCode int
+	}{
+		{
+			description:        "SubscriptionNotificationsRoutes: path without a prefix",
+			path:               constants.PathSubscriptionNotifications,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			description:        "SubscriptionNotificationsRoutes: prefixed path when no prefix is configured",
+			path:               "/mock/hooks/notification",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "SubscriptionNotificationsRoutes: path with the configured prefix",
+			webhookPathPrefix:  "mock/hooks",
+			path:               "/mock/hooks/notification",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			description:        "SubscriptionNotificationsRoutes: path without a prefix when a prefix is configured",
+			webhookPathPrefix:  "mock/hooks",
+			path:               constants.PathSubscriptionNotifications,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			description:        "SubscriptionNotificationsRoutes: path with a different prefix",
+			webhookPathPrefix:  "mock/hooks",
+			path:               "/mock/notification",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			p.setConfiguration(&config.Configuration{WebhookPathPrefix: testCase.webhookPathPrefix})
+
+			// Reaching the handler is verified by the response to a request which is not signed by Azure DevOps
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return "", http.StatusUnauthorized, errors.New(constants.ErrorUnauthorisedSubscriptionsWebhookRequest)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s%s?%s=%s", constants.APIPrefix, testCase.path, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(`{}`))
+
+			w := httptest.NewRecorder()
+			p.router.ServeHTTP(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
 func TestWithRecovery(t *testing.T) {
 	defer func() {
 		if x := recover(); x != nil {
@@ -108,7 +170,9 @@ func TestHandleCreateTask(t *testing.T) {
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
 	mockedClient := mocks.NewMockClient(mockCtrl)
-	p := setupMockPlugin(mockAPI, nil, mockedClient)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	mockedStore.EXPECT().StoreTaskPostMapping(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
 	for _, testCase := range []struct {
 		description        string
 		body               string
@@ -201,495 +265,1961 @@ func TestHandleCreateTask(t *testing.T) {
 	}
 }
 
-func TestHandleLink(t *testing.T) {
+func TestHandleUpdateTask(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
 	mockedClient := mocks.NewMockClient(mockCtrl)
-	mockedStore := mocks.NewMockKVStore(mockCtrl)
-	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p := setupMockPlugin(mockAPI, nil, mockedClient)
 	for _, testCase := range []struct {
-		description     string
-		body            string
-		err             error
-		statusCode      int
-		projectList     []serializers.ProjectDetails
-		project         serializers.ProjectDetails
-		isProjectLinked bool
+		description        string
+		taskID             string
+		body               string
+		clientError        error
+		statusCode         int
+		expectedStatusCode int
 	}{
 		{
-			description: "HandleLink: valid",
+			description: "UpdateTask: valid fields",
+			taskID:      "1",
 			body: `{
 				"organization": "mockOrganization",
-				"project": "mockProject"
+				"project": "mockProjectName",
+				"fields": {
+					"System.State": "mockState"
+					}
 				}`,
-			statusCode:  http.StatusOK,
-			projectList: testutils.GetProjectDetailsPayload(),
-			project:     testutils.GetProjectDetailsPayload()[0],
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
 		},
 		{
-			description: "HandleLink: empty body",
-			body:        `{}`,
-			err:         errors.New("error empty body"),
-			statusCode:  http.StatusBadRequest,
+			description: "UpdateTask: fields are cleared",
+			taskID:      "1",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"fields": {
+					"System.Description": "",
+					"System.AssignedTo": {"uniqueName": ""}
+					}
+				}`,
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
 		},
 		{
-			description: "HandleLink: invalid body",
+			description: "UpdateTask: title is cleared",
+			taskID:      "1",
 			body: `{
 				"organization": "mockOrganization",
-				"project": "mockProject",`,
-			err:        errors.New("error invalid body"),
-			statusCode: http.StatusBadRequest,
+				"project": "mockProjectName",
+				"fields": {
+					"System.Title": " "
+					}
+				}`,
+			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleLink: missing fields",
+			description:        "UpdateTask: task ID is not numeric",
+			taskID:             "mockTaskID",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "UpdateTask: invalid body",
+			taskID:      "1",
 			body: `{
 				"organization": "mockOrganization",
+				"project": "mockProjectName",`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "UpdateTask: missing fields",
+			taskID:      "1",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName"
 				}`,
-			err:        errors.New("error missing fields"),
-			statusCode: http.StatusBadRequest,
+			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleLink: project is already linked",
+			description: "UpdateTask: error while updating the task",
+			taskID:      "1",
 			body: `{
 				"organization": "mockOrganization",
-				"project": "mockProject"
+				"project": "mockProjectName",
+				"fields": {
+					"System.Title": "mockTitle"
+					}
 				}`,
-			statusCode:      http.StatusOK,
-			projectList:     testutils.GetProjectDetailsPayload(),
-			isProjectLinked: true,
+			clientError:        errors.New("error while updating the task"),
+			statusCode:         http.StatusNotFound,
+			expectedStatusCode: http.StatusNotFound,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
-			mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
-			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
-
-			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsProjectLinked", func(*Plugin, []serializers.ProjectDetails, serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
-				return &serializers.ProjectDetails{}, testCase.isProjectLinked
-			})
 
-			if testCase.statusCode == http.StatusOK {
-				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
-				if !testCase.isProjectLinked {
-					mockedClient.EXPECT().Link(gomock.Any(), gomock.Any()).Return(&serializers.Project{}, testCase.statusCode, testCase.err)
-					mockedStore.EXPECT().StoreProject(&serializers.ProjectDetails{
-						MattermostUserID: testutils.MockMattermostUserID,
-						ProjectName:      "Mockproject",
-						OrganizationName: "mockorganization",
-					}).Return(nil)
-				}
+			if testCase.statusCode != 0 {
+				mockedClient.EXPECT().UpdateTask("mockOrganization", "mockProjectName", testCase.taskID, gomock.Any(), testutils.MockMattermostUserID).Return(&serializers.TaskValue{}, testCase.statusCode, testCase.clientError)
 			}
 
-			req := httptest.NewRequest(http.MethodPost, "/link", bytes.NewBufferString(testCase.body))
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/"+testCase.taskID, bytes.NewBufferString(testCase.body))
 			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+			req = mux.SetURLVars(req, map[string]string{constants.PathParamTaskID: testCase.taskID})
 
 			w := httptest.NewRecorder()
-			p.handleLink(w, req)
+			p.handleUpdateTask(w, req)
 			resp := w.Result()
-			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
 		})
 	}
 }
 
-func TestHandleDeleteAllSubscriptions(t *testing.T) {
+func TestHandleCreateTaskWithDefaultOrganization(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
 	mockedClient := mocks.NewMockClient(mockCtrl)
 	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	mockedStore.EXPECT().StoreTaskPostMapping(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.setConfiguration(&config.Configuration{
+		DefaultOrganization: "mockdefaultorganization",
+	})
+
 	for _, testCase := range []struct {
-		description            string
-		userID                 string
-		projectID              string
-		err                    error
-		statusCode             int
-		getAllSubscriptionsErr error
-		subscriptionList       []*serializers.SubscriptionDetails
-		expectedErrorMessage   string
+		description string
+		body        string
 	}{
 		{
-			description: "HandleDeleteAllSubscriptions: valid",
-			userID:      testutils.MockMattermostUserID,
-			projectID:   testutils.MockProjectID,
-			statusCode:  http.StatusOK,
-			subscriptionList: []*serializers.SubscriptionDetails{
-				{
-					MattermostUserID: testutils.MockMattermostUserID,
-					ProjectID:        testutils.MockProjectID,
-					OrganizationName: testutils.MockOrganization,
-					EventType:        testutils.MockEventType,
-					ChannelID:        testutils.MockChannelID,
-					SubscriptionID:   testutils.MockSubscriptionID,
-				},
-			},
-		},
-		{
-			description:            "HandleDeleteAllSubscriptions: GetAllSubscriptions gives error",
-			userID:                 "mockMattermostUserID",
-			projectID:              "mockProjectID",
-			statusCode:             http.StatusInternalServerError,
-			getAllSubscriptionsErr: errors.New("error in getting subscriptions"),
-			expectedErrorMessage:   "error in getting subscriptions",
+			description: "CreateTask: organization provided by the user is overridden",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"type": "mockType",
+				"fields": {
+					"title": "mockTitle"
+					}
+				}`,
 		},
 		{
-			description: "HandleDeleteAllSubscriptions: DeleteSubscription gives error",
-			userID:      testutils.MockMattermostUserID,
-			projectID:   testutils.MockProjectID,
-			statusCode:  http.StatusInternalServerError,
-			subscriptionList: []*serializers.SubscriptionDetails{
-				{
-					MattermostUserID: testutils.MockMattermostUserID,
-					ProjectID:        testutils.MockProjectID,
-					OrganizationName: testutils.MockOrganization,
-					EventType:        testutils.MockEventType,
-					ChannelID:        testutils.MockChannelID,
-					SubscriptionID:   testutils.MockSubscriptionID,
-				},
-			},
-			err:                  errors.New("error in deleting subscription"),
-			expectedErrorMessage: "error in deleting subscription",
+			description: "CreateTask: organization is not provided by the user",
+			body: `{
+				"project": "mockProjectName",
+				"type": "mockType",
+				"fields": {
+					"title": "mockTitle"
+					}
+				}`,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
-			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
-
-			mockedStore.EXPECT().GetAllSubscriptions(testCase.userID).Return(testCase.subscriptionList, testCase.getAllSubscriptionsErr)
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
 
-			if testCase.getAllSubscriptionsErr == nil {
-				mockedClient.EXPECT().DeleteSubscription(gomock.Any(), gomock.Any(), gomock.Any()).Return(testCase.statusCode, testCase.err)
-				if testCase.err == nil {
-					mockedStore.EXPECT().DeleteSubscription(gomock.Any()).Return(nil)
-					mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(gomock.Any()).Return(nil)
-				}
-			}
+			mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(func(body *serializers.CreateTaskRequestPayload, _ string) (*serializers.TaskValue, int, error) {
+				assert.Equal(t, "mockdefaultorganization", body.Organization)
+				return &serializers.TaskValue{}, http.StatusOK, nil
+			})
 
-			statusCode, err := p.handleDeleteAllSubscriptions(testCase.userID, testCase.projectID)
-			assert.Equal(t, testCase.statusCode, statusCode)
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(testCase.body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
 
-			if testCase.err != nil || testCase.getAllSubscriptionsErr != nil {
-				assert.EqualError(t, err, testCase.expectedErrorMessage)
-			} else {
-				assert.Nil(t, err)
-			}
+			w := httptest.NewRecorder()
+			p.handleCreateTask(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
 		})
 	}
 }
 
-func TestHandleGetAllLinkedProjects(t *testing.T) {
+func TestHandleCreateTaskWithMaxDescriptionLength(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
 	mockedStore := mocks.NewMockKVStore(mockCtrl)
-	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	mockedStore.EXPECT().StoreTaskPostMapping(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.setConfiguration(&config.Configuration{
+		MaxDescriptionLength: 10,
+	})
+
+	t.Run("CreateTask: description exceeding the maximum length is rejected", func(t *testing.T) {
+		body := `{
+			"organization": "mockOrganization",
+			"project": "mockProjectName",
+			"type": "mockType",
+			"fields": {
+				"title": "mockTitle",
+				"description": "mockDescriptionTooLong"
+				}
+			}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
+		req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+		// The client is not expected to be called
+		w := httptest.NewRecorder()
+		p.handleCreateTask(w, req)
+		resp := w.Result()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, fmt.Sprintf(constants.DescriptionTooLong, 22, 10), response[constants.Error])
+	})
+
+	t.Run("CreateTask: trailing whitespace is not counted in the description length", func(t *testing.T) {
+		mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+		mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+		mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(func(body *serializers.CreateTaskRequestPayload, _ string) (*serializers.TaskValue, int, error) {
+			assert.Equal(t, "mockDesc", body.Fields.Description)
+			return &serializers.TaskValue{}, http.StatusOK, nil
+		})
+
+		body := `{
+			"organization": "mockOrganization",
+			"project": "mockProjectName",
+			"type": "mockType",
+			"fields": {
+				"title": "mockTitle",
+				"description": "mockDesc  \n\n  "
+				}
+			}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
+		req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+		w := httptest.NewRecorder()
+		p.handleCreateTask(w, req)
+		resp := w.Result()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestHandleCreateTaskWithRequiredFields(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	mockedStore.EXPECT().StoreTaskPostMapping(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.setConfiguration(&config.Configuration{
+		RequiredTaskFields: "Bug=description,areaPath; User Story=description",
+	})
+
+	mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+	mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+
 	for _, testCase := range []struct {
-		description string
-		projectList []serializers.ProjectDetails
-		err         error
-		statusCode  int
+		description     string
+		taskType        string
+		fields          string
+		statusCode      int
+		expectedMessage string
 	}{
 		{
-			description: "HandleGetAllLinkedProjects: valid",
-			projectList: []serializers.ProjectDetails{},
-			statusCode:  http.StatusOK,
+			description:     "CreateTask: required fields are missing",
+			taskType:        "Bug",
+			fields:          `"title": "mockTitle", "description": "  "`,
+			statusCode:      http.StatusBadRequest,
+			expectedMessage: fmt.Sprintf(constants.RequiredTaskFieldsMissing, "Bug", "description, areaPath"),
 		},
 		{
-			description: "HandleGetAllLinkedProjects: error while fetching project list",
-			err:         errors.New("error while fetching project list"),
-			statusCode:  http.StatusInternalServerError,
+			description:     "CreateTask: work item type is matched case insensitively",
+			taskType:        "user story",
+			fields:          `"title": "mockTitle", "areaPath": "mockAreaPath"`,
+			statusCode:      http.StatusBadRequest,
+			expectedMessage: fmt.Sprintf(constants.RequiredTaskFieldsMissing, "user story", "description"),
 		},
 		{
-			description: "HandleGetAllLinkedProjects: empty project list",
+			description: "CreateTask: required fields are present",
+			taskType:    "Bug",
+			fields:      `"title": "mockTitle", "description": "mockDescription", "areaPath": "mockAreaPath"`,
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "CreateTask: work item type without required fields",
+			taskType:    "Task",
+			fields:      `"title": "mockTitle"`,
 			statusCode:  http.StatusOK,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
-			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
-
-			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, testCase.err)
+			if testCase.statusCode == http.StatusOK {
+				mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(&serializers.TaskValue{}, http.StatusOK, nil)
+			}
 
-			req := httptest.NewRequest(http.MethodGet, "/project/link", bytes.NewBufferString(`{}`))
+			body := fmt.Sprintf(`{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"type": %q,
+				"fields": {%s}
+				}`, testCase.taskType, testCase.fields)
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
 			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
 
 			w := httptest.NewRecorder()
-			p.handleGetAllLinkedProjects(w, req)
+			p.handleCreateTask(w, req)
 			resp := w.Result()
 			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+
+			if testCase.expectedMessage != "" {
+				var response map[string]string
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+				assert.Equal(t, testCase.expectedMessage, response[constants.Error])
+			}
 		})
 	}
 }
 
-func TestHandleUnlinkProject(t *testing.T) {
+func TestHandleCreateTaskWithEstimates(t *testing.T) {
 	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description           string
+		taskType              string
+		fields                string
+		statusCode            int
+		expectedMessage       string
+		expectedStoryPoints   *float64
+		expectedRemainingWork *float64
+		expectedPostMessage   string
+	}{
+		{
+			description:         "CreateTask: story points of a user story are set",
+			taskType:            "User Story",
+			fields:              `"title": "mockTitle", "storyPoints": 3`,
+			statusCode:          http.StatusOK,
+			expectedStoryPoints: func(value float64) *float64 { return &value }(3),
+		},
+		{
+			description:           "CreateTask: remaining work of a task is set",
+			taskType:              "task",
+			fields:                `"title": "mockTitle", "remainingWork": 0`,
+			statusCode:            http.StatusOK,
+			expectedRemainingWork: func(value float64) *float64 { return &value }(0),
+		},
+		{
+			description:         "CreateTask: estimate which does not apply to the work item type is not set",
+			taskType:            "Task",
+			fields:              `"title": "mockTitle", "storyPoints": 3, "effort": 2`,
+			statusCode:          http.StatusOK,
+			expectedPostMessage: fmt.Sprintf(constants.TaskEstimatesNotSet, "storyPoints, effort", "Task"),
+		},
+		{
+			description:         "CreateTask: estimates of a custom work item type are set",
+			taskType:            "mockType",
+			fields:              `"title": "mockTitle", "storyPoints": 1.5`,
+			statusCode:          http.StatusOK,
+			expectedStoryPoints: func(value float64) *float64 { return &value }(1.5),
+		},
+		{
+			description:     "CreateTask: negative estimate",
+			taskType:        "Task",
+			fields:          `"title": "mockTitle", "remainingWork": -1`,
+			statusCode:      http.StatusBadRequest,
+			expectedMessage: fmt.Sprintf(constants.InvalidTaskEstimate, constants.TaskFieldRemainingWork),
+		},
+		{
+			description: "CreateTask: estimate which is not a number",
+			taskType:    "User Story",
+			fields:      `"title": "mockTitle", "storyPoints": "three"`,
+			statusCode:  http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			mockedStore.EXPECT().StoreTaskPostMapping(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Maybe()
+			mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+			postMessage := ""
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+				postMessage = args.Get(0).(*model.Post).Attachments()[0].Text
+			}).Return(&model.Post{}, nil)
+
+			if testCase.statusCode == http.StatusOK {
+				mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(func(body *serializers.CreateTaskRequestPayload, _ string) (*serializers.TaskValue, int, error) {
+					assert.Equal(t, testCase.expectedStoryPoints, body.Fields.StoryPoints)
+					assert.Equal(t, testCase.expectedRemainingWork, body.Fields.RemainingWork)
+					assert.Nil(t, body.Fields.Effort)
+					return &serializers.TaskValue{}, http.StatusOK, nil
+				})
+			}
+
+			body := fmt.Sprintf(`{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"type": %q,
+				"fields": {%s}
+				}`, testCase.taskType, testCase.fields)
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateTask(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+
+			if testCase.expectedMessage != "" {
+				var response map[string]string
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+				assert.Equal(t, testCase.expectedMessage, response[constants.Error])
+			}
+			if testCase.expectedPostMessage != "" {
+				assert.Contains(t, postMessage, testCase.expectedPostMessage)
+			}
+		})
+	}
+}
+
+func TestHandleCreateTaskStoresTaskPost(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description string
+		postErr     *model.AppError
+		storeErr    error
+	}{
+		{
+			description: "CreateTask: post of the created task is stored",
+		},
+		{
+			description: "CreateTask: post of the created task is not stored if the DM fails",
+			postErr:     &model.AppError{Message: "mockError"},
+		},
+		{
+			description: "CreateTask: error while storing the post of the created task",
+			storeErr:    errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Maybe()
+			mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+			if testCase.postErr != nil {
+				mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, testCase.postErr)
+			} else {
+				mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+				mockedStore.EXPECT().StoreTaskPostMapping("mockOrganization", "mockProjectName", "1", "mockPostID").Return(testCase.storeErr)
+			}
+
+			mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(&serializers.TaskValue{ID: 1}, http.StatusOK, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"type": "mockType",
+				"fields": {
+					"title": "mockTitle"
+					}
+				}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateTask(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleCreateTaskWorkItemURL(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	expectedURL := "https://dev.azure.com/mockOrganization/mock%20Project%20Name/_workitems/edit/1"
+	mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+	mockAPI.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+		attachments, ok := post.GetProp("attachments").([]*model.SlackAttachment)
+		return ok && len(attachments) == 1 && strings.Contains(attachments[0].Text, "("+expectedURL+")")
+	})).Return(&model.Post{Id: "mockPostID"}, nil)
+	mockedStore.EXPECT().StoreTaskPostMapping("mockOrganization", "mock Project Name", "1", "mockPostID").Return(nil)
+	mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(&serializers.TaskValue{ID: 1}, http.StatusOK, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{
+		"organization": "mockOrganization",
+		"project": "mock Project Name",
+		"type": "mockType",
+		"fields": {
+			"title": "mockTitle"
+			}
+		}`))
+	req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+	w := httptest.NewRecorder()
+	p.handleCreateTask(w, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&response))
+	assert.Equal(t, expectedURL, response["workItemURL"])
+	assert.Equal(t, float64(1), response["id"])
+	mockAPI.AssertExpectations(t)
+}
+
+func TestHandleLink(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	for _, testCase := range []struct {
+		description     string
+		body            string
+		err             error
+		statusCode      int
+		projectList     []serializers.ProjectDetails
+		project         serializers.ProjectDetails
+		isProjectLinked bool
+	}{
+		{
+			description: "HandleLink: valid",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProject"
+				}`,
+			statusCode:  http.StatusOK,
+			projectList: testutils.GetProjectDetailsPayload(),
+			project:     testutils.GetProjectDetailsPayload()[0],
+		},
+		{
+			description: "HandleLink: empty body",
+			body:        `{}`,
+			err:         errors.New("error empty body"),
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			description: "HandleLink: invalid body",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProject",`,
+			err:        errors.New("error invalid body"),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleLink: missing fields",
+			body: `{
+				"organization": "mockOrganization",
+				}`,
+			err:        errors.New("error missing fields"),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleLink: project is already linked",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProject"
+				}`,
+			statusCode:      http.StatusOK,
+			projectList:     testutils.GetProjectDetailsPayload(),
+			isProjectLinked: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("GetDirectChannel", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.Channel{}, nil)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsProjectLinked", func(*Plugin, []serializers.ProjectDetails, serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
+				return &serializers.ProjectDetails{}, testCase.isProjectLinked
+			})
+
+			if testCase.statusCode == http.StatusOK {
+				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
+				if !testCase.isProjectLinked {
+					mockedClient.EXPECT().Link(gomock.Any(), gomock.Any()).Return(&serializers.Project{ID: "mockProjectID2", Name: "MockProject"}, testCase.statusCode, testCase.err)
+					mockedStore.EXPECT().StoreProject(&serializers.ProjectDetails{
+						MattermostUserID: testutils.MockMattermostUserID,
+						ProjectID:        "mockProjectID2",
+						ProjectName:      "MockProject",
+						OrganizationName: "mockorganization",
+					}).Return(nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/link", bytes.NewBufferString(testCase.body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleLink(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleLinkNormalization(t *testing.T) {
+	linkedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: "mockorganization", ProjectName: "MockProject", ProjectID: testutils.MockProjectID}
+	for _, testCase := range []struct {
+		description        string
+		body               string
+		projectList        []serializers.ProjectDetails
+		linkResponse       *serializers.Project
+		linkStatusCode     int
+		linkErr            error
+		expectedLink       bool
+		expectedStore      *serializers.ProjectDetails
+		expectedStatusCode int
+	}{
+		{
+			description:        "HandleLinkNormalization: project linked under a name differing in case and spaces",
+			body:               `{"organization": " MockOrganization ", "project": "mockproject "}`,
+			projectList:        []serializers.ProjectDetails{linkedProject},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleLinkNormalization: project linked under a previous name",
+			body:               `{"organization": "mockOrganization", "project": "mockRenamedProject"}`,
+			projectList:        []serializers.ProjectDetails{linkedProject},
+			linkResponse:       &serializers.Project{ID: testutils.MockProjectID, Name: "mockRenamedProject"},
+			linkStatusCode:     http.StatusOK,
+			expectedLink:       true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleLinkNormalization: project is stored with its ID and name in Azure DevOps",
+			body:               `{"organization": " MockOrganization", "project": " mockproject "}`,
+			linkResponse:       &serializers.Project{ID: testutils.MockProjectID, Name: "MockProject"},
+			linkStatusCode:     http.StatusOK,
+			expectedLink:       true,
+			expectedStore:      &linkedProject,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleLinkNormalization: project doesn't exist in Azure DevOps",
+			body:               `{"organization": "mockOrganization", "project": "mockProject"}`,
+			linkStatusCode:     http.StatusNotFound,
+			linkErr:            errors.New("failed to link Project"),
+			expectedLink:       true,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleLinkNormalization: project without an ID in the response",
+			body:               `{"organization": "mockOrganization", "project": "mockProject"}`,
+			linkResponse:       &serializers.Project{},
+			linkStatusCode:     http.StatusOK,
+			expectedLink:       true,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
+			if testCase.expectedLink {
+				mockedClient.EXPECT().Link(gomock.Any(), testutils.MockMattermostUserID).Return(testCase.linkResponse, testCase.linkStatusCode, testCase.linkErr)
+			}
+			if testCase.expectedStore != nil {
+				mockedStore.EXPECT().StoreProject(testCase.expectedStore).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/link", bytes.NewBufferString(testCase.body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleLink(w, req)
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedStatusCode == http.StatusNotFound {
+				assert.Contains(t, w.Body.String(), constants.ProjectNotFound)
+			}
+		})
+	}
+}
+
+func TestHandleLinkWithMaxLinkedProjects(t *testing.T) {
+	defer monkey.UnpatchAll()
+	projectList := []serializers.ProjectDetails{
+		{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: "mockorganization", ProjectName: "Mockproject1", ProjectID: "mockProjectID1"},
+		{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: "mockorganization", ProjectName: "Mockproject2", ProjectID: "mockProjectID2"},
+	}
+	for _, testCase := range []struct {
+		description        string
+		maxLinkedProjects  int
+		projectList        []serializers.ProjectDetails
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			description:        "HandleLinkWithMaxLinkedProjects: projects are not limited by default",
+			projectList:        projectList,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleLinkWithMaxLinkedProjects: user is under the limit",
+			maxLinkedProjects:  3,
+			projectList:        projectList,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleLinkWithMaxLinkedProjects: user is at the limit",
+			maxLinkedProjects:  2,
+			projectList:        projectList,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    fmt.Sprintf(constants.LinkedProjectsLimitReached, 2),
+		},
+		{
+			description:        "HandleLinkWithMaxLinkedProjects: user is over the limit lowered after linking the projects",
+			maxLinkedProjects:  1,
+			projectList:        projectList,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    fmt.Sprintf(constants.LinkedProjectsLimitReached, 1),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{MaxLinkedProjectsPerUser: testCase.maxLinkedProjects})
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
+			mockedClient.EXPECT().Link(gomock.Any(), testutils.MockMattermostUserID).Return(&serializers.Project{ID: "mockProjectID3"}, http.StatusOK, nil)
+			if testCase.expectedStatusCode == http.StatusOK {
+				mockedStore.EXPECT().StoreProject(gomock.Any()).Return(nil)
+			}
+
+			body := `{
+				"organization": "mockOrganization",
+				"project": "mockProject3"
+				}`
+			req := httptest.NewRequest(http.MethodPost, "/link", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleLink(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			if testCase.expectedMessage != "" {
+				var response map[string]string
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+				assert.Equal(t, testCase.expectedMessage, response[constants.Error])
+			}
+		})
+	}
+}
+
+func TestHandleDeleteAllSubscriptions(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	for _, testCase := range []struct {
+		description            string
+		userID                 string
+		projectID              string
+		err                    error
+		statusCode             int
+		getAllSubscriptionsErr error
+		subscriptionList       []*serializers.SubscriptionDetails
+		expectedErrorMessage   string
+	}{
+		{
+			description: "HandleDeleteAllSubscriptions: valid",
+			userID:      testutils.MockMattermostUserID,
+			projectID:   testutils.MockProjectID,
+			statusCode:  http.StatusOK,
+			subscriptionList: []*serializers.SubscriptionDetails{
+				{
+					MattermostUserID: testutils.MockMattermostUserID,
+					ProjectID:        testutils.MockProjectID,
+					OrganizationName: testutils.MockOrganization,
+					EventType:        testutils.MockEventType,
+					ChannelID:        testutils.MockChannelID,
+					SubscriptionID:   testutils.MockSubscriptionID,
+				},
+			},
+		},
+		{
+			description:            "HandleDeleteAllSubscriptions: GetAllSubscriptions gives error",
+			userID:                 "mockMattermostUserID",
+			projectID:              "mockProjectID",
+			statusCode:             http.StatusInternalServerError,
+			getAllSubscriptionsErr: errors.New("error in getting subscriptions"),
+			expectedErrorMessage:   "error in getting subscriptions",
+		},
+		{
+			description: "HandleDeleteAllSubscriptions: DeleteSubscription gives error",
+			userID:      testutils.MockMattermostUserID,
+			projectID:   testutils.MockProjectID,
+			statusCode:  http.StatusInternalServerError,
+			subscriptionList: []*serializers.SubscriptionDetails{
+				{
+					MattermostUserID: testutils.MockMattermostUserID,
+					ProjectID:        testutils.MockProjectID,
+					OrganizationName: testutils.MockOrganization,
+					EventType:        testutils.MockEventType,
+					ChannelID:        testutils.MockChannelID,
+					SubscriptionID:   testutils.MockSubscriptionID,
+				},
+			},
+			err:                  errors.New("error in deleting subscription"),
+			expectedErrorMessage: "error in deleting subscription",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+
+			mockedStore.EXPECT().GetAllSubscriptions(testCase.userID).Return(testCase.subscriptionList, testCase.getAllSubscriptionsErr)
+
+			if testCase.getAllSubscriptionsErr == nil {
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(testCase.subscriptionList, nil)
+				mockedClient.EXPECT().DeleteSubscription(gomock.Any(), gomock.Any(), gomock.Any()).Return(testCase.statusCode, testCase.err)
+				if testCase.err == nil {
+					mockedStore.EXPECT().DeleteSubscription(gomock.Any()).Return(nil)
+					mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(gomock.Any()).Return(nil)
+					mockedStore.EXPECT().DeleteLastNotification(gomock.Any()).Return(nil)
+				}
+			}
+
+			statusCode, err := p.handleDeleteAllSubscriptions(testCase.userID, testCase.projectID)
+			assert.Equal(t, testCase.statusCode, statusCode)
+
+			if testCase.err != nil || testCase.getAllSubscriptionsErr != nil {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestHandleGetAllLinkedProjects(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	project := serializers.ProjectDetails{OrganizationName: "mockorganization", ProjectName: testutils.MockProjectName}
+	otherProject := serializers.ProjectDetails{OrganizationName: "mockotherorganization", ProjectName: "mockOtherProject"}
+	for _, testCase := range []struct {
+		description      string
+		projectList      []serializers.ProjectDetails
+		organization     string
+		err              error
+		statusCode       int
+		expectedProjects []serializers.ProjectDetails
+	}{
+		{
+			description: "HandleGetAllLinkedProjects: valid",
+			projectList: []serializers.ProjectDetails{},
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "HandleGetAllLinkedProjects: error while fetching project list",
+			err:         errors.New("error while fetching project list"),
+			statusCode:  http.StatusInternalServerError,
+		},
+		{
+			description: "HandleGetAllLinkedProjects: empty project list",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description:      "HandleGetAllLinkedProjects: projects of all the organizations without the organization filter",
+			projectList:      []serializers.ProjectDetails{project, otherProject},
+			statusCode:       http.StatusOK,
+			expectedProjects: []serializers.ProjectDetails{project, otherProject},
+		},
+		{
+			description:      "HandleGetAllLinkedProjects: projects of the filtered organization",
+			projectList:      []serializers.ProjectDetails{project, otherProject},
+			organization:     "mockOrganization",
+			statusCode:       http.StatusOK,
+			expectedProjects: []serializers.ProjectDetails{project},
+		},
+		{
+			description:      "HandleGetAllLinkedProjects: no projects of the filtered organization",
+			projectList:      []serializers.ProjectDetails{project, otherProject},
+			organization:     "mockUnknownOrganization",
+			statusCode:       http.StatusOK,
+			expectedProjects: []serializers.ProjectDetails{},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, testCase.err)
+
+			target := "/project/link"
+			if testCase.organization != "" {
+				target = fmt.Sprintf("%s?%s=%s", target, constants.QueryParamOrganization, testCase.organization)
+			}
+			req := httptest.NewRequest(http.MethodGet, target, bytes.NewBufferString(`{}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetAllLinkedProjects(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+			if testCase.expectedProjects != nil {
+				var projects []serializers.ProjectDetails
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&projects))
+				assert.Equal(t, testCase.expectedProjects, projects)
+			}
+		})
+	}
+}
+
+func TestHandleUnlinkProject(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	for _, testCase := range []struct {
+		description        string
+		body               string
+		err                error
+		marshalError       error
+		statusCode         int
+		expectedStatusCode int
+		projectList        []serializers.ProjectDetails
+		project            serializers.ProjectDetails
+	}{
+		{
+			description: "HandleUnlinkProject: valid",
+			body: `{
+				"organizationName": "mockOrganization",
+				"projectName": "mockProjectName",
+				"projectID" :"mockProjectID"
+				}`,
+			statusCode:         http.StatusOK,
+			projectList:        testutils.GetProjectDetailsPayload(),
+			project:            testutils.GetProjectDetailsPayload()[0],
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "HandleUnlinkProject: invalid body",
+			body: `{
+				"organizationName": "mockOrganization",
+				"projectName": "mockProjectName",`,
+			err:                errors.New("error invalid body"),
+			statusCode:         http.StatusBadRequest,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleUnlinkProject: missing fields",
+			body: `{
+				"organization": "mockOrganization",
+				}`,
+			err:                errors.New("error missing fields"),
+			statusCode:         http.StatusBadRequest,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleUnlinkProject: marshaling gives error",
+			body: `{
+				"organizationName": "mockOrganization",
+				"projectName": "mockProjectName",
+				"projectID" :"mockProjectID"
+				}`,
+			statusCode:         http.StatusOK,
+			projectList:        testutils.GetProjectDetailsPayload(),
+			project:            testutils.GetProjectDetailsPayload()[0],
+			marshalError:       errors.New("error while marshaling"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsProjectLinked", func(*Plugin, []serializers.ProjectDetails, serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
+				return &testCase.project, true
+			})
+
+			if testCase.statusCode == http.StatusOK {
+				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
+				mockedStore.EXPECT().DeleteProject(&testCase.project).Return(nil)
+			}
+
+			monkey.Patch(json.Marshal, func(interface{}) ([]byte, error) {
+				return []byte{}, testCase.marshalError
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/project/unlink", bytes.NewBufferString(testCase.body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleUnlinkProject(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetUserAccountDetails(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	for _, testCase := range []struct {
+		description   string
+		err           error
+		marshalError  error
+		statusCode    int
+		user          *serializers.User
+		loadUserError error
+	}{
+		{
+			description: "HandleGetUserAccountDetails: valid",
+			statusCode:  http.StatusOK,
+			user: &serializers.User{
+				MattermostUserID: testutils.MockMattermostUserID,
+			},
+		},
+		{
+			description: "HandleGetUserAccountDetails: empty user details",
+			err:         nil,
+			statusCode:  http.StatusUnauthorized,
+			user:        &serializers.User{},
+		},
+		{
+			description:   "HandleGetUserAccountDetails: error while loading user",
+			loadUserError: errors.New("error while loading user"),
+			statusCode:    http.StatusInternalServerError,
+		},
+		{
+			description: "HandleGetUserAccountDetails: marshaling gives error",
+			statusCode:  http.StatusInternalServerError,
+			user: &serializers.User{
+				MattermostUserID: testutils.MockMattermostUserID,
+			},
+			marshalError: errors.New("error while marshaling"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return(nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, testCase.loadUserError)
+			if testCase.statusCode != http.StatusUnauthorized && testCase.loadUserError == nil {
+				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{}, nil)
+				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionDetails{}, nil)
+			}
+
+			monkey.Patch(json.Marshal, func(interface{}) ([]byte, error) {
+				return []byte{}, testCase.marshalError
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/user", bytes.NewBufferString(`{}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetUserAccountDetails(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetUserAccountDetailsWithScopes(t *testing.T) {
+	monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
 	mockedStore := mocks.NewMockKVStore(mockCtrl)
 	p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+	mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return(nil)
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+	mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{
+		MattermostUserID: testutils.MockMattermostUserID,
+		AccessToken:      "mockAccessToken",
+		RefreshToken:     "mockRefreshToken",
+		Scopes:           []string{constants.ScopeWorkFull, constants.ScopeCodeFull},
+	}, nil)
+	mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, nil)
+	mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/user", bytes.NewBufferString(`{}`))
+	req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+	w := httptest.NewRecorder()
+	p.handleGetUserAccountDetails(w, req)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var user serializers.User
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&user))
+	assert.Equal(t, []string{constants.ScopeWorkFull, constants.ScopeCodeFull}, user.Scopes)
+	assert.Empty(t, user.AccessToken)
+	assert.Empty(t, user.RefreshToken)
+}
+
+func TestHandleGetUserAccountDetailsWithOrganizations(t *testing.T) {
+	monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description                    string
+		user                           *serializers.User
+		expectedConnectedOrganizations []string
+	}{
+		{
+			description:                    "HandleGetUserAccountDetailsWithOrganizations: account connected without an organization",
+			user:                           &serializers.User{MattermostUserID: testutils.MockMattermostUserID},
+			expectedConnectedOrganizations: []string{},
+		},
+		{
+			description: "HandleGetUserAccountDetailsWithOrganizations: organizations connected after the account",
+			user: &serializers.User{
+				MattermostUserID:        testutils.MockMattermostUserID,
+				Organization:            "mock-organization",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken"}},
+			},
+			expectedConnectedOrganizations: []string{"mock-organization", "other-organization"},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return(nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, nil)
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, nil)
+			mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(nil, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/user", bytes.NewBufferString(`{}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetUserAccountDetails(w, req)
+			resp := w.Result()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.NotContains(t, string(body), "mockAccessToken")
+			assert.NotContains(t, string(body), "mockRefreshToken")
+
+			var accountDetails serializers.UserAccountDetails
+			require.NoError(t, json.Unmarshal(body, &accountDetails))
+			assert.Equal(t, testCase.expectedConnectedOrganizations, accountDetails.ConnectedOrganizations)
+		})
+	}
+}
+
+func TestHandleGetUserAccountDetailsWithCounts(t *testing.T) {
+	monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description               string
+		projectList               []serializers.ProjectDetails
+		projectListError          error
+		subscriptionList          []*serializers.SubscriptionDetails
+		subscriptionListError     error
+		expectedProjectCount      int
+		expectedSubscriptionCount int
+		expectedCountsUnavailable bool
+	}{
+		{
+			description:               "HandleGetUserAccountDetailsWithCounts: linked projects and subscriptions are counted",
+			projectList:               []serializers.ProjectDetails{{ProjectID: "mockProjectID-1"}, {ProjectID: "mockProjectID-2"}},
+			subscriptionList:          []*serializers.SubscriptionDetails{{SubscriptionID: testutils.MockSubscriptionID}},
+			expectedProjectCount:      2,
+			expectedSubscriptionCount: 1,
+		},
+		{
+			description:               "HandleGetUserAccountDetailsWithCounts: error in fetching the projects",
+			projectListError:          errors.New("error in fetching the projects"),
+			expectedCountsUnavailable: true,
+		},
+		{
+			description:               "HandleGetUserAccountDetailsWithCounts: error in fetching the subscriptions",
+			projectList:               []serializers.ProjectDetails{{ProjectID: "mockProjectID-1"}},
+			subscriptionListError:     errors.New("error in fetching the subscriptions"),
+			expectedCountsUnavailable: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockAPI.On("LogWarn", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return(nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{
+				MattermostUserID: testutils.MockMattermostUserID,
+				Scopes:           []string{constants.ScopeWorkFull},
+			}, nil)
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, testCase.projectListError)
+			if testCase.projectListError == nil {
+				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(testCase.subscriptionList, testCase.subscriptionListError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/user", bytes.NewBufferString(`{}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetUserAccountDetails(w, req)
+			resp := w.Result()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var accountDetails serializers.UserAccountDetails
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&accountDetails))
+			assert.Equal(t, testutils.MockMattermostUserID, accountDetails.MattermostUserID)
+			assert.Equal(t, []string{constants.ScopeWorkFull}, accountDetails.Scopes)
+			assert.Equal(t, testCase.expectedProjectCount, accountDetails.LinkedProjectCount)
+			assert.Equal(t, testCase.expectedSubscriptionCount, accountDetails.SubscriptionCount)
+			assert.Equal(t, testCase.expectedCountsUnavailable, accountDetails.CountsUnavailable)
+		})
+	}
+}
+
+func TestHandleCreateTaskWithMissingScope(t *testing.T) {
+	monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+	mockAPI.On("LogError", mock.AnythingOfType("string"))
+	mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusUnauthorized, errors.New("failed to create task"))
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+	mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{
+		MattermostUserID: testutils.MockMattermostUserID,
+		Scopes:           []string{constants.ScopeWork, constants.ScopeCodeFull},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{
+		"organization": "mockOrganization",
+		"project": "mockProjectName",
+		"type": "mockType",
+		"fields": {
+			"title": "mockTitle"
+			}
+		}`))
+	req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+	w := httptest.NewRecorder()
+	p.handleCreateTask(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	var errResponse map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResponse))
+	assert.Equal(t, fmt.Sprintf(constants.ErrorMissingScope, constants.ScopeWorkWrite, "vso.work, vso.code_full"), errResponse[constants.Error])
+}
+
+func TestHandleCreateSubscriptions(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
 	for _, testCase := range []struct {
 		description        string
 		body               string
 		err                error
 		marshalError       error
-		statusCode         int
 		expectedStatusCode int
+		statusCode         int
 		projectList        []serializers.ProjectDetails
 		project            serializers.ProjectDetails
+		subscriptionList   []*serializers.SubscriptionDetails
+		subscription       *serializers.SubscriptionDetails
+		isProjectLinked    bool
+		channelStatusCode  int
+		channelErr         error
 	}{
 		{
-			description: "HandleUnlinkProject: valid",
+			description: "HandleCreateSubscriptions: valid",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID",
+				"channelName": "mockChannelName"
+				}`,
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+			projectList:        []serializers.ProjectDetails{},
+			project:            serializers.ProjectDetails{},
+			subscriptionList:   []*serializers.SubscriptionDetails{},
+			subscription:       testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, testutils.MockServiceType, testutils.MockEventType)[0],
+		},
+		{
+			description: "HandleCreateSubscriptions: names of the linked project are stored",
+			body: `{
+				"organization": "MOCKORGANIZATION",
+				"project": "mockprojectname",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID",
+				"channelName": "mockChannelName"
+				}`,
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+			projectList:        []serializers.ProjectDetails{},
+			subscriptionList:   []*serializers.SubscriptionDetails{},
+			subscription:       testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, testutils.MockServiceType, testutils.MockEventType)[0],
+		},
+		{
+			description:        "HandleCreateSubscriptions: empty body",
+			body:               `{}`,
+			err:                errors.New("error empty body"),
+			statusCode:         http.StatusBadRequest,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleCreateSubscriptions: invalid body",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",`,
+			err:                errors.New("error invalid body"),
+			statusCode:         http.StatusBadRequest,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleCreateSubscriptions: missing fields",
 			body: `{
-				"organizationName": "mockOrganization",
-				"projectName": "mockProjectName",
-				"projectID" :"mockProjectID"
+				"organization": "mockOrganization",
 				}`,
-			statusCode:         http.StatusOK,
-			projectList:        testutils.GetProjectDetailsPayload(),
-			project:            testutils.GetProjectDetailsPayload()[0],
-			expectedStatusCode: http.StatusOK,
+			err:                errors.New("error missing fields"),
+			statusCode:         http.StatusBadRequest,
+			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleUnlinkProject: invalid body",
+			description: "HandleCreateSubscriptions: label is too long",
 			body: `{
-				"organizationName": "mockOrganization",
-				"projectName": "mockProjectName",`,
-			err:                errors.New("error invalid body"),
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID",
+				"label": "mockLabelWhichIsTooLong"
+				}`,
 			statusCode:         http.StatusBadRequest,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleUnlinkProject: missing fields",
+			description: "HandleCreateSubscriptions: work item type for a subscription of Repos events",
 			body: `{
 				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "git.push",
+				"serviceType": "repos",
+				"channelID": "mockChannelID",
+				"workItemType": "Bug"
 				}`,
-			err:                errors.New("error missing fields"),
 			statusCode:         http.StatusBadRequest,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleUnlinkProject: marshaling gives error",
+			description: "HandleCreateSubscriptions: malformed channel ID",
 			body: `{
-				"organizationName": "mockOrganization",
-				"projectName": "mockProjectName",
-				"projectID" :"mockProjectID"
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
+				}`,
+			channelStatusCode:  http.StatusBadRequest,
+			channelErr:         errors.New(constants.InvalidChannelID),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleCreateSubscriptions: channel does not exist",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
+				}`,
+			channelStatusCode:  http.StatusNotFound,
+			channelErr:         ErrChannelNotFound,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "HandleCreateSubscriptions: user is not a member of the channel",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
+				}`,
+			channelStatusCode:  http.StatusForbidden,
+			channelErr:         errors.New(constants.ErrorNotAChannelMember),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			description: "HandleCreateSubscriptions: marshaling gives error",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
 				}`,
 			statusCode:         http.StatusOK,
-			projectList:        testutils.GetProjectDetailsPayload(),
-			project:            testutils.GetProjectDetailsPayload()[0],
 			marshalError:       errors.New("error while marshaling"),
 			expectedStatusCode: http.StatusInternalServerError,
+			projectList:        []serializers.ProjectDetails{},
+			project:            serializers.ProjectDetails{},
+			subscriptionList:   []*serializers.SubscriptionDetails{},
+			subscription:       testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, testutils.MockServiceType, testutils.MockEventType)[0],
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("GetChannel", mock.AnythingOfType("string")).Return(&model.Channel{
+				DisplayName: "mockChannelName",
+			}, nil)
+			mockAPI.On("GetUser", mock.AnythingOfType("string")).Return(&model.User{
+				FirstName: "mockCreatedBy",
+			}, nil)
+
+			showFullName := true
+			privacySettings := model.PrivacySettings{ShowFullName: &showFullName}
+			mockAPI.On("GetConfig", mock.AnythingOfType("string")).Return(&model.Config{PrivacySettings: privacySettings}, nil)
 
+			monkey.Patch(json.Marshal, func(interface{}) ([]byte, error) {
+				return []byte{}, testCase.marshalError
+			})
 			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsProjectLinked", func(*Plugin, []serializers.ProjectDetails, serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
-				return &serializers.ProjectDetails{}, true
+				return &serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}, true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsSubscriptionPresent", func(*Plugin, []*serializers.SubscriptionDetails, *serializers.SubscriptionDetails) (*serializers.SubscriptionDetails, bool) {
+				return &serializers.SubscriptionDetails{}, false
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+				return testCase.channelStatusCode, testCase.channelErr
 			})
 
 			if testCase.statusCode == http.StatusOK {
+				mockedClient.EXPECT().CreateSubscription(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&serializers.SubscriptionValue{
+					ID: testutils.MockSubscriptionID,
+				}, testCase.statusCode, testCase.err)
 				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
-				mockedStore.EXPECT().DeleteProject(&testCase.project).Return(nil)
+				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(testCase.subscriptionList, nil)
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(testCase.subscriptionList, nil)
+				mockedStore.EXPECT().StoreSubscription(gomock.Any()).DoAndReturn(func(subscription *serializers.SubscriptionDetails) error {
+					// The subscription is compared without its creation time, which is checked to be set when it's created
+					assert.WithinDuration(t, time.Now(), subscription.CreatedAt, time.Minute)
+					storedSubscription := *subscription
+					storedSubscription.CreatedAt = time.Time{}
+					assert.Equal(t, testCase.subscription, &storedSubscription)
+					return nil
+				})
+				mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(testCase.body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateSubscription(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleCreateSubscriptionDefaultChannel(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	p.setConfiguration(&config.Configuration{
+		OrganizationDefaultChannels: "mockOrganization=qwertyuiopasdfghjklzxcvbnm",
+	})
+	for _, testCase := range []struct {
+		description        string
+		organization       string
+		channelID          string
+		expectedChannelID  string
+		expectedStatusCode int
+	}{
+		{
+			description:        "HandleCreateSubscriptionDefaultChannel: default channel of the organization is used when no channel is provided",
+			organization:       "MockOrganization",
+			expectedChannelID:  "qwertyuiopasdfghjklzxcvbnm",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleCreateSubscriptionDefaultChannel: provided channel takes precedence over the default channel",
+			organization:       "mockOrganization",
+			channelID:          "mnbvcxzlkjhgfdsapoiuytrewq",
+			expectedChannelID:  "mnbvcxzlkjhgfdsapoiuytrewq",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleCreateSubscriptionDefaultChannel: no channel is provided and the organization has no default channel",
+			organization:       "otherOrganization",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+			channelID := ""
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(_ *Plugin, requestChannelID, _ string) (int, error) {
+				channelID = requestChannelID
+				return http.StatusNotFound, ErrChannelNotFound
+			})
+
+			body := fmt.Sprintf(`{
+				"organization": %q,
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": %q
+				}`, testCase.organization, testCase.channelID)
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateSubscription(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, testCase.expectedChannelID, channelID)
+		})
+	}
+}
+
+func TestHandleCreateSubscriptionAutoLink(t *testing.T) {
+	defer monkey.UnpatchAll()
+	linkedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: "mockorganization", ProjectName: "Mockprojectname", ProjectID: testutils.MockProjectID, DefaultQuery: "mockQuery"}
+	renamedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: "mockorganization", ProjectName: "mockPreviousProjectName", ProjectID: testutils.MockProjectID}
+	autoLinkedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: "mockorganization", ProjectName: testutils.MockProjectName, ProjectID: testutils.MockProjectID}
+	for _, testCase := range []struct {
+		description        string
+		autoLink           bool
+		projectList        []serializers.ProjectDetails
+		linkStatusCode     int
+		linkErr            error
+		expectedStatusCode int
+		expectedLink       bool
+		expectedStore      bool
+		expectedProject    *serializers.ProjectDetails
+	}{
+		{
+			description:        "HandleCreateSubscriptionAutoLink: project is required to be linked by default",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleCreateSubscriptionAutoLink: project is linked before subscribing to it",
+			autoLink:           true,
+			linkStatusCode:     http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+			expectedLink:       true,
+			expectedStore:      true,
+			expectedProject:    &autoLinkedProject,
+		},
+		{
+			description:        "HandleCreateSubscriptionAutoLink: already linked project is used without linking it",
+			autoLink:           true,
+			projectList:        []serializers.ProjectDetails{{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName, ProjectID: testutils.MockProjectID}},
+			expectedStatusCode: http.StatusOK,
+			expectedProject:    &serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName, ProjectID: testutils.MockProjectID},
+		},
+		{
+			description:        "HandleCreateSubscriptionAutoLink: project linked under a name differing in case is used without linking it",
+			autoLink:           true,
+			projectList:        []serializers.ProjectDetails{linkedProject},
+			expectedStatusCode: http.StatusOK,
+			expectedProject:    &linkedProject,
+		},
+		{
+			description:        "HandleCreateSubscriptionAutoLink: project linked under a previous name is not replaced",
+			autoLink:           true,
+			projectList:        []serializers.ProjectDetails{renamedProject},
+			linkStatusCode:     http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+			expectedLink:       true,
+			expectedProject:    &renamedProject,
+		},
+		{
+			description:        "HandleCreateSubscriptionAutoLink: project which can't be linked fails the subscription",
+			autoLink:           true,
+			linkStatusCode:     http.StatusNotFound,
+			linkErr:            errors.New("mockError"),
+			expectedStatusCode: http.StatusNotFound,
+			expectedLink:       true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{AutoLinkSubscriptionProjects: testCase.autoLink})
+
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("GetChannel", mock.AnythingOfType("string")).Return(&model.Channel{DisplayName: "mockChannelName"}, nil)
+			mockAPI.On("GetUser", mock.AnythingOfType("string")).Return(&model.User{FirstName: "mockCreatedBy"}, nil)
+			mockAPI.On("GetConfig", mock.AnythingOfType("string")).Return(&model.Config{}, nil)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+				return http.StatusOK, nil
+			})
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
+			if testCase.expectedLink {
+				mockedClient.EXPECT().Link(&serializers.LinkRequestPayload{Organization: testutils.MockOrganization, Project: testutils.MockProjectName}, testutils.MockMattermostUserID).Return(&serializers.Project{ID: testutils.MockProjectID}, testCase.linkStatusCode, testCase.linkErr)
+			}
+			if testCase.expectedStore {
+				mockedStore.EXPECT().StoreProject(&autoLinkedProject).Return(nil)
+			}
+			if testCase.expectedProject != nil {
+				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(nil, nil)
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(nil, nil)
+				mockedClient.EXPECT().CreateSubscription(gomock.Any(), testCase.expectedProject, testutils.MockChannelID, gomock.Any(), testutils.MockMattermostUserID, gomock.Any()).Return(&serializers.SubscriptionValue{ID: testutils.MockSubscriptionID}, http.StatusOK, nil)
+				mockedStore.EXPECT().StoreSubscription(gomock.Any()).Return(nil)
+				mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			body := `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
+				}`
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateSubscription(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleCreateSubscriptionWithMaxSubscriptions(t *testing.T) {
+	defer monkey.UnpatchAll()
+	subscriptionList := []*serializers.SubscriptionDetails{
+		{MattermostUserID: testutils.MockMattermostUserID, SubscriptionID: "mockSubscriptionID1", EventType: "mockOtherEventType"},
+		{MattermostUserID: testutils.MockMattermostUserID, SubscriptionID: "mockSubscriptionID2", EventType: "mockOtherEventType"},
+	}
+	for _, testCase := range []struct {
+		description        string
+		maxSubscriptions   int
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			description:        "HandleCreateSubscriptionWithMaxSubscriptions: subscriptions are not limited by default",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleCreateSubscriptionWithMaxSubscriptions: user is under the limit",
+			maxSubscriptions:   3,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleCreateSubscriptionWithMaxSubscriptions: user is at the limit",
+			maxSubscriptions:   2,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    fmt.Sprintf(constants.SubscriptionsLimitReached, 2),
+		},
+		{
+			description:        "HandleCreateSubscriptionWithMaxSubscriptions: user is over the limit lowered after creating the subscriptions",
+			maxSubscriptions:   1,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    fmt.Sprintf(constants.SubscriptionsLimitReached, 1),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{MaxSubscriptionsPerUser: testCase.maxSubscriptions})
+
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockAPI.On("GetChannel", mock.AnythingOfType("string")).Return(&model.Channel{DisplayName: "mockChannelName"}, nil)
+			mockAPI.On("GetUser", mock.AnythingOfType("string")).Return(&model.User{FirstName: "mockCreatedBy"}, nil)
+			mockAPI.On("GetConfig", mock.AnythingOfType("string")).Return(&model.Config{}, nil)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+				return http.StatusOK, nil
+			})
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testutils.GetProjectDetailsPayload(), nil)
+			mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptionList, nil)
+			if testCase.expectedStatusCode == http.StatusOK {
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+				mockedClient.EXPECT().CreateSubscription(gomock.Any(), gomock.Any(), testutils.MockChannelID, gomock.Any(), testutils.MockMattermostUserID, gomock.Any()).Return(&serializers.SubscriptionValue{ID: testutils.MockSubscriptionID}, http.StatusOK, nil)
+				mockedStore.EXPECT().StoreSubscription(gomock.Any()).Return(nil)
+				mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			body := `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
+				}`
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateSubscription(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			if testCase.expectedMessage != "" {
+				var response map[string]string
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+				assert.Equal(t, testCase.expectedMessage, response[constants.Error])
 			}
+		})
+	}
+}
+
+func TestHandleCreateSubscriptionEventTypeAlias(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	for _, testCase := range []struct {
+		description        string
+		eventType          string
+		expectedEventType  string
+		expectedStatusCode int
+	}{
+		{
+			description:        "HandleCreateSubscriptionEventTypeAlias: alias is resolved before the validation",
+			eventType:          "pr-created",
+			expectedEventType:  constants.SubscriptionEventPullRequestCreated,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleCreateSubscriptionEventTypeAlias: event type is used as it is",
+			eventType:          constants.SubscriptionEventCodePushed,
+			expectedEventType:  constants.SubscriptionEventCodePushed,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleCreateSubscriptionEventTypeAlias: unknown alias",
+			eventType:          "pr-closed",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
 
-			monkey.Patch(json.Marshal, func(interface{}) ([]byte, error) {
-				return []byte{}, testCase.marshalError
+			eventType := ""
+			monkey.PatchInstanceMethod(reflect.TypeOf(&serializers.CreateSubscriptionRequestPayload{}), "IsSubscriptionRequestPayloadValid", func(payload *serializers.CreateSubscriptionRequestPayload) error {
+				eventType = payload.EventType
+				return nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(_ *Plugin, _, _ string) (int, error) {
+				return http.StatusNotFound, ErrChannelNotFound
 			})
 
-			req := httptest.NewRequest(http.MethodPost, "/project/unlink", bytes.NewBufferString(testCase.body))
+			body := fmt.Sprintf(`{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": %q,
+				"serviceType": "mockServiceType",
+				"channelID": "mockChannelID"
+				}`, testCase.eventType)
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(body))
 			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
 
 			w := httptest.NewRecorder()
-			p.handleUnlinkProject(w, req)
+			p.handleCreateSubscription(w, req)
 			resp := w.Result()
 			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, testCase.expectedEventType, eventType)
 		})
 	}
 }
 
-func TestHandleGetUserAccountDetails(t *testing.T) {
+func TestHandleDeleteWorkItem(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
 	mockedStore := mocks.NewMockKVStore(mockCtrl)
-	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
 	for _, testCase := range []struct {
-		description   string
-		err           error
-		marshalError  error
-		statusCode    int
-		user          *serializers.User
-		loadUserError error
+		description        string
+		action             string
+		destroy            bool
+		scopes             []string
+		deleteStatusCode   int
+		deleteErr          error
+		expectedStatusCode int
+		expectedMessage    string
 	}{
 		{
-			description: "HandleGetUserAccountDetails: valid",
-			statusCode:  http.StatusOK,
-			user: &serializers.User{
-				MattermostUserID: testutils.MockMattermostUserID,
-			},
+			description:        "HandleDeleteWorkItem: work item is moved to the recycle bin",
+			action:             constants.DeleteWorkItemActionConfirm,
+			scopes:             []string{constants.ScopeWorkWrite},
+			deleteStatusCode:   http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.WorkItemDeleted, 1),
 		},
 		{
-			description: "HandleGetUserAccountDetails: empty user details",
-			err:         nil,
-			statusCode:  http.StatusUnauthorized,
-			user:        &serializers.User{},
+			description:        "HandleDeleteWorkItem: work item is destroyed",
+			action:             constants.DeleteWorkItemActionConfirm,
+			destroy:            true,
+			scopes:             []string{constants.ScopeWorkFull},
+			deleteStatusCode:   http.StatusNoContent,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.WorkItemDestroyed, 1),
 		},
 		{
-			description:   "HandleGetUserAccountDetails: error while loading user",
-			loadUserError: errors.New("error while loading user"),
-			statusCode:    http.StatusInternalServerError,
+			description:        "HandleDeleteWorkItem: deletion is canceled",
+			action:             constants.DeleteWorkItemActionCancel,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.WorkItemDeleteCanceled, 1),
 		},
 		{
-			description: "HandleGetUserAccountDetails: marshaling gives error",
-			statusCode:  http.StatusInternalServerError,
-			user: &serializers.User{
-				MattermostUserID: testutils.MockMattermostUserID,
-			},
-			marshalError: errors.New("error while marshaling"),
+			description:        "HandleDeleteWorkItem: user is not allowed to delete the work item",
+			action:             constants.DeleteWorkItemActionConfirm,
+			destroy:            true,
+			scopes:             []string{constants.ScopeWorkWrite},
+			deleteStatusCode:   http.StatusForbidden,
+			deleteErr:          errors.New("forbidden"),
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.WorkItemDeleteForbidden, 1),
+		},
+		{
+			description:        "HandleDeleteWorkItem: write scope is not granted",
+			action:             constants.DeleteWorkItemActionConfirm,
+			scopes:             []string{constants.ScopeWork},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.ErrorMissingScope, constants.ScopeWorkWrite, constants.ScopeWork),
+		},
+		{
+			description:        "HandleDeleteWorkItem: error in deleting the work item",
+			action:             constants.DeleteWorkItemActionConfirm,
+			scopes:             []string{constants.ScopeWorkWrite},
+			deleteStatusCode:   http.StatusInternalServerError,
+			deleteErr:          errors.New("error deleting the work item"),
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    constants.GenericErrorMessage,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
-			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
-			mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return(nil)
-			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
-			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, testCase.loadUserError)
+			if testCase.scopes != nil {
+				mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+				mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{Scopes: testCase.scopes}, nil)
+			}
+			if testCase.deleteStatusCode != 0 {
+				mockedClient.EXPECT().DeleteWorkItem(testutils.MockOrganization, testutils.MockProjectName, 1, testCase.destroy, testutils.MockMattermostUserID).Return(testCase.deleteStatusCode, testCase.deleteErr)
+			}
 
-			monkey.Patch(json.Marshal, func(interface{}) ([]byte, error) {
-				return []byte{}, testCase.marshalError
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{
+				PostId: "mockPostID",
+				Context: map[string]interface{}{
+					constants.DeleteWorkItemContextOrganization: testutils.MockOrganization,
+					constants.DeleteWorkItemContextProjectName:  testutils.MockProjectName,
+					constants.DeleteWorkItemContextWorkItemID:   1,
+					constants.DeleteWorkItemContextDestroy:      testCase.destroy,
+					constants.DeleteWorkItemContextAction:       testCase.action,
+				},
 			})
+			require.NoError(t, err)
 
-			req := httptest.NewRequest(http.MethodGet, "/user", bytes.NewBufferString(`{}`))
+			req := httptest.NewRequest(http.MethodPost, "/workitems/delete", bytes.NewBuffer(body))
 			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
 
 			w := httptest.NewRecorder()
-			p.handleGetUserAccountDetails(w, req)
+			p.handleDeleteWorkItem(w, req)
 			resp := w.Result()
-			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+
+			var response *model.PostActionIntegrationResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			require.NotNil(t, response.Update)
+			assert.Equal(t, "mockPostID", response.Update.Id)
+			assert.Equal(t, testCase.expectedMessage, response.Update.Message)
 		})
 	}
+
+	t.Run("HandleDeleteWorkItem: invalid context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/workitems/delete", bytes.NewBufferString(`{"context": {"action": "confirm"}}`))
+		req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+		w := httptest.NewRecorder()
+		p.handleDeleteWorkItem(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
 }
 
-func TestHandleCreateSubscriptions(t *testing.T) {
-	defer monkey.UnpatchAll()
+func TestHandleStoreSubscriptionTemplate(t *testing.T) {
+	monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	mockCtrl := gomock.NewController(t)
-	mockedClient := mocks.NewMockClient(mockCtrl)
 	mockedStore := mocks.NewMockKVStore(mockCtrl)
-	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
 	for _, testCase := range []struct {
 		description        string
 		body               string
-		err                error
-		marshalError       error
+		storeError         error
 		expectedStatusCode int
-		statusCode         int
-		projectList        []serializers.ProjectDetails
-		project            serializers.ProjectDetails
-		subscriptionList   []*serializers.SubscriptionDetails
-		subscription       *serializers.SubscriptionDetails
-		isProjectLinked    bool
 	}{
 		{
-			description: "HandleCreateSubscriptions: valid",
-			body: `{
-				"organization": "mockOrganization",
-				"project": "mockProjectName",
-				"eventType": "mockEventType",
-				"serviceType": "mockServiceType",
-				"channelID": "mockChannelID",
-				"channelName": "mockChannelName"
-				}`,
-			statusCode:         http.StatusOK,
+			description:        "HandleStoreSubscriptionTemplate: valid",
+			body:               `{"name": "mockTemplate", "events": [{"eventType": "workitem.created"}, {"eventType": "git.push", "channelID": "mockChannelID"}]}`,
 			expectedStatusCode: http.StatusOK,
-			projectList:        []serializers.ProjectDetails{},
-			project:            serializers.ProjectDetails{},
-			subscriptionList:   []*serializers.SubscriptionDetails{},
-			subscription:       testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, testutils.MockServiceType, testutils.MockEventType)[0],
 		},
 		{
-			description:        "HandleCreateSubscriptions: empty body",
-			body:               `{}`,
-			err:                errors.New("error empty body"),
-			statusCode:         http.StatusBadRequest,
+			description:        "HandleStoreSubscriptionTemplate: invalid body",
+			body:               `{"name": "mockTemplate",`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleCreateSubscriptions: invalid body",
-			body: `{
-				"organization": "mockOrganization",
-				"project": "mockProjectName",`,
-			err:                errors.New("error invalid body"),
-			statusCode:         http.StatusBadRequest,
+			description:        "HandleStoreSubscriptionTemplate: name with whitespace",
+			body:               `{"name": "mock template", "events": [{"eventType": "workitem.created"}]}`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleCreateSubscriptions: missing fields",
-			body: `{
-				"organization": "mockOrganization",
-				}`,
-			err:                errors.New("error missing fields"),
-			statusCode:         http.StatusBadRequest,
+			description:        "HandleStoreSubscriptionTemplate: unsupported event type",
+			body:               `{"name": "mockTemplate", "events": [{"eventType": "mockEventType"}]}`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			description: "HandleCreateSubscriptions: marshaling gives error",
-			body: `{
-				"organization": "mockOrganization",
-				"project": "mockProjectName",
-				"eventType": "mockEventType",
-				"serviceType": "mockServiceType",
-				"channelID": "mockChannelID"
-				}`,
-			statusCode:         http.StatusOK,
-			marshalError:       errors.New("error while marshaling"),
+			description:        "HandleStoreSubscriptionTemplate: error in storing the template",
+			body:               `{"name": "mockTemplate", "events": [{"eventType": "workitem.created"}]}`,
+			storeError:         errors.New("error in storing the template"),
 			expectedStatusCode: http.StatusInternalServerError,
-			projectList:        []serializers.ProjectDetails{},
-			project:            serializers.ProjectDetails{},
-			subscriptionList:   []*serializers.SubscriptionDetails{},
-			subscription:       testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, testutils.MockServiceType, testutils.MockEventType)[0],
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
-			mockAPI.On("GetChannel", mock.AnythingOfType("string")).Return(&model.Channel{
-				DisplayName: "mockChannelName",
-			}, nil)
-			mockAPI.On("GetUser", mock.AnythingOfType("string")).Return(&model.User{
-				FirstName: "mockCreatedBy",
-			}, nil)
-
-			showFullName := true
-			privacySettings := model.PrivacySettings{ShowFullName: &showFullName}
-			mockAPI.On("GetConfig", mock.AnythingOfType("string")).Return(&model.Config{PrivacySettings: privacySettings}, nil)
-
-			monkey.Patch(json.Marshal, func(interface{}) ([]byte, error) {
-				return []byte{}, testCase.marshalError
-			})
-			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsProjectLinked", func(*Plugin, []serializers.ProjectDetails, serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
-				return &serializers.ProjectDetails{}, true
-			})
-			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsSubscriptionPresent", func(*Plugin, []*serializers.SubscriptionDetails, *serializers.SubscriptionDetails) (*serializers.SubscriptionDetails, bool) {
-				return &serializers.SubscriptionDetails{}, false
-			})
-			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
-				return 0, nil
-			})
 
-			if testCase.statusCode == http.StatusOK {
-				mockedClient.EXPECT().CreateSubscription(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&serializers.SubscriptionValue{
-					ID: testutils.MockSubscriptionID,
-				}, testCase.statusCode, testCase.err)
-				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
-				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(testCase.subscriptionList, nil)
-				mockedStore.EXPECT().StoreSubscription(testCase.subscription).Return(nil)
-				mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			if testCase.expectedStatusCode != http.StatusBadRequest {
+				mockedStore.EXPECT().StoreSubscriptionTemplate(gomock.Any()).DoAndReturn(func(template *serializers.SubscriptionTemplate) error {
+					assert.Equal(t, testutils.MockMattermostUserID, template.MattermostUserID)
+					return testCase.storeError
+				})
 			}
 
-			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(testCase.body))
+			req := httptest.NewRequest(http.MethodPost, "/subscription-templates", bytes.NewBufferString(testCase.body))
 			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
 
 			w := httptest.NewRecorder()
-			p.handleCreateSubscription(w, req)
+			p.handleStoreSubscriptionTemplate(w, req)
 			resp := w.Result()
 			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
 		})
@@ -771,13 +2301,260 @@ func TestHandleGetSubscriptions(t *testing.T) {
 				return testCase.isTeamIDValid
 			})
 
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/subscriptions/%s/%s/%s", testutils.MockTeamID, testutils.MockOrganization, testutils.MockProjectName), bytes.NewBufferString(`{}`))
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/subscriptions/%s/%s/%s", testutils.MockTeamID, testutils.MockOrganization, testutils.MockProjectName), bytes.NewBufferString(`{}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetSubscriptions(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetSubscriptionsPagination(t *testing.T) {
+	defer monkey.UnpatchAll()
+	subscriptionList := []*serializers.SubscriptionDetails{}
+	for index := 0; index < 5; index++ {
+		// The case of the project name stored with the older subscriptions may differ from the linked project
+		projectName := testutils.MockProjectName
+		if index%2 == 1 {
+			projectName = strings.ToUpper(projectName)
+		}
+		subscriptionList = append(subscriptionList, &serializers.SubscriptionDetails{
+			ProjectName:    projectName,
+			SubscriptionID: fmt.Sprintf("mockSubscriptionID%d", index),
+			ChannelID:      testutils.MockChannelID,
+			CreatedAt:      time.Unix(int64(index), 0),
+		})
+	}
+	subscriptionList = append(subscriptionList, &serializers.SubscriptionDetails{
+		ProjectName:    "mockOtherProject",
+		SubscriptionID: "mockOtherSubscriptionID",
+		ChannelID:      testutils.MockChannelID,
+	})
+
+	for _, testCase := range []struct {
+		description             string
+		queryParams             string
+		expectedSubscriptionIDs []string
+	}{
+		{
+			description:             "HandleGetSubscriptions: first page",
+			queryParams:             "page=0&per_page=2&sort=oldest",
+			expectedSubscriptionIDs: []string{"mockSubscriptionID0", "mockSubscriptionID1"},
+		},
+		{
+			description:             "HandleGetSubscriptions: last page",
+			queryParams:             "page=2&per_page=2&sort=oldest",
+			expectedSubscriptionIDs: []string{"mockSubscriptionID4"},
+		},
+		{
+			description:             "HandleGetSubscriptions: page after the last one",
+			queryParams:             "page=3&per_page=2&sort=oldest",
+			expectedSubscriptionIDs: []string{},
+		},
+		{
+			description:             "HandleGetSubscriptions: negative page and per_page are clamped to the defaults",
+			queryParams:             "page=-1&per_page=-2&sort=oldest",
+			expectedSubscriptionIDs: []string{"mockSubscriptionID0", "mockSubscriptionID1", "mockSubscriptionID2", "mockSubscriptionID3", "mockSubscriptionID4"},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Maybe()
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{DisplayName: "mockChannel"}, nil)
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsProjectLinked", func(*Plugin, []serializers.ProjectDetails, serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
+				return &serializers.ProjectDetails{ProjectName: testutils.MockProjectName}, true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetSubscriptionsForAccessibleChannelsOrProjects", func(_ *Plugin, subscriptions []*serializers.SubscriptionDetails, _, _, _ string) ([]*serializers.SubscriptionDetails, error) {
+				return subscriptions, nil
+			})
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{}, nil)
+			mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptionList, nil)
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/subscriptions/%s/%s/%s?%s", testutils.MockTeamID, testutils.MockOrganization, testutils.MockProjectName, testCase.queryParams), nil)
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+			req = mux.SetURLVars(req, map[string]string{
+				constants.PathParamTeamID:       model.NewId(),
+				constants.PathParamOrganization: testutils.MockOrganization,
+				constants.PathParamProject:      testutils.MockProjectName,
+			})
+
+			w := httptest.NewRecorder()
+			p.handleGetSubscriptions(w, req)
+			resp := w.Result()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var response *serializers.SubscriptionListResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			assert.Equal(t, 5, response.TotalCount)
+			subscriptionIDs := []string{}
+			for _, subscription := range response.Subscriptions {
+				subscriptionIDs = append(subscriptionIDs, subscription.SubscriptionID)
+			}
+			assert.Equal(t, testCase.expectedSubscriptionIDs, subscriptionIDs)
+		})
+	}
+}
+
+func TestHandleGetProjectWorkItems(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+	projectList := []serializers.ProjectDetails{{OrganizationName: testutils.MockOrganization, ProjectID: testutils.MockProjectID, ProjectName: testutils.MockProjectName}}
+	for _, testCase := range []struct {
+		description        string
+		projectID          string
+		queryParams        string
+		expectedQuery      serializers.WorkItemQuery
+		workItemsErr       error
+		expectedStatusCode int
+	}{
+		{
+			description:        "HandleGetProjectWorkItems: valid",
+			projectID:          testutils.MockProjectID,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleGetProjectWorkItems: with the state and assignee filters",
+			projectID:          testutils.MockProjectID,
+			queryParams:        "?state=Active&assigned_to=me",
+			expectedQuery:      serializers.WorkItemQuery{State: "Active", AssignedTo: "me"},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleGetProjectWorkItems: project is not linked",
+			projectID:          "mockUnlinkedProjectID",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleGetProjectWorkItems: error in fetching the work items",
+			projectID:          testutils.MockProjectID,
+			workItemsErr:       errors.New("error fetching the work items"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(projectList, nil)
+			if testCase.projectID == testutils.MockProjectID {
+				if testCase.workItemsErr != nil {
+					mockedClient.EXPECT().GetWorkItems(testutils.MockOrganization, testutils.MockProjectName, testCase.expectedQuery, testutils.MockMattermostUserID).Return(nil, http.StatusBadRequest, testCase.workItemsErr)
+				} else {
+					mockedClient.EXPECT().GetWorkItems(testutils.MockOrganization, testutils.MockProjectName, testCase.expectedQuery, testutils.MockMattermostUserID).Return([]*serializers.TaskValue{{
+						ID: 1,
+						Fields: serializers.TaskFieldValue{
+							Title:      "mockTitle",
+							Type:       "Bug",
+							State:      "Active",
+							AssignedTo: serializers.TaskUserDetails{DisplayName: "mockUser"},
+						},
+					}}, http.StatusOK, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/project/"+testCase.projectID+"/workitems"+testCase.queryParams, nil)
+			req = mux.SetURLVars(req, map[string]string{constants.PathParamProjectID: testCase.projectID})
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetProjectWorkItems(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+
+			if testCase.expectedStatusCode == http.StatusOK {
+				var workItems []*serializers.WorkItemSummary
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&workItems))
+				require.Len(t, workItems, 1)
+				assert.Equal(t, "mockTitle", workItems[0].Title)
+				assert.Equal(t, "mockUser", workItems[0].AssignedTo)
+				assert.Equal(t, fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), testutils.MockOrganization, testutils.MockProjectName, 1), workItems[0].Link)
+			}
+		})
+	}
+}
+
+func TestHandleGetProjectPullRequests(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+	projectList := []serializers.ProjectDetails{{OrganizationName: testutils.MockOrganization, ProjectID: testutils.MockProjectID, ProjectName: testutils.MockProjectName}}
+	pullRequests := []*serializers.PullRequest{
+		{PullRequestID: 1, Title: "mockTitle1", Status: "active", CreatedBy: serializers.Identity{DisplayName: "mockUser"}, Repository: serializers.Repository{Name: "mockRepository1"}},
+		{PullRequestID: 2, Title: "mockTitle2", Status: "active", CreatedBy: serializers.Identity{DisplayName: "mockUser"}, Repository: serializers.Repository{Name: "mock Repository2"}},
+	}
+	for _, testCase := range []struct {
+		description          string
+		projectID            string
+		queryParams          string
+		expectedRepositoryID string
+		pullRequestsErr      error
+		expectedStatusCode   int
+	}{
+		{
+			description:        "HandleGetProjectPullRequests: pull requests of all the repositories",
+			projectID:          testutils.MockProjectID,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:          "HandleGetProjectPullRequests: pull requests of a repository",
+			projectID:            testutils.MockProjectID,
+			queryParams:          "?repository=mockRepositoryID",
+			expectedRepositoryID: "mockRepositoryID",
+			expectedStatusCode:   http.StatusOK,
+		},
+		{
+			description:        "HandleGetProjectPullRequests: project is not linked",
+			projectID:          "mockUnlinkedProjectID",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleGetProjectPullRequests: error in fetching the pull requests",
+			projectID:          testutils.MockProjectID,
+			pullRequestsErr:    errors.New("error fetching the pull requests"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(projectList, nil)
+			if testCase.projectID == testutils.MockProjectID {
+				if testCase.pullRequestsErr != nil {
+					mockedClient.EXPECT().GetPullRequests(testutils.MockOrganization, testutils.MockProjectName, testCase.expectedRepositoryID, testutils.MockMattermostUserID).Return(nil, http.StatusBadRequest, testCase.pullRequestsErr)
+				} else {
+					mockedClient.EXPECT().GetPullRequests(testutils.MockOrganization, testutils.MockProjectName, testCase.expectedRepositoryID, testutils.MockMattermostUserID).Return(pullRequests, http.StatusOK, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/project/"+testCase.projectID+"/pullrequests"+testCase.queryParams, nil)
+			req = mux.SetURLVars(req, map[string]string{constants.PathParamProjectID: testCase.projectID})
 			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
 
 			w := httptest.NewRecorder()
-			p.handleGetSubscriptions(w, req)
+			p.handleGetProjectPullRequests(w, req)
 			resp := w.Result()
-			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+
+			if testCase.expectedStatusCode == http.StatusOK {
+				var pullRequestSummaries []*serializers.PullRequestSummary
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&pullRequestSummaries))
+				assert.Equal(t, []*serializers.PullRequestSummary{
+					{ID: 1, Title: "mockTitle1", Author: "mockUser", Status: "active", Repository: "mockRepository1", URL: "https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepository1/pullrequest/1"},
+					{ID: 2, Title: "mockTitle2", Author: "mockUser", Status: "active", Repository: "mock Repository2", URL: "https://dev.azure.com/mockOrganization/mockProjectName/_git/mock%20Repository2/pullrequest/2"},
+				}, pullRequestSummaries)
+			}
 		})
 	}
 }
@@ -785,7 +2562,14 @@ func TestHandleGetSubscriptions(t *testing.T) {
 func TestHandleSubscriptionNotifications(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
-	p := setupMockPlugin(mockAPI, nil, nil)
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{}, nil).AnyTimes()
+	mockedStore.EXPECT().GetChannelNotificationPrefs(gomock.Any()).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+	mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+	mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
 	for _, testCase := range []struct {
 		description      string
 		body             string
@@ -795,6 +2579,7 @@ func TestHandleSubscriptionNotifications(t *testing.T) {
 		statusCode       int
 		parseTimeError   error
 		webhookSecret    string
+		channelErr       *model.AppError
 	}{
 		{
 			description: "SubscriptionNotifications: valid",
@@ -863,6 +2648,26 @@ func TestHandleSubscriptionNotifications(t *testing.T) {
 			isValidChannelID: true,
 			webhookSecret:    "mockWebhookSecret",
 		},
+		{
+			description: "SubscriptionNotifications: eventType workItem updated",
+			body: `{
+				"eventType": "workitem.updated",
+				"resource": {
+					"fields": {
+						"System.State": {"oldValue": "New", "newValue": "Active"},
+						"System.Rev": {"oldValue": 1, "newValue": 2}
+					},
+					"revision": {"fields": {"System.Title": "mockTitle", "System.TeamProject": "mockProject"}}
+				},
+				"detailedMessage": {
+					"markdown": "mockMarkdown"
+					}
+				}`,
+			channelID:        "mockChannelIDmockChannelID",
+			statusCode:       http.StatusOK,
+			isValidChannelID: true,
+			webhookSecret:    "mockWebhookSecret",
+		},
 		{
 			description: "SubscriptionNotifications: eventType  pull request commented",
 			body: `{
@@ -1023,6 +2828,19 @@ func TestHandleSubscriptionNotifications(t *testing.T) {
 			isValidChannelID: true,
 			webhookSecret:    "mockWebhookSecret",
 		},
+		{
+			description: "SubscriptionNotifications: channel does not exist",
+			body: `{
+				"detailedMessage": {
+					"markdown": "mockMarkdown"
+					}
+				}`,
+			channelID:        "mockChannelIDmockChannelID",
+			statusCode:       http.StatusNotFound,
+			isValidChannelID: true,
+			webhookSecret:    "mockWebhookSecret",
+			channelErr:       &model.AppError{StatusCode: http.StatusNotFound},
+		},
 		{
 			description: "SubscriptionNotifications: without webhookSecret",
 			body: `{	
@@ -1036,8 +2854,12 @@ func TestHandleSubscriptionNotifications(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.ExpectedCalls = nil
+			mockAPI.Calls = nil
 			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
 			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+			mockAPI.On("GetChannel", mock.AnythingOfType("string")).Return(&model.Channel{}, testCase.channelErr)
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.AnythingOfType("map[string]interface {}"), &model.WebsocketBroadcast{ChannelId: testCase.channelID})
 
 			monkey.Patch(model.IsValidId, func(string) bool {
 				return testCase.isValidChannelID
@@ -1057,6 +2879,157 @@ func TestHandleSubscriptionNotifications(t *testing.T) {
 			p.handleSubscriptionNotifications(w, req)
 			resp := w.Result()
 			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+
+			// Only the posted notifications are published to the channel
+			if testCase.statusCode == http.StatusOK && testCase.err == nil {
+				mockAPI.AssertCalled(t, "PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.AnythingOfType("map[string]interface {}"), &model.WebsocketBroadcast{ChannelId: testCase.channelID})
+			} else {
+				mockAPI.AssertNotCalled(t, "PublishWebSocketEvent", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPublishNotificationPostedEvent(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, map[string]interface{}{
+		"channelID":      testutils.MockChannelID,
+		"eventType":      "git.push",
+		"subscriptionID": testutils.MockSubscriptionID,
+		"postID":         "mockPostID",
+	}, &model.WebsocketBroadcast{ChannelId: testutils.MockChannelID})
+
+	p.publishNotificationPostedEvent(testutils.MockChannelID, &serializers.SubscriptionNotification{EventType: "git.push", SubscriptionID: testutils.MockSubscriptionID}, "mockPostID")
+
+	mockAPI.AssertExpectations(t)
+}
+
+func TestHandleSubscriptionNotificationsForWorkItemComment(t *testing.T) {
+	defer monkey.UnpatchAll()
+	body := `{
+		"subscriptionID": "mockSubscriptionID",
+		"eventType": "workitem.commented",
+		"resource": {"fields": {"System.TeamProject": "mockProject"}},
+		"message": {"markdown": "mockMarkdown"},
+		"detailedMessage": {"markdown": "Bug #1 commented on by mockUser\n<div>Looks <b>good</b></div>"}
+	}`
+	keepRawHTML, convertHTML, showEmoji := true, false, false
+	commentLength := 5
+	for _, testCase := range []struct {
+		description     string
+		keepRawHTML     bool
+		label           string
+		truncation      *serializers.NotificationTruncation
+		channelPrefs    serializers.ChannelNotificationPrefs
+		language        string
+		expectedText    string
+		expectedPretext string
+		expectedColor   string
+		expectedTitle   string
+	}{
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: HTML is converted to Markdown",
+			expectedText:    "Looks **good**",
+			expectedPretext: "🔵 mockMarkdown",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: raw HTML is kept",
+			keepRawHTML:     true,
+			expectedText:    "<div>Looks <b>good</b></div>",
+			expectedPretext: "🔵 mockMarkdown",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: label is prefixed",
+			label:           "Billing",
+			expectedText:    "Looks **good**",
+			expectedPretext: "🔵 [Billing] mockMarkdown",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: channel preferences are inherited",
+			channelPrefs:    serializers.ChannelNotificationPrefs{KeepRawHTML: &keepRawHTML, ShowEmoji: &showEmoji, Color: "#0078d4"},
+			expectedText:    "<div>Looks <b>good</b></div>",
+			expectedPretext: "mockMarkdown",
+			expectedColor:   "#0078d4",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: subscription option overrides the channel preference",
+			keepRawHTML:     true,
+			channelPrefs:    serializers.ChannelNotificationPrefs{KeepRawHTML: &convertHTML},
+			expectedText:    "<div>Looks <b>good</b></div>",
+			expectedPretext: "🔵 mockMarkdown",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: long comment is truncated",
+			truncation:      &serializers.NotificationTruncation{Comment: &commentLength},
+			expectedText:    "Looks…",
+			expectedPretext: "🔵 mockMarkdown",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: static texts are in the language of the channel",
+			channelPrefs:    serializers.ChannelNotificationPrefs{Language: "de"},
+			language:        "es",
+			expectedText:    "Looks **good**",
+			expectedPretext: "🔵 mockMarkdown",
+			expectedTitle:   "Kommentar",
+		},
+		{
+			description:     "SubscriptionNotificationsForWorkItemComment: static texts are in the language of the plugin configuration",
+			language:        "es",
+			expectedText:    "Looks **good**",
+			expectedPretext: "🔵 mockMarkdown",
+			expectedTitle:   "Comentario",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{NotificationLanguage: testCase.language})
+
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{{
+				SubscriptionID: testutils.MockSubscriptionID,
+				KeepRawHTML:    testCase.keepRawHTML,
+				Label:          testCase.label,
+				Truncation:     testCase.truncation,
+			}}, nil)
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&testCase.channelPrefs, nil)
+			mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil)
+			mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+			var post *model.Post
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+				post = args.Get(0).(*model.Post)
+			}).Return(&model.Post{}, nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return testutils.MockChannelID, http.StatusOK, nil
+			})
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(body))
+
+			w := httptest.NewRecorder()
+			p.handleSubscriptionNotifications(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			require.NotNil(t, post)
+			attachments := post.Attachments()
+			require.Len(t, attachments, 1)
+			assert.Equal(t, testCase.expectedText, attachments[0].Text)
+			assert.Equal(t, testCase.expectedPretext, attachments[0].Pretext)
+			if testCase.expectedColor != "" {
+				assert.Equal(t, testCase.expectedColor, attachments[0].Color)
+			}
+			if testCase.expectedTitle == "" {
+				testCase.expectedTitle = "Comment"
+			}
+			assert.Equal(t, testCase.expectedTitle, attachments[0].Title)
 		})
 	}
 }
@@ -1075,6 +3048,8 @@ func TestHandleDeleteSubscriptions(t *testing.T) {
 		statusCode       int
 		subscriptionList []*serializers.SubscriptionDetails
 		subscription     *serializers.SubscriptionDetails
+		isValidChannelID bool
+		channelErr       *model.AppError
 	}{
 		{
 			description: "HandleDeleteSubscriptions: valid",
@@ -1088,6 +3063,63 @@ func TestHandleDeleteSubscriptions(t *testing.T) {
 			statusCode:       http.StatusOK,
 			subscriptionList: []*serializers.SubscriptionDetails{},
 			subscription:     testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, testutils.MockServiceType, testutils.MockEventType)[0],
+			isValidChannelID: true,
+		},
+		{
+			description: "HandleDeleteSubscriptions: valid with the subscription ID",
+			body: `{
+				"subscriptionID": "mockSubscriptionID",
+				"channelID": "mockChannelID",
+				"mmUserID": "mockMattermostUserID"
+				}`,
+			statusCode:       http.StatusOK,
+			subscriptionList: []*serializers.SubscriptionDetails{{SubscriptionID: "mockSubscriptionID", ChannelID: testutils.MockChannelID}},
+			isValidChannelID: true,
+		},
+		{
+			description: "HandleDeleteSubscriptions: subscription ID with missing channel ID",
+			body: `{
+				"subscriptionID": "mockSubscriptionID",
+				"mmUserID": "mockMattermostUserID"
+				}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleDeleteSubscriptions: channel ID with surrounding spaces",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"channelID": "  mockChannelID ",
+				"mmUserID": "mockMattermostUserID"
+				}`,
+			statusCode:       http.StatusOK,
+			subscriptionList: []*serializers.SubscriptionDetails{},
+			isValidChannelID: true,
+		},
+		{
+			description: "HandleDeleteSubscriptions: malformed channel ID",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"channelID": "mockChannelID",
+				"mmUserID": "mockMattermostUserID"
+				}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			description: "HandleDeleteSubscriptions: channel does not exist",
+			body: `{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": "mockEventType",
+				"channelID": "mockChannelID",
+				"mmUserID": "mockMattermostUserID"
+				}`,
+			statusCode:       http.StatusNotFound,
+			isValidChannelID: true,
+			channelErr:       &model.AppError{StatusCode: http.StatusNotFound},
 		},
 		{
 			description: "HandleDeleteSubscriptions: empty body",
@@ -1113,18 +3145,25 @@ func TestHandleDeleteSubscriptions(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.ExpectedCalls = nil
 			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
 			mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
 
 			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsSubscriptionPresent", func(*Plugin, []*serializers.SubscriptionDetails, *serializers.SubscriptionDetails) (*serializers.SubscriptionDetails, bool) {
 				return &serializers.SubscriptionDetails{}, true
 			})
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return testCase.isValidChannelID
+			})
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, testCase.channelErr)
 
 			if testCase.statusCode == http.StatusOK {
 				mockedClient.EXPECT().DeleteSubscription(gomock.Any(), gomock.Any(), gomock.Any()).Return(testCase.statusCode, testCase.err)
 				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(testCase.subscriptionList, nil)
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(testCase.subscriptionList, nil)
 				mockedStore.EXPECT().DeleteSubscription(gomock.Any()).Return(nil)
 				mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(gomock.Any()).Return(nil)
+				mockedStore.EXPECT().DeleteLastNotification(gomock.Any()).Return(nil)
 			}
 
 			req := httptest.NewRequest(http.MethodDelete, "/subscriptions", bytes.NewBufferString(testCase.body))
@@ -1138,6 +3177,47 @@ func TestHandleDeleteSubscriptions(t *testing.T) {
 	}
 }
 
+func TestFindSubscriptionToDelete(t *testing.T) {
+	p := setupTestPlugin(nil)
+	subscriptionList := []*serializers.SubscriptionDetails{
+		{SubscriptionID: "mockSubscriptionID1", ChannelID: "mockChannelID1", OrganizationName: "mockOrganization", ProjectName: "mockProjectName", EventType: "mockEventType"},
+		{SubscriptionID: "mockSubscriptionID2", ChannelID: "mockChannelID2", OrganizationName: "mockOrganization", ProjectName: "mockProjectName", EventType: "mockEventType"},
+	}
+	for _, testCase := range []struct {
+		description          string
+		body                 *serializers.DeleteSubscriptionRequestPayload
+		expectedSubscription *serializers.SubscriptionDetails
+	}{
+		{
+			description:          "FindSubscriptionToDelete: subscription found by its ID even though its fields changed",
+			body:                 &serializers.DeleteSubscriptionRequestPayload{SubscriptionID: "mockSubscriptionID2", ChannelID: "mockChannelID2", EventType: "mockChangedEventType"},
+			expectedSubscription: subscriptionList[1],
+		},
+		{
+			description: "FindSubscriptionToDelete: subscription ID of another channel",
+			body:        &serializers.DeleteSubscriptionRequestPayload{SubscriptionID: "mockSubscriptionID2", ChannelID: "mockChannelID1"},
+		},
+		{
+			description: "FindSubscriptionToDelete: unknown subscription ID",
+			body:        &serializers.DeleteSubscriptionRequestPayload{SubscriptionID: "mockSubscriptionID3", ChannelID: "mockChannelID1"},
+		},
+		{
+			description:          "FindSubscriptionToDelete: subscription found by its fields without the ID",
+			body:                 &serializers.DeleteSubscriptionRequestPayload{ChannelID: "mockChannelID1", Organization: "mockOrganization", Project: "mockProjectName", EventType: "mockEventType"},
+			expectedSubscription: subscriptionList[0],
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			subscription, isSubscriptionPresent := p.findSubscriptionToDelete(subscriptionList, testCase.body)
+
+			assert.Equal(t, testCase.expectedSubscription != nil, isSubscriptionPresent)
+			if testCase.expectedSubscription != nil {
+				assert.Equal(t, testCase.expectedSubscription, subscription)
+			}
+		})
+	}
+}
+
 func TestHandlePipelineApproveOrRejectRunRequest(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
@@ -1283,7 +3363,9 @@ func TestHandlePipelineApproveOrRejectReleaseRequest(t *testing.T) {
 		getApprovalDetailsError                   error
 		updatePipelineApprovalRequestStatus       int
 		getApprovalDetailsStatus                  int
+		approver                                  string
 		isPayloadInvalid                          bool
+		expectedEphemeralMessage                  string
 	}{
 		{
 			description:                         "HandlePipelineApproveOrRejectReleaseRequest: valid",
@@ -1315,6 +3397,24 @@ func TestHandlePipelineApproveOrRejectReleaseRequest(t *testing.T) {
 			statusCode:                          http.StatusInternalServerError,
 			getApprovalDetailsStatus:            http.StatusInternalServerError,
 		},
+		{
+			description:                               "HandlePipelineApproveOrRejectReleaseRequest: user is not an approver",
+			updatePipelineApprovalRequestStatus:       http.StatusForbidden,
+			updatePipelineReleaseApprovalRequestError: errors.New("forbidden"),
+			getApprovalDetailsStatus:                  http.StatusOK,
+			approver:                                  "mockApprover",
+			statusCode:                                http.StatusOK,
+			expectedEphemeralMessage:                  fmt.Sprintf("%s %s", constants.ReleaseApprovalNotAllowed, fmt.Sprintf(constants.ReleaseApprovalApprover, "mockApprover")),
+		},
+		{
+			description:                               "HandlePipelineApproveOrRejectReleaseRequest: user is not an approver and failed to fetch approval details",
+			updatePipelineApprovalRequestStatus:       http.StatusForbidden,
+			updatePipelineReleaseApprovalRequestError: errors.New("forbidden"),
+			getApprovalDetailsStatus:                  http.StatusInternalServerError,
+			getApprovalDetailsError:                   errors.New("failed to get the approval details"),
+			statusCode:                                http.StatusOK,
+			expectedEphemeralMessage:                  constants.ReleaseApprovalNotAllowed,
+		},
 		{
 			description:      "HandlePipelineApproveOrRejectReleaseRequest: invalid payload",
 			isPayloadInvalid: true,
@@ -1333,12 +3433,18 @@ func TestHandlePipelineApproveOrRejectReleaseRequest(t *testing.T) {
 			mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Return(&model.Post{})
 			mockAPI.On("UpdateEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Return(nil)
 
+			mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
+
 			if !testCase.isPayloadInvalid {
-				mockedClient.EXPECT().UpdatePipelineApprovalRequest(gomock.Any(), testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID, 1234).Return(testCase.updatePipelineApprovalRequestStatus, testCase.updatePipelineReleaseApprovalRequestError)
+				mockedClient.EXPECT().SetReleaseApproval(testutils.MockOrganization, testutils.MockProjectName, 1234, "mockRequestType", "mockComment", testutils.MockMattermostUserID).Return(testCase.updatePipelineApprovalRequestStatus, testCase.updatePipelineReleaseApprovalRequestError)
 			}
 
-			if testCase.updatePipelineApprovalRequestStatus == http.StatusBadRequest {
-				mockedClient.EXPECT().GetApprovalDetails(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID, 1234).Return(&serializers.PipelineApprovalDetails{}, testCase.getApprovalDetailsStatus, testCase.getApprovalDetailsError)
+			if testCase.updatePipelineApprovalRequestStatus == http.StatusBadRequest || testCase.updatePipelineApprovalRequestStatus == http.StatusForbidden {
+				var releaseApproval *serializers.ReleaseApproval
+				if testCase.getApprovalDetailsError == nil {
+					releaseApproval = &serializers.ReleaseApproval{Approver: serializers.Approver{DisplayName: testCase.approver}}
+				}
+				mockedClient.EXPECT().GetReleaseApproval(testutils.MockOrganization, testutils.MockProjectName, 1234, testutils.MockMattermostUserID).Return(releaseApproval, testCase.getApprovalDetailsStatus, testCase.getApprovalDetailsError)
 			}
 
 			monkey.PatchInstanceMethod(reflect.TypeOf(p), "UpdatePipelineReleaseApprovalPost", func(_ *Plugin, _, _, _ string) error {
@@ -1361,6 +3467,16 @@ func TestHandlePipelineApproveOrRejectReleaseRequest(t *testing.T) {
 			p.handlePipelineApproveOrRejectReleaseRequest(w, req)
 			resp := w.Result()
 			assert.Equal(t, testCase.statusCode, resp.StatusCode)
+
+			if testCase.expectedEphemeralMessage != "" {
+				var ephemeralMessages []string
+				for _, call := range mockAPI.Calls {
+					if call.Method == "SendEphemeralPost" {
+						ephemeralMessages = append(ephemeralMessages, call.Arguments.Get(1).(*model.Post).Message)
+					}
+				}
+				assert.Equal(t, testCase.expectedEphemeralMessage, ephemeralMessages[len(ephemeralMessages)-1])
+			}
 		})
 	}
 }
@@ -1554,3 +3670,192 @@ func TestHandleGetSubscriptionFilterPossibleValues(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGetUserChannelsForTeam(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	for _, testCase := range []struct {
+		description        string
+		teamID             string
+		teamMember         *model.TeamMember
+		teamMemberErr      *model.AppError
+		channels           []*model.Channel
+		expectedChannels   int
+		expectedStatusCode int
+	}{
+		{
+			description: "HandleGetUserChannelsForTeam: user is a member of the team",
+			teamID:      "qteks46as3befxj4ec1mip5ume",
+			teamMember: &model.TeamMember{
+				UserId: testutils.MockMattermostUserID,
+			},
+			channels: []*model.Channel{
+				{
+					Id:   testutils.MockChannelID,
+					Type: model.CHANNEL_OPEN,
+				},
+				{
+					Id:   "mockDirectChannelID",
+					Type: model.CHANNEL_DIRECT,
+				},
+			},
+			expectedChannels:   1,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleGetUserChannelsForTeam: user is not a member of the team",
+			teamID:             "qteks46as3befxj4ec1mip5ume",
+			teamMemberErr:      &model.AppError{StatusCode: http.StatusNotFound},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			description:        "HandleGetUserChannelsForTeam: invalid team ID",
+			teamID:             "mockTeamID",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.ExpectedCalls = nil
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			mockAPI.On("LogWarn", mock.AnythingOfType("string"))
+			mockAPI.On("GetTeamMember", testCase.teamID, testutils.MockMattermostUserID).Return(testCase.teamMember, testCase.teamMemberErr)
+			mockAPI.On("GetChannelsForTeamForUser", testCase.teamID, testutils.MockMattermostUserID, false).Return(testCase.channels, nil)
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/channels/%s", testCase.teamID), bytes.NewBufferString(`{}`))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+			req = mux.SetURLVars(req, map[string]string{constants.PathParamTeamID: testCase.teamID})
+
+			w := httptest.NewRecorder()
+			p.handleGetUserChannelsForTeam(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+
+			if testCase.expectedStatusCode == http.StatusOK {
+				var channels []*model.Channel
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&channels))
+				assert.Len(t, channels, testCase.expectedChannels)
+			}
+		})
+	}
+}
+
+func TestHandleCreateSubscriptionResourceVersion(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description             string
+		resourceVersion         string
+		messageFormat           string
+		expectedStatusCode      int
+		expectedResourceVersion string
+		expectedMessageFormat   string
+	}{
+		{
+			description:             "HandleCreateSubscriptionResourceVersion: non-default version and format are stored",
+			resourceVersion:         "1.0-preview.1",
+			messageFormat:           "Text",
+			expectedStatusCode:      http.StatusOK,
+			expectedResourceVersion: "1.0-preview.1",
+			expectedMessageFormat:   constants.SubscriptionMessageFormatText,
+		},
+		{
+			description:             "HandleCreateSubscriptionResourceVersion: default version is stored",
+			expectedStatusCode:      http.StatusOK,
+			expectedResourceVersion: "1.0",
+		},
+		{
+			description:        "HandleCreateSubscriptionResourceVersion: unsupported version",
+			resourceVersion:    "3.0-preview.1",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "HandleCreateSubscriptionResourceVersion: unsupported format",
+			messageFormat:      "json",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{DisplayName: "mockChannelName"}, nil)
+			mockAPI.On("GetUser", testutils.MockMattermostUserID).Return(&model.User{Username: "mockCreatedBy"}, nil)
+			mockAPI.On("GetConfig").Return(&model.Config{})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+				return http.StatusOK, nil
+			})
+
+			var storedSubscription *serializers.SubscriptionDetails
+			if testCase.expectedStatusCode == http.StatusOK {
+				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}}, nil)
+				mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(nil, nil)
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(nil, nil)
+				mockedClient.EXPECT().CreateSubscription(gomock.Any(), gomock.Any(), testutils.MockChannelID, gomock.Any(), testutils.MockMattermostUserID, gomock.Any()).Return(&serializers.SubscriptionValue{ID: testutils.MockSubscriptionID}, http.StatusOK, nil)
+				mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(testutils.MockSubscriptionID, gomock.Any(), testutils.MockChannelID).Return(nil)
+				mockedStore.EXPECT().StoreSubscription(gomock.Any()).DoAndReturn(func(subscription *serializers.SubscriptionDetails) error {
+					storedSubscription = subscription
+					return nil
+				})
+			}
+
+			body := fmt.Sprintf(`{
+				"organization": "mockOrganization",
+				"project": "mockProjectName",
+				"eventType": %q,
+				"serviceType": "boards",
+				"channelID": "mockChannelID",
+				"resourceVersion": %q,
+				"messageFormat": %q
+				}`, constants.SubscriptionEventWorkItemCreated, testCase.resourceVersion, testCase.messageFormat)
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleCreateSubscription(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			if testCase.expectedStatusCode == http.StatusOK {
+				require.NotNil(t, storedSubscription)
+				assert.Equal(t, testCase.expectedResourceVersion, storedSubscription.ResourceVersion)
+				assert.Equal(t, testCase.expectedMessageFormat, storedSubscription.MessageFormat)
+			}
+		})
+	}
+}
+
+func TestConvertNotificationMessagesToMarkdown(t *testing.T) {
+	for _, testCase := range []struct {
+		description      string
+		subscription     *serializers.SubscriptionDetails
+		expectedMarkdown string
+	}{
+		{
+			description:      "ConvertNotificationMessagesToMarkdown: text messages",
+			subscription:     &serializers.SubscriptionDetails{MessageFormat: constants.SubscriptionMessageFormatText},
+			expectedMarkdown: "mockText",
+		},
+		{
+			description:      "ConvertNotificationMessagesToMarkdown: HTML messages",
+			subscription:     &serializers.SubscriptionDetails{MessageFormat: constants.SubscriptionMessageFormatHTML},
+			expectedMarkdown: "**mockHTML**",
+		},
+		{
+			description:  "ConvertNotificationMessagesToMarkdown: Markdown messages",
+			subscription: &serializers.SubscriptionDetails{},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			body := &serializers.SubscriptionNotification{
+				Message:         serializers.DetailedMessage{Text: "mockText", HTML: "<b>mockHTML</b>"},
+				DetailedMessage: serializers.DetailedMessage{Markdown: "mockMarkdown", Text: "mockText"},
+			}
+
+			convertNotificationMessagesToMarkdown(testCase.subscription, body)
+
+			assert.Equal(t, testCase.expectedMarkdown, body.Message.Markdown)
+			assert.Equal(t, "mockMarkdown", body.DetailedMessage.Markdown)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// workItemBlocker is a work item linked as a predecessor of the work item whose blockers are listed, or of one of its blockers
+type workItemBlocker struct {
+	id int
+	// The work item is nil if it could not be fetched, e.g. if the user can't access its project
+	workItem *serializers.TaskValue
+	// The depth of the direct blockers is 0
+	depth  int
+	isOpen bool
+}
+
+// workItemBlockers contains the blockers of a work item in the order they are shown, each followed by its own blockers
+type workItemBlockers struct {
+	blockers []*workItemBlocker
+	visited  map[int]bool
+	// The work items being traversed, a predecessor which is one of them makes the dependencies circular
+	path           map[int]bool
+	circularID     int
+	isDepthLimited bool
+	isCountLimited bool
+}
+
+// getWorkItemBlockers returns the work items blocking a work item as a table, along with the work items blocking them up to a maximum depth.
+// The blockers which are still open are highlighted.
+func (p *Plugin) getWorkItemBlockers(mattermostUserID, projectArgument, workItemID string) (string, error) {
+	if _, err := strconv.Atoi(workItemID); err != nil {
+		return fmt.Sprintf(constants.InvalidWorkItemID, workItemID), nil
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	workItem, statusCode, err := p.Client.GetWorkItem(project.OrganizationName, workItemID, project.ProjectName, mattermostUserID)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return fmt.Sprintf(constants.WorkItemNotFound, workItemID, project.ProjectName), nil
+		}
+		return "", err
+	}
+
+	result := &workItemBlockers{
+		visited: map[int]bool{workItem.ID: true},
+		path:    map[int]bool{},
+	}
+	p.addWorkItemBlockers(result, workItem, 0, project, mattermostUserID)
+	if len(result.blockers) == 0 {
+		return fmt.Sprintf(constants.NoWorkItemBlockers, workItemID), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Work items blocking [%s %d: %s](%s)\n", workItem.Fields.Type, workItem.ID, workItem.Fields.Title, workItem.Link.HTML.Href))
+	sb.WriteString("| ID | Type | Title | State |\n")
+	sb.WriteString("| :- | :--- | :---- | :---- |\n")
+	openCount := 0
+	for _, blocker := range result.blockers {
+		if blocker.workItem == nil {
+			link := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), blocker.id)
+			sb.WriteString(fmt.Sprintf("| [%d](%s) |  |  |  |\n", blocker.id, link))
+			continue
+		}
+
+		title := escapeTableCell(blocker.workItem.Fields.Title)
+		if blocker.depth > 0 {
+			title = fmt.Sprintf("%s↳ %s", strings.Repeat("· ", blocker.depth-1), title)
+		}
+
+		state := escapeTableCell(blocker.workItem.Fields.State)
+		if blocker.isOpen {
+			openCount++
+			state = fmt.Sprintf("**%s**", state)
+		}
+
+		sb.WriteString(fmt.Sprintf("| [%d](%s) | %s | %s | %s |\n", blocker.id, blocker.workItem.Link.HTML.Href, escapeTableCell(blocker.workItem.Fields.Type), title, state))
+	}
+
+	sb.WriteString("\n")
+	if openCount > 0 {
+		sb.WriteString(fmt.Sprintf(constants.WorkItemBlockersOpen, openCount, len(result.blockers)))
+	} else {
+		sb.WriteString(fmt.Sprintf(constants.WorkItemBlockersDone, len(result.blockers)))
+	}
+	if result.circularID != 0 {
+		sb.WriteString("\n" + fmt.Sprintf(constants.WorkItemBlockersCircular, result.circularID))
+	}
+	if result.isDepthLimited {
+		sb.WriteString("\n" + fmt.Sprintf(constants.WorkItemBlockersMaxDepth, constants.BlockersMaxDepth))
+	}
+	if result.isCountLimited {
+		sb.WriteString("\n" + fmt.Sprintf(constants.WorkItemBlockersMaxCount, constants.BlockersMaxWorkItems))
+	}
+
+	return sb.String(), nil
+}
+
+// addWorkItemBlockers adds the predecessors of a work item to the blockers, each followed by its own predecessors.
+// A work item blocking multiple others is only listed once, and the traversal stops at the maximum depth or number of blockers.
+func (p *Plugin) addWorkItemBlockers(result *workItemBlockers, workItem *serializers.TaskValue, depth int, project *serializers.ProjectDetails, mattermostUserID string) {
+	result.path[workItem.ID] = true
+	defer delete(result.path, workItem.ID)
+
+	for _, predecessorID := range getPredecessorIDs(workItem.Relations) {
+		switch {
+		case result.path[predecessorID]:
+			result.circularID = predecessorID
+			continue
+		case result.visited[predecessorID]:
+			continue
+		case depth == constants.BlockersMaxDepth:
+			result.isDepthLimited = true
+			return
+		case len(result.blockers) == constants.BlockersMaxWorkItems:
+			result.isCountLimited = true
+			return
+		}
+
+		result.visited[predecessorID] = true
+		blocker := &workItemBlocker{id: predecessorID, depth: depth}
+		result.blockers = append(result.blockers, blocker)
+
+		predecessor, _, err := p.Client.GetWorkItem(project.OrganizationName, strconv.Itoa(predecessorID), project.ProjectName, mattermostUserID)
+		if err != nil {
+			p.API.LogDebug("Error in getting the blocking work item from Azure", "WorkItemID", predecessorID, "Error", err.Error())
+			continue
+		}
+
+		blocker.workItem = predecessor
+		blocker.isOpen = p.isWorkItemOpen(project, predecessor, mattermostUserID)
+		p.addWorkItemBlockers(result, predecessor, depth+1, project, mattermostUserID)
+	}
+}
+
+// isWorkItemOpen checks if a work item is still to be done or in progress using the category of its state.
+// A predecessor can belong to another project of the organization than the linked one.
+func (p *Plugin) isWorkItemOpen(project *serializers.ProjectDetails, workItem *serializers.TaskValue, mattermostUserID string) bool {
+	projectName := workItem.Fields.Project
+	if projectName == "" {
+		projectName = project.ProjectName
+	}
+
+	stateGroup := p.getSprintStateGroup(project.OrganizationName, projectName, workItem.Fields.Type, workItem.Fields.State, mattermostUserID)
+	return stateGroup == constants.SprintStateToDo || stateGroup == constants.SprintStateInProgress
+}
+
+// getPredecessorIDs returns the IDs of the work items linked as predecessors from the URLs of the relations like ".../_apis/wit/workItems/{id}"
+func getPredecessorIDs(relations []*serializers.WorkItemRelation) []int {
+	var ids []int
+	for _, relation := range relations {
+		if relation == nil || relation.Rel != constants.RelationPredecessor {
+			continue
+		}
+
+		if id, err := strconv.Atoi(relation.URL[strings.LastIndex(relation.URL, "/")+1:]); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
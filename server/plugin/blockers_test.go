@@ -0,0 +1,175 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getMockBlockerWorkItem(id int, state string, predecessorIDs ...int) *serializers.TaskValue {
+	workItem := &serializers.TaskValue{
+		ID: id,
+		Fields: serializers.TaskFieldValue{
+			Title:   fmt.Sprintf("mockTitle%d", id),
+			Project: testutils.MockProjectName,
+			Type:    "Task",
+			State:   state,
+		},
+		Link: serializers.Link{HTML: serializers.Href{Href: fmt.Sprintf("mockLink%d", id)}},
+	}
+	for _, predecessorID := range predecessorIDs {
+		workItem.Relations = append(workItem.Relations, &serializers.WorkItemRelation{
+			Rel: constants.RelationPredecessor,
+			URL: fmt.Sprintf("https://dev.azure.com/mockOrganization/_apis/wit/workItems/%d", predecessorID),
+		})
+	}
+
+	return workItem
+}
+
+func TestGetPredecessorIDs(t *testing.T) {
+	assert.Equal(t, []int{2, 3}, getPredecessorIDs([]*serializers.WorkItemRelation{
+		{Rel: constants.RelationPredecessor, URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/2"},
+		{Rel: "System.LinkTypes.Dependency-Forward", URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/4"},
+		{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F12"},
+		{Rel: constants.RelationPredecessor, URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/3"},
+		{Rel: constants.RelationPredecessor, URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/invalid"},
+		nil,
+	}))
+}
+
+func TestGetWorkItemBlockers(t *testing.T) {
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	for _, testCase := range []struct {
+		description     string
+		workItems       []*serializers.TaskValue
+		expectedMessage string
+	}{
+		{
+			description:     "GetWorkItemBlockers: work item without blockers",
+			workItems:       []*serializers.TaskValue{getMockBlockerWorkItem(1, "Active")},
+			expectedMessage: "Work item 1 is not blocked by any work item",
+		},
+		{
+			description: "GetWorkItemBlockers: direct blockers",
+			workItems:   []*serializers.TaskValue{getMockBlockerWorkItem(1, "Active", 2, 3), getMockBlockerWorkItem(2, "Active"), getMockBlockerWorkItem(3, "Closed")},
+			expectedMessage: "###### Work items blocking [Task 1: mockTitle1](mockLink1)\n" +
+				"| ID | Type | Title | State |\n" +
+				"| :- | :--- | :---- | :---- |\n" +
+				"| [2](mockLink2) | Task | mockTitle2 | **Active** |\n" +
+				"| [3](mockLink3) | Task | mockTitle3 | Closed |\n" +
+				"\n1 of the 2 blocking work item(s) are still open",
+		},
+		{
+			description: "GetWorkItemBlockers: blockers of the blockers",
+			workItems:   []*serializers.TaskValue{getMockBlockerWorkItem(1, "Active", 2), getMockBlockerWorkItem(2, "Closed", 3), getMockBlockerWorkItem(3, "Done")},
+			expectedMessage: "###### Work items blocking [Task 1: mockTitle1](mockLink1)\n" +
+				"| ID | Type | Title | State |\n" +
+				"| :- | :--- | :---- | :---- |\n" +
+				"| [2](mockLink2) | Task | mockTitle2 | Closed |\n" +
+				"| [3](mockLink3) | Task | ↳ mockTitle3 | Done |\n" +
+				"\nAll the 2 blocking work item(s) are done",
+		},
+		{
+			description: "GetWorkItemBlockers: circular dependencies",
+			workItems:   []*serializers.TaskValue{getMockBlockerWorkItem(1, "Active", 2), getMockBlockerWorkItem(2, "New", 1)},
+			expectedMessage: "###### Work items blocking [Task 1: mockTitle1](mockLink1)\n" +
+				"| ID | Type | Title | State |\n" +
+				"| :- | :--- | :---- | :---- |\n" +
+				"| [2](mockLink2) | Task | mockTitle2 | **New** |\n" +
+				"\n1 of the 1 blocking work item(s) are still open" +
+				"\nThe dependencies are circular, work item 1 is blocked by a work item it blocks",
+		},
+		{
+			description: "GetWorkItemBlockers: blockers deeper than the maximum depth",
+			workItems: []*serializers.TaskValue{
+				getMockBlockerWorkItem(1, "Active", 2), getMockBlockerWorkItem(2, "Closed", 3), getMockBlockerWorkItem(3, "Closed", 4), getMockBlockerWorkItem(4, "Closed", 5),
+			},
+			expectedMessage: "###### Work items blocking [Task 1: mockTitle1](mockLink1)\n" +
+				"| ID | Type | Title | State |\n" +
+				"| :- | :--- | :---- | :---- |\n" +
+				"| [2](mockLink2) | Task | mockTitle2 | Closed |\n" +
+				"| [3](mockLink3) | Task | ↳ mockTitle3 | Closed |\n" +
+				"| [4](mockLink4) | Task | · ↳ mockTitle4 | Closed |\n" +
+				"\nAll the 3 blocking work item(s) are done" +
+				"\nOnly the blockers up to 3 levels deep are shown",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+			mockedClient.EXPECT().GetWorkItemTypeStates(testutils.MockOrganization, testutils.MockProjectName, "Task", testutils.MockMattermostUserID).Return([]*serializers.WorkItemTypeState{
+				{Name: "New", Category: constants.StateCategoryProposed},
+				{Name: "Active", Category: constants.StateCategoryInProgress},
+				{Name: "Closed", Category: constants.StateCategoryCompleted},
+			}, http.StatusOK, nil).MaxTimes(1)
+			for _, workItem := range testCase.workItems {
+				mockedClient.EXPECT().GetWorkItem(testutils.MockOrganization, fmt.Sprint(workItem.ID), testutils.MockProjectName, testutils.MockMattermostUserID).Return(workItem, http.StatusOK, nil)
+			}
+
+			message, err := p.getWorkItemBlockers(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+
+	t.Run("GetWorkItemBlockers: blocker can't be fetched", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		mockAPI.On("LogDebug", mock.AnythingOfType("string"), "WorkItemID", 2, "Error", "error in getting the work item")
+
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetWorkItem(testutils.MockOrganization, "1", testutils.MockProjectName, testutils.MockMattermostUserID).Return(getMockBlockerWorkItem(1, "Active", 2), http.StatusOK, nil)
+		mockedClient.EXPECT().GetWorkItem(testutils.MockOrganization, "2", testutils.MockProjectName, testutils.MockMattermostUserID).Return(nil, http.StatusNotFound, errors.New("error in getting the work item"))
+
+		message, err := p.getWorkItemBlockers(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+		assert.NoError(t, err)
+		assert.Contains(t, message, "| [2](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/2) |  |  |  |\n")
+	})
+
+	t.Run("GetWorkItemBlockers: work item does not exist", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, mockedClient)
+
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetWorkItem(testutils.MockOrganization, "1", testutils.MockProjectName, testutils.MockMattermostUserID).Return(nil, http.StatusNotFound, errors.New("error in getting the work item"))
+
+		message, err := p.getWorkItemBlockers(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.WorkItemNotFound, "1", testutils.MockProjectName), message)
+	})
+
+	t.Run("GetWorkItemBlockers: invalid work item ID", func(t *testing.T) {
+		p := setupMockPlugin(&plugintest.API{}, nil, nil)
+
+		message, err := p.getWorkItemBlockers(testutils.MockMattermostUserID, testutils.MockProjectName, "abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.InvalidWorkItemID, "abc"), message)
+	})
+}
@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getNotificationBranch returns the branch of the push, pull request or build a notification is about.
+// It's empty for the other notifications, which are not filtered by branch.
+func getNotificationBranch(body *serializers.SubscriptionNotification) string {
+	var ref string
+	switch body.EventType {
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
+		ref = body.Resource.TargetRefName
+	case constants.SubscriptionEventPullRequestCommented:
+		ref = body.Resource.PullRequest.TargetRefName
+	case constants.SubscriptionEventCodePushed:
+		if len(body.Resource.RefUpdates) > 0 {
+			ref = body.Resource.RefUpdates[0].Name
+		}
+	case constants.SubscriptionEventBuildCompleted:
+		ref = body.Resource.SourceBranch
+	}
+
+	return strings.TrimPrefix(ref, constants.GitBranchRefPrefix)
+}
+
+// isBranchMatchingFilters checks if the notifications of a branch are posted for the branch filters of a subscription.
+// A branch should match at least one of the filters, unless all of them are negated, and none of the negated filters.
+func isBranchMatchingFilters(filters []string, branch string) bool {
+	if len(filters) == 0 || branch == "" {
+		return true
+	}
+
+	isIncluded, hasIncludeFilters := false, false
+	for _, filter := range filters {
+		if strings.HasPrefix(filter, constants.BranchFilterNegation) {
+			if isMatched, _ := path.Match(strings.TrimPrefix(filter, constants.BranchFilterNegation), branch); isMatched {
+				return false
+			}
+			continue
+		}
+
+		hasIncludeFilters = true
+		if isMatched, _ := path.Match(filter, branch); isMatched {
+			isIncluded = true
+		}
+	}
+
+	return isIncluded || !hasIncludeFilters
+}
+
+// isPullRequestTargetBranchMatching checks if the notification of a pull request is posted for the target branches of a subscription.
+// The pull request is fetched when its target branch is not in the payload, and the notification is posted if it can't be fetched.
+func (p *Plugin) isPullRequestTargetBranchMatching(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) bool {
+	if subscription == nil || len(subscription.PullRequestTargetBranches) == 0 {
+		return true
+	}
+
+	var pullRequestID int
+	switch body.EventType {
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
+		pullRequestID = body.Resource.PullRequestID
+	case constants.SubscriptionEventPullRequestCommented:
+		pullRequestID = body.Resource.PullRequest.PullRequestID
+	default:
+		return true
+	}
+
+	branch := getNotificationBranch(body)
+	if branch == "" && pullRequestID > 0 {
+		pullRequest, _, err := p.Client.GetPullRequest(subscription.OrganizationName, strconv.Itoa(pullRequestID), subscription.ProjectName, subscription.MattermostUserID)
+		if err != nil {
+			p.API.LogDebug("Error in getting the target branch of the pull request from Azure", "Error", err.Error())
+		} else if pullRequest != nil {
+			branch = strings.TrimPrefix(pullRequest.TargetRefName, constants.GitBranchRefPrefix)
+		}
+	}
+
+	return isBranchMatchingFilters(subscription.PullRequestTargetBranches, branch)
+}
+
+// getBranchFilters returns the branch filters of a subscription without the whitespace and the empty filters
+func getBranchFilters(filters []string) []string {
+	var branchFilters []string
+	for _, filter := range filters {
+		if filter = strings.TrimSpace(filter); filter != "" {
+			branchFilters = append(branchFilters, filter)
+		}
+	}
+
+	return branchFilters
+}
@@ -0,0 +1,260 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestIsBranchMatchingFilters(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		filters     []string
+		branch      string
+		isMatched   bool
+	}{
+		{
+			description: "IsBranchMatchingFilters: no filters",
+			branch:      "feature/login",
+			isMatched:   true,
+		},
+		{
+			description: "IsBranchMatchingFilters: notification without a branch",
+			filters:     []string{"main"},
+			isMatched:   true,
+		},
+		{
+			description: "IsBranchMatchingFilters: branch matches a filter",
+			filters:     []string{"main", "release/*"},
+			branch:      "main",
+			isMatched:   true,
+		},
+		{
+			description: "IsBranchMatchingFilters: branch matches a glob pattern",
+			filters:     []string{"main", "release/*"},
+			branch:      "release/1.0",
+			isMatched:   true,
+		},
+		{
+			description: "IsBranchMatchingFilters: glob pattern does not match nested branches",
+			filters:     []string{"release/*"},
+			branch:      "release/1.0/hotfix",
+		},
+		{
+			description: "IsBranchMatchingFilters: branch does not match any filter",
+			filters:     []string{"main", "release/*"},
+			branch:      "feature/login",
+		},
+		{
+			description: "IsBranchMatchingFilters: branch matches a negated filter",
+			filters:     []string{"release/*", "!release/experimental"},
+			branch:      "release/experimental",
+		},
+		{
+			description: "IsBranchMatchingFilters: only negated filters",
+			filters:     []string{"!feature/*"},
+			branch:      "main",
+			isMatched:   true,
+		},
+		{
+			description: "IsBranchMatchingFilters: branch matches only negated filters",
+			filters:     []string{"!feature/*"},
+			branch:      "feature/login",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.isMatched, isBranchMatchingFilters(testCase.filters, testCase.branch))
+		})
+	}
+}
+
+func TestGetNotificationBranch(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		body           *serializers.SubscriptionNotification
+		expectedBranch string
+	}{
+		{
+			description:    "GetNotificationBranch: target branch of a pull request",
+			body:           &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCreated, Resource: serializers.Resource{SourceRefName: "refs/heads/feature/login", TargetRefName: "refs/heads/release/1.0"}},
+			expectedBranch: "release/1.0",
+		},
+		{
+			description:    "GetNotificationBranch: target branch of a commented pull request",
+			body:           &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCommented, Resource: serializers.Resource{PullRequest: serializers.PullRequest{TargetRefName: "refs/heads/main"}}},
+			expectedBranch: "main",
+		},
+		{
+			description:    "GetNotificationBranch: pushed branch",
+			body:           &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed, Resource: serializers.Resource{RefUpdates: []serializers.RefUpdates{{Name: "refs/heads/main"}}}},
+			expectedBranch: "main",
+		},
+		{
+			description:    "GetNotificationBranch: source branch of a build",
+			body:           &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted, Resource: serializers.Resource{SourceBranch: "refs/heads/main"}},
+			expectedBranch: "main",
+		},
+		{
+			description: "GetNotificationBranch: work item notification",
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCreated},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedBranch, getNotificationBranch(testCase.body))
+		})
+	}
+}
+
+func TestHandleSubscriptionNotificationsWithBranchFilters(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description  string
+		targetBranch string
+		isPosted     bool
+	}{
+		{
+			description:  "SubscriptionNotificationsWithBranchFilters: notification of a matching branch is posted",
+			targetBranch: "refs/heads/release/1.0",
+			isPosted:     true,
+		},
+		{
+			description:  "SubscriptionNotificationsWithBranchFilters: notification of a non matching branch is suppressed",
+			targetBranch: "refs/heads/feature/login",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{{
+				SubscriptionID: testutils.MockSubscriptionID,
+				BranchFilters:  []string{"main", "release/*"},
+			}}, nil)
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+			mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+			isPosted := false
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(mock.Arguments) {
+				isPosted = true
+			}).Return(&model.Post{}, nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return testutils.MockChannelID, http.StatusOK, nil
+			})
+
+			body := fmt.Sprintf(`{
+				"subscriptionID": "mockSubscriptionID",
+				"eventType": "git.pullrequest.created",
+				"resource": {"pullRequestId": 1, "targetRefName": %q, "sourceRefName": "refs/heads/mockBranch"},
+				"message": {"markdown": "mockMarkdown"}
+			}`, testCase.targetBranch)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(body))
+
+			w := httptest.NewRecorder()
+			p.handleSubscriptionNotifications(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, testCase.isPosted, isPosted)
+		})
+	}
+}
+
+func TestIsPullRequestTargetBranchMatching(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{
+		OrganizationName:          testutils.MockOrganization,
+		ProjectName:               testutils.MockProjectName,
+		MattermostUserID:          testutils.MockMattermostUserID,
+		PullRequestTargetBranches: []string{"main", "release/*"},
+	}
+	for _, testCase := range []struct {
+		description          string
+		subscription         *serializers.SubscriptionDetails
+		body                 *serializers.SubscriptionNotification
+		fetchedTargetRefName string
+		fetchErr             error
+		expectedFetch        bool
+		isMatched            bool
+	}{
+		{
+			description:  "IsPullRequestTargetBranchMatching: subscription without target branches",
+			subscription: &serializers.SubscriptionDetails{},
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCreated, Resource: serializers.Resource{TargetRefName: "refs/heads/feature/login"}},
+			isMatched:    true,
+		},
+		{
+			description:  "IsPullRequestTargetBranchMatching: pull request into a protected branch",
+			subscription: subscription,
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCreated, Resource: serializers.Resource{TargetRefName: "refs/heads/release/1.0"}},
+			isMatched:    true,
+		},
+		{
+			description:  "IsPullRequestTargetBranchMatching: pull request into another branch",
+			subscription: subscription,
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestUpdated, Resource: serializers.Resource{TargetRefName: "refs/heads/feature/login"}},
+		},
+		{
+			description:  "IsPullRequestTargetBranchMatching: comment on a pull request into a protected branch",
+			subscription: subscription,
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCommented, Resource: serializers.Resource{PullRequest: serializers.PullRequest{TargetRefName: "refs/heads/main"}}},
+			isMatched:    true,
+		},
+		{
+			description:  "IsPullRequestTargetBranchMatching: notification of another event",
+			subscription: subscription,
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted, Resource: serializers.Resource{SourceBranch: "refs/heads/feature/login"}},
+			isMatched:    true,
+		},
+		{
+			description:          "IsPullRequestTargetBranchMatching: target branch is fetched when it's not in the payload",
+			subscription:         subscription,
+			body:                 &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestMerged, Resource: serializers.Resource{PullRequestID: 1}},
+			fetchedTargetRefName: "refs/heads/feature/login",
+			expectedFetch:        true,
+		},
+		{
+			description:   "IsPullRequestTargetBranchMatching: notification is posted when the target branch can't be fetched",
+			subscription:  subscription,
+			body:          &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestMerged, Resource: serializers.Resource{PullRequestID: 1}},
+			fetchErr:      errors.New("error getting the pull request"),
+			expectedFetch: true,
+			isMatched:     true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			p := setupMockPlugin(mockAPI, nil, mockedClient)
+			mockAPI.On("LogDebug", "Error in getting the target branch of the pull request from Azure", "Error", mock.AnythingOfType("string"))
+			if testCase.expectedFetch {
+				mockedClient.EXPECT().GetPullRequest(testutils.MockOrganization, "1", testutils.MockProjectName, testutils.MockMattermostUserID).Return(&serializers.PullRequest{TargetRefName: testCase.fetchedTargetRefName}, http.StatusOK, testCase.fetchErr)
+			}
+
+			assert.Equal(t, testCase.isMatched, p.isPullRequestTargetBranchMatching(testCase.subscription, testCase.body))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// addRerunBuildAction adds the button re-running a failed build to its notification.
+// The organization is taken from the subscription as the payload of the build doesn't have it.
+func (p *Plugin) addRerunBuildAction(attachment *model.SlackAttachment, subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification, localizer *i18n.Localizer) {
+	if subscription == nil || body.EventType != constants.SubscriptionEventBuildCompleted || body.Resource.Result != constants.BuildResultFailed || body.Resource.Definition.ID <= 0 {
+		return
+	}
+
+	projectName := body.Resource.Project.Name
+	if projectName == "" {
+		projectName = subscription.ProjectName
+	}
+
+	attachment.Actions = append(attachment.Actions, &model.PostAction{
+		Id:   constants.RerunBuildActionID,
+		Type: model.POST_ACTION_TYPE_BUTTON,
+		Name: localizer.Localize("Re-run build"),
+		Integration: &model.PostActionIntegration{
+			URL: fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathRerunBuild),
+			Context: map[string]interface{}{
+				constants.RerunBuildContextOrganization: subscription.OrganizationName,
+				constants.RerunBuildContextProjectName:  projectName,
+				constants.RerunBuildContextDefinitionID: body.Resource.Definition.ID,
+				constants.RerunBuildContextSourceBranch: body.Resource.SourceBranch,
+			},
+		},
+	})
+}
+
+// rerunBuild queues the definition of a failed build again on the same branch, with the connection of the user clicking the button,
+// and returns the message shown to them
+func (p *Plugin) rerunBuild(mattermostUserID, organization, projectName string, definitionID int, sourceBranch string) string {
+	if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeBuildExecute); scopeErr != nil {
+		return scopeErr.Error()
+	}
+
+	build, statusCode, err := p.Client.QueueBuild(organization, projectName, definitionID, sourceBranch, mattermostUserID)
+	if err != nil {
+		switch statusCode {
+		case http.StatusForbidden, http.StatusUnauthorized:
+			return fmt.Sprintf(constants.BuildQueueForbidden, projectName)
+		case http.StatusNotFound:
+			return constants.BuildDefinitionNotFound
+		}
+
+		p.API.LogError(constants.ErrorQueueBuild, "Error", err.Error())
+		return constants.GenericErrorMessage
+	}
+
+	return fmt.Sprintf(constants.BuildQueued, build.BuildNumber, build.Definition.Name, strings.TrimPrefix(build.SourceBranch, constants.GitBranchRefPrefix))
+}
+
+// handleRerunBuild handles the button of a failed build notification and replies to the user with the number of the queued build
+func (p *Plugin) handleRerunBuild(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	requestContext := postActionIntegrationRequest.Context
+	organization, _ := requestContext[constants.RerunBuildContextOrganization].(string)
+	projectName, _ := requestContext[constants.RerunBuildContextProjectName].(string)
+	definitionID, _ := requestContext[constants.RerunBuildContextDefinitionID].(float64)
+	sourceBranch, _ := requestContext[constants.RerunBuildContextSourceBranch].(string)
+	if organization == "" || projectName == "" || definitionID <= 0 {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: "invalid build"})
+		return
+	}
+
+	p.returnPostActionIntegrationResponse(w, &model.PostActionIntegrationResponse{
+		EphemeralText: p.rerunBuild(mattermostUserID, organization, projectName, int(definitionID), sourceBranch),
+	})
+}
@@ -0,0 +1,194 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestAddRerunBuildAction(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	failedBuild := &serializers.SubscriptionNotification{
+		EventType: constants.SubscriptionEventBuildCompleted,
+		Resource: serializers.Resource{
+			Result:       constants.BuildResultFailed,
+			SourceBranch: "refs/heads/main",
+			Definition:   serializers.Definition{ID: 7, Name: "mockPipeline"},
+			Project:      serializers.Project{Name: testutils.MockProjectName},
+		},
+	}
+	succeededBuild := &serializers.SubscriptionNotification{
+		EventType: constants.SubscriptionEventBuildCompleted,
+		Resource:  serializers.Resource{Result: "succeeded", Definition: serializers.Definition{ID: 7}},
+	}
+	for _, testCase := range []struct {
+		description     string
+		subscription    *serializers.SubscriptionDetails
+		body            *serializers.SubscriptionNotification
+		expectedContext map[string]interface{}
+	}{
+		{
+			description:  "AddRerunBuildAction: failed build",
+			subscription: subscription,
+			body:         failedBuild,
+			expectedContext: map[string]interface{}{
+				constants.RerunBuildContextOrganization: testutils.MockOrganization,
+				constants.RerunBuildContextProjectName:  testutils.MockProjectName,
+				constants.RerunBuildContextDefinitionID: 7,
+				constants.RerunBuildContextSourceBranch: "refs/heads/main",
+			},
+		},
+		{
+			description:  "AddRerunBuildAction: succeeded build",
+			subscription: subscription,
+			body:         succeededBuild,
+		},
+		{
+			description: "AddRerunBuildAction: notification without a subscription",
+			body:        failedBuild,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := setupMockPlugin(&plugintest.API{}, nil, nil)
+			attachment := &model.SlackAttachment{}
+
+			p.addRerunBuildAction(attachment, testCase.subscription, testCase.body, i18n.NewLocalizer(""))
+
+			if testCase.expectedContext == nil {
+				assert.Empty(t, attachment.Actions)
+				return
+			}
+
+			require.Len(t, attachment.Actions, 1)
+			assert.Equal(t, constants.RerunBuildActionID, attachment.Actions[0].Id)
+			assert.Equal(t, "Re-run build", attachment.Actions[0].Name)
+			assert.Equal(t, testCase.expectedContext, attachment.Actions[0].Integration.Context)
+		})
+	}
+}
+
+func TestHandleRerunBuild(t *testing.T) {
+	for _, testCase := range []struct {
+		description        string
+		context            map[string]interface{}
+		scopes             []string
+		queueStatusCode    int
+		queueErr           error
+		expectedQueue      bool
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			description:        "HandleRerunBuild: build is queued",
+			scopes:             []string{constants.ScopeBuildExecute},
+			queueStatusCode:    http.StatusOK,
+			expectedQueue:      true,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.BuildQueued, "20261016.2", "mockPipeline", "main"),
+		},
+		{
+			description:        "HandleRerunBuild: user is not allowed to queue builds",
+			scopes:             []string{constants.ScopeBuildExecute},
+			queueStatusCode:    http.StatusForbidden,
+			queueErr:           errors.New("forbidden"),
+			expectedQueue:      true,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.BuildQueueForbidden, testutils.MockProjectName),
+		},
+		{
+			description:        "HandleRerunBuild: pipeline is deleted",
+			scopes:             []string{constants.ScopeBuildExecute},
+			queueStatusCode:    http.StatusNotFound,
+			queueErr:           errors.New("not found"),
+			expectedQueue:      true,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    constants.BuildDefinitionNotFound,
+		},
+		{
+			description:        "HandleRerunBuild: error in queuing the build",
+			scopes:             []string{constants.ScopeBuildExecute},
+			queueStatusCode:    http.StatusInternalServerError,
+			queueErr:           errors.New("error queuing the build"),
+			expectedQueue:      true,
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    constants.GenericErrorMessage,
+		},
+		{
+			description:        "HandleRerunBuild: connection without the scope to queue builds",
+			scopes:             []string{constants.ScopeBuild},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.ErrorMissingScope, constants.ScopeBuildExecute, constants.ScopeBuild),
+		},
+		{
+			description:        "HandleRerunBuild: invalid build",
+			context:            map[string]interface{}{constants.RerunBuildContextOrganization: testutils.MockOrganization},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			mockAPI.On("LogError", constants.ErrorQueueBuild, "Error", mock.AnythingOfType("string"))
+
+			if testCase.scopes != nil {
+				mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+				mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{Scopes: testCase.scopes}, nil)
+			}
+			if testCase.expectedQueue {
+				mockedClient.EXPECT().QueueBuild(testutils.MockOrganization, testutils.MockProjectName, 7, "refs/heads/main", testutils.MockMattermostUserID).Return(&serializers.BuildDetails{
+					ID:           42,
+					BuildNumber:  "20261016.2",
+					SourceBranch: "refs/heads/main",
+					Definition:   serializers.Definition{ID: 7, Name: "mockPipeline"},
+				}, testCase.queueStatusCode, testCase.queueErr)
+			}
+
+			context := testCase.context
+			if context == nil {
+				context = map[string]interface{}{
+					constants.RerunBuildContextOrganization: testutils.MockOrganization,
+					constants.RerunBuildContextProjectName:  testutils.MockProjectName,
+					constants.RerunBuildContextDefinitionID: 7,
+					constants.RerunBuildContextSourceBranch: "refs/heads/main",
+				}
+			}
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{PostId: "mockPostID", Context: context})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, constants.PathRerunBuild, bytes.NewBuffer(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleRerunBuild(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var response *model.PostActionIntegrationResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			assert.Equal(t, testCase.expectedMessage, response.EphemeralText)
+		})
+	}
+}
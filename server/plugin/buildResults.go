@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// isBuildResultSelected checks if the notification of a build is posted for the build results selected in its subscription.
+// The builds without a result are posted unless the subscription skips them, and the other notifications are not filtered.
+func isBuildResultSelected(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) bool {
+	if subscription == nil || body.EventType != constants.SubscriptionEventBuildCompleted {
+		return true
+	}
+
+	result := serializers.GetBuildResult(body.Resource.Result)
+	if result == "" {
+		return !subscription.SkipBuildsWithoutResult
+	}
+
+	if len(subscription.BuildResults) == 0 {
+		return true
+	}
+
+	for _, selectedResult := range subscription.BuildResults {
+		if selectedResult == result {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBuildResults returns the build results selected in a subscription as they are sent by Azure DevOps, without the duplicates
+func getBuildResults(results []string) []string {
+	var buildResults []string
+	isResultAdded := map[string]bool{}
+	for _, result := range results {
+		if buildResult := serializers.GetBuildResult(result); buildResult != "" && !isResultAdded[buildResult] {
+			isResultAdded[buildResult] = true
+			buildResults = append(buildResults, buildResult)
+		}
+	}
+
+	return buildResults
+}
@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestIsBuildResultSelected(t *testing.T) {
+	getBuild := func(result string) *serializers.SubscriptionNotification {
+		return &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted, Resource: serializers.Resource{Result: result}}
+	}
+	for _, testCase := range []struct {
+		description  string
+		subscription *serializers.SubscriptionDetails
+		body         *serializers.SubscriptionNotification
+		isSelected   bool
+	}{
+		{
+			description:  "IsBuildResultSelected: all the results are posted by default",
+			subscription: &serializers.SubscriptionDetails{},
+			body:         getBuild(constants.BuildResultSucceeded),
+			isSelected:   true,
+		},
+		{
+			description:  "IsBuildResultSelected: failed build is posted for the failed builds",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultFailed}},
+			body:         getBuild(constants.BuildResultFailed),
+			isSelected:   true,
+		},
+		{
+			description:  "IsBuildResultSelected: succeeded build is dropped for the failed builds",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultFailed}},
+			body:         getBuild(constants.BuildResultSucceeded),
+		},
+		{
+			description:  "IsBuildResultSelected: partially succeeded build is posted for the partially succeeded and failed builds",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultPartiallySucceeded, constants.BuildResultFailed}},
+			body:         getBuild(constants.BuildResultPartiallySucceeded),
+			isSelected:   true,
+		},
+		{
+			description:  "IsBuildResultSelected: canceled build is dropped for the partially succeeded and failed builds",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultPartiallySucceeded, constants.BuildResultFailed}},
+			body:         getBuild(constants.BuildResultCanceled),
+		},
+		{
+			description:  "IsBuildResultSelected: canceled build is posted for the canceled builds",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultCanceled}},
+			body:         getBuild(constants.BuildResultCanceled),
+			isSelected:   true,
+		},
+		{
+			description:  "IsBuildResultSelected: case of the result is ignored",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultPartiallySucceeded}},
+			body:         getBuild("PartiallySucceeded"),
+			isSelected:   true,
+		},
+		{
+			description:  "IsBuildResultSelected: build without a result is posted by default",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultFailed}},
+			body:         getBuild(""),
+			isSelected:   true,
+		},
+		{
+			description:  "IsBuildResultSelected: build without a result is dropped if the subscription skips them",
+			subscription: &serializers.SubscriptionDetails{SkipBuildsWithoutResult: true},
+			body:         getBuild("none"),
+		},
+		{
+			description:  "IsBuildResultSelected: notification of another event is not filtered",
+			subscription: &serializers.SubscriptionDetails{BuildResults: []string{constants.BuildResultFailed}, SkipBuildsWithoutResult: true},
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed},
+			isSelected:   true,
+		},
+		{
+			description: "IsBuildResultSelected: notification without a subscription",
+			body:        getBuild(constants.BuildResultSucceeded),
+			isSelected:  true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.isSelected, isBuildResultSelected(testCase.subscription, testCase.body))
+		})
+	}
+}
+
+func TestGetBuildResults(t *testing.T) {
+	assert.Equal(t, []string{constants.BuildResultFailed, constants.BuildResultPartiallySucceeded}, getBuildResults([]string{" Failed", "partiallysucceeded", "failed", "unknown"}))
+	assert.Nil(t, getBuildResults(nil))
+}
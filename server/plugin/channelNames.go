@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// channelNameResolver resolves the IDs of the channels of the subscriptions to the names shown to the users.
+// Every channel and team is fetched once, so a resolver is meant to be used within a single request.
+type channelNameResolver struct {
+	p            *Plugin
+	channelNames map[string]string
+	teamNames    map[string]string
+}
+
+func (p *Plugin) newChannelNameResolver() *channelNameResolver {
+	return &channelNameResolver{
+		p:            p,
+		channelNames: map[string]string{},
+		teamNames:    map[string]string{},
+	}
+}
+
+// getChannelName returns the display name of a channel along with the display name of its team, like "Town Square (Engineering)".
+// The ID of the channel is returned when it's deleted or can't be fetched, marked as such.
+func (r *channelNameResolver) getChannelName(channelID string) string {
+	if channelName, ok := r.channelNames[channelID]; ok {
+		return channelName
+	}
+
+	channelName := r.resolveChannelName(channelID)
+	r.channelNames[channelID] = channelName
+	return channelName
+}
+
+func (r *channelNameResolver) resolveChannelName(channelID string) string {
+	channel, appErr := r.p.API.GetChannel(channelID)
+	if appErr != nil {
+		r.p.API.LogDebug("Error in getting the channel of the subscription", "ChannelID", channelID, "Error", appErr.Error())
+		return fmt.Sprintf(constants.ChannelNameInaccessible, channelID)
+	}
+
+	if channel.DeleteAt != 0 {
+		return fmt.Sprintf(constants.ChannelNameDeleted, channelID)
+	}
+
+	// The direct and group messages don't belong to a team
+	switch {
+	case channel.Type == model.CHANNEL_DIRECT:
+		return constants.ChannelNameDirectMessage
+	case channel.TeamId == "":
+		return channel.DisplayName
+	}
+
+	channelName := channel.DisplayName
+	if channelName == "" {
+		channelName = channel.Name
+	}
+
+	teamName := r.getTeamName(channel.TeamId)
+	if teamName == "" {
+		return channelName
+	}
+
+	return fmt.Sprintf(constants.ChannelNameWithTeam, channelName, teamName)
+}
+
+// getTeamName returns the display name of a team, it's empty if the team can't be fetched
+func (r *channelNameResolver) getTeamName(teamID string) string {
+	if teamName, ok := r.teamNames[teamID]; ok {
+		return teamName
+	}
+
+	teamName := ""
+	if team, appErr := r.p.API.GetTeam(teamID); appErr != nil {
+		r.p.API.LogDebug("Error in getting the team of the channel", "TeamID", teamID, "Error", appErr.Error())
+	} else {
+		teamName = team.DisplayName
+	}
+
+	r.teamNames[teamID] = teamName
+	return teamName
+}
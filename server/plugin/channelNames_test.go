@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestChannelNameResolver(t *testing.T) {
+	for _, testCase := range []struct {
+		description         string
+		channel             *model.Channel
+		channelErr          *model.AppError
+		team                *model.Team
+		teamErr             *model.AppError
+		expectedChannelName string
+	}{
+		{
+			description:         "ChannelNameResolver: channel name is resolved along with its team",
+			channel:             &model.Channel{Id: testutils.MockChannelID, DisplayName: "Town Square", TeamId: testutils.MockTeamID, Type: model.CHANNEL_OPEN},
+			team:                &model.Team{Id: testutils.MockTeamID, DisplayName: "Engineering"},
+			expectedChannelName: "Town Square (Engineering)",
+		},
+		{
+			description:         "ChannelNameResolver: team can't be fetched",
+			channel:             &model.Channel{Id: testutils.MockChannelID, DisplayName: "Town Square", TeamId: testutils.MockTeamID, Type: model.CHANNEL_OPEN},
+			teamErr:             &model.AppError{Message: "error getting the team"},
+			expectedChannelName: "Town Square",
+		},
+		{
+			description:         "ChannelNameResolver: direct message",
+			channel:             &model.Channel{Id: testutils.MockChannelID, Name: "mockUserID1__mockUserID2", Type: model.CHANNEL_DIRECT},
+			expectedChannelName: "Direct message",
+		},
+		{
+			description:         "ChannelNameResolver: group message",
+			channel:             &model.Channel{Id: testutils.MockChannelID, DisplayName: "alice, bob", Type: model.CHANNEL_GROUP},
+			expectedChannelName: "alice, bob",
+		},
+		{
+			description:         "ChannelNameResolver: channel is deleted",
+			channel:             &model.Channel{Id: testutils.MockChannelID, DisplayName: "Town Square", TeamId: testutils.MockTeamID, DeleteAt: 1},
+			expectedChannelName: "mockChannelID (deleted)",
+		},
+		{
+			description:         "ChannelNameResolver: channel is inaccessible",
+			channelErr:          &model.AppError{Message: "channel not found", StatusCode: http.StatusNotFound},
+			expectedChannelName: "mockChannelID (inaccessible)",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p := setupMockPlugin(mockAPI, nil, nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(testCase.channel, testCase.channelErr)
+			mockAPI.On("GetTeam", testutils.MockTeamID).Return(testCase.team, testCase.teamErr)
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+			channelNames := p.newChannelNameResolver()
+
+			assert.Equal(t, testCase.expectedChannelName, channelNames.getChannelName(testutils.MockChannelID))
+		})
+	}
+}
+
+func TestChannelNameResolverCache(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID, DisplayName: "Town Square", TeamId: testutils.MockTeamID}, nil)
+	mockAPI.On("GetChannel", "mockChannelID-2").Return(&model.Channel{Id: "mockChannelID-2", DisplayName: "Off-Topic", TeamId: testutils.MockTeamID}, nil)
+	mockAPI.On("GetChannel", "mockDeletedChannelID").Return(nil, &model.AppError{Message: "channel not found"})
+	mockAPI.On("GetTeam", testutils.MockTeamID).Return(&model.Team{Id: testutils.MockTeamID, DisplayName: "Engineering"}, nil)
+	mockAPI.On("LogDebug", mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	channelNames := p.newChannelNameResolver()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "Town Square (Engineering)", channelNames.getChannelName(testutils.MockChannelID))
+		assert.Equal(t, "Off-Topic (Engineering)", channelNames.getChannelName("mockChannelID-2"))
+		assert.Equal(t, "mockDeletedChannelID (inaccessible)", channelNames.getChannelName("mockDeletedChannelID"))
+	}
+
+	mockAPI.AssertNumberOfCalls(t, "GetChannel", 3)
+	mockAPI.AssertNumberOfCalls(t, "GetTeam", 1)
+}
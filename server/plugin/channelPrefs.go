@@ -0,0 +1,116 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getChannelNotificationPrefs returns the notification preferences of a channel, the defaults are used if they can't be fetched
+func (p *Plugin) getChannelNotificationPrefs(channelID string) *serializers.ChannelNotificationPrefs {
+	prefs, err := p.Store.GetChannelNotificationPrefs(channelID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchChannelPrefs, "Error", err.Error())
+		return &serializers.ChannelNotificationPrefs{ChannelID: channelID}
+	}
+
+	return prefs
+}
+
+// shouldKeepRawHTML checks if the HTML in a notification is posted as it is.
+// A subscription keeping the raw HTML takes precedence over the preference of its channel.
+func shouldKeepRawHTML(subscription *serializers.SubscriptionDetails, prefs *serializers.ChannelNotificationPrefs) bool {
+	if subscription != nil && subscription.KeepRawHTML {
+		return true
+	}
+
+	return prefs.KeepRawHTML != nil && *prefs.KeepRawHTML
+}
+
+// getNotificationLocalizer returns the localizer of the static texts in the notifications of a channel.
+// The language of the channel takes precedence over the one set in the plugin configuration.
+func (p *Plugin) getNotificationLocalizer(prefs *serializers.ChannelNotificationPrefs) *i18n.Localizer {
+	if prefs.Language != "" {
+		return i18n.NewLocalizer(prefs.Language)
+	}
+
+	return i18n.NewLocalizer(p.getConfiguration().GetNotificationLanguage())
+}
+
+// getChannelNotificationPrefsMessage returns the list of the notification preferences of a channel to be shown to the user
+func getChannelNotificationPrefsMessage(prefs *serializers.ChannelNotificationPrefs) string {
+	formatBool := func(value *bool) string {
+		if value == nil {
+			return constants.ChannelPrefValueDefault
+		}
+		return strconv.FormatBool(*value)
+	}
+	formatString := func(value string) string {
+		if value == "" {
+			return constants.ChannelPrefValueDefault
+		}
+		return value
+	}
+
+	var sb strings.Builder
+	sb.WriteString("###### Notification preferences of this channel\n")
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefColor, formatString(prefs.Color)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefHTML, formatBool(prefs.KeepRawHTML)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefEmoji, formatBool(prefs.ShowEmoji)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefTimezone, formatString(prefs.Timezone)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefLanguage, formatString(prefs.Language)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefSummary, formatBool(prefs.Summary)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefSummaryDay, formatString(prefs.SummaryDay)))
+	summaryHour := constants.ChannelPrefValueDefault
+	if prefs.SummaryHour != nil {
+		summaryHour = strconv.Itoa(*prefs.SummaryHour)
+	}
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", constants.ChannelPrefSummaryHour, summaryHour))
+	return sb.String()
+}
+
+// setChannelNotificationPref updates a notification preference of a channel and returns the message to be shown to the user
+func (p *Plugin) setChannelNotificationPref(mattermostUserID, channelID, option, value string) (string, error) {
+	if !p.canManageChannel(mattermostUserID, channelID) {
+		return constants.ChannelPrefsNotAllowed, nil
+	}
+
+	prefs, err := p.Store.GetChannelNotificationPrefs(channelID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchChannelPrefs)
+	}
+
+	if err := prefs.Set(option, value); err != nil {
+		return err.Error(), nil
+	}
+
+	if err := p.Store.SetChannelNotificationPrefs(prefs); err != nil {
+		return "", errors.Wrap(err, constants.ErrorStoreChannelPrefs)
+	}
+
+	return fmt.Sprintf("%s\n%s", fmt.Sprintf(constants.ChannelPrefUpdated, option), getChannelNotificationPrefsMessage(prefs)), nil
+}
+
+// canManageChannel checks if the user can change the properties of the channel, as the preferences affect all of its members
+func (p *Plugin) canManageChannel(mattermostUserID, channelID string) bool {
+	channel, appErr := p.API.GetChannel(channelID)
+	if appErr != nil {
+		p.API.LogError("Error in getting the channel", "Error", appErr.Error())
+		return false
+	}
+
+	permission := model.PERMISSION_MANAGE_PUBLIC_CHANNEL_PROPERTIES
+	if channel.Type == model.CHANNEL_PRIVATE {
+		permission = model.PERMISSION_MANAGE_PRIVATE_CHANNEL_PROPERTIES
+	}
+
+	return p.API.HasPermissionToChannel(mattermostUserID, channelID, permission)
+}
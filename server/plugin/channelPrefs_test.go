@@ -0,0 +1,148 @@
+package plugin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestShouldKeepRawHTML(t *testing.T) {
+	keepRawHTML, convertHTML := true, false
+	for _, testCase := range []struct {
+		description  string
+		subscription *serializers.SubscriptionDetails
+		prefs        *serializers.ChannelNotificationPrefs
+		expected     bool
+	}{
+		{
+			description: "ShouldKeepRawHTML: nothing is set",
+			prefs:       &serializers.ChannelNotificationPrefs{},
+		},
+		{
+			description:  "ShouldKeepRawHTML: channel preference is inherited",
+			subscription: &serializers.SubscriptionDetails{},
+			prefs:        &serializers.ChannelNotificationPrefs{KeepRawHTML: &keepRawHTML},
+			expected:     true,
+		},
+		{
+			description:  "ShouldKeepRawHTML: subscription overrides the channel preference",
+			subscription: &serializers.SubscriptionDetails{KeepRawHTML: true},
+			prefs:        &serializers.ChannelNotificationPrefs{KeepRawHTML: &convertHTML},
+			expected:     true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, shouldKeepRawHTML(testCase.subscription, testCase.prefs))
+		})
+	}
+}
+
+func TestSetChannelNotificationPref(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		hasPermission bool
+		option        string
+		value         string
+		storedPrefs   *serializers.ChannelNotificationPrefs
+		expectStore   bool
+		expected      string
+	}{
+		{
+			description: "SetChannelNotificationPref: user can't manage the channel",
+			option:      constants.ChannelPrefColor,
+			value:       "#0078d4",
+			expected:    constants.ChannelPrefsNotAllowed,
+		},
+		{
+			description:   "SetChannelNotificationPref: invalid value",
+			hasPermission: true,
+			option:        constants.ChannelPrefColor,
+			value:         "blue",
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID},
+			expected:      constants.InvalidChannelPrefColor,
+		},
+		{
+			description:   "SetChannelNotificationPref: preference is stored",
+			hasPermission: true,
+			option:        constants.ChannelPrefTimezone,
+			value:         "UTC",
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID, Color: "#0078d4"},
+			expectStore:   true,
+			expected:      "- color: #0078d4\n- html: default\n- emoji: default\n- timezone: UTC\n",
+		},
+		{
+			description:   "SetChannelNotificationPref: preference is unset",
+			hasPermission: true,
+			option:        constants.ChannelPrefColor,
+			value:         constants.ChannelPrefValueDefault,
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID, Color: "#0078d4"},
+			expectStore:   true,
+			expected:      "- color: default\n",
+		},
+		{
+			description:   "SetChannelNotificationPref: hour of the weekly summary is stored",
+			hasPermission: true,
+			option:        constants.ChannelPrefSummaryHour,
+			value:         "17",
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID},
+			expectStore:   true,
+			expected:      "- summary: default\n- summary-day: default\n- summary-hour: 17\n",
+		},
+		{
+			description:   "SetChannelNotificationPref: invalid day of the weekly summary",
+			hasPermission: true,
+			option:        constants.ChannelPrefSummaryDay,
+			value:         "someday",
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID},
+			expected:      fmt.Sprintf(constants.InvalidChannelPrefDay, "someday"),
+		},
+		{
+			description:   "SetChannelNotificationPref: language is stored",
+			hasPermission: true,
+			option:        constants.ChannelPrefLanguage,
+			value:         "DE",
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID},
+			expectStore:   true,
+			expected:      "- timezone: default\n- language: de\n",
+		},
+		{
+			description:   "SetChannelNotificationPref: unsupported language",
+			hasPermission: true,
+			option:        constants.ChannelPrefLanguage,
+			value:         "xx",
+			storedPrefs:   &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID},
+			expected:      fmt.Sprintf(constants.InvalidChannelPrefLanguage, "xx", "de, en, es"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID, Type: model.CHANNEL_OPEN}, nil)
+			mockAPI.On("HasPermissionToChannel", testutils.MockMattermostUserID, testutils.MockChannelID, mock.Anything).Return(testCase.hasPermission)
+			if testCase.storedPrefs != nil {
+				mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(testCase.storedPrefs, nil)
+			}
+			if testCase.expectStore {
+				mockedStore.EXPECT().SetChannelNotificationPrefs(testCase.storedPrefs).Return(nil)
+			}
+
+			message, err := p.setChannelNotificationPref(testutils.MockMattermostUserID, testutils.MockChannelID, testCase.option, testCase.value)
+
+			assert.NoError(t, err)
+			assert.Contains(t, message, testCase.expected)
+		})
+	}
+}
@@ -2,12 +2,19 @@ package plugin
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/pkg/errors"
@@ -18,36 +25,70 @@ import (
 
 type Client interface {
 	GenerateOAuthToken(encodedFormValues url.Values) (*serializers.OAuthSuccessResponse, int, error)
+	GenerateDeviceCode(encodedFormValues url.Values) (*serializers.DeviceCodeResponse, int, error)
+	GenerateDeviceCodeToken(encodedFormValues url.Values) (*serializers.DeviceCodeTokenResponse, string, int, error)
 	CreateTask(body *serializers.CreateTaskRequestPayload, mattermostUserID string) (*serializers.TaskValue, int, error)
+	UpdateTask(organization, project, taskID string, fields serializers.UpdateTaskFieldValue, mattermostUserID string) (*serializers.TaskValue, int, error)
+	AddWorkItemComment(organization, project, taskID, text, mattermostUserID string) (*serializers.WorkItemComment, int, error)
 	GetTask(organization, taskID, projectName, mattermostUserID string) (*serializers.TaskValue, int, error)
+	GetWorkItem(organization, workItemID, projectName, mattermostUserID string) (*serializers.TaskValue, int, error)
+	GetWorkItemExpanded(organization, projectName string, workItemID int, mattermostUserID string) (*serializers.WorkItemExpanded, int, error)
+	DeleteWorkItem(organization, projectName string, workItemID int, destroy bool, mattermostUserID string) (int, error)
+	GetRecycleBin(organization, projectName, mattermostUserID string) ([]*serializers.DeletedWorkItem, int, error)
+	RestoreWorkItem(organization, projectName string, workItemID int, mattermostUserID string) (int, error)
+	GetGitRepository(organization, projectName, repositoryID, mattermostUserID string) (*serializers.GitRepository, int, error)
 	GetPullRequest(organization, pullRequestID, projectName, mattermostUserID string) (*serializers.PullRequest, int, error)
+	GetPullRequestsByCreator(organization, projectName, creatorID, mattermostUserID string) ([]*serializers.PullRequest, int, error)
+	GetPullRequestWorkItems(organization, projectName, repositoryID string, pullRequestID int, mattermostUserID string) ([]*serializers.ResourceRef, int, error)
+	GetProjectPullRequests(organization, projectName, mattermostUserID string) ([]*serializers.PullRequest, int, error)
+	GetPullRequests(organization, projectName, repositoryID, mattermostUserID string) ([]*serializers.PullRequest, int, error)
+	GetGitRepositories(organization, projectName, mattermostUserID string) ([]*serializers.GitRepository, int, error)
+	GetPushes(organization, projectName, repositoryID string, fromDate time.Time, mattermostUserID string) ([]*serializers.Push, int, error)
 	Link(body *serializers.LinkRequestPayload, mattermostUserID string) (*serializers.Project, int, error)
 	CreateSubscription(body *serializers.CreateSubscriptionRequestPayload, project *serializers.ProjectDetails, channelID, pluginURL, mattermostUserID, uuid string) (*serializers.SubscriptionValue, int, error)
 	DeleteSubscription(organization, subscriptionID, mattermostUserID string) (int, error)
-	UpdatePipelineApprovalRequest(pipelineApproveRequestPayload *serializers.PipelineApproveRequest, organization, projectName, mattermostUserID string, approvalID int) (int, error)
+	ListSubscriptions(organization, mattermostUserID string) ([]serializers.SubscriptionValue, int, error)
+	GetSubscription(organization, subscriptionID, mattermostUserID string) (*serializers.SubscriptionValue, int, error)
+	UpdateSubscriptionWebhookURL(organization, subscriptionID, webhookURL, mattermostUserID string) (string, int, error)
+	SetReleaseApproval(organization, projectName string, approvalID int, status, comment, mattermostUserID string) (int, error)
 	UpdatePipelineRunApprovalRequest(pipelineApproveRequestPayload []*serializers.PipelineApproveRequest, organization, projectID, mattermostUserID string) (*serializers.PipelineRunApproveResponse, int, error)
-	GetApprovalDetails(organization, projectName, mattermostUserID string, approvalID int) (*serializers.PipelineApprovalDetails, int, error)
+	GetReleaseApproval(organization, projectName string, approvalID int, mattermostUserID string) (*serializers.ReleaseApproval, int, error)
 	GetRunApprovalDetails(organization, projectID, mattermostUserID, approvalID string) (*serializers.PipelineRunApprovalDetails, int, error)
 	GetBuildDetails(organization, projectName, buildID, mattermostUserID string) (*serializers.BuildDetails, int, error)
+	QueueBuild(organization, projectName string, definitionID int, sourceBranch, mattermostUserID string) (*serializers.BuildDetails, int, error)
 	GetReleaseDetails(organization, projectName, releaseID, mattermostUserID string) (*serializers.ReleaseDetails, int, error)
 	GetSubscriptionFilterPossibleValues(request *serializers.GetSubscriptionFilterPossibleValuesRequestPayload, mattermostUserID string) (*serializers.SubscriptionFilterPossibleValuesResponseFromClient, int, error)
 	OpenDialogRequest(body *model.OpenDialogRequest, mattermostUserID string) (int, error)
 	GetUserProfile(id, accessToken string) (*serializers.UserProfile, int, error)
+	GetWorkItemTypeStates(organization, projectName, workItemType, mattermostUserID string) ([]*serializers.WorkItemTypeState, int, error)
+	GetProcess(organization, projectID, mattermostUserID string) (*serializers.Process, int, error)
+	QueryWorkItems(organization, projectName, query, mattermostUserID string) ([]*serializers.WorkItemReference, int, error)
+	GetWorkItemsBatch(organization, projectName string, workItemIDs []int, fields []string, mattermostUserID string) ([]*serializers.TaskValue, int, error)
+	GetWorkItems(organization, projectName string, query serializers.WorkItemQuery, mattermostUserID string) ([]*serializers.TaskValue, int, error)
+	SearchWorkItems(organization, projectName, text string, top int, mattermostUserID string) ([]*serializers.WorkItemSearchResult, int, error)
+	ValidateWIQL(organization, projectName, query, mattermostUserID string) (int, error)
+	GetCurrentIteration(organization, projectName, teamName, mattermostUserID string) (*serializers.Iteration, int, error)
+	GetQueries(organization, projectName, filter, mattermostUserID string) ([]*serializers.Query, int, error)
+	RunSharedQuery(organization, projectName, queryID, mattermostUserID string) (*serializers.WorkItemQueryResult, int, error)
+	PingURL(URL string) (int, error)
 }
 
 type client struct {
-	plugin     *Plugin
-	httpClient *http.Client
-}
+	plugin *Plugin
+
+	// httpClientLock synchronizes rebuilding the HTTP client when the TLS configuration changes
+	httpClientLock sync.Mutex
+	httpClient     *http.Client
+	// tlsConfig is the TLS configuration the HTTP client was built with
+	tlsConfig *tls.Config
 
-type ErrorResponse struct {
-	Message string `json:"message"`
+	limiter *requestLimiter
 }
 
 func (c *client) GenerateOAuthToken(encodedFormValues url.Values) (*serializers.OAuthSuccessResponse, int, error) {
 	var oAuthSuccessResponse *serializers.OAuthSuccessResponse
 
-	_, statusCode, err := c.callFormURLEncoded(constants.BaseOauthURL, constants.PathToken, http.MethodPost, &oAuthSuccessResponse, encodedFormValues)
+	_, statusCode, err := c.callFormURLEncoded(c.plugin.getConfiguration().GetOAuthBaseURL(), constants.PathToken, http.MethodPost, &oAuthSuccessResponse, encodedFormValues)
 	if err != nil {
 		return nil, statusCode, err
 	}
@@ -55,6 +96,35 @@ func (c *client) GenerateOAuthToken(encodedFormValues url.Values) (*serializers.
 	return oAuthSuccessResponse, statusCode, nil
 }
 
+// GenerateDeviceCode starts the device code flow and returns the code to be entered by the user
+func (c *client) GenerateDeviceCode(encodedFormValues url.Values) (*serializers.DeviceCodeResponse, int, error) {
+	var deviceCode *serializers.DeviceCodeResponse
+	path := fmt.Sprintf(constants.PathDeviceCode, c.plugin.getConfiguration().GetDeviceCodeTenant())
+	_, statusCode, err := c.callFormURLEncoded(constants.BaseDeviceCodeOAuthURL, path, http.MethodPost, &deviceCode, encodedFormValues)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to generate the device code")
+	}
+
+	return deviceCode, statusCode, nil
+}
+
+// GenerateDeviceCodeToken polls for the token of the device code flow.
+// The error code of the response, like "authorization_pending" until the user enters the code, is returned along with the error.
+func (c *client) GenerateDeviceCodeToken(encodedFormValues url.Values) (*serializers.DeviceCodeTokenResponse, string, int, error) {
+	var token *serializers.DeviceCodeTokenResponse
+	path := fmt.Sprintf(constants.PathDeviceCodeToken, c.plugin.getConfiguration().GetDeviceCodeTenant())
+	responseData, statusCode, err := c.callFormURLEncoded(constants.BaseDeviceCodeOAuthURL, path, http.MethodPost, &token, encodedFormValues)
+	if err != nil {
+		var errorResponse serializers.DeviceCodeErrorResponse
+		if len(responseData) > 0 && json.Unmarshal(responseData, &errorResponse) == nil && errorResponse.Error != "" {
+			return nil, errorResponse.Error, statusCode, errors.Wrap(errors.New(errorResponse.ErrorDescription), errorResponse.Error)
+		}
+		return nil, "", statusCode, errors.Wrap(err, "failed to generate the device code token")
+	}
+
+	return token, "", statusCode, nil
+}
+
 func (c *client) GetUserProfile(id, accessToken string) (*serializers.UserProfile, int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths("", "", id); err != nil {
 		return nil, statusCode, err
@@ -62,7 +132,7 @@ func (c *client) GetUserProfile(id, accessToken string) (*serializers.UserProfil
 	userProfilePath := fmt.Sprintf(constants.PathUserProfile, id)
 
 	var userProfile *serializers.UserProfile
-	_, statusCode, err := c.makeHTTPRequestWithAccessToken(constants.BaseOauthURL, userProfilePath, http.MethodGet, accessToken, "application/json", &userProfile)
+	_, statusCode, err := c.makeHTTPRequestWithAccessToken(c.plugin.getConfiguration().GetOAuthBaseURL(), userProfilePath, http.MethodGet, accessToken, "application/json", &userProfile)
 	if err != nil {
 		return nil, statusCode, err
 	}
@@ -105,9 +175,36 @@ func (c *client) CreateTask(body *serializers.CreateTaskRequestPayload, mattermo
 				Value:     body.Fields.AreaPath,
 			})
 	}
+	if body.Fields.StoryPoints != nil {
+		payload = append(payload,
+			&serializers.CreateTaskBodyPayload{
+				Operation: "add",
+				Path:      "/fields/" + constants.FieldStoryPoints,
+				From:      "",
+				Value:     *body.Fields.StoryPoints,
+			})
+	}
+	if body.Fields.Effort != nil {
+		payload = append(payload,
+			&serializers.CreateTaskBodyPayload{
+				Operation: "add",
+				Path:      "/fields/" + constants.FieldEffort,
+				From:      "",
+				Value:     *body.Fields.Effort,
+			})
+	}
+	if body.Fields.RemainingWork != nil {
+		payload = append(payload,
+			&serializers.CreateTaskBodyPayload{
+				Operation: "add",
+				Path:      "/fields/" + constants.FieldRemainingWork,
+				From:      "",
+				Value:     *body.Fields.RemainingWork,
+			})
+	}
 
 	var task *serializers.TaskValue
-	_, statusCode, err := c.CallPatchJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, createTaskPath, http.MethodPost, mattermostUserID, &payload, &task, nil)
+	_, statusCode, err := c.CallPatchJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), createTaskPath, http.MethodPost, mattermostUserID, &payload, &task, nil)
 	if err != nil {
 		return nil, statusCode, errors.Wrap(err, "failed to create task")
 	}
@@ -115,6 +212,39 @@ func (c *client) CreateTask(body *serializers.CreateTaskRequestPayload, mattermo
 	return task, statusCode, nil
 }
 
+// UpdateTask patches only the provided updatable fields of an existing work item, the ones provided empty are cleared
+func (c *client) UpdateTask(organization, project, taskID string, fields serializers.UpdateTaskFieldValue, mattermostUserID string) (*serializers.TaskValue, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, project, taskID); err != nil {
+		return nil, statusCode, err
+	}
+	updateTaskPath := fmt.Sprintf(constants.UpdateTask, organization, project, taskID)
+
+	payload := fields.GetUpdateOperations()
+	var task *serializers.TaskValue
+	_, statusCode, err := c.CallPatchJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), updateTaskPath, http.MethodPatch, mattermostUserID, &payload, &task, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to update task")
+	}
+
+	return task, statusCode, nil
+}
+
+// AddWorkItemComment adds a comment to a work item, the text is rendered as markdown by Azure DevOps
+func (c *client) AddWorkItemComment(organization, project, taskID, text, mattermostUserID string) (*serializers.WorkItemComment, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, project, taskID); err != nil {
+		return nil, statusCode, err
+	}
+	addWorkItemCommentPath := fmt.Sprintf(constants.AddWorkItemComment, organization, project, taskID)
+
+	var comment *serializers.WorkItemComment
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), addWorkItemCommentPath, http.MethodPost, mattermostUserID, &serializers.WorkItemCommentRequest{Text: text}, &comment, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to add the comment")
+	}
+
+	return comment, statusCode, nil
+}
+
 // Function to get the task.
 func (c *client) GetTask(organization, taskID, projectName, mattermostUserID string) (*serializers.TaskValue, int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, taskID); err != nil {
@@ -123,7 +253,7 @@ func (c *client) GetTask(organization, taskID, projectName, mattermostUserID str
 	getTaskPath := fmt.Sprintf(constants.GetTask, organization, projectName, taskID)
 
 	var task *serializers.TaskValue
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, getTaskPath, http.MethodGet, mattermostUserID, nil, &task, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getTaskPath, http.MethodGet, mattermostUserID, nil, &task, nil)
 	if err != nil {
 		return nil, statusCode, errors.Wrap(err, "failed to get the Task")
 	}
@@ -139,7 +269,7 @@ func (c *client) GetPullRequest(organization, pullRequestID, projectName, matter
 	getPullRequestPath := fmt.Sprintf(constants.GetPullRequest, organization, projectName, pullRequestID)
 
 	var pullRequest *serializers.PullRequest
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, getPullRequestPath, http.MethodGet, mattermostUserID, nil, &pullRequest, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getPullRequestPath, http.MethodGet, mattermostUserID, nil, &pullRequest, nil)
 	if err != nil {
 		return nil, statusCode, errors.Wrap(err, "failed to get the pull request")
 	}
@@ -147,6 +277,285 @@ func (c *client) GetPullRequest(organization, pullRequestID, projectName, matter
 	return pullRequest, statusCode, nil
 }
 
+// GetPullRequestsByCreator fetches the active pull requests of a project created by a user
+func (c *client) GetPullRequestsByCreator(organization, projectName, creatorID, mattermostUserID string) ([]*serializers.PullRequest, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, creatorID); err != nil {
+		return nil, statusCode, err
+	}
+	getPullRequestsPath := fmt.Sprintf(constants.GetPullRequestsByCreator, organization, projectName, url.QueryEscape(creatorID), constants.PullRequestsMaxResults)
+
+	var pullRequests *serializers.PullRequestsResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getPullRequestsPath, http.MethodGet, mattermostUserID, nil, &pullRequests, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the pull requests")
+	}
+
+	if pullRequests == nil {
+		return nil, statusCode, nil
+	}
+
+	return pullRequests.Value, statusCode, nil
+}
+
+// GetPullRequestWorkItems fetches the references of the work items linked to a pull request
+func (c *client) GetPullRequestWorkItems(organization, projectName, repositoryID string, pullRequestID int, mattermostUserID string) ([]*serializers.ResourceRef, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, repositoryID); err != nil {
+		return nil, statusCode, err
+	}
+	getPullRequestWorkItemsPath := fmt.Sprintf(constants.GetPullRequestWorkItems, organization, projectName, repositoryID, pullRequestID)
+
+	var workItems *serializers.ResourceRefsResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getPullRequestWorkItemsPath, http.MethodGet, mattermostUserID, nil, &workItems, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the work items of the pull request")
+	}
+
+	if workItems == nil {
+		return nil, statusCode, nil
+	}
+
+	return workItems.Value, statusCode, nil
+}
+
+// GetProjectPullRequests fetches the latest pull requests of a project in any status, newest first
+func (c *client) GetProjectPullRequests(organization, projectName, mattermostUserID string) ([]*serializers.PullRequest, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	getProjectPullRequestsPath := fmt.Sprintf(constants.GetProjectPullRequests, organization, projectName, constants.PullRequestsMaxResults)
+
+	var pullRequests *serializers.PullRequestsResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getProjectPullRequestsPath, http.MethodGet, mattermostUserID, nil, &pullRequests, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the pull requests of the project")
+	}
+
+	if pullRequests == nil {
+		return nil, statusCode, nil
+	}
+
+	return pullRequests.Value, statusCode, nil
+}
+
+// GetPullRequests fetches the active pull requests of a repository, or of all the repositories of the project when no repository is given
+func (c *client) GetPullRequests(organization, projectName, repositoryID, mattermostUserID string) ([]*serializers.PullRequest, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, repositoryID); err != nil {
+		return nil, statusCode, err
+	}
+
+	getPullRequestsPath := fmt.Sprintf(constants.GetActivePullRequests, organization, projectName, constants.PullRequestsMaxResults)
+	if repositoryID != "" {
+		getPullRequestsPath = fmt.Sprintf(constants.GetRepositoryActivePullRequests, organization, projectName, url.PathEscape(repositoryID), constants.PullRequestsMaxResults)
+	}
+
+	var pullRequests *serializers.PullRequestsResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getPullRequestsPath, http.MethodGet, mattermostUserID, nil, &pullRequests, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the pull requests")
+	}
+
+	if pullRequests == nil {
+		return nil, statusCode, nil
+	}
+
+	return pullRequests.Value, statusCode, nil
+}
+
+// GetGitRepositories fetches the Git repositories of a project
+func (c *client) GetGitRepositories(organization, projectName, mattermostUserID string) ([]*serializers.GitRepository, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	getGitRepositoriesPath := fmt.Sprintf(constants.GetGitRepositories, organization, projectName)
+
+	var repositories *serializers.GitRepositoriesResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getGitRepositoriesPath, http.MethodGet, mattermostUserID, nil, &repositories, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the repositories")
+	}
+
+	if repositories == nil {
+		return nil, statusCode, nil
+	}
+
+	return repositories.Value, statusCode, nil
+}
+
+// GetPushes fetches the latest pushes to a repository since a date along with the branches they updated
+func (c *client) GetPushes(organization, projectName, repositoryID string, fromDate time.Time, mattermostUserID string) ([]*serializers.Push, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, repositoryID); err != nil {
+		return nil, statusCode, err
+	}
+	getPushesPath := fmt.Sprintf(constants.GetPushes, organization, projectName, repositoryID, url.QueryEscape(fromDate.UTC().Format(time.RFC3339)), constants.ProjectActivityMaxResults)
+
+	var pushes *serializers.PushesResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getPushesPath, http.MethodGet, mattermostUserID, nil, &pushes, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the pushes")
+	}
+
+	if pushes == nil {
+		return nil, statusCode, nil
+	}
+
+	return pushes.Value, statusCode, nil
+}
+
+// GetWorkItem fetches a work item along with its relations
+func (c *client) GetWorkItem(organization, workItemID, projectName, mattermostUserID string) (*serializers.TaskValue, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, workItemID); err != nil {
+		return nil, statusCode, err
+	}
+	getWorkItemPath := fmt.Sprintf(constants.GetWorkItem, organization, projectName, workItemID)
+
+	var workItem *serializers.TaskValue
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getWorkItemPath, http.MethodGet, mattermostUserID, nil, &workItem, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the work item")
+	}
+
+	return workItem, statusCode, nil
+}
+
+// GetWorkItemExpanded fetches a work item with all its fields and relations in a single request,
+// and derives the counts of its attachments and comments from them
+func (c *client) GetWorkItemExpanded(organization, projectName string, workItemID int, mattermostUserID string) (*serializers.WorkItemExpanded, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	getWorkItemExpandedPath := fmt.Sprintf(constants.GetWorkItemExpanded, organization, projectName, workItemID)
+
+	// The response is parsed separately as the expanded fields can be of any type
+	responseData, statusCode, err := c.CallWithResponseLimit(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), http.MethodGet, getWorkItemExpandedPath, "application/json", mattermostUserID, nil, nil, nil, constants.MaxBytesSizeForReadingExpandedWorkItem)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the expanded work item")
+	}
+
+	workItem, err := parseWorkItemExpanded(responseData)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "failed to parse the expanded work item")
+	}
+
+	return workItem, statusCode, nil
+}
+
+// parseWorkItemExpanded parses an expanded work item. A field with an unexpected type only leaves its count empty
+// instead of failing the whole work item, and the relations without a type are ignored.
+func parseWorkItemExpanded(responseData []byte) (*serializers.WorkItemExpanded, error) {
+	var workItem *serializers.TaskValue
+	if err := json.Unmarshal(responseData, &workItem); err != nil {
+		return nil, err
+	}
+	if workItem == nil {
+		return nil, errors.New("empty work item")
+	}
+
+	var expandedFields struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(responseData, &expandedFields); err != nil {
+		return nil, err
+	}
+
+	workItemExpanded := &serializers.WorkItemExpanded{WorkItem: workItem}
+	if commentCount, ok := expandedFields.Fields[constants.FieldCommentCount]; ok {
+		_ = json.Unmarshal(commentCount, &workItemExpanded.CommentCount)
+	}
+
+	for _, relation := range workItem.Relations {
+		if relation != nil && relation.Rel == constants.RelationAttachedFile {
+			workItemExpanded.AttachmentCount++
+		}
+	}
+
+	return workItemExpanded, nil
+}
+
+// GetGitRepository fetches a Git repository by its ID or name
+// DeleteWorkItem moves a work item to the recycle bin of its project, it's deleted permanently instead if destroy is set
+func (c *client) DeleteWorkItem(organization, projectName string, workItemID int, destroy bool, mattermostUserID string) (int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return statusCode, err
+	}
+	deleteWorkItemPath := fmt.Sprintf(constants.DeleteWorkItem, organization, projectName, workItemID, destroy)
+
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), deleteWorkItemPath, http.MethodDelete, mattermostUserID, nil, nil, nil)
+	if err != nil {
+		return statusCode, errors.Wrap(err, "failed to delete the work item")
+	}
+
+	return statusCode, nil
+}
+
+// GetRecycleBin fetches the details of the work items in the recycle bin of a project, at most 200 work items are fetched
+func (c *client) GetRecycleBin(organization, projectName, mattermostUserID string) ([]*serializers.DeletedWorkItem, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	baseURL := c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL()
+
+	// Only the IDs of the work items are returned while listing the recycle bin
+	var references *serializers.DeletedWorkItemsResponse
+	_, statusCode, err := c.CallJSON(baseURL, fmt.Sprintf(constants.GetRecycleBin, organization, projectName), http.MethodGet, mattermostUserID, nil, &references, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the recycle bin")
+	}
+
+	if references == nil || len(references.Value) == 0 {
+		return []*serializers.DeletedWorkItem{}, statusCode, nil
+	}
+
+	var workItemIDs []string
+	for _, reference := range references.Value {
+		if len(workItemIDs) == constants.RecycleBinMaxWorkItems {
+			break
+		}
+		workItemIDs = append(workItemIDs, strconv.Itoa(reference.ID))
+	}
+
+	var deletedWorkItems *serializers.DeletedWorkItemsResponse
+	_, statusCode, err = c.CallJSON(baseURL, fmt.Sprintf(constants.GetRecycleBinWorkItems, organization, projectName, strings.Join(workItemIDs, ",")), http.MethodGet, mattermostUserID, nil, &deletedWorkItems, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the work items in the recycle bin")
+	}
+
+	if deletedWorkItems == nil {
+		return []*serializers.DeletedWorkItem{}, statusCode, nil
+	}
+
+	return deletedWorkItems.Value, statusCode, nil
+}
+
+// RestoreWorkItem restores a work item from the recycle bin of its project
+func (c *client) RestoreWorkItem(organization, projectName string, workItemID int, mattermostUserID string) (int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return statusCode, err
+	}
+	restoreWorkItemPath := fmt.Sprintf(constants.RestoreWorkItem, organization, projectName, workItemID)
+
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), restoreWorkItemPath, http.MethodPatch, mattermostUserID, &serializers.RestoreWorkItemRequest{IsDeleted: false}, nil, nil)
+	if err != nil {
+		return statusCode, errors.Wrap(err, "failed to restore the work item")
+	}
+
+	return statusCode, nil
+}
+
+func (c *client) GetGitRepository(organization, projectName, repositoryID, mattermostUserID string) (*serializers.GitRepository, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, repositoryID); err != nil {
+		return nil, statusCode, err
+	}
+	getGitRepositoryPath := fmt.Sprintf(constants.GetGitRepository, organization, projectName, repositoryID)
+
+	var repository *serializers.GitRepository
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getGitRepositoryPath, http.MethodGet, mattermostUserID, nil, &repository, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the repository")
+	}
+
+	return repository, statusCode, nil
+}
+
 // Function to get the pipeline build details.
 func (c *client) GetBuildDetails(organization, projectName, buildID, mattermostUserID string) (*serializers.BuildDetails, int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, buildID); err != nil {
@@ -155,7 +564,7 @@ func (c *client) GetBuildDetails(organization, projectName, buildID, mattermostU
 	getBuildDetailsPath := fmt.Sprintf(constants.GetBuildDetails, organization, projectName, buildID)
 
 	var buildDetails *serializers.BuildDetails
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, getBuildDetailsPath, http.MethodGet, mattermostUserID, nil, &buildDetails, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getBuildDetailsPath, http.MethodGet, mattermostUserID, nil, &buildDetails, nil)
 	if err != nil {
 		return nil, statusCode, errors.Wrap(err, "failed to get the pipeline build details")
 	}
@@ -163,6 +572,27 @@ func (c *client) GetBuildDetails(organization, projectName, buildID, mattermostU
 	return buildDetails, statusCode, nil
 }
 
+// QueueBuild queues a build of a pipeline definition on a branch and returns the queued build
+func (c *client) QueueBuild(organization, projectName string, definitionID int, sourceBranch, mattermostUserID string) (*serializers.BuildDetails, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	queueBuildPath := fmt.Sprintf(constants.QueueBuild, organization, projectName)
+
+	queueBuildRequest := &serializers.QueueBuildRequest{
+		Definition:   serializers.QueueBuildDefinition{ID: definitionID},
+		SourceBranch: sourceBranch,
+	}
+
+	var build *serializers.BuildDetails
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), queueBuildPath, http.MethodPost, mattermostUserID, queueBuildRequest, &build, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to queue the build")
+	}
+
+	return build, statusCode, nil
+}
+
 // Function to get the pipeline release details.
 func (c *client) GetReleaseDetails(organization, projectName, releaseID, mattermostUserID string) (*serializers.ReleaseDetails, int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, releaseID); err != nil {
@@ -171,8 +601,7 @@ func (c *client) GetReleaseDetails(organization, projectName, releaseID, matterm
 	getReleaseDetailsPath := fmt.Sprintf(constants.GetReleaseDetails, organization, projectName, releaseID)
 
 	var releaseDetails *serializers.ReleaseDetails
-	baseURL := c.plugin.getConfiguration().AzureDevopsAPIBaseURL
-	baseURL = strings.Replace(baseURL, "://", "://vsrm.", 1)
+	baseURL := c.plugin.getConfiguration().GetReleaseAPIBaseURL()
 	_, statusCode, err := c.CallJSON(baseURL, getReleaseDetailsPath, http.MethodGet, mattermostUserID, nil, &releaseDetails, nil)
 	if err != nil {
 		return nil, statusCode, errors.Wrap(err, "failed to get the pipeline release details")
@@ -181,6 +610,297 @@ func (c *client) GetReleaseDetails(organization, projectName, releaseID, matterm
 	return releaseDetails, statusCode, nil
 }
 
+// Function to get the states of a work item type along with their colors.
+func (c *client) GetWorkItemTypeStates(organization, projectName, workItemType, mattermostUserID string) ([]*serializers.WorkItemTypeState, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, workItemType); err != nil {
+		return nil, statusCode, err
+	}
+	getWorkItemTypeStatesPath := fmt.Sprintf(constants.GetWorkItemTypeStates, organization, projectName, url.PathEscape(workItemType))
+
+	var workItemTypeStates *serializers.WorkItemTypeStatesResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getWorkItemTypeStatesPath, http.MethodGet, mattermostUserID, nil, &workItemTypeStates, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the work item type states")
+	}
+
+	if workItemTypeStates == nil {
+		return nil, statusCode, nil
+	}
+
+	return workItemTypeStates.Value, statusCode, nil
+}
+
+// GetProcess fetches the process of a project along with the work item types of the process.
+// The work item types of a custom inherited process include the ones it inherits from its parent process.
+func (c *client) GetProcess(organization, projectID, mattermostUserID string) (*serializers.Process, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectID, ""); err != nil {
+		return nil, statusCode, err
+	}
+	baseURL := c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL()
+
+	var project *serializers.ProjectCapabilities
+	_, statusCode, err := c.CallJSON(baseURL, fmt.Sprintf(constants.GetProjectCapabilities, organization, projectID), http.MethodGet, mattermostUserID, nil, &project, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the project capabilities")
+	}
+
+	if project == nil || project.Capabilities.ProcessTemplate.TemplateTypeID == "" {
+		return nil, statusCode, errors.New("failed to get the process of the project")
+	}
+	processTypeID := project.Capabilities.ProcessTemplate.TemplateTypeID
+
+	var process *serializers.Process
+	_, statusCode, err = c.CallJSON(baseURL, fmt.Sprintf(constants.GetProcess, organization, processTypeID), http.MethodGet, mattermostUserID, nil, &process, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the process")
+	}
+
+	if process == nil {
+		process = &serializers.Process{TypeID: processTypeID, Name: project.Capabilities.ProcessTemplate.TemplateName}
+	}
+
+	var workItemTypes *serializers.ProcessWorkItemTypesResponse
+	_, statusCode, err = c.CallJSON(baseURL, fmt.Sprintf(constants.GetProcessWorkItemTypes, organization, processTypeID), http.MethodGet, mattermostUserID, nil, &workItemTypes, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the work item types of the process")
+	}
+
+	if workItemTypes != nil {
+		process.WorkItemTypes = workItemTypes.Value
+	}
+
+	return process, statusCode, nil
+}
+
+// QueryWorkItems runs a WIQL query in the context of a project and returns the references of the matching work items
+func (c *client) QueryWorkItems(organization, projectName, query, mattermostUserID string) ([]*serializers.WorkItemReference, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	queryWorkItemsPath := fmt.Sprintf(constants.QueryWorkItems, organization, projectName)
+
+	var queryResponse *serializers.WorkItemQueryResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), queryWorkItemsPath, http.MethodPost, mattermostUserID, &serializers.WorkItemQueryRequest{Query: query}, &queryResponse, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to query the work items")
+	}
+
+	if queryResponse == nil {
+		return nil, statusCode, nil
+	}
+
+	return queryResponse.WorkItems, statusCode, nil
+}
+
+// ValidateWIQL runs a WIQL query in the context of a project while fetching a single result, so that it can be checked before it's saved.
+// The errors in the syntax or the fields of the query are returned with the status code 400 and the message of Azure DevOps.
+func (c *client) ValidateWIQL(organization, projectName, query, mattermostUserID string) (int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return statusCode, err
+	}
+	validateWIQLPath := fmt.Sprintf(constants.ValidateWIQL, organization, projectName)
+
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), validateWIQLPath, http.MethodPost, mattermostUserID, &serializers.WorkItemQueryRequest{Query: query}, nil, nil)
+	if err != nil {
+		if statusCode == http.StatusBadRequest {
+			message := strings.TrimPrefix(errors.Cause(err).Error(), constants.AzureDevopsErrorMessagePrefix)
+			var azureError *serializers.AzureError
+			if errors.As(err, &azureError) {
+				message = azureError.Message
+			}
+			return statusCode, errors.New(getWIQLErrorMessage(query, message))
+		}
+		return statusCode, errors.Wrap(err, "failed to validate the query")
+	}
+
+	return statusCode, nil
+}
+
+// getWIQLErrorMessage adds the clause of a query to the message of an error in it, if Azure DevOps marks the part of the query causing it like «[System.Foo]»
+func getWIQLErrorMessage(query, message string) string {
+	cause := wiqlErrorCauseRegex.FindStringSubmatch(message)
+	if cause == nil {
+		return message
+	}
+
+	causeIndex := strings.Index(strings.ToLower(query), strings.ToLower(cause[1]))
+	if causeIndex < 0 {
+		return message
+	}
+
+	start, end := 0, len(query)
+	for _, keyword := range wiqlClauseRegex.FindAllStringIndex(query, -1) {
+		if keyword[0] > causeIndex {
+			end = keyword[0]
+			break
+		}
+		start = keyword[0]
+	}
+
+	return fmt.Sprintf(constants.WIQLErrorClause, message, strings.TrimSpace(query[start:end]))
+}
+
+// GetWorkItemsBatch fetches the given fields of at most 200 work items in a single request
+func (c *client) GetWorkItemsBatch(organization, projectName string, workItemIDs []int, fields []string, mattermostUserID string) ([]*serializers.TaskValue, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	getWorkItemsBatchPath := fmt.Sprintf(constants.GetWorkItemsBatch, organization, projectName)
+
+	var workItemsBatch *serializers.WorkItemsBatchResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getWorkItemsBatchPath, http.MethodPost, mattermostUserID, &serializers.WorkItemsBatchRequest{IDs: workItemIDs, Fields: fields}, &workItemsBatch, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the work items")
+	}
+
+	if workItemsBatch == nil {
+		return nil, statusCode, nil
+	}
+
+	return workItemsBatch.Value, statusCode, nil
+}
+
+// SearchWorkItems finds at most top work items of a project matching a text with the search API, ordered by relevance
+func (c *client) SearchWorkItems(organization, projectName, text string, top int, mattermostUserID string) ([]*serializers.WorkItemSearchResult, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	searchWorkItemsPath := fmt.Sprintf(constants.SearchWorkItems, organization, projectName)
+
+	var searchResponse *serializers.WorkItemSearchResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetSearchAPIBaseURL(), searchWorkItemsPath, http.MethodPost, mattermostUserID, &serializers.WorkItemSearchRequest{SearchText: text, Top: top}, &searchResponse, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to search the work items")
+	}
+
+	if searchResponse == nil || searchResponse.Results == nil {
+		return []*serializers.WorkItemSearchResult{}, statusCode, nil
+	}
+
+	return searchResponse.Results, statusCode, nil
+}
+
+// GetWorkItems fetches the most recently changed work items of a project matching the filters of the query.
+// The work items are returned in the order of the query, at most the first 50 of them.
+func (c *client) GetWorkItems(organization, projectName string, query serializers.WorkItemQuery, mattermostUserID string) ([]*serializers.TaskValue, int, error) {
+	workItemReferences, statusCode, err := c.QueryWorkItems(organization, projectName, getProjectWorkItemsQuery(query), mattermostUserID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	var workItemIDs []int
+	for _, reference := range workItemReferences {
+		if len(workItemIDs) == constants.ProjectWorkItemsMaxResults {
+			break
+		}
+		workItemIDs = append(workItemIDs, reference.ID)
+	}
+
+	if len(workItemIDs) == 0 {
+		return []*serializers.TaskValue{}, statusCode, nil
+	}
+
+	fields := []string{constants.FieldWorkItemType, constants.FieldTitle, constants.FieldState, constants.FieldAssignedTo, constants.FieldChangedDate}
+	workItems, statusCode, err := c.GetWorkItemsBatch(organization, projectName, workItemIDs, fields, mattermostUserID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	workItemsByID := map[int]*serializers.TaskValue{}
+	for _, workItem := range workItems {
+		workItemsByID[workItem.ID] = workItem
+	}
+
+	orderedWorkItems := make([]*serializers.TaskValue, 0, len(workItems))
+	for _, workItemID := range workItemIDs {
+		if workItem, ok := workItemsByID[workItemID]; ok {
+			orderedWorkItems = append(orderedWorkItems, workItem)
+		}
+	}
+
+	return orderedWorkItems, statusCode, nil
+}
+
+// getProjectWorkItemsQuery returns the WIQL query of the work items of a project matching the filters
+func getProjectWorkItemsQuery(query serializers.WorkItemQuery) string {
+	var sb strings.Builder
+	sb.WriteString(constants.QueryProjectWorkItems)
+	if query.State != "" {
+		sb.WriteString(fmt.Sprintf(constants.QueryProjectWorkItemsState, escapeWIQLString(query.State)))
+	}
+
+	switch {
+	case strings.EqualFold(query.AssignedTo, constants.ProjectWorkItemsAssignedToMe):
+		sb.WriteString(constants.QueryProjectWorkItemsAssignedToMe)
+	case query.AssignedTo != "":
+		sb.WriteString(fmt.Sprintf(constants.QueryProjectWorkItemsAssignedTo, escapeWIQLString(query.AssignedTo)))
+	}
+
+	sb.WriteString(constants.QueryProjectWorkItemsOrder)
+	return sb.String()
+}
+
+// GetCurrentIteration fetches the current iteration of a team, the default team of the project is used if the team name is empty
+func (c *client) GetCurrentIteration(organization, projectName, teamName, mattermostUserID string) (*serializers.Iteration, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, teamName); err != nil {
+		return nil, statusCode, err
+	}
+
+	teamProject := projectName
+	if teamName != "" {
+		teamProject = fmt.Sprintf("%s/%s", projectName, url.PathEscape(teamName))
+	}
+	getCurrentIterationPath := fmt.Sprintf(constants.GetCurrentIteration, organization, teamProject)
+
+	var iterations *serializers.IterationsResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getCurrentIterationPath, http.MethodGet, mattermostUserID, nil, &iterations, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the current iteration")
+	}
+
+	if iterations == nil || len(iterations.Value) == 0 {
+		return nil, statusCode, nil
+	}
+
+	return iterations.Value[0], statusCode, nil
+}
+
+// GetQueries searches the saved queries of a project by name, the matching queries are returned from all the folders
+func (c *client) GetQueries(organization, projectName, filter, mattermostUserID string) ([]*serializers.Query, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	getQueriesPath := fmt.Sprintf(constants.GetQueries, organization, projectName, url.QueryEscape(filter), constants.QueriesSearchMaxResults)
+
+	var queries *serializers.QueriesResponse
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getQueriesPath, http.MethodGet, mattermostUserID, nil, &queries, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the queries")
+	}
+
+	if queries == nil {
+		return nil, statusCode, nil
+	}
+
+	return queries.Value, statusCode, nil
+}
+
+// RunSharedQuery runs a saved query and returns the references of the resulting work items or work item links
+func (c *client) RunSharedQuery(organization, projectName, queryID, mattermostUserID string) (*serializers.WorkItemQueryResult, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
+		return nil, statusCode, err
+	}
+	runSavedQueryPath := fmt.Sprintf(constants.RunSavedQuery, organization, projectName, url.PathEscape(queryID), constants.SharedQueryMaxResults)
+
+	var queryResult *serializers.WorkItemQueryResult
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), runSavedQueryPath, http.MethodGet, mattermostUserID, nil, &queryResult, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to run the query")
+	}
+
+	return queryResult, statusCode, nil
+}
+
 // Function to link a project and an organization.
 func (c *client) Link(body *serializers.LinkRequestPayload, mattermostUserID string) (*serializers.Project, int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths(body.Organization, body.Project, ""); err != nil {
@@ -189,7 +909,7 @@ func (c *client) Link(body *serializers.LinkRequestPayload, mattermostUserID str
 	linkProjectPath := fmt.Sprintf(constants.GetProject, body.Organization, body.Project)
 
 	var project *serializers.Project
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, linkProjectPath, http.MethodGet, mattermostUserID, nil, &project, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), linkProjectPath, http.MethodGet, mattermostUserID, nil, &project, nil)
 	if err != nil {
 		return nil, statusCode, errors.Wrap(err, "failed to link Project")
 	}
@@ -202,6 +922,12 @@ func (c *client) callFormURLEncoded(url, path, method string, out interface{}, f
 	return c.Call(url, method, path, contentType, "", nil, out, formValues)
 }
 
+var (
+	wiqlErrorCauseRegex  = regexp.MustCompile(constants.WIQLErrorCauseRegex)
+	wiqlClauseRegex      = regexp.MustCompile(constants.WIQLClauseRegex)
+	apiVersionQueryRegex = regexp.MustCompile(constants.APIVersionQueryRegex)
+)
+
 // publishedID is sent in the payload while calling the Azure DevOps API and it varies according to the eventType
 var publisherID = map[string]string{
 	constants.SubscriptionEventPullRequestCreated:                 constants.PublisherIDTFS,
@@ -232,10 +958,10 @@ func (c *client) CreateSubscription(body *serializers.CreateSubscriptionRequestP
 	}
 	createSubscriptionPath := fmt.Sprintf(constants.CreateSubscription, body.Organization)
 
-	uniqueWebhookSecret := url.QueryEscape(uuid)
-
 	consumerInputs := serializers.ConsumerInputs{
-		URL: fmt.Sprintf("%s%s?%s=%s", strings.TrimRight(pluginURL, "/"), constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, uniqueWebhookSecret),
+		URL:                    c.plugin.getSubscriptionWebhookURL(pluginURL, uuid),
+		MessagesToSend:         body.GetMessageFormat(),
+		DetailedMessagesToSend: body.GetMessageFormat(),
 	}
 
 	payload := serializers.CreateSubscriptionBodyPayload{
@@ -244,9 +970,11 @@ func (c *client) CreateSubscription(body *serializers.CreateSubscriptionRequestP
 		ConsumerID:       constants.ConsumerID,
 		ConsumerActionID: constants.ConsumerActionID,
 		ConsumerInputs:   consumerInputs,
+		ResourceVersion:  body.GetResourceVersion(),
 		PublisherInputs: serializers.PublisherInputsGeneric{
 			ProjectID:                    project.ProjectID,
 			AreaPath:                     body.AreaPath,
+			WorkItemType:                 body.WorkItemType,
 			Repository:                   body.Repository,
 			Branch:                       body.TargetBranch,
 			PushedBy:                     body.PushedBy,
@@ -272,7 +1000,7 @@ func (c *client) CreateSubscription(body *serializers.CreateSubscriptionRequestP
 		},
 	}
 
-	baseURL := c.plugin.updateBaseURLForReleaseEventTypes(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, body.EventType)
+	baseURL := c.plugin.updateBaseURLForReleaseEventTypes(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), body.EventType)
 	var subscription *serializers.SubscriptionValue
 	_, statusCode, err := c.CallJSON(baseURL, createSubscriptionPath, http.MethodPost, mattermostUserID, payload, &subscription, nil)
 	if err != nil {
@@ -288,7 +1016,7 @@ func (c *client) DeleteSubscription(organization, subscriptionID, mattermostUser
 	}
 	deleteSubscriptionPath := fmt.Sprintf(constants.DeleteSubscription, organization, subscriptionID)
 
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, deleteSubscriptionPath, http.MethodDelete, mattermostUserID, nil, nil, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), deleteSubscriptionPath, http.MethodDelete, mattermostUserID, nil, nil, nil)
 	if err != nil {
 		return statusCode, errors.Wrap(err, "failed to delete subscription")
 	}
@@ -296,15 +1024,85 @@ func (c *client) DeleteSubscription(organization, subscriptionID, mattermostUser
 	return statusCode, nil
 }
 
-func (c *client) UpdatePipelineApprovalRequest(pipelineApproveRequestPayload *serializers.PipelineApproveRequest, organization, projectName, mattermostUserID string, approvalID int) (int, error) {
+// ListSubscriptions lists the subscriptions of the webhooks of an organization which the user can view
+func (c *client) ListSubscriptions(organization, mattermostUserID string) ([]serializers.SubscriptionValue, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, "", ""); err != nil {
+		return nil, statusCode, err
+	}
+	listSubscriptionsPath := fmt.Sprintf(constants.ListSubscriptions, organization, constants.ConsumerID)
+
+	var subscriptionList *serializers.SubscriptionList
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), listSubscriptionsPath, http.MethodGet, mattermostUserID, nil, &subscriptionList, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to list the subscriptions")
+	}
+
+	if subscriptionList == nil {
+		return nil, statusCode, nil
+	}
+
+	return subscriptionList.SubscriptionValue, statusCode, nil
+}
+
+// GetSubscription gets the subscription of a webhook by its ID
+func (c *client) GetSubscription(organization, subscriptionID, mattermostUserID string) (*serializers.SubscriptionValue, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, "", subscriptionID); err != nil {
+		return nil, statusCode, err
+	}
+	getSubscriptionPath := fmt.Sprintf(constants.GetSubscription, organization, subscriptionID)
+
+	var subscription *serializers.SubscriptionValue
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getSubscriptionPath, http.MethodGet, mattermostUserID, nil, &subscription, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get subscription")
+	}
+
+	return subscription, statusCode, nil
+}
+
+// UpdateSubscriptionWebhookURL changes the URL the webhook of a subscription sends its notifications to and returns the previous one.
+// The subscription is fetched and replaced as a whole, so that the fields which are not known by the plugin are kept.
+func (c *client) UpdateSubscriptionWebhookURL(organization, subscriptionID, webhookURL, mattermostUserID string) (string, int, error) {
+	if statusCode, err := c.plugin.SanitizeURLPaths(organization, "", subscriptionID); err != nil {
+		return "", statusCode, err
+	}
+	updateSubscriptionPath := fmt.Sprintf(constants.UpdateSubscription, organization, subscriptionID)
+
+	var subscription map[string]interface{}
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), updateSubscriptionPath, http.MethodGet, mattermostUserID, nil, &subscription, nil)
+	if err != nil {
+		return "", statusCode, errors.Wrap(err, "failed to get subscription")
+	}
+
+	consumerInputs, ok := subscription["consumerInputs"].(map[string]interface{})
+	if !ok {
+		return "", http.StatusInternalServerError, errors.New("subscription has no webhook URL")
+	}
+	previousURL, _ := consumerInputs["url"].(string)
+	consumerInputs["url"] = webhookURL
+
+	_, statusCode, err = c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), updateSubscriptionPath, http.MethodPut, mattermostUserID, subscription, nil, nil)
+	if err != nil {
+		return "", statusCode, errors.Wrap(err, "failed to update subscription")
+	}
+
+	return previousURL, statusCode, nil
+}
+
+// SetReleaseApproval approves or rejects a pending deployment approval of a release
+func (c *client) SetReleaseApproval(organization, projectName string, approvalID int, status, comment, mattermostUserID string) (int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
 		return statusCode, err
 	}
-	updatePipelineApproveRequestPath := fmt.Sprintf(constants.PipelineApproveRequest, organization, projectName, approvalID)
+	setReleaseApprovalPath := fmt.Sprintf(constants.PipelineApproveRequest, organization, projectName, approvalID)
+
+	payload := &serializers.PipelineApproveRequest{
+		Status:   status,
+		Comments: comment,
+	}
 
-	baseURL := c.plugin.getConfiguration().AzureDevopsAPIBaseURL
-	baseURL = strings.Replace(baseURL, "://", "://vsrm.", 1)
-	_, statusCode, err := c.CallJSON(baseURL, updatePipelineApproveRequestPath, http.MethodPatch, mattermostUserID, &pipelineApproveRequestPayload, nil, nil)
+	baseURL := c.plugin.getConfiguration().GetReleaseAPIBaseURL()
+	_, statusCode, err := c.CallJSON(baseURL, setReleaseApprovalPath, http.MethodPatch, mattermostUserID, payload, nil, nil)
 
 	return statusCode, err
 }
@@ -316,7 +1114,7 @@ func (c *client) UpdatePipelineRunApprovalRequest(pipelineApproveRequestPayload
 	updatePipelineApproveRunRequestPath := fmt.Sprintf(constants.PipelineRunApproveRequest, organization, projectID)
 
 	var pipelineRunApproveResponse *serializers.PipelineRunApproveResponse
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, updatePipelineApproveRunRequestPath, http.MethodPatch, mattermostUserID, &pipelineApproveRequestPayload, &pipelineRunApproveResponse, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), updatePipelineApproveRunRequestPath, http.MethodPatch, mattermostUserID, &pipelineApproveRequestPayload, &pipelineRunApproveResponse, nil)
 	if err != nil {
 		return nil, statusCode, err
 	}
@@ -374,9 +1172,9 @@ func (c *client) GetSubscriptionFilterPossibleValues(request *serializers.GetSub
 		}
 	}
 
-	baseURL := c.plugin.getConfiguration().AzureDevopsAPIBaseURL
+	baseURL := c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL()
 	if strings.Contains(request.EventType, constants.EventOfTypeRelease) {
-		baseURL = strings.Replace(baseURL, "://", "://vsrm.", 1)
+		baseURL = c.plugin.getConfiguration().GetReleaseAPIBaseURL()
 	}
 
 	var subscriptionFiltersResponse *serializers.SubscriptionFilterPossibleValuesResponseFromClient
@@ -388,21 +1186,21 @@ func (c *client) GetSubscriptionFilterPossibleValues(request *serializers.GetSub
 	return subscriptionFiltersResponse, statusCode, nil
 }
 
-func (c *client) GetApprovalDetails(organization, projectName, mattermostUserID string, approvalID int) (*serializers.PipelineApprovalDetails, int, error) {
+// GetReleaseApproval returns the details of a deployment approval of a release including its status and approver
+func (c *client) GetReleaseApproval(organization, projectName string, approvalID int, mattermostUserID string) (*serializers.ReleaseApproval, int, error) {
 	if statusCode, err := c.plugin.SanitizeURLPaths(organization, projectName, ""); err != nil {
 		return nil, statusCode, err
 	}
-	getPipelineApprovalDetailsPath := fmt.Sprintf(constants.PipelineApproveRequest, organization, projectName, approvalID)
+	getReleaseApprovalPath := fmt.Sprintf(constants.PipelineApproveRequest, organization, projectName, approvalID)
 
-	baseURL := c.plugin.getConfiguration().AzureDevopsAPIBaseURL
-	baseURL = strings.Replace(baseURL, "://", "://vsrm.", 1)
-	var pipelineApprovalDetails *serializers.PipelineApprovalDetails
-	_, statusCode, err := c.CallJSON(baseURL, getPipelineApprovalDetailsPath, http.MethodGet, mattermostUserID, nil, &pipelineApprovalDetails, nil)
+	baseURL := c.plugin.getConfiguration().GetReleaseAPIBaseURL()
+	var releaseApproval *serializers.ReleaseApproval
+	_, statusCode, err := c.CallJSON(baseURL, getReleaseApprovalPath, http.MethodGet, mattermostUserID, nil, &releaseApproval, nil)
 	if err != nil {
 		return nil, statusCode, err
 	}
 
-	return pipelineApprovalDetails, statusCode, nil
+	return releaseApproval, statusCode, nil
 }
 
 func (c *client) GetRunApprovalDetails(organization, projectID, mattermostUserID, approvalID string) (*serializers.PipelineRunApprovalDetails, int, error) {
@@ -412,7 +1210,7 @@ func (c *client) GetRunApprovalDetails(organization, projectID, mattermostUserID
 	getPipelineRunApprovalDetailsPath := fmt.Sprintf(constants.PipelineRunApproveDetails, organization, projectID, approvalID)
 
 	var pipelineApprovalDetails *serializers.PipelineRunApprovalDetails
-	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().AzureDevopsAPIBaseURL, getPipelineRunApprovalDetailsPath, http.MethodGet, mattermostUserID, nil, &pipelineApprovalDetails, nil)
+	_, statusCode, err := c.CallJSON(c.plugin.getConfiguration().GetAzureDevopsAPIBaseURL(), getPipelineRunApprovalDetailsPath, http.MethodGet, mattermostUserID, nil, &pipelineApprovalDetails, nil)
 	if err != nil {
 		return nil, statusCode, err
 	}
@@ -442,58 +1240,198 @@ func (c *client) CallJSON(url, path, method, mattermostUserID string, in, out in
 
 // Makes HTTP request to REST APIs
 func (c *client) Call(basePath, method, path, contentType string, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+	return c.CallWithResponseLimit(basePath, method, path, contentType, mattermostUserID, inBody, out, formValues, constants.MaxBytesSizeForReadingResponseBody)
+}
+
+// CallWithResponseLimit makes HTTP request to REST APIs whose responses can be larger than the default limit
+func (c *client) CallWithResponseLimit(basePath, method, path, contentType string, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values, maxResponseBytes int64) (responseData []byte, statusCode int, err error) {
 	errContext := fmt.Sprintf("Azure DevOps: Call failed: method:%s, path:%s", method, path)
 	URL, err := c.parsePath(basePath, path, method)
 	if err != nil {
 		return nil, http.StatusInternalServerError, errors.WithMessage(err, errContext)
 	}
 
-	// Check refresh token only for APIs other than OAuth
-	if basePath != constants.BaseOauthURL {
+	// The token is selected by the organization of the request, which is empty for the OAuth requests and the ones sent to Mattermost
+	var organization string
+
+	// Check refresh token only for APIs other than OAuth, Azure DevOps Server serves both from the same base URL but only the OAuth requests send form values
+	isOAuthRequest := formValues != nil && (basePath == c.plugin.getConfiguration().GetOAuthBaseURL() || basePath == constants.BaseDeviceCodeOAuthURL)
+	if !isOAuthRequest {
+		URL = overrideAPIVersion(URL, c.plugin.getConfiguration().AzureDevopsAPIVersion)
+		organization = c.getRequestOrganization(basePath, path)
+		if statusCode, err := c.plugin.checkOrganizationConnection(mattermostUserID, organization); err != nil {
+			return nil, statusCode, err
+		}
+
 		if isAccessTokenExpired, refreshToken := c.plugin.IsAccessTokenExpired(mattermostUserID); isAccessTokenExpired {
 			if errRefreshingToken := c.plugin.RefreshOAuthToken(mattermostUserID, refreshToken); errRefreshingToken != nil {
-				message := constants.SessionExpiredMessage
-				if isDeleted, dErr := c.plugin.Store.DeleteUser(mattermostUserID); !isDeleted {
-					if dErr != nil {
-						c.plugin.API.LogError(constants.UnableToDisconnectUser, "Error", dErr.Error())
-					}
-					message = constants.GenericErrorMessage
-				}
-
-				c.plugin.API.PublishWebSocketEvent(
-					constants.WSEventDisconnect,
-					nil,
-					&model.WebsocketBroadcast{UserId: mattermostUserID},
-				)
-
-				if _, DMErr := c.plugin.DM(mattermostUserID, message, false); DMErr != nil {
-					c.plugin.API.LogError(constants.UnableToDMBot, "Error", DMErr.Error())
-				}
+				c.plugin.disconnectExpiredSession(mattermostUserID)
 				return nil, http.StatusInternalServerError, errRefreshingToken
 			}
 		}
 	}
 
+	// The body is kept so that the request can be sent again if its access token is rejected
+	var body []byte
+	if formValues == nil && inBody != nil {
+		if body, err = io.ReadAll(inBody); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	// The retries are bounded as a whole, so that their waits don't keep the user waiting indefinitely
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestRetriesTimeout)
+	defer cancel()
+
+	req, responseData, statusCode, err := c.sendWithRetries(ctx, method, URL, mattermostUserID, organization, body, formValues, contentType, out, maxResponseBytes)
+	if req == nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if statusCode != http.StatusUnauthorized || isOAuthRequest || mattermostUserID == "" {
+		return responseData, statusCode, err
+	}
+
+	// The token can expire while the request is in flight, or it can be refreshed by a concurrent request after it's read.
+	// The request is sent again once, with the token refreshed by the other request or refreshed here.
+	retryReq, retryErr := c.newAuthorizedRequest(method, URL, mattermostUserID, organization, body, formValues)
+	if retryErr != nil {
+		return responseData, statusCode, err
+	}
+
+	if retryReq.Header.Get(constants.Authorization) == req.Header.Get(constants.Authorization) {
+		user, loadErr := c.plugin.loadAzureDevopsUser(mattermostUserID)
+		if loadErr != nil {
+			c.plugin.API.LogError(constants.ErrorLoadingUserData, "Error", loadErr.Error())
+			return responseData, statusCode, err
+		}
+
+		// The tokens of the organization connections are only refreshed once they expire
+		if user.GetOrganizationConnection(organization) != nil {
+			return responseData, statusCode, err
+		}
+
+		if refreshErr := c.plugin.RefreshAccessToken(user); refreshErr != nil {
+			c.plugin.API.LogError(constants.ErrorRefreshAccessToken, "Error", refreshErr.Error())
+			return responseData, statusCode, err
+		}
+	}
+
+	sentReq, retryResponseData, retryStatusCode, retryErr := c.sendWithRetries(ctx, method, URL, mattermostUserID, organization, body, formValues, contentType, out, maxResponseBytes)
+	if sentReq == nil {
+		return responseData, statusCode, err
+	}
+
+	return retryResponseData, retryStatusCode, retryErr
+}
+
+// sendWithRetries sends a request to Azure DevOps, and sends it again with an exponential backoff while it fails due to a transient error.
+// It returns the last request sent, which is nil if the request could not be created, and it stops waiting to send it again once the context is done.
+func (c *client) sendWithRetries(ctx context.Context, method, URL, mattermostUserID, organization string, body []byte, formValues url.Values, contentType string, out interface{}, maxResponseBytes int64) (req *http.Request, responseData []byte, statusCode int, err error) {
+	maxRetries := c.plugin.getConfiguration().MaxRequestRetries
+	for attempt := 0; ; attempt++ {
+		if req, err = c.newAuthorizedRequest(method, URL, mattermostUserID, organization, body, formValues); err != nil {
+			return nil, nil, 0, err
+		}
+		req = req.WithContext(ctx)
+
+		responseData, statusCode, err = c.MakeHTTPRequestWithResponseLimit(req, contentType, out, maxResponseBytes)
+		if attempt >= maxRetries || ctx.Err() != nil || !isRetryableRequest(method, statusCode, err) {
+			return req, responseData, statusCode, err
+		}
+
+		backoff := getRequestRetryBackoff(attempt, err)
+		c.plugin.API.LogWarn("Retrying the request to Azure DevOps", "Method", method, "Path", req.URL.Path, "StatusCode", statusCode, "Attempt", attempt+1, "Backoff", backoff.String(), "Error", err.Error())
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return req, responseData, statusCode, err
+		}
+	}
+}
+
+// isRetryableRequest checks if a failed request can be sent again.
+// The GET and DELETE requests are sent again when Azure DevOps is throttling or unavailable, but the other ones only when
+// they could not connect to Azure DevOps, so that e.g. a work item is not created twice.
+func isRetryableRequest(method string, statusCode int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	isIdempotent := method == http.MethodGet || method == http.MethodDelete
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// A request whose connection has timed out, been reset or closed once it was sent may have been processed by Azure DevOps
+		return isIdempotent || isDialError(err)
+	}
+
+	return isIdempotent && (statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable)
+}
+
+// isDialError checks if a request failed while connecting to the server, before any of it was sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// getRequestRetryBackoff returns the wait before sending a request again, which is the one requested by Azure DevOps if any.
+// It doubles with every failed attempt and it's limited so that the users are not kept waiting for too long.
+func getRequestRetryBackoff(attempt int, err error) time.Duration {
+	backoff := constants.RequestRetryInitialBackoff * time.Duration(1<<uint(attempt))
+	var azureError *serializers.AzureError
+	if errors.As(err, &azureError) && azureError.RetryAfter > 0 {
+		backoff = azureError.RetryAfter
+	}
+
+	if backoff > constants.RequestRetryMaxBackoff {
+		return constants.RequestRetryMaxBackoff
+	}
+
+	return backoff
+}
+
+// parseRetryAfter returns the wait requested by the Retry-After header, given either in seconds or as a date
+func parseRetryAfter(retryAfter string, now time.Time) time.Duration {
+	if retryAfter == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(retryAfter); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// newAuthorizedRequest creates a request with the current access token of the user for the organization, if any
+func (c *client) newAuthorizedRequest(method, URL, mattermostUserID, organization string, body []byte, formValues url.Values) (*http.Request, error) {
 	var req *http.Request
+	var err error
 	if formValues != nil {
 		req, err = http.NewRequest(method, URL, strings.NewReader(formValues.Encode()))
-		if err != nil {
-			return nil, http.StatusInternalServerError, err
-		}
 	} else {
-		req, err = http.NewRequest(method, URL, inBody)
-		if err != nil {
-			return nil, http.StatusInternalServerError, err
-		}
+		req, err = http.NewRequest(method, URL, bytes.NewReader(body))
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	if mattermostUserID != "" {
-		if err = c.plugin.AddAuthorization(req, mattermostUserID); err != nil {
-			return nil, http.StatusInternalServerError, err
+		if err = c.plugin.AddAuthorization(req, mattermostUserID, organization); err != nil {
+			return nil, err
 		}
 	}
 
-	return c.MakeHTTPRequest(req, contentType, out)
+	return req, nil
 }
 
 func (c *client) OpenDialogRequest(body *model.OpenDialogRequest, mattermostUserID string) (int, error) {
@@ -501,6 +1439,27 @@ func (c *client) OpenDialogRequest(body *model.OpenDialogRequest, mattermostUser
 	return statusCode, err
 }
 
+// PingURL makes an unauthenticated GET request to a URL to check if it's reachable
+func (c *client) PingURL(URL string) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, URL, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	_, statusCode, err := c.MakeHTTPRequest(req, "", nil)
+	return statusCode, err
+}
+
+// getRequestOrganization returns the organization of a request sent to Azure DevOps, which is the first segment of its path
+func (c *client) getRequestOrganization(basePath, path string) string {
+	config := c.plugin.getConfiguration()
+	if basePath != config.GetAzureDevopsAPIBaseURL() && basePath != config.GetReleaseAPIBaseURL() && basePath != config.GetSearchAPIBaseURL() {
+		return ""
+	}
+
+	return strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+}
+
 func (c *client) parsePath(basePath, path, method string) (string, error) {
 	pathURL, err := url.Parse(path)
 	if err != nil {
@@ -522,12 +1481,31 @@ func (c *client) parsePath(basePath, path, method string) (string, error) {
 	return path, nil
 }
 
+// overrideAPIVersion replaces the API version of a request URL, so that an Azure DevOps Server not supporting the default versions can be used
+func overrideAPIVersion(URL, apiVersion string) string {
+	if apiVersion == "" {
+		return URL
+	}
+
+	return apiVersionQueryRegex.ReplaceAllString(URL, "${1}"+apiVersion)
+}
+
 func (c *client) MakeHTTPRequest(req *http.Request, contentType string, out interface{}) (responseData []byte, statusCode int, err error) {
+	return c.MakeHTTPRequestWithResponseLimit(req, contentType, out, constants.MaxBytesSizeForReadingResponseBody)
+}
+
+func (c *client) MakeHTTPRequestWithResponseLimit(req *http.Request, contentType string, out interface{}, maxResponseBytes int64) (responseData []byte, statusCode int, err error) {
 	if contentType != "" {
 		req.Header.Add("Content-Type", contentType)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	// The slot is held until the response body is read
+	if err = c.limiter.acquire(func() int { return c.plugin.getConfiguration().GetMaxConcurrentRequests() }, constants.RequestLimiterMaxWait); err != nil {
+		return nil, http.StatusServiceUnavailable, err
+	}
+	defer c.limiter.release()
+
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -537,9 +1515,9 @@ func (c *client) MakeHTTPRequest(req *http.Request, contentType string, out inte
 	}
 	defer resp.Body.Close()
 
-	// Limit reading the response bodies to 1 MB or 1000 KB
+	// Limit reading the response bodies to 1 MB or 1000 KB by default
 	// This is ideal for the responses returned by Azure DevOps APIs used here
-	responseBody := http.MaxBytesReader(nil, resp.Body, constants.MaxBytesSizeForReadingResponseBody)
+	responseBody := http.MaxBytesReader(nil, resp.Body, maxResponseBytes)
 
 	responseData, err = io.ReadAll(responseBody)
 	if err != nil {
@@ -562,11 +1540,23 @@ func (c *client) MakeHTTPRequest(req *http.Request, contentType string, out inte
 		return nil, resp.StatusCode, ErrNotFound
 	}
 
-	errResp := ErrorResponse{}
-	if err = json.Unmarshal(responseData, &errResp); err != nil {
-		return responseData, http.StatusInternalServerError, errors.WithMessagef(err, "status: %s", resp.Status)
+	azureError := parseAzureError(responseData, resp.StatusCode)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		azureError.RetryAfter = parseRetryAfter(resp.Header.Get(constants.RetryAfter), time.Now())
+	}
+
+	return responseData, resp.StatusCode, azureError
+}
+
+// parseAzureError returns the error in the body of an error response of Azure DevOps.
+// The bodies which are not JSON or don't have a message, like the ones of a proxy, fall back to the text of the status code.
+func parseAzureError(responseData []byte, statusCode int) *serializers.AzureError {
+	azureError := &serializers.AzureError{}
+	if err := json.Unmarshal(responseData, azureError); err != nil || azureError.Message == "" {
+		return &serializers.AzureError{Message: fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))}
 	}
-	return responseData, resp.StatusCode, fmt.Errorf("errorMessage %s", errResp.Message)
+
+	return azureError
 }
 
 func (c *client) makeHTTPRequestWithAccessToken(basePath, path, method, accessToken, contentType string, out interface{}) (responseData []byte, statusCode int, err error) {
@@ -585,9 +1575,27 @@ func (c *client) makeHTTPRequestWithAccessToken(basePath, path, method, accessTo
 	return c.MakeHTTPRequest(req, contentType, out)
 }
 
+// getHTTPClient returns the HTTP client, which is rebuilt if the TLS configuration has changed since it was built
+func (c *client) getHTTPClient() *http.Client {
+	tlsConfig := c.plugin.getTLSConfig()
+
+	c.httpClientLock.Lock()
+	defer c.httpClientLock.Unlock()
+
+	if c.httpClient == nil || c.tlsConfig != tlsConfig {
+		if c.httpClient != nil {
+			c.httpClient.CloseIdleConnections()
+		}
+		c.httpClient = newHTTPClient(tlsConfig)
+		c.tlsConfig = tlsConfig
+	}
+
+	return c.httpClient
+}
+
 func InitClient(p *Plugin) Client {
 	return &client{
-		plugin:     p,
-		httpClient: &http.Client{},
+		plugin:  p,
+		limiter: newRequestLimiter(),
 	}
 }
@@ -1,19 +1,32 @@
 package plugin
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
@@ -57,6 +70,97 @@ func TestClientGenerateOAuthToken(t *testing.T) {
 	}
 }
 
+func TestGenerateDeviceCode(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	p.setConfiguration(&config.Configuration{})
+
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GenerateDeviceCode: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GenerateDeviceCode: with error",
+			err:         errors.New("error generating the device code"),
+			statusCode:  http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, constants.BaseDeviceCodeOAuthURL, basePath)
+				assert.Equal(t, "/organizations/oauth2/v2.0/devicecode", path)
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GenerateDeviceCode(url.Values{})
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGenerateDeviceCodeToken(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	p.setConfiguration(&config.Configuration{DeviceCodeTenant: "mockTenant"})
+
+	for _, testCase := range []struct {
+		description       string
+		responseData      []byte
+		err               error
+		statusCode        int
+		expectedErrorCode string
+	}{
+		{
+			description: "GenerateDeviceCodeToken: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description:       "GenerateDeviceCodeToken: authorization is pending",
+			responseData:      []byte(`{"error":"authorization_pending","error_description":"The user has not yet entered the code"}`),
+			err:               errors.New("error generating the device code token"),
+			statusCode:        http.StatusBadRequest,
+			expectedErrorCode: constants.DeviceCodeAuthorizationPending,
+		},
+		{
+			description: "GenerateDeviceCodeToken: with error",
+			err:         errors.New("error generating the device code token"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, "/mockTenant/oauth2/v2.0/token", path)
+				return testCase.responseData, testCase.statusCode, testCase.err
+			})
+
+			_, errorCode, statusCode, err := p.Client.GenerateDeviceCodeToken(url.Values{})
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.expectedErrorCode, errorCode)
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
 func TestCreateTask(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
@@ -99,6 +203,147 @@ func TestCreateTask(t *testing.T) {
 	}
 }
 
+func TestCreateTaskWithEstimates(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	var requestBody []byte
+	monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+		requestBody, err = io.ReadAll(inBody)
+		return nil, http.StatusOK, err
+	})
+
+	storyPoints, remainingWork := 5.0, 2.5
+	_, _, err := p.Client.CreateTask(&serializers.CreateTaskRequestPayload{
+		Fields: serializers.CreateTaskFieldValue{
+			Title:         "mockTitle",
+			StoryPoints:   &storyPoints,
+			RemainingWork: &remainingWork,
+		},
+	}, testutils.MockMattermostUserID)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op": "add", "path": "/fields/System.Title", "from": "", "value": "mockTitle"},
+		{"op": "add", "path": "/fields/Microsoft.VSTS.Scheduling.StoryPoints", "from": "", "value": 5},
+		{"op": "add", "path": "/fields/Microsoft.VSTS.Scheduling.RemainingWork", "from": "", "value": 2.5}
+	]`, string(requestBody))
+}
+
+func TestUpdateTask(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	remainingWork := 1.5
+	state, title, description := "mockState", "mockTitle", ""
+	for _, testCase := range []struct {
+		description         string
+		fields              serializers.UpdateTaskFieldValue
+		err                 error
+		statusCode          int
+		expectedRequestBody string
+	}{
+		{
+			description: "UpdateTask: only the provided fields are patched",
+			fields: serializers.UpdateTaskFieldValue{
+				State:         &state,
+				AssignedTo:    &serializers.TaskUserDetails{UniqueName: "mockUser@example.com"},
+				RemainingWork: &remainingWork,
+			},
+			statusCode: http.StatusOK,
+			expectedRequestBody: `[
+				{"op": "add", "path": "/fields/System.State", "from": "", "value": "mockState"},
+				{"op": "add", "path": "/fields/System.AssignedTo", "from": "", "value": "mockUser@example.com"},
+				{"op": "add", "path": "/fields/Microsoft.VSTS.Scheduling.RemainingWork", "from": "", "value": 1.5}
+			]`,
+		},
+		{
+			description: "UpdateTask: the fields provided empty are cleared",
+			fields: serializers.UpdateTaskFieldValue{
+				Description: &description,
+				AssignedTo:  &serializers.TaskUserDetails{},
+			},
+			statusCode: http.StatusOK,
+			expectedRequestBody: `[
+				{"op": "remove", "path": "/fields/System.Description", "from": "", "value": null},
+				{"op": "remove", "path": "/fields/System.AssignedTo", "from": "", "value": null}
+			]`,
+		},
+		{
+			description: "UpdateTask: with error",
+			fields:      serializers.UpdateTaskFieldValue{Title: &title},
+			err:         errors.New("error updating the task"),
+			statusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `[
+				{"op": "add", "path": "/fields/System.Title", "from": "", "value": "mockTitle"}
+			]`,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var requestMethod string
+			var requestBody []byte
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				requestMethod = method
+				requestBody, _ = io.ReadAll(inBody)
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.UpdateTask(testutils.MockOrganization, testutils.MockProjectName, "1", testCase.fields, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.Equal(t, http.MethodPatch, requestMethod)
+			assert.JSONEq(t, testCase.expectedRequestBody, string(requestBody))
+		})
+	}
+}
+
+func TestAddWorkItemComment(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "AddWorkItemComment: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "AddWorkItemComment: work item is deleted",
+			err:         errors.New("error adding the comment"),
+			statusCode:  http.StatusNotFound,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, http.MethodPost, method)
+				assert.Equal(t, fmt.Sprintf(constants.AddWorkItemComment, testutils.MockOrganization, testutils.MockProjectName, "1"), path)
+				body, _ := io.ReadAll(inBody)
+				assert.JSONEq(t, `{"text": "mockComment"}`, string(body))
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.AddWorkItemComment(testutils.MockOrganization, testutils.MockProjectName, "1", "mockComment", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
 func TestGetTask(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
@@ -136,25 +381,23 @@ func TestGetTask(t *testing.T) {
 	}
 }
 
-func TestGetReleaseDetails(t *testing.T) {
+func TestGetWorkItemTypeStates(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	p := setupTestPlugin(mockAPI)
 	for _, testCase := range []struct {
-		description          string
-		err                  error
-		statusCode           int
-		expectedErrorMessage string
+		description string
+		err         error
+		statusCode  int
 	}{
 		{
-			description: "GetReleaseDetails: valid",
+			description: "GetWorkItemTypeStates: valid",
 			statusCode:  http.StatusOK,
 		},
 		{
-			description:          "GetReleaseDetails: with error",
-			err:                  errors.New("failed to get release details"),
-			statusCode:           http.StatusInternalServerError,
-			expectedErrorMessage: "failed to get the pipeline release details: failed to get release details",
+			description: "GetWorkItemTypeStates: with error",
+			err:         errors.New("error getting the work item type states"),
+			statusCode:  http.StatusInternalServerError,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
@@ -162,74 +405,1356 @@ func TestGetReleaseDetails(t *testing.T) {
 				return nil, testCase.statusCode, testCase.err
 			})
 
-			_, statusCode, err := p.Client.GetReleaseDetails(testutils.MockOrganization, testutils.MockProjectName, "mockReleaseID", testutils.MockMattermostUserID)
+			_, statusCode, err := p.Client.GetWorkItemTypeStates(testutils.MockOrganization, testutils.MockProjectName, "User Story", testutils.MockMattermostUserID)
 
 			if testCase.err != nil {
-				assert.EqualError(t, err, testCase.expectedErrorMessage)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetProcess(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	responses := map[string]string{
+		"/_apis/projects/mockProjectID":                     `{"capabilities": {"processTemplate": {"templateName": "Custom Agile", "templateTypeId": "mockProcessID"}}}`,
+		"/_apis/work/processes/mockProcessID?":              `{"typeId": "mockProcessID", "name": "Custom Agile", "parentProcessTypeId": "adcc42ab-9882-485e-a3ed-7678f01f66bc", "customizationType": "inherited"}`,
+		"/_apis/work/processes/mockProcessID/workitemtypes": `{"count": 1, "value": [{"referenceName": "Custom.Risk", "name": "Risk", "customization": "custom"}]}`,
+	}
+	for _, testCase := range []struct {
+		description     string
+		err             error
+		statusCode      int
+		expectedProcess *serializers.Process
+	}{
+		{
+			description: "GetProcess: valid",
+			statusCode:  http.StatusOK,
+			expectedProcess: &serializers.Process{
+				TypeID:              "mockProcessID",
+				Name:                "Custom Agile",
+				ParentProcessTypeID: "adcc42ab-9882-485e-a3ed-7678f01f66bc",
+				CustomizationType:   constants.ProcessCustomizationInherited,
+				WorkItemTypes:       []*serializers.ProcessWorkItemType{{ReferenceName: "Custom.Risk", Name: "Risk", Customization: "custom"}},
+			},
+		},
+		{
+			description: "GetProcess: with error",
+			err:         errors.New("error getting the project capabilities"),
+			statusCode:  http.StatusUnauthorized,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				if testCase.err != nil {
+					return nil, testCase.statusCode, testCase.err
+				}
+
+				for responsePath, response := range responses {
+					if strings.Contains(path, responsePath) {
+						require.NoError(t, json.Unmarshal([]byte(response), out))
+					}
+				}
+				return nil, testCase.statusCode, nil
+			})
+
+			process, statusCode, err := p.Client.GetProcess(testutils.MockOrganization, "mockProjectID", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.Equal(t, testCase.expectedProcess, process)
+		})
+	}
+}
+
+func TestQueryWorkItems(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "QueryWorkItems: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "QueryWorkItems: with error",
+			err:         errors.New("error querying the work items"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, "SELECT [System.Id] FROM WorkItems", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestValidateWIQL(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description   string
+		query         string
+		err           error
+		statusCode    int
+		expectedError string
+	}{
+		{
+			description: "ValidateWIQL: valid",
+			query:       "SELECT [System.Id] FROM WorkItems",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description:   "ValidateWIQL: invalid query",
+			query:         "SELECT [Unknown.Field] FROM WorkItems WHERE [System.State] = 'Active'",
+			err:           errors.New("errorMessage TF51005: The query references a field that does not exist. The error is caused by «[Unknown.Field]»."),
+			statusCode:    http.StatusBadRequest,
+			expectedError: "TF51005: The query references a field that does not exist. The error is caused by «[Unknown.Field]». (in `SELECT [Unknown.Field]`)",
+		},
+		{
+			description:   "ValidateWIQL: invalid query without the type of the error",
+			query:         "SELECT [Unknown.Field] FROM WorkItems WHERE [System.State] = 'Active'",
+			err:           &serializers.AzureError{Message: "TF51005: The query references a field that does not exist. The error is caused by «[Unknown.Field]».", TypeKey: "QueryException"},
+			statusCode:    http.StatusBadRequest,
+			expectedError: "TF51005: The query references a field that does not exist. The error is caused by «[Unknown.Field]». (in `SELECT [Unknown.Field]`)",
+		},
+		{
+			description:   "ValidateWIQL: error in validating the query",
+			query:         "SELECT [System.Id] FROM WorkItems",
+			err:           errors.New("errorMessage internal server error"),
+			statusCode:    http.StatusInternalServerError,
+			expectedError: "failed to validate the query: errorMessage internal server error",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			statusCode, err := p.Client.ValidateWIQL(testutils.MockOrganization, testutils.MockProjectName, testCase.query, testutils.MockMattermostUserID)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
 			} else {
 				assert.NoError(t, err)
 			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetWIQLErrorMessage(t *testing.T) {
+	query := "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active' AND [Unknown.Field] = 1 ORDER BY [System.Id]"
+	for _, testCase := range []struct {
+		description     string
+		message         string
+		expectedMessage string
+	}{
+		{
+			description:     "GetWIQLErrorMessage: cause is not marked",
+			message:         "TF51006: The query statement is missing a FROM clause.",
+			expectedMessage: "TF51006: The query statement is missing a FROM clause.",
+		},
+		{
+			description:     "GetWIQLErrorMessage: cause is not in the query",
+			message:         "TF51005: The error is caused by «[Other.Field]».",
+			expectedMessage: "TF51005: The error is caused by «[Other.Field]».",
+		},
+		{
+			description:     "GetWIQLErrorMessage: cause is in the WHERE clause",
+			message:         "TF51005: The error is caused by «[unknown.field]».",
+			expectedMessage: "TF51005: The error is caused by «[unknown.field]». (in `WHERE [System.State] = 'Active' AND [Unknown.Field] = 1`)",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedMessage, getWIQLErrorMessage(query, testCase.message))
+		})
+	}
+}
+
+func TestGetWorkItem(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetWorkItem: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetWorkItem: with error",
+			err:         errors.New("error getting the work item"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetWorkItem(testutils.MockOrganization, "1", testutils.MockProjectName, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetWorkItemExpanded(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	expandedWorkItem := []byte(`{
+		"id": 1,
+		"fields": {
+			"System.Title": "mockTitle",
+			"System.WorkItemType": "Bug",
+			"System.CommentCount": 3,
+			"System.History": "<div>mockHistory</div>",
+			"Microsoft.VSTS.Common.Priority": 2,
+			"Custom.Tags": ["mockTag"]
+		},
+		"relations": [
+			{"rel": "AttachedFile", "url": "https://dev.azure.com/mockOrganization/_apis/wit/attachments/1", "attributes": {"name": "mockFile.png", "resourceSize": 1024}},
+			{"rel": "AttachedFile", "url": "https://dev.azure.com/mockOrganization/_apis/wit/attachments/2", "attributes": {"name": "mockLog.txt"}},
+			{"rel": "ArtifactLink", "url": "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F12", "attributes": {"name": "Pull Request"}},
+			null,
+			{"rel": "System.LinkTypes.Hierarchy-Reverse", "url": "https://dev.azure.com/mockOrganization/_apis/wit/workItems/2"}
+		],
+		"_links": {"html": {"href": "https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/1"}}
+	}`)
+	for _, testCase := range []struct {
+		description             string
+		responseData            []byte
+		err                     error
+		statusCode              int
+		expectedStatusCode      int
+		expectedAttachmentCount int
+		expectedCommentCount    int
+	}{
+		{
+			description:             "GetWorkItemExpanded: relations and comments are counted",
+			responseData:            expandedWorkItem,
+			statusCode:              http.StatusOK,
+			expectedStatusCode:      http.StatusOK,
+			expectedAttachmentCount: 2,
+			expectedCommentCount:    3,
+		},
+		{
+			description:        "GetWorkItemExpanded: comment count with an unexpected type",
+			responseData:       []byte(`{"id": 1, "fields": {"System.CommentCount": "many"}}`),
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "GetWorkItemExpanded: invalid response",
+			responseData:       []byte(`{"id": "mockID"}`),
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			description:        "GetWorkItemExpanded: with error",
+			err:                errors.New("error getting the work item"),
+			statusCode:         http.StatusInternalServerError,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "CallWithResponseLimit", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values, maxResponseBytes int64) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, "/mockOrganization/mockProjectName/_apis/wit/workitems/1?$expand=all&api-version=7.1-preview.3", path)
+				assert.Equal(t, int64(constants.MaxBytesSizeForReadingExpandedWorkItem), maxResponseBytes)
+				return testCase.responseData, testCase.statusCode, testCase.err
+			})
+
+			workItem, statusCode, err := p.Client.GetWorkItemExpanded(testutils.MockOrganization, testutils.MockProjectName, 1, testutils.MockMattermostUserID)
+
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.expectedStatusCode != http.StatusOK {
+				assert.Error(t, err)
+				assert.Nil(t, workItem)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, workItem.WorkItem.ID)
+			assert.Equal(t, testCase.expectedAttachmentCount, workItem.AttachmentCount)
+			assert.Equal(t, testCase.expectedCommentCount, workItem.CommentCount)
+		})
+	}
+}
+
+func TestDeleteWorkItem(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description  string
+		destroy      bool
+		err          error
+		statusCode   int
+		expectedPath string
+	}{
+		{
+			description:  "DeleteWorkItem: work item is moved to the recycle bin",
+			statusCode:   http.StatusOK,
+			expectedPath: "/mockOrganization/mockProjectName/_apis/wit/workitems/1?destroy=false&",
+		},
+		{
+			description:  "DeleteWorkItem: work item is destroyed",
+			destroy:      true,
+			statusCode:   http.StatusNoContent,
+			expectedPath: "/mockOrganization/mockProjectName/_apis/wit/workitems/1?destroy=true&",
+		},
+		{
+			description:  "DeleteWorkItem: with error",
+			err:          errors.New("error deleting the work item"),
+			statusCode:   http.StatusForbidden,
+			expectedPath: "/mockOrganization/mockProjectName/_apis/wit/workitems/1?destroy=false&",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, http.MethodDelete, method)
+				assert.Contains(t, path, testCase.expectedPath)
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			statusCode, err := p.Client.DeleteWorkItem(testutils.MockOrganization, testutils.MockProjectName, 1, testCase.destroy, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetRecycleBin(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description       string
+		references        string
+		err               error
+		statusCode        int
+		expectedWorkItems []*serializers.DeletedWorkItem
+	}{
+		{
+			description:       "GetRecycleBin: details of the deleted work items are fetched",
+			references:        `{"count": 2, "value": [{"id": 1}, {"id": 2}]}`,
+			statusCode:        http.StatusOK,
+			expectedWorkItems: []*serializers.DeletedWorkItem{{ID: 1, Name: "mockTitle", Type: "Bug", DeletedBy: "mockUser"}},
+		},
+		{
+			description:       "GetRecycleBin: recycle bin is empty",
+			references:        `{"count": 0, "value": []}`,
+			statusCode:        http.StatusOK,
+			expectedWorkItems: []*serializers.DeletedWorkItem{},
+		},
+		{
+			description: "GetRecycleBin: with error",
+			err:         errors.New("error getting the recycle bin"),
+			statusCode:  http.StatusForbidden,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				if testCase.err != nil {
+					return nil, testCase.statusCode, testCase.err
+				}
+
+				if strings.Contains(path, "ids=") {
+					assert.Contains(t, path, "/mockOrganization/mockProjectName/_apis/wit/recyclebin?ids=1,2&")
+					require.NoError(t, json.Unmarshal([]byte(`{"count": 1, "value": [{"id": 1, "name": "mockTitle", "type": "Bug", "deletedBy": "mockUser"}]}`), out))
+				} else {
+					require.NoError(t, json.Unmarshal([]byte(testCase.references), out))
+				}
+				return nil, testCase.statusCode, nil
+			})
+
+			workItems, statusCode, err := p.Client.GetRecycleBin(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.Equal(t, testCase.expectedWorkItems, workItems)
+		})
+	}
+}
+
+func TestRestoreWorkItem(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "RestoreWorkItem: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "RestoreWorkItem: work item is not in the recycle bin",
+			err:         errors.New("work item not found"),
+			statusCode:  http.StatusNotFound,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, http.MethodPatch, method)
+				assert.Contains(t, path, "/mockOrganization/mockProjectName/_apis/wit/recyclebin/1?")
+
+				body, err := io.ReadAll(inBody)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"isDeleted": false}`, string(body))
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			statusCode, err := p.Client.RestoreWorkItem(testutils.MockOrganization, testutils.MockProjectName, 1, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetGitRepository(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetGitRepository: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetGitRepository: with error",
+			err:         errors.New("error getting the repository"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetGitRepository(testutils.MockOrganization, testutils.MockProjectID, "mockRepositoryID", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetWorkItems(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description         string
+		queryResponse       string
+		queryErr            error
+		batchResponse       string
+		batchErr            error
+		expectedWorkItemIDs []int
+		expectedStatusCode  int
+	}{
+		{
+			description:         "GetWorkItems: work items are returned in the order of the query",
+			queryResponse:       `{"workItems": [{"id": 3}, {"id": 1}, {"id": 2}]}`,
+			batchResponse:       `{"count": 3, "value": [{"id": 1}, {"id": 2}, {"id": 3}]}`,
+			expectedWorkItemIDs: []int{3, 1, 2},
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			description:         "GetWorkItems: no work item matches the query",
+			queryResponse:       `{"workItems": []}`,
+			expectedWorkItemIDs: []int{},
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			description:        "GetWorkItems: error in querying the work items",
+			queryErr:           errors.New("error querying the work items"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "GetWorkItems: error in fetching the work items",
+			queryResponse:      `{"workItems": [{"id": 1}]}`,
+			batchErr:           errors.New("error fetching the work items"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				if strings.Contains(path, "/_apis/wit/wiql") {
+					if testCase.queryErr != nil {
+						return nil, http.StatusBadRequest, testCase.queryErr
+					}
+					return nil, http.StatusOK, json.Unmarshal([]byte(testCase.queryResponse), out)
+				}
+
+				if testCase.batchErr != nil {
+					return nil, http.StatusInternalServerError, testCase.batchErr
+				}
+				return nil, http.StatusOK, json.Unmarshal([]byte(testCase.batchResponse), out)
+			})
+
+			workItems, statusCode, err := p.Client.GetWorkItems(testutils.MockOrganization, testutils.MockProjectName, serializers.WorkItemQuery{}, testutils.MockMattermostUserID)
+
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.queryErr != nil || testCase.batchErr != nil {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			workItemIDs := []int{}
+			for _, workItem := range workItems {
+				workItemIDs = append(workItemIDs, workItem.ID)
+			}
+			assert.Equal(t, testCase.expectedWorkItemIDs, workItemIDs)
+		})
+	}
+}
+
+func TestGetProjectWorkItemsQuery(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		query         serializers.WorkItemQuery
+		expectedQuery string
+	}{
+		{
+			description:   "GetProjectWorkItemsQuery: no filter",
+			expectedQuery: "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project ORDER BY [System.ChangedDate] DESC",
+		},
+		{
+			description:   "GetProjectWorkItemsQuery: state and assignee filters",
+			query:         serializers.WorkItemQuery{State: "Active", AssignedTo: "O'Brien"},
+			expectedQuery: "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.State] = 'Active' AND [System.AssignedTo] = 'O''Brien' ORDER BY [System.ChangedDate] DESC",
+		},
+		{
+			description:   "GetProjectWorkItemsQuery: work items assigned to the user",
+			query:         serializers.WorkItemQuery{AssignedTo: "Me"},
+			expectedQuery: "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.AssignedTo] = @Me ORDER BY [System.ChangedDate] DESC",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedQuery, getProjectWorkItemsQuery(testCase.query))
+		})
+	}
+}
+
+func TestGetWorkItemsBatch(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetWorkItemsBatch: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetWorkItemsBatch: with error",
+			err:         errors.New("error getting the work items"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, []int{1, 2}, []string{"System.State"}, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestSearchWorkItems(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	p.setConfiguration(&config.Configuration{})
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "SearchWorkItems: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "SearchWorkItems: with error",
+			err:         errors.New("error searching the work items"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, "https://almsearch.dev.azure.com", basePath)
+				body, _ := io.ReadAll(inBody)
+				assert.JSONEq(t, `{"searchText": "login", "$skip": 0, "$top": 5, "includeFacets": false}`, string(body))
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			searchResults, statusCode, err := p.Client.SearchWorkItems(testutils.MockOrganization, testutils.MockProjectName, "login", 5, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []*serializers.WorkItemSearchResult{}, searchResults)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetCurrentIteration(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetCurrentIteration: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetCurrentIteration: with error",
+			err:         errors.New("error getting the current iteration"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetCurrentIteration(testutils.MockOrganization, testutils.MockProjectName, "mock team", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetQueries(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetQueries: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetQueries: with error",
+			err:         errors.New("error getting the queries"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Active Bugs", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestRunSharedQuery(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "RunSharedQuery: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "RunSharedQuery: with error",
+			err:         errors.New("error running the query"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.RunSharedQuery(testutils.MockOrganization, testutils.MockProjectName, "mockQueryID", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetReleaseDetails(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description          string
+		err                  error
+		statusCode           int
+		expectedErrorMessage string
+	}{
+		{
+			description: "GetReleaseDetails: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description:          "GetReleaseDetails: with error",
+			err:                  errors.New("failed to get release details"),
+			statusCode:           http.StatusInternalServerError,
+			expectedErrorMessage: "failed to get the pipeline release details: failed to get release details",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetReleaseDetails(testutils.MockOrganization, testutils.MockProjectName, "mockReleaseID", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetPullRequest(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetPullRequest: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetPullRequest: with error",
+			err:         errors.New("error getting the pull request"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetPullRequest(testutils.MockOrganization, "mockPullRequestID", testutils.MockProjectName, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetPullRequestsByCreator(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetPullRequestsByCreator: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetPullRequestsByCreator: with error",
+			err:         errors.New("error getting the pull requests"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Contains(t, path, "searchCriteria.creatorId=mockAzureDevopsUserID")
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetPullRequestsByCreator(testutils.MockOrganization, testutils.MockProjectName, "mockAzureDevopsUserID", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetPullRequestWorkItems(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetPullRequestWorkItems: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetPullRequestWorkItems: with error",
+			err:         errors.New("error getting the work items of the pull request"),
+			statusCode:  http.StatusNotFound,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Contains(t, path, "/_apis/git/repositories/mockRepositoryID/pullRequests/1/workitems")
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetPullRequestWorkItems(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", 1, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetProjectPullRequests(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetProjectPullRequests: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetProjectPullRequests: with error",
+			err:         errors.New("error getting the pull requests of the project"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Contains(t, path, "/_apis/git/pullrequests?searchCriteria.status=all")
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetProjectPullRequests(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetPullRequests(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description  string
+		repositoryID string
+		expectedPath string
+		err          error
+		statusCode   int
+	}{
+		{
+			description:  "GetPullRequests: pull requests of all the repositories",
+			expectedPath: "/mockOrganization/mockProjectName/_apis/git/pullrequests?searchCriteria.status=active",
+			statusCode:   http.StatusOK,
+		},
+		{
+			description:  "GetPullRequests: pull requests of a repository",
+			repositoryID: "mockRepositoryID",
+			expectedPath: "/mockOrganization/mockProjectName/_apis/git/repositories/mockRepositoryID/pullrequests?searchCriteria.status=active",
+			statusCode:   http.StatusOK,
+		},
+		{
+			description:  "GetPullRequests: with error",
+			expectedPath: "/_apis/git/pullrequests?",
+			err:          errors.New("error getting the pull requests"),
+			statusCode:   http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Contains(t, path, testCase.expectedPath)
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetPullRequests(testutils.MockOrganization, testutils.MockProjectName, testCase.repositoryID, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetGitRepositories(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetGitRepositories: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetGitRepositories: with error",
+			err:         errors.New("error getting the repositories"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Contains(t, path, "/_apis/git/repositories?")
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetGitRepositories(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetPushes(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "GetPushes: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "GetPushes: with error",
+			err:         errors.New("error getting the pushes"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Contains(t, path, "/_apis/git/repositories/mockRepositoryID/pushes?searchCriteria.fromDate=2026-10-15T10%3A00%3A00Z")
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetPushes(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC), testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestGetBuildDetails(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description          string
+		err                  error
+		statusCode           int
+		expectedErrorMessage string
+	}{
+		{
+			description: "GetBuildDetails: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description:          "GetBuildDetails: with error",
+			err:                  errors.New("failed to get build details"),
+			statusCode:           http.StatusInternalServerError,
+			expectedErrorMessage: "failed to get the pipeline build details: failed to get build details",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.GetBuildDetails(testutils.MockOrganization, testutils.MockProjectName, "mockBuildID", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestQueueBuild(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description          string
+		err                  error
+		statusCode           int
+		expectedErrorMessage string
+	}{
+		{
+			description: "QueueBuild: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description:          "QueueBuild: user is not allowed to queue builds",
+			err:                  errors.New("forbidden"),
+			statusCode:           http.StatusForbidden,
+			expectedErrorMessage: "failed to queue the build: forbidden",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var requestBody map[string]interface{}
+			var requestPath, requestMethod string
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				requestPath, requestMethod = path, method
+				_ = json.NewDecoder(inBody).Decode(&requestBody)
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.QueueBuild(testutils.MockOrganization, testutils.MockProjectName, 7, "refs/heads/main", testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.Equal(t, http.MethodPost, requestMethod)
+			assert.Equal(t, fmt.Sprintf(constants.QueueBuild, testutils.MockOrganization, testutils.MockProjectName), requestPath)
+			assert.Equal(t, map[string]interface{}{"definition": map[string]interface{}{"id": float64(7)}, "sourceBranch": "refs/heads/main"}, requestBody)
+		})
+	}
+}
+
+func TestLink(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "Link: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "Link: with error",
+			err:         errors.New("error linking the project"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.Link(&serializers.LinkRequestPayload{}, testutils.MockMattermostUserID)
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestCreateSubscription(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	p.setConfiguration(&config.Configuration{WebhookPathPrefix: "mock/hooks"})
+	for _, testCase := range []struct {
+		description string
+		err         error
+		statusCode  int
+	}{
+		{
+			description: "CreateSubscription: valid",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "CreateSubscription: with error",
+			err:         errors.New("error creating subscription"),
+			statusCode:  http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				payload, _ := io.ReadAll(inBody)
+				assert.Contains(t, string(payload), "mockPluginURL/mock/hooks/notification?webhookSecret=mockUUID")
+				return nil, testCase.statusCode, testCase.err
+			})
+
+			_, statusCode, err := p.Client.CreateSubscription(&serializers.CreateSubscriptionRequestPayload{}, &serializers.ProjectDetails{}, testutils.MockChannelID, "mockPluginURL", testutils.MockMattermostUserID, "mockUUID")
+
+			if testCase.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
 			assert.Equal(t, testCase.statusCode, statusCode)
 		})
 	}
 }
 
-func TestGetPullRequest(t *testing.T) {
+func TestCreateSubscriptionResourceVersion(t *testing.T) {
 	defer monkey.UnpatchAll()
-	mockAPI := &plugintest.API{}
-	p := setupTestPlugin(mockAPI)
+	p := setupTestPlugin(&plugintest.API{})
+	p.setConfiguration(&config.Configuration{})
 	for _, testCase := range []struct {
-		description string
-		err         error
-		statusCode  int
+		description             string
+		body                    *serializers.CreateSubscriptionRequestPayload
+		expectedResourceVersion string
+		expectedMessageFormat   string
 	}{
 		{
-			description: "GetPullRequest: valid",
-			statusCode:  http.StatusOK,
+			description:             "CreateSubscriptionResourceVersion: default version and format",
+			body:                    &serializers.CreateSubscriptionRequestPayload{EventType: constants.SubscriptionEventWorkItemCreated},
+			expectedResourceVersion: "1.0",
+			expectedMessageFormat:   constants.SubscriptionMessageFormatMarkdown,
 		},
 		{
-			description: "GetPullRequest: with error",
-			err:         errors.New("error getting the pull request"),
-			statusCode:  http.StatusInternalServerError,
+			description:             "CreateSubscriptionResourceVersion: non-default version and format",
+			body:                    &serializers.CreateSubscriptionRequestPayload{EventType: constants.SubscriptionEventWorkItemCreated, ResourceVersion: "1.0-preview.1", MessageFormat: "HTML"},
+			expectedResourceVersion: "1.0-preview.1",
+			expectedMessageFormat:   constants.SubscriptionMessageFormatHTML,
+		},
+		{
+			description:           "CreateSubscriptionResourceVersion: version of an unknown event type is left to Azure DevOps",
+			body:                  &serializers.CreateSubscriptionRequestPayload{EventType: testutils.MockEventType},
+			expectedMessageFormat: constants.SubscriptionMessageFormatMarkdown,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
+			var payload *serializers.CreateSubscriptionBodyPayload
 			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
-				return nil, testCase.statusCode, testCase.err
+				require.NoError(t, json.NewDecoder(inBody).Decode(&payload))
+				return nil, http.StatusOK, nil
 			})
 
-			_, statusCode, err := p.Client.GetPullRequest(testutils.MockOrganization, "mockPullRequestID", testutils.MockProjectName, testutils.MockMattermostUserID)
-
-			if testCase.err != nil {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			_, _, err := p.Client.CreateSubscription(testCase.body, &serializers.ProjectDetails{}, testutils.MockChannelID, "mockPluginURL", testutils.MockMattermostUserID, "mockUUID")
 
-			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.NoError(t, err)
+			require.NotNil(t, payload)
+			assert.Equal(t, testCase.expectedResourceVersion, payload.ResourceVersion)
+			assert.Equal(t, testCase.expectedMessageFormat, payload.ConsumerInputs.MessagesToSend)
+			assert.Equal(t, testCase.expectedMessageFormat, payload.ConsumerInputs.DetailedMessagesToSend)
 		})
 	}
 }
 
-func TestGetBuildDetails(t *testing.T) {
+func TestCreateSubscriptionWorkItemFilters(t *testing.T) {
+	defer monkey.UnpatchAll()
+	p := setupTestPlugin(&plugintest.API{})
+	p.setConfiguration(&config.Configuration{})
+
+	var payload *serializers.CreateSubscriptionBodyPayload
+	monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+		require.NoError(t, json.NewDecoder(inBody).Decode(&payload))
+		return nil, http.StatusOK, nil
+	})
+
+	_, _, err := p.Client.CreateSubscription(&serializers.CreateSubscriptionRequestPayload{
+		EventType:    constants.SubscriptionEventWorkItemCreated,
+		AreaPath:     `\mockProject\Billing\`,
+		WorkItemType: "Bug",
+	}, &serializers.ProjectDetails{ProjectID: testutils.MockProjectID}, testutils.MockChannelID, "mockPluginURL", testutils.MockMattermostUserID, "mockUUID")
+
+	assert.NoError(t, err)
+	require.NotNil(t, payload)
+	assert.Equal(t, map[string]interface{}{"projectId": testutils.MockProjectID, "areaPath": `\mockProject\Billing\`, "workItemType": "Bug"}, payload.PublisherInputs)
+}
+
+func TestDeleteSubscription(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	p := setupTestPlugin(mockAPI)
 	for _, testCase := range []struct {
-		description          string
-		err                  error
-		statusCode           int
-		expectedErrorMessage string
+		description string
+		err         error
+		statusCode  int
 	}{
 		{
-			description: "GetBuildDetails: valid",
+			description: "DeleteSubscription: valid",
 			statusCode:  http.StatusOK,
 		},
 		{
-			description:          "GetBuildDetails: with error",
-			err:                  errors.New("failed to get build details"),
-			statusCode:           http.StatusInternalServerError,
-			expectedErrorMessage: "failed to get the pipeline build details: failed to get build details",
+			description: "DeleteSubscription: with error",
+			err:         errors.New("error deleting the subscription"),
+			statusCode:  http.StatusInternalServerError,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
@@ -237,10 +1762,10 @@ func TestGetBuildDetails(t *testing.T) {
 				return nil, testCase.statusCode, testCase.err
 			})
 
-			_, statusCode, err := p.Client.GetBuildDetails(testutils.MockOrganization, testutils.MockProjectName, "mockBuildID", testutils.MockMattermostUserID)
+			statusCode, err := p.Client.DeleteSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, testutils.MockMattermostUserID)
 
 			if testCase.err != nil {
-				assert.EqualError(t, err, testCase.expectedErrorMessage)
+				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -250,7 +1775,7 @@ func TestGetBuildDetails(t *testing.T) {
 	}
 }
 
-func TestLink(t *testing.T) {
+func TestListSubscriptions(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	p := setupTestPlugin(mockAPI)
@@ -260,21 +1785,22 @@ func TestLink(t *testing.T) {
 		statusCode  int
 	}{
 		{
-			description: "Link: valid",
+			description: "ListSubscriptions: valid",
 			statusCode:  http.StatusOK,
 		},
 		{
-			description: "Link: with error",
-			err:         errors.New("error linking the project"),
+			description: "ListSubscriptions: with error",
+			err:         errors.New("error listing the subscriptions"),
 			statusCode:  http.StatusInternalServerError,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, "/mockOrganization/_apis/hooks/subscriptions?consumerId=webHooks&api-version=6.0", path)
 				return nil, testCase.statusCode, testCase.err
 			})
 
-			_, statusCode, err := p.Client.Link(&serializers.LinkRequestPayload{}, testutils.MockMattermostUserID)
+			_, statusCode, err := p.Client.ListSubscriptions(testutils.MockOrganization, testutils.MockMattermostUserID)
 
 			if testCase.err != nil {
 				assert.Error(t, err)
@@ -287,7 +1813,7 @@ func TestLink(t *testing.T) {
 	}
 }
 
-func TestCreateSubscription(t *testing.T) {
+func TestGetSubscription(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	p := setupTestPlugin(mockAPI)
@@ -297,21 +1823,23 @@ func TestCreateSubscription(t *testing.T) {
 		statusCode  int
 	}{
 		{
-			description: "CreateSubscription: valid",
+			description: "GetSubscription: valid",
 			statusCode:  http.StatusOK,
 		},
 		{
-			description: "CreateSubscription: with error",
-			err:         errors.New("error creating subscription"),
-			statusCode:  http.StatusInternalServerError,
+			description: "GetSubscription: subscription is deleted",
+			err:         errors.New("error getting the subscription"),
+			statusCode:  http.StatusNotFound,
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
+				assert.Equal(t, http.MethodGet, method)
+				assert.Equal(t, "/mockOrganization/_apis/hooks/subscriptions/mockSubscriptionID?api-version=6.0", path)
 				return nil, testCase.statusCode, testCase.err
 			})
 
-			_, statusCode, err := p.Client.CreateSubscription(&serializers.CreateSubscriptionRequestPayload{}, &serializers.ProjectDetails{}, testutils.MockChannelID, "mockPluginURL", testutils.MockMattermostUserID, "mockUUID")
+			_, statusCode, err := p.Client.GetSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, testutils.MockMattermostUserID)
 
 			if testCase.err != nil {
 				assert.Error(t, err)
@@ -324,39 +1852,69 @@ func TestCreateSubscription(t *testing.T) {
 	}
 }
 
-func TestDeleteSubscription(t *testing.T) {
+func TestUpdateSubscriptionWebhookURL(t *testing.T) {
 	defer monkey.UnpatchAll()
 	mockAPI := &plugintest.API{}
 	p := setupTestPlugin(mockAPI)
 	for _, testCase := range []struct {
-		description string
-		err         error
-		statusCode  int
+		description         string
+		subscription        string
+		getErr              error
+		updateErr           error
+		expectedPreviousURL string
+		expectedErr         string
 	}{
 		{
-			description: "DeleteSubscription: valid",
-			statusCode:  http.StatusOK,
+			description:         "UpdateSubscriptionWebhookURL: webhook URL is replaced and the other fields are kept",
+			subscription:        `{"id": "mockSubscriptionID", "eventType": "workitem.created", "consumerInputs": {"url": "mockPreviousURL", "messagesToSend": "text"}}`,
+			expectedPreviousURL: "mockPreviousURL",
 		},
 		{
-			description: "DeleteSubscription: with error",
-			err:         errors.New("error deleting the subscription"),
-			statusCode:  http.StatusInternalServerError,
+			description:  "UpdateSubscriptionWebhookURL: subscription can't be fetched",
+			subscription: `{}`,
+			getErr:       errors.New("mockError"),
+			expectedErr:  "failed to get subscription: mockError",
+		},
+		{
+			description:  "UpdateSubscriptionWebhookURL: subscription without a webhook",
+			subscription: `{"id": "mockSubscriptionID"}`,
+			expectedErr:  "subscription has no webhook URL",
+		},
+		{
+			description:  "UpdateSubscriptionWebhookURL: subscription can't be updated",
+			subscription: `{"id": "mockSubscriptionID", "consumerInputs": {"url": "mockPreviousURL"}}`,
+			updateErr:    errors.New("mockError"),
+			expectedErr:  "failed to update subscription: mockError",
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
+			var updatedSubscription map[string]interface{}
 			monkey.PatchInstanceMethod(reflect.TypeOf(&client{}), "Call", func(_ *client, basePath, method, path, contentType, mattermostUserID string, inBody io.Reader, out interface{}, formValues url.Values) (responseData []byte, statusCode int, err error) {
-				return nil, testCase.statusCode, testCase.err
+				assert.Equal(t, "/mockOrganization/_apis/hooks/subscriptions/mockSubscriptionID?api-version=6.0", path)
+				if method == http.MethodGet {
+					require.NoError(t, json.Unmarshal([]byte(testCase.subscription), out))
+					return nil, http.StatusOK, testCase.getErr
+				}
+
+				assert.Equal(t, http.MethodPut, method)
+				require.NoError(t, json.NewDecoder(inBody).Decode(&updatedSubscription))
+				return nil, http.StatusOK, testCase.updateErr
 			})
 
-			statusCode, err := p.Client.DeleteSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, testutils.MockMattermostUserID)
+			previousURL, _, err := p.Client.UpdateSubscriptionWebhookURL(testutils.MockOrganization, testutils.MockSubscriptionID, "mockWebhookURL", testutils.MockMattermostUserID)
 
-			if testCase.err != nil {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
 			}
 
-			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedPreviousURL, previousURL)
+			assert.Equal(t, map[string]interface{}{
+				"id":             "mockSubscriptionID",
+				"eventType":      "workitem.created",
+				"consumerInputs": map[string]interface{}{"url": "mockWebhookURL", "messagesToSend": "text"},
+			}, updatedSubscription)
 		})
 	}
 }
@@ -409,7 +1967,7 @@ func TestCall(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
-			monkey.PatchInstanceMethod(reflect.TypeOf(p), "AddAuthorization", func(_ *Plugin, _ *http.Request, _ string) error {
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "AddAuthorization", func(_ *Plugin, _ *http.Request, _, _ string) error {
 				return nil
 			})
 			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsAccessTokenExpired", func(_ *Plugin, _ string) (bool, string) {
@@ -421,6 +1979,7 @@ func TestCall(t *testing.T) {
 			client := &client{
 				plugin:     p,
 				httpClient: &http.Client{},
+				limiter:    newRequestLimiter(),
 			}
 
 			_, _, err := client.Call("mockBasePath", "mockMethod", "mockPath", "mockContentType", testutils.MockMattermostUserID, nil, nil, url.Values{})
@@ -429,6 +1988,113 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestCallWithRejectedAccessToken(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description             string
+		responseStatusCodes     []int
+		isRefreshedConcurrently bool
+		refreshErr              error
+		expectedStatusCode      int
+		expectedRefreshCount    int
+		expectedAuthorizations  []string
+	}{
+		{
+			description:            "Call: request is sent again after refreshing the rejected token",
+			responseStatusCodes:    []int{http.StatusUnauthorized, http.StatusOK},
+			expectedStatusCode:     http.StatusOK,
+			expectedRefreshCount:   1,
+			expectedAuthorizations: []string{"Bearer mockToken0", "Bearer mockToken1"},
+		},
+		{
+			description:             "Call: request is sent again with the token refreshed by a concurrent request",
+			responseStatusCodes:     []int{http.StatusUnauthorized, http.StatusOK},
+			isRefreshedConcurrently: true,
+			expectedStatusCode:      http.StatusOK,
+			expectedAuthorizations:  []string{"Bearer mockToken0", "Bearer mockToken1"},
+		},
+		{
+			description:            "Call: rejection is returned if the token can't be refreshed",
+			responseStatusCodes:    []int{http.StatusUnauthorized},
+			refreshErr:             errors.New("error refreshing the token"),
+			expectedStatusCode:     http.StatusUnauthorized,
+			expectedRefreshCount:   1,
+			expectedAuthorizations: []string{"Bearer mockToken0"},
+		},
+		{
+			description:            "Call: request is sent again only once",
+			responseStatusCodes:    []int{http.StatusUnauthorized, http.StatusUnauthorized},
+			expectedStatusCode:     http.StatusUnauthorized,
+			expectedRefreshCount:   1,
+			expectedAuthorizations: []string{"Bearer mockToken0", "Bearer mockToken1"},
+		},
+		{
+			description:            "Call: accepted request is not sent again",
+			responseStatusCodes:    []int{http.StatusOK},
+			expectedStatusCode:     http.StatusOK,
+			expectedAuthorizations: []string{"Bearer mockToken0"},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Maybe()
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupTestPlugin(mockAPI)
+			p.Store = mockedStore
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil).AnyTimes()
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{MattermostUserID: testutils.MockMattermostUserID, RefreshToken: "mockRefreshToken"}, nil).AnyTimes()
+
+			var authorizations, requestBodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				authorizations = append(authorizations, req.Header.Get(constants.Authorization))
+				body, _ := io.ReadAll(req.Body)
+				requestBodies = append(requestBodies, string(body))
+				rw.WriteHeader(testCase.responseStatusCodes[len(authorizations)-1])
+				_, _ = rw.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			tokenVersion, authorizationCount, refreshCount := 0, 0, 0
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsAccessTokenExpired", func(_ *Plugin, _ string) (bool, string) {
+				return false, ""
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "AddAuthorization", func(_ *Plugin, req *http.Request, _, _ string) error {
+				authorizationCount++
+				if testCase.isRefreshedConcurrently && authorizationCount > 1 {
+					tokenVersion = 1
+				}
+				req.Header.Add(constants.Authorization, fmt.Sprintf("%s mockToken%d", constants.Bearer, tokenVersion))
+				return nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "RefreshAccessToken", func(_ *Plugin, user *serializers.User) error {
+				assert.Equal(t, "mockRefreshToken", user.RefreshToken)
+				refreshCount++
+				if testCase.refreshErr != nil {
+					return testCase.refreshErr
+				}
+				tokenVersion++
+				return nil
+			})
+
+			client := &client{
+				plugin:     p,
+				httpClient: server.Client(),
+				limiter:    newRequestLimiter(),
+			}
+
+			_, statusCode, _ := client.Call(server.URL, http.MethodPost, "/mockPath", "application/json", testutils.MockMattermostUserID, strings.NewReader(`{"mockKey": "mockValue"}`), nil, nil)
+
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			assert.Equal(t, testCase.expectedRefreshCount, refreshCount)
+			assert.Equal(t, testCase.expectedAuthorizations, authorizations)
+			for _, requestBody := range requestBodies {
+				assert.Equal(t, `{"mockKey": "mockValue"}`, requestBody)
+			}
+		})
+	}
+}
+
 func TestUpdatePipelineRunApprovalRequest(t *testing.T) {
 	defer monkey.UnpatchAll()
 	p := setupTestPlugin(&plugintest.API{})
@@ -501,7 +2167,7 @@ func TestGetRunApprovalDetails(t *testing.T) {
 	}
 }
 
-func TestUpdatePipelineApprovalRequest(t *testing.T) {
+func TestSetReleaseApproval(t *testing.T) {
 	defer monkey.UnpatchAll()
 	p := setupTestPlugin(&plugintest.API{})
 	for _, testCase := range []struct {
@@ -510,11 +2176,11 @@ func TestUpdatePipelineApprovalRequest(t *testing.T) {
 		statusCode  int
 	}{
 		{
-			description: "UpdatePipelineApprovalRequest: valid",
+			description: "SetReleaseApproval: valid",
 			statusCode:  http.StatusOK,
 		},
 		{
-			description: "UpdatePipelineApprovalRequest: with error",
+			description: "SetReleaseApproval: with error",
 			err:         errors.New("failed to update pipeline approval request"),
 			statusCode:  http.StatusInternalServerError,
 		},
@@ -524,7 +2190,7 @@ func TestUpdatePipelineApprovalRequest(t *testing.T) {
 				return nil, testCase.statusCode, testCase.err
 			})
 
-			statusCode, err := p.Client.UpdatePipelineApprovalRequest(&serializers.PipelineApproveRequest{}, testutils.MockOrganization, testutils.MockProjectID, testutils.MockMattermostUserID, 1234)
+			statusCode, err := p.Client.SetReleaseApproval(testutils.MockOrganization, testutils.MockProjectID, 1234, constants.PipelineRequestIDApproved, "mockComment", testutils.MockMattermostUserID)
 
 			if testCase.err != nil {
 				assert.EqualError(t, err, testCase.err.Error())
@@ -537,7 +2203,7 @@ func TestUpdatePipelineApprovalRequest(t *testing.T) {
 	}
 }
 
-func TestGetApprovalDetails(t *testing.T) {
+func TestGetReleaseApproval(t *testing.T) {
 	defer monkey.UnpatchAll()
 	p := setupTestPlugin(&plugintest.API{})
 	for _, testCase := range []struct {
@@ -546,11 +2212,11 @@ func TestGetApprovalDetails(t *testing.T) {
 		statusCode  int
 	}{
 		{
-			description: "GetApprovalDetails: valid",
+			description: "GetReleaseApproval: valid",
 			statusCode:  http.StatusOK,
 		},
 		{
-			description: "GetApprovalDetails: with error",
+			description: "GetReleaseApproval: with error",
 			err:         errors.New("failed to get approval details"),
 			statusCode:  http.StatusInternalServerError,
 		},
@@ -560,7 +2226,7 @@ func TestGetApprovalDetails(t *testing.T) {
 				return nil, testCase.statusCode, testCase.err
 			})
 
-			_, statusCode, err := p.Client.GetApprovalDetails(testutils.MockOrganization, testutils.MockProjectID, testutils.MockMattermostUserID, 1234)
+			_, statusCode, err := p.Client.GetReleaseApproval(testutils.MockOrganization, testutils.MockProjectID, 1234, testutils.MockMattermostUserID)
 
 			if testCase.err != nil {
 				assert.EqualError(t, err, testCase.err.Error())
@@ -619,6 +2285,260 @@ func TestGetSubscriptionFilterPossibleValues(t *testing.T) {
 	}
 }
 
+func TestCallWithRetries(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description         string
+		method              string
+		maxRetries          int
+		responseStatusCodes []int
+		isServerClosed      bool
+		expectedStatusCode  int
+		expectedAttempts    int
+	}{
+		{
+			description:         "Call: throttled GET request is sent again",
+			method:              http.MethodGet,
+			maxRetries:          3,
+			responseStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatusCode:  http.StatusOK,
+			expectedAttempts:    3,
+		},
+		{
+			description:         "Call: DELETE request is sent again up to the maximum retries",
+			method:              http.MethodDelete,
+			maxRetries:          2,
+			responseStatusCodes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedAttempts:    3,
+		},
+		{
+			description:         "Call: request is not sent again without retries",
+			method:              http.MethodGet,
+			responseStatusCodes: []int{http.StatusTooManyRequests},
+			expectedStatusCode:  http.StatusTooManyRequests,
+			expectedAttempts:    1,
+		},
+		{
+			description:         "Call: POST request is not sent again after an error response",
+			method:              http.MethodPost,
+			maxRetries:          3,
+			responseStatusCodes: []int{http.StatusServiceUnavailable},
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedAttempts:    1,
+		},
+		{
+			description:         "Call: GET request failing for another reason is not sent again",
+			method:              http.MethodGet,
+			maxRetries:          3,
+			responseStatusCodes: []int{http.StatusBadRequest},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedAttempts:    1,
+		},
+		{
+			description:        "Call: POST request is sent again when Azure DevOps can't be reached",
+			method:             http.MethodPost,
+			maxRetries:         2,
+			isServerClosed:     true,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p := setupTestPlugin(mockAPI)
+			p.setConfiguration(&config.Configuration{MaxRequestRetries: testCase.maxRetries})
+
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				attempts++
+				rw.WriteHeader(testCase.responseStatusCodes[attempts-1])
+				_, _ = rw.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+			if testCase.isServerClosed {
+				server.Close()
+			}
+
+			retries := 0
+			logWarnArgs := make([]interface{}, 13)
+			for i := range logWarnArgs {
+				logWarnArgs[i] = mock.Anything
+			}
+			mockAPI.On("LogWarn", logWarnArgs...).Run(func(_ mock.Arguments) {
+				retries++
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "IsAccessTokenExpired", func(_ *Plugin, _ string) (bool, string) {
+				return false, ""
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "AddAuthorization", func(_ *Plugin, req *http.Request, _, _ string) error {
+				return nil
+			})
+			monkey.Patch(getRequestRetryBackoff, func(_ int, _ error) time.Duration {
+				return 0
+			})
+
+			client := &client{
+				plugin:     p,
+				httpClient: server.Client(),
+				limiter:    newRequestLimiter(),
+			}
+
+			_, statusCode, _ := client.Call(server.URL, testCase.method, "/mockPath", "application/json", testutils.MockMattermostUserID, nil, nil, nil)
+
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.isServerClosed {
+				assert.Equal(t, testCase.maxRetries, retries)
+				return
+			}
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+			assert.Equal(t, testCase.expectedAttempts-1, retries)
+		})
+	}
+}
+
+func TestSendWithRetriesStopsWaitingWhenContextIsDone(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	p.setConfiguration(&config.Configuration{MaxRequestRetries: 3})
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logWarnArgs := make([]interface{}, 13)
+	for i := range logWarnArgs {
+		logWarnArgs[i] = mock.Anything
+	}
+	mockAPI.On("LogWarn", logWarnArgs...)
+	monkey.Patch(getRequestRetryBackoff, func(_ int, _ error) time.Duration {
+		return time.Hour
+	})
+
+	client := &client{
+		plugin:     p,
+		httpClient: server.Client(),
+		limiter:    newRequestLimiter(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _, statusCode, err := client.sendWithRetries(ctx, http.MethodGet, server.URL, "", "", nil, nil, "", nil, constants.MaxBytesSizeForReadingResponseBody)
+
+	require.NotNil(t, req)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestIsRetryableRequest(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "mockURL", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	resetErr := &url.Error{Op: "Post", URL: "mockURL", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}
+	eofErr := &url.Error{Op: "Post", URL: "mockURL", Err: io.EOF}
+	for _, testCase := range []struct {
+		description string
+		method      string
+		statusCode  int
+		err         error
+		expected    bool
+	}{
+		{description: "IsRetryableRequest: successful request", method: http.MethodGet, statusCode: http.StatusOK},
+		{description: "IsRetryableRequest: throttled GET request", method: http.MethodGet, statusCode: http.StatusTooManyRequests, err: &serializers.AzureError{}, expected: true},
+		{description: "IsRetryableRequest: throttled POST request", method: http.MethodPost, statusCode: http.StatusTooManyRequests, err: &serializers.AzureError{}},
+		{description: "IsRetryableRequest: GET request whose connection was reset", method: http.MethodGet, statusCode: http.StatusInternalServerError, err: resetErr, expected: true},
+		{description: "IsRetryableRequest: POST request which could not connect", method: http.MethodPost, statusCode: http.StatusInternalServerError, err: dialErr, expected: true},
+		{description: "IsRetryableRequest: POST request whose connection was reset", method: http.MethodPost, statusCode: http.StatusInternalServerError, err: resetErr},
+		{description: "IsRetryableRequest: PATCH request whose connection was closed", method: http.MethodPatch, statusCode: http.StatusInternalServerError, err: eofErr},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, isRetryableRequest(testCase.method, testCase.statusCode, testCase.err))
+		})
+	}
+}
+
+func TestGetRequestRetryBackoff(t *testing.T) {
+	assert.Equal(t, constants.RequestRetryInitialBackoff, getRequestRetryBackoff(0, errors.New("mockError")))
+	assert.Equal(t, 4*constants.RequestRetryInitialBackoff, getRequestRetryBackoff(2, &serializers.AzureError{}))
+	assert.Equal(t, 5*time.Second, getRequestRetryBackoff(0, &serializers.AzureError{RetryAfter: 5 * time.Second}))
+	assert.Equal(t, constants.RequestRetryMaxBackoff, getRequestRetryBackoff(0, &serializers.AzureError{RetryAfter: time.Hour}))
+	assert.Equal(t, constants.RequestRetryMaxBackoff, getRequestRetryBackoff(10, nil))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 10*time.Second, parseRetryAfter("10", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-10", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter("Fri, 16 Oct 2026 12:01:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Fri, 16 Oct 2026 11:59:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("mockRetryAfter", now))
+}
+
+func TestOverrideAPIVersion(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		URL         string
+		apiVersion  string
+		expectedURL string
+	}{
+		{
+			description: "OverrideAPIVersion: API version is replaced",
+			URL:         "https://tfs.example.com/tfs/mockOrganization/_apis/projects?api-version=7.1-preview.4&$top=10",
+			apiVersion:  "6.0",
+			expectedURL: "https://tfs.example.com/tfs/mockOrganization/_apis/projects?api-version=6.0&$top=10",
+		},
+		{
+			description: "OverrideAPIVersion: URL is left as it is without an API version to override",
+			URL:         "https://dev.azure.com/mockOrganization/_apis/projects?api-version=7.1-preview.4",
+			expectedURL: "https://dev.azure.com/mockOrganization/_apis/projects?api-version=7.1-preview.4",
+		},
+		{
+			description: "OverrideAPIVersion: URL without an API version",
+			URL:         "https://tfs.example.com/tfs/_apis/profile/profiles/me",
+			apiVersion:  "6.0",
+			expectedURL: "https://tfs.example.com/tfs/_apis/profile/profiles/me",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedURL, overrideAPIVersion(testCase.URL, testCase.apiVersion))
+		})
+	}
+}
+
+func TestGetRequestOrganization(t *testing.T) {
+	p := setupTestPlugin(&plugintest.API{})
+	p.setConfiguration(&config.Configuration{})
+	c := &client{plugin: p}
+
+	assert.Equal(t, "mockOrganization", c.getRequestOrganization("https://dev.azure.com", "/mockOrganization/_apis/projects/mockProject"))
+	assert.Equal(t, "mockOrganization", c.getRequestOrganization("https://vsrm.dev.azure.com", "/mockOrganization/mockProject/_apis/release/releases/1"))
+	assert.Equal(t, "mockOrganization", c.getRequestOrganization("https://almsearch.dev.azure.com", "/mockOrganization/mockProject/_apis/search/workitemsearchresults"))
+	assert.Equal(t, "", c.getRequestOrganization("https://mattermost.example.com", "/plugins/azuredevops/api/v1/comment"))
+}
+
+func TestCallWithUnconnectedOrganization(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupTestPlugin(&plugintest.API{})
+	p.Store = mockedStore
+	p.setConfiguration(&config.Configuration{})
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+	mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{
+		MattermostUserID: testutils.MockMattermostUserID,
+		AccessToken:      "mockAccessToken",
+		Organization:     "mock-organization",
+	}, nil)
+
+	_, statusCode, err := p.Client.(*client).Call("https://dev.azure.com", http.MethodGet, "/other-organization/_apis/projects/mockProject", "application/json", testutils.MockMattermostUserID, nil, nil, nil)
+
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.EqualError(t, err, fmt.Sprintf(constants.ErrorOrganizationNotConnected, "other-organization", "other-organization"))
+}
+
 func TestMakeHTTPRequest(t *testing.T) {
 	mockAPI := &plugintest.API{}
 	p := setupTestPlugin(mockAPI)
@@ -650,6 +2570,7 @@ func TestMakeHTTPRequest(t *testing.T) {
 			client := &client{
 				plugin:     p,
 				httpClient: server.Client(),
+				limiter:    newRequestLimiter(),
 			}
 
 			req := httptest.NewRequest(http.MethodGet, server.URL, nil)
@@ -665,6 +2586,121 @@ func TestMakeHTTPRequest(t *testing.T) {
 	}
 }
 
+func TestMakeHTTPRequestAzureError(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	for _, testCase := range []struct {
+		description        string
+		statusCode         int
+		body               string
+		expectedErr        string
+		expectedAzureError *serializers.AzureError
+	}{
+		{
+			description: "MakeHTTPRequest: message and type of an Azure DevOps error",
+			statusCode:  http.StatusBadRequest,
+			body: `{
+				"$id": "1",
+				"innerException": null,
+				"message": "VS402323: Work item type Epic Story does not exist in project mockProjectName.",
+				"typeName": "Microsoft.TeamFoundation.WorkItemTracking.Server.WorkItemTypeNotFoundException, Microsoft.TeamFoundation.WorkItemTracking.Server",
+				"typeKey": "WorkItemTypeNotFoundException",
+				"errorCode": 0,
+				"eventId": 3200
+			}`,
+			expectedErr: "errorMessage VS402323: Work item type Epic Story does not exist in project mockProjectName. (WorkItemTypeNotFoundException)",
+			expectedAzureError: &serializers.AzureError{
+				Message: "VS402323: Work item type Epic Story does not exist in project mockProjectName.",
+				TypeKey: "WorkItemTypeNotFoundException",
+			},
+		},
+		{
+			description: "MakeHTTPRequest: error which is not JSON",
+			statusCode:  http.StatusBadGateway,
+			body:        "<html><body>Bad gateway</body></html>",
+			expectedErr: "errorMessage 502 Bad Gateway",
+		},
+		{
+			description: "MakeHTTPRequest: error without a message",
+			statusCode:  http.StatusForbidden,
+			body:        `{"count": 0}`,
+			expectedErr: "errorMessage 403 Forbidden",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(testCase.statusCode)
+				_, _ = rw.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			client := &client{
+				plugin:     p,
+				httpClient: server.Client(),
+				limiter:    newRequestLimiter(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, server.URL, nil)
+			req.RequestURI = ""
+			_, statusCode, err := client.MakeHTTPRequest(req, "", nil)
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.EqualError(t, err, testCase.expectedErr)
+			if testCase.expectedAzureError != nil {
+				var azureError *serializers.AzureError
+				require.True(t, errors.As(err, &azureError))
+				assert.Equal(t, testCase.expectedAzureError, azureError)
+			}
+		})
+	}
+}
+
+func TestMakeHTTPRequestConcurrencyLimit(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	p.setConfiguration(&config.Configuration{MaxConcurrentRequests: 3})
+
+	var activeRequests, maxActiveRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		active := atomic.AddInt32(&activeRequests, 1)
+		defer atomic.AddInt32(&activeRequests, -1)
+		for {
+			maxActive := atomic.LoadInt32(&maxActiveRequests)
+			if active <= maxActive || atomic.CompareAndSwapInt32(&maxActiveRequests, maxActive, active) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &client{
+		plugin:     p,
+		httpClient: server.Client(),
+		limiter:    newRequestLimiter(),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, server.URL, nil)
+			req.RequestURI = ""
+			_, statusCode, err := client.MakeHTTPRequest(req, "", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, statusCode)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxActiveRequests, int32(3))
+	assert.Greater(t, maxActiveRequests, int32(0))
+	assert.Zero(t, client.limiter.active)
+}
+
 func setupTestPlugin(api *plugintest.API) *Plugin {
 	p := Plugin{}
 	p.API = api
@@ -3,6 +3,8 @@ package plugin
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -14,6 +16,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/plugin"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 )
 
 type HandlerFunc func(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError)
@@ -25,13 +28,20 @@ type Handler struct {
 
 var azureDevopsCommandHandler = Handler{
 	handlers: map[string]HandlerFunc{
-		constants.CommandHelp:       azureDevopsHelpCommand,
-		constants.CommandConnect:    azureDevopsConnectCommand,
-		constants.CommandDisconnect: azureDevopsDisconnectCommand,
-		constants.CommandLink:       azureDevopsAccountConnectionCheck,
-		constants.CommandBoards:     azureDevopsBoardsCommand,
-		constants.CommandRepos:      azureDevopsReposCommand,
-		constants.CommandPipelines:  azureDevopsPipelinesCommand,
+		constants.CommandHelp:          azureDevopsHelpCommand,
+		constants.CommandConnect:       azureDevopsConnectCommand,
+		constants.CommandConnectDevice: azureDevopsConnectDeviceCommand,
+		constants.CommandDisconnect:    azureDevopsDisconnectCommand,
+		constants.CommandReset:         azureDevopsResetCommand,
+		constants.CommandLink:          azureDevopsLinkCommand,
+		constants.CommandProject:       azureDevopsProjectCommand,
+		constants.CommandProjects:      azureDevopsProjectsCommand,
+		constants.CommandBoards:        azureDevopsBoardsCommand,
+		constants.CommandRepos:         azureDevopsReposCommand,
+		constants.CommandPipelines:     azureDevopsPipelinesCommand,
+		constants.CommandSubscriptions: azureDevopsSubscriptionsCommand,
+		constants.CommandAdmin:         azureDevopsAdminCommand,
+		constants.CommandPermissions:   azureDevopsPermissionsCommand,
 	},
 	defaultHandler: executeDefault,
 }
@@ -39,7 +49,12 @@ var azureDevopsCommandHandler = Handler{
 // Handle function calls the respective handlers of the commands.
 // It checks whether any HandlerFunc is present for the given command by checking in the "azureDevopsCommandHandler".
 // If the command is present, it calls its handler function, else calls the default handler.
+// The permissions required to run the command are checked first.
 func (ch *Handler) Handle(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if message := p.checkCommandPermissions(commandArgs, args...); message != "" {
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
 	for arg := len(args); arg > 0; arg-- {
 		handler := ch.handlers[strings.Join(args[:arg], "/")]
 		if handler != nil {
@@ -55,19 +70,39 @@ func (p *Plugin) getAutoCompleteData() *model.AutocompleteData {
 	help := model.NewAutocompleteData(constants.CommandHelp, "", fmt.Sprintf("Show %s slash command help", constants.CommandTriggerName))
 	azureDevops.AddCommand(help)
 
-	connect := model.NewAutocompleteData(constants.CommandConnect, "", "Connect to your Azure DevOps account")
+	connect := model.NewAutocompleteData(constants.CommandConnect, "[organization]", "Connect to your Azure DevOps account, optionally for an organization")
 	azureDevops.AddCommand(connect)
 
-	disconnect := model.NewAutocompleteData(constants.CommandDisconnect, "", "Disconnect your Azure DevOps account")
+	connectDevice := model.NewAutocompleteData(constants.CommandConnectDevice, "", "Connect to your Azure DevOps account by entering a code on any device")
+	azureDevops.AddCommand(connectDevice)
+
+	disconnect := model.NewAutocompleteData(constants.CommandDisconnect, "", "Disconnect your Azure DevOps account, after confirming it")
 	azureDevops.AddCommand(disconnect)
 
+	reset := model.NewAutocompleteData(constants.CommandReset, "", "Delete all your subscriptions, linked projects and subscription templates and disconnect your account, after confirming it")
+	azureDevops.AddCommand(reset)
+
 	link := model.NewAutocompleteData(constants.CommandLink, "", "Link a project")
 	link.AddTextArgument("URL of the project to be linked", "[projectURL]", "")
 	azureDevops.AddCommand(link)
 
+	projects := model.NewAutocompleteData(constants.CommandProjects, "", "View your linked projects")
+	azureDevops.AddCommand(projects)
+
+	project := model.NewAutocompleteData(constants.CommandProject, "", "Manage your linked projects")
+	dedupe := model.NewAutocompleteData(constants.CommandDedupe, "", "Merge the projects you have linked more than once and move their subscriptions to the kept ones")
+	project.AddCommand(dedupe)
+	activity := model.NewAutocompleteData(constants.CommandActivity, "", "View the recent work item changes, pull requests and pushes of a linked project")
+	activity.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	activity.AddTextArgument("(Optional) Number of hours to look back, up to a week", "[--hours number]", "")
+	project.AddCommand(activity)
+	sync := model.NewAutocompleteData(constants.CommandSync, "", "Update the names of your linked projects which were renamed in Azure DevOps")
+	project.AddCommand(sync)
+	azureDevops.AddCommand(project)
+
 	subscription := model.NewAutocompleteData(constants.CommandSubscription, "", "Add/list/delete subscriptions")
 	subscriptionAdd := model.NewAutocompleteData(constants.CommandAdd, "", "Add a new subscription")
-	subscriptionList := model.NewAutocompleteData(constants.CommandList, "", "List subscriptions")
+	subscriptionList := model.NewAutocompleteData(constants.CommandList, "[me or anyone] [all_channels] [--oldest]", "List subscriptions")
 	subscriptionDelete := model.NewAutocompleteData(constants.CommandDelete, "", "Delete a subscription")
 	subscriptionDelete.AddTextArgument("ID of the subscription to be deleted", "[subscription id]", "")
 	subscriptionCreatedByMe := model.NewAutocompleteData(constants.FilterCreatedByMe, "", "Created By Me")
@@ -81,17 +116,55 @@ func (p *Plugin) getAutoCompleteData() *model.AutocompleteData {
 	subscription.AddCommand(subscriptionList)
 	subscription.AddCommand(subscriptionDelete)
 
-	boards := model.NewAutocompleteData(constants.CommandBoards, "", "Create a new work-item or add/list/delete board subscriptions")
+	boards := model.NewAutocompleteData(constants.CommandBoards, "", "Create, delete or restore a work-item, view the current sprint, run a saved or default query or add/list/delete board subscriptions")
 	workitem := model.NewAutocompleteData(constants.CommandWorkitem, "", "Create a new work-item")
 	create := model.NewAutocompleteData(constants.CommandCreate, "", "Create a new work-item")
 	create.AddTextArgument("Title", "[title]", "")
 	create.AddTextArgument("Description", "[description]", "")
 	workitem.AddCommand(create)
 	boards.AddCommand(workitem)
+	sprint := model.NewAutocompleteData(constants.CommandSprint, "", "View a summary of the current sprint of a team")
+	sprint.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	sprint.AddTextArgument("(Optional) Name of the team, the default team of the project is used if it's not provided", "[team]", "")
+	boards.AddCommand(sprint)
+	show := model.NewAutocompleteData(constants.CommandShow, "", "View the details of a work item along with its linked pull requests and branches")
+	show.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	show.AddTextArgument("ID of the work item", "[work item ID]", "")
+	boards.AddCommand(show)
+	blockers := model.NewAutocompleteData(constants.CommandBlockers, "", "View the work items blocking a work item through its predecessor links")
+	blockers.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	blockers.AddTextArgument("ID of the work item", "[work item ID]", "")
+	boards.AddCommand(blockers)
+	deleteWorkItem := model.NewAutocompleteData(constants.CommandDelete, "", "Delete a work item, it's moved to the recycle bin unless --destroy is set")
+	deleteWorkItem.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	deleteWorkItem.AddTextArgument("ID of the work item", "[work item ID]", "")
+	deleteWorkItem.AddTextArgument("(Optional) Delete the work item permanently instead of moving it to the recycle bin", "[--destroy]", "")
+	boards.AddCommand(deleteWorkItem)
+	restore := model.NewAutocompleteData(constants.CommandRestore, "", "Restore a deleted work item, the recently deleted work items are listed if the ID is not provided")
+	restore.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	restore.AddTextArgument("(Optional) ID of the work item", "[work item ID]", "")
+	boards.AddCommand(restore)
+	query := model.NewAutocompleteData(constants.CommandQuery, "", "View the work items returned by a saved query")
+	query.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	query.AddTextArgument("Name of the query or its path like \"Shared Queries/Team/Active Bugs\"", "[query name or path]", "")
+	query.AddTextArgument("(Optional) Page of the results to view", "[--page number]", "")
+	boards.AddCommand(query)
+	defaultQuery := model.NewAutocompleteData(constants.CommandDefaultQuery, "", "Run or set the default query of a linked project")
+	runDefaultQuery := model.NewAutocompleteData(constants.CommandRun, "", "View the work items returned by the default query, your active work items are listed if no query is set")
+	runDefaultQuery.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	defaultQuery.AddCommand(runDefaultQuery)
+	setDefaultQuery := model.NewAutocompleteData(constants.CommandSet, "", "Set the WIQL of the default query, it's run once to validate it")
+	setDefaultQuery.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	setDefaultQuery.AddTextArgument("WIQL of the query, or default to list your active work items", "[WIQL or default]", "")
+	defaultQuery.AddCommand(setDefaultQuery)
+	boards.AddCommand(defaultQuery)
 	boards.AddCommand(subscription)
 	azureDevops.AddCommand(boards)
 
-	repos := model.NewAutocompleteData(constants.CommandRepos, "", "Add/list/delete repo subscriptions")
+	repos := model.NewAutocompleteData(constants.CommandRepos, "", "View your pull requests or add/list/delete repo subscriptions")
+	myPRs := model.NewAutocompleteData(constants.CommandMyPRs, "", "View your open pull requests")
+	myPRs.AddTextArgument(fmt.Sprintf("Name of the linked project or organization/project, or %s for all the linked projects", constants.CommandAllFlag), "[project or --all]", "")
+	repos.AddCommand(myPRs)
 	repos.AddCommand(subscription)
 	azureDevops.AddCommand(repos)
 
@@ -99,6 +172,60 @@ func (p *Plugin) getAutoCompleteData() *model.AutocompleteData {
 	pipelines.AddCommand(subscription)
 	azureDevops.AddCommand(pipelines)
 
+	subscriptions := model.NewAutocompleteData(constants.CommandSubscriptions, "", "Manage subscriptions across Boards, Repos and Pipelines")
+	applyTemplate := model.NewAutocompleteData(constants.CommandApplyTemplate, "", "Create all the subscriptions of a subscription template for a linked project")
+	applyTemplate.AddTextArgument("Name of the subscription template", "[template name]", "")
+	applyTemplate.AddTextArgument("Name of the linked project or organization/project", "[project]", "")
+	subscriptions.AddCommand(applyTemplate)
+	deleteProject := model.NewAutocompleteData(constants.CommandDeleteProject, "", "Delete all your subscriptions of a project")
+	deleteProject.AddTextArgument("Name of the project or organization/project", "[project]", "")
+	deleteProject.AddTextArgument("(Optional) Only delete the subscriptions of a channel", "[--channel channel name]", "")
+	subscriptions.AddCommand(deleteProject)
+	move := model.NewAutocompleteData(constants.CommandMove, "", "Move your subscriptions of a channel to another channel")
+	move.AddTextArgument("Name of the channel the subscriptions are in", "[from channel name]", "")
+	move.AddTextArgument("Name of the channel the subscriptions are moved to", "[to channel name]", "")
+	subscriptions.AddCommand(move)
+	preferences := model.NewAutocompleteData(constants.CommandPreferences, "", "View the notification preferences of the current channel")
+	setPreference := model.NewAutocompleteData(constants.CommandSet, "", "Set a notification preference of the current channel for all of its subscriptions")
+	setPreference.AddStaticListArgument("Preference", true, []model.AutocompleteListItem{
+		{Item: constants.ChannelPrefColor, HelpText: "Color of the notifications like #0078d4"},
+		{Item: constants.ChannelPrefHTML, HelpText: "Keep the HTML in the work item comments instead of converting it to Markdown"},
+		{Item: constants.ChannelPrefEmoji, HelpText: "Prefix the notifications with their status emoji"},
+		{Item: constants.ChannelPrefTimezone, HelpText: "Timezone of the times in the notifications like America/New_York"},
+		{Item: constants.ChannelPrefLanguage, HelpText: "Language of the texts added to the notifications like de"},
+		{Item: constants.ChannelPrefSummary, HelpText: "Post a weekly summary of the notifications of this channel"},
+		{Item: constants.ChannelPrefSummaryDay, HelpText: "Day of the week when the summary is posted like monday"},
+		{Item: constants.ChannelPrefSummaryHour, HelpText: "Hour from 0 to 23 when the summary is posted in the timezone of this channel"},
+	})
+	setPreference.AddTextArgument("Value of the preference or default to unset it", "[value]", "")
+	preferences.AddCommand(setPreference)
+	subscriptions.AddCommand(preferences)
+	last := model.NewAutocompleteData(constants.CommandLast, "", "View the last notification sent by a subscription")
+	last.AddTextArgument("ID of the subscription", "[subscription id]", "")
+	subscriptions.AddCommand(last)
+	pause := model.NewAutocompleteData(constants.CommandPause, "", "Pause the notifications of the subscriptions you created")
+	pause.AddTextArgument("(Optional) Duration of the pause like 30m, 4h or 2d, an hour by default", "[duration]", "")
+	subscriptions.AddCommand(pause)
+	resume := model.NewAutocompleteData(constants.CommandResume, "", "Resume the notifications of the subscriptions you created")
+	subscriptions.AddCommand(resume)
+	azureDevops.AddCommand(subscriptions)
+
+	admin := model.NewAutocompleteData(constants.CommandAdmin, "", "Audit the usage and check the configuration of the plugin")
+	projectAccess := model.NewAutocompleteData(constants.CommandProjectAccess, "", "View the Mattermost users who have linked a project")
+	projectAccess.AddTextArgument("Name of the project or organization/project", "[project]", "")
+	admin.AddCommand(projectAccess)
+	diagnose := model.NewAutocompleteData(constants.CommandDiagnose, "", "Check the plugin configuration and your connection to Azure DevOps")
+	admin.AddCommand(diagnose)
+	connections := model.NewAutocompleteData(constants.CommandConnections, "[--page number]", "View the users who have connected their Azure DevOps accounts and the expiry of their tokens")
+	admin.AddCommand(connections)
+	deliveryLog := model.NewAutocompleteData(constants.CommandDeliveryLog, "", "View the latest notifications delivered to a channel")
+	deliveryLog.AddTextArgument("Name of the channel", "[channel]", "")
+	admin.AddCommand(deliveryLog)
+	azureDevops.AddCommand(admin)
+
+	permissions := model.NewAutocompleteData(constants.CommandPermissions, "", "View the commands and the permissions required to run them")
+	azureDevops.AddCommand(permissions)
+
 	return azureDevops
 }
 
@@ -118,23 +245,30 @@ func (p *Plugin) getCommand() (*model.Command, error) {
 	}, nil
 }
 
-func azureDevopsAccountConnectionCheck(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	if isConnected := p.MattermostUserAlreadyConnected(commandArgs.UserId); !isConnected {
-		return p.sendEphemeralPostForCommand(commandArgs, p.getConnectAccountFirstMessage())
-	}
+// azureDevopsLinkCommand does nothing once the account connection is checked, as the project is linked from the modal opened by the webapp
+func azureDevopsLinkCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
 	return &model.CommandResponse{}, nil
 }
 
 func azureDevopsBoardsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	// Check if the user's Azure DevOps account is connected
-	if isConnected := p.MattermostUserAlreadyConnected(commandArgs.UserId); !isConnected {
-		return p.sendEphemeralPostForCommand(commandArgs, p.getConnectAccountFirstMessage())
-	}
-
 	// Validate commands and their arguments
 	switch {
 	case len(args) >= 1 && args[0] == constants.CommandWorkitem && args[1] == constants.CommandCreate:
 		return &model.CommandResponse{}, nil
+	case len(args) >= 1 && args[0] == constants.CommandSprint:
+		return azureDevopsSprintCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandShow:
+		return azureDevopsShowCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandBlockers:
+		return azureDevopsBlockersCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandDelete:
+		return azureDevopsDeleteWorkItemCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandRestore:
+		return azureDevopsRestoreWorkItemCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandQuery:
+		return azureDevopsQueryCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandDefaultQuery:
+		return azureDevopsDefaultQueryCommand(p, c, commandArgs, args...)
 		// For "subscription" command there must be at least 2 arguments
 	case len(args) >= 2 && args[0] == constants.CommandSubscription:
 		switch args[1] {
@@ -151,14 +285,12 @@ func azureDevopsBoardsCommand(p *Plugin, c *plugin.Context, commandArgs *model.C
 }
 
 func azureDevopsReposCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	// Check if the user's Azure DevOps account is connected
-	if isConnected := p.MattermostUserAlreadyConnected(commandArgs.UserId); !isConnected {
-		return p.sendEphemeralPostForCommand(commandArgs, p.getConnectAccountFirstMessage())
-	}
-
 	// Validate commands and their arguments
-	// For "subscription" command there must be at least 2 arguments
-	if len(args) >= 2 && args[0] == constants.CommandSubscription {
+	switch {
+	case len(args) >= 1 && args[0] == constants.CommandMyPRs:
+		return azureDevopsMyPullRequestsCommand(p, c, commandArgs, args...)
+		// For "subscription" command there must be at least 2 arguments
+	case len(args) >= 2 && args[0] == constants.CommandSubscription:
 		switch args[1] {
 		case constants.CommandList:
 			return azureDevopsListSubscriptionsCommand(p, c, commandArgs, constants.CommandRepos, args...)
@@ -173,11 +305,6 @@ func azureDevopsReposCommand(p *Plugin, c *plugin.Context, commandArgs *model.Co
 }
 
 func azureDevopsPipelinesCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	// Check if the user's Azure DevOps account is connected
-	if isConnected := p.MattermostUserAlreadyConnected(commandArgs.UserId); !isConnected {
-		return p.sendEphemeralPostForCommand(commandArgs, p.getConnectAccountFirstMessage())
-	}
-
 	// Validate commands and their arguments
 	// For "subscription" command there must be at least 2 arguments
 	if len(args) >= 2 && args[0] == constants.CommandSubscription {
@@ -194,6 +321,483 @@ func azureDevopsPipelinesCommand(p *Plugin, c *plugin.Context, commandArgs *mode
 	return executeDefault(p, c, commandArgs, args...)
 }
 
+func azureDevopsProjectCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) >= 1 && args[0] == constants.CommandDedupe {
+		message, err := p.dedupeProjects(commandArgs.UserId)
+		if err != nil {
+			p.API.LogError(constants.ErrorDedupeProjects, "Error", err.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	if len(args) >= 1 && args[0] == constants.CommandActivity {
+		return azureDevopsProjectActivityCommand(p, c, commandArgs, args...)
+	}
+
+	if len(args) >= 1 && args[0] == constants.CommandSync {
+		message, err := p.syncProjects(commandArgs.UserId)
+		if err != nil {
+			p.API.LogError(constants.ErrorSyncProjects, "Error", err.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	return executeDefault(p, c, commandArgs, args...)
+}
+
+func azureDevopsProjectsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	message, err := p.getLinkedProjectsList(commandArgs.UserId)
+	if err != nil {
+		p.API.LogError(constants.ErrorListProjects, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsProjectActivityCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	hours := constants.ProjectActivityDefaultHours
+	if len(args) >= 2 && args[len(args)-2] == constants.CommandHoursFlag {
+		hoursNumber, err := strconv.Atoi(args[len(args)-1])
+		if err != nil || hoursNumber < 1 || hoursNumber > constants.ProjectActivityMaxHours {
+			return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf(constants.InvalidProjectActivityHours, args[len(args)-1], constants.ProjectActivityMaxHours))
+		}
+		hours = hoursNumber
+		args = args[:len(args)-2]
+	}
+
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project is required")
+	}
+
+	message, err := p.getProjectActivity(commandArgs.UserId, commandArgs.ChannelId, args[1], hours)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectActivity, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsSubscriptionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	switch {
+	case len(args) >= 1 && args[0] == constants.CommandApplyTemplate:
+		return azureDevopsApplyTemplateCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandPreferences:
+		return azureDevopsPreferencesCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandDeleteProject:
+		return azureDevopsDeleteProjectSubscriptionsCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandLast:
+		return azureDevopsLastNotificationCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandMove:
+		return azureDevopsMoveSubscriptionsCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandPause:
+		return azureDevopsPauseSubscriptionsCommand(p, c, commandArgs, args...)
+	case len(args) >= 1 && args[0] == constants.CommandResume:
+		return azureDevopsResumeSubscriptionsCommand(p, c, commandArgs, args...)
+	}
+
+	return executeDefault(p, c, commandArgs, args...)
+}
+
+func azureDevopsAdminCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) >= 1 {
+		switch args[0] {
+		case constants.CommandProjectAccess:
+			return azureDevopsProjectAccessCommand(p, c, commandArgs, args...)
+		case constants.CommandDiagnose:
+			return p.sendEphemeralPostForCommand(commandArgs, p.getDiagnostics(commandArgs.UserId))
+		case constants.CommandConnections:
+			return azureDevopsConnectionsCommand(p, c, commandArgs, args...)
+		case constants.CommandDeliveryLog:
+			return azureDevopsDeliveryLogCommand(p, c, commandArgs, args...)
+		}
+	}
+
+	return executeDefault(p, c, commandArgs, args...)
+}
+
+func azureDevopsProjectAccessCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project is required")
+	}
+
+	message, err := p.getProjectAccess(commandArgs.UserId, args[1])
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectAccess, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsConnectionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	page := 1
+	if len(args) >= 3 && args[1] == constants.CommandPageFlag {
+		pageNumber, err := strconv.Atoi(args[2])
+		if err != nil {
+			return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf(constants.InvalidSharedQueryPage, args[2]))
+		}
+		page = pageNumber
+	}
+
+	message, err := p.getConnections(commandArgs.UserId, page)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchConnections, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsDeliveryLogCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Channel is required")
+	}
+
+	channelName := strings.TrimPrefix(args[1], "~")
+	channel, appErr := p.API.GetChannelByName(commandArgs.TeamId, channelName, false)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf(constants.ChannelNotFoundWithName, channelName))
+		}
+		p.API.LogError("Error in getting the channel", "Error", appErr.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	message, err := p.getDeliveryLog(commandArgs.UserId, channel)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchDeliveryLog, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsApplyTemplateCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 3 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Template name and project are required")
+	}
+
+	message, err := p.applySubscriptionTemplate(commandArgs.UserId, commandArgs.ChannelId, args[1], args[2])
+	if err != nil {
+		p.API.LogError("Error in applying subscription template", "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsLastNotificationCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Subscription ID is required")
+	}
+
+	post, message, err := p.getLastNotificationPost(commandArgs.UserId, commandArgs.ChannelId, args[1])
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchLastNotification, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+	if post == nil {
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	_ = p.API.SendEphemeralPost(commandArgs.UserId, post)
+	return &model.CommandResponse{}, nil
+}
+
+func azureDevopsPauseSubscriptionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	message, err := p.pauseSubscriptions(commandArgs.UserId, args[1:])
+	if err != nil {
+		p.API.LogError(constants.ErrorPauseSubscriptions, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsResumeSubscriptionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	message, err := p.resumeSubscriptions(commandArgs.UserId)
+	if err != nil {
+		p.API.LogError(constants.ErrorResumeSubscriptions, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsDeleteProjectSubscriptionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	channelID := ""
+	if len(args) >= 2 && args[len(args)-2] == constants.CommandChannelFlag {
+		channelName := strings.TrimPrefix(args[len(args)-1], "~")
+		channel, appErr := p.API.GetChannelByName(commandArgs.TeamId, channelName, false)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf(constants.ChannelNotFoundWithName, channelName))
+			}
+			p.API.LogError("Error in getting the channel", "Error", appErr.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+		channelID = channel.Id
+		args = args[:len(args)-2]
+	}
+
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project is required")
+	}
+
+	message, err := p.deleteProjectSubscriptions(commandArgs.UserId, args[1], channelID)
+	if err != nil {
+		p.API.LogError(constants.ErrorDeleteProjectSubscriptions, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsMoveSubscriptionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 3 {
+		return p.sendEphemeralPostForCommand(commandArgs, "The channels to move the subscriptions from and to are required")
+	}
+
+	var channels []*model.Channel
+	for _, channelName := range args[1:3] {
+		channelName = strings.TrimPrefix(channelName, "~")
+		channel, appErr := p.API.GetChannelByName(commandArgs.TeamId, channelName, false)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf(constants.ChannelNotFoundWithName, channelName))
+			}
+			p.API.LogError("Error in getting the channel", "Error", appErr.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+		channels = append(channels, channel)
+	}
+
+	message, err := p.moveSubscriptions(commandArgs.UserId, channels[0], channels[1])
+	if err != nil {
+		p.API.LogError(constants.ErrorMoveSubscriptions, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsPreferencesCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) == 1 {
+		prefs, err := p.Store.GetChannelNotificationPrefs(commandArgs.ChannelId)
+		if err != nil {
+			p.API.LogError(constants.ErrorFetchChannelPrefs, "Error", err.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+
+		return p.sendEphemeralPostForCommand(commandArgs, getChannelNotificationPrefsMessage(prefs))
+	}
+
+	if args[1] != constants.CommandSet {
+		return executeDefault(p, c, commandArgs, args...)
+	}
+
+	if len(args) < 4 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Preference and value are required")
+	}
+
+	message, err := p.setChannelNotificationPref(commandArgs.UserId, commandArgs.ChannelId, args[2], args[3])
+	if err != nil {
+		p.API.LogError(constants.ErrorStoreChannelPrefs, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsSprintCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project is required")
+	}
+
+	// Team names can contain spaces, so all the remaining arguments make the team name
+	teamName := strings.Join(args[2:], " ")
+	message, err := p.getSprintSummary(commandArgs.UserId, args[1], teamName)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchSprintSummary, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsShowCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 3 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project and work item ID are required")
+	}
+
+	attachment, message, err := p.getWorkItemDetails(commandArgs.UserId, args[1], args[2])
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchWorkItemDetails, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	if attachment == nil {
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: commandArgs.ChannelId,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	_ = p.API.SendEphemeralPost(commandArgs.UserId, post)
+
+	return &model.CommandResponse{}, nil
+}
+
+func azureDevopsBlockersCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 3 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project and work item ID are required")
+	}
+
+	message, err := p.getWorkItemBlockers(commandArgs.UserId, args[1], args[2])
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchWorkItemBlockers, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsDeleteWorkItemCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	// Work items are moved to the recycle bin unless destroying them is explicitly requested
+	destroy := false
+	if len(args) >= 1 && args[len(args)-1] == constants.CommandDestroyFlag {
+		destroy = true
+		args = args[:len(args)-1]
+	}
+
+	if len(args) < 3 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project and work item ID are required")
+	}
+
+	attachment, message, err := p.getDeleteWorkItemConfirmation(commandArgs.UserId, args[1], args[2], destroy)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchWorkItemDetails, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	if attachment == nil {
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: commandArgs.ChannelId,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	_ = p.API.SendEphemeralPost(commandArgs.UserId, post)
+
+	return &model.CommandResponse{}, nil
+}
+
+// azureDevopsRestoreWorkItemCommand restores a work item, or lists the recycle bin of the project if the work item ID is not provided
+func azureDevopsRestoreWorkItemCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project is required")
+	}
+
+	if len(args) == 2 {
+		message, err := p.getRecycleBinTable(commandArgs.UserId, args[1])
+		if err != nil {
+			p.API.LogError(constants.ErrorFetchRecycleBin, "Error", err.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	message, err := p.restoreDeletedWorkItem(commandArgs.UserId, args[1], args[2])
+	if err != nil {
+		p.API.LogError(constants.ErrorRestoreWorkItem, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsQueryCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	page := 1
+	if len(args) >= 2 && args[len(args)-2] == constants.CommandPageFlag {
+		pageNumber, err := strconv.Atoi(args[len(args)-1])
+		if err != nil {
+			return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf(constants.InvalidSharedQueryPage, args[len(args)-1]))
+		}
+		page = pageNumber
+		args = args[:len(args)-2]
+	}
+
+	if len(args) < 3 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project and query name are required")
+	}
+
+	// Query names can contain spaces, so all the remaining arguments make the query name
+	message, err := p.getSharedQueryResults(commandArgs.UserId, args[1], strings.Join(args[2:], " "), page)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchSharedQueryResults, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsDefaultQueryCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 || (args[1] != constants.CommandRun && args[1] != constants.CommandSet) {
+		return executeDefault(p, c, commandArgs, args...)
+	}
+
+	if args[1] == constants.CommandRun {
+		if len(args) < 3 {
+			return p.sendEphemeralPostForCommand(commandArgs, "Project is required")
+		}
+
+		message, err := p.runProjectDefaultQuery(commandArgs.UserId, args[2])
+		if err != nil {
+			p.API.LogError(constants.ErrorRunDefaultQuery, "Error", err.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+		}
+
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	if len(args) < 4 {
+		return p.sendEphemeralPostForCommand(commandArgs, "Project and query are required")
+	}
+
+	// The WIQL contains spaces, so all the remaining arguments make the query
+	message, err := p.setProjectDefaultQuery(commandArgs.UserId, args[2], strings.Join(args[3:], " "))
+	if err != nil {
+		p.API.LogError(constants.ErrorSetDefaultQuery, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsMyPullRequestsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	if len(args) < 2 {
+		return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("Project or %s is required", constants.CommandAllFlag))
+	}
+
+	message, err := p.getMyPullRequests(commandArgs.UserId, args[1], args[1] == constants.CommandAllFlag)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchPullRequests, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
 func azureDevopsDeleteCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, command string, args ...string) (*model.CommandResponse, *model.AppError) {
 	if len(args) < 3 {
 		return p.sendEphemeralPostForCommand(commandArgs, "Subscription ID is not provided")
@@ -206,39 +810,57 @@ func azureDevopsDeleteCommand(p *Plugin, c *plugin.Context, commandArgs *model.C
 	}
 
 	subscriptionIDToBeDeleted := args[2]
+	var subscriptionToBeDeleted *serializers.SubscriptionDetails
 	for _, subscription := range subscriptionList {
-		if subscription.SubscriptionID == subscriptionIDToBeDeleted && subscription.ServiceType == command {
-			if _, err := p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("%s subscription with ID: %q is being deleted", cases.Title(language.Und).String(command), subscriptionIDToBeDeleted)); err != nil {
-				p.API.LogError("Error in sending ephemeral post", "Error", err.Error())
-			}
+		if subscription.SubscriptionID != subscriptionIDToBeDeleted || subscription.ServiceType != command {
+			continue
+		}
 
-			if statusCode, err := p.Client.DeleteSubscription(subscription.OrganizationName, subscription.SubscriptionID, commandArgs.UserId); err != nil {
-				if statusCode == http.StatusForbidden {
-					return p.sendEphemeralPostForCommand(commandArgs, constants.ErrorAdminAccess)
-				}
-				p.API.LogError("Error in deleting subscription", "Error", err.Error())
-				return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
-			}
+		// A subscription shared by several users is deleted for the user running the command if they are one of them
+		if subscriptionToBeDeleted == nil || subscription.MattermostUserID == commandArgs.UserId {
+			subscriptionToBeDeleted = subscription
+		}
+	}
 
-			if deleteErr := p.Store.DeleteSubscription(subscription); deleteErr != nil {
-				p.API.LogError("Error in deleting subscription", "Error", deleteErr.Error())
-				return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
-			}
+	if subscriptionToBeDeleted == nil {
+		return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("%s subscription with ID: %q does not exist", cases.Title(language.Und).String(command), subscriptionIDToBeDeleted))
+	}
 
-			p.API.PublishWebSocketEvent(
-				constants.WSEventSubscriptionDeleted,
-				nil,
-				&model.WebsocketBroadcast{UserId: commandArgs.UserId},
-			)
+	if _, err := p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("%s subscription with ID: %q is being deleted", cases.Title(language.Und).String(command), subscriptionIDToBeDeleted)); err != nil {
+		p.API.LogError("Error in sending ephemeral post", "Error", err.Error())
+	}
 
-			return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("%s subscription with ID: %q is successfully deleted", cases.Title(language.Und).String(command), subscriptionIDToBeDeleted))
+	if !isSubscriptionShared(subscriptionList, subscriptionToBeDeleted) {
+		if statusCode, err := p.Client.DeleteSubscription(subscriptionToBeDeleted.OrganizationName, subscriptionToBeDeleted.SubscriptionID, commandArgs.UserId); err != nil {
+			if statusCode == http.StatusForbidden {
+				return p.sendEphemeralPostForCommand(commandArgs, constants.ErrorAdminAccess)
+			}
+			p.API.LogError("Error in deleting subscription", "Error", err.Error())
+			return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
 		}
 	}
 
-	return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("%s subscription with ID: %q does not exist", cases.Title(language.Und).String(command), subscriptionIDToBeDeleted))
+	if deleteErr := p.Store.DeleteSubscription(subscriptionToBeDeleted); deleteErr != nil {
+		p.API.LogError("Error in deleting subscription", "Error", deleteErr.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	p.API.PublishWebSocketEvent(
+		constants.WSEventSubscriptionDeleted,
+		nil,
+		&model.WebsocketBroadcast{UserId: commandArgs.UserId},
+	)
+
+	return p.sendEphemeralPostForCommand(commandArgs, fmt.Sprintf("%s subscription with ID: %q is successfully deleted", cases.Title(language.Und).String(command), subscriptionIDToBeDeleted))
 }
 
 func azureDevopsListSubscriptionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, command string, args ...string) (*model.CommandResponse, *model.AppError) {
+	oldestFirst := false
+	if len(args) >= 3 && args[len(args)-1] == constants.CommandOldestFlag {
+		oldestFirst = true
+		args = args[:len(args)-1]
+	}
+
 	createdByArgument := constants.FilterCreatedByAnyone
 	// Check if 3rd argument is "me"
 	if len(args) >= 3 && args[2] == constants.FilterCreatedByMe {
@@ -260,44 +882,89 @@ func azureDevopsListSubscriptionsCommand(p *Plugin, c *plugin.Context, commandAr
 	if len(args) >= 4 && args[3] == constants.FilterAllChannels {
 		showForChannelID = ""
 	}
-	return p.sendEphemeralPostForCommand(commandArgs, p.ParseSubscriptionsToCommandResponse(subscriptionList, showForChannelID, createdByArgument, commandArgs.UserId, command, commandArgs.TeamId))
+	return p.sendEphemeralPostForCommand(commandArgs, p.ParseSubscriptionsToCommandResponse(subscriptionList, showForChannelID, createdByArgument, commandArgs.UserId, command, commandArgs.TeamId, oldestFirst))
+}
+
+// azureDevopsPermissionsCommand lists the commands along with the permissions required to run them
+func azureDevopsPermissionsCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	return p.sendEphemeralPostForCommand(commandArgs, p.getCommandPermissionsMessage())
 }
 
 func azureDevopsHelpCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	return p.sendEphemeralPostForCommand(commandArgs, constants.HelpText)
+	return p.sendEphemeralPostForCommand(commandArgs, p.getHelpText())
 }
 
 func azureDevopsConnectCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	message := fmt.Sprintf(constants.ConnectAccount, p.GetPluginURLPath(), constants.PathOAuthConnect)
-	if isConnected := p.MattermostUserAlreadyConnected(commandArgs.UserId); isConnected {
-		message = constants.MattermostUserAlreadyConnected
+	connectPath := constants.PathOAuthConnect
+	organization := ""
+	if len(args) > 0 {
+		organization = strings.ToLower(args[0])
+		connectPath = fmt.Sprintf("%s?%s", connectPath, url.Values{constants.QueryParamOrganization: {args[0]}}.Encode())
 	}
+
+	message := fmt.Sprintf(constants.ConnectAccount, p.GetPluginURLPath(), connectPath)
+	if connectMessage := p.getConnectMessage(commandArgs.UserId, organization); connectMessage != "" {
+		message = connectMessage
+	}
+	return p.sendEphemeralPostForCommand(commandArgs, message)
+}
+
+func azureDevopsConnectDeviceCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	message, err := p.startDeviceCodeFlow(commandArgs.UserId)
+	if err != nil {
+		p.API.LogError("Error in starting the device code flow", "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
 	return p.sendEphemeralPostForCommand(commandArgs, message)
 }
 
+// azureDevopsDisconnectCommand asks the user to confirm disconnecting their account, or tells them they don't have any account connected
 func azureDevopsDisconnectCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	message := constants.UserDisconnected
-	if isConnected := p.MattermostUserAlreadyConnected(commandArgs.UserId); !isConnected {
-		message = p.getConnectAccountFirstMessage()
-	} else {
-		if isDeleted, err := p.Store.DeleteUser(commandArgs.UserId); !isDeleted {
-			if err != nil {
-				p.API.LogError(constants.UnableToDisconnectUser, "Error", err.Error())
-			}
-			message = constants.GenericErrorMessage
-		}
+	attachment, message, err := p.getDisconnectUserConfirmation(commandArgs.UserId)
+	if err != nil {
+		p.API.LogError(constants.UnableToDisconnectUser, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
 
-		p.API.PublishWebSocketEvent(
-			constants.WSEventDisconnect,
-			nil,
-			&model.WebsocketBroadcast{UserId: commandArgs.UserId},
-		)
+	if attachment == nil {
+		return p.sendEphemeralPostForCommand(commandArgs, message)
 	}
-	return p.sendEphemeralPostForCommand(commandArgs, message)
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: commandArgs.ChannelId,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	_ = p.API.SendEphemeralPost(commandArgs.UserId, post)
+
+	return &model.CommandResponse{}, nil
+}
+
+// azureDevopsResetCommand asks the user to confirm resetting their plugin state, which works even if their account is not connected anymore
+func azureDevopsResetCommand(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
+	attachment, message, err := p.getResetUserConfirmation(commandArgs.UserId)
+	if err != nil {
+		p.API.LogError(constants.ErrorResetUser, "Error", err.Error())
+		return p.sendEphemeralPostForCommand(commandArgs, constants.GenericErrorMessage)
+	}
+
+	if attachment == nil {
+		return p.sendEphemeralPostForCommand(commandArgs, message)
+	}
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: commandArgs.ChannelId,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	_ = p.API.SendEphemeralPost(commandArgs.UserId, post)
+
+	return &model.CommandResponse{}, nil
 }
 
 func executeDefault(p *Plugin, c *plugin.Context, commandArgs *model.CommandArgs, args ...string) (*model.CommandResponse, *model.AppError) {
-	out := constants.InvalidCommand + constants.HelpText
+	out := constants.InvalidCommand + p.getHelpText()
 
 	return p.sendEphemeralPostForCommand(commandArgs, out)
 }
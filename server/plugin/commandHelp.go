@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// commandUsageExamples shows how to run the commands taking arguments, keyed like the handlers of the commands
+var commandUsageExamples = map[string]string{
+	constants.CommandConnect: "/azuredevops connect my-organization",
+	constants.CommandLink:    "/azuredevops link https://dev.azure.com/my-organization/my-project",
+	constants.CommandProject + "/" + constants.CommandActivity:                                       "/azuredevops project activity my-organization/my-project --hours 48",
+	constants.CommandBoards + "/" + constants.CommandSprint:                                          "/azuredevops boards sprint my-organization/my-project My Team",
+	constants.CommandBoards + "/" + constants.CommandShow:                                            "/azuredevops boards show my-organization/my-project 42",
+	constants.CommandBoards + "/" + constants.CommandQuery:                                           "/azuredevops boards query my-organization/my-project Shared Queries/Active Bugs --page 2",
+	constants.CommandBoards + "/" + constants.CommandDefaultQuery + "/" + constants.CommandSet:       "/azuredevops boards default-query set my-organization/my-project SELECT [System.Id] FROM workitems WHERE [System.State] = 'Active'",
+	constants.CommandRepos + "/" + constants.CommandMyPRs:                                            "/azuredevops repos my-prs --all",
+	constants.CommandSubscriptions + "/" + constants.CommandApplyTemplate:                            "/azuredevops subscriptions apply-template releases my-organization/my-project",
+	constants.CommandSubscriptions + "/" + constants.CommandDeleteProject:                            "/azuredevops subscriptions delete-project my-organization/my-project --channel town-square",
+	constants.CommandSubscriptions + "/" + constants.CommandMove:                                     "/azuredevops subscriptions move town-square engineering",
+	constants.CommandSubscriptions + "/" + constants.CommandPreferences + "/" + constants.CommandSet: "/azuredevops subscriptions preferences set timezone America/New_York",
+	constants.CommandSubscriptions + "/" + constants.CommandPause:                                    "/azuredevops subscriptions pause 4h",
+	constants.CommandAdmin + "/" + constants.CommandDeliveryLog:                                      "/azuredevops admin delivery-log town-square",
+}
+
+// commandSubscriptionUsageExamples shows how to run the subscription commands, which are the same for Boards, Repos and Pipelines
+var commandSubscriptionUsageExamples = map[string]string{
+	constants.CommandList:   "/azuredevops %s subscription list me all_channels --oldest",
+	constants.CommandDelete: "/azuredevops %s subscription delete 0a1b2c3d-4e5f-6789-abcd-ef0123456789",
+}
+
+// getHelpText lists every command of the autocomplete along with its arguments, description and an example of its usage,
+// so that the help stays in sync with the commands
+func (p *Plugin) getHelpText() string {
+	var sb strings.Builder
+	sb.WriteString(constants.HelpTitle)
+
+	var writeCommand func(autocompleteData *model.AutocompleteData, args []string)
+	writeCommand = func(autocompleteData *model.AutocompleteData, args []string) {
+		for _, subCommand := range autocompleteData.SubCommands {
+			subCommandArgs := append(append([]string{}, args...), subCommand.Trigger)
+			usage := append([]string{"/" + constants.CommandTriggerName}, subCommandArgs...)
+			if subCommand.Hint != "" {
+				usage = append(usage, subCommand.Hint)
+			}
+			for _, argument := range subCommand.Arguments {
+				if hint := getAutocompleteArgumentHint(argument); hint != "" {
+					usage = append(usage, hint)
+				}
+			}
+
+			sb.WriteString(fmt.Sprintf("\n%s* `%s` - %s", strings.Repeat("  ", len(args)), strings.Join(usage, " "), subCommand.HelpText))
+			if example := getCommandUsageExample(subCommandArgs); example != "" {
+				sb.WriteString(fmt.Sprintf(", e.g. `%s`", example))
+			}
+
+			// The subcommands of a command with a hint are its options, which are already shown in the hint
+			if subCommand.Hint == "" {
+				writeCommand(subCommand, subCommandArgs)
+			}
+		}
+	}
+	writeCommand(p.getAutoCompleteData(), nil)
+
+	return sb.String()
+}
+
+// getAutocompleteArgumentHint returns the hint of a text argument or the items of a static list argument
+func getAutocompleteArgumentHint(argument *model.AutocompleteArg) string {
+	switch data := argument.Data.(type) {
+	case *model.AutocompleteTextArg:
+		return data.Hint
+	case *model.AutocompleteStaticListArg:
+		items := make([]string, 0, len(data.PossibleArguments))
+		for _, item := range data.PossibleArguments {
+			items = append(items, item.Item)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+	}
+
+	return ""
+}
+
+func getCommandUsageExample(args []string) string {
+	if len(args) == 3 && args[1] == constants.CommandSubscription {
+		if example, ok := commandSubscriptionUsageExamples[args[2]]; ok {
+			return fmt.Sprintf(example, args[0])
+		}
+	}
+
+	return commandUsageExamples[strings.Join(args, "/")]
+}
@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+func TestGetHelpText(t *testing.T) {
+	p := setupMockPlugin(&plugintest.API{}, nil, nil)
+	helpText := p.getHelpText()
+
+	assert.True(t, strings.HasPrefix(helpText, constants.HelpTitle))
+
+	var checkCommand func(autocompleteData *model.AutocompleteData, args []string)
+	checkCommand = func(autocompleteData *model.AutocompleteData, args []string) {
+		for _, subCommand := range autocompleteData.SubCommands {
+			subCommandArgs := append(append([]string{}, args...), subCommand.Trigger)
+			assert.Contains(t, helpText, fmt.Sprintf("* `/%s %s", constants.CommandTriggerName, strings.Join(subCommandArgs, " ")))
+			if subCommand.Hint == "" {
+				checkCommand(subCommand, subCommandArgs)
+			}
+		}
+	}
+	checkCommand(p.getAutoCompleteData(), nil)
+
+	for _, line := range []string{
+		"\n* `/azuredevops connect [organization]` - Connect to your Azure DevOps account, optionally for an organization, e.g. `/azuredevops connect my-organization`",
+		"\n  * `/azuredevops boards query [project] [query name or path] [--page number]` - View the work items returned by a saved query, e.g. `/azuredevops boards query my-organization/my-project Shared Queries/Active Bugs --page 2`",
+		"\n    * `/azuredevops repos subscription list [me or anyone] [all_channels] [--oldest]` - List subscriptions, e.g. `/azuredevops repos subscription list me all_channels --oldest`",
+		"\n    * `/azuredevops pipelines subscription delete [subscription id]` - Delete a subscription, e.g. `/azuredevops pipelines subscription delete 0a1b2c3d-4e5f-6789-abcd-ef0123456789`",
+		"\n    * `/azuredevops subscriptions preferences set [color, html, emoji, timezone, language, summary, summary-day, summary-hour] [value]` - Set a notification preference of the current channel for all of its subscriptions",
+		"\n  * `/azuredevops admin connections [--page number]` - View the users who have connected their Azure DevOps accounts and the expiry of their tokens\n",
+	} {
+		assert.Contains(t, helpText, line)
+	}
+
+	// The filters of the subscription list are shown in its hint instead of as commands
+	assert.NotContains(t, helpText, "subscription list me`")
+}
+
+func TestGetCommandUsageExample(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		args            []string
+		expectedExample string
+	}{
+		{
+			description:     "GetCommandUsageExample: command with an example",
+			args:            []string{constants.CommandSubscriptions, constants.CommandPause},
+			expectedExample: "/azuredevops subscriptions pause 4h",
+		},
+		{
+			description:     "GetCommandUsageExample: subscription command of a service",
+			args:            []string{constants.CommandRepos, constants.CommandSubscription, constants.CommandList},
+			expectedExample: "/azuredevops repos subscription list me all_channels --oldest",
+		},
+		{
+			description: "GetCommandUsageExample: command without arguments",
+			args:        []string{constants.CommandProjects},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedExample, getCommandUsageExample(testCase.args))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// commandPermission is a permission required to run a command.
+// The permissions which depend on the arguments of the command have no isGranted func, they are checked by the command itself.
+type commandPermission struct {
+	description   string
+	isGranted     func(p *Plugin, commandArgs *model.CommandArgs) bool
+	deniedMessage func(p *Plugin) string
+}
+
+var (
+	permissionConnectedAccount = &commandPermission{
+		description: constants.PermissionConnectedAccount,
+		isGranted: func(p *Plugin, commandArgs *model.CommandArgs) bool {
+			return p.MattermostUserAlreadyConnected(commandArgs.UserId)
+		},
+		deniedMessage: func(p *Plugin) string {
+			return p.getConnectAccountFirstMessage()
+		},
+	}
+	permissionSystemAdmin = &commandPermission{
+		description: constants.PermissionSystemAdmin,
+		isGranted: func(p *Plugin, commandArgs *model.CommandArgs) bool {
+			return p.API.HasPermissionTo(commandArgs.UserId, model.PERMISSION_MANAGE_SYSTEM)
+		},
+	}
+	permissionManageChannel = &commandPermission{
+		description: constants.PermissionManageChannel,
+		isGranted: func(p *Plugin, commandArgs *model.CommandArgs) bool {
+			return p.canManageChannel(commandArgs.UserId, commandArgs.ChannelId)
+		},
+	}
+	permissionPostInTargetChannel = &commandPermission{
+		description: constants.PermissionPostInTargetChannel,
+	}
+	permissionProjectAccess = &commandPermission{
+		description: constants.PermissionProjectAccess,
+	}
+)
+
+// azureDevopsCommandPermissions lists the permissions required to run the commands, keyed like the handlers of the commands.
+// A command requires the permissions of all of its parent commands.
+var azureDevopsCommandPermissions = map[string][]*commandPermission{
+	constants.CommandLink:                                        {permissionConnectedAccount},
+	constants.CommandProject:                                     {permissionConnectedAccount},
+	constants.CommandBoards:                                      {permissionConnectedAccount},
+	constants.CommandRepos:                                       {permissionConnectedAccount},
+	constants.CommandPipelines:                                   {permissionConnectedAccount},
+	constants.CommandSubscriptions:                               {permissionConnectedAccount},
+	constants.CommandSubscriptions + "/" + constants.CommandMove: {permissionPostInTargetChannel},
+	constants.CommandSubscriptions + "/" + constants.CommandPreferences + "/" + constants.CommandSet: {permissionManageChannel},
+	constants.CommandAdmin + "/" + constants.CommandProjectAccess:                                    {permissionProjectAccess},
+	constants.CommandAdmin + "/" + constants.CommandDiagnose:                                         {permissionSystemAdmin},
+	constants.CommandAdmin + "/" + constants.CommandConnections:                                      {permissionSystemAdmin},
+	constants.CommandAdmin + "/" + constants.CommandDeliveryLog:                                      {permissionSystemAdmin},
+}
+
+// getCommandPermissions returns the permissions required to run the command in the args, along with the ones of its parent commands
+func getCommandPermissions(args ...string) []*commandPermission {
+	var permissions []*commandPermission
+	for arg := 1; arg <= len(args); arg++ {
+		permissions = append(permissions, azureDevopsCommandPermissions[strings.Join(args[:arg], "/")]...)
+	}
+
+	return permissions
+}
+
+// checkCommandPermissions returns the message shown to the user if they are missing a permission required to run the command in the args
+func (p *Plugin) checkCommandPermissions(commandArgs *model.CommandArgs, args ...string) string {
+	for _, permission := range getCommandPermissions(args...) {
+		if permission.isGranted == nil || permission.isGranted(p, commandArgs) {
+			continue
+		}
+
+		if permission.deniedMessage != nil {
+			return permission.deniedMessage(p)
+		}
+
+		return fmt.Sprintf(constants.CommandPermissionDenied, permission.description)
+	}
+
+	return ""
+}
+
+// getCommandPermissionsMessage lists every command of the autocomplete along with the permissions checked before running it,
+// so that the list stays in sync with the commands and the checks.
+func (p *Plugin) getCommandPermissionsMessage() string {
+	var sb strings.Builder
+	sb.WriteString(constants.CommandPermissionsTitle)
+	sb.WriteString("\n| Command | Required permissions |\n| :-- | :-- |")
+
+	var writeCommand func(autocompleteData *model.AutocompleteData, args []string)
+	writeCommand = func(autocompleteData *model.AutocompleteData, args []string) {
+		for _, subCommand := range autocompleteData.SubCommands {
+			subCommandArgs := append(append([]string{}, args...), subCommand.Trigger)
+			permissions := getCommandPermissions(subCommandArgs...)
+			descriptions := make([]string, 0, len(permissions))
+			for _, permission := range permissions {
+				descriptions = append(descriptions, permission.description)
+			}
+
+			if len(descriptions) == 0 {
+				descriptions = append(descriptions, constants.PermissionNone)
+			}
+
+			sb.WriteString(fmt.Sprintf("\n| `/%s %s` | %s |", constants.CommandTriggerName, strings.Join(subCommandArgs, " "), strings.Join(descriptions, ", ")))
+			writeCommand(subCommand, subCommandArgs)
+		}
+	}
+	writeCommand(p.getAutoCompleteData(), nil)
+
+	return sb.String()
+}
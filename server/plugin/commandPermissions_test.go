@@ -0,0 +1,120 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetCommandPermissionsMessage(t *testing.T) {
+	p := setupMockPlugin(&plugintest.API{}, nil, nil)
+	message := p.getCommandPermissionsMessage()
+
+	var checkCommand func(autocompleteData *model.AutocompleteData, args []string)
+	checkCommand = func(autocompleteData *model.AutocompleteData, args []string) {
+		for _, subCommand := range autocompleteData.SubCommands {
+			subCommandArgs := append(append([]string{}, args...), subCommand.Trigger)
+			assert.Contains(t, message, fmt.Sprintf("| `/%s %s` |", constants.CommandTriggerName, strings.Join(subCommandArgs, " ")))
+			checkCommand(subCommand, subCommandArgs)
+		}
+	}
+	checkCommand(p.getAutoCompleteData(), nil)
+
+	for _, row := range []string{
+		fmt.Sprintf("| `/azuredevops help` | %s |", constants.PermissionNone),
+		fmt.Sprintf("| `/azuredevops boards subscription add` | %s |", constants.PermissionConnectedAccount),
+		fmt.Sprintf("| `/azuredevops subscriptions move` | %s, %s |", constants.PermissionConnectedAccount, constants.PermissionPostInTargetChannel),
+		fmt.Sprintf("| `/azuredevops subscriptions preferences` | %s |", constants.PermissionConnectedAccount),
+		fmt.Sprintf("| `/azuredevops subscriptions preferences set` | %s, %s |", constants.PermissionConnectedAccount, constants.PermissionManageChannel),
+		fmt.Sprintf("| `/azuredevops admin project-access` | %s |", constants.PermissionProjectAccess),
+		fmt.Sprintf("| `/azuredevops admin diagnose` | %s |", constants.PermissionSystemAdmin),
+		fmt.Sprintf("| `/azuredevops admin connections` | %s |", constants.PermissionSystemAdmin),
+		fmt.Sprintf("| `/azuredevops permissions` | %s |", constants.PermissionNone),
+	} {
+		assert.Contains(t, message, row)
+	}
+}
+
+func TestCheckCommandPermissions(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description            string
+		args                   []string
+		isConnected            bool
+		isSystemAdmin          bool
+		canManageChannel       bool
+		expectedMessage        string
+		expectedConnectMessage bool
+	}{
+		{
+			description: "CheckCommandPermissions: command without permissions",
+			args:        []string{constants.CommandHelp},
+		},
+		{
+			description:            "CheckCommandPermissions: account is not connected",
+			args:                   []string{constants.CommandBoards, constants.CommandSubscription, constants.CommandAdd},
+			expectedConnectMessage: true,
+		},
+		{
+			description: "CheckCommandPermissions: account is connected",
+			args:        []string{constants.CommandBoards, constants.CommandSubscription, constants.CommandAdd},
+			isConnected: true,
+		},
+		{
+			description:     "CheckCommandPermissions: user can't manage the channel",
+			args:            []string{constants.CommandSubscriptions, constants.CommandPreferences, constants.CommandSet, constants.ChannelPrefColor, "#0078d4"},
+			isConnected:     true,
+			expectedMessage: fmt.Sprintf(constants.CommandPermissionDenied, constants.PermissionManageChannel),
+		},
+		{
+			description:      "CheckCommandPermissions: user can manage the channel",
+			args:             []string{constants.CommandSubscriptions, constants.CommandPreferences, constants.CommandSet, constants.ChannelPrefColor, "#0078d4"},
+			isConnected:      true,
+			canManageChannel: true,
+		},
+		{
+			description:     "CheckCommandPermissions: user is not a system admin",
+			args:            []string{constants.CommandAdmin, constants.CommandDiagnose},
+			expectedMessage: fmt.Sprintf(constants.CommandPermissionDenied, constants.PermissionSystemAdmin),
+		},
+		{
+			description:   "CheckCommandPermissions: user is a system admin",
+			args:          []string{constants.CommandAdmin, constants.CommandConnections},
+			isSystemAdmin: true,
+		},
+		{
+			description: "CheckCommandPermissions: permission depending on the arguments is checked by the command",
+			args:        []string{constants.CommandAdmin, constants.CommandProjectAccess, testutils.MockProjectName},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p := setupMockPlugin(mockAPI, nil, nil)
+			mockAPI.On("HasPermissionTo", testutils.MockMattermostUserID, model.PERMISSION_MANAGE_SYSTEM).Return(testCase.isSystemAdmin)
+			mockAPI.On("GetBundlePath").Return("/test-path", nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID, Type: model.CHANNEL_OPEN}, nil)
+			mockAPI.On("HasPermissionToChannel", testutils.MockMattermostUserID, testutils.MockChannelID, mock.Anything).Return(testCase.canManageChannel)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "MattermostUserAlreadyConnected", func(_ *Plugin, _ string) bool {
+				return testCase.isConnected
+			})
+
+			message := p.checkCommandPermissions(&model.CommandArgs{UserId: testutils.MockMattermostUserID, ChannelId: testutils.MockChannelID}, testCase.args...)
+
+			if testCase.expectedConnectMessage {
+				assert.Equal(t, p.getConnectAccountFirstMessage(), message)
+				return
+			}
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
@@ -36,6 +36,7 @@ func TestExecuteCommand(t *testing.T) {
 		patchAPICalls                 func()
 		isListCommand                 bool
 		isDeleteCommand               bool
+		isDisconnectCommand           bool
 		serviceType                   string
 		deleteSubscriptionClientError error
 		deleteSubscriptionStoreError  error
@@ -44,18 +45,23 @@ func TestExecuteCommand(t *testing.T) {
 		{
 			description:      "ExecuteCommand: empty command",
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops"},
-			ephemeralMessage: constants.InvalidCommand + constants.HelpText,
+			ephemeralMessage: constants.InvalidCommand + p.getHelpText(),
 		},
 		{
 			description:      "ExecuteCommand: help command",
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops help"},
-			ephemeralMessage: constants.HelpText,
+			ephemeralMessage: p.getHelpText(),
 		},
 		{
 			description:      "ExecuteCommand: connect command",
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops connect"},
 			ephemeralMessage: fmt.Sprintf(constants.ConnectAccount, p.GetPluginURLPath(), constants.PathOAuthConnect),
 		},
+		{
+			description:      "ExecuteCommand: connect command with organization",
+			commandArgs:      &model.CommandArgs{Command: "/azuredevops connect mockOrganization"},
+			ephemeralMessage: fmt.Sprintf(constants.ConnectAccount, p.GetPluginURLPath(), constants.PathOAuthConnect+"?organization=mockOrganization"),
+		},
 		{
 			description:      "ExecuteCommand: connect command with user already connected",
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops connect"},
@@ -63,15 +69,16 @@ func TestExecuteCommand(t *testing.T) {
 			ephemeralMessage: constants.MattermostUserAlreadyConnected,
 		},
 		{
-			description:      "ExecuteCommand: disconnect command with user not connected",
-			commandArgs:      &model.CommandArgs{Command: "/azuredevops disconnect"},
-			ephemeralMessage: fmt.Sprintf(constants.ConnectAccountFirst, fmt.Sprintf(constants.ConnectAccount, p.GetPluginURLPath(), constants.PathOAuthConnect)),
+			description:         "ExecuteCommand: disconnect command with user not connected",
+			commandArgs:         &model.CommandArgs{Command: "/azuredevops disconnect"},
+			isDisconnectCommand: true,
+			ephemeralMessage:    constants.NothingToDisconnect,
 		},
 		{
-			description:      "ExecuteCommand: disconnect command with user connected",
-			commandArgs:      &model.CommandArgs{Command: "/azuredevops disconnect", UserId: testutils.MockMattermostUserID},
-			isConnected:      true,
-			ephemeralMessage: constants.UserDisconnected,
+			description:         "ExecuteCommand: disconnect command with user connected asks for a confirmation",
+			commandArgs:         &model.CommandArgs{Command: "/azuredevops disconnect", UserId: testutils.MockMattermostUserID},
+			isConnected:         true,
+			isDisconnectCommand: true,
 		},
 		{
 			description:      "ExecuteCommand: boards command when user is not connected",
@@ -82,7 +89,7 @@ func TestExecuteCommand(t *testing.T) {
 			description:      "ExecuteCommand: invalid boards command",
 			isConnected:      true,
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops boards wrong [title] [description]"},
-			ephemeralMessage: constants.InvalidCommand + constants.HelpText,
+			ephemeralMessage: constants.InvalidCommand + p.getHelpText(),
 		},
 		{
 			description:      "ExecuteCommand: boards create command",
@@ -143,7 +150,7 @@ func TestExecuteCommand(t *testing.T) {
 			description:      "ExecuteCommand: invalid repos command",
 			isConnected:      true,
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops repos wrong [title] [description]"},
-			ephemeralMessage: constants.InvalidCommand + constants.HelpText,
+			ephemeralMessage: constants.InvalidCommand + p.getHelpText(),
 		},
 		{
 			description: "ExecuteCommand: repos add subscription command",
@@ -178,7 +185,7 @@ func TestExecuteCommand(t *testing.T) {
 			description:      "ExecuteCommand: invalid pipelines command",
 			isConnected:      true,
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops pipelines wrong [title] [description]"},
-			ephemeralMessage: constants.InvalidCommand + constants.HelpText,
+			ephemeralMessage: constants.InvalidCommand + p.getHelpText(),
 		},
 		{
 			description:     "ExecuteCommand: pipelines delete subscription command",
@@ -197,7 +204,7 @@ func TestExecuteCommand(t *testing.T) {
 		{
 			description:      "ExecuteCommand: invalid command",
 			commandArgs:      &model.CommandArgs{Command: "/azuredevops abc"},
-			ephemeralMessage: constants.InvalidCommand + constants.HelpText,
+			ephemeralMessage: constants.InvalidCommand + p.getHelpText(),
 		},
 		{
 			description: "ExecuteCommand: link command",
@@ -226,7 +233,7 @@ func TestExecuteCommand(t *testing.T) {
 				return testCase.isConnected
 			})
 
-			monkey.PatchInstanceMethod(reflect.TypeOf(p), "ParseSubscriptionsToCommandResponse", func(_ *Plugin, _ []*serializers.SubscriptionDetails, _, _, _, _, _ string) string {
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "ParseSubscriptionsToCommandResponse", func(_ *Plugin, _ []*serializers.SubscriptionDetails, _, _, _, _, _ string, _ bool) string {
 				return "mockSubscriptionList"
 			})
 
@@ -240,8 +247,13 @@ func TestExecuteCommand(t *testing.T) {
 				mockedStore.EXPECT().DeleteSubscription(gomock.Any()).Return(testCase.deleteSubscriptionStoreError)
 			}
 
-			if testCase.ephemeralMessage == constants.UserDisconnected {
-				mockedStore.EXPECT().DeleteUser(testutils.MockMattermostUserID).Return(true, nil)
+			if testCase.isDisconnectCommand {
+				if testCase.isConnected {
+					mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testCase.commandArgs.UserId).Return(testutils.MockAzureDevopsUserID, nil)
+					mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{}, nil)
+				} else {
+					mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testCase.commandArgs.UserId).Return("", nil)
+				}
 			}
 
 			_, err := p.getCommand()
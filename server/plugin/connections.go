@@ -0,0 +1,171 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+const (
+	connectionStatusInvalid      = "Invalid"
+	connectionStatusExpired      = "Expired"
+	connectionStatusExpiringSoon = "Expiring soon"
+	connectionStatusConnected    = "Connected"
+)
+
+// connectionStatuses lists the statuses of the connections in the order they are shown, the ones which need attention first
+var connectionStatuses = []string{connectionStatusInvalid, connectionStatusExpired, connectionStatusExpiringSoon, connectionStatusConnected}
+
+// userConnection is the connection of a Mattermost user to Azure DevOps, without their tokens
+type userConnection struct {
+	mattermostUserID string
+	status           string
+	expiresAt        int64
+	hasRefreshToken  bool
+}
+
+// getConnections returns a page of the users who have connected their Azure DevOps accounts as a table, along with the status and expiry of their tokens.
+// Only system admins can view the connections.
+func (p *Plugin) getConnections(mattermostUserID string, page int) (string, error) {
+	if !p.API.HasPermissionTo(mattermostUserID, model.PERMISSION_MANAGE_SYSTEM) {
+		return constants.ErrorConnectionsPermission, nil
+	}
+
+	mattermostUserIDs, err := p.Store.GetAllConnectedMattermostUserIDs()
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchConnections)
+	}
+
+	if len(mattermostUserIDs) == 0 {
+		return constants.NoConnectedUsers, nil
+	}
+
+	now := time.Now()
+	statusCounts := map[string]int{}
+	connectionsByStatus := map[string][]*userConnection{}
+	for _, userID := range mattermostUserIDs {
+		connection, err := p.getUserConnection(userID, now)
+		if err != nil {
+			return "", err
+		}
+
+		statusCounts[connection.status]++
+		connectionsByStatus[connection.status] = append(connectionsByStatus[connection.status], connection)
+	}
+
+	// The user IDs are sorted, so the users keep their positions across the pages as long as their statuses don't change
+	var connections []*userConnection
+	for _, status := range connectionStatuses {
+		connections = append(connections, connectionsByStatus[status]...)
+	}
+
+	pageCount := (len(connections) + constants.ConnectionsPageSize - 1) / constants.ConnectionsPageSize
+	if page < 1 || page > pageCount {
+		return fmt.Sprintf(constants.ConnectionsPageNotFound, page, pageCount), nil
+	}
+
+	start := (page - 1) * constants.ConnectionsPageSize
+	end := start + constants.ConnectionsPageSize
+	if end > len(connections) {
+		end = len(connections)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("###### Azure DevOps connections\n")
+	sb.WriteString("| Username | Status | Token Expiry | Action |\n")
+	sb.WriteString("| :------- | :----- | :----------- | :----- |\n")
+	for _, connection := range connections[start:end] {
+		username := connection.mattermostUserID
+		if user, appErr := p.API.GetUser(connection.mattermostUserID); appErr != nil {
+			// The user can be deleted after connecting their account, so their ID is shown instead
+			p.API.LogDebug("Error in getting the Mattermost user", "UserID", connection.mattermostUserID, "Error", appErr.Error())
+		} else {
+			username = "@" + user.Username
+		}
+
+		expiry := ""
+		if connection.expiresAt != 0 {
+			expiry = time.Unix(connection.expiresAt, 0).UTC().Format(constants.DateTimeFormat)
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", username, connection.status, expiry, connection.getAction()))
+	}
+
+	var counts []string
+	for _, status := range connectionStatuses {
+		if statusCounts[status] > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", statusCounts[status], strings.ToLower(status)))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("\n%d user(s) have connected: %s", len(connections), strings.Join(counts, ", ")))
+	if pageCount > 1 {
+		sb.WriteString(fmt.Sprintf(". Showing %d-%d", start+1, end))
+	}
+	if page < pageCount {
+		sb.WriteString(fmt.Sprintf(", use `/%s %s %s %s %d` to view the next page.", constants.CommandTriggerName, constants.CommandAdmin, constants.CommandConnections, constants.CommandPageFlag, page+1))
+	}
+
+	return sb.String(), nil
+}
+
+// getUserConnection loads the connection of a Mattermost user from the KV store
+func (p *Plugin) getUserConnection(mattermostUserID string, now time.Time) (*userConnection, error) {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, constants.ErrorLoadingUserData)
+	}
+
+	user, err := p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, constants.ErrorLoadingUserData)
+	}
+
+	return &userConnection{
+		mattermostUserID: mattermostUserID,
+		status:           getConnectionStatus(user, now),
+		expiresAt:        user.ExpiresAt,
+		hasRefreshToken:  user.RefreshToken != "",
+	}, nil
+}
+
+// getConnectionStatus categorizes the connection of a user by the expiry of their access token.
+// A connection is invalid if the details of the user are missing, e.g. when they could not be decrypted.
+func getConnectionStatus(user *serializers.User, now time.Time) string {
+	if user == nil || user.AccessToken == "" {
+		return connectionStatusInvalid
+	}
+
+	expiresAt := time.Unix(user.ExpiresAt, 0)
+	switch {
+	// The same buffer is used while refreshing the tokens, so a token expiring within it is already treated as expired
+	case !now.Add(time.Minute * constants.TokenExpiryTimeBufferInMinutes).Before(expiresAt):
+		return connectionStatusExpired
+	case now.Add(constants.ConnectionExpiringSoonDuration).After(expiresAt):
+		return connectionStatusExpiringSoon
+	}
+
+	return connectionStatusConnected
+}
+
+// getAction returns what is needed for a connection to keep working.
+// An expired access token is refreshed the next time the user uses the plugin if they have a refresh token, the user has to connect again otherwise.
+func (c *userConnection) getAction() string {
+	switch c.status {
+	case connectionStatusInvalid:
+		return "Reconnect"
+	case connectionStatusExpired, connectionStatusExpiringSoon:
+		if !c.hasRefreshToken {
+			return "Reconnect"
+		}
+		return "Refreshed on the next use"
+	}
+
+	return ""
+}
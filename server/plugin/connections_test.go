@@ -0,0 +1,188 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestGetConnectionStatus(t *testing.T) {
+	now := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	for _, testCase := range []struct {
+		description    string
+		user           *serializers.User
+		expectedStatus string
+	}{
+		{
+			description:    "GetConnectionStatus: token expires after more than a day",
+			user:           &serializers.User{AccessToken: "mockAccessToken", ExpiresAt: now.Add(48 * time.Hour).Unix()},
+			expectedStatus: connectionStatusConnected,
+		},
+		{
+			description:    "GetConnectionStatus: token expires within a day",
+			user:           &serializers.User{AccessToken: "mockAccessToken", ExpiresAt: now.Add(time.Hour).Unix()},
+			expectedStatus: connectionStatusExpiringSoon,
+		},
+		{
+			description:    "GetConnectionStatus: token expires within the refresh buffer",
+			user:           &serializers.User{AccessToken: "mockAccessToken", ExpiresAt: now.Add(time.Minute).Unix()},
+			expectedStatus: connectionStatusExpired,
+		},
+		{
+			description:    "GetConnectionStatus: token is expired",
+			user:           &serializers.User{AccessToken: "mockAccessToken", ExpiresAt: now.Add(-time.Hour).Unix()},
+			expectedStatus: connectionStatusExpired,
+		},
+		{
+			description:    "GetConnectionStatus: access token is missing",
+			user:           &serializers.User{ExpiresAt: now.Add(48 * time.Hour).Unix()},
+			expectedStatus: connectionStatusInvalid,
+		},
+		{
+			description:    "GetConnectionStatus: user details are missing",
+			expectedStatus: connectionStatusInvalid,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedStatus, getConnectionStatus(testCase.user, now))
+		})
+	}
+}
+
+func TestUserConnectionGetAction(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		connection     *userConnection
+		expectedAction string
+	}{
+		{
+			description:    "UserConnectionGetAction: expired token with a refresh token",
+			connection:     &userConnection{status: connectionStatusExpired, hasRefreshToken: true},
+			expectedAction: "Refreshed on the next use",
+		},
+		{
+			description:    "UserConnectionGetAction: expiring token without a refresh token",
+			connection:     &userConnection{status: connectionStatusExpiringSoon},
+			expectedAction: "Reconnect",
+		},
+		{
+			description:    "UserConnectionGetAction: invalid connection",
+			connection:     &userConnection{status: connectionStatusInvalid, hasRefreshToken: true},
+			expectedAction: "Reconnect",
+		},
+		{
+			description: "UserConnectionGetAction: connected",
+			connection:  &userConnection{status: connectionStatusConnected, hasRefreshToken: true},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedAction, testCase.connection.getAction())
+		})
+	}
+}
+
+func TestGetConnections(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+	mockAPI.On("HasPermissionTo", "mockAdminID", model.PERMISSION_MANAGE_SYSTEM).Return(true)
+	mockAPI.On("HasPermissionTo", "mockUserID", model.PERMISSION_MANAGE_SYSTEM).Return(false)
+	mockAPI.On("GetUser", "mockDeletedUserID").Return(nil, &model.AppError{Message: "user not found"})
+	mockAPI.On("GetUser", mock.AnythingOfType("string")).Return(func(userID string) *model.User {
+		return &model.User{Id: userID, Username: strings.TrimSuffix(userID, "ID")}
+	}, nil)
+	mockAPI.On("LogDebug", mock.AnythingOfType("string"), "UserID", "mockDeletedUserID", "Error", mock.AnythingOfType("string"))
+
+	mockUsers := func(users map[string]*serializers.User) []string {
+		var userIDs []string
+		for userID, user := range users {
+			userIDs = append(userIDs, userID)
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(userID).Return("azd-"+userID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails("azd-"+userID).Return(user, nil)
+		}
+		return userIDs
+	}
+
+	t.Run("GetConnections: user is not a system admin", func(t *testing.T) {
+		message, err := p.getConnections("mockUserID", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ErrorConnectionsPermission, message)
+	})
+
+	t.Run("GetConnections: no users have connected", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllConnectedMattermostUserIDs().Return(nil, nil)
+
+		message, err := p.getConnections("mockAdminID", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.NoConnectedUsers, message)
+	})
+
+	t.Run("GetConnections: connections which need attention are listed first", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour).Unix()
+		userIDs := mockUsers(map[string]*serializers.User{
+			"mockConnectedID":   {AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken", ExpiresAt: time.Now().Add(48 * time.Hour).Unix()},
+			"mockExpiredID":     {AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken", ExpiresAt: expiresAt},
+			"mockDeletedUserID": {},
+		})
+		mockedStore.EXPECT().GetAllConnectedMattermostUserIDs().Return(userIDs, nil)
+
+		message, err := p.getConnections("mockAdminID", 1)
+
+		require.NoError(t, err)
+		assert.NotContains(t, message, "mockAccessToken")
+		assert.NotContains(t, message, "mockRefreshToken")
+		invalidRow := "| mockDeletedUserID | Invalid |  | Reconnect |"
+		expiredRow := fmt.Sprintf("| @mockExpired | Expired | %s | Refreshed on the next use |", time.Unix(expiresAt, 0).UTC().Format(constants.DateTimeFormat))
+		connectedRow := "| @mockConnected | Connected |"
+		assert.Contains(t, message, invalidRow)
+		assert.Contains(t, message, expiredRow)
+		assert.Contains(t, message, connectedRow)
+		assert.Less(t, strings.Index(message, invalidRow), strings.Index(message, expiredRow))
+		assert.Less(t, strings.Index(message, expiredRow), strings.Index(message, connectedRow))
+		assert.Contains(t, message, "3 user(s) have connected: 1 invalid, 1 expired, 1 connected")
+		assert.NotContains(t, message, "next page")
+	})
+
+	t.Run("GetConnections: connections are paginated", func(t *testing.T) {
+		users := map[string]*serializers.User{}
+		for i := 0; i < constants.ConnectionsPageSize+1; i++ {
+			users[fmt.Sprintf("mockUser%02dID", i)] = &serializers.User{AccessToken: "mockAccessToken", ExpiresAt: time.Now().Add(48 * time.Hour).Unix()}
+		}
+		userIDs := mockUsers(users)
+		mockedStore.EXPECT().GetAllConnectedMattermostUserIDs().Return(userIDs, nil)
+
+		message, err := p.getConnections("mockAdminID", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(message, "| Connected |"))
+		assert.Contains(t, message, "21 user(s) have connected: 21 connected. Showing 21-21")
+		assert.NotContains(t, message, "next page")
+	})
+
+	t.Run("GetConnections: page does not exist", func(t *testing.T) {
+		userIDs := mockUsers(map[string]*serializers.User{"mockConnectedID": {AccessToken: "mockAccessToken"}})
+		mockedStore.EXPECT().GetAllConnectedMattermostUserIDs().Return(userIDs, nil)
+
+		message, err := p.getConnections("mockAdminID", 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.ConnectionsPageNotFound, 2, 1), message)
+	})
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// dedupeProjects merges the projects linked more than once by a user, keyed by their organization and project ID, and reports the merged ones.
+// The subscriptions are repointed to the linked projects even if nothing was merged, so running it again completes a previous run which failed midway.
+func (p *Plugin) dedupeProjects(mattermostUserID string) (string, error) {
+	mergedProjects, err := p.Store.DedupeProjects(mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	repointedCount, err := p.Store.RepointSubscriptions(mattermostUserID, projectList)
+	if err != nil {
+		return "", err
+	}
+
+	if len(mergedProjects) == 0 && repointedCount == 0 {
+		return constants.NoDuplicateProjects, nil
+	}
+
+	var sb strings.Builder
+	if len(mergedProjects) > 0 {
+		sb.WriteString("###### Merged duplicate projects\n")
+		sb.WriteString("| Project | Organization | Project ID | Removed entries |\n")
+		sb.WriteString("| :------ | :----------- | :--------- | :-------------- |\n")
+		for _, mergedProject := range mergedProjects {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d |\n", escapeTableCell(mergedProject.Project.ProjectName), escapeTableCell(mergedProject.Project.OrganizationName), mergedProject.Project.ProjectID, len(mergedProject.Duplicates)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf(constants.SubscriptionsRepointed, repointedCount))
+
+	return sb.String(), nil
+}
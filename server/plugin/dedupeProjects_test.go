@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestDedupeProjects(t *testing.T) {
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectID: testutils.MockProjectID, ProjectName: testutils.MockProjectName}
+	for _, testCase := range []struct {
+		description     string
+		mergedProjects  []store.MergedProject
+		repointedCount  int
+		expectedMessage string
+	}{
+		{
+			description: "DedupeProjects: duplicate projects are merged",
+			mergedProjects: []store.MergedProject{{
+				Project: project,
+				Duplicates: []serializers.ProjectDetails{
+					{OrganizationName: testutils.MockOrganization, ProjectID: " " + testutils.MockProjectID, ProjectName: testutils.MockProjectName},
+					{OrganizationName: testutils.MockOrganization, ProjectID: testutils.MockProjectID + " ", ProjectName: testutils.MockProjectName},
+				},
+			}},
+			repointedCount: 3,
+			expectedMessage: "###### Merged duplicate projects\n" +
+				"| Project | Organization | Project ID | Removed entries |\n" +
+				"| :------ | :----------- | :--------- | :-------------- |\n" +
+				"| mockProjectName | mockOrganization | mockProjectID | 2 |\n" +
+				"\n3 of your subscription(s) now refer to the kept projects",
+		},
+		{
+			description:     "DedupeProjects: subscriptions left by an earlier run are repointed",
+			repointedCount:  1,
+			expectedMessage: "1 of your subscription(s) now refer to the kept projects",
+		},
+		{
+			description:     "DedupeProjects: no duplicate projects",
+			expectedMessage: constants.NoDuplicateProjects,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+			mockedStore.EXPECT().DedupeProjects(testutils.MockMattermostUserID).Return(testCase.mergedProjects, nil)
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+			mockedStore.EXPECT().RepointSubscriptions(testutils.MockMattermostUserID, []serializers.ProjectDetails{project}).Return(testCase.repointedCount, nil)
+
+			message, err := p.dedupeProjects(testutils.MockMattermostUserID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+
+	t.Run("DedupeProjects: projects can't be merged", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+		mockedStore.EXPECT().DedupeProjects(testutils.MockMattermostUserID).Return(nil, errors.New("reached write attempt limit"))
+
+		message, err := p.dedupeProjects(testutils.MockMattermostUserID)
+
+		assert.EqualError(t, err, "reached write attempt limit")
+		assert.Empty(t, message)
+	})
+}
@@ -0,0 +1,143 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+var workItemLinksQueryRegex = regexp.MustCompile(`(?i)\bFROM\s+WorkItemLinks\b`)
+
+// validateDefaultQuery validates a WIQL query with a dry run in the context of a project, so that an invalid query is rejected when it's set instead of every time it's run.
+// The errors caused by the query itself are returned with the status code 400.
+func (p *Plugin) validateDefaultQuery(organization, projectName, query, mattermostUserID string) (int, error) {
+	// The links returned by a query of the work item links are not listed, so such a query would always look empty
+	if workItemLinksQueryRegex.MatchString(query) {
+		return http.StatusBadRequest, fmt.Errorf(constants.InvalidDefaultQuery, constants.DefaultQueryNotFlat)
+	}
+
+	if statusCode, err := p.Client.ValidateWIQL(organization, projectName, query, mattermostUserID); err != nil {
+		if statusCode == http.StatusBadRequest {
+			return statusCode, fmt.Errorf(constants.InvalidDefaultQuery, err.Error())
+		}
+		return statusCode, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// setProjectDefaultQuery sets the WIQL of the default query of a linked project after running it once, and returns the message to be shown to the user.
+// The default query is unset by the value "default".
+func (p *Plugin) setProjectDefaultQuery(mattermostUserID, projectArgument, query string) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	message := fmt.Sprintf(constants.DefaultQuerySet, project.ProjectName)
+	if strings.EqualFold(query, constants.DefaultQueryValueDefault) {
+		query = ""
+		message = fmt.Sprintf(constants.DefaultQueryReset, project.ProjectName)
+	} else if statusCode, queryErr := p.validateDefaultQuery(project.OrganizationName, project.ProjectName, query, mattermostUserID); queryErr != nil {
+		if statusCode == http.StatusBadRequest {
+			return queryErr.Error(), nil
+		}
+		return "", queryErr
+	}
+
+	project.DefaultQuery = query
+	if err := p.Store.StoreProject(project); err != nil {
+		return "", err
+	}
+
+	return message, nil
+}
+
+// runProjectDefaultQuery returns the first work items returned by the default query of a linked project as a table.
+// The active work items assigned to the user are listed if the project has no default query.
+func (p *Plugin) runProjectDefaultQuery(mattermostUserID, projectArgument string) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	query := project.DefaultQuery
+	if query == "" {
+		query = constants.DefaultProjectQuery
+	}
+
+	workItemReferences, statusCode, err := p.Client.QueryWorkItems(project.OrganizationName, project.ProjectName, query, mattermostUserID)
+	if err != nil {
+		// The query can become invalid after it's set, e.g. if a field used in it is deleted
+		if statusCode == http.StatusBadRequest {
+			return fmt.Sprintf(constants.InvalidDefaultQuery, errors.Cause(err).Error()), nil
+		}
+		return "", err
+	}
+
+	if len(workItemReferences) == 0 {
+		return fmt.Sprintf(constants.NoDefaultQueryResults, project.ProjectName), nil
+	}
+
+	var workItemIDs []int
+	for _, reference := range workItemReferences {
+		if len(workItemIDs) == constants.DefaultQueryMaxResults {
+			break
+		}
+		workItemIDs = append(workItemIDs, reference.ID)
+	}
+
+	fields := []string{constants.FieldWorkItemType, constants.FieldTitle, constants.FieldState, constants.FieldAssignedTo}
+	workItems, erroredWorkItemIDs, err := p.getWorkItemsInBatches(project.OrganizationName, project.ProjectName, workItemIDs, fields, mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	workItemsByID := map[int]*serializers.TaskValue{}
+	for _, workItem := range workItems {
+		workItemsByID[workItem.ID] = workItem
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Results of the default query of %s/%s\n", project.OrganizationName, project.ProjectName))
+	sb.WriteString("| ID | Type | Title | State | Assigned To |\n")
+	sb.WriteString("| :- | :--- | :---- | :---- | :---------- |\n")
+	for _, workItemID := range workItemIDs {
+		link := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), workItemID)
+		if erroredWorkItemIDs[workItemID] {
+			sb.WriteString(fmt.Sprintf("| [%d](%s) |  | %s |  |  |\n", workItemID, link, constants.WorkItemFetchFailed))
+			continue
+		}
+
+		workItem, ok := workItemsByID[workItemID]
+		if !ok {
+			sb.WriteString(fmt.Sprintf("| [%d](%s) |  |  |  |  |\n", workItemID, link))
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("| [%d](%s) | %s | %s | %s | %s |\n", workItemID, link, escapeTableCell(workItem.Fields.Type), escapeTableCell(workItem.Fields.Title), escapeTableCell(workItem.Fields.State), escapeTableCell(workItem.Fields.AssignedTo.DisplayName)))
+	}
+
+	if len(workItemReferences) > len(workItemIDs) {
+		sb.WriteString(fmt.Sprintf("\nShowing the first %d of %d work items", len(workItemIDs), len(workItemReferences)))
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,253 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+const mockDefaultQuery = "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active'"
+
+func TestSetProjectDefaultQuery(t *testing.T) {
+	for _, testCase := range []struct {
+		description          string
+		projectArgument      string
+		query                string
+		queryStatusCode      int
+		queryErr             error
+		expectedDefaultQuery *string
+		expectedMessage      string
+		expectedErr          string
+	}{
+		{
+			description:          "SetProjectDefaultQuery: query is run once and stored",
+			projectArgument:      "mockOrganization/mockProjectName",
+			query:                mockDefaultQuery,
+			queryStatusCode:      http.StatusOK,
+			expectedDefaultQuery: &[]string{mockDefaultQuery}[0],
+			expectedMessage:      fmt.Sprintf(constants.DefaultQuerySet, testutils.MockProjectName),
+		},
+		{
+			description:          "SetProjectDefaultQuery: default query is reset",
+			projectArgument:      testutils.MockProjectName,
+			query:                "Default",
+			expectedDefaultQuery: &[]string{""}[0],
+			expectedMessage:      fmt.Sprintf(constants.DefaultQueryReset, testutils.MockProjectName),
+		},
+		{
+			description:     "SetProjectDefaultQuery: invalid WIQL",
+			projectArgument: testutils.MockProjectName,
+			query:           "SELECT [Unknown.Field] FROM WorkItems",
+			queryStatusCode: http.StatusBadRequest,
+			queryErr:        errors.New("TF51005: The query references a field that does not exist"),
+			expectedMessage: fmt.Sprintf(constants.InvalidDefaultQuery, "TF51005: The query references a field that does not exist"),
+		},
+		{
+			description:     "SetProjectDefaultQuery: query of the work item links is rejected without running it",
+			projectArgument: testutils.MockProjectName,
+			query:           "SELECT [System.Id] FROM workitemLinks WHERE [Source].[System.State] = 'Active'",
+			expectedMessage: fmt.Sprintf(constants.InvalidDefaultQuery, constants.DefaultQueryNotFlat),
+		},
+		{
+			description:     "SetProjectDefaultQuery: error in running the query",
+			projectArgument: testutils.MockProjectName,
+			query:           mockDefaultQuery,
+			queryStatusCode: http.StatusInternalServerError,
+			queryErr:        errors.New("error in running the query"),
+			expectedErr:     "error in running the query",
+		},
+		{
+			description:     "SetProjectDefaultQuery: project is not linked",
+			projectArgument: "mockUnlinkedProject",
+			query:           mockDefaultQuery,
+			expectedMessage: fmt.Sprintf(constants.ProjectNotLinkedWithName, "mockUnlinkedProject"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testutils.GetProjectDetailsPayload(), nil)
+			if testCase.queryStatusCode != 0 {
+				mockedClient.EXPECT().ValidateWIQL(testutils.MockOrganization, testutils.MockProjectName, testCase.query, testutils.MockMattermostUserID).Return(testCase.queryStatusCode, testCase.queryErr)
+			}
+			if testCase.expectedDefaultQuery != nil {
+				project := testutils.GetProjectDetailsPayload()[0]
+				project.DefaultQuery = *testCase.expectedDefaultQuery
+				mockedStore.EXPECT().StoreProject(&project).Return(nil)
+			}
+
+			message, err := p.setProjectDefaultQuery(testutils.MockMattermostUserID, testCase.projectArgument, testCase.query)
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
+
+func TestRunProjectDefaultQuery(t *testing.T) {
+	p := setupMockPlugin(&plugintest.API{}, nil, nil)
+	p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+
+	projectWithDefaultQuery := testutils.GetProjectDetailsPayload()
+	projectWithDefaultQuery[0].DefaultQuery = mockDefaultQuery
+	for _, testCase := range []struct {
+		description        string
+		projectList        []serializers.ProjectDetails
+		expectedQuery      string
+		workItemReferences []*serializers.WorkItemReference
+		queryStatusCode    int
+		queryErr           error
+		expectedMessage    string
+	}{
+		{
+			description:        "RunProjectDefaultQuery: active work items of the user are listed if no query is set",
+			projectList:        testutils.GetProjectDetailsPayload(),
+			expectedQuery:      constants.DefaultProjectQuery,
+			workItemReferences: []*serializers.WorkItemReference{{ID: 1}, {ID: 2}},
+			queryStatusCode:    http.StatusOK,
+			expectedMessage: "###### Results of the default query of mockOrganization/mockProjectName\n" +
+				"| ID | Type | Title | State | Assigned To |\n" +
+				"| :- | :--- | :---- | :---- | :---------- |\n" +
+				"| [1](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/1) | Bug | mock \\| title | Active | mockUser |\n" +
+				"| [2](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/2) |  |  |  |  |\n",
+		},
+		{
+			description:     "RunProjectDefaultQuery: default query of the project returns no work items",
+			projectList:     projectWithDefaultQuery,
+			expectedQuery:   mockDefaultQuery,
+			queryStatusCode: http.StatusOK,
+			expectedMessage: fmt.Sprintf(constants.NoDefaultQueryResults, testutils.MockProjectName),
+		},
+		{
+			description:     "RunProjectDefaultQuery: default query has become invalid",
+			projectList:     projectWithDefaultQuery,
+			expectedQuery:   mockDefaultQuery,
+			queryStatusCode: http.StatusBadRequest,
+			queryErr:        errors.Wrap(errors.New("TF51005: The query references a field that does not exist"), "failed to query the work items"),
+			expectedMessage: fmt.Sprintf(constants.InvalidDefaultQuery, "TF51005: The query references a field that does not exist"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p.Client = mockedClient
+			p.Store = mockedStore
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, nil)
+			mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, testCase.expectedQuery, testutils.MockMattermostUserID).Return(testCase.workItemReferences, testCase.queryStatusCode, testCase.queryErr)
+			if len(testCase.workItemReferences) > 0 {
+				mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, []int{1, 2}, gomock.Any(), testutils.MockMattermostUserID).Return([]*serializers.TaskValue{{
+					ID: 1,
+					Fields: serializers.TaskFieldValue{
+						Type:       "Bug",
+						Title:      "mock | title",
+						State:      "Active",
+						AssignedTo: serializers.TaskUserDetails{DisplayName: "mockUser"},
+					},
+				}}, http.StatusOK, nil)
+			}
+
+			message, err := p.runProjectDefaultQuery(testutils.MockMattermostUserID, testutils.MockProjectName)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+
+	t.Run("RunProjectDefaultQuery: only the first work items are listed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p.Client = mockedClient
+		p.Store = mockedStore
+
+		var workItemReferences []*serializers.WorkItemReference
+		for id := 1; id <= constants.DefaultQueryMaxResults+5; id++ {
+			workItemReferences = append(workItemReferences, &serializers.WorkItemReference{ID: id})
+		}
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testutils.GetProjectDetailsPayload(), nil)
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, constants.DefaultProjectQuery, testutils.MockMattermostUserID).Return(workItemReferences, http.StatusOK, nil)
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, gomock.Len(constants.DefaultQueryMaxResults), gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusOK, nil)
+
+		message, err := p.runProjectDefaultQuery(testutils.MockMattermostUserID, testutils.MockProjectName)
+
+		require.NoError(t, err)
+		assert.Contains(t, message, fmt.Sprintf("\nShowing the first %d of %d work items", constants.DefaultQueryMaxResults, constants.DefaultQueryMaxResults+5))
+	})
+}
+
+func TestHandleLinkDefaultQuery(t *testing.T) {
+	for _, testCase := range []struct {
+		description        string
+		queryStatusCode    int
+		queryErr           error
+		expectedStatusCode int
+	}{
+		{
+			description:        "HandleLinkDefaultQuery: project is linked with its default query",
+			queryStatusCode:    http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleLinkDefaultQuery: project is not linked with an invalid default query",
+			queryStatusCode:    http.StatusBadRequest,
+			queryErr:           errors.New("TF51005: The query references a field that does not exist"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, nil)
+			mockedClient.EXPECT().Link(gomock.Any(), testutils.MockMattermostUserID).Return(&serializers.Project{ID: testutils.MockProjectID}, http.StatusOK, nil)
+			mockedClient.EXPECT().ValidateWIQL(testutils.MockOrganization, "mockProject", mockDefaultQuery, testutils.MockMattermostUserID).Return(testCase.queryStatusCode, testCase.queryErr)
+			if testCase.queryErr == nil {
+				mockedStore.EXPECT().StoreProject(&serializers.ProjectDetails{
+					MattermostUserID: testutils.MockMattermostUserID,
+					ProjectID:        testutils.MockProjectID,
+					ProjectName:      "mockProject",
+					OrganizationName: "mockorganization",
+					DefaultQuery:     mockDefaultQuery,
+				}).Return(nil)
+			}
+
+			body := fmt.Sprintf(`{"organization": "mockOrganization", "project": "mockProject", "defaultQuery": %q}`, " "+mockDefaultQuery+" ")
+			req := httptest.NewRequest(http.MethodPost, "/link", bytes.NewBufferString(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleLink(w, req)
+			assert.Equal(t, testCase.expectedStatusCode, w.Result().StatusCode)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// logNotificationDelivery records a notification delivered to a channel in its delivery log, the post ID is empty for the notifications summarized in a burst.
+// A notification is still delivered if it can't be recorded.
+func (p *Plugin) logNotificationDelivery(channelID string, body *serializers.SubscriptionNotification, postID string) {
+	entry := &serializers.DeliveryLogEntry{
+		Timestamp:      time.Now().Unix(),
+		EventType:      body.EventType,
+		SubscriptionID: body.SubscriptionID,
+		PostID:         postID,
+	}
+	if err := p.Store.AddDeliveryLogEntry(channelID, entry); err != nil {
+		p.API.LogDebug("Error in adding the notification to the delivery log of the channel", "ChannelID", channelID, "Error", err.Error())
+	}
+}
+
+// getDeliveryLog returns the latest notifications delivered to a channel as a table, the latest first.
+// Only system admins can view the delivery logs.
+func (p *Plugin) getDeliveryLog(mattermostUserID string, channel *model.Channel) (string, error) {
+	if !p.API.HasPermissionTo(mattermostUserID, model.PERMISSION_MANAGE_SYSTEM) {
+		return constants.ErrorDeliveryLogPermission, nil
+	}
+
+	entries, err := p.Store.GetDeliveryLog(channel.Id)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchDeliveryLog)
+	}
+
+	if len(entries) == 0 {
+		return fmt.Sprintf(constants.NoDeliveryLog, channel.Name), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Notifications delivered to ~%s\n", channel.Name))
+	sb.WriteString("| Delivered At | Event Type | Subscription ID | Post |\n")
+	sb.WriteString("| :----------- | :--------- | :-------------- | :--- |\n")
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		post := "Summarized in the post of a burst"
+		if entry.PostID != "" {
+			post = fmt.Sprintf("[View](%s/_redirect/pl/%s)", p.GetSiteURL(), entry.PostID)
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", time.Unix(entry.Timestamp, 0).UTC().Format(constants.DateTimeFormat), entry.EventType, entry.SubscriptionID, post))
+	}
+	sb.WriteString(fmt.Sprintf("\nThe latest %d notification(s) delivered to the channel are kept.", constants.DeliveryLogMaxEntries))
+
+	return sb.String(), nil
+}
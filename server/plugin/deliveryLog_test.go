@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestLogNotificationDelivery(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+	mockedStore.EXPECT().AddDeliveryLogEntry(testutils.MockChannelID, gomock.Any()).DoAndReturn(func(_ string, entry *serializers.DeliveryLogEntry) error {
+		assert.Equal(t, constants.SubscriptionEventBuildCompleted, entry.EventType)
+		assert.Equal(t, testutils.MockSubscriptionID, entry.SubscriptionID)
+		assert.Equal(t, "mockPostID", entry.PostID)
+		assert.NotZero(t, entry.Timestamp)
+		return nil
+	})
+
+	p.logNotificationDelivery(testutils.MockChannelID, &serializers.SubscriptionNotification{SubscriptionID: testutils.MockSubscriptionID, EventType: constants.SubscriptionEventBuildCompleted}, "mockPostID")
+}
+
+func TestGetDeliveryLog(t *testing.T) {
+	channel := &model.Channel{Id: testutils.MockChannelID, Name: "mock-channel"}
+	for _, testCase := range []struct {
+		description      string
+		isSystemAdmin    bool
+		entries          []*serializers.DeliveryLogEntry
+		err              error
+		expectedMessage  string
+		expectedRows     []string
+		expectedErrorMsg string
+	}{
+		{
+			description:     "GetDeliveryLog: user is not a system admin",
+			expectedMessage: constants.ErrorDeliveryLogPermission,
+		},
+		{
+			description:     "GetDeliveryLog: no notifications delivered to the channel",
+			isSystemAdmin:   true,
+			expectedMessage: fmt.Sprintf(constants.NoDeliveryLog, "mock-channel"),
+		},
+		{
+			description:   "GetDeliveryLog: deliveries are listed from the latest",
+			isSystemAdmin: true,
+			entries: []*serializers.DeliveryLogEntry{
+				{Timestamp: 1704704400, EventType: constants.SubscriptionEventWorkItemCreated, SubscriptionID: "mockSubscriptionID1"},
+				{Timestamp: 1704708000, EventType: constants.SubscriptionEventBuildCompleted, SubscriptionID: "mockSubscriptionID2", PostID: "mockPostID"},
+			},
+			expectedRows: []string{
+				"| Mon Jan 8 10:00:00 +0000 UTC 2024 | build.complete | mockSubscriptionID2 | [View](https://mattermost.example.com/_redirect/pl/mockPostID) |",
+				"| Mon Jan 8 09:00:00 +0000 UTC 2024 | workitem.created | mockSubscriptionID1 | Summarized in the post of a burst |",
+			},
+		},
+		{
+			description:      "GetDeliveryLog: error in fetching the delivery log",
+			isSystemAdmin:    true,
+			err:              errors.New("error fetching the delivery log"),
+			expectedErrorMsg: fmt.Sprintf("%s: error fetching the delivery log", constants.ErrorFetchDeliveryLog),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{MattermostSiteURL: "https://mattermost.example.com"})
+			mockAPI.On("HasPermissionTo", testutils.MockMattermostUserID, model.PERMISSION_MANAGE_SYSTEM).Return(testCase.isSystemAdmin)
+			mockAPI.On("LogDebug", mock.Anything, mock.Anything, mock.Anything)
+			if testCase.isSystemAdmin {
+				mockedStore.EXPECT().GetDeliveryLog(testutils.MockChannelID).Return(testCase.entries, testCase.err)
+			}
+
+			message, err := p.getDeliveryLog(testutils.MockMattermostUserID, channel)
+
+			if testCase.expectedErrorMsg != "" {
+				assert.EqualError(t, err, testCase.expectedErrorMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			if testCase.expectedRows == nil {
+				assert.Equal(t, testCase.expectedMessage, message)
+				return
+			}
+
+			assert.Contains(t, message, "###### Notifications delivered to ~mock-channel")
+			assert.Contains(t, message, testCase.expectedRows[0]+"\n"+testCase.expectedRows[1])
+		})
+	}
+}
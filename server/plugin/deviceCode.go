@@ -0,0 +1,173 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// startDeviceCodeFlow starts connecting the account of a user with the device code flow and returns the instructions to complete it.
+// The token is polled for in the background until the user enters the code or it expires.
+func (p *Plugin) startDeviceCodeFlow(mattermostUserID string) (string, error) {
+	clientID := p.getConfiguration().DeviceCodeClientID
+	if clientID == "" {
+		return constants.DeviceCodeNotEnabled, nil
+	}
+
+	if p.MattermostUserAlreadyConnected(mattermostUserID) {
+		return constants.MattermostUserAlreadyConnected, nil
+	}
+
+	isStarted, err := p.Store.StartDeviceCodeFlow(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to start the device code flow")
+	}
+
+	if !isStarted {
+		return constants.DeviceCodeAlreadyInProgress, nil
+	}
+
+	deviceCode, _, err := p.Client.GenerateDeviceCode(url.Values{
+		"client_id": {clientID},
+		"scope":     {constants.DeviceCodeScopes},
+	})
+	if err != nil {
+		p.endDeviceCodeFlow(mattermostUserID)
+		return "", err
+	}
+
+	go p.pollDeviceCodeToken(mattermostUserID, deviceCode)
+
+	return fmt.Sprintf(constants.DeviceCodeInstructions, deviceCode.VerificationURI, deviceCode.UserCode, (deviceCode.ExpiresIn+59)/60), nil
+}
+
+// pollDeviceCodeToken polls for the token of the device code flow at the interval given by the device code response,
+// which is increased whenever the polling is asked to slow down, and stores it once the user enters the code.
+// The user is notified in a direct message when the flow is completed, declined or expired.
+func (p *Plugin) pollDeviceCodeToken(mattermostUserID string, deviceCode *serializers.DeviceCodeResponse) {
+	defer p.endDeviceCodeFlow(mattermostUserID)
+
+	interval := time.Duration(deviceCode.Interval) * time.Second
+	if interval <= 0 {
+		interval = constants.DeviceCodeDefaultInterval
+	}
+	expiresAt := time.Now().Add(time.Duration(deviceCode.ExpiresIn) * time.Second)
+
+	formValues := url.Values{
+		"client_id":   {p.getConfiguration().DeviceCodeClientID},
+		"grant_type":  {constants.GrantTypeDeviceCode},
+		"device_code": {deviceCode.DeviceCode},
+	}
+
+	for {
+		select {
+		case <-time.After(interval):
+		case <-p.deviceCodeFlowsDone:
+			return
+		}
+
+		if time.Now().After(expiresAt) {
+			p.sendDeviceCodeFlowDM(mattermostUserID, constants.DeviceCodeExpired)
+			return
+		}
+
+		token, errorCode, _, err := p.Client.GenerateDeviceCodeToken(formValues)
+		switch errorCode {
+		case "":
+		case constants.DeviceCodeAuthorizationPending:
+			continue
+		case constants.DeviceCodeSlowDown:
+			interval += constants.DeviceCodeSlowDownInterval
+			continue
+		case constants.DeviceCodeExpiredToken:
+			p.sendDeviceCodeFlowDM(mattermostUserID, constants.DeviceCodeExpired)
+			return
+		case constants.DeviceCodeAuthorizationDeclined, constants.DeviceCodeAccessDenied:
+			p.sendDeviceCodeFlowDM(mattermostUserID, constants.DeviceCodeDeclined)
+			return
+		}
+
+		if err != nil {
+			p.API.LogError("Error in polling for the device code token", "Error", err.Error())
+			p.sendDeviceCodeFlowDM(mattermostUserID, constants.GenericErrorMessage)
+			return
+		}
+
+		if err := p.storeOAuthToken(mattermostUserID, getDeviceCodeOAuthResponse(token), constants.AuthTypeDeviceCode, "", false); err != nil {
+			p.API.LogError(constants.UnableToCompleteOAuth, "Error", err.Error())
+			return
+		}
+
+		p.API.PublishWebSocketEvent(
+			constants.WSEventConnect,
+			nil,
+			&model.WebsocketBroadcast{UserId: mattermostUserID},
+		)
+		p.sendDeviceCodeFlowDM(mattermostUserID, fmt.Sprintf("%s\n\n%s", constants.UserConnected, p.getHelpText()))
+		return
+	}
+}
+
+// IsDeviceCodeConnection checks if the account of a user is connected with the device code flow, whose token is refreshed differently
+func (p *Plugin) IsDeviceCodeConnection(mattermostUserID string) bool {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorLoadingUserData, "Error", err.Error())
+		return false
+	}
+
+	user, err := p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorLoadingUserData, "Error", err.Error())
+		return false
+	}
+
+	return user.AuthType == constants.AuthTypeDeviceCode
+}
+
+// RefreshDeviceCodeToken refreshes the token of an account connected with the device code flow
+func (p *Plugin) RefreshDeviceCodeToken(mattermostUserID, refreshToken string) error {
+	token, _, _, err := p.Client.GenerateDeviceCodeToken(url.Values{
+		"client_id":     {p.getConfiguration().DeviceCodeClientID},
+		"grant_type":    {constants.GrantTypeRefresh},
+		"refresh_token": {refreshToken},
+		"scope":         {constants.DeviceCodeScopes},
+	})
+	if err != nil {
+		if _, DMErr := p.DM(mattermostUserID, constants.GenericErrorMessage, false); DMErr != nil {
+			return DMErr
+		}
+		return errors.Wrap(err, "failed to refresh the device code token")
+	}
+
+	return p.storeOAuthToken(mattermostUserID, getDeviceCodeOAuthResponse(token), constants.AuthTypeDeviceCode, "", true)
+}
+
+// getDeviceCodeOAuthResponse converts the token of the device code flow to the token of the browser redirect flow so that it's stored the same way.
+// The scopes are not kept as they are granted for the Azure DevOps resource instead of the scopes checked by the plugin.
+func getDeviceCodeOAuthResponse(token *serializers.DeviceCodeTokenResponse) *serializers.OAuthSuccessResponse {
+	return &serializers.OAuthSuccessResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    strconv.Itoa(token.ExpiresIn),
+	}
+}
+
+func (p *Plugin) endDeviceCodeFlow(mattermostUserID string) {
+	if err := p.Store.DeleteDeviceCodeFlow(mattermostUserID); err != nil {
+		p.API.LogError("Error in deleting the device code flow", "Error", err.Error())
+	}
+}
+
+func (p *Plugin) sendDeviceCodeFlowDM(mattermostUserID, message string) {
+	if _, err := p.DM(mattermostUserID, message, false); err != nil {
+		p.API.LogError(constants.UnableToDMBot, "Error", err.Error())
+	}
+}
@@ -0,0 +1,240 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestStartDeviceCodeFlow(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+	// The polling started by a successful flow is stopped right away
+	p.deviceCodeFlowsDone = make(chan struct{})
+	close(p.deviceCodeFlowsDone)
+	mockedStore.EXPECT().DeleteDeviceCodeFlow(testutils.MockMattermostUserID).Return(nil).AnyTimes()
+
+	for _, testCase := range []struct {
+		description           string
+		clientID              string
+		isConnected           bool
+		isStarted             bool
+		startError            error
+		deviceCodeError       error
+		expectedMessage       string
+		expectedError         string
+		expectDeviceCodeCall  bool
+		expectStartFlowCalled bool
+	}{
+		{
+			description:     "StartDeviceCodeFlow: device code flow is not enabled",
+			expectedMessage: constants.DeviceCodeNotEnabled,
+		},
+		{
+			description:     "StartDeviceCodeFlow: user is already connected",
+			clientID:        "mockClientID",
+			isConnected:     true,
+			expectedMessage: constants.MattermostUserAlreadyConnected,
+		},
+		{
+			description:           "StartDeviceCodeFlow: flow is already in progress",
+			clientID:              "mockClientID",
+			expectStartFlowCalled: true,
+			expectedMessage:       constants.DeviceCodeAlreadyInProgress,
+		},
+		{
+			description:           "StartDeviceCodeFlow: error in starting the flow",
+			clientID:              "mockClientID",
+			startError:            errors.New("error in starting the flow"),
+			expectStartFlowCalled: true,
+			expectedError:         "failed to start the device code flow: error in starting the flow",
+		},
+		{
+			description:           "StartDeviceCodeFlow: error in generating the device code",
+			clientID:              "mockClientID",
+			isStarted:             true,
+			deviceCodeError:       errors.New("error in generating the device code"),
+			expectStartFlowCalled: true,
+			expectDeviceCodeCall:  true,
+			expectedError:         "error in generating the device code",
+		},
+		{
+			description:           "StartDeviceCodeFlow: success",
+			clientID:              "mockClientID",
+			isStarted:             true,
+			expectStartFlowCalled: true,
+			expectDeviceCodeCall:  true,
+			expectedMessage:       fmt.Sprintf(constants.DeviceCodeInstructions, "https://microsoft.com/devicelogin", "mockUserCode", 15),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p.setConfiguration(&config.Configuration{DeviceCodeClientID: testCase.clientID})
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "MattermostUserAlreadyConnected", func(_ *Plugin, _ string) bool {
+				return testCase.isConnected
+			})
+
+			if testCase.expectStartFlowCalled {
+				mockedStore.EXPECT().StartDeviceCodeFlow(testutils.MockMattermostUserID).Return(testCase.isStarted, testCase.startError)
+			}
+
+			if testCase.expectDeviceCodeCall {
+				var deviceCode *serializers.DeviceCodeResponse
+				if testCase.deviceCodeError == nil {
+					deviceCode = &serializers.DeviceCodeResponse{
+						DeviceCode:      "mockDeviceCode",
+						UserCode:        "mockUserCode",
+						VerificationURI: "https://microsoft.com/devicelogin",
+						ExpiresIn:       900,
+						Interval:        5,
+					}
+				}
+				mockedClient.EXPECT().GenerateDeviceCode(gomock.Any()).Return(deviceCode, http.StatusOK, testCase.deviceCodeError)
+			}
+
+			message, err := p.startDeviceCodeFlow(testutils.MockMattermostUserID)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
+
+func TestPollDeviceCodeToken(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.deviceCodeFlowsDone = make(chan struct{})
+	p.setConfiguration(&config.Configuration{DeviceCodeClientID: "mockClientID"})
+
+	mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return()
+	mockAPI.On("PublishWebSocketEvent", constants.WSEventConnect, mock.Anything, mock.Anything).Return()
+
+	// The polling doesn't wait for the interval in the tests
+	monkey.Patch(time.After, func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "Encrypt", func(_ *Plugin, _, _ []byte) ([]byte, error) {
+		return nil, nil
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "Encode", func(_ *Plugin, _ []byte) string {
+		return ""
+	})
+
+	deviceCode := &serializers.DeviceCodeResponse{
+		DeviceCode: "mockDeviceCode",
+		ExpiresIn:  900,
+		Interval:   5,
+	}
+
+	for _, testCase := range []struct {
+		description     string
+		deviceCode      *serializers.DeviceCodeResponse
+		errorCodes      []string
+		tokenError      error
+		expectedMessage string
+		expectStored    bool
+	}{
+		{
+			description:     "PollDeviceCodeToken: token is generated after the user enters the code",
+			deviceCode:      deviceCode,
+			errorCodes:      []string{constants.DeviceCodeAuthorizationPending, constants.DeviceCodeSlowDown, ""},
+			expectedMessage: fmt.Sprintf("%s\n\n%s", constants.UserConnected, p.getHelpText()),
+			expectStored:    true,
+		},
+		{
+			description:     "PollDeviceCodeToken: device code is expired",
+			deviceCode:      deviceCode,
+			errorCodes:      []string{constants.DeviceCodeAuthorizationPending, constants.DeviceCodeExpiredToken},
+			tokenError:      errors.New("device code is expired"),
+			expectedMessage: constants.DeviceCodeExpired,
+		},
+		{
+			description:     "PollDeviceCodeToken: user declined the authorization",
+			deviceCode:      deviceCode,
+			errorCodes:      []string{constants.DeviceCodeAuthorizationDeclined},
+			tokenError:      errors.New("user declined the authorization"),
+			expectedMessage: constants.DeviceCodeDeclined,
+		},
+		{
+			description:     "PollDeviceCodeToken: error in generating the token",
+			deviceCode:      deviceCode,
+			errorCodes:      []string{""},
+			tokenError:      errors.New("error in generating the token"),
+			expectedMessage: constants.GenericErrorMessage,
+		},
+		{
+			description:     "PollDeviceCodeToken: device code expires before the user enters the code",
+			deviceCode:      &serializers.DeviceCodeResponse{DeviceCode: "mockDeviceCode", ExpiresIn: -1},
+			expectedMessage: constants.DeviceCodeExpired,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var messages []string
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(_ *Plugin, _, format string, _ bool, _ ...interface{}) (string, error) {
+				messages = append(messages, format)
+				return "", nil
+			})
+
+			for i, errorCode := range testCase.errorCodes {
+				var token *serializers.DeviceCodeTokenResponse
+				var err error
+				if i == len(testCase.errorCodes)-1 {
+					err = testCase.tokenError
+					if err == nil {
+						token = &serializers.DeviceCodeTokenResponse{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken", ExpiresIn: 3600}
+					}
+				} else {
+					err = errors.New(errorCode)
+				}
+				mockedClient.EXPECT().GenerateDeviceCodeToken(gomock.Any()).Return(token, errorCode, http.StatusBadRequest, err)
+			}
+
+			if testCase.expectStored {
+				mockedClient.EXPECT().GetUserProfile(constants.CurrentAzureDevopsUserProfileID, "mockAccessToken").Return(&serializers.UserProfile{ID: "mockAzureDevopsUserID"}, http.StatusOK, nil)
+				mockedStore.EXPECT().LoadAzureDevopsUserDetails("mockAzureDevopsUserID").Return(&serializers.User{}, nil)
+				mockedStore.EXPECT().StoreAzureDevopsUserDetailsWithMattermostUserID(gomock.Any()).DoAndReturn(func(user *serializers.User) error {
+					assert.Equal(t, constants.AuthTypeDeviceCode, user.AuthType)
+					assert.Empty(t, user.Scopes)
+					return nil
+				})
+			}
+
+			mockedStore.EXPECT().DeleteDeviceCodeFlow(testutils.MockMattermostUserID).Return(nil)
+
+			p.pollDeviceCodeToken(testutils.MockMattermostUserID, testCase.deviceCode)
+
+			assert.Equal(t, []string{testCase.expectedMessage}, messages)
+		})
+	}
+}
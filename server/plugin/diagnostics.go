@@ -0,0 +1,214 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+const (
+	diagnosticPassed  = "Passed"
+	diagnosticFailed  = "Failed"
+	diagnosticSkipped = "Skipped"
+)
+
+// diagnosticResult is the result of a single check of the plugin diagnostics along with a hint to fix it if it has failed
+type diagnosticResult struct {
+	check   string
+	status  string
+	details string
+	hint    string
+}
+
+// getDiagnostics runs the checks of the plugin configuration and returns their results as a table.
+// Every check is run even if the previous ones have failed, so that all the problems are reported at once.
+func (p *Plugin) getDiagnostics(mattermostUserID string) string {
+	if !p.API.HasPermissionTo(mattermostUserID, model.PERMISSION_MANAGE_SYSTEM) {
+		return constants.ErrorDiagnosticsPermission
+	}
+
+	results := []*diagnosticResult{
+		p.checkOAuthSettings(),
+		p.checkEncryptionSecret(),
+		p.checkSiteURL(),
+		p.checkBotAccount(),
+		p.checkAuthenticatedCall(mattermostUserID),
+	}
+
+	failedChecks := 0
+	var sb strings.Builder
+	sb.WriteString("###### Azure DevOps plugin diagnostics\n")
+	sb.WriteString("| Check | Status | Details |\n")
+	sb.WriteString("| :---- | :----- | :------ |\n")
+	for _, result := range results {
+		details := escapeTableCell(result.details)
+		if result.hint != "" {
+			details = fmt.Sprintf("%s. %s", details, escapeTableCell(result.hint))
+		}
+		if result.status == diagnosticFailed {
+			failedChecks++
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", result.check, result.status, details))
+	}
+	sb.WriteString(fmt.Sprintf("\n%d of %d check(s) failed", failedChecks, len(results)))
+
+	return sb.String()
+}
+
+func (p *Plugin) checkOAuthSettings() *diagnosticResult {
+	config := p.getConfiguration()
+	var missingSettings []string
+	if config.AzureDevopsOAuthAppID == "" {
+		missingSettings = append(missingSettings, "Azure DevOps OAuth App ID")
+	}
+	if config.AzureDevopsOAuthClientSecret == "" {
+		missingSettings = append(missingSettings, "Azure DevOps OAuth Client Secret")
+	}
+
+	if len(missingSettings) > 0 {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckOAuthSettings,
+			status:  diagnosticFailed,
+			details: fmt.Sprintf(constants.DiagnosticMissingOAuthSettings, strings.Join(missingSettings, ", ")),
+			hint:    constants.DiagnosticMissingOAuthSettingsHint,
+		}
+	}
+
+	return &diagnosticResult{
+		check:   constants.DiagnosticCheckOAuthSettings,
+		status:  diagnosticPassed,
+		details: fmt.Sprintf(constants.DiagnosticOAuthCallbackURL, p.OAuthConfig().redirectURI),
+		hint:    constants.DiagnosticOAuthCallbackURLHint,
+	}
+}
+
+func (p *Plugin) checkEncryptionSecret() *diagnosticResult {
+	if p.getConfiguration().EncryptionSecret == "" {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckEncryptionSecret,
+			status:  diagnosticFailed,
+			details: constants.DiagnosticMissingEncryptionSecret,
+			hint:    constants.DiagnosticMissingEncryptionSecretHint,
+		}
+	}
+
+	return &diagnosticResult{
+		check:   constants.DiagnosticCheckEncryptionSecret,
+		status:  diagnosticPassed,
+		details: constants.DiagnosticEncryptionSecretConfigured,
+	}
+}
+
+// checkSiteURL checks if the Site URL is reachable from the Mattermost server itself, as Azure DevOps sends the OAuth callback and the notifications to it
+func (p *Plugin) checkSiteURL() *diagnosticResult {
+	siteURL := strings.TrimRight(p.GetSiteURL(), "/")
+	if siteURL == "" {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckSiteURL,
+			status:  diagnosticFailed,
+			details: constants.DiagnosticMissingSiteURL,
+			hint:    constants.DiagnosticMissingSiteURLHint,
+		}
+	}
+
+	if _, err := p.Client.PingURL(siteURL + constants.PathMattermostPing); err != nil {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckSiteURL,
+			status:  diagnosticFailed,
+			details: fmt.Sprintf(constants.DiagnosticSiteURLUnreachable, siteURL, err.Error()),
+			hint:    constants.DiagnosticSiteURLUnreachableHint,
+		}
+	}
+
+	return &diagnosticResult{
+		check:   constants.DiagnosticCheckSiteURL,
+		status:  diagnosticPassed,
+		details: fmt.Sprintf(constants.DiagnosticSiteURLReachable, siteURL),
+	}
+}
+
+func (p *Plugin) checkBotAccount() *diagnosticResult {
+	bot, appErr := p.API.GetUser(p.botUserID)
+	if appErr != nil {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckBotAccount,
+			status:  diagnosticFailed,
+			details: fmt.Sprintf(constants.DiagnosticBotAccountNotFound, appErr.Message),
+			hint:    constants.DiagnosticBotAccountNotFoundHint,
+		}
+	}
+
+	if bot.DeleteAt != 0 {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckBotAccount,
+			status:  diagnosticFailed,
+			details: fmt.Sprintf(constants.DiagnosticBotAccountDeactivated, bot.Username),
+			hint:    constants.DiagnosticBotAccountDeactivatedHint,
+		}
+	}
+
+	return &diagnosticResult{
+		check:   constants.DiagnosticCheckBotAccount,
+		status:  diagnosticPassed,
+		details: fmt.Sprintf(constants.DiagnosticBotAccountHealthy, bot.Username),
+	}
+}
+
+// checkAuthenticatedCall fetches the Azure DevOps profile of the user running the diagnostics with their access token, it's skipped if they are not connected
+func (p *Plugin) checkAuthenticatedCall(mattermostUserID string) *diagnosticResult {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil || azureDevopsUserID == "" {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckAuthenticatedCall,
+			status:  diagnosticSkipped,
+			details: constants.DiagnosticUserNotConnected,
+			hint:    constants.DiagnosticUserNotConnectedHint,
+		}
+	}
+
+	user, err := p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID)
+	if err != nil || user.AccessToken == "" {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckAuthenticatedCall,
+			status:  diagnosticSkipped,
+			details: constants.DiagnosticUserNotConnected,
+			hint:    constants.DiagnosticUserNotConnectedHint,
+		}
+	}
+
+	accessToken, err := p.ParseAuthToken(user.AccessToken)
+	if err != nil {
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckAuthenticatedCall,
+			status:  diagnosticFailed,
+			details: fmt.Sprintf(constants.DiagnosticInvalidAccessToken, err.Error()),
+			hint:    constants.DiagnosticInvalidAccessTokenHint,
+		}
+	}
+
+	profile, statusCode, err := p.Client.GetUserProfile(constants.CurrentAzureDevopsUserProfileID, accessToken)
+	if err != nil {
+		hint := constants.DiagnosticAuthenticatedCallFailedHint
+		if statusCode == http.StatusUnauthorized {
+			hint = constants.DiagnosticAuthenticatedCallUnauthorizedHint
+		}
+
+		return &diagnosticResult{
+			check:   constants.DiagnosticCheckAuthenticatedCall,
+			status:  diagnosticFailed,
+			details: fmt.Sprintf(constants.DiagnosticAuthenticatedCallFailed, statusCode, err.Error()),
+			hint:    hint,
+		}
+	}
+
+	return &diagnosticResult{
+		check:   constants.DiagnosticCheckAuthenticatedCall,
+		status:  diagnosticPassed,
+		details: fmt.Sprintf(constants.DiagnosticAuthenticatedCallSucceeded, profile.DisplayName),
+	}
+}
@@ -0,0 +1,102 @@
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.botUserID = "mockBotUserID"
+
+	mockAPI.On("HasPermissionTo", "mockUserID", model.PERMISSION_MANAGE_SYSTEM).Return(false)
+	mockAPI.On("HasPermissionTo", testutils.MockMattermostUserID, model.PERMISSION_MANAGE_SYSTEM).Return(true)
+
+	t.Run("GetDiagnostics: user is not a system admin", func(t *testing.T) {
+		assert.Equal(t, constants.ErrorDiagnosticsPermission, p.getDiagnostics("mockUserID"))
+	})
+
+	t.Run("GetDiagnostics: all the checks pass", func(t *testing.T) {
+		p.setConfiguration(&config.Configuration{
+			AzureDevopsAPIBaseURL:        "https://dev.azure.com",
+			AzureDevopsOAuthAppID:        "mockAppID",
+			AzureDevopsOAuthClientSecret: "mockClientSecret",
+			EncryptionSecret:             "mockEncryptionSecret",
+			MattermostSiteURL:            "https://mattermost.example.com",
+		})
+		monkey.PatchInstanceMethod(reflect.TypeOf(p), "ParseAuthToken", func(_ *Plugin, _ string) (string, error) {
+			return "mockAccessToken", nil
+		})
+
+		mockAPI.On("GetUser", "mockBotUserID").Return(&model.User{Username: "azuredevops"}, nil).Once()
+		mockedClient.EXPECT().PingURL("https://mattermost.example.com/api/v4/system/ping").Return(http.StatusOK, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{AccessToken: "mockEncryptedAccessToken"}, nil)
+		mockedClient.EXPECT().GetUserProfile(constants.CurrentAzureDevopsUserProfileID, "mockAccessToken").Return(&serializers.UserProfile{DisplayName: "Mock User"}, http.StatusOK, nil)
+
+		diagnostics := p.getDiagnostics(testutils.MockMattermostUserID)
+
+		assert.Contains(t, diagnostics, "| OAuth settings | Passed | Callback URL is `https://mattermost.example.com/plugins/mattermost-plugin-azure-devops/api/v1/oauth/complete`.")
+		assert.Contains(t, diagnostics, "| Encryption secret | Passed | Configured |\n")
+		assert.Contains(t, diagnostics, "| Site URL | Passed | `https://mattermost.example.com` is reachable |\n")
+		assert.Contains(t, diagnostics, "| Bot account | Passed | @azuredevops is active |\n")
+		assert.Contains(t, diagnostics, "| Authenticated call | Passed | Connected as Mock User |\n")
+		assert.Contains(t, diagnostics, "0 of 5 check(s) failed")
+	})
+
+	t.Run("GetDiagnostics: failed checks don't stop the other checks", func(t *testing.T) {
+		p.setConfiguration(&config.Configuration{
+			AzureDevopsAPIBaseURL: "https://dev.azure.com",
+			MattermostSiteURL:     "https://mattermost.example.com",
+		})
+
+		mockAPI.On("GetUser", "mockBotUserID").Return(&model.User{Username: "azuredevops", DeleteAt: 1}, nil).Once()
+		mockedClient.EXPECT().PingURL("https://mattermost.example.com/api/v4/system/ping").Return(http.StatusInternalServerError, errors.New("connection refused"))
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{AccessToken: "mockEncryptedAccessToken"}, nil)
+		mockedClient.EXPECT().GetUserProfile(constants.CurrentAzureDevopsUserProfileID, "mockAccessToken").Return(nil, http.StatusUnauthorized, errors.New("unauthorized"))
+
+		diagnostics := p.getDiagnostics(testutils.MockMattermostUserID)
+
+		assert.Contains(t, diagnostics, "| OAuth settings | Failed | Azure DevOps OAuth App ID, Azure DevOps OAuth Client Secret not set. "+constants.DiagnosticMissingOAuthSettingsHint+" |\n")
+		assert.Contains(t, diagnostics, "| Encryption secret | Failed | Not set. "+constants.DiagnosticMissingEncryptionSecretHint+" |\n")
+		assert.Contains(t, diagnostics, "| Site URL | Failed | `https://mattermost.example.com` is not reachable from the Mattermost server: connection refused. ")
+		assert.Contains(t, diagnostics, "| Bot account | Failed | @azuredevops is deactivated. "+constants.DiagnosticBotAccountDeactivatedHint+" |\n")
+		assert.Contains(t, diagnostics, "| Authenticated call | Failed | Fetching your Azure DevOps profile failed with status 401: unauthorized. "+constants.DiagnosticAuthenticatedCallUnauthorizedHint+" |\n")
+		assert.Contains(t, diagnostics, "5 of 5 check(s) failed")
+	})
+
+	t.Run("GetDiagnostics: authenticated call is skipped if the user is not connected", func(t *testing.T) {
+		p.setConfiguration(&config.Configuration{})
+
+		mockAPI.On("GetUser", "mockBotUserID").Return(nil, &model.AppError{Message: "user not found"}).Once()
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("", nil)
+
+		diagnostics := p.getDiagnostics(testutils.MockMattermostUserID)
+
+		assert.Contains(t, diagnostics, "| Site URL | Failed | Not set. "+constants.DiagnosticMissingSiteURLHint+" |\n")
+		assert.Contains(t, diagnostics, "| Bot account | Failed | The bot account is not found: user not found. ")
+		assert.Contains(t, diagnostics, "| Authenticated call | Skipped | Your account is not connected. "+constants.DiagnosticUserNotConnectedHint+" |\n")
+		assert.Contains(t, diagnostics, "4 of 5 check(s) failed")
+	})
+}
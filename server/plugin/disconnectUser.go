@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getDisconnectUserConfirmation returns the buttons confirming the disconnection of the Azure DevOps account of a user,
+// or the message shown to the user if they don't have any account connected
+func (p *Plugin) getDisconnectUserConfirmation(mattermostUserID string) (*model.SlackAttachment, string, error) {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if azureDevopsUserID == "" {
+		return nil, constants.NothingToDisconnect, nil
+	}
+
+	text := constants.DisconnectUserConfirmation
+	if user, err := p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID); err != nil {
+		p.API.LogDebug("Error in loading the details of the connected account", "Error", err.Error())
+	} else if user.Email != "" {
+		text = fmt.Sprintf("Connected account: **%s**\n%s", user.Email, text)
+	}
+
+	actionURL := fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathDisconnectUser)
+	return &model.SlackAttachment{
+		Title: "Disconnect your Azure DevOps account",
+		Text:  text,
+		Color: constants.IconColorBoards,
+		Actions: []*model.PostAction{
+			{
+				Id:    constants.DisconnectUserActionConfirm,
+				Type:  model.POST_ACTION_TYPE_BUTTON,
+				Name:  "Disconnect",
+				Style: "danger",
+				Integration: &model.PostActionIntegration{
+					URL:     actionURL,
+					Context: map[string]interface{}{constants.DisconnectUserContextAction: constants.DisconnectUserActionConfirm},
+				},
+			},
+			{
+				Id:   constants.DisconnectUserActionCancel,
+				Type: model.POST_ACTION_TYPE_BUTTON,
+				Name: "Cancel",
+				Integration: &model.PostActionIntegration{
+					URL:     actionURL,
+					Context: map[string]interface{}{constants.DisconnectUserContextAction: constants.DisconnectUserActionCancel},
+				},
+			},
+		},
+	}, "", nil
+}
+
+// handleDisconnectUser handles the buttons confirming or canceling the disconnection and replaces the confirmation with the result.
+// Like for the reset, the user is taken from the request so that a user can only disconnect their own account.
+func (p *Plugin) handleDisconnectUser(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	message := constants.DisconnectUserCanceled
+	if action, _ := postActionIntegrationRequest.Context[constants.DisconnectUserContextAction].(string); action == constants.DisconnectUserActionConfirm {
+		disconnectMessage, err := p.disconnectUser(mattermostUserID)
+		if err != nil {
+			p.API.LogError(constants.UnableToDisconnectUser, "Error", err.Error())
+			disconnectMessage = constants.GenericErrorMessage
+		}
+		message = disconnectMessage
+	}
+
+	p.returnPostActionIntegrationResponse(w, &model.PostActionIntegrationResponse{
+		Update: &model.Post{
+			Id:        postActionIntegrationRequest.PostId,
+			UserId:    p.botUserID,
+			ChannelId: postActionIntegrationRequest.ChannelId,
+			Message:   message,
+		},
+	})
+}
+
+// disconnectUser deletes the connected account of a user and lets the webapp know about it.
+// The connection is checked again, as the account may have been disconnected since the confirmation was posted.
+func (p *Plugin) disconnectUser(mattermostUserID string) (string, error) {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	if azureDevopsUserID == "" {
+		return constants.NothingToDisconnect, nil
+	}
+
+	if _, err := p.Store.DeleteUser(mattermostUserID); err != nil {
+		return "", err
+	}
+
+	p.API.PublishWebSocketEvent(constants.WSEventDisconnect, nil, &model.WebsocketBroadcast{UserId: mattermostUserID})
+	return constants.UserDisconnected, nil
+}
@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetDisconnectUserConfirmation(t *testing.T) {
+	t.Run("GetDisconnectUserConfirmation: connected account is shown", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{UserProfile: serializers.UserProfile{Email: "mock@example.com"}}, nil)
+
+		attachment, message, err := p.getDisconnectUserConfirmation(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, message)
+		require.NotNil(t, attachment)
+		assert.Equal(t, "Connected account: **mock@example.com**\n"+constants.DisconnectUserConfirmation, attachment.Text)
+		require.Len(t, attachment.Actions, 2)
+		assert.Equal(t, map[string]interface{}{constants.DisconnectUserContextAction: constants.DisconnectUserActionConfirm}, attachment.Actions[0].Integration.Context)
+	})
+
+	t.Run("GetDisconnectUserConfirmation: confirmation is asked even if the account details can't be loaded", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(nil, errors.New("error loading the user"))
+		mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
+
+		attachment, message, err := p.getDisconnectUserConfirmation(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, message)
+		require.NotNil(t, attachment)
+		assert.Equal(t, constants.DisconnectUserConfirmation, attachment.Text)
+	})
+
+	t.Run("GetDisconnectUserConfirmation: user is not connected", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("", nil)
+
+		attachment, message, err := p.getDisconnectUserConfirmation(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, attachment)
+		assert.Equal(t, constants.NothingToDisconnect, message)
+	})
+}
+
+func TestHandleDisconnectUser(t *testing.T) {
+	for _, testCase := range []struct {
+		description       string
+		action            string
+		azureDevopsUserID string
+		deleteUserError   error
+		expectedMessage   string
+	}{
+		{
+			description:       "HandleDisconnectUser: account of the user clicking the button is disconnected",
+			action:            constants.DisconnectUserActionConfirm,
+			azureDevopsUserID: testutils.MockAzureDevopsUserID,
+			expectedMessage:   constants.UserDisconnected,
+		},
+		{
+			description:     "HandleDisconnectUser: account was already disconnected",
+			action:          constants.DisconnectUserActionConfirm,
+			expectedMessage: constants.NothingToDisconnect,
+		},
+		{
+			description:       "HandleDisconnectUser: error in deleting the user",
+			action:            constants.DisconnectUserActionConfirm,
+			azureDevopsUserID: testutils.MockAzureDevopsUserID,
+			deleteUserError:   errors.New("error deleting the user"),
+			expectedMessage:   constants.GenericErrorMessage,
+		},
+		{
+			description:     "HandleDisconnectUser: disconnection is canceled",
+			action:          constants.DisconnectUserActionCancel,
+			expectedMessage: constants.DisconnectUserCanceled,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			if testCase.action == constants.DisconnectUserActionConfirm {
+				mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testCase.azureDevopsUserID, nil)
+				if testCase.azureDevopsUserID != "" {
+					mockedStore.EXPECT().DeleteUser(testutils.MockMattermostUserID).Return(testCase.deleteUserError == nil, testCase.deleteUserError)
+				}
+				if testCase.deleteUserError != nil {
+					mockAPI.On("LogError", constants.UnableToDisconnectUser, "Error", testCase.deleteUserError.Error()).Once()
+				}
+				if testCase.expectedMessage == constants.UserDisconnected {
+					mockAPI.On("PublishWebSocketEvent", constants.WSEventDisconnect, mock.Anything, &model.WebsocketBroadcast{UserId: testutils.MockMattermostUserID}).Once()
+				}
+			}
+
+			// A user ID in the context is ignored, the account of the user of the request is disconnected
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{
+				PostId: "mockPostID",
+				Context: map[string]interface{}{
+					constants.DisconnectUserContextAction: testCase.action,
+					"mattermostUserID":                    "mockOtherMattermostUserID",
+				},
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, constants.PathDisconnectUser, bytes.NewBuffer(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleDisconnectUser(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var response *model.PostActionIntegrationResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			require.NotNil(t, response.Update)
+			assert.Equal(t, "mockPostID", response.Update.Id)
+			assert.Equal(t, testCase.expectedMessage, response.Update.Message)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
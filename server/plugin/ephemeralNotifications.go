@@ -0,0 +1,34 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// sendEphemeralNotification shows the notification of a subscription only to its creator in the channel of the subscription.
+// Ephemeral posts are only delivered to the active sessions of a user, so the notification is sent as a direct message instead if the creator is offline.
+func (p *Plugin) sendEphemeralNotification(post *model.Post, subscription *serializers.SubscriptionDetails, channel *model.Channel) {
+	status, appErr := p.API.GetUserStatus(subscription.MattermostUserID)
+	if appErr != nil {
+		p.API.LogDebug("Error in getting the status of the creator of the subscription", "Error", appErr.Error())
+	} else if status.Status != model.STATUS_OFFLINE {
+		p.API.SendEphemeralPost(subscription.MattermostUserID, post)
+		return
+	}
+
+	directChannel, appErr := p.API.GetDirectChannel(subscription.MattermostUserID, p.botUserID)
+	if appErr != nil {
+		p.API.LogError("Couldn't get bot's DM channel", "userID", subscription.MattermostUserID, "Error", appErr.Error())
+		return
+	}
+
+	post.ChannelId = directChannel.Id
+	post.Message = fmt.Sprintf(constants.EphemeralNotificationDM, channel.Name)
+	if _, appErr := p.API.CreatePost(post); appErr != nil {
+		p.API.LogError("Error in creating post", "Error", appErr.Error())
+	}
+}
@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestSendEphemeralNotification(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{MattermostUserID: testutils.MockMattermostUserID}
+	channel := &model.Channel{Id: testutils.MockChannelID, Name: "mock-channel"}
+	for _, testCase := range []struct {
+		description     string
+		status          *model.Status
+		statusErr       *model.AppError
+		isDirectMessage bool
+	}{
+		{
+			description: "SendEphemeralNotification: creator is online",
+			status:      &model.Status{Status: model.STATUS_ONLINE},
+		},
+		{
+			description: "SendEphemeralNotification: creator is away",
+			status:      &model.Status{Status: model.STATUS_AWAY},
+		},
+		{
+			description:     "SendEphemeralNotification: creator is offline",
+			status:          &model.Status{Status: model.STATUS_OFFLINE},
+			isDirectMessage: true,
+		},
+		{
+			description:     "SendEphemeralNotification: status of the creator can't be fetched",
+			statusErr:       &model.AppError{Message: "error in getting the status"},
+			isDirectMessage: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p := setupMockPlugin(mockAPI, nil, nil)
+			mockAPI.On("GetUserStatus", testutils.MockMattermostUserID).Return(testCase.status, testCase.statusErr)
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), "Error", mock.AnythingOfType("string")).Maybe()
+
+			var ephemeralPost, directPost *model.Post
+			mockAPI.On("SendEphemeralPost", testutils.MockMattermostUserID, mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+				ephemeralPost = args.Get(1).(*model.Post)
+			}).Return(&model.Post{}).Maybe()
+			mockAPI.On("GetDirectChannel", testutils.MockMattermostUserID, mock.AnythingOfType("string")).Return(&model.Channel{Id: "mockDirectChannelID"}, nil).Maybe()
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+				directPost = args.Get(0).(*model.Post)
+			}).Return(&model.Post{}, nil).Maybe()
+
+			p.sendEphemeralNotification(&model.Post{ChannelId: testutils.MockChannelID}, subscription, channel)
+
+			if testCase.isDirectMessage {
+				assert.Nil(t, ephemeralPost)
+				assert.Equal(t, "mockDirectChannelID", directPost.ChannelId)
+				assert.Equal(t, fmt.Sprintf(constants.EphemeralNotificationDM, "mock-channel"), directPost.Message)
+			} else {
+				assert.Nil(t, directPost)
+				assert.Equal(t, testutils.MockChannelID, ephemeralPost.ChannelId)
+			}
+		})
+	}
+}
+
+func TestHandleSubscriptionNotificationsWithVisibility(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description     string
+		visibility      string
+		isPosted        bool
+		isEphemeralSent bool
+	}{
+		{
+			description: "SubscriptionNotificationsWithVisibility: notification is posted in the channel by default",
+			isPosted:    true,
+		},
+		{
+			description: "SubscriptionNotificationsWithVisibility: notification is posted in the channel",
+			visibility:  constants.SubscriptionVisibilityChannel,
+			isPosted:    true,
+		},
+		{
+			description:     "SubscriptionNotificationsWithVisibility: notification is only shown to the creator",
+			visibility:      constants.SubscriptionVisibilityEphemeral,
+			isEphemeralSent: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{{
+				SubscriptionID:   testutils.MockSubscriptionID,
+				MattermostUserID: testutils.MockMattermostUserID,
+				Visibility:       testCase.visibility,
+			}}, nil)
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+			mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+			isPosted, isEphemeralSent := false, false
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(mock.Arguments) {
+				isPosted = true
+			}).Return(&model.Post{}, nil)
+			mockAPI.On("GetUserStatus", testutils.MockMattermostUserID).Return(&model.Status{Status: model.STATUS_ONLINE}, nil)
+			mockAPI.On("SendEphemeralPost", testutils.MockMattermostUserID, mock.AnythingOfType("*model.Post")).Run(func(mock.Arguments) {
+				isEphemeralSent = true
+			}).Return(&model.Post{})
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return testutils.MockChannelID, http.StatusOK, nil
+			})
+
+			body := `{
+				"subscriptionID": "mockSubscriptionID",
+				"eventType": "git.pullrequest.created",
+				"resource": {"pullRequestId": 1, "targetRefName": "refs/heads/main", "sourceRefName": "refs/heads/mockBranch"},
+				"message": {"markdown": "mockMarkdown"}
+			}`
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(body))
+
+			w := httptest.NewRecorder()
+			p.handleSubscriptionNotifications(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, testCase.isPosted, isPosted)
+			assert.Equal(t, testCase.isEphemeralSent, isEphemeralSent)
+		})
+	}
+}
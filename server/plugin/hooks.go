@@ -4,6 +4,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
 )
 
@@ -26,6 +27,18 @@ func (p *Plugin) OnConfigurationChange() error {
 		return err
 	}
 
+	if err := p.validateOrganizationDefaultChannels(configuration); err != nil {
+		p.API.LogError("Error in validating the organization default channels.", "Error", err.Error())
+		return err
+	}
+
+	// The CA certificates are loaded once here instead of on every request, the transport of the HTTP client is rebuilt when they change
+	tlsConfig, err := p.buildTLSConfig(configuration)
+	if err != nil {
+		p.API.LogError("Error in loading the CA certificates.", "Error", err.Error())
+		return err
+	}
+
 	oldEncryptionSecret := p.getConfiguration().EncryptionSecret
 	mattermostSiteURL := p.API.GetConfig().ServiceSettings.SiteURL
 	if mattermostSiteURL == nil {
@@ -33,6 +46,7 @@ func (p *Plugin) OnConfigurationChange() error {
 	}
 	configuration.MattermostSiteURL = *mattermostSiteURL
 	p.setConfiguration(configuration)
+	p.setTLSConfig(tlsConfig)
 
 	if oldEncryptionSecret != "" && oldEncryptionSecret != p.getConfiguration().EncryptionSecret {
 		if err := p.Store.DeleteUserTokenOnEncryptionSecretChange(); err != nil {
@@ -63,8 +77,43 @@ func (p *Plugin) OnActivate() error {
 		return errors.Wrap(err, "failed to register command")
 	}
 
-	p.Store = store.NewStore(p.API)
+	p.projectLists = newProjectListCache(constants.ProjectListCacheMaxSize)
+	p.Store = &projectListCacheStore{KVStore: store.NewStore(p.API), cache: p.projectLists, getTTL: p.getProjectListCacheTTL}
+	p.writeRateLimiter = newWriteRateLimiter()
 	p.router = p.InitAPI()
 	p.InitRoutes()
+
+	p.retryQueueJob = p.scheduleJob(constants.RetryQueueJobKey, waitForInterval(constants.RetryQueueJobInterval), p.processRetryQueue)
+	// The job runs on a single node of the cluster, so every summary is posted once
+	p.weeklySummaryJob = p.scheduleJob(constants.WeeklySummaryJobKey, waitForInterval(constants.WeeklySummaryJobInterval), p.processWeeklySummaries)
+	p.pendingWebhookDeletionsJob = p.scheduleJob(constants.PendingWebhookDeletionsJobKey, waitForInterval(constants.PendingWebhookDeletionsJobInterval), p.processPendingWebhookDeletions)
+	p.subscriptionReconciliationJob = p.scheduleJob(constants.SubscriptionReconciliationJobKey, p.getSubscriptionReconciliationWaitInterval, p.reconcileSubscriptions)
+	p.deviceCodeFlowsDone = make(chan struct{})
+
+	return nil
+}
+
+// Invoked when the plugin is deactivated
+func (p *Plugin) OnDeactivate() error {
+	if p.deviceCodeFlowsDone != nil {
+		close(p.deviceCodeFlowsDone)
+	}
+
+	if p.retryQueueJob != nil {
+		p.retryQueueJob.Close()
+	}
+
+	if p.weeklySummaryJob != nil {
+		p.weeklySummaryJob.Close()
+	}
+
+	if p.pendingWebhookDeletionsJob != nil {
+		p.pendingWebhookDeletionsJob.Close()
+	}
+
+	if p.subscriptionReconciliationJob != nil {
+		p.subscriptionReconciliationJob.Close()
+	}
+
 	return nil
 }
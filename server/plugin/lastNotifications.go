@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getLastNotificationPost renders the last notification of a subscription again as a post in the given channel.
+// The post is nil if the user can't view the notification or there is no notification to show, in which case the message explains why.
+func (p *Plugin) getLastNotificationPost(mattermostUserID, channelID, subscriptionID string) (*model.Post, string, error) {
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		return nil, "", errors.Wrap(err, constants.FetchSubscriptionListError)
+	}
+
+	var subscription *serializers.SubscriptionDetails
+	for _, storedSubscription := range subscriptionList {
+		if storedSubscription.SubscriptionID == subscriptionID {
+			subscription = storedSubscription
+			break
+		}
+	}
+	if subscription == nil {
+		return nil, fmt.Sprintf(constants.SubscriptionNotFoundWithID, subscriptionID), nil
+	}
+
+	// The notifications are posted in the channel of the subscription, so only its members can view them
+	if _, appErr := p.API.GetChannelMember(subscription.ChannelID, mattermostUserID); appErr != nil {
+		return nil, fmt.Sprintf(constants.ErrorLastNotificationPermission, subscriptionID), nil
+	}
+
+	notification, err := p.Store.GetLastNotification(subscriptionID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, constants.ErrorFetchLastNotification)
+	}
+	if notification == nil {
+		return nil, fmt.Sprintf(constants.NoLastNotification, subscriptionID), nil
+	}
+
+	attachment, err := p.getSubscriptionNotificationAttachment(subscription, notification, p.getChannelNotificationPrefs(subscription.ChannelID))
+	if err != nil {
+		return nil, "", err
+	}
+	if attachment == nil {
+		return nil, fmt.Sprintf(constants.NoLastNotification, subscriptionID), nil
+	}
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: channelID,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+
+	return post, "", nil
+}
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetLastNotificationPost(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.botUserID = "mockBotUserID"
+
+	subscriptionList := []*serializers.SubscriptionDetails{
+		{SubscriptionID: testutils.MockSubscriptionID, ChannelID: testutils.MockChannelID, Label: "Billing"},
+	}
+	mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+	mockAPI.On("GetChannelMember", testutils.MockChannelID, testutils.MockMattermostUserID).Return(&model.ChannelMember{}, nil)
+	mockAPI.On("GetChannelMember", testutils.MockChannelID, "mockUserID").Return(nil, &model.AppError{Message: "channel member not found"})
+
+	t.Run("GetLastNotificationPost: last notification is rendered in the current channel", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+		mockedStore.EXPECT().GetLastNotification(testutils.MockSubscriptionID).Return(&serializers.SubscriptionNotification{
+			SubscriptionID: testutils.MockSubscriptionID,
+			EventType:      constants.SubscriptionEventCodePushed,
+			Message:        serializers.DetailedMessage{Markdown: "mockMarkdown"},
+			Resource: serializers.Resource{
+				RefUpdates: []serializers.RefUpdates{{Name: "refs/heads/main"}},
+				Repository: serializers.Repository{Name: "mockRepository"},
+			},
+		}, nil)
+
+		post, message, err := p.getLastNotificationPost(testutils.MockMattermostUserID, "mockCurrentChannelID", testutils.MockSubscriptionID)
+
+		require.NoError(t, err)
+		assert.Empty(t, message)
+		require.NotNil(t, post)
+		assert.Equal(t, "mockCurrentChannelID", post.ChannelId)
+		assert.Equal(t, "mockBotUserID", post.UserId)
+		attachments := post.Attachments()
+		require.Len(t, attachments, 1)
+		assert.Equal(t, "🔵 [Billing] mockMarkdown", attachments[0].Pretext)
+		assert.Equal(t, "main | mockRepository", attachments[0].Footer)
+	})
+
+	t.Run("GetLastNotificationPost: subscription has not sent any notifications", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+		mockedStore.EXPECT().GetLastNotification(testutils.MockSubscriptionID).Return(nil, nil)
+
+		post, message, err := p.getLastNotificationPost(testutils.MockMattermostUserID, testutils.MockChannelID, testutils.MockSubscriptionID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, post)
+		assert.Equal(t, `Subscription "mockSubscriptionID" has not sent any notifications yet`, message)
+	})
+
+	t.Run("GetLastNotificationPost: user is not a member of the channel of the subscription", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+
+		post, message, err := p.getLastNotificationPost("mockUserID", testutils.MockChannelID, testutils.MockSubscriptionID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, post)
+		assert.Equal(t, `Only the members of the channel of subscription "mockSubscriptionID" can view its notifications`, message)
+	})
+
+	t.Run("GetLastNotificationPost: subscription does not exist", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+
+		post, message, err := p.getLastNotificationPost(testutils.MockMattermostUserID, testutils.MockChannelID, "mockOtherSubscriptionID")
+
+		assert.NoError(t, err)
+		assert.Nil(t, post)
+		assert.Equal(t, `Subscription "mockOtherSubscriptionID" does not exist`, message)
+	})
+
+	t.Run("GetLastNotificationPost: error in fetching the last notification", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+		mockedStore.EXPECT().GetLastNotification(testutils.MockSubscriptionID).Return(nil, errors.New("error in loading the notification"))
+
+		post, _, err := p.getLastNotificationPost(testutils.MockMattermostUserID, testutils.MockChannelID, testutils.MockSubscriptionID)
+
+		assert.EqualError(t, err, constants.ErrorFetchLastNotification+": error in loading the notification")
+		assert.Nil(t, post)
+	})
+}
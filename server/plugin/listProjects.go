@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// getLinkedProjectsList lists the projects linked by a user sorted by their organization and then by their name, so the list is the same every time
+func (p *Plugin) getLinkedProjectsList(mattermostUserID string) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	if len(projectList) == 0 {
+		return constants.NoLinkedProjects, nil
+	}
+
+	sort.Slice(projectList, func(i, j int) bool {
+		organizationI, organizationJ := strings.ToLower(projectList[i].OrganizationName), strings.ToLower(projectList[j].OrganizationName)
+		if organizationI != organizationJ {
+			return organizationI < organizationJ
+		}
+		return strings.ToLower(projectList[i].ProjectName) < strings.ToLower(projectList[j].ProjectName)
+	})
+
+	var sb strings.Builder
+	sb.WriteString(constants.LinkedProjectsTitle + "\n")
+	sb.WriteString("| Organization | Project |\n")
+	sb.WriteString("| :----------- | :------ |\n")
+	for _, project := range projectList {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", escapeTableCell(project.OrganizationName), escapeTableCell(project.ProjectName)))
+	}
+
+	return sb.String(), nil
+}
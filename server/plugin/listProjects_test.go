@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetLinkedProjectsList(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		projectList     []serializers.ProjectDetails
+		err             error
+		expectedMessage string
+		expectedError   string
+	}{
+		{
+			description: "GetLinkedProjectsList: projects are sorted by organization and then by project",
+			projectList: []serializers.ProjectDetails{
+				{OrganizationName: "zeta", ProjectName: "Alpha"},
+				{OrganizationName: "Beta", ProjectName: "web|app"},
+				{OrganizationName: "beta", ProjectName: "API"},
+			},
+			expectedMessage: constants.LinkedProjectsTitle + "\n" +
+				"| Organization | Project |\n" +
+				"| :----------- | :------ |\n" +
+				"| beta | API |\n" +
+				"| Beta | web\\|app |\n" +
+				"| zeta | Alpha |\n",
+		},
+		{
+			description:     "GetLinkedProjectsList: no linked projects",
+			expectedMessage: constants.NoLinkedProjects,
+		},
+		{
+			description:   "GetLinkedProjectsList: error in fetching the projects",
+			err:           errors.New("error fetching the projects"),
+			expectedError: constants.ErrorFetchProjectList + ": error fetching the projects",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projectList, testCase.err)
+
+			message, err := p.getLinkedProjectsList(testutils.MockMattermostUserID)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// moveSubscriptions moves the subscriptions a user created in a channel to another channel which the user can post in.
+// A subscription already present in the other channel is deleted instead of being moved, and a subscription which can't be moved
+// is left in its channel without stopping the others. The result for each subscription is returned as a markdown table.
+func (p *Plugin) moveSubscriptions(mattermostUserID string, fromChannel, toChannel *model.Channel) (string, error) {
+	if fromChannel.Id == toChannel.Id {
+		return constants.SameMoveChannels, nil
+	}
+
+	if _, err := p.CheckValidChannelForSubscription(toChannel.Id, mattermostUserID); err != nil || !p.API.HasPermissionToChannel(mattermostUserID, toChannel.Id, model.PERMISSION_CREATE_POST) {
+		return fmt.Sprintf(constants.MoveSubscriptionsNotAllowed, toChannel.Name), nil
+	}
+
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		return "", errors.Wrap(err, constants.FetchSubscriptionListError)
+	}
+
+	// The subscriptions of all the users in the other channel are considered while looking for duplicates, as they post the same notifications
+	var subscriptionsToMove, channelSubscriptions []*serializers.SubscriptionDetails
+	for _, subscription := range subscriptionList {
+		switch {
+		case subscription.ChannelID == toChannel.Id:
+			channelSubscriptions = append(channelSubscriptions, subscription)
+		case subscription.ChannelID == fromChannel.Id && subscription.MattermostUserID == mattermostUserID:
+			subscriptionsToMove = append(subscriptionsToMove, subscription)
+		}
+	}
+
+	if len(subscriptionsToMove) == 0 {
+		return fmt.Sprintf(constants.NoSubscriptionsToMove, fromChannel.Name), nil
+	}
+
+	sortSubscriptionsByCreation(subscriptionsToMove, true)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Subscriptions moved from ~%s to ~%s\n", fromChannel.Name, toChannel.Name))
+	sb.WriteString("| Subscription ID | Project | Event Type | Result |\n")
+	sb.WriteString("| :-------------- | :------ | :--------- | :----- |\n")
+
+	movedCount, deletedCount := 0, 0
+	for _, subscription := range subscriptionsToMove {
+		movedSubscription := *subscription
+		movedSubscription.ChannelID = toChannel.Id
+		movedSubscription.ChannelName = toChannel.DisplayName
+		movedSubscription.ChannelType = toChannel.Type
+
+		result := "Moved"
+		if _, isSubscriptionPresent := p.IsSubscriptionPresent(channelSubscriptions, &movedSubscription); isSubscriptionPresent {
+			result = "Deleted, already present in the channel"
+			if statusCode, err := p.deleteSubscription(subscription, mattermostUserID); err != nil {
+				p.API.LogError(constants.DeleteSubscriptionError, "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+				result = fmt.Sprintf("Failed: %s", err.Error())
+				if statusCode == http.StatusForbidden {
+					result = "Failed: you need to be a project or team administrator to delete it"
+				}
+			} else {
+				deletedCount++
+			}
+		} else if statusCode, err := p.moveSubscription(mattermostUserID, &movedSubscription); err != nil {
+			p.API.LogError(constants.ErrorMoveSubscription, "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+			result = fmt.Sprintf("Failed: %s", err.Error())
+			if statusCode == http.StatusForbidden {
+				result = "Failed: you need to be a project or team administrator to move it"
+			}
+		} else {
+			movedCount++
+			channelSubscriptions = append(channelSubscriptions, &movedSubscription)
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", subscription.SubscriptionID, escapeTableCell(subscription.ProjectName), subscription.EventType, escapeTableCell(result)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(constants.SubscriptionsMoved, movedCount, len(subscriptionsToMove), toChannel.Name, deletedCount))
+	if movedCount > 0 || deletedCount > 0 {
+		p.API.PublishWebSocketEvent(
+			constants.WSEventSubscriptionDeleted,
+			nil,
+			&model.WebsocketBroadcast{UserId: mattermostUserID},
+		)
+	}
+
+	return sb.String(), nil
+}
+
+// moveSubscription routes the notifications of a subscription to the channel it has been given.
+// The webhook gets a new secret mapped to the channel, so the notifications sent with the previous secret are not accepted anymore.
+// The webhook is updated before anything is stored, and its previous URL is restored if the new secret can't be stored.
+func (p *Plugin) moveSubscription(mattermostUserID string, subscription *serializers.SubscriptionDetails) (int, error) {
+	webhookSecret := uuid.New().String()
+	previousWebhookURL, statusCode, err := p.Client.UpdateSubscriptionWebhookURL(subscription.OrganizationName, subscription.SubscriptionID, p.getSubscriptionWebhookURL(p.GetPluginURL(), webhookSecret), mattermostUserID)
+	if err != nil {
+		return statusCode, err
+	}
+
+	if err := p.Store.StoreSubscriptionAndChannelIDMap(subscription.SubscriptionID, webhookSecret, subscription.ChannelID); err != nil {
+		if _, _, restoreErr := p.Client.UpdateSubscriptionWebhookURL(subscription.OrganizationName, subscription.SubscriptionID, previousWebhookURL, mattermostUserID); restoreErr != nil {
+			p.API.LogError("Error in restoring the webhook URL of the subscription", "SubscriptionID", subscription.SubscriptionID, "Error", restoreErr.Error())
+		}
+		return http.StatusInternalServerError, err
+	}
+
+	if err := p.Store.StoreSubscription(subscription); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,171 @@
+package plugin
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestMoveSubscriptions(t *testing.T) {
+	fromChannel := &model.Channel{Id: "mockFromChannelID", Name: "mock-from-channel", DisplayName: "Mock From Channel", Type: model.CHANNEL_OPEN}
+	toChannel := &model.Channel{Id: "mockToChannelID", Name: "mock-to-channel", DisplayName: "Mock To Channel", Type: model.CHANNEL_PRIVATE}
+	newSubscription := func(subscriptionID, mattermostUserID, channelID, eventType string) *serializers.SubscriptionDetails {
+		return &serializers.SubscriptionDetails{
+			SubscriptionID:   subscriptionID,
+			MattermostUserID: mattermostUserID,
+			OrganizationName: testutils.MockOrganization,
+			ProjectName:      testutils.MockProjectName,
+			ChannelID:        channelID,
+			ChannelName:      "Mock From Channel",
+			EventType:        eventType,
+		}
+	}
+	movedSubscription := newSubscription("mockSubscriptionID1", testutils.MockMattermostUserID, fromChannel.Id, constants.SubscriptionEventWorkItemCreated)
+	duplicateSubscription := newSubscription("mockSubscriptionID2", testutils.MockMattermostUserID, fromChannel.Id, constants.SubscriptionEventWorkItemUpdated)
+	failingSubscription := newSubscription("mockSubscriptionID3", testutils.MockMattermostUserID, fromChannel.Id, constants.SubscriptionEventPullRequestCreated)
+	otherUserSubscription := newSubscription("mockSubscriptionID4", "mockOtherUserID", fromChannel.Id, constants.SubscriptionEventPullRequestMerged)
+	channelSubscription := newSubscription("mockSubscriptionID5", "mockOtherUserID", toChannel.Id, constants.SubscriptionEventWorkItemUpdated)
+	previousWebhookURL := "https://example.com/plugins/azure-devops/api/v1/notification?webhookSecret=mockPreviousSecret"
+
+	t.Run("MoveSubscriptions: subscriptions are moved, deleted if present in the channel or left if they can't be moved", func(t *testing.T) {
+		defer monkey.UnpatchAll()
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+			return 0, nil
+		})
+		mockAPI.On("HasPermissionToChannel", testutils.MockMattermostUserID, toChannel.Id, model.PERMISSION_CREATE_POST).Return(true)
+		mockAPI.On("LogError", constants.ErrorMoveSubscription, "SubscriptionID", failingSubscription.SubscriptionID, "Error", "mockError")
+		mockAPI.On("PublishWebSocketEvent", constants.WSEventSubscriptionDeleted, mock.Anything, mock.Anything)
+
+		// The subscriptions are fetched again to check if the duplicate subscription is shared before deleting it
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{failingSubscription, channelSubscription, duplicateSubscription, otherUserSubscription, movedSubscription}, nil).Times(2)
+
+		// The new secret of the webhook is the one routing its notifications to the channel
+		webhookSecret := ""
+		mockedClient.EXPECT().UpdateSubscriptionWebhookURL(testutils.MockOrganization, movedSubscription.SubscriptionID, gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(func(_, _, webhookURL, _ string) (string, int, error) {
+			parsedURL, err := url.Parse(webhookURL)
+			require.NoError(t, err)
+			webhookSecret = parsedURL.Query().Get(constants.AzureDevopsQueryParamWebhookSecret)
+			assert.NotEmpty(t, webhookSecret)
+			return previousWebhookURL, http.StatusOK, nil
+		})
+		mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(movedSubscription.SubscriptionID, gomock.Any(), toChannel.Id).DoAndReturn(func(_, secret, _ string) error {
+			assert.Equal(t, webhookSecret, secret)
+			return nil
+		})
+		expectedSubscription := *movedSubscription
+		expectedSubscription.ChannelID = toChannel.Id
+		expectedSubscription.ChannelName = toChannel.DisplayName
+		expectedSubscription.ChannelType = toChannel.Type
+		mockedStore.EXPECT().StoreSubscription(&expectedSubscription).Return(nil)
+
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, duplicateSubscription.SubscriptionID, testutils.MockMattermostUserID).Return(http.StatusNoContent, nil)
+		mockedStore.EXPECT().DeleteSubscription(duplicateSubscription).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(duplicateSubscription.SubscriptionID).Return(nil)
+		mockedStore.EXPECT().DeleteLastNotification(duplicateSubscription.SubscriptionID).Return(nil)
+
+		mockedClient.EXPECT().UpdateSubscriptionWebhookURL(testutils.MockOrganization, failingSubscription.SubscriptionID, gomock.Any(), testutils.MockMattermostUserID).Return("", http.StatusInternalServerError, errors.New("mockError"))
+
+		message, err := p.moveSubscriptions(testutils.MockMattermostUserID, fromChannel, toChannel)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "###### Subscriptions moved from ~mock-from-channel to ~mock-to-channel\n"+
+			"| Subscription ID | Project | Event Type | Result |\n"+
+			"| :-------------- | :------ | :--------- | :----- |\n"+
+			"| mockSubscriptionID1 | mockProjectName | workitem.created | Moved |\n"+
+			"| mockSubscriptionID2 | mockProjectName | workitem.updated | Deleted, already present in the channel |\n"+
+			"| mockSubscriptionID3 | mockProjectName | git.pullrequest.created | Failed: mockError |\n"+
+			"\n1 of 3 subscription(s) moved to ~mock-to-channel and 1 deleted as they were already present in it", message)
+	})
+
+	t.Run("MoveSubscriptions: webhook is restored if the new secret can't be stored", func(t *testing.T) {
+		defer monkey.UnpatchAll()
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+			return 0, nil
+		})
+		mockAPI.On("HasPermissionToChannel", testutils.MockMattermostUserID, toChannel.Id, model.PERMISSION_CREATE_POST).Return(true)
+		mockAPI.On("LogError", constants.ErrorMoveSubscription, "SubscriptionID", movedSubscription.SubscriptionID, "Error", "mockError")
+
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{movedSubscription}, nil)
+		gomock.InOrder(
+			mockedClient.EXPECT().UpdateSubscriptionWebhookURL(testutils.MockOrganization, movedSubscription.SubscriptionID, gomock.Any(), testutils.MockMattermostUserID).Return(previousWebhookURL, http.StatusOK, nil),
+			mockedClient.EXPECT().UpdateSubscriptionWebhookURL(testutils.MockOrganization, movedSubscription.SubscriptionID, previousWebhookURL, testutils.MockMattermostUserID).Return("", http.StatusOK, nil),
+		)
+		mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(movedSubscription.SubscriptionID, gomock.Any(), toChannel.Id).Return(errors.New("mockError"))
+
+		message, err := p.moveSubscriptions(testutils.MockMattermostUserID, fromChannel, toChannel)
+
+		assert.NoError(t, err)
+		assert.Contains(t, message, "| mockSubscriptionID1 | mockProjectName | workitem.created | Failed: mockError |")
+		assert.Contains(t, message, "0 of 1 subscription(s) moved")
+	})
+
+	for _, testCase := range []struct {
+		description     string
+		toChannel       *model.Channel
+		canPost         bool
+		subscriptions   []*serializers.SubscriptionDetails
+		expectedMessage string
+	}{
+		{
+			description:     "MoveSubscriptions: user can't post in the channel",
+			toChannel:       toChannel,
+			expectedMessage: "You can't move the subscriptions to ~mock-to-channel as you can't post in it",
+		},
+		{
+			description:     "MoveSubscriptions: channels are the same",
+			toChannel:       fromChannel,
+			canPost:         true,
+			expectedMessage: constants.SameMoveChannels,
+		},
+		{
+			description:     "MoveSubscriptions: user has no subscriptions in the channel",
+			toChannel:       toChannel,
+			canPost:         true,
+			subscriptions:   []*serializers.SubscriptionDetails{otherUserSubscription, channelSubscription},
+			expectedMessage: "You don't have any subscriptions in ~mock-from-channel",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			defer monkey.UnpatchAll()
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+				return 0, nil
+			})
+			mockAPI.On("HasPermissionToChannel", testutils.MockMattermostUserID, testCase.toChannel.Id, model.PERMISSION_CREATE_POST).Return(testCase.canPost)
+			mockedStore.EXPECT().GetAllSubscriptions("").Return(testCase.subscriptions, nil).MaxTimes(1)
+
+			message, err := p.moveSubscriptions(testutils.MockMattermostUserID, fromChannel, testCase.toChannel)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// notificationAttachmentField is a field added to the notifications of an event type, it's left out when its value is empty
+type notificationAttachmentField struct {
+	title    string
+	getValue func(body *serializers.SubscriptionNotification) string
+}
+
+// notificationAttachmentStyle is what is added to the attachments of the notifications of an event type after they are rendered
+type notificationAttachmentStyle struct {
+	// getTitle returns the title of the notifications which don't have one, so that they also have a title linking to their resource
+	getTitle func(body *serializers.SubscriptionNotification, localizer *i18n.Localizer) string
+	// getResult returns the result of the pipeline, which colors the notification when it has succeeded or failed
+	getResult func(body *serializers.SubscriptionNotification) string
+	fields    []notificationAttachmentField
+}
+
+var (
+	notificationActorField = func(title string) notificationAttachmentField {
+		return notificationAttachmentField{title: title, getValue: getNotificationActorName}
+	}
+
+	pullRequestFields = []notificationAttachmentField{
+		{title: "Author", getValue: func(body *serializers.SubscriptionNotification) string { return body.Resource.CreatedBy.DisplayName }},
+		{title: "State", getValue: func(body *serializers.SubscriptionNotification) string {
+			return formatNotificationState(body.Resource.Status)
+		}},
+	}
+
+	// notificationAttachmentStyles are the styles of the event types, the notifications of the other event types are left as they are rendered
+	notificationAttachmentStyles = map[string]notificationAttachmentStyle{
+		constants.SubscriptionEventWorkItemCreated:    {fields: []notificationAttachmentField{notificationActorField("Created by")}},
+		constants.SubscriptionEventWorkItemUpdated:    {fields: []notificationAttachmentField{notificationActorField("Changed by")}},
+		constants.SubscriptionEventWorkItemDeleted:    {fields: []notificationAttachmentField{notificationActorField("Deleted by")}},
+		constants.SubscriptionEventWorkItemCommented:  {fields: []notificationAttachmentField{notificationActorField("Commented by")}},
+		constants.SubscriptionEventPullRequestCreated: {fields: pullRequestFields},
+		constants.SubscriptionEventPullRequestUpdated: {fields: pullRequestFields},
+		constants.SubscriptionEventPullRequestMerged:  {fields: pullRequestFields},
+		constants.SubscriptionEventPullRequestCommented: {
+			fields: []notificationAttachmentField{
+				notificationActorField("Commented by"),
+				{title: "State", getValue: func(body *serializers.SubscriptionNotification) string {
+					return formatNotificationState(body.Resource.PullRequest.Status)
+				}},
+			},
+		},
+		constants.SubscriptionEventCodePushed: {fields: []notificationAttachmentField{notificationActorField("Pushed by")}},
+		constants.SubscriptionEventBuildCompleted: {
+			getTitle: func(body *serializers.SubscriptionNotification, localizer *i18n.Localizer) string {
+				if body.Resource.BuildNumber == "" {
+					return ""
+				}
+				return localizer.Localizef("Build %s", body.Resource.BuildNumber)
+			},
+			getResult: func(body *serializers.SubscriptionNotification) string { return body.Resource.Result },
+			fields: []notificationAttachmentField{
+				{title: "Result", getValue: func(body *serializers.SubscriptionNotification) string {
+					return formatNotificationState(body.Resource.Result)
+				}},
+			},
+		},
+		constants.SubscriptionEventReleaseDeploymentCompleted: {
+			getResult: func(body *serializers.SubscriptionNotification) string { return body.Resource.Environment.Status },
+			fields: []notificationAttachmentField{
+				{title: "Result", getValue: func(body *serializers.SubscriptionNotification) string {
+					return formatNotificationState(body.Resource.Environment.Status)
+				}},
+			},
+		},
+		constants.SubscriptionEventRunStateChanged: {
+			getResult: func(body *serializers.SubscriptionNotification) string { return body.Resource.Run.Result },
+			fields: []notificationAttachmentField{
+				{title: "Result", getValue: func(body *serializers.SubscriptionNotification) string {
+					return formatNotificationState(body.Resource.Run.Result)
+				}},
+			},
+		},
+		constants.SubscriptionEventRunStageStateChanged: {
+			getResult: func(body *serializers.SubscriptionNotification) string { return body.Resource.Stage.Result },
+			fields: []notificationAttachmentField{
+				{title: "Result", getValue: func(body *serializers.SubscriptionNotification) string {
+					return formatNotificationState(body.Resource.Stage.Result)
+				}},
+			},
+		},
+	}
+)
+
+// styleNotificationAttachment adds the title link, the result color and the fields of the style of its event type to the attachment of a notification
+func styleNotificationAttachment(attachment *model.SlackAttachment, body *serializers.SubscriptionNotification, localizer *i18n.Localizer) {
+	style := notificationAttachmentStyles[body.EventType]
+	if attachment.Title == "" && style.getTitle != nil {
+		attachment.Title = style.getTitle(body, localizer)
+	}
+
+	if attachment.Title != "" && attachment.TitleLink == "" {
+		attachment.TitleLink = getNotificationWebURL(body)
+	}
+
+	if style.getResult != nil {
+		if color, ok := constants.NotificationResultColors[getNotificationResultStatus(style.getResult(body))]; ok {
+			attachment.Color = color
+		}
+	}
+
+	for _, field := range style.fields {
+		if value := field.getValue(body); value != "" {
+			attachment.Fields = append(attachment.Fields, &model.SlackAttachmentField{
+				Title: localizer.Localize(field.title),
+				Value: value,
+				Short: true,
+			})
+		}
+	}
+}
+
+// getNotificationMarkdownAttachment renders the notifications of the event types without an attachment of their own from their Markdown
+func getNotificationMarkdownAttachment(body *serializers.SubscriptionNotification) *model.SlackAttachment {
+	return &model.SlackAttachment{
+		Pretext: body.Message.Markdown,
+		Text:    body.DetailedMessage.Markdown,
+	}
+}
+
+// getNotificationActorName returns the display name of the identity which made the change a notification is about
+func getNotificationActorName(body *serializers.SubscriptionNotification) string {
+	if actor := getNotificationActor(body); actor != nil {
+		return actor.DisplayName
+	}
+
+	return ""
+}
+
+// formatNotificationState converts a state or a result like "partiallySucceeded" sent by Azure DevOps to words like "Partially succeeded"
+func formatNotificationState(state string) string {
+	var sb strings.Builder
+	for i, r := range strings.TrimSpace(state) {
+		switch {
+		case i == 0:
+			sb.WriteRune(unicode.ToUpper(r))
+		case unicode.IsUpper(r):
+			sb.WriteRune(' ')
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
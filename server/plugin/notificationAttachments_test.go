@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestStyleNotificationAttachment(t *testing.T) {
+	mockBuildURL := "https://dev.azure.com/mockOrganization/mockProject/_build/results?buildId=1"
+	getBuild := func(result string) *serializers.SubscriptionNotification {
+		return &serializers.SubscriptionNotification{
+			EventType: constants.SubscriptionEventBuildCompleted,
+			Resource: serializers.Resource{
+				Result:      result,
+				BuildNumber: "20261016.1",
+				Links:       serializers.Link{Web: serializers.Href{Href: mockBuildURL}},
+			},
+		}
+	}
+	for _, testCase := range []struct {
+		description string
+		attachment  *model.SlackAttachment
+		body        *serializers.SubscriptionNotification
+		locale      string
+		expected    *model.SlackAttachment
+	}{
+		{
+			description: "StyleNotificationAttachment: succeeded build",
+			attachment:  &model.SlackAttachment{Color: constants.IconColorPipelines},
+			body:        getBuild(constants.BuildResultSucceeded),
+			expected: &model.SlackAttachment{
+				Color:     constants.IconColorSucceeded,
+				Title:     "Build 20261016.1",
+				TitleLink: mockBuildURL,
+				Fields:    []*model.SlackAttachmentField{{Title: "Result", Value: "Succeeded", Short: true}},
+			},
+		},
+		{
+			description: "StyleNotificationAttachment: failed build",
+			attachment:  &model.SlackAttachment{Color: constants.IconColorPipelines},
+			body:        getBuild(constants.BuildResultFailed),
+			locale:      "de",
+			expected: &model.SlackAttachment{
+				Color:     constants.IconColorFailed,
+				Title:     "Build 20261016.1",
+				TitleLink: mockBuildURL,
+				Fields:    []*model.SlackAttachmentField{{Title: "Ergebnis", Value: "Failed", Short: true}},
+			},
+		},
+		{
+			description: "StyleNotificationAttachment: partially succeeded build keeps the color of the pipelines",
+			attachment:  &model.SlackAttachment{Color: constants.IconColorPipelines},
+			body:        getBuild(constants.BuildResultPartiallySucceeded),
+			expected: &model.SlackAttachment{
+				Color:     constants.IconColorPipelines,
+				Title:     "Build 20261016.1",
+				TitleLink: mockBuildURL,
+				Fields:    []*model.SlackAttachmentField{{Title: "Result", Value: "Partially succeeded", Short: true}},
+			},
+		},
+		{
+			description: "StyleNotificationAttachment: pull request",
+			attachment:  &model.SlackAttachment{Color: constants.IconColorRepos, Title: "1: mockTitle"},
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventPullRequestCreated,
+				Resource: serializers.Resource{
+					Status:    "active",
+					CreatedBy: serializers.Identity{DisplayName: "mockAuthor"},
+					Links:     serializers.Link{Web: serializers.Href{Href: "https://dev.azure.com/mockOrganization/mockProject/_git/mockRepo/pullrequest/1"}},
+				},
+			},
+			expected: &model.SlackAttachment{
+				Color:     constants.IconColorRepos,
+				Title:     "1: mockTitle",
+				TitleLink: "https://dev.azure.com/mockOrganization/mockProject/_git/mockRepo/pullrequest/1",
+				Fields: []*model.SlackAttachmentField{
+					{Title: "Author", Value: "mockAuthor", Short: true},
+					{Title: "State", Value: "Active", Short: true},
+				},
+			},
+		},
+		{
+			description: "StyleNotificationAttachment: fields without a value are left out",
+			attachment:  &model.SlackAttachment{Title: "Commit(s)"},
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed},
+			expected:    &model.SlackAttachment{Title: "Commit(s)"},
+		},
+		{
+			description: "StyleNotificationAttachment: event type without a style",
+			attachment:  &model.SlackAttachment{Pretext: "mockMarkdown"},
+			body:        &serializers.SubscriptionNotification{EventType: "mockEventType"},
+			expected:    &model.SlackAttachment{Pretext: "mockMarkdown"},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			styleNotificationAttachment(testCase.attachment, testCase.body, i18n.NewLocalizer(testCase.locale))
+
+			assert.Equal(t, testCase.expected, testCase.attachment)
+		})
+	}
+}
+
+func TestGetSubscriptionNotificationAttachmentForUnknownEvent(t *testing.T) {
+	p := setupTestPlugin(nil)
+	body := &serializers.SubscriptionNotification{
+		EventType:       "mockEventType",
+		Message:         serializers.DetailedMessage{Markdown: "mockMessage"},
+		DetailedMessage: serializers.DetailedMessage{Markdown: "mockDetailedMessage"},
+	}
+
+	showEmoji := false
+
+	attachment, err := p.getSubscriptionNotificationAttachment(nil, body, &serializers.ChannelNotificationPrefs{ShowEmoji: &showEmoji})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mockMessage", attachment.Pretext)
+	assert.Equal(t, "mockDetailedMessage", attachment.Text)
+}
+
+func TestFormatNotificationState(t *testing.T) {
+	assert.Equal(t, "Partially succeeded", formatNotificationState("partiallySucceeded"))
+	assert.Equal(t, "Completed", formatNotificationState("completed"))
+	assert.Equal(t, "", formatNotificationState(""))
+}
@@ -0,0 +1,131 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// addNotificationToBurst records a work item created notification in the burst of its subscription.
+// Once more work items than the threshold are created in quick succession, e.g. by a bulk import, the notification
+// is added to a single summary post instead of being posted, and true is returned.
+func (p *Plugin) addNotificationToBurst(channelID string, subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification, prefs *serializers.ChannelNotificationPrefs) bool {
+	if body.EventType != constants.SubscriptionEventWorkItemCreated || subscription == nil {
+		return false
+	}
+
+	workItemID := getNotificationWorkItemID(body)
+	if workItemID == 0 {
+		return false
+	}
+
+	iterationPath, _ := body.Resource.Fields.IterationPath.(string)
+	burst, err := p.Store.AddNotificationBurstWorkItem(subscription.SubscriptionID, workItemID, iterationPath)
+	if err != nil {
+		p.API.LogError("Error in adding the work item to the notification burst", "Error", err.Error())
+		return false
+	}
+
+	if burst.Count <= constants.NotificationBurstThreshold {
+		return false
+	}
+
+	if burst.SummaryPostID != "" && p.updateNotificationBurstSummaryPost(burst.SummaryPostID, channelID, subscription, burst, prefs) {
+		return true
+	}
+
+	// The summary post is created for the first notification over the threshold, or again if it's deleted during the burst
+	summaryPost, appErr := p.API.CreatePost(p.getNotificationBurstSummaryPost(channelID, subscription, burst, prefs))
+	if appErr != nil {
+		p.API.LogError("Error in creating the notification burst summary post", "Error", appErr.Error())
+		return false
+	}
+
+	latestBurst, err := p.Store.SetNotificationBurstSummaryPost(subscription.SubscriptionID, burst.SummaryPostID, summaryPost.Id)
+	if err != nil {
+		p.API.LogError("Error in storing the notification burst summary post", "Error", err.Error())
+		return true
+	}
+
+	if latestBurst == nil {
+		return true
+	}
+
+	if latestBurst.SummaryPostID != summaryPost.Id {
+		// Another notification of the burst created a summary post at the same time, so only that one is kept
+		if appErr := p.API.DeletePost(summaryPost.Id); appErr != nil {
+			p.API.LogError("Error in deleting the duplicate notification burst summary post", "Error", appErr.Error())
+		}
+	}
+
+	if latestBurst.Count != burst.Count || latestBurst.SummaryPostID != summaryPost.Id {
+		p.updateNotificationBurstSummaryPost(latestBurst.SummaryPostID, channelID, subscription, latestBurst, prefs)
+	}
+
+	return true
+}
+
+// updateNotificationBurstSummaryPost updates the summary post of a burst, it returns false if the post is deleted
+func (p *Plugin) updateNotificationBurstSummaryPost(postID, channelID string, subscription *serializers.SubscriptionDetails, burst *serializers.NotificationBurst, prefs *serializers.ChannelNotificationPrefs) bool {
+	summaryPost, appErr := p.API.GetPost(postID)
+	if appErr != nil || summaryPost.DeleteAt != 0 {
+		return false
+	}
+
+	model.ParseSlackAttachment(summaryPost, p.getNotificationBurstSummaryPost(channelID, subscription, burst, prefs).Attachments())
+	if _, appErr := p.API.UpdatePost(summaryPost); appErr != nil {
+		p.API.LogError("Error in updating the notification burst summary post", "Error", appErr.Error())
+	}
+
+	return true
+}
+
+func (p *Plugin) getNotificationBurstSummaryPost(channelID string, subscription *serializers.SubscriptionDetails, burst *serializers.NotificationBurst, prefs *serializers.ChannelNotificationPrefs) *model.Post {
+	var workItemIDs []string
+	for _, id := range burst.WorkItemIDs {
+		workItemIDs = append(workItemIDs, strconv.Itoa(id))
+	}
+
+	query := fmt.Sprintf(constants.NotificationBurstWorkItemsQuery, strings.Join(workItemIDs, ", "))
+	attachment := &model.SlackAttachment{
+		AuthorName: constants.SlackAttachmentAuthorNameBoards,
+		AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameBoardsIcon),
+		Color:      constants.IconColorBoards,
+		Pretext:    "Work items are being created in bulk, so they are summarized in this post",
+		Title:      fmt.Sprintf("%d work items created in %s", burst.Count, getNotificationBurstLocation(subscription.ProjectName, burst.IterationPaths)),
+		TitleLink:  fmt.Sprintf(constants.WorkItemQueryLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), subscription.OrganizationName, url.PathEscape(subscription.ProjectName), url.QueryEscape(query)),
+		Footer:     subscription.ProjectName,
+		FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
+	}
+
+	if burst.Count > len(burst.WorkItemIDs) {
+		attachment.Text = fmt.Sprintf("The linked query only contains the first %d work items", len(burst.WorkItemIDs))
+	}
+
+	addSubscriptionLabel(attachment, subscription.Label)
+	if prefs.Color != "" {
+		attachment.Color = prefs.Color
+	}
+
+	post := &model.Post{
+		UserId:    p.botUserID,
+		ChannelId: channelID,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	return post
+}
+
+// getNotificationBurstLocation returns the iteration of the work items of a burst like "Sprint 12", or the project if they are in different iterations
+func getNotificationBurstLocation(projectName string, iterationPaths []string) string {
+	if len(iterationPaths) != 1 || iterationPaths[0] == "" {
+		return projectName
+	}
+
+	return iterationPaths[0][strings.LastIndex(iterationPaths[0], "\\")+1:]
+}
@@ -0,0 +1,126 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestAddNotificationToBurst(t *testing.T) {
+	defer monkey.UnpatchAll()
+	subscription := &serializers.SubscriptionDetails{
+		SubscriptionID:   testutils.MockSubscriptionID,
+		OrganizationName: testutils.MockOrganization,
+		ProjectName:      testutils.MockProjectName,
+	}
+	body := &serializers.SubscriptionNotification{
+		EventType: constants.SubscriptionEventWorkItemCreated,
+		Resource: serializers.Resource{
+			ID:     float64(6),
+			Fields: serializers.Fields{IterationPath: `mockProjectName\Sprint 12`},
+		},
+	}
+	prefs := &serializers.ChannelNotificationPrefs{}
+
+	for _, testCase := range []struct {
+		description    string
+		body           *serializers.SubscriptionNotification
+		subscription   *serializers.SubscriptionDetails
+		burst          *serializers.NotificationBurst
+		latestBurst    *serializers.NotificationBurst
+		summaryPost    *model.Post
+		expectedResult bool
+		expectedTitle  string
+	}{
+		{
+			description:  "AddNotificationToBurst: other events are not added to a burst",
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemUpdated, Resource: serializers.Resource{WorkItemID: 6}},
+			subscription: subscription,
+		},
+		{
+			description: "AddNotificationToBurst: notifications of unknown subscriptions are not added to a burst",
+			body:        body,
+		},
+		{
+			description:  "AddNotificationToBurst: work items below the threshold are posted individually",
+			body:         body,
+			subscription: subscription,
+			burst:        &serializers.NotificationBurst{Count: constants.NotificationBurstThreshold, WorkItemIDs: []int{1, 2, 3, 4, 6}, IterationPaths: []string{`mockProjectName\Sprint 12`}},
+		},
+		{
+			description:    "AddNotificationToBurst: summary post is created for a burst",
+			body:           body,
+			subscription:   subscription,
+			burst:          &serializers.NotificationBurst{Count: constants.NotificationBurstThreshold + 1, WorkItemIDs: []int{1, 2, 3, 4, 5, 6}, IterationPaths: []string{`mockProjectName\Sprint 12`}},
+			latestBurst:    &serializers.NotificationBurst{Count: constants.NotificationBurstThreshold + 1, SummaryPostID: "mockSummaryPostID"},
+			expectedResult: true,
+			expectedTitle:  "6 work items created in Sprint 12",
+		},
+		{
+			description:    "AddNotificationToBurst: summary post of a burst is updated",
+			body:           body,
+			subscription:   subscription,
+			burst:          &serializers.NotificationBurst{Count: 25, WorkItemIDs: []int{1, 6}, IterationPaths: []string{`mockProjectName\Sprint 12`, `mockProjectName\Sprint 13`}, SummaryPostID: "mockSummaryPostID"},
+			summaryPost:    &model.Post{Id: "mockSummaryPostID"},
+			expectedResult: true,
+			expectedTitle:  "25 work items created in mockProjectName",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetSiteURL", func(_ *Plugin) string {
+				return "https://mattermost.example.com"
+			})
+
+			if testCase.burst != nil {
+				mockedStore.EXPECT().AddNotificationBurstWorkItem(testutils.MockSubscriptionID, 6, `mockProjectName\Sprint 12`).Return(testCase.burst, nil)
+			}
+
+			var post *model.Post
+			if testCase.latestBurst != nil {
+				mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post = args.Get(0).(*model.Post)
+				}).Return(&model.Post{Id: "mockSummaryPostID"}, nil)
+				mockedStore.EXPECT().SetNotificationBurstSummaryPost(testutils.MockSubscriptionID, "", "mockSummaryPostID").Return(testCase.latestBurst, nil)
+			}
+
+			if testCase.summaryPost != nil {
+				mockAPI.On("GetPost", testCase.summaryPost.Id).Return(testCase.summaryPost, nil)
+				mockAPI.On("UpdatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post = args.Get(0).(*model.Post)
+				}).Return(testCase.summaryPost, nil)
+			}
+
+			result := p.addNotificationToBurst(testutils.MockChannelID, testCase.subscription, testCase.body, prefs)
+
+			assert.Equal(t, testCase.expectedResult, result)
+			if testCase.expectedTitle == "" {
+				assert.Nil(t, post)
+				return
+			}
+
+			require.NotNil(t, post)
+			attachments := post.Attachments()
+			require.Len(t, attachments, 1)
+			assert.Equal(t, testCase.expectedTitle, attachments[0].Title)
+			assert.Contains(t, attachments[0].TitleLink, "https://dev.azure.com/mockOrganization/mockProjectName/_queries/query/?wiql=")
+		})
+	}
+}
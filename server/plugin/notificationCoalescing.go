@@ -0,0 +1,122 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getNotificationCorrelationKeys returns the keys of the pull requests and work items a notification is about, which are shared by the notifications of related events.
+// Azure DevOps doesn't send a correlation ID, so a pull request is related to the work items mentioned like "AB#123" in it, and a work item
+// to the pull requests linked to it. No keys are returned for the other events, whose notifications are always posted separately.
+func getNotificationCorrelationKeys(body *serializers.SubscriptionNotification) []string {
+	var keys []string
+	isKeyAdded := map[string]bool{}
+	addKey := func(format string, id int) {
+		key := fmt.Sprintf(format, id)
+		if id > 0 && !isKeyAdded[key] {
+			isKeyAdded[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	var pullRequest serializers.PullRequest
+	switch body.EventType {
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
+		pullRequest = serializers.PullRequest{
+			PullRequestID: body.Resource.PullRequestID,
+			Title:         body.Resource.Title,
+			Description:   body.Resource.Description,
+		}
+	case constants.SubscriptionEventPullRequestCommented:
+		pullRequest = body.Resource.PullRequest
+	default:
+		addKey(constants.CorrelationKeyWorkItem, getNotificationWorkItemID(body))
+		if len(keys) == 0 {
+			return nil
+		}
+
+		pullRequests, _ := parseWorkItemCodeLinks(body.Resource.Revision.Relations)
+		for _, link := range pullRequests {
+			if pullRequestID, err := strconv.Atoi(link.name); err == nil {
+				addKey(constants.CorrelationKeyPullRequest, pullRequestID)
+			}
+		}
+		return keys
+	}
+
+	addKey(constants.CorrelationKeyPullRequest, pullRequest.PullRequestID)
+	for _, match := range workItemMentionRegex.FindAllStringSubmatch(fmt.Sprintf("%s\n%s", pullRequest.Title, pullRequest.Description), -1) {
+		if workItemID, err := strconv.Atoi(match[1]); err == nil {
+			addKey(constants.CorrelationKeyWorkItem, workItemID)
+		}
+	}
+
+	return keys
+}
+
+// addNotificationToCoalescedPost adds the attachment of a notification to the post of a related notification in the channel, if it was created
+// within the coalescing window, and returns the ID of the post. It returns an empty ID if the notification should be posted separately.
+func (p *Plugin) addNotificationToCoalescedPost(channelID string, subscription *serializers.SubscriptionDetails, correlationKeys []string, attachment *model.SlackAttachment) string {
+	window := int64(p.getConfiguration().NotificationCoalescingWindow)
+	if window == 0 || subscription == nil || attachment == nil {
+		return ""
+	}
+
+	for _, key := range correlationKeys {
+		postID, err := p.Store.GetCoalescedPost(channelID, subscription.OrganizationName, key)
+		if err != nil {
+			p.API.LogError("Error in fetching the coalesced notification post", "Error", err.Error())
+			continue
+		}
+
+		if postID == "" {
+			continue
+		}
+
+		post, appErr := p.API.GetPost(postID)
+		if appErr != nil || post.DeleteAt != 0 || post.ChannelId != channelID {
+			continue
+		}
+
+		// The window starts with the first notification of the post, so that a busy pull request doesn't keep extending the same post
+		remainingSeconds := window - (model.GetMillis()-post.CreateAt)/1000
+		attachments := post.Attachments()
+		if remainingSeconds <= 0 || len(attachments) >= constants.NotificationCoalescingMaxAttachments {
+			continue
+		}
+
+		// The buttons are found by their IDs when they are clicked, so the buttons of the added notification need different IDs
+		for _, action := range attachment.Actions {
+			action.Id = fmt.Sprintf("%s%d", action.Id, len(attachments))
+		}
+
+		model.ParseSlackAttachment(post, append(attachments, attachment))
+		if _, appErr := p.API.UpdatePost(post); appErr != nil {
+			p.API.LogError("Error in updating the coalesced notification post", "Error", appErr.Error())
+			return ""
+		}
+
+		p.storeCoalescedPost(channelID, subscription, correlationKeys, post.Id, remainingSeconds)
+		return post.Id
+	}
+
+	return ""
+}
+
+// storeCoalescedPost records a notification post for all the correlation keys of its notifications, so it can be found by the notifications related to any of them
+func (p *Plugin) storeCoalescedPost(channelID string, subscription *serializers.SubscriptionDetails, correlationKeys []string, postID string, ttlSeconds int64) {
+	if ttlSeconds <= 0 || subscription == nil {
+		return
+	}
+
+	for _, key := range correlationKeys {
+		if err := p.Store.StoreCoalescedPost(channelID, subscription.OrganizationName, key, postID, ttlSeconds); err != nil {
+			p.API.LogError("Error in storing the coalesced notification post", "Error", err.Error())
+		}
+	}
+}
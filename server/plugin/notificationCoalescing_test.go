@@ -0,0 +1,290 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetNotificationCorrelationKeys(t *testing.T) {
+	for _, testCase := range []struct {
+		description  string
+		body         *serializers.SubscriptionNotification
+		expectedKeys []string
+	}{
+		{
+			description: "GetNotificationCorrelationKeys: pull request and the work items mentioned in it",
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventPullRequestMerged,
+				Resource:  serializers.Resource{PullRequestID: 1, Title: "Fix AB#5", Description: "Also fixes AB#6 and AB#5"},
+			},
+			expectedKeys: []string{"pullrequest_1", "workitem_5", "workitem_6"},
+		},
+		{
+			description: "GetNotificationCorrelationKeys: pull request of a comment",
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventPullRequestCommented,
+				Resource:  serializers.Resource{PullRequest: serializers.PullRequest{PullRequestID: 2}},
+			},
+			expectedKeys: []string{"pullrequest_2"},
+		},
+		{
+			description: "GetNotificationCorrelationKeys: work item and the pull requests linked to it",
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventWorkItemUpdated,
+				Resource: serializers.Resource{
+					WorkItemID: 5,
+					Revision: serializers.Revision{Relations: []*serializers.WorkItemRelation{
+						{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F1"},
+						{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/Ref/mockProjectID%2FmockRepositoryID%2FGBmain"},
+						{Rel: "System.LinkTypes.Hierarchy-Reverse", URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/4"},
+					}},
+				},
+			},
+			expectedKeys: []string{"workitem_5", "pullrequest_1"},
+		},
+		{
+			description: "GetNotificationCorrelationKeys: created work item",
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventWorkItemCreated,
+				Resource:  serializers.Resource{ID: float64(7)},
+			},
+			expectedKeys: []string{"workitem_7"},
+		},
+		{
+			description: "GetNotificationCorrelationKeys: event which can't be correlated",
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventBuildCompleted,
+			},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedKeys, getNotificationCorrelationKeys(testCase.body))
+		})
+	}
+}
+
+func TestAddNotificationToCoalescedPost(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{SubscriptionID: testutils.MockSubscriptionID, OrganizationName: testutils.MockOrganization}
+	correlationKeys := []string{"workitem_5", "pullrequest_1"}
+	for _, testCase := range []struct {
+		description       string
+		window            int
+		storedPostIDs     map[string]string
+		storeErr          error
+		post              *model.Post
+		expectedCoalesced bool
+	}{
+		{
+			description:       "AddNotificationToCoalescedPost: notification is added to the post of a related notification",
+			window:            30,
+			storedPostIDs:     map[string]string{"pullrequest_1": "mockPostID"},
+			post:              &model.Post{Id: "mockPostID", ChannelId: testutils.MockChannelID, CreateAt: model.GetMillis() - 10*1000},
+			expectedCoalesced: true,
+		},
+		{
+			description: "AddNotificationToCoalescedPost: no related notification",
+			window:      30,
+		},
+		{
+			description:   "AddNotificationToCoalescedPost: coalescing window of the post has ended",
+			window:        30,
+			storedPostIDs: map[string]string{"workitem_5": "mockPostID"},
+			post:          &model.Post{Id: "mockPostID", ChannelId: testutils.MockChannelID, CreateAt: model.GetMillis() - 40*1000},
+		},
+		{
+			description:   "AddNotificationToCoalescedPost: post of the related notification is deleted",
+			window:        30,
+			storedPostIDs: map[string]string{"workitem_5": "mockPostID"},
+			post:          &model.Post{Id: "mockPostID", ChannelId: testutils.MockChannelID, CreateAt: model.GetMillis(), DeleteAt: model.GetMillis()},
+		},
+		{
+			description: "AddNotificationToCoalescedPost: error in fetching the post of a related notification",
+			window:      30,
+			storeErr:    errors.New("mockError"),
+		},
+		{
+			description: "AddNotificationToCoalescedPost: coalescing is disabled",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{NotificationCoalescingWindow: testCase.window})
+
+			if testCase.window > 0 {
+				for _, key := range correlationKeys {
+					mockedStore.EXPECT().GetCoalescedPost(testutils.MockChannelID, testutils.MockOrganization, key).Return(testCase.storedPostIDs[key], testCase.storeErr).MaxTimes(1)
+				}
+			}
+			mockAPI.On("LogError", mock.AnythingOfType("string"), "Error", "mockError")
+			if testCase.post != nil {
+				model.ParseSlackAttachment(testCase.post, []*model.SlackAttachment{{Title: "mockPullRequest"}})
+				mockAPI.On("GetPost", "mockPostID").Return(testCase.post, nil)
+			}
+
+			var updatedPost *model.Post
+			if testCase.expectedCoalesced {
+				mockAPI.On("UpdatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					updatedPost = args.Get(0).(*model.Post)
+				}).Return(&model.Post{}, nil)
+				for _, key := range correlationKeys {
+					mockedStore.EXPECT().StoreCoalescedPost(testutils.MockChannelID, testutils.MockOrganization, key, "mockPostID", gomock.Any()).DoAndReturn(func(_, _, _, _ string, ttlSeconds int64) error {
+						// The window isn't extended by the added notification
+						assert.LessOrEqual(t, ttlSeconds, int64(20))
+						return nil
+					})
+				}
+			}
+
+			attachment := &model.SlackAttachment{
+				Title:   "mockWorkItem",
+				Actions: []*model.PostAction{{Id: constants.ShowNotificationSubscriptionActionID}},
+			}
+			coalescedPostID := p.addNotificationToCoalescedPost(testutils.MockChannelID, subscription, correlationKeys, attachment)
+
+			assert.Equal(t, testCase.expectedCoalesced, coalescedPostID != "")
+			if testCase.expectedCoalesced {
+				require.NotNil(t, updatedPost)
+				attachments := updatedPost.Attachments()
+				require.Len(t, attachments, 2)
+				assert.Equal(t, "mockPullRequest", attachments[0].Title)
+				assert.Equal(t, "mockWorkItem", attachments[1].Title)
+				assert.Equal(t, constants.ShowNotificationSubscriptionActionID+"1", attachments[1].Actions[0].Id)
+			}
+		})
+	}
+}
+
+func TestHandleSubscriptionNotificationsCoalescing(t *testing.T) {
+	pullRequestMerged := `{
+		"subscriptionID": "mockPullRequestSubscriptionID",
+		"eventType": "git.pullrequest.merged",
+		"resource": {"pullRequestId": 1, "targetRefName": "refs/heads/main", "sourceRefName": "refs/heads/mockBranch"},
+		"message": {"markdown": "mockPullRequestMarkdown"}
+	}`
+	linkedWorkItemUpdated := `{
+		"subscriptionID": "mockWorkItemSubscriptionID",
+		"eventType": "workitem.updated",
+		"resource": {
+			"workItemId": 5,
+			"revision": {
+				"fields": {"System.Title": "mockTitle", "System.TeamProject": "mockProject"},
+				"relations": [{"rel": "ArtifactLink", "url": "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F1"}]
+			}
+		},
+		"message": {"markdown": "mockWorkItemMarkdown"}
+	}`
+	unlinkedWorkItemUpdated := `{
+		"subscriptionID": "mockWorkItemSubscriptionID",
+		"eventType": "workitem.updated",
+		"resource": {
+			"workItemId": 6,
+			"revision": {"fields": {"System.Title": "mockTitle", "System.TeamProject": "mockProject"}}
+		},
+		"message": {"markdown": "mockWorkItemMarkdown"}
+	}`
+	for _, testCase := range []struct {
+		description         string
+		notifications       []string
+		expectedPostCount   int
+		expectedUpdateCount int
+	}{
+		{
+			description:         "HandleSubscriptionNotifications: update of a work item linked to a merged pull request is added to its post",
+			notifications:       []string{pullRequestMerged, linkedWorkItemUpdated},
+			expectedPostCount:   1,
+			expectedUpdateCount: 1,
+		},
+		{
+			description:       "HandleSubscriptionNotifications: update of an unrelated work item is posted separately",
+			notifications:     []string{pullRequestMerged, unlinkedWorkItemUpdated},
+			expectedPostCount: 2,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			defer monkey.UnpatchAll()
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{NotificationCoalescingWindow: 30})
+
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{
+				{SubscriptionID: "mockPullRequestSubscriptionID", OrganizationName: testutils.MockOrganization},
+				{SubscriptionID: "mockWorkItemSubscriptionID", OrganizationName: testutils.MockOrganization},
+			}, nil).AnyTimes()
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+			mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+			mockedStore.EXPECT().GetNotificationThread(testutils.MockChannelID, testutils.MockOrganization, gomock.Any()).Return("", nil).AnyTimes()
+			mockedStore.EXPECT().StoreNotificationThread(testutils.MockChannelID, testutils.MockOrganization, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			// The coalesced posts are kept in memory, as the notifications of a sequence depend on each other
+			coalescedPosts := map[string]string{}
+			mockedStore.EXPECT().GetCoalescedPost(testutils.MockChannelID, testutils.MockOrganization, gomock.Any()).DoAndReturn(func(_, _, key string) (string, error) {
+				return coalescedPosts[key], nil
+			}).AnyTimes()
+			mockedStore.EXPECT().StoreCoalescedPost(testutils.MockChannelID, testutils.MockOrganization, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_, _, key, postID string, _ int64) error {
+				coalescedPosts[key] = postID
+				return nil
+			}).AnyTimes()
+
+			posts := map[string]*model.Post{}
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(func(post *model.Post) *model.Post {
+				post.Id = fmt.Sprintf("mockPostID%d", len(posts))
+				post.CreateAt = model.GetMillis()
+				posts[post.Id] = post
+				return post
+			}, nil)
+			mockAPI.On("GetPost", mock.AnythingOfType("string")).Return(func(postID string) *model.Post {
+				return posts[postID]
+			}, nil)
+			mockAPI.On("UpdatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return testutils.MockChannelID, http.StatusOK, nil
+			})
+
+			for _, notification := range testCase.notifications {
+				req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(notification))
+				w := httptest.NewRecorder()
+				p.handleSubscriptionNotifications(w, req)
+				assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+			}
+
+			mockAPI.AssertNumberOfCalls(t, "CreatePost", testCase.expectedPostCount)
+			mockAPI.AssertNumberOfCalls(t, "UpdatePost", testCase.expectedUpdateCount)
+			if testCase.expectedUpdateCount > 0 {
+				require.Len(t, posts["mockPostID0"].Attachments(), 2)
+				assert.Contains(t, posts["mockPostID0"].Attachments()[1].Pretext, "mockWorkItemMarkdown")
+			}
+		})
+	}
+}
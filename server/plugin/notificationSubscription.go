@@ -0,0 +1,105 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// addShowSubscriptionAction adds the button showing the subscription which produced a notification posted in a channel
+func (p *Plugin) addShowSubscriptionAction(attachment *model.SlackAttachment, subscriptionID string, localizer *i18n.Localizer) {
+	if attachment == nil {
+		return
+	}
+
+	attachment.Actions = append(attachment.Actions, &model.PostAction{
+		Id:   constants.ShowNotificationSubscriptionActionID,
+		Type: model.POST_ACTION_TYPE_BUTTON,
+		Name: localizer.Localize("Show subscription"),
+		Integration: &model.PostActionIntegration{
+			URL: fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathNotificationSubscription),
+			Context: map[string]interface{}{
+				constants.ShowSubscriptionContextID: subscriptionID,
+			},
+		},
+	})
+}
+
+// getNotificationSubscriptionID returns the subscription of the notification whose button was clicked in a post.
+// The subscription in the context of the button is only trusted if the post has a button for it, otherwise the one in the props of the post is returned.
+func getNotificationSubscriptionID(post *model.Post, requestedSubscriptionID string) string {
+	if requestedSubscriptionID != "" {
+		for _, attachment := range post.Attachments() {
+			for _, action := range attachment.Actions {
+				if action != nil && action.Integration != nil && action.Integration.Context[constants.ShowSubscriptionContextID] == requestedSubscriptionID {
+					return requestedSubscriptionID
+				}
+			}
+		}
+	}
+
+	subscriptionID, _ := post.GetProp(constants.PostPropSubscriptionID).(string)
+	return subscriptionID
+}
+
+// getNotificationSubscriptionMessage describes the subscription which produced a notification in a post.
+// The posts created before the subscription ID was stored in the props don't have it, which is reported instead of an error.
+func (p *Plugin) getNotificationSubscriptionMessage(post *model.Post, requestedSubscriptionID string) string {
+	subscriptionID := getNotificationSubscriptionID(post, requestedSubscriptionID)
+	if subscriptionID == "" {
+		return constants.NotificationWithoutSubscription
+	}
+
+	subscription := p.getSubscriptionDetails(subscriptionID)
+	if subscription == nil {
+		return fmt.Sprintf(constants.NotificationSubscriptionDeleted, subscriptionID)
+	}
+
+	eventType := constants.EventTypeDisplayNames[subscription.EventType]
+	if eventType == "" {
+		eventType = subscription.EventType
+	}
+
+	message := fmt.Sprintf(constants.NotificationSubscriptionDetails, subscription.SubscriptionID, subscription.ProjectName, subscription.OrganizationName, eventType, subscription.CreatedBy, getSubscriptionCreatedAt(subscription))
+	if subscription.Label != "" {
+		message = fmt.Sprintf("%s\n* Label: %s", message, subscription.Label)
+	}
+
+	return message
+}
+
+// handleShowNotificationSubscription replies to the user clicking the button of a notification with the subscription which produced it.
+// The post is fetched again instead of trusting the request, and only the members of its channel can see its subscription.
+func (p *Plugin) handleShowNotificationSubscription(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	post, appErr := p.API.GetPost(postActionIntegrationRequest.PostId)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorNotificationSubscription, "Error", appErr.Error())
+		p.handleError(w, r, &serializers.Error{Code: appErr.StatusCode, Message: appErr.Message})
+		return
+	}
+
+	if _, appErr := p.API.GetChannelMember(post.ChannelId, mattermostUserID); appErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusForbidden, Message: constants.ErrorNotificationSubscription})
+		return
+	}
+
+	requestedSubscriptionID, _ := postActionIntegrationRequest.Context[constants.ShowSubscriptionContextID].(string)
+
+	p.returnPostActionIntegrationResponse(w, &model.PostActionIntegrationResponse{
+		EphemeralText: p.getNotificationSubscriptionMessage(post, requestedSubscriptionID),
+	})
+}
@@ -0,0 +1,217 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestHandleSubscriptionNotificationsStoresSubscriptionID(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+	mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{{
+		SubscriptionID:   testutils.MockSubscriptionID,
+		MattermostUserID: testutils.MockMattermostUserID,
+	}}, nil)
+	mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+	mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+	mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+	var createdPost *model.Post
+	mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+	mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+		createdPost = args.Get(0).(*model.Post)
+	}).Return(&model.Post{}, nil)
+	mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+	monkey.Patch(model.IsValidId, func(string) bool {
+		return true
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+		return testutils.MockChannelID, http.StatusOK, nil
+	})
+
+	body := `{
+		"subscriptionID": "mockSubscriptionID",
+		"eventType": "git.pullrequest.created",
+		"resource": {"pullRequestId": 1, "targetRefName": "refs/heads/main", "sourceRefName": "refs/heads/mockBranch"},
+		"message": {"markdown": "mockMarkdown"}
+	}`
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(body))
+
+	w := httptest.NewRecorder()
+	p.handleSubscriptionNotifications(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NotNil(t, createdPost)
+	assert.Equal(t, testutils.MockSubscriptionID, createdPost.GetProp(constants.PostPropSubscriptionID))
+	attachments := createdPost.Attachments()
+	require.Len(t, attachments, 1)
+	actions := attachments[0].Actions
+	require.NotEmpty(t, actions)
+	assert.Equal(t, constants.ShowNotificationSubscriptionActionID, actions[len(actions)-1].Id)
+}
+
+func TestGetNotificationSubscriptionMessage(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{
+		SubscriptionID:   testutils.MockSubscriptionID,
+		OrganizationName: testutils.MockOrganization,
+		ProjectName:      testutils.MockProjectName,
+		EventType:        constants.SubscriptionEventWorkItemCreated,
+		CreatedBy:        "mockCreatedBy",
+	}
+	for _, testCase := range []struct {
+		description     string
+		props           model.StringInterface
+		subscriptions   []*serializers.SubscriptionDetails
+		expectedMessage string
+	}{
+		{
+			description:     "GetNotificationSubscriptionMessage: details of the subscription",
+			props:           model.StringInterface{constants.PostPropSubscriptionID: testutils.MockSubscriptionID},
+			subscriptions:   []*serializers.SubscriptionDetails{subscription},
+			expectedMessage: fmt.Sprintf(constants.NotificationSubscriptionDetails, testutils.MockSubscriptionID, testutils.MockProjectName, testutils.MockOrganization, "Work Item Created", "mockCreatedBy", constants.SubscriptionCreatedAtUnknown),
+		},
+		{
+			description:     "GetNotificationSubscriptionMessage: subscription is deleted",
+			props:           model.StringInterface{constants.PostPropSubscriptionID: testutils.MockSubscriptionID},
+			expectedMessage: fmt.Sprintf(constants.NotificationSubscriptionDeleted, testutils.MockSubscriptionID),
+		},
+		{
+			description:     "GetNotificationSubscriptionMessage: post created before the subscription ID was stored",
+			expectedMessage: constants.NotificationWithoutSubscription,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+			if testCase.props != nil {
+				mockedStore.EXPECT().GetAllSubscriptions("").Return(testCase.subscriptions, nil)
+			}
+
+			message := p.getNotificationSubscriptionMessage(&model.Post{Props: testCase.props}, "")
+
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
+
+func TestHandleShowNotificationSubscription(t *testing.T) {
+	for _, testCase := range []struct {
+		description        string
+		post               *model.Post
+		getPostErr         *model.AppError
+		channelMemberErr   *model.AppError
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			description:        "HandleShowNotificationSubscription: subscription ID is read from the post",
+			post:               &model.Post{Id: "mockPostID", ChannelId: testutils.MockChannelID, Props: model.StringInterface{constants.PostPropSubscriptionID: testutils.MockSubscriptionID}},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    fmt.Sprintf(constants.NotificationSubscriptionDeleted, testutils.MockSubscriptionID),
+		},
+		{
+			description:        "HandleShowNotificationSubscription: post without a subscription ID",
+			post:               &model.Post{Id: "mockPostID", ChannelId: testutils.MockChannelID},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    constants.NotificationWithoutSubscription,
+		},
+		{
+			description:        "HandleShowNotificationSubscription: post is not found",
+			getPostErr:         &model.AppError{Message: "post not found", StatusCode: http.StatusNotFound},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleShowNotificationSubscription: user is not a member of the channel",
+			post:               &model.Post{Id: "mockPostID", ChannelId: testutils.MockChannelID},
+			channelMemberErr:   &model.AppError{Message: "channel member not found"},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockAPI.On("GetPost", "mockPostID").Return(testCase.post, testCase.getPostErr)
+			mockAPI.On("GetChannelMember", testutils.MockChannelID, testutils.MockMattermostUserID).Return(&model.ChannelMember{}, testCase.channelMemberErr)
+			mockAPI.On("LogError", mock.AnythingOfType("string"), "Error", mock.AnythingOfType("string"))
+			mockedStore.EXPECT().GetAllSubscriptions("").Return(nil, nil).AnyTimes()
+
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{PostId: "mockPostID"})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, constants.PathNotificationSubscription, bytes.NewBuffer(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleShowNotificationSubscription(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var response *model.PostActionIntegrationResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			assert.Equal(t, testCase.expectedMessage, response.EphemeralText)
+		})
+	}
+}
+
+func TestGetNotificationSubscriptionID(t *testing.T) {
+	post := &model.Post{Props: model.StringInterface{constants.PostPropSubscriptionID: testutils.MockSubscriptionID}}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{
+		{Actions: []*model.PostAction{{Integration: &model.PostActionIntegration{Context: map[string]interface{}{constants.ShowSubscriptionContextID: testutils.MockSubscriptionID}}}}},
+		{Actions: []*model.PostAction{{Integration: &model.PostActionIntegration{Context: map[string]interface{}{constants.ShowSubscriptionContextID: "mockCoalescedSubscriptionID"}}}}},
+	})
+	for _, testCase := range []struct {
+		description             string
+		requestedSubscriptionID string
+		expectedSubscriptionID  string
+	}{
+		{
+			description:             "GetNotificationSubscriptionID: subscription of a notification added to the post",
+			requestedSubscriptionID: "mockCoalescedSubscriptionID",
+			expectedSubscriptionID:  "mockCoalescedSubscriptionID",
+		},
+		{
+			description:             "GetNotificationSubscriptionID: subscription without a button in the post is ignored",
+			requestedSubscriptionID: "mockOtherSubscriptionID",
+			expectedSubscriptionID:  testutils.MockSubscriptionID,
+		},
+		{
+			description:            "GetNotificationSubscriptionID: button created before the subscription ID was added to its context",
+			expectedSubscriptionID: testutils.MockSubscriptionID,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedSubscriptionID, getNotificationSubscriptionID(post, testCase.requestedSubscriptionID))
+		})
+	}
+}
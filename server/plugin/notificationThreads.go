@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getNotificationWorkItemID returns the ID of the work item a notification is about, it's 0 for the other notifications
+func getNotificationWorkItemID(body *serializers.SubscriptionNotification) int {
+	switch body.EventType {
+	case constants.SubscriptionEventWorkItemUpdated:
+		return body.Resource.WorkItemID
+	case constants.SubscriptionEventWorkItemCreated, constants.SubscriptionEventWorkItemDeleted, constants.SubscriptionEventWorkItemCommented:
+		// JSON numbers are decoded as float64 in an interface
+		if id, ok := body.Resource.ID.(float64); ok {
+			return int(id)
+		}
+	}
+
+	return 0
+}
+
+// getNotificationThreadRootID returns the post under which the notifications of a work item are threaded in a channel.
+// It's empty if the work item doesn't have a thread yet or its root post is deleted, so that a new thread is started.
+func (p *Plugin) getNotificationThreadRootID(channelID, organization string, workItemID int) string {
+	rootPostID, err := p.Store.GetNotificationThread(channelID, organization, workItemID)
+	if err != nil {
+		p.API.LogError("Error in fetching the notification thread", "Error", err.Error())
+		return ""
+	}
+
+	if rootPostID == "" {
+		return ""
+	}
+
+	rootPost, appErr := p.API.GetPost(rootPostID)
+	if appErr != nil || rootPost.DeleteAt != 0 || rootPost.ChannelId != channelID {
+		return ""
+	}
+
+	return rootPostID
+}
+
+// createNotificationPost creates the post of a notification, threading it under the earlier notifications of the same work item
+func (p *Plugin) createNotificationPost(post *model.Post, subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) (*model.Post, *model.AppError) {
+	workItemID := getNotificationWorkItemID(body)
+	if workItemID == 0 {
+		return p.API.CreatePost(post)
+	}
+
+	organization := ""
+	if subscription != nil {
+		organization = subscription.OrganizationName
+	}
+
+	post.RootId = p.getNotificationThreadRootID(post.ChannelId, organization, workItemID)
+	createdPost, appErr := p.API.CreatePost(post)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rootPostID := createdPost.RootId
+	if rootPostID == "" {
+		rootPostID = createdPost.Id
+	}
+
+	// The thread is stored again for every notification to extend its expiry
+	if err := p.Store.StoreNotificationThread(post.ChannelId, organization, workItemID, rootPostID); err != nil {
+		p.API.LogError("Error in storing the notification thread", "Error", err.Error())
+	}
+
+	return createdPost, nil
+}
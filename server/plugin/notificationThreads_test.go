@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetNotificationWorkItemID(t *testing.T) {
+	for _, testCase := range []struct {
+		description        string
+		body               string
+		expectedWorkItemID int
+	}{
+		{
+			description:        "GetNotificationWorkItemID: work item created",
+			body:               `{"eventType": "workitem.created", "resource": {"id": 5}}`,
+			expectedWorkItemID: 5,
+		},
+		{
+			description:        "GetNotificationWorkItemID: work item updated",
+			body:               `{"eventType": "workitem.updated", "resource": {"id": 2, "workItemId": 5}}`,
+			expectedWorkItemID: 5,
+		},
+		{
+			description:        "GetNotificationWorkItemID: work item commented",
+			body:               `{"eventType": "workitem.commented", "resource": {"id": 5}}`,
+			expectedWorkItemID: 5,
+		},
+		{
+			description: "GetNotificationWorkItemID: other event",
+			body:        `{"eventType": "build.complete", "resource": {"id": 5}}`,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			body, err := serializers.SubscriptionNotificationFromJSON(bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedWorkItemID, getNotificationWorkItemID(body))
+		})
+	}
+}
+
+func TestCreateNotificationPost(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{OrganizationName: testutils.MockOrganization}
+	workItemBody := &serializers.SubscriptionNotification{
+		EventType: "workitem.updated",
+		Resource:  serializers.Resource{WorkItemID: 5},
+	}
+
+	for _, testCase := range []struct {
+		description        string
+		body               *serializers.SubscriptionNotification
+		storedRootPostID   string
+		rootPost           *model.Post
+		rootPostErr        *model.AppError
+		expectedRootPostID string
+	}{
+		{
+			description: "CreateNotificationPost: notification which is not about a work item",
+			body:        &serializers.SubscriptionNotification{EventType: "build.complete"},
+		},
+		{
+			description: "CreateNotificationPost: first notification of a work item starts a thread",
+			body:        workItemBody,
+		},
+		{
+			description:        "CreateNotificationPost: notification is threaded under the root post",
+			body:               workItemBody,
+			storedRootPostID:   "mockRootPostID",
+			rootPost:           &model.Post{Id: "mockRootPostID", ChannelId: testutils.MockChannelID},
+			expectedRootPostID: "mockRootPostID",
+		},
+		{
+			description:      "CreateNotificationPost: root post is deleted",
+			body:             workItemBody,
+			storedRootPostID: "mockRootPostID",
+			rootPostErr:      &model.AppError{StatusCode: 404},
+		},
+		{
+			description:      "CreateNotificationPost: root post is marked as deleted",
+			body:             workItemBody,
+			storedRootPostID: "mockRootPostID",
+			rootPost:         &model.Post{Id: "mockRootPostID", ChannelId: testutils.MockChannelID, DeleteAt: 1},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(func(post *model.Post) *model.Post {
+				assert.Equal(t, testCase.expectedRootPostID, post.RootId)
+				return &model.Post{Id: "mockPostID", RootId: post.RootId, ChannelId: post.ChannelId}
+			}, nil)
+			mockAPI.On("GetPost", "mockRootPostID").Return(testCase.rootPost, testCase.rootPostErr)
+
+			if testCase.body == workItemBody {
+				expectedStoredRootPostID := testCase.expectedRootPostID
+				if expectedStoredRootPostID == "" {
+					expectedStoredRootPostID = "mockPostID"
+				}
+				mockedStore.EXPECT().GetNotificationThread(testutils.MockChannelID, testutils.MockOrganization, 5).Return(testCase.storedRootPostID, nil)
+				mockedStore.EXPECT().StoreNotificationThread(testutils.MockChannelID, testutils.MockOrganization, 5, expectedStoredRootPostID).Return(nil)
+			}
+
+			post, err := p.createNotificationPost(&model.Post{ChannelId: testutils.MockChannelID}, subscription, testCase.body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "mockPostID", post.Id)
+		})
+	}
+
+	t.Run("CreateNotificationPost: error in fetching the thread", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+		mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+		mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+		mockedStore.EXPECT().GetNotificationThread(testutils.MockChannelID, testutils.MockOrganization, 5).Return("", errors.New("error in fetching the thread"))
+		mockedStore.EXPECT().StoreNotificationThread(testutils.MockChannelID, testutils.MockOrganization, 5, "mockPostID").Return(nil)
+
+		post, err := p.createNotificationPost(&model.Post{ChannelId: testutils.MockChannelID}, subscription, workItemBody)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "mockPostID", post.Id)
+	})
+}
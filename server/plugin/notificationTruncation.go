@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// notificationTruncation contains the maximum lengths of the texts in a notification along with the link to view the full texts
+type notificationTruncation struct {
+	titleLength       int
+	descriptionLength int
+	commentLength     int
+	link              string
+}
+
+// getNotificationTruncation returns the maximum lengths of the texts in a notification of a subscription.
+// The lengths set in the plugin configuration are used unless they are overridden for the subscription.
+func (p *Plugin) getNotificationTruncation(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) *notificationTruncation {
+	config := p.getConfiguration()
+	truncation := &notificationTruncation{
+		titleLength:       config.NotificationTitleLength,
+		descriptionLength: config.NotificationDescriptionLength,
+		commentLength:     config.NotificationCommentLength,
+	}
+
+	if subscription == nil {
+		return truncation
+	}
+
+	if overrides := subscription.Truncation; overrides != nil {
+		if overrides.Title != nil {
+			truncation.titleLength = *overrides.Title
+		}
+		if overrides.Description != nil {
+			truncation.descriptionLength = *overrides.Description
+		}
+		if overrides.Comment != nil {
+			truncation.commentLength = *overrides.Comment
+		}
+	}
+
+	truncation.link = p.getNotificationLink(subscription, body)
+	return truncation
+}
+
+// getNotificationLink returns the link of the work item or pull request a notification is about, it's empty for the other notifications
+func (p *Plugin) getNotificationLink(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) string {
+	baseURL := p.getConfiguration().GetAzureDevopsAPIBaseURL()
+	projectName := url.PathEscape(subscription.ProjectName)
+	if workItemID := getNotificationWorkItemID(body); workItemID != 0 {
+		return fmt.Sprintf(constants.WorkItemEditLink, baseURL, subscription.OrganizationName, projectName, workItemID)
+	}
+
+	switch body.EventType {
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
+		return fmt.Sprintf(constants.PullRequestLink, baseURL, subscription.OrganizationName, projectName, url.PathEscape(body.Resource.Repository.Name), body.Resource.PullRequestID)
+	case constants.SubscriptionEventPullRequestCommented:
+		return fmt.Sprintf(constants.PullRequestLink, baseURL, subscription.OrganizationName, projectName, url.PathEscape(body.Resource.PullRequest.Repository.Name), body.Resource.PullRequest.PullRequestID)
+	}
+
+	return ""
+}
+
+// truncateTitle truncates the title of a notification.
+// Links are not rendered in the titles of attachments, so the title links to the full title instead if it's not linked already.
+func (t *notificationTruncation) truncateTitle(attachment *model.SlackAttachment) {
+	title, isTruncated := truncateText(attachment.Title, t.titleLength)
+	if !isTruncated {
+		return
+	}
+
+	attachment.Title = title
+	if attachment.TitleLink == "" {
+		attachment.TitleLink = t.link
+	}
+}
+
+func (t *notificationTruncation) truncateDescription(description string) string {
+	return t.truncate(description, t.descriptionLength)
+}
+
+func (t *notificationTruncation) truncateComment(comment string) string {
+	return t.truncate(comment, t.commentLength)
+}
+
+func (t *notificationTruncation) truncate(text string, maxLength int) string {
+	truncatedText, isTruncated := truncateText(text, maxLength)
+	if !isTruncated || t.link == "" {
+		return truncatedText
+	}
+
+	return fmt.Sprintf(constants.NotificationViewMoreFormat, truncatedText, t.link)
+}
+
+// truncateText shortens a text to the given number of characters followed by an ellipsis, the text is not truncated if the length is 0.
+// The characters are counted as runes so that a multibyte character is never split.
+func truncateText(text string, maxLength int) (string, bool) {
+	if maxLength <= 0 || utf8.RuneCountInString(text) <= maxLength {
+		return text, false
+	}
+
+	runes := []rune(text)
+	return strings.TrimRightFunc(string(runes[:maxLength]), unicode.IsSpace) + constants.NotificationTruncationEllipsis, true
+}
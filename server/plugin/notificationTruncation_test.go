@@ -0,0 +1,173 @@
+package plugin
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestTruncateText(t *testing.T) {
+	for _, testCase := range []struct {
+		description         string
+		text                string
+		maxLength           int
+		expectedText        string
+		expectedIsTruncated bool
+	}{
+		{
+			description:  "TruncateText: text is shorter than the maximum length",
+			text:         "mock title",
+			maxLength:    20,
+			expectedText: "mock title",
+		},
+		{
+			description:  "TruncateText: text is not truncated when the maximum length is 0",
+			text:         "mock title",
+			expectedText: "mock title",
+		},
+		{
+			description:         "TruncateText: text is truncated",
+			text:                "mock long title",
+			maxLength:           9,
+			expectedText:        "mock long…",
+			expectedIsTruncated: true,
+		},
+		{
+			description:         "TruncateText: trailing whitespace is removed before the ellipsis",
+			text:                "mock long title",
+			maxLength:           5,
+			expectedText:        "mock…",
+			expectedIsTruncated: true,
+		},
+		{
+			description:  "TruncateText: multibyte characters are counted as single characters",
+			text:         "日本語のタイトル",
+			maxLength:    8,
+			expectedText: "日本語のタイトル",
+		},
+		{
+			description:         "TruncateText: multibyte characters are not split",
+			text:                "日本語のタイトル",
+			maxLength:           3,
+			expectedText:        "日本語…",
+			expectedIsTruncated: true,
+		},
+		{
+			description:         "TruncateText: emoji are not split",
+			text:                "🚀 Ünïcödé 🚀 release",
+			maxLength:           12,
+			expectedText:        "🚀 Ünïcödé 🚀…",
+			expectedIsTruncated: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			text, isTruncated := truncateText(testCase.text, testCase.maxLength)
+
+			assert.Equal(t, testCase.expectedText, text)
+			assert.Equal(t, testCase.expectedIsTruncated, isTruncated)
+			assert.True(t, utf8.ValidString(text))
+		})
+	}
+}
+
+func TestGetNotificationTruncation(t *testing.T) {
+	p := Plugin{}
+	p.setConfiguration(&config.Configuration{
+		AzureDevopsAPIBaseURL:         "https://dev.azure.com",
+		NotificationTitleLength:       150,
+		NotificationDescriptionLength: 500,
+		NotificationCommentLength:     1000,
+	})
+
+	titleLength, commentLength := 50, 0
+	for _, testCase := range []struct {
+		description        string
+		subscription       *serializers.SubscriptionDetails
+		body               *serializers.SubscriptionNotification
+		expectedTruncation *notificationTruncation
+	}{
+		{
+			description:        "GetNotificationTruncation: subscription is not found",
+			body:               &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCreated, Resource: serializers.Resource{ID: float64(1)}},
+			expectedTruncation: &notificationTruncation{titleLength: 150, descriptionLength: 500, commentLength: 1000},
+		},
+		{
+			description: "GetNotificationTruncation: lengths are overridden for the subscription",
+			subscription: &serializers.SubscriptionDetails{
+				OrganizationName: testutils.MockOrganization,
+				ProjectName:      "mock project",
+				Truncation:       &serializers.NotificationTruncation{Title: &titleLength, Comment: &commentLength},
+			},
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemUpdated, Resource: serializers.Resource{WorkItemID: 1}},
+			expectedTruncation: &notificationTruncation{
+				titleLength:       50,
+				descriptionLength: 500,
+				link:              "https://dev.azure.com/mockOrganization/mock%20project/_workitems/edit/1",
+			},
+		},
+		{
+			description:  "GetNotificationTruncation: pull request comment",
+			subscription: &serializers.SubscriptionDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName},
+			body: &serializers.SubscriptionNotification{
+				EventType: constants.SubscriptionEventPullRequestCommented,
+				Resource:  serializers.Resource{PullRequest: serializers.PullRequest{PullRequestID: 2, Repository: serializers.Repository{Name: "mockRepository"}}},
+			},
+			expectedTruncation: &notificationTruncation{
+				titleLength:       150,
+				descriptionLength: 500,
+				commentLength:     1000,
+				link:              "https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepository/pullrequest/2",
+			},
+		},
+		{
+			description:        "GetNotificationTruncation: notification without a link",
+			subscription:       &serializers.SubscriptionDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName},
+			body:               &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted},
+			expectedTruncation: &notificationTruncation{titleLength: 150, descriptionLength: 500, commentLength: 1000},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedTruncation, p.getNotificationTruncation(testCase.subscription, testCase.body))
+		})
+	}
+}
+
+func TestNotificationTruncation(t *testing.T) {
+	truncation := &notificationTruncation{titleLength: 5, descriptionLength: 4, commentLength: 0, link: "https://mockLink"}
+
+	t.Run("NotificationTruncation: view more link is appended to a truncated description", func(t *testing.T) {
+		assert.Equal(t, "Ünïc… [view more](https://mockLink)", truncation.truncateDescription("Ünïcödé description"))
+		assert.Equal(t, "Ünïc", truncation.truncateDescription("Ünïc"))
+	})
+
+	t.Run("NotificationTruncation: comment is not truncated when the length is 0", func(t *testing.T) {
+		assert.Equal(t, "mock comment", truncation.truncateComment("mock comment"))
+	})
+
+	t.Run("NotificationTruncation: view more link is not appended without a link", func(t *testing.T) {
+		assert.Equal(t, "Ünïc…", (&notificationTruncation{descriptionLength: 4}).truncateDescription("Ünïcödé description"))
+	})
+
+	t.Run("NotificationTruncation: truncated title links to the full title", func(t *testing.T) {
+		attachment := &model.SlackAttachment{Title: "1: mock title"}
+		truncation.truncateTitle(attachment)
+
+		assert.Equal(t, "1: mo…", attachment.Title)
+		assert.Equal(t, "https://mockLink", attachment.TitleLink)
+	})
+
+	t.Run("NotificationTruncation: title is not linked if it's not truncated", func(t *testing.T) {
+		attachment := &model.SlackAttachment{Title: "mock"}
+		truncation.truncateTitle(attachment)
+
+		assert.Equal(t, "mock", attachment.Title)
+		assert.Empty(t, attachment.TitleLink)
+	})
+}
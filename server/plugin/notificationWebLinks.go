@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// apiURLMappings map the API URLs of the resources to their web pages, a repository is matched after its pull requests
+var apiURLMappings = []struct {
+	regex  *regexp.Regexp
+	webURL string
+}{
+	{regexp.MustCompile(constants.WorkItemAPIURLRegex), "${1}/_workitems/edit/${2}"},
+	{regexp.MustCompile(constants.PullRequestAPIURLRegex), "${1}/_git/${2}/pullrequest/${3}"},
+	{regexp.MustCompile(constants.RepositoryAPIURLRegex), "${1}/_git/${2}"},
+	{regexp.MustCompile(constants.BuildAPIURLRegex), "${1}/_build/results?buildId=${2}"},
+	{regexp.MustCompile(constants.ReleaseAPIURLRegex), "${1}${2}/_releaseProgress?releaseId=${3}&_a=release-pipeline-progress"},
+}
+
+// getWebURLFromAPIURL returns the web page of a work item, pull request, repository, build or release from its API URL.
+// The rest of the API URL like the revision of a work item or the query is left out, and it's empty for any other API URL.
+func getWebURLFromAPIURL(apiURL string) string {
+	for _, mapping := range apiURLMappings {
+		if match := mapping.regex.FindStringSubmatchIndex(apiURL); match != nil {
+			return string(mapping.regex.ExpandString(nil, mapping.webURL, apiURL, match))
+		}
+	}
+
+	return ""
+}
+
+// getNotificationWebURL returns the web page of the resource of a notification.
+// The web links of the payload are preferred, and the API URLs are mapped for the payloads which only have them.
+func getNotificationWebURL(body *serializers.SubscriptionNotification) string {
+	resource := body.Resource
+	var webURLs []string
+	switch body.EventType {
+	case constants.SubscriptionEventWorkItemCreated, constants.SubscriptionEventWorkItemUpdated, constants.SubscriptionEventWorkItemCommented, constants.SubscriptionEventWorkItemDeleted:
+		webURLs = []string{resource.Links.HTML.Href, getWebURLFromAPIURL(resource.URL)}
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
+		webURLs = []string{resource.Links.Web.Href, getWebURLFromAPIURL(resource.URL)}
+	case constants.SubscriptionEventPullRequestCommented:
+		webURLs = []string{getWebURLFromAPIURL(resource.PullRequest.URL)}
+	case constants.SubscriptionEventCodePushed:
+		repositoryURL := resource.Repository.RemoteURL
+		if repositoryURL == "" {
+			repositoryURL = getWebURLFromAPIURL(resource.Repository.URL)
+		}
+		if branch := getNotificationBranch(body); repositoryURL != "" && branch != "" {
+			repositoryURL = fmt.Sprintf("%s?version=GB%s", repositoryURL, url.QueryEscape(branch))
+		}
+		webURLs = []string{repositoryURL}
+	case constants.SubscriptionEventBuildCompleted:
+		webURLs = []string{resource.Links.Web.Href, getWebURLFromAPIURL(resource.URL)}
+	case constants.SubscriptionEventReleaseCreated, constants.SubscriptionEventReleaseAbandoned, constants.SubscriptionEventReleaseDeploymentStarted,
+		constants.SubscriptionEventReleaseDeploymentEventPending, constants.SubscriptionEventReleaseDeploymentApprovalCompleted:
+		webURLs = []string{resource.Release.Links.Web.Href, getWebURLFromAPIURL(resource.Release.URL)}
+	case constants.SubscriptionEventReleaseDeploymentCompleted:
+		webURLs = []string{resource.Environment.Release.Links.Web.Href, getWebURLFromAPIURL(resource.Environment.Release.URL)}
+	case constants.SubscriptionEventRunStateChanged:
+		webURLs = []string{resource.Run.Links.Web.Href, resource.Run.Links.PipelineWeb.Href, resource.Pipeline.Links.Web.Href}
+	case constants.SubscriptionEventRunStageStateChanged, constants.SubscriptionEventRunStageWaitingForApproval, constants.SubscriptionEventRunStageApprovalCompleted:
+		webURLs = []string{resource.Stage.Links.Web.Href, resource.Stage.Links.PipelineWeb.Href, resource.Pipeline.Links.Web.Href}
+	default:
+		webURLs = []string{getWebURLFromAPIURL(resource.URL)}
+	}
+
+	for _, webURL := range webURLs {
+		if isWebURL(webURL) {
+			return webURL
+		}
+	}
+
+	return ""
+}
+
+// isWebURL checks if a URL can be opened in the browser, so that a payload can't make the link open any other kind of URL
+func isWebURL(webURL string) bool {
+	parsedURL, err := url.Parse(webURL)
+	if err != nil {
+		return false
+	}
+
+	return (parsedURL.Scheme == "https" || parsedURL.Scheme == "http") && parsedURL.Host != ""
+}
+
+// addOpenInAzureDevopsLink adds the link opening the resource of a notification in Azure DevOps as the last field of the notification
+func addOpenInAzureDevopsLink(attachment *model.SlackAttachment, body *serializers.SubscriptionNotification, localizer *i18n.Localizer) {
+	webURL := getNotificationWebURL(body)
+	if webURL == "" {
+		return
+	}
+
+	attachment.Fields = append(attachment.Fields, &model.SlackAttachmentField{
+		Value: fmt.Sprintf("[%s](%s)", localizer.Localize("Open in Azure DevOps"), webURL),
+	})
+}
@@ -0,0 +1,179 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestGetWebURLFromAPIURL(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		apiURL         string
+		expectedWebURL string
+	}{
+		{
+			description:    "GetWebURLFromAPIURL: work item",
+			apiURL:         "https://dev.azure.com/mockOrganization/_apis/wit/workItems/5",
+			expectedWebURL: "https://dev.azure.com/mockOrganization/_workitems/edit/5",
+		},
+		{
+			description:    "GetWebURLFromAPIURL: update of a work item",
+			apiURL:         "https://dev.azure.com/mockOrganization/mockProjectID/_apis/wit/workItems/5/updates/2",
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_workitems/edit/5",
+		},
+		{
+			description:    "GetWebURLFromAPIURL: pull request",
+			apiURL:         "https://dev.azure.com/mockOrganization/mockProjectID/_apis/git/repositories/mockRepositoryID/pullRequests/12?api-version=7.1",
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_git/mockRepositoryID/pullrequest/12",
+		},
+		{
+			description:    "GetWebURLFromAPIURL: repository",
+			apiURL:         "https://dev.azure.com/mockOrganization/mockProjectID/_apis/git/repositories/mockRepositoryID/pushes/7",
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_git/mockRepositoryID",
+		},
+		{
+			description:    "GetWebURLFromAPIURL: build",
+			apiURL:         "https://dev.azure.com/mockOrganization/mockProjectID/_apis/build/Builds/3",
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_build/results?buildId=3",
+		},
+		{
+			description:    "GetWebURLFromAPIURL: release of Azure DevOps Services",
+			apiURL:         "https://vsrm.dev.azure.com/mockOrganization/mockProjectID/_apis/Release/releases/4",
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_releaseProgress?releaseId=4&_a=release-pipeline-progress",
+		},
+		{
+			description:    "GetWebURLFromAPIURL: release of Azure DevOps Server",
+			apiURL:         "https://tfs.example.com/mockCollection/mockProject/_apis/Release/releases/4",
+			expectedWebURL: "https://tfs.example.com/mockCollection/mockProject/_releaseProgress?releaseId=4&_a=release-pipeline-progress",
+		},
+		{
+			description: "GetWebURLFromAPIURL: unknown resource",
+			apiURL:      "https://dev.azure.com/mockOrganization/_apis/projects/mockProjectID",
+		},
+		{
+			description: "GetWebURLFromAPIURL: empty URL",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedWebURL, getWebURLFromAPIURL(testCase.apiURL))
+		})
+	}
+}
+
+func TestGetNotificationWebURL(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		body           *serializers.SubscriptionNotification
+		expectedWebURL string
+	}{
+		{
+			description: "GetNotificationWebURL: web link of a work item is preferred",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCreated, Resource: serializers.Resource{
+				URL:   "https://dev.azure.com/mockOrganization/_apis/wit/workItems/5",
+				Links: serializers.Link{HTML: serializers.Href{Href: "https://dev.azure.com/mockOrganization/mockProject/_workitems/edit/5"}},
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProject/_workitems/edit/5",
+		},
+		{
+			description: "GetNotificationWebURL: API URL of an updated work item",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemUpdated, Resource: serializers.Resource{
+				URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/5/updates/2",
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/_workitems/edit/5",
+		},
+		{
+			description: "GetNotificationWebURL: pull request",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCreated, Resource: serializers.Resource{
+				URL: "https://dev.azure.com/mockOrganization/mockProjectID/_apis/git/repositories/mockRepositoryID/pullRequests/12",
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_git/mockRepositoryID/pullrequest/12",
+		},
+		{
+			description: "GetNotificationWebURL: commented pull request",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCommented, Resource: serializers.Resource{
+				URL:         "https://dev.azure.com/mockOrganization/mockProjectID/_apis/git/repositories/mockRepositoryID/pullRequests/12/threads/1/comments/1",
+				PullRequest: serializers.PullRequest{URL: "https://dev.azure.com/mockOrganization/mockProjectID/_apis/git/repositories/mockRepositoryID/pullRequests/12"},
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_git/mockRepositoryID/pullrequest/12",
+		},
+		{
+			description: "GetNotificationWebURL: branch of a push",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed, Resource: serializers.Resource{
+				Repository: serializers.Repository{RemoteURL: "https://dev.azure.com/mockOrganization/mockProject/_git/mockRepository"},
+				RefUpdates: []serializers.RefUpdates{{Name: "refs/heads/feature/mockBranch"}},
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProject/_git/mockRepository?version=GBfeature%2FmockBranch",
+		},
+		{
+			description: "GetNotificationWebURL: build",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted, Resource: serializers.Resource{
+				URL: "https://dev.azure.com/mockOrganization/mockProjectID/_apis/build/Builds/3",
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_build/results?buildId=3",
+		},
+		{
+			description: "GetNotificationWebURL: release",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventReleaseCreated, Resource: serializers.Resource{
+				Release: serializers.Release{URL: "https://vsrm.dev.azure.com/mockOrganization/mockProjectID/_apis/Release/releases/4"},
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProjectID/_releaseProgress?releaseId=4&_a=release-pipeline-progress",
+		},
+		{
+			description: "GetNotificationWebURL: release of a completed deployment",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventReleaseDeploymentCompleted, Resource: serializers.Resource{
+				Environment: serializers.Environment{Release: serializers.Release{Links: serializers.ProjectLink{Web: serializers.Href{Href: "https://dev.azure.com/mockOrganization/mockProject/_release?releaseId=4"}}}},
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProject/_release?releaseId=4",
+		},
+		{
+			description: "GetNotificationWebURL: run",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventRunStateChanged, Resource: serializers.Resource{
+				Run: serializers.Stage{Links: serializers.ProjectLink{Web: serializers.Href{Href: "https://dev.azure.com/mockOrganization/mockProject/_build/results?buildId=6"}}},
+			}},
+			expectedWebURL: "https://dev.azure.com/mockOrganization/mockProject/_build/results?buildId=6",
+		},
+		{
+			description: "GetNotificationWebURL: link which can't be opened in the browser",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCreated, Resource: serializers.Resource{
+				Links: serializers.Link{HTML: serializers.Href{Href: "javascript:alert(1)"}},
+			}},
+		},
+		{
+			description: "GetNotificationWebURL: notification without a URL",
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedWebURL, getNotificationWebURL(testCase.body))
+		})
+	}
+}
+
+func TestAddOpenInAzureDevopsLink(t *testing.T) {
+	body := &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted, Resource: serializers.Resource{
+		URL: "https://dev.azure.com/mockOrganization/mockProjectID/_apis/build/Builds/3",
+	}}
+
+	t.Run("AddOpenInAzureDevopsLink: link is added after the other fields", func(t *testing.T) {
+		attachment := &model.SlackAttachment{Fields: []*model.SlackAttachmentField{{Title: "mockTitle"}}}
+		addOpenInAzureDevopsLink(attachment, body, i18n.NewLocalizer("de"))
+
+		require.Len(t, attachment.Fields, 2)
+		assert.Equal(t, "[In Azure DevOps öffnen](https://dev.azure.com/mockOrganization/mockProjectID/_build/results?buildId=3)", attachment.Fields[1].Value)
+		assert.Empty(t, attachment.Actions)
+	})
+
+	t.Run("AddOpenInAzureDevopsLink: link is not added without a web URL", func(t *testing.T) {
+		attachment := &model.SlackAttachment{}
+		addOpenInAzureDevopsLink(attachment, &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted}, i18n.NewLocalizer(""))
+
+		assert.Empty(t, attachment.Fields)
+	})
+}
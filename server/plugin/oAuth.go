@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,8 @@ import (
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 )
 
+var organizationNameRegex = regexp.MustCompile(constants.OrganizationNameRegex)
+
 type OAuthConfig struct {
 	appID        string
 	clientSecret string
@@ -29,18 +32,22 @@ func (p *Plugin) OAuthConfig() *OAuthConfig {
 	return &OAuthConfig{
 		appID:        p.getConfiguration().AzureDevopsOAuthAppID,
 		clientSecret: p.getConfiguration().AzureDevopsOAuthClientSecret,
-		authURL:      fmt.Sprintf("%s%s", constants.BaseOauthURL, constants.PathAuth),
+		authURL:      fmt.Sprintf("%s%s", p.getConfiguration().GetOAuthBaseURL(), constants.PathAuth),
 		redirectURI:  fmt.Sprintf("%s%s%s", p.GetSiteURL(), p.GetPluginURLPath(), constants.PathOAuthCallback),
 		responseType: constants.ResponseType,
 		scope:        constants.Scopes, // these scopes must be set in the OAuth app registered with the Azure portal
 	}
 }
 
-// GenerateOAuthConnectURL generates URL for Azure OAuth authorization
-func (p *Plugin) GenerateOAuthConnectURL(mattermostUserID string) string {
+// GenerateOAuthConnectURL generates URL for Azure OAuth authorization.
+// The organization, if any, is added to the OAuth state so that it can be used once the authorization is completed.
+func (p *Plugin) GenerateOAuthConnectURL(mattermostUserID, organization string) string {
 	oAuthConfig := p.OAuthConfig()
 
 	oAuthState := fmt.Sprintf("%s_%s", model.NewId()[0:15], mattermostUserID)
+	if organization != "" {
+		oAuthState = fmt.Sprintf("%s_%s", oAuthState, organization)
+	}
 	if err := p.Store.StoreOAuthState(mattermostUserID, oAuthState); err != nil {
 		p.API.LogError(fmt.Sprintf(constants.UnableToStoreOauthState, mattermostUserID), "Error", err.Error())
 	}
@@ -68,17 +75,26 @@ func (p *Plugin) GenerateOAuthConnectURL(mattermostUserID string) string {
 func (p *Plugin) OAuthConnect(w http.ResponseWriter, r *http.Request) {
 	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
 
-	if isConnected := p.MattermostUserAlreadyConnected(mattermostUserID); isConnected {
+	organization := strings.ToLower(strings.TrimSpace(r.URL.Query().Get(constants.QueryParamOrganization)))
+	if organization != "" {
+		if err := p.validateConnectOrganization(organization); err != nil {
+			p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+	}
+
+	// A connected user can still connect another organization
+	if message := p.getConnectMessage(mattermostUserID, organization); message != "" {
 		p.CloseBrowserWindowWithHTTPResponse(w)
-		if _, DMErr := p.DM(mattermostUserID, constants.MattermostUserAlreadyConnected, false); DMErr != nil {
+		if _, DMErr := p.DM(mattermostUserID, message, false); DMErr != nil {
 			p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: DMErr.Error()})
 			return
 		}
-		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: constants.MattermostUserAlreadyConnected})
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: message})
 		return
 	}
 
-	redirectURL := p.GenerateOAuthConnectURL(mattermostUserID)
+	redirectURL := p.GenerateOAuthConnectURL(mattermostUserID, organization)
 
 	http.Redirect(w, r, redirectURL, http.StatusFound)
 }
@@ -97,7 +113,7 @@ func (p *Plugin) OAuthComplete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(strings.Split(state, "_")) != 2 || strings.Split(state, "_")[1] == "" {
+	if _, _, isValid := parseOAuthState(state); !isValid {
 		http.Error(w, constants.InvalidAuthState, http.StatusBadRequest)
 		return
 	}
@@ -119,7 +135,7 @@ func (p *Plugin) OAuthComplete(w http.ResponseWriter, r *http.Request) {
 
 // GenerateOAuthToken generates OAuth token after successful authorization
 func (p *Plugin) GenerateOAuthToken(code, state, authenticatedMattermostUserID string) error {
-	mattermostUserID := strings.Split(state, "_")[1]
+	mattermostUserID, organization, _ := parseOAuthState(state)
 
 	if mattermostUserID != authenticatedMattermostUserID {
 		return errors.New("failed to complete oAuth, mattermost user is not authenticated")
@@ -137,7 +153,16 @@ func (p *Plugin) GenerateOAuthToken(code, state, authenticatedMattermostUserID s
 		"redirect_uri":          {fmt.Sprintf("%s%s%s", p.GetSiteURL(), p.GetPluginURLPath(), constants.PathOAuthCallback)},
 	}
 
-	if err := p.GenerateAndStoreOAuthToken(mattermostUserID, oauthTokenFormValues, false); err != nil {
+	// The organization is validated again as the configuration could have changed since the connection was started
+	if organization != "" && p.validateConnectOrganization(organization) != nil {
+		organization = ""
+	}
+
+	if organization != "" && p.MattermostUserAlreadyConnected(mattermostUserID) {
+		return p.connectOrganization(mattermostUserID, organization, oauthTokenFormValues)
+	}
+
+	if err := p.GenerateAndStoreOAuthToken(mattermostUserID, organization, oauthTokenFormValues, false); err != nil {
 		return err
 	}
 
@@ -147,13 +172,50 @@ func (p *Plugin) GenerateOAuthToken(code, state, authenticatedMattermostUserID s
 		&model.WebsocketBroadcast{UserId: mattermostUserID},
 	)
 
-	if _, err := p.DM(mattermostUserID, fmt.Sprintf("%s\n\n%s", constants.UserConnected, constants.HelpText), false); err != nil {
+	message := constants.UserConnected
+	if organization != "" {
+		message = fmt.Sprintf("%s\n%s", message, fmt.Sprintf(constants.UserConnectedWithOrganization, organization, p.getConfiguration().GetAzureDevopsAPIBaseURL(), organization))
+	}
+
+	if _, err := p.DM(mattermostUserID, fmt.Sprintf("%s\n\n%s", message, p.getHelpText()), false); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// parseOAuthState returns the Mattermost user ID and the organization, if any, from an OAuth state
+// in the format "{random}_{mattermostUserID}" or "{random}_{mattermostUserID}_{organization}"
+func parseOAuthState(state string) (mattermostUserID, organization string, isValid bool) {
+	parts := strings.Split(state, "_")
+	if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+		return "", "", false
+	}
+
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false
+		}
+		organization = parts[2]
+	}
+
+	return parts[1], organization, true
+}
+
+// validateConnectOrganization checks if an organization can be used while connecting an account.
+// Only the default organization can be used if it's set in the plugin configuration.
+func (p *Plugin) validateConnectOrganization(organization string) error {
+	if !organizationNameRegex.MatchString(organization) {
+		return errors.New(constants.InvalidConnectOrganization)
+	}
+
+	if defaultOrganization := p.getConfiguration().DefaultOrganization; defaultOrganization != "" && organization != defaultOrganization {
+		return fmt.Errorf(constants.ConnectOrganizationNotAllowed, defaultOrganization)
+	}
+
+	return nil
+}
+
 // RefreshOAuthToken refreshes OAuth token
 func (p *Plugin) RefreshOAuthToken(mattermostUserID, refreshToken string) error {
 	decodedRefreshToken, err := p.Decode(refreshToken)
@@ -172,6 +234,10 @@ func (p *Plugin) RefreshOAuthToken(mattermostUserID, refreshToken string) error
 		return err
 	}
 
+	if p.IsDeviceCodeConnection(mattermostUserID) {
+		return p.RefreshDeviceCodeToken(mattermostUserID, string(decryptedRefreshToken))
+	}
+
 	oauthTokenFormValues := url.Values{
 		"client_assertion_type": {constants.ClientAssertionType},
 		"client_assertion":      {p.getConfiguration().AzureDevopsOAuthClientSecret},
@@ -180,11 +246,58 @@ func (p *Plugin) RefreshOAuthToken(mattermostUserID, refreshToken string) error
 		"redirect_uri":          {fmt.Sprintf("%s%s%s", p.GetSiteURL(), p.GetPluginURLPath(), constants.PathOAuthCallback)},
 	}
 
-	return p.GenerateAndStoreOAuthToken(mattermostUserID, oauthTokenFormValues, true)
+	return p.GenerateAndStoreOAuthToken(mattermostUserID, "", oauthTokenFormValues, true)
 }
 
-// GenerateAndStoreOAuthToken generates and stores OAuth token
-func (p *Plugin) GenerateAndStoreOAuthToken(mattermostUserID string, oauthTokenFormValues url.Values, isTokenRefreshRequest bool) error {
+// RefreshAccessToken refreshes the access token of a user which was rejected by Azure DevOps before its expiry time,
+// e.g. because it expired while a request was in flight. The user is disconnected if the token can't be refreshed.
+func (p *Plugin) RefreshAccessToken(user *serializers.User) error {
+	if user.RefreshToken == "" {
+		p.disconnectExpiredSession(user.MattermostUserID)
+		return errors.New(constants.ErrorNoRefreshToken)
+	}
+
+	if err := p.RefreshOAuthToken(user.MattermostUserID, user.RefreshToken); err != nil {
+		p.disconnectExpiredSession(user.MattermostUserID)
+		return err
+	}
+
+	return nil
+}
+
+// disconnectExpiredSession disconnects a user whose access token can't be refreshed, so that the webapp prompts them to connect again
+func (p *Plugin) disconnectExpiredSession(mattermostUserID string) {
+	message := constants.SessionExpiredMessage
+	if isDeleted, err := p.Store.DeleteUser(mattermostUserID); !isDeleted {
+		if err != nil {
+			p.API.LogError(constants.UnableToDisconnectUser, "Error", err.Error())
+		}
+		message = constants.GenericErrorMessage
+	}
+
+	p.API.PublishWebSocketEvent(
+		constants.WSEventDisconnect,
+		nil,
+		&model.WebsocketBroadcast{UserId: mattermostUserID},
+	)
+
+	if _, DMErr := p.DM(mattermostUserID, message, false); DMErr != nil {
+		p.API.LogError(constants.UnableToDMBot, "Error", DMErr.Error())
+	}
+}
+
+// loadAzureDevopsUser loads the Azure DevOps account connected by a user
+func (p *Plugin) loadAzureDevopsUser(mattermostUserID string) (*serializers.User, error) {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID)
+}
+
+// GenerateAndStoreOAuthToken generates and stores OAuth token, organization is the organization the account is connected for, if any
+func (p *Plugin) GenerateAndStoreOAuthToken(mattermostUserID, organization string, oauthTokenFormValues url.Values, isTokenRefreshRequest bool) error {
 	successResponse, _, err := p.Client.GenerateOAuthToken(oauthTokenFormValues)
 	if err != nil {
 		if _, DMErr := p.DM(mattermostUserID, constants.GenericErrorMessage, false); DMErr != nil {
@@ -193,6 +306,12 @@ func (p *Plugin) GenerateAndStoreOAuthToken(mattermostUserID string, oauthTokenF
 		return errors.Wrap(err, "failed to generate oAuth token")
 	}
 
+	return p.storeOAuthToken(mattermostUserID, successResponse, "", organization, isTokenRefreshRequest)
+}
+
+// storeOAuthToken stores the token of a user along with their Azure DevOps profile, authType is the flow used to connect the account.
+// The organizations connected by the user are kept when the token is refreshed.
+func (p *Plugin) storeOAuthToken(mattermostUserID string, successResponse *serializers.OAuthSuccessResponse, authType, organization string, isTokenRefreshRequest bool) error {
 	userProfile, _, err := p.Client.GetUserProfile(constants.CurrentAzureDevopsUserProfileID, successResponse.AccessToken)
 	if err != nil {
 		if _, DMErr := p.DM(mattermostUserID, constants.GenericErrorMessage, false); DMErr != nil {
@@ -240,9 +359,17 @@ func (p *Plugin) GenerateAndStoreOAuthToken(mattermostUserID string, oauthTokenF
 		AccessToken:      p.Encode(encryptedAccessToken),
 		RefreshToken:     p.Encode(encryptedRefreshToken),
 		ExpiresAt:        time.Now().UTC().Add(time.Second * time.Duration(tokenExpiryDurationInSeconds)).Unix(),
+		Scopes:           strings.Fields(successResponse.Scope),
+		AuthType:         authType,
+		Organization:     organization,
 		UserProfile:      *userProfile,
 	}
 
+	if isTokenRefreshRequest {
+		user.Organization = azureDevopsUser.Organization
+		user.OrganizationConnections = azureDevopsUser.OrganizationConnections
+	}
+
 	if err := p.Store.StoreAzureDevopsUserDetailsWithMattermostUserID(&user); err != nil {
 		return err
 	}
@@ -32,15 +32,42 @@ func TestOAuthConnect(t *testing.T) {
 	mockAPI := &plugintest.API{}
 	p.API = mockAPI
 	for _, testCase := range []struct {
-		description string
-		isConnected bool
-		DMErr       error
-		statusCode  int
+		description          string
+		isConnected          bool
+		DMErr                error
+		organization         string
+		defaultOrganization  string
+		expectedOrganization string
+		statusCode           int
 	}{
 		{
 			description: "OAuthConnect: valid",
 			statusCode:  http.StatusFound,
 		},
+		{
+			description:          "OAuthConnect: with organization",
+			organization:         " Mock-Organization ",
+			expectedOrganization: "mock-organization",
+			statusCode:           http.StatusFound,
+		},
+		{
+			description:          "OAuthConnect: with the default organization",
+			organization:         "mock-organization",
+			defaultOrganization:  "mock-organization",
+			expectedOrganization: "mock-organization",
+			statusCode:           http.StatusFound,
+		},
+		{
+			description:         "OAuthConnect: organization other than the default organization",
+			organization:        "mock-organization",
+			defaultOrganization: "mock-default-organization",
+			statusCode:          http.StatusBadRequest,
+		},
+		{
+			description:  "OAuthConnect: invalid organization",
+			organization: "mock_organization",
+			statusCode:   http.StatusBadRequest,
+		},
 		{
 			description: "OAuthConnect: user already connected",
 			isConnected: true,
@@ -57,7 +84,8 @@ func TestOAuthConnect(t *testing.T) {
 			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "MattermostUserAlreadyConnected", func(_ *Plugin, _ string) bool {
 				return testCase.isConnected
 			})
-			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GenerateOAuthConnectURL", func(_ *Plugin, _ string) string {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GenerateOAuthConnectURL", func(_ *Plugin, _, organization string) string {
+				assert.Equal(t, testCase.expectedOrganization, organization)
 				return "mockRedirectURL"
 			})
 			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "CloseBrowserWindowWithHTTPResponse", func(_ *Plugin, _ http.ResponseWriter) {})
@@ -65,7 +93,12 @@ func TestOAuthConnect(t *testing.T) {
 				return "", testCase.DMErr
 			})
 
+			p.setConfiguration(&config.Configuration{DefaultOrganization: testCase.defaultOrganization})
+
 			req := httptest.NewRequest(http.MethodGet, "/oauth/connect", bytes.NewBufferString(`{}`))
+			q := req.URL.Query()
+			q.Add(constants.QueryParamOrganization, testCase.organization)
+			req.URL.RawQuery = q.Encode()
 
 			res := httptest.NewRecorder()
 
@@ -109,6 +142,18 @@ func TestOAuthComplete(t *testing.T) {
 			state:       "mockState",
 			statusCode:  http.StatusBadRequest,
 		},
+		{
+			description: "OAuthComplete: state with organization",
+			code:        "mockCode",
+			state:       "mock_State_mockOrganization",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "OAuthComplete: state with empty organization",
+			code:        "mockCode",
+			state:       "mock_State_",
+			statusCode:  http.StatusBadRequest,
+		},
 		{
 			description: "OAuthComplete: state second word empty",
 			code:        "mockCode",
@@ -166,23 +211,40 @@ func TestGenerateOAuthToken(t *testing.T) {
 		verifyOAuthError error
 		expectedError    string
 		DMError          error
+		expectedDM       string
+		expectedOrg      string
 	}{
 		{
 			description: "GenerateOAuthToken: valid",
 			code:        "mockCode",
 			state:       fmt.Sprintf("mockState_%s", testutils.MockMattermostUserID),
 			mmuserID:    testutils.MockMattermostUserID,
+			expectedDM:  fmt.Sprintf("%s\n\n%s", constants.UserConnected, p.getHelpText()),
+		},
+		{
+			description: "GenerateOAuthToken: with organization",
+			code:        "mockCode",
+			state:       fmt.Sprintf("mockState_%s_mock-organization", testutils.MockMattermostUserID),
+			mmuserID:    testutils.MockMattermostUserID,
+			expectedDM:  fmt.Sprintf("%s\n%s\n\n%s", constants.UserConnected, fmt.Sprintf(constants.UserConnectedWithOrganization, "mock-organization", "https://dev.azure.com", "mock-organization"), p.getHelpText()),
+			expectedOrg: "mock-organization",
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return(nil)
 
-			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "DM", func(_ *Plugin, _, _ string, _ bool, _ ...interface{}) (string, error) {
+			p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "DM", func(_ *Plugin, _, format string, _ bool, _ ...interface{}) (string, error) {
+				assert.Equal(t, testCase.expectedDM, format)
 				return "", testCase.DMError
 			})
-			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GenerateAndStoreOAuthToken", func(_ *Plugin, _ string, _ url.Values, _ bool) error {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GenerateAndStoreOAuthToken", func(_ *Plugin, _, organization string, _ url.Values, _ bool) error {
+				assert.Equal(t, testCase.expectedOrg, organization)
 				return nil
 			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "MattermostUserAlreadyConnected", func(_ *Plugin, _ string) bool {
+				return false
+			})
 
 			if testCase.expectedError == "" {
 				mockedStore.EXPECT().VerifyOAuthState(testCase.mmuserID, testCase.state).Return(testCase.verifyOAuthError)
@@ -198,6 +260,46 @@ func TestGenerateOAuthToken(t *testing.T) {
 	}
 }
 
+func TestParseOAuthState(t *testing.T) {
+	for _, testCase := range []struct {
+		description              string
+		state                    string
+		expectedMattermostUserID string
+		expectedOrganization     string
+		expectedIsValid          bool
+	}{
+		{
+			description:              "ParseOAuthState: without organization",
+			state:                    "mockState_mockMattermostUserID",
+			expectedMattermostUserID: "mockMattermostUserID",
+			expectedIsValid:          true,
+		},
+		{
+			description:              "ParseOAuthState: with organization",
+			state:                    "mockState_mockMattermostUserID_mock-organization",
+			expectedMattermostUserID: "mockMattermostUserID",
+			expectedOrganization:     "mock-organization",
+			expectedIsValid:          true,
+		},
+		{
+			description: "ParseOAuthState: without Mattermost user ID",
+			state:       "mockState_",
+		},
+		{
+			description: "ParseOAuthState: too many parts",
+			state:       "mockState_mockMattermostUserID_mock_organization",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mattermostUserID, organization, isValid := parseOAuthState(testCase.state)
+
+			assert.Equal(t, testCase.expectedMattermostUserID, mattermostUserID)
+			assert.Equal(t, testCase.expectedOrganization, organization)
+			assert.Equal(t, testCase.expectedIsValid, isValid)
+		})
+	}
+}
+
 func TestRefreshOAuthToken(t *testing.T) {
 	defer monkey.UnpatchAll()
 	p := Plugin{}
@@ -265,9 +367,12 @@ func TestRefreshOAuthToken(t *testing.T) {
 			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "Decrypt", func(_ *Plugin, _, _ []byte) ([]byte, error) {
 				return nil, testCase.decryptError
 			})
-			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GenerateAndStoreOAuthToken", func(_ *Plugin, _ string, _ url.Values, _ bool) error {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GenerateAndStoreOAuthToken", func(_ *Plugin, _, _ string, _ url.Values, _ bool) error {
 				return nil
 			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "IsDeviceCodeConnection", func(_ *Plugin, _ string) bool {
+				return false
+			})
 
 			err := p.RefreshOAuthToken(testutils.MockMattermostUserID, "mockRefreshToken")
 			if testCase.expectedError != "" {
@@ -305,7 +410,7 @@ func TestGenerateAndStoreOAuthToken(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
-			mockedClient.EXPECT().GenerateOAuthToken(gomock.Any()).Return(&serializers.OAuthSuccessResponse{}, 200, nil)
+			mockedClient.EXPECT().GenerateOAuthToken(gomock.Any()).Return(&serializers.OAuthSuccessResponse{Scope: "vso.work_full vso.code_full"}, 200, nil)
 			mockedClient.EXPECT().GetUserProfile("me", "").Return(&serializers.UserProfile{}, 200, nil)
 			mockedStore.EXPECT().LoadAzureDevopsUserDetails("").Return(&serializers.User{}, nil)
 
@@ -326,6 +431,7 @@ func TestGenerateAndStoreOAuthToken(t *testing.T) {
 			if testCase.storeError == nil {
 				mockedStore.EXPECT().StoreAzureDevopsUserDetailsWithMattermostUserID(&serializers.User{
 					ExpiresAt: time.Now().UTC().Add(time.Second * time.Duration(0)).Unix(),
+					Scopes:    []string{"vso.work_full", "vso.code_full"},
 				}).Return(testCase.storeUserError)
 			}
 
@@ -335,7 +441,7 @@ func TestGenerateAndStoreOAuthToken(t *testing.T) {
 					AzureDevopsOAuthClientSecret: "mockAzureDevopsOAuthClientSecret",
 				})
 
-			err := p.GenerateAndStoreOAuthToken("", nil, false)
+			err := p.GenerateAndStoreOAuthToken("", "", nil, false)
 			if testCase.expectedError != "" {
 				assert.NotNil(t, err)
 				return
@@ -346,6 +452,62 @@ func TestGenerateAndStoreOAuthToken(t *testing.T) {
 	}
 }
 
+func TestRefreshAccessToken(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description        string
+		refreshToken       string
+		refreshErr         error
+		expectedDisconnect bool
+	}{
+		{
+			description:  "RefreshAccessToken: token is refreshed",
+			refreshToken: "mockRefreshToken",
+		},
+		{
+			description:        "RefreshAccessToken: user is disconnected if the token is not refreshed",
+			refreshToken:       "mockRefreshToken",
+			refreshErr:         errors.New("error refreshing the token"),
+			expectedDisconnect: true,
+		},
+		{
+			description:        "RefreshAccessToken: user is disconnected if there is no refresh token",
+			expectedDisconnect: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "RefreshOAuthToken", func(_ *Plugin, mattermostUserID, refreshToken string) error {
+				assert.Equal(t, testutils.MockMattermostUserID, mattermostUserID)
+				assert.Equal(t, testCase.refreshToken, refreshToken)
+				return testCase.refreshErr
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(_ *Plugin, _, message string, _ bool, _ ...interface{}) (string, error) {
+				assert.Equal(t, constants.SessionExpiredMessage, message)
+				return "", nil
+			})
+
+			if testCase.expectedDisconnect {
+				mockedStore.EXPECT().DeleteUser(testutils.MockMattermostUserID).Return(true, nil)
+				mockAPI.On("PublishWebSocketEvent", constants.WSEventDisconnect, mock.Anything, &model.WebsocketBroadcast{UserId: testutils.MockMattermostUserID}).Return()
+			}
+
+			err := p.RefreshAccessToken(&serializers.User{MattermostUserID: testutils.MockMattermostUserID, RefreshToken: testCase.refreshToken})
+
+			if testCase.expectedDisconnect {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
 func TestIsAccessTokenExpired(t *testing.T) {
 	defer monkey.UnpatchAll()
 	p := Plugin{}
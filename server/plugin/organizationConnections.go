@@ -0,0 +1,214 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// isConnectedToOrganization checks if a user has connected their account, for the organization if it's not empty.
+// An account connected without an organization still lets the user connect an organization with another account.
+func (p *Plugin) isConnectedToOrganization(mattermostUserID, organization string) bool {
+	if isConnected := p.MattermostUserAlreadyConnected(mattermostUserID); !isConnected || organization == "" {
+		return isConnected
+	}
+
+	user, err := p.loadAzureDevopsUser(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.UnableToCheckIfAlreadyConnected, "Error", err.Error())
+		return false
+	}
+
+	return strings.EqualFold(user.Organization, organization) || user.GetOrganizationConnection(organization) != nil
+}
+
+// getConnectMessage returns the message shown to a user who is already connected to an organization, or an empty message if they can connect it
+func (p *Plugin) getConnectMessage(mattermostUserID, organization string) string {
+	if !p.isConnectedToOrganization(mattermostUserID, organization) {
+		return ""
+	}
+
+	if organization == "" {
+		return constants.MattermostUserAlreadyConnected
+	}
+
+	return fmt.Sprintf(constants.OrganizationAlreadyConnected, organization)
+}
+
+// checkOrganizationConnection checks if a user can send a request to an organization, and refreshes the token of the organization connection if it's expired.
+// The users who are not connected are not checked here, their requests fail while adding the authorization.
+func (p *Plugin) checkOrganizationConnection(mattermostUserID, organization string) (int, error) {
+	if mattermostUserID == "" || organization == "" {
+		return http.StatusOK, nil
+	}
+
+	user, err := p.loadAzureDevopsUser(mattermostUserID)
+	if err != nil || user.AccessToken == "" {
+		return http.StatusOK, nil
+	}
+
+	if !user.IsOrganizationConnected(organization) {
+		return http.StatusForbidden, fmt.Errorf(constants.ErrorOrganizationNotConnected, organization, organization)
+	}
+
+	connection := user.GetOrganizationConnection(organization)
+	if connection == nil || time.Until(time.Unix(connection.ExpiresAt, 0)) > time.Minute*constants.TokenExpiryTimeBufferInMinutes {
+		return http.StatusOK, nil
+	}
+
+	if err := p.refreshOrganizationConnection(user, connection); err != nil {
+		p.API.LogError(constants.ErrorRefreshOrganizationConnection, "Organization", connection.Organization, "Error", err.Error())
+		p.removeOrganizationConnection(user, connection)
+		return http.StatusUnauthorized, fmt.Errorf(constants.ErrorOrganizationConnectionExpired, organization, organization)
+	}
+
+	return http.StatusOK, nil
+}
+
+// connectOrganization stores a token generated after the OAuth authorization as the connection of an organization for an already connected user
+func (p *Plugin) connectOrganization(mattermostUserID, organization string, oauthTokenFormValues url.Values) error {
+	successResponse, _, err := p.Client.GenerateOAuthToken(oauthTokenFormValues)
+	if err != nil {
+		if _, DMErr := p.DM(mattermostUserID, constants.GenericErrorMessage, false); DMErr != nil {
+			return DMErr
+		}
+		return errors.Wrap(err, "failed to generate oAuth token")
+	}
+
+	userProfile, _, err := p.Client.GetUserProfile(constants.CurrentAzureDevopsUserProfileID, successResponse.AccessToken)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch user profile")
+	}
+
+	// An Azure DevOps account connected by another user can't be used for an organization either
+	otherUser, err := p.Store.LoadAzureDevopsUserDetails(userProfile.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the user details")
+	}
+
+	if otherUser.AccessToken != "" && otherUser.MattermostUserID != mattermostUserID {
+		return fmt.Errorf(constants.ErrorMessageAzureDevopsAccountAlreadyConnected, userProfile.Email)
+	}
+
+	user, err := p.loadAzureDevopsUser(mattermostUserID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the user details")
+	}
+
+	connection, err := p.newOrganizationConnection(organization, successResponse, userProfile.Email)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Store.StoreOrganizationConnection(user.ID, connection); err != nil {
+		return err
+	}
+
+	if existingConnection := user.GetOrganizationConnection(organization); existingConnection != nil {
+		*existingConnection = *connection
+	} else {
+		user.OrganizationConnections = append(user.OrganizationConnections, connection)
+	}
+
+	p.API.PublishWebSocketEvent(
+		constants.WSEventConnect,
+		nil,
+		&model.WebsocketBroadcast{UserId: mattermostUserID},
+	)
+
+	message := fmt.Sprintf(constants.OrganizationConnected, organization, userProfile.Email, p.getConfiguration().GetAzureDevopsAPIBaseURL(), organization)
+	if _, err := p.DM(mattermostUserID, message, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// refreshOrganizationConnection refreshes the token of an organization connection and stores it without modifying the rest of the user
+func (p *Plugin) refreshOrganizationConnection(user *serializers.User, connection *serializers.OrganizationConnection) error {
+	decodedRefreshToken, err := p.Decode(connection.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	decryptedRefreshToken, err := p.Decrypt(decodedRefreshToken, []byte(p.getConfiguration().EncryptionSecret))
+	if err != nil {
+		return err
+	}
+
+	successResponse, _, err := p.Client.GenerateOAuthToken(url.Values{
+		"client_assertion_type": {constants.ClientAssertionType},
+		"client_assertion":      {p.getConfiguration().AzureDevopsOAuthClientSecret},
+		"grant_type":            {constants.GrantTypeRefresh},
+		"assertion":             {string(decryptedRefreshToken)},
+		"redirect_uri":          {fmt.Sprintf("%s%s%s", p.GetSiteURL(), p.GetPluginURLPath(), constants.PathOAuthCallback)},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to refresh oAuth token")
+	}
+
+	refreshedConnection, err := p.newOrganizationConnection(connection.Organization, successResponse, connection.Email)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Store.StoreOrganizationConnection(user.ID, refreshedConnection); err != nil {
+		return err
+	}
+
+	*connection = *refreshedConnection
+	return nil
+}
+
+// removeOrganizationConnection removes the connection of an organization whose token can't be refreshed, so that the user can connect it again.
+// A connection refreshed meanwhile by another request is kept.
+func (p *Plugin) removeOrganizationConnection(user *serializers.User, connection *serializers.OrganizationConnection) {
+	if err := p.Store.DeleteOrganizationConnection(user.ID, connection); err != nil {
+		p.API.LogError(constants.ErrorRefreshOrganizationConnection, "Organization", connection.Organization, "Error", err.Error())
+		return
+	}
+
+	connections := []*serializers.OrganizationConnection{}
+	for _, existingConnection := range user.OrganizationConnections {
+		if existingConnection != connection {
+			connections = append(connections, existingConnection)
+		}
+	}
+
+	user.OrganizationConnections = connections
+}
+
+// newOrganizationConnection creates the connection of an organization with the encrypted tokens of an OAuth response
+func (p *Plugin) newOrganizationConnection(organization string, successResponse *serializers.OAuthSuccessResponse, email string) (*serializers.OrganizationConnection, error) {
+	encryptedAccessToken, err := p.Encrypt([]byte(successResponse.AccessToken), []byte(p.getConfiguration().EncryptionSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedRefreshToken, err := p.Encrypt([]byte(successResponse.RefreshToken), []byte(p.getConfiguration().EncryptionSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenExpiryDurationInSeconds, err := strconv.Atoi(successResponse.ExpiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serializers.OrganizationConnection{
+		Organization: organization,
+		AccessToken:  p.Encode(encryptedAccessToken),
+		RefreshToken: p.Encode(encryptedRefreshToken),
+		ExpiresAt:    time.Now().UTC().Add(time.Second * time.Duration(tokenExpiryDurationInSeconds)).Unix(),
+		Email:        email,
+	}, nil
+}
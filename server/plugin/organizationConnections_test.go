@@ -0,0 +1,283 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetConnectMessage(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		user            *serializers.User
+		organization    string
+		expectedMessage string
+	}{
+		{
+			description: "GetConnectMessage: user is not connected",
+			user:        &serializers.User{},
+		},
+		{
+			description:     "GetConnectMessage: user is already connected",
+			user:            &serializers.User{AccessToken: "mockAccessToken"},
+			expectedMessage: constants.MattermostUserAlreadyConnected,
+		},
+		{
+			description:  "GetConnectMessage: organization can be connected by a user connected without an organization",
+			user:         &serializers.User{AccessToken: "mockAccessToken"},
+			organization: "mock-organization",
+		},
+		{
+			description:     "GetConnectMessage: organization of the account is already connected",
+			user:            &serializers.User{AccessToken: "mockAccessToken", Organization: "mock-organization"},
+			organization:    "mock-organization",
+			expectedMessage: fmt.Sprintf(constants.OrganizationAlreadyConnected, "mock-organization"),
+		},
+		{
+			description: "GetConnectMessage: organization is already connected",
+			user: &serializers.User{
+				AccessToken:             "mockAccessToken",
+				Organization:            "mock-organization",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "other-organization"}},
+			},
+			organization:    "other-organization",
+			expectedMessage: fmt.Sprintf(constants.OrganizationAlreadyConnected, "other-organization"),
+		},
+		{
+			description:  "GetConnectMessage: another organization can be connected",
+			user:         &serializers.User{AccessToken: "mockAccessToken", Organization: "mock-organization"},
+			organization: "other-organization",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil).AnyTimes()
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, nil).AnyTimes()
+
+			assert.Equal(t, testCase.expectedMessage, p.getConnectMessage(testutils.MockMattermostUserID, testCase.organization))
+		})
+	}
+}
+
+func TestCheckOrganizationConnection(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description           string
+		user                  *serializers.User
+		organization          string
+		refreshErr            error
+		expectedStatusCode    int
+		expectedError         string
+		expectedConnections   []*serializers.OrganizationConnection
+		expectedUserIsUpdated bool
+	}{
+		{
+			description:        "CheckOrganizationConnection: user is not connected",
+			user:               &serializers.User{},
+			organization:       "mock-organization",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "CheckOrganizationConnection: account connected without an organization can be used with any organization",
+			user:               &serializers.User{AccessToken: "mockAccessToken"},
+			organization:       "mock-organization",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "CheckOrganizationConnection: organization of the account",
+			user:               &serializers.User{AccessToken: "mockAccessToken", Organization: "mock-organization"},
+			organization:       "Mock-Organization",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "CheckOrganizationConnection: organization is not connected",
+			user:               &serializers.User{AccessToken: "mockAccessToken", Organization: "mock-organization"},
+			organization:       "other-organization",
+			expectedStatusCode: http.StatusForbidden,
+			expectedError:      fmt.Sprintf(constants.ErrorOrganizationNotConnected, "other-organization", "other-organization"),
+		},
+		{
+			description: "CheckOrganizationConnection: connection of the organization is not expired",
+			user: &serializers.User{
+				AccessToken:             "mockAccessToken",
+				Organization:            "mock-organization",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", ExpiresAt: time.Now().Add(time.Hour).Unix()}},
+			},
+			organization:       "other-organization",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "CheckOrganizationConnection: expired connection of the organization is refreshed",
+			user: &serializers.User{
+				AccessToken:             "mockAccessToken",
+				Organization:            "mock-organization",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", RefreshToken: "mockRefreshToken", Email: "mockEmail"}},
+			},
+			organization:          "other-organization",
+			expectedStatusCode:    http.StatusOK,
+			expectedConnections:   []*serializers.OrganizationConnection{{Organization: "other-organization", AccessToken: "mockEncodedToken", RefreshToken: "mockEncodedToken", ExpiresAt: time.Now().UTC().Add(time.Hour).Unix(), Email: "mockEmail"}},
+			expectedUserIsUpdated: true,
+		},
+		{
+			description: "CheckOrganizationConnection: connection of the organization is removed if it can't be refreshed",
+			user: &serializers.User{
+				AccessToken:             "mockAccessToken",
+				Organization:            "mock-organization",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", RefreshToken: "mockRefreshToken"}},
+			},
+			organization:          "other-organization",
+			refreshErr:            errors.New("error refreshing the token"),
+			expectedStatusCode:    http.StatusUnauthorized,
+			expectedError:         fmt.Sprintf(constants.ErrorOrganizationConnectionExpired, "other-organization", "other-organization"),
+			expectedConnections:   []*serializers.OrganizationConnection{},
+			expectedUserIsUpdated: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{EncryptionSecret: "mockEncryptionSecret"})
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, nil)
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "Decode", func(_ *Plugin, _ string) ([]byte, error) {
+				return []byte("mockDecodedToken"), nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "Decrypt", func(_ *Plugin, _, _ []byte) ([]byte, error) {
+				return []byte("mockRefreshToken"), nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "Encrypt", func(_ *Plugin, _, _ []byte) ([]byte, error) {
+				return []byte("mockEncryptedToken"), nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "Encode", func(_ *Plugin, _ []byte) string {
+				return "mockEncodedToken"
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetSiteURL", func(_ *Plugin) string {
+				return "mockSiteURL"
+			})
+			if testCase.expectedUserIsUpdated {
+				mockedClient.EXPECT().GenerateOAuthToken(gomock.Any()).Return(&serializers.OAuthSuccessResponse{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken", ExpiresIn: "3600"}, http.StatusOK, testCase.refreshErr)
+				if testCase.refreshErr != nil {
+					mockedStore.EXPECT().DeleteOrganizationConnection(testCase.user.ID, testCase.user.OrganizationConnections[0]).Return(nil)
+				} else {
+					mockedStore.EXPECT().StoreOrganizationConnection(testCase.user.ID, gomock.Any()).Return(nil)
+				}
+			}
+
+			statusCode, err := p.checkOrganizationConnection(testutils.MockMattermostUserID, testCase.organization)
+
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if testCase.expectedConnections != nil {
+				if len(testCase.expectedConnections) > 0 {
+					assert.InDelta(t, testCase.expectedConnections[0].ExpiresAt, testCase.user.OrganizationConnections[0].ExpiresAt, 1)
+					testCase.expectedConnections[0].ExpiresAt = testCase.user.OrganizationConnections[0].ExpiresAt
+				}
+				assert.Equal(t, testCase.expectedConnections, testCase.user.OrganizationConnections)
+			}
+		})
+	}
+}
+
+func TestConnectOrganization(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description         string
+		user                *serializers.User
+		otherUser           *serializers.User
+		expectedError       string
+		expectedConnections []*serializers.OrganizationConnection
+	}{
+		{
+			description:         "ConnectOrganization: organization is connected",
+			user:                &serializers.User{MattermostUserID: testutils.MockMattermostUserID, AccessToken: "mockAccessToken", Organization: "mock-organization"},
+			otherUser:           &serializers.User{},
+			expectedConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", AccessToken: "mockEncodedToken", RefreshToken: "mockEncodedToken", Email: "mockEmail"}},
+		},
+		{
+			description: "ConnectOrganization: connection of the organization is replaced",
+			user: &serializers.User{
+				MattermostUserID:        testutils.MockMattermostUserID,
+				AccessToken:             "mockAccessToken",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", AccessToken: "mockOldAccessToken", Email: "mockOldEmail"}},
+			},
+			otherUser:           &serializers.User{},
+			expectedConnections: []*serializers.OrganizationConnection{{Organization: "other-organization", AccessToken: "mockEncodedToken", RefreshToken: "mockEncodedToken", Email: "mockEmail"}},
+		},
+		{
+			description:   "ConnectOrganization: Azure DevOps account is connected by another user",
+			otherUser:     &serializers.User{MattermostUserID: "mockOtherMattermostUserID", AccessToken: "mockAccessToken"},
+			expectedError: fmt.Sprintf(constants.ErrorMessageAzureDevopsAccountAlreadyConnected, "mockEmail"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventConnect, mock.Anything, mock.Anything)
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{EncryptionSecret: "mockEncryptionSecret", AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+
+			mockedClient.EXPECT().GenerateOAuthToken(gomock.Any()).Return(&serializers.OAuthSuccessResponse{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken", ExpiresIn: "0"}, http.StatusOK, nil)
+			mockedClient.EXPECT().GetUserProfile(constants.CurrentAzureDevopsUserProfileID, "mockAccessToken").Return(&serializers.UserProfile{ID: "mockOtherAzureDevopsUserID", Email: "mockEmail"}, http.StatusOK, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails("mockOtherAzureDevopsUserID").Return(testCase.otherUser, nil)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "Encrypt", func(_ *Plugin, _, _ []byte) ([]byte, error) {
+				return []byte("mockEncryptedToken"), nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "Encode", func(_ *Plugin, _ []byte) string {
+				return "mockEncodedToken"
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(_ *Plugin, _, format string, _ bool, _ ...interface{}) (string, error) {
+				assert.Equal(t, fmt.Sprintf(constants.OrganizationConnected, "other-organization", "mockEmail", "https://dev.azure.com", "other-organization"), format)
+				return "", nil
+			})
+			if testCase.expectedError == "" {
+				mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+				mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, nil)
+				mockedStore.EXPECT().StoreOrganizationConnection(testCase.user.ID, gomock.Any()).Return(nil)
+			}
+
+			err := p.connectOrganization(testutils.MockMattermostUserID, "other-organization", nil)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "mockAccessToken", testCase.user.AccessToken)
+			for _, connection := range testCase.user.OrganizationConnections {
+				connection.ExpiresAt = 0
+			}
+			assert.Equal(t, testCase.expectedConnections, testCase.user.OrganizationConnections)
+			mockAPI.AssertCalled(t, "PublishWebSocketEvent", constants.WSEventConnect, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"crypto/tls"
 	"net/http"
 	"path/filepath"
 	"reflect"
@@ -34,8 +35,42 @@ type Plugin struct {
 	router        *mux.Router
 	Store         store.KVStore
 
+	// tlsConfig is the TLS configuration of the requests to Azure DevOps built from the configuration,
+	// it's guarded by the configurationLock. Consult getTLSConfig and setTLSConfig for usage.
+	tlsConfig *tls.Config
+
 	// user ID of the bot account
 	botUserID string
+
+	// workItemTypeStates caches the states of the work item types per project and type
+	workItemTypeStates workItemTypeStatesCache
+
+	// processes caches the process of each project along with the time it expires
+	processes sync.Map
+
+	// projectLists caches the projects linked by the users, it's used by the Store and invalidated when a user links or unlinks a project
+	projectLists *projectListCache
+
+	// writeRateLimiter limits the requests changing data sent by each user
+	writeRateLimiter *writeRateLimiter
+
+	// retryQueueJob retries the failed operations queued in the retry queue
+	retryQueueJob *scheduledJob
+
+	// weeklySummaryJob posts the weekly summaries of the notifications of the channels
+	weeklySummaryJob *scheduledJob
+
+	// pendingWebhookDeletionsJob deletes the webhooks of the deleted subscriptions once their grace period is over
+	pendingWebhookDeletionsJob *scheduledJob
+
+	// subscriptionReconciliationJob removes the subscriptions whose webhook was deleted in Azure DevOps
+	subscriptionReconciliationJob *scheduledJob
+
+	// reconcilingSubscriptions is set while the subscriptions are being compared with the ones in Azure DevOps
+	reconcilingSubscriptions int32
+
+	// deviceCodeFlowsDone is closed to stop polling for the tokens of the device code flows when the plugin is deactivated
+	deviceCodeFlowsDone chan struct{}
 }
 
 // getConfiguration retrieves the active configuration under lock, making it safe to use
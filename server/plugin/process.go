@@ -0,0 +1,57 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// cachedProcess is the process of a project cached until it expires, so that the changes made to a custom process are picked up eventually
+type cachedProcess struct {
+	process   *serializers.Process
+	expiresAt time.Time
+}
+
+// getProjectProcess returns the process of a project with only the enabled work item types, so that the disabled ones are never offered to the users.
+// The system process inherited by a custom process is resolved by its type ID.
+func (p *Plugin) getProjectProcess(organization, projectID, mattermostUserID string) (*serializers.Process, int, error) {
+	cacheKey := strings.ToLower(fmt.Sprintf("%s/%s", organization, projectID))
+	if cached, ok := p.processes.Load(cacheKey); ok && time.Now().Before(cached.(*cachedProcess).expiresAt) {
+		return cached.(*cachedProcess).process, http.StatusOK, nil
+	}
+
+	process, statusCode, err := p.Client.GetProcess(organization, projectID, mattermostUserID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	enabledWorkItemTypes := []*serializers.ProcessWorkItemType{}
+	for _, workItemType := range process.WorkItemTypes {
+		if !workItemType.IsDisabled {
+			enabledWorkItemTypes = append(enabledWorkItemTypes, workItemType)
+		}
+	}
+	process.WorkItemTypes = enabledWorkItemTypes
+
+	if process.ParentProcessTypeID != "" {
+		process.ParentProcessName = constants.SystemProcessNames[strings.ToLower(process.ParentProcessTypeID)]
+	}
+
+	p.processes.Store(cacheKey, &cachedProcess{process: process, expiresAt: time.Now().Add(constants.ProcessCacheDuration)})
+	return process, statusCode, nil
+}
+
+// isProjectIDLinked checks if a project is linked by its ID, the organization is matched case insensitively
+func isProjectIDLinked(projectList []serializers.ProjectDetails, organization, projectID string) bool {
+	for _, project := range projectList {
+		if strings.EqualFold(project.OrganizationName, organization) && project.ProjectID == projectID {
+			return true
+		}
+	}
+
+	return false
+}
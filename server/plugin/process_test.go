@@ -0,0 +1,147 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getMockProcess() *serializers.Process {
+	return &serializers.Process{
+		TypeID:              "mockProcessID",
+		Name:                "Custom Agile",
+		ParentProcessTypeID: "ADCC42AB-9882-485E-A3ED-7678F01F66BC",
+		CustomizationType:   constants.ProcessCustomizationInherited,
+		WorkItemTypes: []*serializers.ProcessWorkItemType{
+			{ReferenceName: "Microsoft.VSTS.WorkItemTypes.Bug", Name: "Bug", Customization: constants.ProcessCustomizationInherited},
+			{ReferenceName: "Microsoft.VSTS.WorkItemTypes.Issue", Name: "Issue", Customization: constants.ProcessCustomizationSystem, IsDisabled: true},
+			{ReferenceName: "Custom.Risk", Name: "Risk", Customization: "custom"},
+		},
+	}
+}
+
+func TestGetProjectProcess(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	p := setupMockPlugin(mockAPI, nil, mockedClient)
+
+	t.Run("GetProjectProcess: disabled work item types are removed and the parent process is resolved", func(t *testing.T) {
+		mockedClient.EXPECT().GetProcess(testutils.MockOrganization, testutils.MockProjectID, testutils.MockMattermostUserID).Return(getMockProcess(), http.StatusOK, nil).Times(1)
+
+		process, statusCode, err := p.getProjectProcess(testutils.MockOrganization, testutils.MockProjectID, testutils.MockMattermostUserID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "Agile", process.ParentProcessName)
+		require.Len(t, process.WorkItemTypes, 2)
+		assert.Equal(t, "Bug", process.WorkItemTypes[0].Name)
+		assert.Equal(t, "Risk", process.WorkItemTypes[1].Name)
+
+		// The process is cached, so it's not fetched again
+		cachedProcess, _, err := p.getProjectProcess("MockOrganization", testutils.MockProjectID, testutils.MockMattermostUserID)
+		require.NoError(t, err)
+		assert.Equal(t, process, cachedProcess)
+	})
+
+	t.Run("GetProjectProcess: expired process is fetched again", func(t *testing.T) {
+		p.processes.Store("mockorganization/mockprojectid", &cachedProcess{process: &serializers.Process{Name: "Agile"}, expiresAt: time.Now().Add(-time.Minute)})
+		mockedClient.EXPECT().GetProcess(testutils.MockOrganization, testutils.MockProjectID, testutils.MockMattermostUserID).Return(&serializers.Process{Name: "Scrum"}, http.StatusOK, nil)
+
+		process, _, err := p.getProjectProcess(testutils.MockOrganization, testutils.MockProjectID, testutils.MockMattermostUserID)
+		require.NoError(t, err)
+		assert.Equal(t, "Scrum", process.Name)
+		assert.Empty(t, process.ParentProcessName)
+	})
+
+	t.Run("GetProjectProcess: error in fetching the process is not cached", func(t *testing.T) {
+		mockedClient.EXPECT().GetProcess(testutils.MockOrganization, "mockOtherProjectID", testutils.MockMattermostUserID).Return(nil, http.StatusForbidden, errors.New("error fetching the process")).Times(2)
+
+		for i := 0; i < 2; i++ {
+			_, statusCode, err := p.getProjectProcess(testutils.MockOrganization, "mockOtherProjectID", testutils.MockMattermostUserID)
+			assert.EqualError(t, err, "error fetching the process")
+			assert.Equal(t, http.StatusForbidden, statusCode)
+		}
+	})
+}
+
+func TestHandleGetProjectProcess(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+	projectList := []serializers.ProjectDetails{{OrganizationName: "mockorganization", ProjectID: testutils.MockProjectID, ProjectName: testutils.MockProjectName}}
+	for _, testCase := range []struct {
+		description        string
+		projectID          string
+		processErr         error
+		expectedStatusCode int
+	}{
+		{
+			description:        "HandleGetProjectProcess: valid",
+			projectID:          testutils.MockProjectID,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "HandleGetProjectProcess: project is not linked",
+			projectID:          "mockUnlinkedProjectID",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleGetProjectProcess: error in fetching the process",
+			projectID:          testutils.MockProjectID,
+			processErr:         errors.New("error fetching the process"),
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p.processes = sync.Map{}
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(projectList, nil)
+			if testCase.projectID == testutils.MockProjectID {
+				if testCase.processErr != nil {
+					mockedClient.EXPECT().GetProcess(testutils.MockOrganization, testCase.projectID, testutils.MockMattermostUserID).Return(nil, http.StatusUnauthorized, testCase.processErr)
+				} else {
+					mockedClient.EXPECT().GetProcess(testutils.MockOrganization, testCase.projectID, testutils.MockMattermostUserID).Return(getMockProcess(), http.StatusOK, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/project/mockOrganization/"+testCase.projectID+"/process", nil)
+			req = mux.SetURLVars(req, map[string]string{
+				constants.PathParamOrganization: testutils.MockOrganization,
+				constants.PathParamProjectID:    testCase.projectID,
+			})
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleGetProjectProcess(w, req)
+			resp := w.Result()
+			assert.Equal(t, testCase.expectedStatusCode, resp.StatusCode)
+
+			if testCase.expectedStatusCode == http.StatusOK {
+				var process *serializers.Process
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&process))
+				assert.Equal(t, "Agile", process.ParentProcessName)
+				assert.Len(t, process.WorkItemTypes, 2)
+			}
+		})
+	}
+}
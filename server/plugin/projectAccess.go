@@ -0,0 +1,113 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+)
+
+// projectAccess is a project along with the Mattermost users who have linked it
+type projectAccess struct {
+	project           serializers.ProjectDetails
+	mattermostUserIDs []string
+}
+
+// getProjectAccess returns the Mattermost users who have linked a project as a table.
+// Only system admins and the users who have linked the project themselves can view them.
+func (p *Plugin) getProjectAccess(mattermostUserID, projectArgument string) (string, error) {
+	// The projects of all the users are stored under a single key, so finding the users of a project takes one KV read
+	projectList, err := p.Store.GetProject()
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	matchingProjects := p.findProjectAccess(projectList.ByMattermostUserID, projectArgument)
+	if !p.API.HasPermissionTo(mattermostUserID, model.PERMISSION_MANAGE_SYSTEM) {
+		var ownedProjects []*projectAccess
+		for _, access := range matchingProjects {
+			for _, userID := range access.mattermostUserIDs {
+				if userID == mattermostUserID {
+					ownedProjects = append(ownedProjects, access)
+					break
+				}
+			}
+		}
+
+		if len(ownedProjects) == 0 {
+			return fmt.Sprintf(constants.ErrorProjectAccessPermission, projectArgument), nil
+		}
+		matchingProjects = ownedProjects
+	}
+
+	switch {
+	case len(matchingProjects) == 0:
+		return fmt.Sprintf(constants.ProjectNotLinkedByAnyUser, projectArgument), nil
+	case len(matchingProjects) > 1:
+		return fmt.Sprintf(constants.MultipleProjectsWithName, projectArgument), nil
+	}
+
+	access := matchingProjects[0]
+	var rows []string
+	for _, userID := range access.mattermostUserIDs {
+		user, appErr := p.API.GetUser(userID)
+		if appErr != nil {
+			// The user can be deleted after linking the project, so their ID is shown instead
+			p.API.LogDebug("Error in getting the Mattermost user", "UserID", userID, "Error", appErr.Error())
+			rows = append(rows, fmt.Sprintf("| %s |  |\n", userID))
+			continue
+		}
+
+		rows = append(rows, fmt.Sprintf("| @%s | %s |\n", user.Username, escapeTableCell(user.GetFullName())))
+	}
+	sort.Strings(rows)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Users who have linked %s/%s\n", access.project.OrganizationName, access.project.ProjectName))
+	sb.WriteString("| Username | Name |\n")
+	sb.WriteString("| :------- | :--- |\n")
+	sb.WriteString(strings.Join(rows, ""))
+	sb.WriteString(fmt.Sprintf("\n%d user(s) have linked the project", len(rows)))
+
+	return sb.String(), nil
+}
+
+// findProjectAccess returns the projects matching the given name or organization/project along with the users who have linked each of them
+func (p *Plugin) findProjectAccess(projectsByMattermostUserID map[string]store.ProjectListMap, projectArgument string) []*projectAccess {
+	organization, projectName := "", projectArgument
+	if parts := strings.SplitN(projectArgument, "/", 2); len(parts) == 2 {
+		organization, projectName = parts[0], parts[1]
+	}
+	organization = p.getOrganization(organization)
+
+	projectAccessByID := map[string]*projectAccess{}
+	for userID, projects := range projectsByMattermostUserID {
+		for _, project := range projects {
+			if !strings.EqualFold(project.ProjectName, projectName) {
+				continue
+			}
+			if organization != "" && !strings.EqualFold(project.OrganizationName, organization) {
+				continue
+			}
+
+			if _, ok := projectAccessByID[project.ProjectID]; !ok {
+				projectAccessByID[project.ProjectID] = &projectAccess{project: project}
+			}
+			projectAccessByID[project.ProjectID].mattermostUserIDs = append(projectAccessByID[project.ProjectID].mattermostUserIDs, userID)
+		}
+	}
+
+	var matchingProjects []*projectAccess
+	for _, access := range projectAccessByID {
+		matchingProjects = append(matchingProjects, access)
+	}
+
+	return matchingProjects
+}
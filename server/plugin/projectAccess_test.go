@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetProjectAccess(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+	project := serializers.ProjectDetails{ProjectID: "mockProjectID", OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	otherOrganizationProject := serializers.ProjectDetails{ProjectID: "mockOtherProjectID", OrganizationName: "mockOtherOrganization", ProjectName: testutils.MockProjectName}
+	projectList := &store.ProjectList{
+		ByMattermostUserID: map[string]store.ProjectListMap{
+			"mockUserID-1": {"mockKey-1": project},
+			"mockUserID-2": {"mockKey-2": project, "mockKey-3": otherOrganizationProject},
+			"mockUserID-3": {"mockKey-4": otherOrganizationProject},
+		},
+	}
+
+	for _, testCase := range []struct {
+		description      string
+		mattermostUserID string
+		projectArgument  string
+		isAdmin          bool
+		expectedMessage  string
+		expectedRows     []string
+	}{
+		{
+			description:      "GetProjectAccess: system admin views the users of a project",
+			mattermostUserID: "mockAdminID",
+			projectArgument:  "mockOrganization/mockProjectName",
+			isAdmin:          true,
+			expectedRows:     []string{"| @mockUsername-1 | mock \\| name |\n", "| @mockUsername-2 | mock \\| name |\n"},
+		},
+		{
+			description:      "GetProjectAccess: user who has linked the project views its users",
+			mattermostUserID: "mockUserID-1",
+			projectArgument:  testutils.MockProjectName,
+			expectedRows:     []string{"| @mockUsername-1 | mock \\| name |\n", "| @mockUsername-2 | mock \\| name |\n"},
+		},
+		{
+			description:      "GetProjectAccess: user who has not linked the project",
+			mattermostUserID: "mockUserID-3",
+			projectArgument:  "mockOrganization/mockProjectName",
+			expectedMessage:  fmt.Sprintf(constants.ErrorProjectAccessPermission, "mockOrganization/mockProjectName"),
+		},
+		{
+			description:      "GetProjectAccess: project is linked for multiple organizations",
+			mattermostUserID: "mockAdminID",
+			projectArgument:  testutils.MockProjectName,
+			isAdmin:          true,
+			expectedMessage:  fmt.Sprintf(constants.MultipleProjectsWithName, testutils.MockProjectName),
+		},
+		{
+			description:      "GetProjectAccess: project is not linked by any user",
+			mattermostUserID: "mockAdminID",
+			projectArgument:  "mockUnlinkedProject",
+			isAdmin:          true,
+			expectedMessage:  fmt.Sprintf(constants.ProjectNotLinkedByAnyUser, "mockUnlinkedProject"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI.ExpectedCalls = nil
+			mockAPI.On("HasPermissionTo", testCase.mattermostUserID, model.PERMISSION_MANAGE_SYSTEM).Return(testCase.isAdmin)
+			mockAPI.On("GetUser", mock.AnythingOfType("string")).Return(func(userID string) *model.User {
+				return &model.User{Id: userID, Username: fmt.Sprintf("mockUsername-%s", userID[len(userID)-1:]), FirstName: "mock |", LastName: "name"}
+			}, nil)
+			mockedStore.EXPECT().GetProject().Return(projectList, nil)
+
+			message, err := p.getProjectAccess(testCase.mattermostUserID, testCase.projectArgument)
+
+			assert.NoError(t, err)
+			if testCase.expectedMessage != "" {
+				assert.Equal(t, testCase.expectedMessage, message)
+				return
+			}
+
+			assert.Contains(t, message, "###### Users who have linked mockOrganization/mockProjectName")
+			for _, row := range testCase.expectedRows {
+				assert.Contains(t, message, row)
+			}
+			assert.Contains(t, message, fmt.Sprintf("%d user(s) have linked the project", len(testCase.expectedRows)))
+		})
+	}
+}
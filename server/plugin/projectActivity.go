@@ -0,0 +1,259 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// projectActivity is an event listed in the recent activity of a project, like a work item being changed or a branch being pushed to
+type projectActivity struct {
+	time        time.Time
+	description string
+}
+
+// projectActivitySource fetches one kind of the activities of a project since a time
+type projectActivitySource struct {
+	name  string
+	fetch func(project *serializers.ProjectDetails, since time.Time, mattermostUserID string) ([]*projectActivity, error)
+}
+
+// projectActivityResult is the result of fetching the activities of a source
+type projectActivityResult struct {
+	activities []*projectActivity
+	err        error
+}
+
+// getProjectActivity lists the work items changed, the pull requests created or closed and the pushes in a linked project in the last hours.
+// The sources are fetched at the same time and a failing source is noted while the activities of the others are still listed,
+// an error is only returned if all of them fail. The times are shown in the timezone of the channel.
+func (p *Plugin) getProjectActivity(mattermostUserID, channelID, projectArgument string, hours int) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	sources := []projectActivitySource{
+		{name: constants.ProjectActivityWorkItems, fetch: p.getWorkItemActivities},
+		{name: constants.ProjectActivityPullRequests, fetch: p.getPullRequestActivities},
+		{name: constants.ProjectActivityPushes, fetch: p.getPushActivities},
+	}
+
+	results := make([]projectActivityResult, len(sources))
+	var wg sync.WaitGroup
+	for index, source := range sources {
+		wg.Add(1)
+		go func(index int, source projectActivitySource) {
+			defer wg.Done()
+			activities, err := source.fetch(project, since, mattermostUserID)
+			results[index] = projectActivityResult{activities: activities, err: err}
+		}(index, source)
+	}
+	wg.Wait()
+
+	var activities []*projectActivity
+	var failedSources []string
+	for index, result := range results {
+		if result.err != nil {
+			p.API.LogError(constants.ErrorFetchProjectActivity, "Source", sources[index].name, "Error", result.err.Error())
+			err = result.err
+			failedSources = append(failedSources, sources[index].name)
+			continue
+		}
+		activities = append(activities, result.activities...)
+	}
+
+	if len(failedSources) == len(sources) {
+		return "", err
+	}
+
+	activities, totalCount := mergeProjectActivities(activities, since, constants.ProjectActivityMaxResults)
+
+	var sb strings.Builder
+	if len(activities) == 0 {
+		sb.WriteString(fmt.Sprintf(constants.NoProjectActivity, project.ProjectName, hours))
+	} else {
+		location := p.getChannelNotificationPrefs(channelID).GetLocation()
+		sb.WriteString(fmt.Sprintf(constants.ProjectActivityTitle, project.ProjectName, hours))
+		for _, activity := range activities {
+			sb.WriteString(fmt.Sprintf("\n- `%s` %s", activity.time.In(location).Format(constants.ProjectActivityTimeFormat), activity.description))
+		}
+	}
+
+	if totalCount > len(activities) {
+		sb.WriteString("\n\n")
+		sb.WriteString(fmt.Sprintf(constants.ProjectActivityMaxCount, len(activities), totalCount))
+	}
+
+	for _, source := range failedSources {
+		sb.WriteString("\n\n")
+		sb.WriteString(fmt.Sprintf(constants.ProjectActivitySourceFailed, source))
+	}
+
+	return sb.String(), nil
+}
+
+// mergeProjectActivities orders the activities of all the sources from the latest to the oldest and keeps at most maxCount of them.
+// The activities before since are dropped, and the number of activities in the window is returned along with the kept ones.
+func mergeProjectActivities(activities []*projectActivity, since time.Time, maxCount int) ([]*projectActivity, int) {
+	merged := make([]*projectActivity, 0, len(activities))
+	for _, activity := range activities {
+		if activity != nil && !activity.time.Before(since) {
+			merged = append(merged, activity)
+		}
+	}
+
+	// The sort is stable so that the activities at the same time keep the order of their sources
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].time.After(merged[j].time)
+	})
+
+	totalCount := len(merged)
+	if len(merged) > maxCount {
+		merged = merged[:maxCount]
+	}
+
+	return merged, totalCount
+}
+
+// getWorkItemActivities returns the latest changes of the work items of a project, each work item is listed once with its last change
+func (p *Plugin) getWorkItemActivities(project *serializers.ProjectDetails, since time.Time, mattermostUserID string) ([]*projectActivity, error) {
+	query := fmt.Sprintf(constants.QueryChangedWorkItems, since.UTC().Format(time.RFC3339))
+	workItemReferences, _, err := p.Client.QueryWorkItems(project.OrganizationName, project.ProjectName, query, mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	workItemIDs := make([]int, 0, len(workItemReferences))
+	for _, workItemReference := range workItemReferences {
+		if workItemReference != nil && len(workItemIDs) < constants.ProjectActivityMaxResults {
+			workItemIDs = append(workItemIDs, workItemReference.ID)
+		}
+	}
+
+	if len(workItemIDs) == 0 {
+		return nil, nil
+	}
+
+	fields := []string{constants.FieldWorkItemType, constants.FieldTitle, constants.FieldState, constants.FieldChangedDate, constants.FieldChangedBy}
+	workItems, _, err := p.Client.GetWorkItemsBatch(project.OrganizationName, project.ProjectName, workItemIDs, fields, mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]*projectActivity, 0, len(workItems))
+	for _, workItem := range workItems {
+		if workItem == nil {
+			continue
+		}
+
+		link := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), workItem.ID)
+		activities = append(activities, &projectActivity{
+			time:        workItem.Fields.UpdatedAt,
+			description: fmt.Sprintf(constants.ProjectActivityWorkItem, workItem.Fields.Type, workItem.ID, link, workItem.Fields.Title, workItem.Fields.State, workItem.Fields.UpdatedBy.DisplayName),
+		})
+	}
+
+	return activities, nil
+}
+
+// getPullRequestActivities returns the creation of the latest pull requests of a project along with their completion or abandonment
+func (p *Plugin) getPullRequestActivities(project *serializers.ProjectDetails, since time.Time, mattermostUserID string) ([]*projectActivity, error) {
+	pullRequests, _, err := p.Client.GetProjectPullRequests(project.OrganizationName, project.ProjectName, mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []*projectActivity
+	for _, pullRequest := range pullRequests {
+		if pullRequest == nil {
+			continue
+		}
+
+		link := fmt.Sprintf(constants.PullRequestLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), url.PathEscape(pullRequest.Repository.Name), pullRequest.PullRequestID)
+		if createdAt, err := time.Parse(time.RFC3339, pullRequest.CreationDate); err == nil {
+			activities = append(activities, &projectActivity{
+				time:        createdAt,
+				description: fmt.Sprintf(constants.ProjectActivityPullRequest, pullRequest.PullRequestID, pullRequest.Title, link, pullRequest.Repository.Name, "created", pullRequest.CreatedBy.DisplayName),
+			})
+		}
+
+		if pullRequest.Status != constants.PullRequestStatusCompleted && pullRequest.Status != constants.PullRequestStatusAbandoned {
+			continue
+		}
+
+		if closedAt, err := time.Parse(time.RFC3339, pullRequest.ClosedDate); err == nil {
+			activities = append(activities, &projectActivity{
+				time:        closedAt,
+				description: fmt.Sprintf(constants.ProjectActivityPullRequest, pullRequest.PullRequestID, pullRequest.Title, link, pullRequest.Repository.Name, pullRequest.Status, pullRequest.ClosedBy.DisplayName),
+			})
+		}
+	}
+
+	return activities, nil
+}
+
+// getPushActivities returns the pushes to the first few repositories of a project.
+// A repository whose pushes can't be fetched is skipped, unless the pushes of none of the repositories can be fetched.
+func (p *Plugin) getPushActivities(project *serializers.ProjectDetails, since time.Time, mattermostUserID string) ([]*projectActivity, error) {
+	repositories, _, err := p.Client.GetGitRepositories(project.OrganizationName, project.ProjectName, mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repositories) > constants.ProjectActivityMaxRepositories {
+		repositories = repositories[:constants.ProjectActivityMaxRepositories]
+	}
+
+	var activities []*projectActivity
+	failedCount := 0
+	for _, repository := range repositories {
+		if repository == nil {
+			continue
+		}
+
+		pushes, _, pushesErr := p.Client.GetPushes(project.OrganizationName, project.ProjectName, repository.ID, since, mattermostUserID)
+		if pushesErr != nil {
+			p.API.LogDebug("Error in fetching the pushes of the repository", "Repository", repository.Name, "Error", pushesErr.Error())
+			err = pushesErr
+			failedCount++
+			continue
+		}
+
+		for _, push := range pushes {
+			if push == nil {
+				continue
+			}
+
+			branches := make([]string, 0, len(push.RefUpdates))
+			for _, refUpdate := range push.RefUpdates {
+				branches = append(branches, fmt.Sprintf("`%s`", strings.TrimPrefix(refUpdate.Name, constants.GitBranchRefPrefix)))
+			}
+
+			activities = append(activities, &projectActivity{
+				time:        push.Date,
+				description: fmt.Sprintf(constants.ProjectActivityPush, push.PushedBy.DisplayName, strings.Join(branches, ", "), repository.Name, repository.WebURL),
+			})
+		}
+	}
+
+	if failedCount > 0 && failedCount == len(repositories) {
+		return nil, err
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,190 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestMergeProjectActivities(t *testing.T) {
+	since := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	workItemChange := &projectActivity{time: since.Add(3 * time.Hour), description: "mockWorkItemChange"}
+	pullRequestCreation := &projectActivity{time: since.Add(5 * time.Hour), description: "mockPullRequestCreation"}
+	push := &projectActivity{time: since.Add(time.Hour), description: "mockPush"}
+	simultaneousPush := &projectActivity{time: since.Add(3 * time.Hour), description: "mockSimultaneousPush"}
+	oldPush := &projectActivity{time: since.Add(-time.Hour), description: "mockOldPush"}
+	for _, testCase := range []struct {
+		description        string
+		activities         []*projectActivity
+		maxCount           int
+		expectedActivities []*projectActivity
+		expectedTotalCount int
+	}{
+		{
+			description:        "MergeProjectActivities: activities of all the sources are ordered from the latest",
+			activities:         []*projectActivity{workItemChange, pullRequestCreation, push},
+			maxCount:           10,
+			expectedActivities: []*projectActivity{pullRequestCreation, workItemChange, push},
+			expectedTotalCount: 3,
+		},
+		{
+			description:        "MergeProjectActivities: activities at the same time keep the order of their sources",
+			activities:         []*projectActivity{workItemChange, simultaneousPush, push},
+			maxCount:           10,
+			expectedActivities: []*projectActivity{workItemChange, simultaneousPush, push},
+			expectedTotalCount: 3,
+		},
+		{
+			description:        "MergeProjectActivities: activities before the time window are dropped",
+			activities:         []*projectActivity{oldPush, nil, push},
+			maxCount:           10,
+			expectedActivities: []*projectActivity{push},
+			expectedTotalCount: 1,
+		},
+		{
+			description:        "MergeProjectActivities: only the latest activities are kept",
+			activities:         []*projectActivity{push, workItemChange, pullRequestCreation, oldPush},
+			maxCount:           2,
+			expectedActivities: []*projectActivity{pullRequestCreation, workItemChange},
+			expectedTotalCount: 3,
+		},
+		{
+			description:        "MergeProjectActivities: no activities",
+			maxCount:           10,
+			expectedActivities: []*projectActivity{},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			activities, totalCount := mergeProjectActivities(testCase.activities, since, testCase.maxCount)
+
+			assert.Equal(t, testCase.expectedActivities, activities)
+			assert.Equal(t, testCase.expectedTotalCount, totalCount)
+		})
+	}
+}
+
+func TestGetProjectActivity(t *testing.T) {
+	now := time.Now()
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	workItems := []*serializers.TaskValue{{
+		ID: 1,
+		Fields: serializers.TaskFieldValue{
+			Title:     "mockTitle",
+			Type:      "Bug",
+			State:     "Active",
+			UpdatedAt: now.Add(-2 * time.Hour),
+			UpdatedBy: serializers.TaskUserDetails{DisplayName: "mockChanger"},
+		},
+	}}
+	pullRequests := []*serializers.PullRequest{{
+		PullRequestID: 2,
+		Title:         "mockPullRequest",
+		Repository:    serializers.Repository{Name: "mockRepo"},
+		Status:        constants.PullRequestStatusCompleted,
+		CreatedBy:     serializers.Identity{DisplayName: "mockCreator"},
+		CreationDate:  now.Add(-30 * time.Hour).UTC().Format(time.RFC3339),
+		ClosedBy:      serializers.Identity{DisplayName: "mockCloser"},
+		ClosedDate:    now.Add(-time.Hour).UTC().Format(time.RFC3339),
+	}}
+	repositories := []*serializers.GitRepository{{ID: "mockRepositoryID", Name: "mockRepo", WebURL: "https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepo"}}
+	pushes := []*serializers.Push{{
+		PushID:     3,
+		Date:       now.Add(-3 * time.Hour),
+		PushedBy:   serializers.Identity{DisplayName: "mockPusher"},
+		RefUpdates: []serializers.RefUpdates{{Name: "refs/heads/main"}},
+	}}
+
+	workItemLine := "[Bug 1](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/1): mockTitle was changed to Active by mockChanger"
+	pullRequestLine := "Pull request [!2: mockPullRequest](https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepo/pullrequest/2) in mockRepo was completed by mockCloser"
+	pushLine := "mockPusher pushed to `main` in [mockRepo](https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepo)"
+	for _, testCase := range []struct {
+		description      string
+		pullRequestsErr  error
+		pushesErr        error
+		workItemsErr     error
+		expectedErr      string
+		expectedLines    []string
+		notExpectedLines []string
+	}{
+		{
+			description:      "GetProjectActivity: activities of all the sources are listed from the latest",
+			expectedLines:    []string{pullRequestLine, workItemLine, pushLine},
+			notExpectedLines: []string{"mockCreator"},
+		},
+		{
+			description:     "GetProjectActivity: failing source is noted",
+			pullRequestsErr: errors.New("mockError"),
+			expectedLines:   []string{workItemLine, pushLine, fmt.Sprintf(constants.ProjectActivitySourceFailed, constants.ProjectActivityPullRequests)},
+		},
+		{
+			description:     "GetProjectActivity: all the sources fail",
+			pullRequestsErr: errors.New("mockError"),
+			pushesErr:       errors.New("mockError"),
+			workItemsErr:    errors.New("mockError"),
+			expectedErr:     "mockError",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+			mockAPI.On("LogError", constants.ErrorFetchProjectActivity, "Source", mock.AnythingOfType("string"), "Error", "mockError")
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), "Repository", "mockRepo", "Error", "mockError")
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+			mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).Return([]*serializers.WorkItemReference{{ID: 1}}, http.StatusOK, testCase.workItemsErr)
+			mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, []int{1}, gomock.Any(), testutils.MockMattermostUserID).Return(workItems, http.StatusOK, nil).MaxTimes(1)
+			mockedClient.EXPECT().GetProjectPullRequests(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID).Return(pullRequests, http.StatusOK, testCase.pullRequestsErr)
+			mockedClient.EXPECT().GetGitRepositories(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID).Return(repositories, http.StatusOK, nil)
+			mockedClient.EXPECT().GetPushes(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", gomock.Any(), testutils.MockMattermostUserID).Return(pushes, http.StatusOK, testCase.pushesErr)
+
+			message, err := p.getProjectActivity(testutils.MockMattermostUserID, testutils.MockChannelID, testutils.MockProjectName, constants.ProjectActivityDefaultHours)
+
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			lastIndex := -1
+			for _, line := range testCase.expectedLines {
+				index := strings.Index(message, line)
+				assert.Greater(t, index, lastIndex, line)
+				lastIndex = index
+			}
+			for _, line := range testCase.notExpectedLines {
+				assert.NotContains(t, message, line)
+			}
+		})
+	}
+
+	t.Run("GetProjectActivity: project is not linked", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, nil)
+
+		message, err := p.getProjectActivity(testutils.MockMattermostUserID, testutils.MockChannelID, testutils.MockProjectName, constants.ProjectActivityDefaultHours)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, message)
+	})
+}
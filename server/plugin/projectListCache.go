@@ -0,0 +1,163 @@
+package plugin
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+)
+
+// projectListCacheEntry is the list of the projects linked by a user cached until it expires
+type projectListCacheEntry struct {
+	mattermostUserID string
+	projects         []serializers.ProjectDetails
+	expiresAt        time.Time
+}
+
+// projectListCache is an LRU cache of the projects linked by each user, which are otherwise read from the KV store on every request.
+// It's local to each server of a cluster, so a project linked or unlinked on another server is picked up once the cached list expires.
+type projectListCache struct {
+	lock    sync.Mutex
+	maxSize int
+	// entries are the elements of the order list by Mattermost user ID
+	entries map[string]*list.Element
+	// order lists the entries from the most to the least recently used
+	order *list.List
+	// generation changes every time a list is invalidated, so that a list read before it was changed isn't cached
+	generation uint64
+}
+
+func newProjectListCache(maxSize int) *projectListCache {
+	return &projectListCache{
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// get returns a copy of the projects cached for a user, so that the callers can sort or change it
+func (c *projectListCache) get(mattermostUserID string, now time.Time) ([]serializers.ProjectDetails, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[mattermostUserID]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*projectListCacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, mattermostUserID)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return copyProjectList(entry.projects), true
+}
+
+// getGeneration returns the generation to pass to set the list read from the KV store after it
+func (c *projectListCache) getGeneration() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.generation
+}
+
+// set caches the projects of a user unless a list was invalidated since the generation was read, the least recently used list is evicted when the cache is full
+func (c *projectListCache) set(mattermostUserID string, projects []serializers.ProjectDetails, generation uint64, expiresAt time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if element, ok := c.entries[mattermostUserID]; ok {
+		element.Value = &projectListCacheEntry{mattermostUserID: mattermostUserID, projects: copyProjectList(projects), expiresAt: expiresAt}
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[mattermostUserID] = c.order.PushFront(&projectListCacheEntry{mattermostUserID: mattermostUserID, projects: copyProjectList(projects), expiresAt: expiresAt})
+	for c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*projectListCacheEntry).mattermostUserID)
+	}
+}
+
+// invalidate removes the projects cached for a user after they are changed
+func (c *projectListCache) invalidate(mattermostUserID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generation++
+	if element, ok := c.entries[mattermostUserID]; ok {
+		c.order.Remove(element)
+		delete(c.entries, mattermostUserID)
+	}
+}
+
+func copyProjectList(projects []serializers.ProjectDetails) []serializers.ProjectDetails {
+	if projects == nil {
+		return nil
+	}
+
+	return append([]serializers.ProjectDetails{}, projects...)
+}
+
+// projectListCacheStore is the KV store reading the projects linked by the users through the project list cache.
+// The lists are cached for the TTL set in the configuration, and are read from the KV store every time when it's 0.
+type projectListCacheStore struct {
+	store.KVStore
+	cache  *projectListCache
+	getTTL func() time.Duration
+}
+
+func (s *projectListCacheStore) GetAllProjects(mattermostUserID string) ([]serializers.ProjectDetails, error) {
+	ttl := s.getTTL()
+	if ttl <= 0 {
+		return s.KVStore.GetAllProjects(mattermostUserID)
+	}
+
+	if projects, ok := s.cache.get(mattermostUserID, time.Now()); ok {
+		return projects, nil
+	}
+
+	generation := s.cache.getGeneration()
+	projects, err := s.KVStore.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.set(mattermostUserID, projects, generation, time.Now().Add(ttl))
+	return projects, nil
+}
+
+func (s *projectListCacheStore) StoreProject(project *serializers.ProjectDetails) error {
+	defer s.cache.invalidate(project.MattermostUserID)
+	return s.KVStore.StoreProject(project)
+}
+
+func (s *projectListCacheStore) DeleteProject(project *serializers.ProjectDetails) error {
+	defer s.cache.invalidate(project.MattermostUserID)
+	return s.KVStore.DeleteProject(project)
+}
+
+func (s *projectListCacheStore) UpdateProject(project *serializers.ProjectDetails) error {
+	defer s.cache.invalidate(project.MattermostUserID)
+	return s.KVStore.UpdateProject(project)
+}
+
+func (s *projectListCacheStore) DedupeProjects(mattermostUserID string) ([]store.MergedProject, error) {
+	defer s.cache.invalidate(mattermostUserID)
+	return s.KVStore.DedupeProjects(mattermostUserID)
+}
+
+// getProjectListCacheTTL returns how long the projects linked by a user are cached
+func (p *Plugin) getProjectListCacheTTL() time.Duration {
+	return time.Duration(p.getConfiguration().ProjectListCacheTTL) * time.Second
+}
@@ -0,0 +1,210 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestProjectListCacheStore(t *testing.T) {
+	project := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, ProjectName: testutils.MockProjectName, OrganizationName: testutils.MockOrganization}
+	setupCacheStore := func(t *testing.T, ttl time.Duration) (*projectListCacheStore, *mocks.MockKVStore) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		return &projectListCacheStore{
+			KVStore: mockedStore,
+			cache:   newProjectListCache(10),
+			getTTL:  func() time.Duration { return ttl },
+		}, mockedStore
+	}
+
+	t.Run("ProjectListCacheStore: projects are read once from the KV store", func(t *testing.T) {
+		cacheStore, mockedStore := setupCacheStore(t, time.Minute)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil).Times(1)
+
+		projects, err := cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+		require.NoError(t, err)
+		projects[0].ProjectName = "mockChangedProjectName"
+
+		projects, err = cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+		require.NoError(t, err)
+		assert.Equal(t, []serializers.ProjectDetails{project}, projects)
+	})
+
+	t.Run("ProjectListCacheStore: projects are read every time without a TTL", func(t *testing.T) {
+		cacheStore, mockedStore := setupCacheStore(t, 0)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil).Times(2)
+
+		for i := 0; i < 2; i++ {
+			_, err := cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("ProjectListCacheStore: error in reading the projects is not cached", func(t *testing.T) {
+		cacheStore, mockedStore := setupCacheStore(t, time.Minute)
+		gomock.InOrder(
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, fmt.Errorf("error reading the projects")),
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil),
+		)
+
+		_, err := cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+		require.Error(t, err)
+
+		projects, err := cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+		require.NoError(t, err)
+		assert.Equal(t, []serializers.ProjectDetails{project}, projects)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		change      func(cacheStore *projectListCacheStore, mockedStore *mocks.MockKVStore)
+	}{
+		{
+			description: "ProjectListCacheStore: projects are read again after linking a project",
+			change: func(cacheStore *projectListCacheStore, mockedStore *mocks.MockKVStore) {
+				mockedStore.EXPECT().StoreProject(&project).Return(nil)
+				_ = cacheStore.StoreProject(&project)
+			},
+		},
+		{
+			description: "ProjectListCacheStore: projects are read again after unlinking a project",
+			change: func(cacheStore *projectListCacheStore, mockedStore *mocks.MockKVStore) {
+				mockedStore.EXPECT().DeleteProject(&project).Return(nil)
+				_ = cacheStore.DeleteProject(&project)
+			},
+		},
+		{
+			description: "ProjectListCacheStore: projects are read again after failing to unlink a project",
+			change: func(cacheStore *projectListCacheStore, mockedStore *mocks.MockKVStore) {
+				mockedStore.EXPECT().DeleteProject(&project).Return(fmt.Errorf("error unlinking the project"))
+				_ = cacheStore.DeleteProject(&project)
+			},
+		},
+		{
+			description: "ProjectListCacheStore: projects are read again after renaming a project",
+			change: func(cacheStore *projectListCacheStore, mockedStore *mocks.MockKVStore) {
+				mockedStore.EXPECT().UpdateProject(&project).Return(nil)
+				_ = cacheStore.UpdateProject(&project)
+			},
+		},
+		{
+			description: "ProjectListCacheStore: projects are read again after merging the duplicated projects",
+			change: func(cacheStore *projectListCacheStore, mockedStore *mocks.MockKVStore) {
+				mockedStore.EXPECT().DedupeProjects(testutils.MockMattermostUserID).Return(nil, nil)
+				_, _ = cacheStore.DedupeProjects(testutils.MockMattermostUserID)
+			},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			cacheStore, mockedStore := setupCacheStore(t, time.Minute)
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, nil).Times(2)
+
+			_, err := cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+			require.NoError(t, err)
+
+			testCase.change(cacheStore, mockedStore)
+
+			_, err = cacheStore.GetAllProjects(testutils.MockMattermostUserID)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProjectListCache(t *testing.T) {
+	now := time.Now()
+	projects := []serializers.ProjectDetails{{ProjectName: testutils.MockProjectName}}
+
+	t.Run("ProjectListCache: expired projects are removed", func(t *testing.T) {
+		cache := newProjectListCache(10)
+		cache.set("mockUser1", projects, cache.getGeneration(), now.Add(time.Minute))
+
+		_, ok := cache.get("mockUser1", now.Add(time.Minute))
+		assert.False(t, ok)
+		assert.Empty(t, cache.entries)
+	})
+
+	t.Run("ProjectListCache: least recently used projects are evicted", func(t *testing.T) {
+		cache := newProjectListCache(2)
+		cache.set("mockUser1", projects, cache.getGeneration(), now.Add(time.Minute))
+		cache.set("mockUser2", projects, cache.getGeneration(), now.Add(time.Minute))
+		_, _ = cache.get("mockUser1", now)
+		cache.set("mockUser3", projects, cache.getGeneration(), now.Add(time.Minute))
+
+		_, ok := cache.get("mockUser2", now)
+		assert.False(t, ok)
+		for _, mattermostUserID := range []string{"mockUser1", "mockUser3"} {
+			cachedProjects, ok := cache.get(mattermostUserID, now)
+			assert.True(t, ok)
+			assert.Equal(t, projects, cachedProjects)
+		}
+	})
+
+	t.Run("ProjectListCache: projects read before an invalidation are not cached", func(t *testing.T) {
+		cache := newProjectListCache(10)
+		generation := cache.getGeneration()
+		cache.invalidate("mockUser1")
+		cache.set("mockUser1", projects, generation, now.Add(time.Minute))
+
+		_, ok := cache.get("mockUser1", now)
+		assert.False(t, ok)
+	})
+}
+
+func BenchmarkGetAllProjects(b *testing.B) {
+	const users, projectsPerUser = 200, 20
+	projectList := store.NewProjectList()
+	for i := 0; i < users; i++ {
+		for j := 0; j < projectsPerUser; j++ {
+			projectList.AddProject(fmt.Sprintf("mockUser%d", i), &serializers.ProjectDetails{
+				ProjectID:        fmt.Sprintf("mockProjectID%d", j),
+				ProjectName:      fmt.Sprintf("mockProject%d", j),
+				OrganizationName: testutils.MockOrganization,
+			})
+		}
+	}
+	projectListBytes, err := json.Marshal(projectList)
+	require.NoError(b, err)
+
+	mockAPI := &plugintest.API{}
+	mockAPI.On("KVGet", store.GetProjectListMapKey()).Return(projectListBytes, nil)
+
+	for _, benchmark := range []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{name: "uncached"},
+		{name: "cached", ttl: time.Minute},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			cacheStore := &projectListCacheStore{
+				KVStore: store.NewStore(mockAPI),
+				cache:   newProjectListCache(users),
+				getTTL:  func() time.Duration { return benchmark.ttl },
+			}
+
+			var requestCount int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					mattermostUserID := fmt.Sprintf("mockUser%d", atomic.AddInt64(&requestCount, 1)%users)
+					if _, err := cacheStore.GetAllProjects(mattermostUserID); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+var workItemMentionRegex = regexp.MustCompile(constants.PullRequestWorkItemMentionRegex)
+
+// getMyPullRequests returns the open pull requests created by a user in a linked project, or in all the linked projects, as a table.
+// When listing all the projects, a project whose pull requests can't be fetched is noted and the others are still listed.
+func (p *Plugin) getMyPullRequests(mattermostUserID, projectArgument string, allProjects bool) (string, error) {
+	creatorID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorLoadingUserData)
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	projects := projectList
+	if !allProjects {
+		project, err := p.getLinkedProject(projectList, projectArgument)
+		if err != nil {
+			return err.Error(), nil
+		}
+		projects = []serializers.ProjectDetails{*project}
+	}
+
+	if len(projects) == 0 {
+		return constants.NoProjectLinked, nil
+	}
+
+	var rows, failedProjects []string
+	for _, project := range projects {
+		pullRequests, _, err := p.Client.GetPullRequestsByCreator(project.OrganizationName, project.ProjectName, creatorID, mattermostUserID)
+		if err != nil {
+			if !allProjects {
+				return "", err
+			}
+			p.API.LogError(constants.ErrorFetchPullRequests, "Project", project.ProjectName, "Error", err.Error())
+			failedProjects = append(failedProjects, fmt.Sprintf("%s/%s", project.OrganizationName, project.ProjectName))
+			continue
+		}
+
+		for _, pullRequest := range pullRequests {
+			if pullRequest != nil {
+				rows = append(rows, p.getMyPullRequestRow(&project, pullRequest))
+			}
+		}
+	}
+
+	var sb strings.Builder
+	projectsName := "any of the linked projects"
+	if !allProjects {
+		projectsName = fmt.Sprintf("%s/%s", projects[0].OrganizationName, projects[0].ProjectName)
+	}
+
+	if len(rows) == 0 {
+		sb.WriteString(fmt.Sprintf(constants.NoOpenPullRequests, projectsName))
+	} else {
+		sb.WriteString("###### Your open pull requests\n")
+		sb.WriteString("| Pull Request | Repository | Project | Reviews | Status |\n")
+		sb.WriteString("| :----------- | :--------- | :------ | :------ | :----- |\n")
+		sb.WriteString(strings.Join(rows, ""))
+	}
+
+	if len(failedProjects) > 0 {
+		sb.WriteString(fmt.Sprintf("\n\nThe pull requests of %s could not be fetched", strings.Join(failedProjects, ", ")))
+	}
+
+	return sb.String(), nil
+}
+
+func (p *Plugin) getMyPullRequestRow(project *serializers.ProjectDetails, pullRequest *serializers.PullRequest) string {
+	link := fmt.Sprintf(constants.PullRequestLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), url.PathEscape(pullRequest.Repository.Name), pullRequest.PullRequestID)
+
+	status := "Active"
+	if pullRequest.IsDraft {
+		status = "Draft"
+	}
+	if pullRequest.MergeStatus == constants.PullRequestMergeStatusConflicts {
+		status += ", has merge conflicts"
+	}
+
+	return fmt.Sprintf("| [!%d: %s](%s) | %s | %s | %s | %s |\n", pullRequest.PullRequestID, escapeTableCell(pullRequest.Title), link, escapeTableCell(pullRequest.Repository.Name), escapeTableCell(project.ProjectName), getReviewerVoteSummary(pullRequest.Reviewers), status)
+}
+
+// getReviewerVoteSummary returns the number of reviewers for each vote on a pull request, like "2 approved, 1 waiting for author"
+func getReviewerVoteSummary(reviewers []serializers.Reviewer) string {
+	if len(reviewers) == 0 {
+		return "No reviewers"
+	}
+
+	votes := map[int]int{}
+	for _, reviewer := range reviewers {
+		votes[reviewer.Vote]++
+	}
+
+	var summary []string
+	for _, vote := range []struct {
+		value int
+		name  string
+	}{
+		{constants.PullRequestVoteApproved, "approved"},
+		{constants.PullRequestVoteApprovedWithSuggestion, "approved with suggestions"},
+		{constants.PullRequestVoteWaitingForAuthor, "waiting for author"},
+		{constants.PullRequestVoteRejected, "rejected"},
+		{0, "no vote"},
+	} {
+		if count := votes[vote.value]; count > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", count, vote.name))
+		}
+	}
+
+	return strings.Join(summary, ", ")
+}
+
+// getPullRequestWorkItemsField returns the attachment field listing the work items of the pull request a notification is about.
+// The work items are the ones linked to the pull request along with the ones mentioned like "AB#123" in its title or description,
+// they are fetched only if it's enabled for the subscription. No field is returned if there aren't any work items.
+func (p *Plugin) getPullRequestWorkItemsField(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) *model.SlackAttachmentField {
+	if subscription == nil || !subscription.ShowLinkedWorkItems {
+		return nil
+	}
+
+	var pullRequest serializers.PullRequest
+	switch body.EventType {
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged:
+		pullRequest = serializers.PullRequest{
+			PullRequestID: body.Resource.PullRequestID,
+			Title:         body.Resource.Title,
+			Description:   body.Resource.Description,
+			Repository:    body.Resource.Repository,
+		}
+	case constants.SubscriptionEventPullRequestCommented:
+		pullRequest = body.Resource.PullRequest
+	default:
+		return nil
+	}
+
+	var workItemIDs []int
+	isWorkItemIDAdded := map[int]bool{}
+	addWorkItemID := func(id string) {
+		if workItemID, err := strconv.Atoi(id); err == nil && workItemID > 0 && !isWorkItemIDAdded[workItemID] {
+			isWorkItemIDAdded[workItemID] = true
+			workItemIDs = append(workItemIDs, workItemID)
+		}
+	}
+
+	for _, match := range workItemMentionRegex.FindAllStringSubmatch(fmt.Sprintf("%s\n%s", pullRequest.Title, pullRequest.Description), -1) {
+		addWorkItemID(match[1])
+	}
+
+	if pullRequest.Repository.ID != "" {
+		linkedWorkItems, _, err := p.Client.GetPullRequestWorkItems(subscription.OrganizationName, subscription.ProjectName, pullRequest.Repository.ID, pullRequest.PullRequestID, subscription.MattermostUserID)
+		if err != nil {
+			p.API.LogDebug("Error in getting the work items of the pull request from Azure", "Error", err.Error())
+		}
+
+		for _, workItem := range linkedWorkItems {
+			if workItem != nil {
+				addWorkItemID(workItem.ID)
+			}
+		}
+	}
+
+	if len(workItemIDs) == 0 {
+		return nil
+	}
+
+	moreWorkItemsCount := 0
+	if len(workItemIDs) > constants.PullRequestWorkItemsMaxCount {
+		moreWorkItemsCount = len(workItemIDs) - constants.PullRequestWorkItemsMaxCount
+		workItemIDs = workItemIDs[:constants.PullRequestWorkItemsMaxCount]
+	}
+
+	// The work items are still listed without their titles if they can't be fetched
+	workItemsByID := map[int]*serializers.TaskValue{}
+	workItems, _, err := p.Client.GetWorkItemsBatch(subscription.OrganizationName, subscription.ProjectName, workItemIDs, []string{constants.FieldWorkItemType, constants.FieldTitle}, subscription.MattermostUserID)
+	if err != nil {
+		p.API.LogDebug("Error in getting the work items of the pull request from Azure", "Error", err.Error())
+	}
+	for _, workItem := range workItems {
+		if workItem != nil {
+			workItemsByID[workItem.ID] = workItem
+		}
+	}
+
+	var workItemList []string
+	for _, workItemID := range workItemIDs {
+		link := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), subscription.OrganizationName, url.PathEscape(subscription.ProjectName), workItemID)
+		if workItem, ok := workItemsByID[workItemID]; ok {
+			workItemList = append(workItemList, fmt.Sprintf("- [%s %d](%s): %s", workItem.Fields.Type, workItemID, link, workItem.Fields.Title))
+			continue
+		}
+		workItemList = append(workItemList, fmt.Sprintf("- [#%d](%s)", workItemID, link))
+	}
+
+	if moreWorkItemsCount > 0 {
+		workItemList = append(workItemList, fmt.Sprintf("and %d more", moreWorkItemsCount))
+	}
+
+	return &model.SlackAttachmentField{
+		Title: "Work Items",
+		Value: strings.Join(workItemList, "\n"),
+	}
+}
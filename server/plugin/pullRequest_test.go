@@ -0,0 +1,221 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetReviewerVoteSummary(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		reviewers       []serializers.Reviewer
+		expectedSummary string
+	}{
+		{
+			description:     "GetReviewerVoteSummary: no reviewers",
+			expectedSummary: "No reviewers",
+		},
+		{
+			description: "GetReviewerVoteSummary: reviewers with different votes",
+			reviewers: []serializers.Reviewer{
+				{Vote: constants.PullRequestVoteApproved},
+				{Vote: 0},
+				{Vote: constants.PullRequestVoteWaitingForAuthor},
+				{Vote: constants.PullRequestVoteApproved},
+			},
+			expectedSummary: "2 approved, 1 waiting for author, 1 no vote",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedSummary, getReviewerVoteSummary(testCase.reviewers))
+		})
+	}
+}
+
+func TestGetMyPullRequests(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	otherProject := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: "mockOtherProject"}
+	pullRequests := []*serializers.PullRequest{
+		{
+			PullRequestID: 1,
+			Title:         "mock | title",
+			Repository:    serializers.Repository{Name: "mockRepo"},
+			Reviewers:     []serializers.Reviewer{{Vote: constants.PullRequestVoteApproved}},
+		},
+		{
+			PullRequestID: 2,
+			Title:         "mockTitle",
+			Repository:    serializers.Repository{Name: "mockRepo"},
+			MergeStatus:   constants.PullRequestMergeStatusConflicts,
+			IsDraft:       true,
+		},
+	}
+
+	t.Run("GetMyPullRequests: pull requests of a linked project", func(t *testing.T) {
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("mockAzureDevopsUserID", nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project, otherProject}, nil)
+		mockedClient.EXPECT().GetPullRequestsByCreator(testutils.MockOrganization, testutils.MockProjectName, "mockAzureDevopsUserID", testutils.MockMattermostUserID).Return(pullRequests, http.StatusOK, nil)
+
+		message, err := p.getMyPullRequests(testutils.MockMattermostUserID, testutils.MockProjectName, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, message, "| [!1: mock \\| title](https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepo/pullrequest/1) | mockRepo | mockProjectName | 1 approved | Active |\n")
+		assert.Contains(t, message, "| [!2: mockTitle](https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepo/pullrequest/2) | mockRepo | mockProjectName | No reviewers | Draft, has merge conflicts |\n")
+	})
+
+	t.Run("GetMyPullRequests: no open pull requests", func(t *testing.T) {
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("mockAzureDevopsUserID", nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetPullRequestsByCreator(testutils.MockOrganization, testutils.MockProjectName, "mockAzureDevopsUserID", testutils.MockMattermostUserID).Return(nil, http.StatusOK, nil)
+
+		message, err := p.getMyPullRequests(testutils.MockMattermostUserID, testutils.MockProjectName, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.NoOpenPullRequests, "mockOrganization/mockProjectName"), message)
+	})
+
+	t.Run("GetMyPullRequests: project is not linked", func(t *testing.T) {
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("mockAzureDevopsUserID", nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+
+		message, err := p.getMyPullRequests(testutils.MockMattermostUserID, "mockUnlinkedProject", false)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, message)
+	})
+
+	t.Run("GetMyPullRequests: pull requests of all the linked projects", func(t *testing.T) {
+		mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("mockAzureDevopsUserID", nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project, otherProject}, nil)
+		mockedClient.EXPECT().GetPullRequestsByCreator(testutils.MockOrganization, testutils.MockProjectName, "mockAzureDevopsUserID", testutils.MockMattermostUserID).Return(pullRequests, http.StatusOK, nil)
+		mockedClient.EXPECT().GetPullRequestsByCreator(testutils.MockOrganization, "mockOtherProject", "mockAzureDevopsUserID", testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("error getting the pull requests"))
+
+		message, err := p.getMyPullRequests(testutils.MockMattermostUserID, constants.CommandAllFlag, true)
+
+		assert.NoError(t, err)
+		assert.Contains(t, message, "[!1: mock \\| title]")
+		assert.Contains(t, message, "[!2: mockTitle]")
+		assert.Contains(t, message, "The pull requests of mockOrganization/mockOtherProject could not be fetched")
+	})
+
+	t.Run("GetMyPullRequests: error in fetching the pull requests", func(t *testing.T) {
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("mockAzureDevopsUserID", nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetPullRequestsByCreator(testutils.MockOrganization, testutils.MockProjectName, "mockAzureDevopsUserID", testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("error getting the pull requests"))
+
+		_, err := p.getMyPullRequests(testutils.MockMattermostUserID, testutils.MockProjectName, false)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetPullRequestWorkItemsField(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	p := setupMockPlugin(mockAPI, nil, mockedClient)
+	p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+	mockAPI.On("LogDebug", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+
+	subscription := &serializers.SubscriptionDetails{
+		MattermostUserID:    testutils.MockMattermostUserID,
+		OrganizationName:    testutils.MockOrganization,
+		ProjectName:         testutils.MockProjectName,
+		ShowLinkedWorkItems: true,
+	}
+	body := &serializers.SubscriptionNotification{
+		EventType: constants.SubscriptionEventPullRequestCreated,
+		Resource: serializers.Resource{
+			PullRequestID: 1,
+			Title:         "Fix login AB#12",
+			Description:   "Fixes ab#12 and AB#13, not XAB#14",
+			Repository:    serializers.Repository{ID: "mockRepositoryID", Name: "mockRepository"},
+		},
+	}
+
+	t.Run("GetPullRequestWorkItemsField: linked and mentioned work items are listed", func(t *testing.T) {
+		mockedClient.EXPECT().GetPullRequestWorkItems(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", 1, testutils.MockMattermostUserID).Return([]*serializers.ResourceRef{{ID: "13"}, {ID: "15"}}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, []int{12, 13, 15}, gomock.Any(), testutils.MockMattermostUserID).Return([]*serializers.TaskValue{
+			{ID: 12, Fields: serializers.TaskFieldValue{Type: "Bug", Title: "Login fails"}},
+			{ID: 15, Fields: serializers.TaskFieldValue{Type: "Task", Title: "Add tests"}},
+		}, http.StatusOK, nil)
+
+		field := p.getPullRequestWorkItemsField(subscription, body)
+
+		assert.Equal(t, "Work Items", field.Title)
+		assert.Equal(t, "- [Bug 12](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/12): Login fails\n"+
+			"- [#13](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/13)\n"+
+			"- [Task 15](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/15): Add tests", field.Value)
+	})
+
+	t.Run("GetPullRequestWorkItemsField: work items are listed without titles if they can't be fetched", func(t *testing.T) {
+		mockedClient.EXPECT().GetPullRequestWorkItems(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", 1, testutils.MockMattermostUserID).Return(nil, http.StatusForbidden, errors.New("error getting the work items"))
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, []int{12, 13}, gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusForbidden, errors.New("error getting the work items"))
+
+		field := p.getPullRequestWorkItemsField(subscription, body)
+
+		assert.Equal(t, "- [#12](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/12)\n"+
+			"- [#13](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/13)", field.Value)
+	})
+
+	t.Run("GetPullRequestWorkItemsField: number of listed work items is limited", func(t *testing.T) {
+		linkedWorkItems := []*serializers.ResourceRef{}
+		for id := 1; id <= 12; id++ {
+			linkedWorkItems = append(linkedWorkItems, &serializers.ResourceRef{ID: fmt.Sprint(id)})
+		}
+		mockedClient.EXPECT().GetPullRequestWorkItems(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", 3, testutils.MockMattermostUserID).Return(linkedWorkItems, http.StatusOK, nil)
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, gomock.Any(), testutils.MockMattermostUserID).Return([]*serializers.TaskValue{}, http.StatusOK, nil)
+
+		field := p.getPullRequestWorkItemsField(subscription, &serializers.SubscriptionNotification{
+			EventType: constants.SubscriptionEventPullRequestUpdated,
+			Resource:  serializers.Resource{PullRequestID: 3, Repository: serializers.Repository{ID: "mockRepositoryID"}},
+		})
+
+		assert.True(t, strings.HasSuffix(field.Value.(string), "/_workitems/edit/10)\nand 2 more"))
+	})
+
+	t.Run("GetPullRequestWorkItemsField: pull request without work items", func(t *testing.T) {
+		mockedClient.EXPECT().GetPullRequestWorkItems(testutils.MockOrganization, testutils.MockProjectName, "mockRepositoryID", 2, testutils.MockMattermostUserID).Return([]*serializers.ResourceRef{}, http.StatusOK, nil)
+
+		assert.Nil(t, p.getPullRequestWorkItemsField(subscription, &serializers.SubscriptionNotification{
+			EventType: constants.SubscriptionEventPullRequestCommented,
+			Resource: serializers.Resource{PullRequest: serializers.PullRequest{
+				PullRequestID: 2,
+				Title:         "Update the readme",
+				Repository:    serializers.Repository{ID: "mockRepositoryID"},
+			}},
+		}))
+	})
+
+	t.Run("GetPullRequestWorkItemsField: work items are not shown for the subscription", func(t *testing.T) {
+		assert.Nil(t, p.getPullRequestWorkItemsField(&serializers.SubscriptionDetails{}, body))
+		assert.Nil(t, p.getPullRequestWorkItemsField(nil, body))
+	})
+
+	t.Run("GetPullRequestWorkItemsField: notification is not about a pull request", func(t *testing.T) {
+		assert.Nil(t, p.getPullRequestWorkItemsField(subscription, &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted}))
+	})
+}
@@ -0,0 +1,102 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getPushCommitsText returns the commits of a push as a list linking to each commit.
+// Only the first few commits of a large push are listed, followed by a link to view all of them.
+func getPushCommitsText(resource *serializers.Resource, localizer *i18n.Localizer) string {
+	var refUpdate serializers.RefUpdates
+	if len(resource.RefUpdates) > 0 {
+		refUpdate = resource.RefUpdates[0]
+	}
+
+	if refUpdate.NewObjectID == constants.GitEmptyObjectID {
+		return localizer.Localize(constants.PushBranchDeleted)
+	}
+
+	if len(resource.Commits) == 0 {
+		// A force push which moves the branch back to an existing commit, or a branch created from one, reports no commits
+		if refUpdate.NewObjectID != "" {
+			return localizer.Localizef(constants.PushNoNewCommits, getShortCommitID(refUpdate.NewObjectID), getCommitLink(resource.Repository, serializers.Commit{CommitID: refUpdate.NewObjectID}))
+		}
+		return localizer.Localize(constants.PushNoCommits)
+	}
+
+	var lines []string
+	for i, commit := range resource.Commits {
+		if i == constants.PushNotificationMaxCommits {
+			break
+		}
+		lines = append(lines, getPushCommitLine(resource.Repository, commit, localizer))
+	}
+
+	if remaining := len(resource.Commits) - len(lines); remaining > 0 {
+		if link := getPushCommitsLink(resource.Repository, refUpdate); link != "" {
+			lines = append(lines, localizer.Localizef(constants.PushViewAllCommits, len(resource.Commits), link))
+		} else {
+			lines = append(lines, localizer.Localizef(constants.PushMoreCommits, remaining))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// getPushCommitLine returns a commit as its short ID linking to the commit, followed by the first line of its message and its author
+func getPushCommitLine(repository serializers.Repository, commit serializers.Commit, localizer *i18n.Localizer) string {
+	message := strings.TrimSpace(strings.SplitN(strings.TrimSpace(commit.Comment), "\n", 2)[0])
+	line := fmt.Sprintf("[%s](%s): **%s**", getShortCommitID(commit.CommitID), getCommitLink(repository, commit), message)
+	if commit.Author.Name != "" {
+		line += localizer.Localizef(constants.PushCommitAuthor, commit.Author.Name)
+	}
+
+	if len(commit.Parents) > 1 {
+		line += localizer.Localize(constants.PushMergeCommit)
+	}
+
+	return line
+}
+
+// getCommitLink returns the web link of a commit.
+// The link is built from the remote URL of the repository, as the URL of a commit in the payload can be its API URL.
+func getCommitLink(repository serializers.Repository, commit serializers.Commit) string {
+	if repository.RemoteURL == "" {
+		return commit.URL
+	}
+
+	return fmt.Sprintf(constants.CommitLink, repository.RemoteURL, commit.CommitID)
+}
+
+// getPushCommitsLink returns the web link to view all the commits of a push.
+// The pushed range is compared with the previous commit of the branch, which also lists the new commits of a force push,
+// while the history of the branch is linked for a new branch.
+func getPushCommitsLink(repository serializers.Repository, refUpdate serializers.RefUpdates) string {
+	if repository.RemoteURL == "" || refUpdate.NewObjectID == "" {
+		return ""
+	}
+
+	if refUpdate.OldObjectID == "" || refUpdate.OldObjectID == constants.GitEmptyObjectID {
+		branch := strings.TrimPrefix(refUpdate.Name, constants.GitBranchRefPrefix)
+		if branch == "" {
+			return ""
+		}
+		return fmt.Sprintf(constants.BranchCommitsLink, repository.RemoteURL, url.QueryEscape(branch))
+	}
+
+	return fmt.Sprintf(constants.BranchCompareLink, repository.RemoteURL, refUpdate.OldObjectID, refUpdate.NewObjectID)
+}
+
+func getShortCommitID(commitID string) string {
+	if len(commitID) <= constants.CommitShortIDLength {
+		return commitID
+	}
+
+	return commitID[:constants.CommitShortIDLength]
+}
@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+const mockRemoteURL = "https://dev.azure.com/mockOrganization/mockProject/_git/mockRepository"
+
+func getMockPushResource(t *testing.T, commitCount int, oldObjectID, newObjectID string) *serializers.Resource {
+	commits := make([]map[string]interface{}, 0, commitCount)
+	for i := 0; i < commitCount; i++ {
+		commits = append(commits, map[string]interface{}{
+			"commitId": fmt.Sprintf("%040d", i+1),
+			"author":   map[string]string{"name": "Mock Author", "email": "mock@example.com", "date": "2024-01-08T09:00:00Z"},
+			"comment":  fmt.Sprintf("Mock commit %d\n\nMock commit description", i+1),
+			"url":      fmt.Sprintf("https://dev.azure.com/mockOrganization/_apis/git/repositories/mockRepositoryID/commits/%040d", i+1),
+		})
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"commits":    commits,
+		"refUpdates": []map[string]string{{"name": "refs/heads/feature/mock", "oldObjectId": oldObjectID, "newObjectId": newObjectID}},
+		"repository": map[string]string{"id": "mockRepositoryID", "name": "mockRepository", "remoteUrl": mockRemoteURL},
+	})
+	require.NoError(t, err)
+
+	var resource *serializers.Resource
+	require.NoError(t, json.Unmarshal(payload, &resource))
+	return resource
+}
+
+func TestGetPushCommitsText(t *testing.T) {
+	oldObjectID := "1111111111111111111111111111111111111111"
+	newObjectID := "2222222222222222222222222222222222222222"
+
+	t.Run("GetPushCommitsText: single commit is listed with the first line of its message and its author", func(t *testing.T) {
+		resource := getMockPushResource(t, 1, oldObjectID, newObjectID)
+
+		assert.Equal(t, fmt.Sprintf("[00000000](%s/commit/%040d): **Mock commit 1** by Mock Author", mockRemoteURL, 1), getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale)))
+	})
+
+	t.Run("GetPushCommitsText: first commits of a large push are listed with a link to view all of them", func(t *testing.T) {
+		resource := getMockPushResource(t, 7, oldObjectID, newObjectID)
+
+		text := getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale))
+		assert.Contains(t, text, "**Mock commit 5**")
+		assert.NotContains(t, text, "**Mock commit 6**")
+		assert.Contains(t, text, fmt.Sprintf("[View all 7 commits](%s/branchCompare?baseVersion=GC%s&targetVersion=GC%s)", mockRemoteURL, oldObjectID, newObjectID))
+	})
+
+	t.Run("GetPushCommitsText: history of a new branch is linked to view all the commits", func(t *testing.T) {
+		resource := getMockPushResource(t, 6, constants.GitEmptyObjectID, newObjectID)
+
+		assert.Contains(t, getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale)), fmt.Sprintf("[View all 6 commits](%s/commits?itemVersion=GBfeature%%2Fmock)", mockRemoteURL))
+	})
+
+	t.Run("GetPushCommitsText: remaining commits are counted without the remote URL of the repository", func(t *testing.T) {
+		resource := getMockPushResource(t, 6, oldObjectID, newObjectID)
+		resource.Repository.RemoteURL = ""
+
+		text := getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale))
+		assert.Contains(t, text, fmt.Sprintf("[00000000](%s)", resource.Commits[0].URL))
+		assert.Contains(t, text, "…and 1 more commit(s)")
+	})
+
+	t.Run("GetPushCommitsText: merge commit is marked", func(t *testing.T) {
+		resource := getMockPushResource(t, 1, oldObjectID, newObjectID)
+		resource.Commits[0].Parents = []string{oldObjectID, "3333333333333333333333333333333333333333"}
+
+		assert.Contains(t, getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale)), "**Mock commit 1** by Mock Author (merge commit)")
+	})
+
+	t.Run("GetPushCommitsText: force push without new commits links to the new commit of the branch", func(t *testing.T) {
+		resource := getMockPushResource(t, 0, oldObjectID, newObjectID)
+
+		assert.Equal(t, fmt.Sprintf("No new commits, the branch now points to [22222222](%s/commit/%s)", mockRemoteURL, newObjectID), getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale)))
+	})
+
+	t.Run("GetPushCommitsText: deleted branch", func(t *testing.T) {
+		resource := getMockPushResource(t, 0, oldObjectID, constants.GitEmptyObjectID)
+
+		assert.Equal(t, constants.PushBranchDeleted, getPushCommitsText(resource, i18n.NewLocalizer(constants.DefaultNotificationLocale)))
+	})
+
+	t.Run("GetPushCommitsText: push without ref updates or commits", func(t *testing.T) {
+		assert.Equal(t, constants.PushNoCommits, getPushCommitsText(&serializers.Resource{}, i18n.NewLocalizer(constants.DefaultNotificationLocale)))
+	})
+}
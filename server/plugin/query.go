@@ -0,0 +1,203 @@
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// sharedQueryRow is a work item in the results of a query along with its level in the hierarchy of the results
+type sharedQueryRow struct {
+	id    int
+	depth int
+}
+
+// getSharedQueryResults returns a page of the work items returned by a saved query of a linked project as a table.
+// The query can be given by its name or by its path if queries with the same name are present in different folders.
+func (p *Plugin) getSharedQueryResults(mattermostUserID, projectArgument, queryName string, page int) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	// Queries are searched by their name, so only the last part of a path is used for the search
+	queries, _, err := p.Client.GetQueries(project.OrganizationName, project.ProjectName, queryName[strings.LastIndex(queryName, "/")+1:], mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	matchingQueries := findSharedQueries(queries, queryName)
+	switch {
+	case len(matchingQueries) == 0:
+		return fmt.Sprintf(constants.SharedQueryNotFound, queryName, project.ProjectName), nil
+	case len(matchingQueries) > 1:
+		var paths []string
+		for _, query := range matchingQueries {
+			paths = append(paths, fmt.Sprintf("- %s", query.Path))
+		}
+		return fmt.Sprintf(constants.MultipleSharedQueriesWithName, queryName, strings.Join(paths, "\n")), nil
+	}
+
+	query := matchingQueries[0]
+	queryResult, _, err := p.Client.RunSharedQuery(project.OrganizationName, project.ProjectName, query.ID, mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	rows := getSharedQueryRows(queryResult)
+	if len(rows) == 0 {
+		return fmt.Sprintf(constants.NoSharedQueryResults, query.Path), nil
+	}
+
+	pageCount := (len(rows) + constants.SharedQueryPageSize - 1) / constants.SharedQueryPageSize
+	if page < 1 || page > pageCount {
+		return fmt.Sprintf(constants.SharedQueryPageNotFound, page, pageCount), nil
+	}
+
+	start := (page - 1) * constants.SharedQueryPageSize
+	end := start + constants.SharedQueryPageSize
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	// Only the work items of the current page are fetched, a work item can be present more than once in the links of a query
+	var workItemIDs []int
+	isWorkItemIDAdded := map[int]bool{}
+	for _, row := range rows[start:end] {
+		if !isWorkItemIDAdded[row.id] {
+			isWorkItemIDAdded[row.id] = true
+			workItemIDs = append(workItemIDs, row.id)
+		}
+	}
+
+	fields := []string{constants.FieldWorkItemType, constants.FieldTitle, constants.FieldState, constants.FieldAssignedTo}
+	workItems, erroredWorkItemIDs, err := p.getWorkItemsInBatches(project.OrganizationName, project.ProjectName, workItemIDs, fields, mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	workItemsByID := map[int]*serializers.TaskValue{}
+	for _, workItem := range workItems {
+		workItemsByID[workItem.ID] = workItem
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Results of query %q in %s/%s\n", query.Path, project.OrganizationName, project.ProjectName))
+	sb.WriteString("| ID | Type | Title | State | Assigned To |\n")
+	sb.WriteString("| :- | :--- | :---- | :---- | :---------- |\n")
+	for _, row := range rows[start:end] {
+		link := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), row.id)
+		if erroredWorkItemIDs[row.id] {
+			sb.WriteString(fmt.Sprintf("| [%d](%s) |  | %s |  |  |\n", row.id, link, constants.WorkItemFetchFailed))
+			continue
+		}
+
+		workItem, ok := workItemsByID[row.id]
+		if !ok {
+			// The work item can be deleted or not accessible anymore after the query is run
+			sb.WriteString(fmt.Sprintf("| [%d](%s) |  |  |  |  |\n", row.id, link))
+			continue
+		}
+
+		title := workItem.Fields.Title
+		if row.depth > 0 {
+			title = fmt.Sprintf("%s↳ %s", strings.Repeat("· ", row.depth-1), title)
+		}
+		sb.WriteString(fmt.Sprintf("| [%d](%s) | %s | %s | %s | %s |\n", row.id, link, escapeTableCell(workItem.Fields.Type), escapeTableCell(title), escapeTableCell(workItem.Fields.State), escapeTableCell(workItem.Fields.AssignedTo.DisplayName)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nShowing %d-%d of %d work items", start+1, end, len(rows)))
+	if len(rows) >= constants.SharedQueryMaxResults {
+		sb.WriteString(fmt.Sprintf(", only the first %d results of a query are shown", constants.SharedQueryMaxResults))
+	}
+	if page < pageCount {
+		sb.WriteString(fmt.Sprintf(". Use `/%s %s %s %s %s %s %d` to view the next page.", constants.CommandTriggerName, constants.CommandBoards, constants.CommandQuery, projectArgument, queryName, constants.CommandPageFlag, page+1))
+	}
+
+	return sb.String(), nil
+}
+
+// findSharedQueries returns the queries matching the given path or, if no query has that path, the given name.
+// The folders returned by the search are looked into as well.
+func findSharedQueries(queries []*serializers.Query, queryName string) []*serializers.Query {
+	var matchingQueries []*serializers.Query
+	var findQueries func(queries []*serializers.Query) *serializers.Query
+	findQueries = func(queries []*serializers.Query) *serializers.Query {
+		for _, query := range queries {
+			if query == nil {
+				continue
+			}
+
+			if query.IsFolder {
+				if matchingQuery := findQueries(query.Children); matchingQuery != nil {
+					return matchingQuery
+				}
+				continue
+			}
+
+			if strings.EqualFold(query.Path, queryName) {
+				return query
+			}
+
+			if strings.EqualFold(query.Name, queryName) {
+				matchingQueries = append(matchingQueries, query)
+			}
+		}
+
+		return nil
+	}
+
+	if query := findQueries(queries); query != nil {
+		return []*serializers.Query{query}
+	}
+
+	return matchingQueries
+}
+
+// getSharedQueryRows returns the work items of the result of a query in order.
+// The depth of the work items returned by a hierarchical query is found using the work item they are linked from.
+func getSharedQueryRows(queryResult *serializers.WorkItemQueryResult) []*sharedQueryRow {
+	if queryResult == nil {
+		return nil
+	}
+
+	var rows []*sharedQueryRow
+	if queryResult.QueryResultType != constants.QueryResultTypeLink {
+		for _, workItem := range queryResult.WorkItems {
+			if workItem != nil {
+				rows = append(rows, &sharedQueryRow{id: workItem.ID})
+			}
+		}
+		return rows
+	}
+
+	depths := map[int]int{}
+	for _, link := range queryResult.WorkItemRelations {
+		if link == nil || link.Target == nil {
+			continue
+		}
+
+		depth := 0
+		if link.Source != nil {
+			depth = depths[link.Source.ID] + 1
+		}
+		depths[link.Target.ID] = depth
+		rows = append(rows, &sharedQueryRow{id: link.Target.ID, depth: depth})
+	}
+
+	return rows
+}
+
+func escapeTableCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
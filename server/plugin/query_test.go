@@ -0,0 +1,195 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestFindSharedQueries(t *testing.T) {
+	queries := []*serializers.Query{
+		{ID: "mockQueryID-1", Name: "Active Bugs", Path: "Shared Queries/Active Bugs"},
+		{ID: "mockFolderID", Name: "Team", Path: "Shared Queries/Team", IsFolder: true, Children: []*serializers.Query{
+			{ID: "mockQueryID-2", Name: "Active Bugs", Path: "Shared Queries/Team/Active Bugs"},
+		}},
+		{ID: "mockQueryID-3", Name: "Closed Bugs", Path: "My Queries/Closed Bugs"},
+	}
+
+	for _, testCase := range []struct {
+		description string
+		queryName   string
+		expectedIDs []string
+	}{
+		{
+			description: "FindSharedQueries: query is found by its name",
+			queryName:   "closed bugs",
+			expectedIDs: []string{"mockQueryID-3"},
+		},
+		{
+			description: "FindSharedQueries: query nested in a folder is found by its path",
+			queryName:   "Shared Queries/Team/Active Bugs",
+			expectedIDs: []string{"mockQueryID-2"},
+		},
+		{
+			description: "FindSharedQueries: multiple queries with the same name",
+			queryName:   "Active Bugs",
+			expectedIDs: []string{"mockQueryID-1", "mockQueryID-2"},
+		},
+		{
+			description: "FindSharedQueries: folders are not matched",
+			queryName:   "Team",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var ids []string
+			for _, query := range findSharedQueries(queries, testCase.queryName) {
+				ids = append(ids, query.ID)
+			}
+
+			assert.Equal(t, testCase.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGetSharedQueryRows(t *testing.T) {
+	t.Run("GetSharedQueryRows: flat query", func(t *testing.T) {
+		rows := getSharedQueryRows(&serializers.WorkItemQueryResult{
+			QueryResultType: "workItem",
+			WorkItems:       []*serializers.WorkItemReference{{ID: 1}, {ID: 2}},
+		})
+
+		assert.Equal(t, []*sharedQueryRow{{id: 1}, {id: 2}}, rows)
+	})
+
+	t.Run("GetSharedQueryRows: hierarchical query", func(t *testing.T) {
+		rows := getSharedQueryRows(&serializers.WorkItemQueryResult{
+			QueryResultType: constants.QueryResultTypeLink,
+			WorkItemRelations: []*serializers.WorkItemLink{
+				{Target: &serializers.WorkItemReference{ID: 1}},
+				{Rel: "System.LinkTypes.Hierarchy-Forward", Source: &serializers.WorkItemReference{ID: 1}, Target: &serializers.WorkItemReference{ID: 2}},
+				{Rel: "System.LinkTypes.Hierarchy-Forward", Source: &serializers.WorkItemReference{ID: 2}, Target: &serializers.WorkItemReference{ID: 3}},
+				{Target: &serializers.WorkItemReference{ID: 4}},
+			},
+		})
+
+		assert.Equal(t, []*sharedQueryRow{{id: 1}, {id: 2, depth: 1}, {id: 3, depth: 2}, {id: 4}}, rows)
+	})
+
+	t.Run("GetSharedQueryRows: empty result", func(t *testing.T) {
+		assert.Empty(t, getSharedQueryRows(nil))
+	})
+}
+
+func TestGetSharedQueryResults(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	p.setConfiguration(&config.Configuration{AzureDevopsAPIBaseURL: "https://dev.azure.com"})
+
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	query := &serializers.Query{ID: "mockQueryID", Name: "Active Bugs", Path: "Shared Queries/Active Bugs"}
+
+	var references []*serializers.WorkItemReference
+	for id := 1; id <= constants.SharedQueryPageSize+5; id++ {
+		references = append(references, &serializers.WorkItemReference{ID: id})
+	}
+
+	t.Run("GetSharedQueryResults: first page of the results", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Active Bugs", testutils.MockMattermostUserID).Return([]*serializers.Query{query}, http.StatusOK, nil)
+		mockedClient.EXPECT().RunSharedQuery(testutils.MockOrganization, testutils.MockProjectName, "mockQueryID", testutils.MockMattermostUserID).Return(&serializers.WorkItemQueryResult{WorkItems: references}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(
+			func(_, _ string, workItemIDs []int, _ []string, _ string) ([]*serializers.TaskValue, int, error) {
+				require.Len(t, workItemIDs, constants.SharedQueryPageSize)
+				return []*serializers.TaskValue{{
+					ID: 1,
+					Fields: serializers.TaskFieldValue{
+						Type:       "Bug",
+						Title:      "mock | title",
+						State:      "Active",
+						AssignedTo: serializers.TaskUserDetails{DisplayName: "mockUser"},
+					},
+				}}, http.StatusOK, nil
+			})
+
+		message, err := p.getSharedQueryResults(testutils.MockMattermostUserID, testutils.MockProjectName, "Active Bugs", 1)
+
+		assert.NoError(t, err)
+		assert.Contains(t, message, `###### Results of query "Shared Queries/Active Bugs" in mockOrganization/mockProjectName`)
+		assert.Contains(t, message, "| [1](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/1) | Bug | mock \\| title | Active | mockUser |\n")
+		assert.Contains(t, message, fmt.Sprintf("Showing 1-%d of %d work items", constants.SharedQueryPageSize, len(references)))
+		assert.Contains(t, message, "`/azuredevops boards query mockProjectName Active Bugs --page 2`")
+	})
+
+	t.Run("GetSharedQueryResults: last page of the results", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Active Bugs", testutils.MockMattermostUserID).Return([]*serializers.Query{query}, http.StatusOK, nil)
+		mockedClient.EXPECT().RunSharedQuery(testutils.MockOrganization, testutils.MockProjectName, "mockQueryID", testutils.MockMattermostUserID).Return(&serializers.WorkItemQueryResult{WorkItems: references}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusOK, nil)
+
+		message, err := p.getSharedQueryResults(testutils.MockMattermostUserID, testutils.MockProjectName, "Shared Queries/Active Bugs", 2)
+
+		assert.NoError(t, err)
+		assert.Contains(t, message, fmt.Sprintf("Showing %d-%d of %d work items", constants.SharedQueryPageSize+1, len(references), len(references)))
+		assert.NotContains(t, message, constants.CommandPageFlag)
+	})
+
+	t.Run("GetSharedQueryResults: page does not exist", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Active Bugs", testutils.MockMattermostUserID).Return([]*serializers.Query{query}, http.StatusOK, nil)
+		mockedClient.EXPECT().RunSharedQuery(testutils.MockOrganization, testutils.MockProjectName, "mockQueryID", testutils.MockMattermostUserID).Return(&serializers.WorkItemQueryResult{WorkItems: references}, http.StatusOK, nil)
+
+		message, err := p.getSharedQueryResults(testutils.MockMattermostUserID, testutils.MockProjectName, "Active Bugs", 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.SharedQueryPageNotFound, 3, 2), message)
+	})
+
+	t.Run("GetSharedQueryResults: query does not exist", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Closed Bugs", testutils.MockMattermostUserID).Return([]*serializers.Query{query}, http.StatusOK, nil)
+
+		message, err := p.getSharedQueryResults(testutils.MockMattermostUserID, testutils.MockProjectName, "Closed Bugs", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.SharedQueryNotFound, "Closed Bugs", testutils.MockProjectName), message)
+	})
+
+	t.Run("GetSharedQueryResults: query without results", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Active Bugs", testutils.MockMattermostUserID).Return([]*serializers.Query{query}, http.StatusOK, nil)
+		mockedClient.EXPECT().RunSharedQuery(testutils.MockOrganization, testutils.MockProjectName, "mockQueryID", testutils.MockMattermostUserID).Return(&serializers.WorkItemQueryResult{}, http.StatusOK, nil)
+
+		message, err := p.getSharedQueryResults(testutils.MockMattermostUserID, testutils.MockProjectName, "Active Bugs", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.NoSharedQueryResults, query.Path), message)
+	})
+
+	t.Run("GetSharedQueryResults: error in running the query", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetQueries(testutils.MockOrganization, testutils.MockProjectName, "Active Bugs", testutils.MockMattermostUserID).Return([]*serializers.Query{query}, http.StatusOK, nil)
+		mockedClient.EXPECT().RunSharedQuery(testutils.MockOrganization, testutils.MockProjectName, "mockQueryID", testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("error in running the query"))
+
+		_, err := p.getSharedQueryResults(testutils.MockMattermostUserID, testutils.MockProjectName, "Active Bugs", 1)
+
+		assert.Error(t, err)
+	})
+}
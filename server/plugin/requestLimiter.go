@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var errRequestLimiterTimeout = errors.New("timed out waiting for one of the requests in progress to Azure DevOps to complete")
+
+// requestLimiter limits the number of requests made to Azure DevOps at the same time, the requests over the limit wait for a slot
+type requestLimiter struct {
+	lock   sync.Mutex
+	cond   *sync.Cond
+	active int
+}
+
+func newRequestLimiter() *requestLimiter {
+	limiter := &requestLimiter{}
+	limiter.cond = sync.NewCond(&limiter.lock)
+	return limiter
+}
+
+// acquire waits until fewer than limit requests are in progress and takes a slot, there is no limit if it's 0.
+// The limit is read again every time a waiting request is woken up, so that a change in the plugin configuration is applied to the waiting requests as well.
+// It gives up once the request has waited for maxWait.
+func (l *requestLimiter) acquire(getLimit func() int, maxWait time.Duration) error {
+	deadline := time.Now().Add(maxWait)
+	// The waiting request is woken up at the deadline even if no request completes meanwhile
+	timer := time.AfterFunc(maxWait, l.cond.Broadcast)
+	defer timer.Stop()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for {
+		if limit := getLimit(); limit <= 0 || l.active < limit {
+			l.active++
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return errRequestLimiterTimeout
+		}
+
+		l.cond.Wait()
+	}
+}
+
+func (l *requestLimiter) release() {
+	l.lock.Lock()
+	l.active--
+	l.lock.Unlock()
+
+	l.cond.Broadcast()
+}
@@ -0,0 +1,59 @@
+package plugin
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	t.Run("RequestLimiter: limit changed meanwhile is applied to the waiting request", func(t *testing.T) {
+		limiter := newRequestLimiter()
+		limit := int32(2)
+		getLimit := func() int { return int(atomic.LoadInt32(&limit)) }
+		assert.NoError(t, limiter.acquire(getLimit, time.Minute))
+		assert.NoError(t, limiter.acquire(getLimit, time.Minute))
+
+		acquired := make(chan error, 1)
+		go func() {
+			acquired <- limiter.acquire(getLimit, time.Minute)
+		}()
+
+		atomic.StoreInt32(&limit, 1)
+		limiter.release()
+		select {
+		case <-acquired:
+			assert.Fail(t, "request is not limited by the changed limit")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		limiter.release()
+		select {
+		case err := <-acquired:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "request is still waiting")
+		}
+	})
+
+	t.Run("RequestLimiter: request gives up after waiting for too long", func(t *testing.T) {
+		limiter := newRequestLimiter()
+		getLimit := func() int { return 1 }
+		assert.NoError(t, limiter.acquire(getLimit, time.Minute))
+
+		assert.Equal(t, errRequestLimiterTimeout, limiter.acquire(getLimit, 20*time.Millisecond))
+		assert.Equal(t, 1, limiter.active)
+	})
+
+	t.Run("RequestLimiter: requests are not limited", func(t *testing.T) {
+		limiter := newRequestLimiter()
+		getLimit := func() int { return 0 }
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, limiter.acquire(getLimit, time.Millisecond))
+		}
+
+		assert.Equal(t, 3, limiter.active)
+	})
+}
@@ -0,0 +1,227 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// userState contains everything the plugin stores for a user
+type userState struct {
+	subscriptions     []*serializers.SubscriptionDetails
+	projects          []serializers.ProjectDetails
+	templates         []*serializers.SubscriptionTemplate
+	azureDevopsUserID string
+}
+
+func (s *userState) isEmpty() bool {
+	return len(s.subscriptions) == 0 && len(s.projects) == 0 && len(s.templates) == 0 && s.azureDevopsUserID == ""
+}
+
+// String lists the parts of the state of a user as a Markdown list
+func (s *userState) String() string {
+	var sb strings.Builder
+	if len(s.subscriptions) > 0 {
+		sb.WriteString(fmt.Sprintf("* %d subscription(s) along with their webhooks in Azure DevOps\n", len(s.subscriptions)))
+	}
+	if len(s.projects) > 0 {
+		sb.WriteString(fmt.Sprintf("* %d linked project(s)\n", len(s.projects)))
+	}
+	if len(s.templates) > 0 {
+		sb.WriteString(fmt.Sprintf("* %d subscription template(s)\n", len(s.templates)))
+	}
+	if s.azureDevopsUserID != "" {
+		sb.WriteString("* The connection of your Azure DevOps account\n")
+	}
+
+	return sb.String()
+}
+
+func (p *Plugin) getUserState(mattermostUserID string) (*userState, error) {
+	subscriptions, err := p.Store.GetAllSubscriptions(mattermostUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, constants.FetchSubscriptionListError)
+	}
+
+	projects, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	templates, err := p.Store.GetSubscriptionTemplates(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userState{
+		subscriptions:     subscriptions,
+		projects:          projects,
+		templates:         templates,
+		azureDevopsUserID: azureDevopsUserID,
+	}, nil
+}
+
+// getResetUserConfirmation returns the buttons confirming the reset of the plugin state of a user, listing what will be removed
+func (p *Plugin) getResetUserConfirmation(mattermostUserID string) (*model.SlackAttachment, string, error) {
+	state, err := p.getUserState(mattermostUserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if state.isEmpty() {
+		return nil, constants.NothingToReset, nil
+	}
+
+	actionURL := fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathResetUser)
+	return &model.SlackAttachment{
+		Title: "Reset your Azure DevOps plugin state",
+		Text:  fmt.Sprintf("The following will be removed:\n%s\n%s", state, constants.ResetUserConfirmation),
+		Color: constants.IconColorBoards,
+		Actions: []*model.PostAction{
+			{
+				Id:    constants.ResetUserActionConfirm,
+				Type:  model.POST_ACTION_TYPE_BUTTON,
+				Name:  "Reset",
+				Style: "danger",
+				Integration: &model.PostActionIntegration{
+					URL:     actionURL,
+					Context: map[string]interface{}{constants.ResetUserContextAction: constants.ResetUserActionConfirm},
+				},
+			},
+			{
+				Id:   constants.ResetUserActionCancel,
+				Type: model.POST_ACTION_TYPE_BUTTON,
+				Name: "Cancel",
+				Integration: &model.PostActionIntegration{
+					URL:     actionURL,
+					Context: map[string]interface{}{constants.ResetUserContextAction: constants.ResetUserActionCancel},
+				},
+			},
+		},
+	}, "", nil
+}
+
+// handleResetUser handles the buttons confirming or canceling the reset and replaces the confirmation with the result.
+// The user is taken from the request and never from the context of the button, so that a user can only reset their own state.
+func (p *Plugin) handleResetUser(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	message := constants.ResetUserCanceled
+	if action, _ := postActionIntegrationRequest.Context[constants.ResetUserContextAction].(string); action == constants.ResetUserActionConfirm {
+		resetMessage, err := p.resetUser(mattermostUserID)
+		if err != nil {
+			p.API.LogError(constants.ErrorResetUser, "Error", err.Error())
+			resetMessage = constants.GenericErrorMessage
+		}
+		message = resetMessage
+	}
+
+	p.returnPostActionIntegrationResponse(w, &model.PostActionIntegrationResponse{
+		Update: &model.Post{
+			Id:        postActionIntegrationRequest.PostId,
+			UserId:    p.botUserID,
+			ChannelId: postActionIntegrationRequest.ChannelId,
+			Message:   message,
+		},
+	})
+}
+
+// resetUser removes everything the plugin stores for a user and reports what was removed.
+// The subscriptions are deleted first, as deleting their webhooks in Azure DevOps needs the token of the user.
+// A subscription is removed from the plugin even if its webhook can't be deleted, as its notifications are rejected once its secret is deleted.
+func (p *Plugin) resetUser(mattermostUserID string) (string, error) {
+	state, err := p.getUserState(mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	if state.isEmpty() {
+		return constants.NothingToReset, nil
+	}
+
+	var allSubscriptions []*serializers.SubscriptionDetails
+	if len(state.subscriptions) > 0 {
+		if allSubscriptions, err = p.Store.GetAllSubscriptions(""); err != nil {
+			return "", errors.Wrap(err, constants.FetchSubscriptionListError)
+		}
+	}
+
+	var webhooksNotDeleted []string
+	for _, subscription := range state.subscriptions {
+		// The subscriptions shared with other users are kept on Azure DevOps along with their webhook secret
+		if isSubscriptionShared(allSubscriptions, subscription) {
+			if err := p.Store.DeleteSubscription(subscription); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		if statusCode, err := p.Client.DeleteSubscription(subscription.OrganizationName, subscription.SubscriptionID, mattermostUserID); err != nil && statusCode != http.StatusNotFound {
+			p.API.LogDebug("Error in deleting the webhook of the subscription", "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+			webhooksNotDeleted = append(webhooksNotDeleted, fmt.Sprintf("`%s`", subscription.SubscriptionID))
+		}
+
+		if err := p.Store.DeleteSubscription(subscription); err != nil {
+			return "", err
+		}
+
+		if err := p.Store.DeleteSubscriptionAndChannelIDMap(subscription.SubscriptionID); err != nil {
+			return "", err
+		}
+
+		if err := p.Store.DeleteLastNotification(subscription.SubscriptionID); err != nil {
+			p.API.LogDebug("Error in deleting the last notification of the subscription", "Error", err.Error())
+		}
+	}
+
+	for _, project := range state.projects {
+		project := project
+		if err := p.Store.DeleteProject(&project); err != nil {
+			return "", err
+		}
+	}
+
+	for _, template := range state.templates {
+		if err := p.Store.DeleteSubscriptionTemplate(mattermostUserID, template.Name); err != nil {
+			return "", err
+		}
+	}
+
+	if state.azureDevopsUserID != "" {
+		if _, err := p.Store.DeleteUser(mattermostUserID); err != nil {
+			return "", err
+		}
+	}
+
+	if len(state.subscriptions) > 0 {
+		p.API.PublishWebSocketEvent(constants.WSEventSubscriptionDeleted, nil, &model.WebsocketBroadcast{UserId: mattermostUserID})
+	}
+	if state.azureDevopsUserID != "" {
+		p.API.PublishWebSocketEvent(constants.WSEventDisconnect, nil, &model.WebsocketBroadcast{UserId: mattermostUserID})
+	}
+
+	message := fmt.Sprintf("%s The following was removed:\n%s", constants.ResetUserCompleted, state)
+	if len(webhooksNotDeleted) > 0 {
+		message += "\n" + fmt.Sprintf(constants.ResetUserWebhooksNotDeleted, strings.Join(webhooksNotDeleted, ", "))
+	}
+
+	return message, nil
+}
@@ -0,0 +1,199 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func expectEmptyUserState(mockedStore *mocks.MockKVStore, mattermostUserID string) {
+	mockedStore.EXPECT().GetAllSubscriptions(mattermostUserID).Return(nil, nil)
+	mockedStore.EXPECT().GetAllProjects(mattermostUserID).Return(nil, nil)
+	mockedStore.EXPECT().GetSubscriptionTemplates(mattermostUserID).Return(nil, nil)
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID).Return("", nil)
+}
+
+func TestGetResetUserConfirmation(t *testing.T) {
+	t.Run("GetResetUserConfirmation: state to be removed is listed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionDetails{{SubscriptionID: testutils.MockSubscriptionID}}, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{{ProjectID: testutils.MockProjectID}, {ProjectID: "mockOtherProjectID"}}, nil)
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return(nil, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+
+		attachment, message, err := p.getResetUserConfirmation(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, message)
+		require.NotNil(t, attachment)
+		assert.Equal(t, "The following will be removed:\n"+
+			"* 1 subscription(s) along with their webhooks in Azure DevOps\n"+
+			"* 2 linked project(s)\n"+
+			"* The connection of your Azure DevOps account\n"+
+			"\n"+constants.ResetUserConfirmation, attachment.Text)
+		require.Len(t, attachment.Actions, 2)
+		assert.Equal(t, map[string]interface{}{constants.ResetUserContextAction: constants.ResetUserActionConfirm}, attachment.Actions[0].Integration.Context)
+	})
+
+	t.Run("GetResetUserConfirmation: nothing to reset", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+		expectEmptyUserState(mockedStore, testutils.MockMattermostUserID)
+
+		attachment, message, err := p.getResetUserConfirmation(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, attachment)
+		assert.Equal(t, constants.NothingToReset, message)
+	})
+}
+
+func TestResetUser(t *testing.T) {
+	t.Run("ResetUser: the whole state of the user is removed", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+		subscriptions := []*serializers.SubscriptionDetails{
+			{SubscriptionID: "mockSubscriptionID1", OrganizationName: testutils.MockOrganization, MattermostUserID: testutils.MockMattermostUserID},
+			{SubscriptionID: "mockSubscriptionID2", OrganizationName: testutils.MockOrganization, MattermostUserID: testutils.MockMattermostUserID},
+			{SubscriptionID: "mockSubscriptionID3", OrganizationName: testutils.MockOrganization, MattermostUserID: testutils.MockMattermostUserID},
+		}
+		project := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, ProjectID: testutils.MockProjectID, OrganizationName: testutils.MockOrganization}
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptions, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionTemplate{{Name: "mockTemplate"}}, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptions, nil)
+
+		// The webhook of the second subscription was already deleted in Azure DevOps, and the one of the third can't be deleted
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID1", testutils.MockMattermostUserID).Return(http.StatusNoContent, nil)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID2", testutils.MockMattermostUserID).Return(http.StatusNotFound, errors.New("subscription not found"))
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID3", testutils.MockMattermostUserID).Return(http.StatusForbidden, errors.New("forbidden"))
+		mockAPI.On("LogDebug", mock.AnythingOfType("string"), "SubscriptionID", "mockSubscriptionID3", "Error", "forbidden")
+		for _, subscription := range subscriptions {
+			mockedStore.EXPECT().DeleteSubscription(subscription).Return(nil)
+			mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(subscription.SubscriptionID).Return(nil)
+			mockedStore.EXPECT().DeleteLastNotification(subscription.SubscriptionID).Return(nil)
+		}
+		mockedStore.EXPECT().DeleteProject(&project).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionTemplate(testutils.MockMattermostUserID, "mockTemplate").Return(nil)
+		mockedStore.EXPECT().DeleteUser(testutils.MockMattermostUserID).Return(true, nil)
+		mockAPI.On("PublishWebSocketEvent", constants.WSEventSubscriptionDeleted, mock.Anything, &model.WebsocketBroadcast{UserId: testutils.MockMattermostUserID}).Once()
+		mockAPI.On("PublishWebSocketEvent", constants.WSEventDisconnect, mock.Anything, &model.WebsocketBroadcast{UserId: testutils.MockMattermostUserID}).Once()
+
+		message, err := p.resetUser(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ResetUserCompleted+" The following was removed:\n"+
+			"* 3 subscription(s) along with their webhooks in Azure DevOps\n"+
+			"* 1 linked project(s)\n"+
+			"* 1 subscription template(s)\n"+
+			"* The connection of your Azure DevOps account\n"+
+			"\nThe webhooks of subscription(s) `mockSubscriptionID3` could not be deleted in Azure DevOps, please delete them from the service hooks of their projects.", message)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("ResetUser: resetting again does not remove anything", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, mocks.NewMockClient(mockCtrl))
+		expectEmptyUserState(mockedStore, testutils.MockMattermostUserID)
+
+		message, err := p.resetUser(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.NothingToReset, message)
+	})
+
+	t.Run("ResetUser: error in deleting a linked project", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+		project := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, ProjectID: testutils.MockProjectID}
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(nil, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return(nil, nil)
+		mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("", nil)
+		mockedStore.EXPECT().DeleteProject(&project).Return(errors.New("reached write attempt limit"))
+
+		message, err := p.resetUser(testutils.MockMattermostUserID)
+
+		assert.EqualError(t, err, "reached write attempt limit")
+		assert.Empty(t, message)
+	})
+}
+
+func TestHandleResetUser(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		action          string
+		expectedMessage string
+	}{
+		{
+			description:     "HandleResetUser: state of the user clicking the button is reset",
+			action:          constants.ResetUserActionConfirm,
+			expectedMessage: constants.NothingToReset,
+		},
+		{
+			description:     "HandleResetUser: reset is canceled",
+			action:          constants.ResetUserActionCancel,
+			expectedMessage: constants.ResetUserCanceled,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+			if testCase.action == constants.ResetUserActionConfirm {
+				expectEmptyUserState(mockedStore, testutils.MockMattermostUserID)
+			}
+
+			// A user ID in the context is ignored, the state of the user of the request is reset
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{
+				PostId: "mockPostID",
+				Context: map[string]interface{}{
+					constants.ResetUserContextAction: testCase.action,
+					"mattermostUserID":               "mockOtherMattermostUserID",
+				},
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, constants.PathResetUser, bytes.NewBuffer(body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+
+			w := httptest.NewRecorder()
+			p.handleResetUser(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var response *model.PostActionIntegrationResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			require.NotNil(t, response.Update)
+			assert.Equal(t, "mockPostID", response.Update.Id)
+			assert.Equal(t, testCase.expectedMessage, response.Update.Message)
+		})
+	}
+}
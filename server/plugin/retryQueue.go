@@ -0,0 +1,245 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+var ErrQueuedForRetry = errors.New("azure devops is currently unavailable, the request is queued and will be retried automatically")
+
+// isRetriableStatusCode checks if a failed Azure DevOps API call can succeed when it's retried later.
+// Network errors and timeouts are also reported with the status code 500 by the client.
+func isRetriableStatusCode(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError
+}
+
+// getRetryBackoff returns the wait before the next attempt, doubling with every failed attempt
+func getRetryBackoff(attempts int) time.Duration {
+	return constants.RetryQueueInitialBackoff * time.Duration(1<<uint(attempts))
+}
+
+// enqueueRetryOperation adds a failed operation to the retry queue.
+// It returns false if the retry queue is disabled, the failure is not transient or the operation could not be queued.
+func (p *Plugin) enqueueRetryOperation(operationType, mattermostUserID string, statusCode int, payload interface{}) bool {
+	if !p.getConfiguration().EnableRetryQueue || !isRetriableStatusCode(statusCode) {
+		return false
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		p.API.LogError("Error in marshaling the retry operation payload", "Error", err.Error())
+		return false
+	}
+
+	now := time.Now()
+	operation := &serializers.RetryOperation{
+		ID:               uuid.New().String(),
+		Type:             operationType,
+		MattermostUserID: mattermostUserID,
+		Payload:          payloadBytes,
+		CreatedAt:        now.Unix(),
+		NextAttemptAt:    now.Add(constants.RetryQueueInitialBackoff).Unix(),
+	}
+
+	if err := p.Store.AddRetryOperation(operation); err != nil {
+		p.API.LogError("Error in adding the operation to the retry queue", "Error", err.Error())
+		return false
+	}
+
+	if _, DMErr := p.DM(mattermostUserID, constants.RetryOperationQueued, false, p.getRetryOperationDescription(operation)); DMErr != nil {
+		p.API.LogError("Failed to DM", "Error", DMErr.Error())
+	}
+
+	return true
+}
+
+// processRetryQueue is run by the scheduled job and retries the queued operations which are due
+func (p *Plugin) processRetryQueue() {
+	operations, err := p.Store.GetRetryOperations()
+	if err != nil {
+		p.API.LogError("Error in fetching the retry queue", "Error", err.Error())
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, operation := range operations {
+		if operation.NextAttemptAt > now {
+			continue
+		}
+
+		p.retryOperation(operation)
+	}
+}
+
+// retryOperation makes a new attempt for a queued operation and notifies the user once it succeeds or is given up
+func (p *Plugin) retryOperation(operation *serializers.RetryOperation) {
+	var message string
+	var retriable bool
+	var err error
+	switch {
+	case !p.isUserConnected(operation.MattermostUserID):
+		err = errors.New(constants.SessionExpiredMessage)
+	case operation.Type == constants.RetryOperationTypeCreateTask:
+		message, retriable, err = p.retryCreateTask(operation)
+	case operation.Type == constants.RetryOperationTypeCreateSubscription:
+		message, retriable, err = p.retryCreateSubscription(operation)
+	default:
+		err = fmt.Errorf("unknown operation type %q", operation.Type)
+	}
+
+	operation.Attempts++
+	if err == nil {
+		p.removeRetryOperation(operation)
+		if _, DMErr := p.DM(operation.MattermostUserID, "%s", true, message); DMErr != nil {
+			p.API.LogError("Failed to DM", "Error", DMErr.Error())
+		}
+		return
+	}
+
+	p.API.LogError("Error in retrying the operation", "ID", operation.ID, "Type", operation.Type, "Error", err.Error())
+	if retriable && operation.Attempts < constants.RetryQueueMaxAttempts {
+		operation.LastError = err.Error()
+		operation.NextAttemptAt = time.Now().Add(getRetryBackoff(operation.Attempts)).Unix()
+		if updateErr := p.Store.UpdateRetryOperation(operation); updateErr != nil {
+			p.API.LogError("Error in updating the retry operation", "Error", updateErr.Error())
+		}
+		return
+	}
+
+	p.removeRetryOperation(operation)
+	if _, DMErr := p.DM(operation.MattermostUserID, constants.RetryOperationFailed, false, p.getRetryOperationDescription(operation), operation.Attempts, err.Error()); DMErr != nil {
+		p.API.LogError("Failed to DM", "Error", DMErr.Error())
+	}
+}
+
+func (p *Plugin) removeRetryOperation(operation *serializers.RetryOperation) {
+	if err := p.Store.DeleteRetryOperation(operation.ID); err != nil {
+		p.API.LogError("Error in deleting the retry operation", "Error", err.Error())
+	}
+}
+
+// retryCreateTask creates the work item of a queued operation.
+// A work item matching the operation which was created by the user after the operation was queued is reused,
+// since the failed request might have created it before the error occurred.
+func (p *Plugin) retryCreateTask(operation *serializers.RetryOperation) (string, bool, error) {
+	var body *serializers.CreateTaskRequestPayload
+	if err := json.Unmarshal(operation.Payload, &body); err != nil {
+		return "", false, err
+	}
+
+	createdSince := time.Unix(operation.CreatedAt, 0).Add(-constants.RetryQueueInitialBackoff).UTC().Format(time.RFC3339)
+	query := fmt.Sprintf(constants.QueryWorkItemCreatedByMe, escapeWIQLString(body.Type), escapeWIQLString(body.Fields.Title), createdSince)
+	workItems, statusCode, err := p.Client.QueryWorkItems(body.Organization, body.Project, query, operation.MattermostUserID)
+	if err != nil {
+		return "", isRetriableStatusCode(statusCode), err
+	}
+
+	var task *serializers.TaskValue
+	if len(workItems) > 0 {
+		task, statusCode, err = p.Client.GetTask(body.Organization, strconv.Itoa(workItems[0].ID), body.Project, operation.MattermostUserID)
+	} else {
+		task, statusCode, err = p.Client.CreateTask(body, operation.MattermostUserID)
+	}
+	if err != nil {
+		return "", isRetriableStatusCode(statusCode), err
+	}
+
+	message := fmt.Sprintf(constants.RetryOperationSucceeded, p.getRetryOperationDescription(operation))
+	workItemURL := fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), body.Organization, url.PathEscape(body.Project), task.ID)
+	message = fmt.Sprintf("%s\n%s", message, fmt.Sprintf(constants.CreatedTask, task.ID, task.Fields.Title, workItemURL, task.Fields.Type, task.Fields.CreatedBy.DisplayName))
+	return message, false, nil
+}
+
+// retryCreateSubscription creates the subscription of a queued operation unless the same subscription was created in the meantime
+func (p *Plugin) retryCreateSubscription(operation *serializers.RetryOperation) (string, bool, error) {
+	var body *serializers.CreateSubscriptionRequestPayload
+	if err := json.Unmarshal(operation.Payload, &body); err != nil {
+		return "", false, err
+	}
+
+	projectList, err := p.Store.GetAllProjects(operation.MattermostUserID)
+	if err != nil {
+		return "", true, err
+	}
+
+	project, isProjectLinked := p.IsProjectLinked(projectList, serializers.ProjectDetails{OrganizationName: body.Organization, ProjectName: body.Project})
+	if !isProjectLinked {
+		return "", false, errors.New(constants.ProjectNotLinked)
+	}
+
+	subscriptionList, err := p.Store.GetAllSubscriptions(operation.MattermostUserID)
+	if err != nil {
+		return "", true, err
+	}
+
+	message := fmt.Sprintf(constants.RetryOperationSucceeded, p.getRetryOperationDescription(operation))
+	if _, isSubscriptionPresent := p.IsSubscriptionPresent(subscriptionList, getSubscriptionDetailsFromPayload(body)); isSubscriptionPresent {
+		return message, false, nil
+	}
+
+	// Nothing is created on Azure DevOps for a shared subscription, so it can be retried if storing it fails
+	sharedSubscription, _, err := p.shareExistingSubscription(operation.MattermostUserID, body, project)
+	if err != nil {
+		return "", true, err
+	}
+	if sharedSubscription != nil {
+		return message, false, nil
+	}
+
+	uniqueWebhookSecret := uuid.New().String()
+	subscription, statusCode, err := p.Client.CreateSubscription(body, project, body.ChannelID, p.GetPluginURL(), operation.MattermostUserID, uniqueWebhookSecret)
+	if err != nil {
+		return "", isRetriableStatusCode(statusCode), err
+	}
+
+	// The subscription is already created on Azure DevOps, so it must not be created again if storing it fails
+	if _, err := p.storeCreatedSubscription(operation.MattermostUserID, body, project, subscription, uniqueWebhookSecret); err != nil {
+		return "", false, err
+	}
+
+	return message, false, nil
+}
+
+// isUserConnected checks if the user is still connected, as the queued operations of a disconnected user can't succeed
+func (p *Plugin) isUserConnected(mattermostUserID string) bool {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return false
+	}
+
+	user, err := p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID)
+	return err == nil && user != nil && user.AccessToken != ""
+}
+
+func (p *Plugin) getRetryOperationDescription(operation *serializers.RetryOperation) string {
+	switch operation.Type {
+	case constants.RetryOperationTypeCreateTask:
+		var body *serializers.CreateTaskRequestPayload
+		if err := json.Unmarshal(operation.Payload, &body); err == nil && body != nil {
+			return fmt.Sprintf(constants.RetryOperationCreateTask, body.Fields.Title)
+		}
+	case constants.RetryOperationTypeCreateSubscription:
+		var body *serializers.CreateSubscriptionRequestPayload
+		if err := json.Unmarshal(operation.Payload, &body); err == nil && body != nil {
+			return fmt.Sprintf(constants.RetryOperationCreateSubscription, body.EventType, body.Project)
+		}
+	}
+
+	return operation.Type
+}
+
+// escapeWIQLString escapes a value to be used inside a single quoted string of a WIQL query
+func escapeWIQLString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
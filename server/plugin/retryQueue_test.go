@@ -0,0 +1,225 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getMockCreateTaskRetryOperation(t *testing.T, attempts int) *serializers.RetryOperation {
+	payload, err := json.Marshal(&serializers.CreateTaskRequestPayload{
+		Organization: testutils.MockOrganization,
+		Project:      testutils.MockProjectName,
+		Type:         "Task",
+		Fields:       serializers.CreateTaskFieldValue{Title: "mockTitle's"},
+	})
+	require.NoError(t, err)
+
+	return &serializers.RetryOperation{
+		ID:               "mockRetryOperationID",
+		Type:             constants.RetryOperationTypeCreateTask,
+		MattermostUserID: testutils.MockMattermostUserID,
+		Payload:          payload,
+		Attempts:         attempts,
+		CreatedAt:        time.Now().Unix(),
+	}
+}
+
+func TestEnqueueRetryOperation(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+
+	var DMs []string
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(_ *Plugin, _, format string, _ bool, _ ...interface{}) (string, error) {
+		DMs = append(DMs, format)
+		return "", nil
+	})
+
+	payload := &serializers.CreateTaskRequestPayload{Fields: serializers.CreateTaskFieldValue{Title: "mockTitle"}}
+	for _, testCase := range []struct {
+		description      string
+		enableRetryQueue bool
+		statusCode       int
+		addErr           error
+		expectAdd        bool
+		expectedResult   bool
+	}{
+		{
+			description:      "EnqueueRetryOperation: operation is queued",
+			enableRetryQueue: true,
+			statusCode:       http.StatusServiceUnavailable,
+			expectAdd:        true,
+			expectedResult:   true,
+		},
+		{
+			description:      "EnqueueRetryOperation: retry queue is disabled",
+			enableRetryQueue: false,
+			statusCode:       http.StatusServiceUnavailable,
+		},
+		{
+			description:      "EnqueueRetryOperation: failure is not transient",
+			enableRetryQueue: true,
+			statusCode:       http.StatusBadRequest,
+		},
+		{
+			description:      "EnqueueRetryOperation: retry queue is full",
+			enableRetryQueue: true,
+			statusCode:       http.StatusInternalServerError,
+			addErr:           store.ErrRetryQueueFull,
+			expectAdd:        true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			DMs = nil
+			p.setConfiguration(&config.Configuration{EnableRetryQueue: testCase.enableRetryQueue})
+			if testCase.expectAdd {
+				mockedStore.EXPECT().AddRetryOperation(gomock.Any()).DoAndReturn(func(operation *serializers.RetryOperation) error {
+					assert.Equal(t, constants.RetryOperationTypeCreateTask, operation.Type)
+					assert.Equal(t, testutils.MockMattermostUserID, operation.MattermostUserID)
+					assert.Equal(t, 0, operation.Attempts)
+					assert.Greater(t, operation.NextAttemptAt, operation.CreatedAt)
+					return testCase.addErr
+				})
+			}
+
+			result := p.enqueueRetryOperation(constants.RetryOperationTypeCreateTask, testutils.MockMattermostUserID, testCase.statusCode, payload)
+
+			assert.Equal(t, testCase.expectedResult, result)
+			if testCase.expectedResult {
+				assert.Equal(t, []string{constants.RetryOperationQueued}, DMs)
+			} else {
+				assert.Empty(t, DMs)
+			}
+		})
+	}
+}
+
+func TestRetryOperation(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 7)...)
+
+	var DMs []string
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(_ *Plugin, _, format string, _ bool, args ...interface{}) (string, error) {
+		DMs = append(DMs, fmt.Sprintf(format, args...))
+		return "", nil
+	})
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil).AnyTimes()
+	mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{AccessToken: "mockAccessToken"}, nil).AnyTimes()
+
+	task := &serializers.TaskValue{ID: 1, Fields: serializers.TaskFieldValue{Title: "mockTitle's", Type: "Task"}}
+
+	t.Run("RetryOperation: work item is created and the user is notified", func(t *testing.T) {
+		DMs = nil
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(
+			func(_, _, query, _ string) ([]*serializers.WorkItemReference, int, error) {
+				assert.Contains(t, query, "[System.Title] = 'mockTitle''s'")
+				return nil, http.StatusOK, nil
+			})
+		mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(task, http.StatusOK, nil)
+		mockedStore.EXPECT().DeleteRetryOperation("mockRetryOperationID").Return(nil)
+
+		p.retryOperation(getMockCreateTaskRetryOperation(t, 0))
+
+		require.Len(t, DMs, 1)
+		assert.Contains(t, DMs[0], `Work item [#1: "mockTitle's"](https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/1)`)
+	})
+
+	t.Run("RetryOperation: work item created by the failed request is not created again", func(t *testing.T) {
+		DMs = nil
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).Return([]*serializers.WorkItemReference{{ID: 1}}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetTask(testutils.MockOrganization, "1", testutils.MockProjectName, testutils.MockMattermostUserID).Return(task, http.StatusOK, nil)
+		mockedStore.EXPECT().DeleteRetryOperation("mockRetryOperationID").Return(nil)
+
+		p.retryOperation(getMockCreateTaskRetryOperation(t, 0))
+
+		require.Len(t, DMs, 1)
+		assert.Contains(t, DMs[0], `Work item [#1: "mockTitle's"]`)
+	})
+
+	t.Run("RetryOperation: operation is rescheduled on a transient failure", func(t *testing.T) {
+		DMs = nil
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("error in querying the work items"))
+		mockedStore.EXPECT().UpdateRetryOperation(gomock.Any()).DoAndReturn(func(operation *serializers.RetryOperation) error {
+			assert.Equal(t, 2, operation.Attempts)
+			assert.Equal(t, "error in querying the work items", operation.LastError)
+			assert.GreaterOrEqual(t, operation.NextAttemptAt, time.Now().Add(getRetryBackoff(2)).Unix()-1)
+			return nil
+		})
+
+		p.retryOperation(getMockCreateTaskRetryOperation(t, 1))
+
+		assert.Empty(t, DMs)
+	})
+
+	t.Run("RetryOperation: operation is given up after the maximum attempts", func(t *testing.T) {
+		DMs = nil
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusOK, nil)
+		mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusBadGateway, errors.New("error in creating the work item"))
+		mockedStore.EXPECT().DeleteRetryOperation("mockRetryOperationID").Return(nil)
+
+		p.retryOperation(getMockCreateTaskRetryOperation(t, constants.RetryQueueMaxAttempts-1))
+
+		assert.Equal(t, []string{fmt.Sprintf(constants.RetryOperationFailed, `create the work item "mockTitle's"`, constants.RetryQueueMaxAttempts, "error in creating the work item")}, DMs)
+	})
+
+	t.Run("RetryOperation: operation is given up on a permanent failure", func(t *testing.T) {
+		DMs = nil
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusOK, nil)
+		mockedClient.EXPECT().CreateTask(gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusBadRequest, errors.New("error in creating the work item"))
+		mockedStore.EXPECT().DeleteRetryOperation("mockRetryOperationID").Return(nil)
+
+		p.retryOperation(getMockCreateTaskRetryOperation(t, 0))
+
+		assert.Equal(t, []string{fmt.Sprintf(constants.RetryOperationFailed, `create the work item "mockTitle's"`, 1, "error in creating the work item")}, DMs)
+	})
+}
+
+func TestProcessRetryQueue(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 7)...)
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(*Plugin, string, string, bool, ...interface{}) (string, error) {
+		return "", nil
+	})
+
+	// Only the due operation is attempted, which is given up and removed as its user is not connected anymore
+	now := time.Now()
+	mockedStore.EXPECT().GetRetryOperations().Return([]*serializers.RetryOperation{
+		{ID: "mockDueOperationID", MattermostUserID: testutils.MockMattermostUserID, NextAttemptAt: now.Add(-time.Minute).Unix()},
+		{ID: "mockPendingOperationID", MattermostUserID: testutils.MockMattermostUserID, NextAttemptAt: now.Add(time.Hour).Unix()},
+	}, nil)
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("", ErrNotFound)
+	mockedStore.EXPECT().DeleteRetryOperation("mockDueOperationID").Return(nil)
+
+	p.processRetryQueue()
+}
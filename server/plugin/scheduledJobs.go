@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// jobWaitInterval returns how long a scheduled job waits before running again, lastFinished is zero if the job never ran
+type jobWaitInterval func(now, lastFinished time.Time) time.Duration
+
+// scheduledJob runs a function periodically on one server of the cluster at a time.
+// The jobs of mattermost-plugin-api's cluster package need the plugin API of the server v6,
+// so every server times the job in its own goroutine and the runs are serialized by a lock in the KV store.
+type scheduledJob struct {
+	plugin       *Plugin
+	key          string
+	waitInterval jobWaitInterval
+	callback     func()
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// waitForInterval makes a job run once per interval, counted from the end of its previous run
+func waitForInterval(interval time.Duration) jobWaitInterval {
+	return func(now, lastFinished time.Time) time.Duration {
+		if sinceLastFinished := now.Sub(lastFinished); sinceLastFinished < interval {
+			return interval - sinceLastFinished
+		}
+
+		return 0
+	}
+}
+
+func (p *Plugin) scheduleJob(key string, waitInterval jobWaitInterval, callback func()) *scheduledJob {
+	job := &scheduledJob{
+		plugin:       p,
+		key:          key,
+		waitInterval: waitInterval,
+		callback:     callback,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	go job.run()
+	return job
+}
+
+func (j *scheduledJob) run() {
+	defer close(j.done)
+
+	var wait time.Duration
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-j.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		wait = j.runIfDue()
+	}
+}
+
+// runIfDue runs the job if its interval is over and no other server is running it, and returns how long to wait before checking it again.
+// The last run is read once the job is locked, as another server may have run it in the meantime.
+func (j *scheduledJob) runIfDue() time.Duration {
+	p := j.plugin
+	isLocked, err := p.Store.LockJob(j.key)
+	if err != nil {
+		p.API.LogError(constants.ErrorLockScheduledJob, "Job", j.key, "Error", err.Error())
+		return constants.ScheduledJobLockRetry
+	}
+
+	if !isLocked {
+		return constants.ScheduledJobLockRetry
+	}
+
+	defer func() {
+		if err := p.Store.UnlockJob(j.key); err != nil {
+			p.API.LogError(constants.ErrorUnlockScheduledJob, "Job", j.key, "Error", err.Error())
+		}
+	}()
+
+	lastFinished, err := p.Store.GetJobLastFinished(j.key)
+	if err != nil {
+		p.API.LogError(constants.ErrorScheduledJobLastFinished, "Job", j.key, "Error", err.Error())
+		return constants.ScheduledJobLockRetry
+	}
+
+	if wait := j.waitInterval(time.Now(), lastFinished); wait > 0 {
+		return wait
+	}
+
+	j.callback()
+
+	lastFinished = time.Now()
+	if err := p.Store.StoreJobLastFinished(j.key, lastFinished); err != nil {
+		p.API.LogError(constants.ErrorScheduledJobLastFinished, "Job", j.key, "Error", err.Error())
+	}
+
+	return j.waitInterval(time.Now(), lastFinished)
+}
+
+// Close stops the job on this server, waiting for its current run to finish
+func (j *scheduledJob) Close() {
+	j.stopOnce.Do(func() {
+		close(j.stop)
+	})
+	<-j.done
+}
@@ -0,0 +1,109 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestWaitForInterval(t *testing.T) {
+	now := time.Now()
+	waitInterval := waitForInterval(time.Minute)
+
+	assert.Equal(t, time.Duration(0), waitInterval(now, time.Time{}))
+	assert.Equal(t, 40*time.Second, waitInterval(now, now.Add(-20*time.Second)))
+	assert.Equal(t, time.Duration(0), waitInterval(now, now.Add(-2*time.Minute)))
+}
+
+func TestScheduledJobRunIfDue(t *testing.T) {
+	for _, testCase := range []struct {
+		description  string
+		lockErr      error
+		isLocked     bool
+		lastFinished time.Time
+		isRun        bool
+		expectedWait time.Duration
+	}{
+		{
+			description:  "ScheduledJobRunIfDue: job is run",
+			isLocked:     true,
+			lastFinished: time.Now().Add(-2 * time.Hour),
+			isRun:        true,
+			expectedWait: time.Hour,
+		},
+		{
+			description:  "ScheduledJobRunIfDue: job is run by another server",
+			expectedWait: constants.ScheduledJobLockRetry,
+		},
+		{
+			description:  "ScheduledJobRunIfDue: job was run by another server in the meantime",
+			isLocked:     true,
+			lastFinished: time.Now().Add(-30 * time.Minute),
+			expectedWait: 30 * time.Minute,
+		},
+		{
+			description:  "ScheduledJobRunIfDue: error in locking the job",
+			lockErr:      errors.New("error locking the job"),
+			expectedWait: constants.ScheduledJobLockRetry,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockedStore.EXPECT().LockJob("mockJob").Return(testCase.isLocked, testCase.lockErr)
+			if testCase.isLocked {
+				mockedStore.EXPECT().GetJobLastFinished("mockJob").Return(testCase.lastFinished, nil)
+				mockedStore.EXPECT().UnlockJob("mockJob").Return(nil)
+			}
+
+			if testCase.isRun {
+				mockedStore.EXPECT().StoreJobLastFinished("mockJob", gomock.Any()).Return(nil)
+			}
+
+			isRun := false
+			job := &scheduledJob{plugin: p, key: "mockJob", waitInterval: waitForInterval(time.Hour), callback: func() {
+				isRun = true
+			}}
+
+			assert.InDelta(t, testCase.expectedWait, job.runIfDue(), float64(time.Second))
+			assert.Equal(t, testCase.isRun, isRun)
+		})
+	}
+}
+
+func TestScheduledJobClose(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+	mockedStore.EXPECT().LockJob("mockJob").Return(true, nil).AnyTimes()
+	mockedStore.EXPECT().GetJobLastFinished("mockJob").Return(time.Time{}, nil).AnyTimes()
+	mockedStore.EXPECT().StoreJobLastFinished("mockJob", gomock.Any()).Return(nil).AnyTimes()
+	mockedStore.EXPECT().UnlockJob("mockJob").Return(nil).AnyTimes()
+
+	isRun := make(chan struct{}, 1)
+	job := p.scheduleJob("mockJob", waitForInterval(time.Hour), func() {
+		isRun <- struct{}{}
+	})
+
+	// A job which never ran is run right away
+	select {
+	case <-isRun:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "job was not run")
+	}
+
+	job.Close()
+	job.Close()
+}
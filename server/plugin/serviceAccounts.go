@@ -0,0 +1,158 @@
+package plugin
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// isServiceAccountNotificationExcluded checks if a notification is dropped because the change it's about was made by a service account.
+// The setting of the subscription overrides the plugin configuration, and the notifications whose actor can't be determined are always posted.
+func (p *Plugin) isServiceAccountNotificationExcluded(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) bool {
+	config := p.getConfiguration()
+	isExcluded := config.ExcludeServiceAccounts
+	if subscription != nil && subscription.ExcludeServiceAccounts != nil {
+		isExcluded = *subscription.ExcludeServiceAccounts
+	}
+
+	if !isExcluded {
+		return false
+	}
+
+	actor := getNotificationActor(body)
+	if actor == nil {
+		return false
+	}
+
+	return isServiceAccount(actor, config.GetServiceAccountPatterns())
+}
+
+// getIgnoredNotificationAuthor returns the name of the author of a notification if it's one of the ignored authors of the configuration.
+// Unlike the service account patterns, the ignored authors are matched as a whole and apply to all the subscriptions.
+func (p *Plugin) getIgnoredNotificationAuthor(body *serializers.SubscriptionNotification) string {
+	ignoredAuthors := p.getConfiguration().GetIgnoredNotificationAuthors()
+	if len(ignoredAuthors) == 0 {
+		return ""
+	}
+
+	actor := getNotificationActor(body)
+	if actor == nil {
+		return ""
+	}
+
+	for _, ignoredAuthor := range ignoredAuthors {
+		for _, name := range []string{actor.DisplayName, actor.UniqueName} {
+			if name != "" && strings.EqualFold(ignoredAuthor, name) {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// getNotificationActor returns the identity which made the change a notification is about.
+// It's nil for the events which don't have one, like the deployments and the pipeline runs, and when it's missing from the notification.
+func getNotificationActor(body *serializers.SubscriptionNotification) *serializers.Identity {
+	var actor *serializers.Identity
+	switch body.EventType {
+	case constants.SubscriptionEventWorkItemCreated, constants.SubscriptionEventWorkItemDeleted, constants.SubscriptionEventWorkItemCommented:
+		actor = getIdentityFromField(body.Resource.Fields.All[constants.FieldChangedBy])
+	case constants.SubscriptionEventWorkItemUpdated:
+		actor = &body.Resource.RevisedBy
+	case constants.SubscriptionEventCodePushed:
+		actor = &body.Resource.PushedBy
+	case constants.SubscriptionEventPullRequestCreated:
+		actor = &body.Resource.CreatedBy
+	case constants.SubscriptionEventPullRequestUpdated:
+		// The updated pull request doesn't tell who updated it, its creator isn't the author of a push or a vote made by another identity
+		return nil
+	case constants.SubscriptionEventPullRequestMerged:
+		actor = &body.Resource.ClosedBy
+	case constants.SubscriptionEventPullRequestCommented:
+		jsonBytes, err := json.Marshal(body.Resource.Comment)
+		if err != nil {
+			return nil
+		}
+
+		var comment serializers.Comment
+		if err := json.Unmarshal(jsonBytes, &comment); err != nil {
+			return nil
+		}
+		actor = &comment.Author
+	case constants.SubscriptionEventBuildCompleted:
+		actor = &body.Resource.RequestedBy
+	case constants.SubscriptionEventReleaseCreated:
+		actor = &body.Resource.Release.CreatedBy
+	case constants.SubscriptionEventReleaseAbandoned:
+		actor = &body.Resource.Release.ModifiedBy
+	}
+
+	if actor == nil || (actor.DisplayName == "" && actor.UniqueName == "" && actor.Descriptor == "") {
+		return nil
+	}
+
+	return actor
+}
+
+// getIdentityFromField returns the identity in an identity field of a work item.
+// The identities are like "Display Name <unique name>" in the notifications, and objects in the newer versions of the API.
+func getIdentityFromField(value interface{}) *serializers.Identity {
+	switch value := value.(type) {
+	case string:
+		identity := &serializers.Identity{DisplayName: strings.TrimSpace(value)}
+		if start := strings.LastIndex(value, " <"); start != -1 && strings.HasSuffix(value, ">") {
+			identity.DisplayName = strings.TrimSpace(value[:start])
+			identity.UniqueName = value[start+2 : len(value)-1]
+		}
+		return identity
+	case map[string]interface{}:
+		identity := &serializers.Identity{}
+		identity.DisplayName, _ = value["displayName"].(string)
+		identity.UniqueName, _ = value["uniqueName"].(string)
+		identity.Descriptor, _ = value["descriptor"].(string)
+		return identity
+	}
+
+	return nil
+}
+
+// isServiceAccount checks if the display name, the unique name or the descriptor of an identity matches any of the service account patterns
+func isServiceAccount(identity *serializers.Identity, patterns []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range []string{identity.DisplayName, identity.UniqueName, identity.Descriptor} {
+			if name != "" && isMatchingServiceAccountPattern(pattern, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isMatchingServiceAccountPattern matches a name against a pattern case insensitively, where "*" matches any characters.
+// Unlike the branch filters, the patterns are not glob patterns, since the unique names of the build services contain backslashes like "Build\<id>".
+func isMatchingServiceAccountPattern(pattern, name string) bool {
+	parts := strings.Split(strings.ToLower(pattern), constants.ServiceAccountPatternWildcard)
+	name = strings.ToLower(name)
+	if len(parts) == 1 {
+		return parts[0] == name
+	}
+
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(name, part)
+		if index == -1 {
+			return false
+		}
+		name = name[index+len(part):]
+	}
+
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
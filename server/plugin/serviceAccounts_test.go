@@ -0,0 +1,309 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestIsMatchingServiceAccountPattern(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		pattern     string
+		name        string
+		isMatched   bool
+	}{
+		{
+			description: "IsMatchingServiceAccountPattern: exact name",
+			pattern:     "Microsoft.VisualStudio.Services.TFS",
+			name:        "Microsoft.VisualStudio.Services.TFS",
+			isMatched:   true,
+		},
+		{
+			description: "IsMatchingServiceAccountPattern: name is matched case insensitively",
+			pattern:     "Project Collection Build Service*",
+			name:        "project collection build service (mockOrganization)",
+			isMatched:   true,
+		},
+		{
+			description: "IsMatchingServiceAccountPattern: wildcards in the middle of the pattern",
+			pattern:     "* Build Service (*)",
+			name:        "mockProject Build Service (mockOrganization)",
+			isMatched:   true,
+		},
+		{
+			description: "IsMatchingServiceAccountPattern: backslash in the name",
+			pattern:     `Build\*`,
+			name:        `Build\7c7b1b0c-9d4b-4a38-9ef7-5d1e8f3a6b21`,
+			isMatched:   true,
+		},
+		{
+			description: "IsMatchingServiceAccountPattern: name without the suffix of the pattern",
+			pattern:     "* Build Service (*)",
+			name:        "mockProject Build Service",
+		},
+		{
+			description: "IsMatchingServiceAccountPattern: name only containing the pattern",
+			pattern:     "Microsoft.VisualStudio.Services.TFS",
+			name:        "Microsoft.VisualStudio.Services.TFS.Proxy",
+		},
+		{
+			description: "IsMatchingServiceAccountPattern: user",
+			pattern:     "svc.*",
+			name:        "aad.mockDescriptor",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.isMatched, isMatchingServiceAccountPattern(testCase.pattern, testCase.name))
+		})
+	}
+}
+
+func TestGetNotificationActor(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		body          *serializers.SubscriptionNotification
+		expectedActor *serializers.Identity
+	}{
+		{
+			description: "GetNotificationActor: creator of a work item",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCreated, Resource: serializers.Resource{Fields: serializers.Fields{All: map[string]interface{}{
+				constants.FieldChangedBy: `Project Collection Build Service (mockOrganization) <Build\mockID>`,
+			}}}},
+			expectedActor: &serializers.Identity{DisplayName: "Project Collection Build Service (mockOrganization)", UniqueName: `Build\mockID`},
+		},
+		{
+			description: "GetNotificationActor: identity object of a work item field",
+			body: &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCommented, Resource: serializers.Resource{Fields: serializers.Fields{All: map[string]interface{}{
+				constants.FieldChangedBy: map[string]interface{}{"displayName": "mockDisplayName", "uniqueName": "mock@example.com", "descriptor": "aad.mockDescriptor"},
+			}}}},
+			expectedActor: &serializers.Identity{DisplayName: "mockDisplayName", UniqueName: "mock@example.com", Descriptor: "aad.mockDescriptor"},
+		},
+		{
+			description:   "GetNotificationActor: reviser of a work item",
+			body:          &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemUpdated, Resource: serializers.Resource{RevisedBy: serializers.Identity{DisplayName: "mockDisplayName"}}},
+			expectedActor: &serializers.Identity{DisplayName: "mockDisplayName"},
+		},
+		{
+			description:   "GetNotificationActor: pusher",
+			body:          &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed, Resource: serializers.Resource{PushedBy: serializers.Identity{Descriptor: "svc.mockDescriptor"}}},
+			expectedActor: &serializers.Identity{Descriptor: "svc.mockDescriptor"},
+		},
+		{
+			description:   "GetNotificationActor: author of a pull request comment",
+			body:          &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestCommented, Resource: serializers.Resource{Comment: map[string]interface{}{"content": "mockComment", "author": map[string]interface{}{"displayName": "mockDisplayName"}}}},
+			expectedActor: &serializers.Identity{DisplayName: "mockDisplayName"},
+		},
+		{
+			description:   "GetNotificationActor: creator of a release",
+			body:          &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventReleaseCreated, Resource: serializers.Resource{Release: serializers.Release{CreatedBy: serializers.Identity{DisplayName: "mockDisplayName"}}}},
+			expectedActor: &serializers.Identity{DisplayName: "mockDisplayName"},
+		},
+		{
+			description: "GetNotificationActor: updater of a pull request is unknown",
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventPullRequestUpdated, Resource: serializers.Resource{CreatedBy: serializers.Identity{DisplayName: "mockDisplayName"}}},
+		},
+		{
+			description: "GetNotificationActor: actor missing from the notification",
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventBuildCompleted},
+		},
+		{
+			description: "GetNotificationActor: event without an actor",
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventRunStateChanged},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedActor, getNotificationActor(testCase.body))
+		})
+	}
+}
+
+func TestIsServiceAccountNotificationExcluded(t *testing.T) {
+	excluded, notExcluded := true, false
+	buildServiceNotification := &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed, Resource: serializers.Resource{PushedBy: serializers.Identity{DisplayName: "Project Collection Build Service (mockOrganization)"}}}
+	for _, testCase := range []struct {
+		description  string
+		config       *config.Configuration
+		subscription *serializers.SubscriptionDetails
+		body         *serializers.SubscriptionNotification
+		isExcluded   bool
+	}{
+		{
+			description: "IsServiceAccountNotificationExcluded: service account is not excluded by default",
+			config:      &config.Configuration{},
+			body:        buildServiceNotification,
+		},
+		{
+			description: "IsServiceAccountNotificationExcluded: service account is excluded by the configuration",
+			config:      &config.Configuration{ExcludeServiceAccounts: true},
+			body:        buildServiceNotification,
+			isExcluded:  true,
+		},
+		{
+			description:  "IsServiceAccountNotificationExcluded: service account is excluded by the subscription",
+			config:       &config.Configuration{},
+			subscription: &serializers.SubscriptionDetails{ExcludeServiceAccounts: &excluded},
+			body:         buildServiceNotification,
+			isExcluded:   true,
+		},
+		{
+			description:  "IsServiceAccountNotificationExcluded: subscription overrides the configuration",
+			config:       &config.Configuration{ExcludeServiceAccounts: true},
+			subscription: &serializers.SubscriptionDetails{ExcludeServiceAccounts: &notExcluded},
+			body:         buildServiceNotification,
+		},
+		{
+			description: "IsServiceAccountNotificationExcluded: user is not excluded",
+			config:      &config.Configuration{ExcludeServiceAccounts: true},
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed, Resource: serializers.Resource{PushedBy: serializers.Identity{DisplayName: "mockDisplayName", UniqueName: "mock@example.com"}}},
+		},
+		{
+			description: "IsServiceAccountNotificationExcluded: configured patterns replace the default ones",
+			config:      &config.Configuration{ExcludeServiceAccounts: true, ServiceAccountPatterns: "mock@example.com"},
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed, Resource: serializers.Resource{PushedBy: serializers.Identity{DisplayName: "mockDisplayName", UniqueName: "mock@example.com"}}},
+			isExcluded:  true,
+		},
+		{
+			description: "IsServiceAccountNotificationExcluded: notification without an actor is not excluded",
+			config:      &config.Configuration{ExcludeServiceAccounts: true},
+			body:        &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := setupMockPlugin(&plugintest.API{}, nil, nil)
+			p.setConfiguration(testCase.config)
+
+			assert.Equal(t, testCase.isExcluded, p.isServiceAccountNotificationExcluded(testCase.subscription, testCase.body))
+		})
+	}
+}
+
+func TestGetIgnoredNotificationAuthor(t *testing.T) {
+	userNotification := &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemUpdated, Resource: serializers.Resource{RevisedBy: serializers.Identity{DisplayName: "CI Bot", UniqueName: "ci-bot@example.com"}}}
+	for _, testCase := range []struct {
+		description    string
+		ignoredAuthors string
+		body           *serializers.SubscriptionNotification
+		expectedAuthor string
+	}{
+		{
+			description: "GetIgnoredNotificationAuthor: no author is ignored by default",
+			body:        userNotification,
+		},
+		{
+			description:    "GetIgnoredNotificationAuthor: author is ignored by display name",
+			ignoredAuthors: "mockDisplayName, ci bot",
+			body:           userNotification,
+			expectedAuthor: "CI Bot",
+		},
+		{
+			description:    "GetIgnoredNotificationAuthor: author is ignored by unique name",
+			ignoredAuthors: "CI-BOT@example.com",
+			body:           userNotification,
+			expectedAuthor: "ci-bot@example.com",
+		},
+		{
+			description:    "GetIgnoredNotificationAuthor: names are not matched partially",
+			ignoredAuthors: "CI, ci-bot",
+			body:           userNotification,
+		},
+		{
+			description:    "GetIgnoredNotificationAuthor: notification without an author is not ignored",
+			ignoredAuthors: "CI Bot",
+			body:           &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemUpdated},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := setupMockPlugin(&plugintest.API{}, nil, nil)
+			p.setConfiguration(&config.Configuration{IgnoredNotificationAuthors: testCase.ignoredAuthors})
+
+			assert.Equal(t, testCase.expectedAuthor, p.getIgnoredNotificationAuthor(testCase.body))
+		})
+	}
+}
+
+func TestHandleSubscriptionNotificationsWithServiceAccounts(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for _, testCase := range []struct {
+		description    string
+		pushedBy       string
+		ignoredAuthors string
+		isPosted       bool
+	}{
+		{
+			description: "SubscriptionNotificationsWithServiceAccounts: notification of a user is posted",
+			pushedBy:    "mockDisplayName",
+			isPosted:    true,
+		},
+		{
+			description: "SubscriptionNotificationsWithServiceAccounts: notification of a service account is suppressed",
+			pushedBy:    "mockProject Build Service (mockOrganization)",
+		},
+		{
+			description:    "SubscriptionNotificationsWithServiceAccounts: notification of an ignored author is suppressed",
+			pushedBy:       "CI Bot",
+			ignoredAuthors: "ci bot",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{ExcludeServiceAccounts: true, IgnoredNotificationAuthors: testCase.ignoredAuthors})
+
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{{
+				SubscriptionID: testutils.MockSubscriptionID,
+			}}, nil)
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+			mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+			isPosted := false
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(mock.Arguments) {
+				isPosted = true
+			}).Return(&model.Post{}, nil)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), "SubscriptionID", testutils.MockSubscriptionID, "EventType", constants.SubscriptionEventCodePushed).Maybe()
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), "SubscriptionID", testutils.MockSubscriptionID, "EventType", constants.SubscriptionEventCodePushed, "Author", testCase.pushedBy).Maybe()
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return testutils.MockChannelID, http.StatusOK, nil
+			})
+
+			body := fmt.Sprintf(`{
+				"subscriptionID": "mockSubscriptionID",
+				"eventType": "git.push",
+				"resource": {"refUpdates": [{"name": "refs/heads/main"}], "pushedBy": {"displayName": %q}},
+				"message": {"markdown": "mockMarkdown"}
+			}`, testCase.pushedBy)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(body))
+
+			w := httptest.NewRecorder()
+			p.handleSubscriptionNotifications(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, testCase.isPosted, isPosted)
+		})
+	}
+}
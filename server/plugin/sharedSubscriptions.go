@@ -0,0 +1,102 @@
+package plugin
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getSharedSubscription returns the subscription of another user which sends the same events to the same channel,
+// so that its webhook is shared instead of creating a second one posting every notification twice
+func (p *Plugin) getSharedSubscription(mattermostUserID string, body *serializers.CreateSubscriptionRequestPayload) (*serializers.SubscriptionDetails, error) {
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionDetails := getSubscriptionDetailsFromPayload(body)
+	messageFormat := strings.ToLower(strings.TrimSpace(body.MessageFormat))
+	for _, subscription := range subscriptionList {
+		// The payload of the notifications depends on the resource version and the message format of the webhook
+		if subscription.MattermostUserID == mattermostUserID || subscription.ResourceVersion != body.GetResourceVersion() || subscription.MessageFormat != messageFormat {
+			continue
+		}
+
+		if _, isSubscriptionPresent := p.IsSubscriptionPresent([]*serializers.SubscriptionDetails{subscription}, subscriptionDetails); isSubscriptionPresent {
+			return subscription, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// shareExistingSubscription stores a subscription of the user referencing the subscription on Azure DevOps of another user sending the same events.
+// It returns nil if there is no such subscription, in which case a new one needs to be created on Azure DevOps.
+func (p *Plugin) shareExistingSubscription(mattermostUserID string, body *serializers.CreateSubscriptionRequestPayload, project *serializers.ProjectDetails) (*serializers.SubscriptionValue, int, error) {
+	sharedSubscription, err := p.getSharedSubscription(mattermostUserID, body)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if sharedSubscription == nil {
+		return nil, http.StatusOK, nil
+	}
+
+	subscription := &serializers.SubscriptionValue{
+		ID:          sharedSubscription.SubscriptionID,
+		EventType:   body.EventType,
+		ServiceType: body.ServiceType,
+	}
+	// The webhook keeps the secret it was created with
+	if statusCode, err := p.storeCreatedSubscription(mattermostUserID, body, project, subscription, ""); err != nil {
+		return nil, statusCode, err
+	}
+
+	return subscription, http.StatusOK, nil
+}
+
+// isSubscriptionShared returns true if a subscription of another user references the same subscription on Azure DevOps.
+// The subscription on Azure DevOps, along with its webhook secret, is only deleted along with the last subscription referencing it.
+func isSubscriptionShared(subscriptionList []*serializers.SubscriptionDetails, subscription *serializers.SubscriptionDetails) bool {
+	for _, otherSubscription := range subscriptionList {
+		if otherSubscription.SubscriptionID == subscription.SubscriptionID && otherSubscription.MattermostUserID != subscription.MattermostUserID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getNotificationSubscriptions returns the subscriptions of all the users sharing the webhook which sent a notification.
+// They are sorted by creation, so that the same subscription is used for every notification when several of them let it through.
+// It returns a nil subscription if there is none, e.g. for the webhooks created before the subscriptions were stored with their options.
+func (p *Plugin) getNotificationSubscriptions(subscriptionID string) []*serializers.SubscriptionDetails {
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		p.API.LogDebug(constants.FetchSubscriptionListError, "Error", err.Error())
+		return []*serializers.SubscriptionDetails{nil}
+	}
+
+	var subscriptions []*serializers.SubscriptionDetails
+	for _, subscription := range subscriptionList {
+		if subscription.SubscriptionID == subscriptionID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	if len(subscriptions) == 0 {
+		return []*serializers.SubscriptionDetails{nil}
+	}
+
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		if !subscriptions[i].CreatedAt.Equal(subscriptions[j].CreatedAt) {
+			return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+		}
+		return subscriptions[i].MattermostUserID < subscriptions[j].MattermostUserID
+	})
+
+	return subscriptions
+}
@@ -0,0 +1,327 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getSharedSubscriptionTestPayload() *serializers.CreateSubscriptionRequestPayload {
+	return &serializers.CreateSubscriptionRequestPayload{
+		Organization: testutils.MockOrganization,
+		Project:      testutils.MockProjectName,
+		EventType:    constants.SubscriptionEventWorkItemCreated,
+		ServiceType:  constants.ServiceTypeBoards,
+		ChannelID:    testutils.MockChannelID,
+	}
+}
+
+func getOtherUserSubscription(body *serializers.CreateSubscriptionRequestPayload) *serializers.SubscriptionDetails {
+	return &serializers.SubscriptionDetails{
+		SubscriptionID:   testutils.MockSubscriptionID,
+		MattermostUserID: "mockOtherMattermostUserID",
+		OrganizationName: body.Organization,
+		ProjectName:      body.Project,
+		ChannelID:        body.ChannelID,
+		EventType:        body.EventType,
+		ServiceType:      body.ServiceType,
+		ResourceVersion:  body.GetResourceVersion(),
+	}
+}
+
+func TestGetSharedSubscription(t *testing.T) {
+	body := getSharedSubscriptionTestPayload()
+	for _, testCase := range []struct {
+		description      string
+		subscription     func() *serializers.SubscriptionDetails
+		expectedIsShared bool
+	}{
+		{
+			description:      "GetSharedSubscription: subscription of another user sending the same events to the same channel",
+			subscription:     func() *serializers.SubscriptionDetails { return getOtherUserSubscription(body) },
+			expectedIsShared: true,
+		},
+		{
+			description: "GetSharedSubscription: subscription of the same user",
+			subscription: func() *serializers.SubscriptionDetails {
+				subscription := getOtherUserSubscription(body)
+				subscription.MattermostUserID = testutils.MockMattermostUserID
+				return subscription
+			},
+		},
+		{
+			description: "GetSharedSubscription: subscription of another channel",
+			subscription: func() *serializers.SubscriptionDetails {
+				subscription := getOtherUserSubscription(body)
+				subscription.ChannelID = "mockOtherChannelID"
+				return subscription
+			},
+		},
+		{
+			description: "GetSharedSubscription: subscription with another resource version",
+			subscription: func() *serializers.SubscriptionDetails {
+				subscription := getOtherUserSubscription(body)
+				subscription.ResourceVersion = "mockResourceVersion"
+				return subscription
+			},
+		},
+		{
+			description: "GetSharedSubscription: subscription with another message format",
+			subscription: func() *serializers.SubscriptionDetails {
+				subscription := getOtherUserSubscription(body)
+				subscription.MessageFormat = "html"
+				return subscription
+			},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+			subscription := testCase.subscription()
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{subscription}, nil)
+
+			sharedSubscription, err := p.getSharedSubscription(testutils.MockMattermostUserID, body)
+
+			assert.NoError(t, err)
+			if testCase.expectedIsShared {
+				assert.Equal(t, subscription, sharedSubscription)
+			} else {
+				assert.Nil(t, sharedSubscription)
+			}
+		})
+	}
+}
+
+func TestCreateSharedSubscription(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{DisplayName: "mockChannelName"}, nil)
+	mockAPI.On("GetUser", testutils.MockMattermostUserID).Return(&model.User{Username: "mockUsername"}, nil)
+	mockAPI.On("GetConfig").Return(&model.Config{})
+
+	body := getSharedSubscriptionTestPayload()
+	project := &serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName, ProjectID: testutils.MockProjectID}
+
+	// Neither a subscription nor a webhook secret is created, the new subscription references the one of the other user
+	mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{getOtherUserSubscription(body)}, nil)
+	mockedStore.EXPECT().StoreSubscription(gomock.Any()).DoAndReturn(func(subscription *serializers.SubscriptionDetails) error {
+		assert.Equal(t, testutils.MockSubscriptionID, subscription.SubscriptionID)
+		assert.Equal(t, testutils.MockMattermostUserID, subscription.MattermostUserID)
+		return nil
+	})
+
+	subscription, statusCode, err := p.createSubscription(testutils.MockMattermostUserID, body, project)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	require.NotNil(t, subscription)
+	assert.Equal(t, testutils.MockSubscriptionID, subscription.ID)
+}
+
+func TestDeleteSharedSubscription(t *testing.T) {
+	subscription := &serializers.SubscriptionDetails{
+		SubscriptionID:   testutils.MockSubscriptionID,
+		MattermostUserID: testutils.MockMattermostUserID,
+		OrganizationName: testutils.MockOrganization,
+	}
+	otherUserSubscription := *subscription
+	otherUserSubscription.MattermostUserID = "mockOtherMattermostUserID"
+	otherUserSubscription.IsSharedWebhook = true
+
+	t.Run("DeleteSubscription: subscription shared with another user is only deleted for the user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, mocks.NewMockClient(mockCtrl))
+
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{subscription, &otherUserSubscription}, nil)
+		mockedStore.EXPECT().DeleteSubscription(subscription).Return(nil)
+
+		statusCode, err := p.deleteSubscription(subscription, testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("DeleteSubscription: last subscription referencing the subscription on Azure DevOps deletes it", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, mockedClient)
+
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{subscription}, nil)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, testutils.MockMattermostUserID).Return(http.StatusNoContent, nil)
+		mockedStore.EXPECT().DeleteSubscription(subscription).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(testutils.MockSubscriptionID).Return(nil)
+		mockedStore.EXPECT().DeleteLastNotification(testutils.MockSubscriptionID).Return(nil)
+
+		statusCode, err := p.deleteSubscription(subscription, testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("DeleteSubscription: last subscription is deleted when the user is not allowed to delete the webhook created by another user", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+		// The creator of the webhook deleted their subscription first
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{&otherUserSubscription}, nil)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, "mockOtherMattermostUserID").Return(http.StatusForbidden, errors.New("mockError"))
+		mockedStore.EXPECT().DeleteSubscription(&otherUserSubscription).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap(testutils.MockSubscriptionID).Return(nil)
+		mockedStore.EXPECT().DeleteLastNotification(testutils.MockSubscriptionID).Return(nil)
+		mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
+
+		statusCode, err := p.deleteSubscription(&otherUserSubscription, "mockOtherMattermostUserID")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		mockAPI.AssertNumberOfCalls(t, "LogError", 1)
+	})
+
+	t.Run("DeleteSubscription: subscription is kept when the user is not allowed to delete their own webhook", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, mockedClient)
+
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{subscription}, nil)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, testutils.MockMattermostUserID).Return(http.StatusForbidden, errors.New("mockError"))
+
+		statusCode, err := p.deleteSubscription(subscription, testutils.MockMattermostUserID)
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, statusCode)
+	})
+}
+
+func TestHandleSubscriptionNotificationsWithSharedSubscriptions(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockOtherMattermostUserID := "mockOtherMattermostUserID"
+	for _, testCase := range []struct {
+		description       string
+		subscription      *serializers.SubscriptionDetails
+		otherSubscription *serializers.SubscriptionDetails
+		pausedUserID      string
+		isPosted          bool
+		ephemeralUserIDs  []string
+	}{
+		{
+			description:       "SubscriptionNotificationsWithSharedSubscriptions: notification is posted if the filters of the other subscription let it through",
+			subscription:      &serializers.SubscriptionDetails{BranchFilters: []string{"!main"}},
+			otherSubscription: &serializers.SubscriptionDetails{},
+			isPosted:          true,
+		},
+		{
+			description:       "SubscriptionNotificationsWithSharedSubscriptions: notification is posted if the other subscription is not paused",
+			subscription:      &serializers.SubscriptionDetails{},
+			otherSubscription: &serializers.SubscriptionDetails{},
+			pausedUserID:      testutils.MockMattermostUserID,
+			isPosted:          true,
+		},
+		{
+			description:       "SubscriptionNotificationsWithSharedSubscriptions: notification is not posted if no subscription lets it through",
+			subscription:      &serializers.SubscriptionDetails{BranchFilters: []string{"!main"}},
+			otherSubscription: &serializers.SubscriptionDetails{PullRequestTargetBranches: []string{"release/*"}},
+		},
+		{
+			description:       "SubscriptionNotificationsWithSharedSubscriptions: notification is posted in the channel once if a subscription is shown to the channel",
+			subscription:      &serializers.SubscriptionDetails{Visibility: constants.SubscriptionVisibilityEphemeral},
+			otherSubscription: &serializers.SubscriptionDetails{Visibility: constants.SubscriptionVisibilityChannel},
+			isPosted:          true,
+		},
+		{
+			description:       "SubscriptionNotificationsWithSharedSubscriptions: notification is only shown to the creators of the subscriptions letting it through",
+			subscription:      &serializers.SubscriptionDetails{Visibility: constants.SubscriptionVisibilityEphemeral},
+			otherSubscription: &serializers.SubscriptionDetails{Visibility: constants.SubscriptionVisibilityEphemeral, BranchFilters: []string{"!main"}},
+			ephemeralUserIDs:  []string{testutils.MockMattermostUserID},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			testCase.subscription.SubscriptionID = testutils.MockSubscriptionID
+			testCase.subscription.MattermostUserID = testutils.MockMattermostUserID
+			testCase.subscription.CreatedAt = time.Unix(1, 0)
+			testCase.otherSubscription.SubscriptionID = testutils.MockSubscriptionID
+			testCase.otherSubscription.MattermostUserID = mockOtherMattermostUserID
+			testCase.otherSubscription.CreatedAt = time.Unix(2, 0)
+			mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{testCase.otherSubscription, testCase.subscription}, nil)
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(&serializers.ChannelNotificationPrefs{}, nil).AnyTimes()
+			mockedStore.EXPECT().StoreLastNotification(gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().AddDeliveryLogEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockedStore.EXPECT().GetSubscriptionsPause(gomock.Any()).DoAndReturn(func(mattermostUserID string) (time.Time, error) {
+				if mattermostUserID == testCase.pausedUserID {
+					return time.Now().Add(time.Hour), nil
+				}
+				return time.Time{}, nil
+			}).AnyTimes()
+			postCount := 0
+			var ephemeralUserIDs []string
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockAPI.On("PublishWebSocketEvent", constants.WSEventNotificationPosted, mock.Anything, mock.Anything)
+			mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(mock.Arguments) {
+				postCount++
+			}).Return(&model.Post{}, nil)
+			mockAPI.On("GetUserStatus", mock.AnythingOfType("string")).Return(&model.Status{Status: model.STATUS_ONLINE}, nil)
+			mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+				ephemeralUserIDs = append(ephemeralUserIDs, args.String(0))
+			}).Return(&model.Post{})
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID}, nil)
+			monkey.Patch(model.IsValidId, func(string) bool {
+				return true
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "VerifySubscriptionWebhookSecretAndGetChannelID", func(_ *Plugin, _, _ string) (string, int, error) {
+				return testutils.MockChannelID, http.StatusOK, nil
+			})
+
+			body := `{
+				"subscriptionID": "mockSubscriptionID",
+				"eventType": "git.pullrequest.created",
+				"resource": {"pullRequestId": 1, "targetRefName": "refs/heads/main", "sourceRefName": "refs/heads/mockBranch"},
+				"message": {"markdown": "mockMarkdown"}
+			}`
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s=%s", constants.PathSubscriptionNotifications, constants.AzureDevopsQueryParamWebhookSecret, "mockWebhookSecret"), bytes.NewBufferString(body))
+
+			w := httptest.NewRecorder()
+			p.handleSubscriptionNotifications(w, req)
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			if testCase.isPosted {
+				assert.Equal(t, 1, postCount)
+			} else {
+				assert.Equal(t, 0, postCount)
+			}
+			assert.Equal(t, testCase.ephemeralUserIDs, ephemeralUserIDs)
+		})
+	}
+}
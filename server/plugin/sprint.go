@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+// getSprintSummary returns the number of work items in the current sprint of a team grouped by their state
+// along with the sum of their remaining work. The default team of the project is used if the team name is empty.
+func (p *Plugin) getSprintSummary(mattermostUserID, projectArgument, teamName string) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	iteration, statusCode, err := p.Client.GetCurrentIteration(project.OrganizationName, project.ProjectName, teamName, mattermostUserID)
+	if err != nil {
+		if statusCode == http.StatusNotFound || statusCode == http.StatusBadRequest {
+			return constants.NoCurrentSprint, nil
+		}
+		return "", err
+	}
+
+	if iteration == nil {
+		return constants.NoCurrentSprint, nil
+	}
+
+	query := fmt.Sprintf(constants.QueryWorkItemsInIteration, escapeWIQLString(iteration.Path))
+	workItemReferences, _, err := p.Client.QueryWorkItems(project.OrganizationName, project.ProjectName, query, mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	workItemIDs := make([]int, 0, len(workItemReferences))
+	for _, workItemReference := range workItemReferences {
+		workItemIDs = append(workItemIDs, workItemReference.ID)
+	}
+
+	// The summary would be wrong without some of the work items, so it's not shown if any batch fails
+	fields := []string{constants.FieldWorkItemType, constants.FieldState, constants.FieldRemainingWork}
+	workItems, erroredWorkItemIDs, err := p.getWorkItemsInBatches(project.OrganizationName, project.ProjectName, workItemIDs, fields, mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+	if len(erroredWorkItemIDs) > 0 {
+		return "", errors.New(constants.ErrorFetchSprintWorkItems)
+	}
+
+	workItemCount := map[string]int{}
+	var remainingWork float64
+	hasRemainingWork := false
+	for _, workItem := range workItems {
+		stateGroup := p.getSprintStateGroup(project.OrganizationName, project.ProjectName, workItem.Fields.Type, workItem.Fields.State, mattermostUserID)
+		if stateGroup == "" {
+			continue
+		}
+
+		workItemCount[stateGroup]++
+		if workItem.Fields.RemainingWork != nil {
+			hasRemainingWork = true
+			remainingWork += *workItem.Fields.RemainingWork
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Current sprint %q of %s/%s\n", iteration.Name, project.OrganizationName, project.ProjectName))
+	if iteration.Attributes.StartDate != nil && iteration.Attributes.FinishDate != nil {
+		sb.WriteString(fmt.Sprintf("%s - %s\n", iteration.Attributes.StartDate.Format("Jan 2, 2006"), iteration.Attributes.FinishDate.Format("Jan 2, 2006")))
+	}
+
+	for _, stateGroup := range []string{constants.SprintStateToDo, constants.SprintStateInProgress, constants.SprintStateDone} {
+		sb.WriteString(fmt.Sprintf("- %s: %d\n", stateGroup, workItemCount[stateGroup]))
+	}
+
+	// Remaining work is not shown for the teams not using the scheduling fields
+	if hasRemainingWork {
+		sb.WriteString(fmt.Sprintf("- Remaining work: %s hours\n", strconv.FormatFloat(remainingWork, 'f', -1, 64)))
+	}
+
+	return sb.String(), nil
+}
+
+// getSprintStateGroup returns whether a work item is to be done, in progress or done using the category of its state.
+// An empty string is returned for the removed work items.
+func (p *Plugin) getSprintStateGroup(organization, projectName, workItemType, state, mattermostUserID string) string {
+	if workItemTypeState := p.getWorkItemTypeState(organization, projectName, workItemType, state, mattermostUserID); workItemTypeState != nil {
+		switch workItemTypeState.Category {
+		case constants.StateCategoryProposed:
+			return constants.SprintStateToDo
+		case constants.StateCategoryInProgress, constants.StateCategoryResolved:
+			return constants.SprintStateInProgress
+		case constants.StateCategoryCompleted:
+			return constants.SprintStateDone
+		case constants.StateCategoryRemoved:
+			return ""
+		}
+	}
+
+	if stateGroup, ok := constants.DefaultSprintStateGroups[strings.ToLower(state)]; ok {
+		return stateGroup
+	}
+
+	return constants.SprintStateInProgress
+}
@@ -0,0 +1,149 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getMockSprintWorkItem(id int, workItemType, state string, remainingWork *float64) *serializers.TaskValue {
+	return &serializers.TaskValue{
+		ID: id,
+		Fields: serializers.TaskFieldValue{
+			Type:          workItemType,
+			State:         state,
+			RemainingWork: remainingWork,
+		},
+	}
+}
+
+func TestGetSprintSummary(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	startDate := time.Date(2022, time.January, 3, 0, 0, 0, 0, time.UTC)
+	finishDate := time.Date(2022, time.January, 14, 0, 0, 0, 0, time.UTC)
+	iteration := &serializers.Iteration{
+		Name: "Sprint 1",
+		Path: `mockProjectName\Sprint 1`,
+		Attributes: serializers.IterationAttributes{
+			StartDate:  &startDate,
+			FinishDate: &finishDate,
+		},
+	}
+	mockedClient.EXPECT().GetWorkItemTypeStates(testutils.MockOrganization, testutils.MockProjectName, "Task", testutils.MockMattermostUserID).Return([]*serializers.WorkItemTypeState{
+		{Name: "To Do", Category: constants.StateCategoryProposed},
+		{Name: "Doing", Category: constants.StateCategoryInProgress},
+		{Name: "Done", Category: constants.StateCategoryCompleted},
+		{Name: "Removed", Category: constants.StateCategoryRemoved},
+	}, http.StatusOK, nil)
+	mockedClient.EXPECT().GetWorkItemTypeStates(testutils.MockOrganization, testutils.MockProjectName, "Bug", testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("error in getting the work item type states"))
+	mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
+
+	remainingWork := 2.5
+	for _, testCase := range []struct {
+		description     string
+		teamName        string
+		workItems       []*serializers.TaskValue
+		expectedContent []string
+		unexpected      string
+	}{
+		{
+			description: "GetSprintSummary: work items are grouped by state with their remaining work",
+			teamName:    "mock team",
+			workItems: []*serializers.TaskValue{
+				getMockSprintWorkItem(1, "Task", "To Do", &remainingWork),
+				getMockSprintWorkItem(2, "Task", "Doing", &remainingWork),
+				getMockSprintWorkItem(3, "Task", "Done", nil),
+				getMockSprintWorkItem(4, "Task", "Removed", &remainingWork),
+				getMockSprintWorkItem(5, "Bug", "Closed", nil),
+			},
+			expectedContent: []string{
+				`###### Current sprint "Sprint 1" of mockOrganization/mockProjectName`,
+				"Jan 3, 2022 - Jan 14, 2022",
+				"- To Do: 1\n- In Progress: 1\n- Done: 2\n",
+				"- Remaining work: 5 hours",
+			},
+		},
+		{
+			description: "GetSprintSummary: remaining work is omitted if the scheduling fields are not used",
+			workItems: []*serializers.TaskValue{
+				getMockSprintWorkItem(1, "Task", "Doing", nil),
+			},
+			expectedContent: []string{"- To Do: 0\n- In Progress: 1\n- Done: 0\n"},
+			unexpected:      "Remaining work",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+			mockedClient.EXPECT().GetCurrentIteration(testutils.MockOrganization, testutils.MockProjectName, testCase.teamName, testutils.MockMattermostUserID).Return(iteration, http.StatusOK, nil)
+			mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).DoAndReturn(
+				func(_, _, query, _ string) ([]*serializers.WorkItemReference, int, error) {
+					assert.Equal(t, fmt.Sprintf(constants.QueryWorkItemsInIteration, iteration.Path), query)
+					references := []*serializers.WorkItemReference{}
+					for _, workItem := range testCase.workItems {
+						references = append(references, &serializers.WorkItemReference{ID: workItem.ID})
+					}
+					return references, http.StatusOK, nil
+				})
+			mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), gomock.Any(), testutils.MockMattermostUserID).Return(testCase.workItems, http.StatusOK, nil)
+
+			message, err := p.getSprintSummary(testutils.MockMattermostUserID, testutils.MockProjectName, testCase.teamName)
+
+			assert.NoError(t, err)
+			for _, content := range testCase.expectedContent {
+				assert.Contains(t, message, content)
+			}
+			if testCase.unexpected != "" {
+				assert.NotContains(t, message, testCase.unexpected)
+			}
+		})
+	}
+
+	t.Run("GetSprintSummary: no current sprint", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetCurrentIteration(testutils.MockOrganization, testutils.MockProjectName, "", testutils.MockMattermostUserID).Return(nil, http.StatusOK, nil)
+
+		message, err := p.getSprintSummary(testutils.MockMattermostUserID, testutils.MockProjectName, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.NoCurrentSprint, message)
+	})
+
+	t.Run("GetSprintSummary: project is not linked", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{}, nil)
+
+		message, err := p.getSprintSummary(testutils.MockMattermostUserID, testutils.MockProjectName, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.ProjectNotLinkedWithName, testutils.MockProjectName), message)
+	})
+
+	t.Run("GetSprintSummary: error in querying the work items", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetCurrentIteration(testutils.MockOrganization, testutils.MockProjectName, "", testutils.MockMattermostUserID).Return(iteration, http.StatusOK, nil)
+		mockedClient.EXPECT().QueryWorkItems(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("error in querying the work items"))
+
+		_, err := p.getSprintSummary(testutils.MockMattermostUserID, testutils.MockProjectName, "")
+
+		assert.Error(t, err)
+	})
+}
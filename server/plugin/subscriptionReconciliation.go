@@ -0,0 +1,134 @@
+package plugin
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// getSubscriptionReconciliationWaitInterval returns how long the job waits before comparing the subscriptions again.
+// The interval is counted from the end of the previous comparison, so a comparison taking longer than the interval isn't followed by another one right away.
+func (p *Plugin) getSubscriptionReconciliationWaitInterval(now, lastFinished time.Time) time.Duration {
+	interval := time.Duration(p.getConfiguration().ReconciliationInterval) * time.Minute
+	if interval <= 0 {
+		return constants.SubscriptionReconciliationDisabledJobInterval
+	}
+
+	if sinceLastFinished := now.Sub(lastFinished); sinceLastFinished < interval {
+		return interval - sinceLastFinished
+	}
+
+	return 0
+}
+
+// reconcileSubscriptions is run by the scheduled job and removes the subscriptions whose webhook was deleted in Azure DevOps, which would otherwise never get a notification again
+func (p *Plugin) reconcileSubscriptions() {
+	if p.getConfiguration().ReconciliationInterval <= 0 {
+		return
+	}
+
+	// The job doesn't run concurrently with itself, this only guards against the comparisons started while one is still running on this server
+	if !atomic.CompareAndSwapInt32(&p.reconcilingSubscriptions, 0, 1) {
+		p.API.LogDebug("Skipping the reconciliation of the subscriptions as the previous one is still running")
+		return
+	}
+	defer atomic.StoreInt32(&p.reconcilingSubscriptions, 0)
+
+	// The subscriptions are fetched before the webhooks, so a subscription created meanwhile is not compared with a list missing its webhook
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		p.API.LogError(constants.FetchSubscriptionListError, "Error", err.Error())
+		return
+	}
+
+	// The webhooks are listed once per organization and creator of the subscriptions, a nil set means they couldn't be listed
+	webhookIDs := map[string]map[string]bool{}
+	var prunedSubscriptionIDs []string
+	skippedCount := 0
+	for _, subscription := range subscriptionList {
+		if subscription.SubscriptionID == "" {
+			continue
+		}
+
+		listKey := strings.ToLower(subscription.OrganizationName) + "/" + subscription.MattermostUserID
+		subscriptionIDs, ok := webhookIDs[listKey]
+		if !ok {
+			subscriptionIDs = p.listWebhookIDs(subscription.OrganizationName, subscription.MattermostUserID)
+			webhookIDs[listKey] = subscriptionIDs
+		}
+
+		if subscriptionIDs == nil {
+			skippedCount++
+			continue
+		}
+
+		if subscriptionIDs[subscription.SubscriptionID] {
+			continue
+		}
+
+		// The list only has the webhooks the user can view, so a missing webhook is fetched by its ID to confirm it was deleted
+		if _, statusCode, getErr := p.Client.GetSubscription(subscription.OrganizationName, subscription.SubscriptionID, subscription.MattermostUserID); statusCode != http.StatusNotFound {
+			if getErr != nil {
+				p.API.LogWarn("Error in getting the subscription in Azure DevOps, the subscription is kept", "SubscriptionID", subscription.SubscriptionID, "Error", getErr.Error())
+				skippedCount++
+			}
+			continue
+		}
+
+		if pruneErr := p.pruneSubscription(subscription); pruneErr != nil {
+			p.API.LogError("Error in removing the subscription deleted in Azure DevOps", "SubscriptionID", subscription.SubscriptionID, "Error", pruneErr.Error())
+			continue
+		}
+
+		prunedSubscriptionIDs = append(prunedSubscriptionIDs, subscription.SubscriptionID)
+	}
+
+	p.API.LogInfo("Reconciled the subscriptions with Azure DevOps", "Checked", len(subscriptionList), "Pruned", len(prunedSubscriptionIDs), "Skipped", skippedCount, "PrunedSubscriptionIDs", strings.Join(prunedSubscriptionIDs, ","))
+}
+
+// listWebhookIDs returns the set of the IDs of the webhooks of an organization the user can view, or nil if they couldn't be listed
+func (p *Plugin) listWebhookIDs(organization, mattermostUserID string) map[string]bool {
+	webhooks, _, err := p.Client.ListSubscriptions(organization, mattermostUserID)
+	if err != nil {
+		p.API.LogWarn("Error in listing the subscriptions in Azure DevOps, their subscriptions are kept", "Organization", organization, "MattermostUserID", mattermostUserID, "Error", err.Error())
+		return nil
+	}
+
+	subscriptionIDs := make(map[string]bool, len(webhooks))
+	for _, webhook := range webhooks {
+		subscriptionIDs[webhook.ID] = true
+	}
+
+	return subscriptionIDs
+}
+
+// pruneSubscription removes a subscription from the KV store without deleting it in Azure DevOps, where it doesn't exist anymore.
+// The webhook of a subscription shared with other users is kept mapped to its channel until their subscriptions are pruned as well.
+func (p *Plugin) pruneSubscription(subscription *serializers.SubscriptionDetails) error {
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		return err
+	}
+
+	if err := p.Store.DeleteSubscription(subscription); err != nil {
+		return err
+	}
+
+	if isSubscriptionShared(subscriptionList, subscription) {
+		return nil
+	}
+
+	if err := p.Store.DeleteSubscriptionAndChannelIDMap(subscription.SubscriptionID); err != nil {
+		return err
+	}
+
+	if err := p.Store.DeleteLastNotification(subscription.SubscriptionID); err != nil {
+		p.API.LogDebug("Error in deleting the last notification of the subscription", "Error", err.Error())
+	}
+
+	return nil
+}
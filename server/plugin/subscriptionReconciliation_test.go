@@ -0,0 +1,159 @@
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestGetSubscriptionReconciliationWaitInterval(t *testing.T) {
+	now := time.Now()
+	for _, testCase := range []struct {
+		description      string
+		interval         int
+		lastFinished     time.Time
+		expectedInterval time.Duration
+	}{
+		{
+			description:      "GetSubscriptionReconciliationWaitInterval: reconciliation is disabled",
+			expectedInterval: constants.SubscriptionReconciliationDisabledJobInterval,
+		},
+		{
+			description:      "GetSubscriptionReconciliationWaitInterval: interval is counted from the end of the previous run",
+			interval:         60,
+			lastFinished:     now.Add(-20 * time.Minute),
+			expectedInterval: 40 * time.Minute,
+		},
+		{
+			description:      "GetSubscriptionReconciliationWaitInterval: previous run is older than the interval",
+			interval:         60,
+			lastFinished:     now.Add(-2 * time.Hour),
+			expectedInterval: 0,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := setupTestPlugin(nil)
+			p.setConfiguration(&config.Configuration{ReconciliationInterval: testCase.interval})
+
+			assert.Equal(t, testCase.expectedInterval, p.getSubscriptionReconciliationWaitInterval(now, testCase.lastFinished))
+		})
+	}
+}
+
+func TestReconcileSubscriptions(t *testing.T) {
+	getSubscription := func(subscriptionID, organization, mattermostUserID string) *serializers.SubscriptionDetails {
+		return &serializers.SubscriptionDetails{SubscriptionID: subscriptionID, OrganizationName: organization, MattermostUserID: mattermostUserID, ChannelID: testutils.MockChannelID}
+	}
+
+	t.Run("ReconcileSubscriptions: subscriptions deleted in Azure DevOps are pruned", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		p.setConfiguration(&config.Configuration{ReconciliationInterval: 60})
+
+		kept := getSubscription("mockSubscriptionID1", "mockOrganization", "mockUser1")
+		deleted := getSubscription("mockSubscriptionID2", "MockOrganization", "mockUser1")
+		notListed := getSubscription("mockSubscriptionID3", "mockOrganization", "mockUser2")
+		withoutID := getSubscription("", "mockOrganization", "mockUser1")
+		notViewable := getSubscription("mockSubscriptionID4", "mockOrganization", "mockUser1")
+		notFetched := getSubscription("mockSubscriptionID5", "mockOrganization", "mockUser1")
+		subscriptionList := []*serializers.SubscriptionDetails{kept, deleted, notListed, withoutID, notViewable, notFetched}
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil).Times(2)
+		mockedClient.EXPECT().ListSubscriptions("mockOrganization", "mockUser1").Return([]serializers.SubscriptionValue{{ID: "mockSubscriptionID1"}}, http.StatusOK, nil).Times(1)
+		mockedClient.EXPECT().ListSubscriptions("mockOrganization", "mockUser2").Return(nil, http.StatusUnauthorized, errors.New("error listing the subscriptions"))
+		mockedClient.EXPECT().GetSubscription("MockOrganization", "mockSubscriptionID2", "mockUser1").Return(nil, http.StatusNotFound, errors.New("error getting the subscription"))
+		mockedClient.EXPECT().GetSubscription("mockOrganization", "mockSubscriptionID4", "mockUser1").Return(&serializers.SubscriptionValue{ID: "mockSubscriptionID4"}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetSubscription("mockOrganization", "mockSubscriptionID5", "mockUser1").Return(nil, http.StatusInternalServerError, errors.New("error getting the subscription"))
+		mockedStore.EXPECT().DeleteSubscription(deleted).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap("mockSubscriptionID2").Return(nil)
+		mockedStore.EXPECT().DeleteLastNotification("mockSubscriptionID2").Return(nil)
+		mockAPI.On("LogWarn", testutils.GetMockArgumentsWithType("string", 7)...)
+		mockAPI.On("LogWarn", "Error in getting the subscription in Azure DevOps, the subscription is kept", "SubscriptionID", "mockSubscriptionID5", "Error", "error getting the subscription")
+		mockAPI.On("LogInfo", "Reconciled the subscriptions with Azure DevOps", "Checked", 6, "Pruned", 1, "Skipped", 2, "PrunedSubscriptionIDs", "mockSubscriptionID2")
+
+		p.reconcileSubscriptions()
+
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("ReconcileSubscriptions: webhook of a shared subscription is kept mapped until the last one is pruned", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		p.setConfiguration(&config.Configuration{ReconciliationInterval: 60})
+
+		first := getSubscription("mockSubscriptionID1", "mockOrganization", "mockUser1")
+		second := getSubscription("mockSubscriptionID1", "mockOrganization", "mockUser2")
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{first, second}, nil).Times(2)
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{second}, nil).Times(1)
+		mockedClient.EXPECT().ListSubscriptions("mockOrganization", gomock.Any()).Return([]serializers.SubscriptionValue{}, http.StatusOK, nil).Times(2)
+		mockedClient.EXPECT().GetSubscription("mockOrganization", "mockSubscriptionID1", gomock.Any()).Return(nil, http.StatusNotFound, errors.New("error getting the subscription")).Times(2)
+		mockedStore.EXPECT().DeleteSubscription(first).Return(nil)
+		mockedStore.EXPECT().DeleteSubscription(second).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap("mockSubscriptionID1").Return(nil).Times(1)
+		mockedStore.EXPECT().DeleteLastNotification("mockSubscriptionID1").Return(nil).Times(1)
+		mockAPI.On("LogInfo", "Reconciled the subscriptions with Azure DevOps", "Checked", 2, "Pruned", 2, "Skipped", 0, "PrunedSubscriptionIDs", "mockSubscriptionID1,mockSubscriptionID1")
+
+		p.reconcileSubscriptions()
+
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("ReconcileSubscriptions: subscription which fails to be pruned is not reported", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		p.setConfiguration(&config.Configuration{ReconciliationInterval: 60})
+
+		deleted := getSubscription("mockSubscriptionID1", "mockOrganization", "mockUser1")
+		mockedStore.EXPECT().GetAllSubscriptions("").Return([]*serializers.SubscriptionDetails{deleted}, nil).Times(2)
+		mockedClient.EXPECT().ListSubscriptions("mockOrganization", "mockUser1").Return([]serializers.SubscriptionValue{}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetSubscription("mockOrganization", "mockSubscriptionID1", "mockUser1").Return(nil, http.StatusNotFound, errors.New("error getting the subscription"))
+		mockedStore.EXPECT().DeleteSubscription(deleted).Return(errors.New("error deleting the subscription"))
+		mockAPI.On("LogError", "Error in removing the subscription deleted in Azure DevOps", "SubscriptionID", "mockSubscriptionID1", "Error", "error deleting the subscription")
+		mockAPI.On("LogInfo", "Reconciled the subscriptions with Azure DevOps", "Checked", 1, "Pruned", 0, "Skipped", 0, "PrunedSubscriptionIDs", "")
+
+		p.reconcileSubscriptions()
+
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("ReconcileSubscriptions: reconciliation is disabled", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		p := setupMockPlugin(&plugintest.API{}, mocks.NewMockKVStore(mockCtrl), mocks.NewMockClient(mockCtrl))
+		p.setConfiguration(&config.Configuration{})
+
+		p.reconcileSubscriptions()
+	})
+
+	t.Run("ReconcileSubscriptions: previous reconciliation is still running", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		p := setupMockPlugin(mockAPI, mocks.NewMockKVStore(mockCtrl), mocks.NewMockClient(mockCtrl))
+		p.setConfiguration(&config.Configuration{ReconciliationInterval: 60})
+		p.reconcilingSubscriptions = 1
+		mockAPI.On("LogDebug", mock.AnythingOfType("string"))
+
+		p.reconcileSubscriptions()
+
+		mockAPI.AssertExpectations(t)
+	})
+}
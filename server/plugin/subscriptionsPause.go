@@ -0,0 +1,91 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// parsePauseDuration parses the duration of a pause like 30m, 4h or 2d, as the days are not supported by time.ParseDuration
+func parsePauseDuration(value string) (time.Duration, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	var duration time.Duration
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		numberOfDays, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, false
+		}
+		duration = time.Duration(numberOfDays) * 24 * time.Hour
+	} else {
+		parsedDuration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, false
+		}
+		duration = parsedDuration
+	}
+
+	if duration <= 0 || duration > constants.SubscriptionsPauseMaxDuration {
+		return 0, false
+	}
+
+	return duration, true
+}
+
+// pauseSubscriptions pauses the notifications of the subscriptions created by a user and returns the message shown to them.
+// Pausing again replaces the previous pause, so it can also be extended or shortened.
+func (p *Plugin) pauseSubscriptions(mattermostUserID string, args []string) (string, error) {
+	duration := constants.SubscriptionsPauseDefaultDuration
+	if len(args) > 0 {
+		parsedDuration, isValid := parsePauseDuration(args[0])
+		if !isValid {
+			return fmt.Sprintf(constants.InvalidPauseDuration, args[0]), nil
+		}
+		duration = parsedDuration
+	}
+
+	pausedUntil := time.Now().Add(duration)
+	if err := p.Store.StoreSubscriptionsPause(mattermostUserID, pausedUntil); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(constants.SubscriptionsPaused, pausedUntil.UTC().Format(constants.SubscriptionsPauseTimeFormat)), nil
+}
+
+// resumeSubscriptions resumes the notifications of the subscriptions created by a user before their pause is over
+func (p *Plugin) resumeSubscriptions(mattermostUserID string) (string, error) {
+	pausedUntil, err := p.Store.GetSubscriptionsPause(mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	if !pausedUntil.After(time.Now()) {
+		return constants.SubscriptionsNotPaused, nil
+	}
+
+	if err := p.Store.DeleteSubscriptionsPause(mattermostUserID); err != nil {
+		return "", err
+	}
+
+	return constants.SubscriptionsResumed, nil
+}
+
+// isSubscriptionPaused checks if the notifications of a subscription are paused by its creator.
+// The notifications are posted if the pause can't be fetched, as dropping them can't be undone.
+func (p *Plugin) isSubscriptionPaused(subscription *serializers.SubscriptionDetails) bool {
+	if subscription == nil {
+		return false
+	}
+
+	pausedUntil, err := p.Store.GetSubscriptionsPause(subscription.MattermostUserID)
+	if err != nil {
+		p.API.LogDebug("Error in fetching the pause of the subscriptions of the user", "Error", err.Error())
+		return false
+	}
+
+	// The expired pauses can still be fetched until their key is removed
+	return pausedUntil.After(time.Now())
+}
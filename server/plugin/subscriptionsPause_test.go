@@ -0,0 +1,191 @@
+package plugin
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestParsePauseDuration(t *testing.T) {
+	for _, testCase := range []struct {
+		value            string
+		expectedDuration time.Duration
+		expectedValid    bool
+	}{
+		{value: "30m", expectedDuration: 30 * time.Minute, expectedValid: true},
+		{value: "4H", expectedDuration: 4 * time.Hour, expectedValid: true},
+		{value: "1h30m", expectedDuration: 90 * time.Minute, expectedValid: true},
+		{value: "2d", expectedDuration: 48 * time.Hour, expectedValid: true},
+		{value: "30d", expectedDuration: constants.SubscriptionsPauseMaxDuration, expectedValid: true},
+		{value: "31d"},
+		{value: "0m"},
+		{value: "-1h"},
+		{value: "d"},
+		{value: "tomorrow"},
+	} {
+		t.Run(fmt.Sprintf("ParsePauseDuration: %s", testCase.value), func(t *testing.T) {
+			duration, isValid := parsePauseDuration(testCase.value)
+
+			assert.Equal(t, testCase.expectedValid, isValid)
+			assert.Equal(t, testCase.expectedDuration, duration)
+		})
+	}
+}
+
+func TestPauseSubscriptions(t *testing.T) {
+	for _, testCase := range []struct {
+		description      string
+		args             []string
+		storeErr         error
+		expectedDuration time.Duration
+		expectedMessage  string
+		expectedError    bool
+	}{
+		{
+			description:      "PauseSubscriptions: subscriptions are paused for an hour by default",
+			expectedDuration: time.Hour,
+		},
+		{
+			description:      "PauseSubscriptions: subscriptions are paused for the given duration",
+			args:             []string{"2d"},
+			expectedDuration: 48 * time.Hour,
+		},
+		{
+			description:     "PauseSubscriptions: invalid duration",
+			args:            []string{"forever"},
+			expectedMessage: fmt.Sprintf(constants.InvalidPauseDuration, "forever"),
+		},
+		{
+			description:      "PauseSubscriptions: error in storing the pause",
+			storeErr:         errors.New("error in storing the pause"),
+			expectedDuration: time.Hour,
+			expectedError:    true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+
+			var pausedUntil time.Time
+			if testCase.expectedDuration > 0 {
+				mockedStore.EXPECT().StoreSubscriptionsPause(testutils.MockMattermostUserID, gomock.Any()).DoAndReturn(func(_ string, until time.Time) error {
+					assert.WithinDuration(t, time.Now().Add(testCase.expectedDuration), until, 5*time.Second)
+					pausedUntil = until
+					return testCase.storeErr
+				})
+			}
+
+			message, err := p.pauseSubscriptions(testutils.MockMattermostUserID, testCase.args)
+
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			if testCase.expectedMessage != "" {
+				assert.Equal(t, testCase.expectedMessage, message)
+				return
+			}
+			assert.Equal(t, fmt.Sprintf(constants.SubscriptionsPaused, pausedUntil.UTC().Format(constants.SubscriptionsPauseTimeFormat)), message)
+		})
+	}
+}
+
+func TestResumeSubscriptions(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		pausedUntil     time.Time
+		expectedDelete  bool
+		expectedMessage string
+	}{
+		{
+			description:     "ResumeSubscriptions: paused subscriptions are resumed",
+			pausedUntil:     time.Now().Add(time.Hour),
+			expectedDelete:  true,
+			expectedMessage: constants.SubscriptionsResumed,
+		},
+		{
+			description:     "ResumeSubscriptions: pause is already over",
+			pausedUntil:     time.Now().Add(-time.Minute),
+			expectedMessage: constants.SubscriptionsNotPaused,
+		},
+		{
+			description:     "ResumeSubscriptions: subscriptions are not paused",
+			expectedMessage: constants.SubscriptionsNotPaused,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+			mockedStore.EXPECT().GetSubscriptionsPause(testutils.MockMattermostUserID).Return(testCase.pausedUntil, nil)
+			if testCase.expectedDelete {
+				mockedStore.EXPECT().DeleteSubscriptionsPause(testutils.MockMattermostUserID).Return(nil)
+			}
+
+			message, err := p.resumeSubscriptions(testutils.MockMattermostUserID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+}
+
+func TestIsSubscriptionPaused(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		subscription   *serializers.SubscriptionDetails
+		pausedUntil    time.Time
+		err            error
+		expectedPaused bool
+	}{
+		{
+			description:    "IsSubscriptionPaused: creator of the subscription has paused it",
+			subscription:   &serializers.SubscriptionDetails{MattermostUserID: testutils.MockMattermostUserID},
+			pausedUntil:    time.Now().Add(time.Hour),
+			expectedPaused: true,
+		},
+		{
+			description:  "IsSubscriptionPaused: pause is over",
+			subscription: &serializers.SubscriptionDetails{MattermostUserID: testutils.MockMattermostUserID},
+			pausedUntil:  time.Now().Add(-time.Second),
+		},
+		{
+			description:  "IsSubscriptionPaused: creator of the subscription has not paused it",
+			subscription: &serializers.SubscriptionDetails{MattermostUserID: testutils.MockMattermostUserID},
+		},
+		{
+			description:  "IsSubscriptionPaused: error in fetching the pause",
+			subscription: &serializers.SubscriptionDetails{MattermostUserID: testutils.MockMattermostUserID},
+			err:          errors.New("error in fetching the pause"),
+		},
+		{
+			description: "IsSubscriptionPaused: notification without a subscription",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
+			if testCase.subscription != nil {
+				mockedStore.EXPECT().GetSubscriptionsPause(testutils.MockMattermostUserID).Return(testCase.pausedUntil, testCase.err)
+			}
+
+			assert.Equal(t, testCase.expectedPaused, p.isSubscriptionPaused(testCase.subscription))
+		})
+	}
+}
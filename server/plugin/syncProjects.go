@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// renamedProject is a linked project whose name was changed in Azure DevOps
+type renamedProject struct {
+	project serializers.ProjectDetails
+	oldName string
+}
+
+// syncProjects fetches the current names of the projects linked by a user by their IDs, updates the renamed ones and the names in their subscriptions.
+// A project which can't be fetched is reported without stopping the others, and the ones which are not found are offered to be unlinked.
+func (p *Plugin) syncProjects(mattermostUserID string) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	sort.Slice(projectList, func(i, j int) bool {
+		return projectList[i].ProjectName < projectList[j].ProjectName
+	})
+
+	var renamedProjects []renamedProject
+	var notFoundProjects, failedProjects []serializers.ProjectDetails
+	for index, project := range projectList {
+		currentProject, statusCode, err := p.Client.Link(&serializers.LinkRequestPayload{
+			Organization: project.OrganizationName,
+			Project:      project.ProjectID,
+		}, mattermostUserID)
+		if err != nil {
+			if statusCode == http.StatusNotFound {
+				notFoundProjects = append(notFoundProjects, project)
+				continue
+			}
+
+			p.API.LogError(constants.ErrorFetchProjectName, "Project", project.ProjectID, "Error", err.Error())
+			failedProjects = append(failedProjects, project)
+			continue
+		}
+
+		if currentProject == nil || currentProject.Name == "" || currentProject.Name == project.ProjectName {
+			continue
+		}
+
+		oldName := project.ProjectName
+		project.ProjectName = currentProject.Name
+		if err := p.Store.UpdateProject(&project); err != nil {
+			p.API.LogError(constants.ErrorSyncProjects, "Project", project.ProjectID, "Error", err.Error())
+			failedProjects = append(failedProjects, projectList[index])
+			continue
+		}
+
+		projectList[index] = project
+		renamedProjects = append(renamedProjects, renamedProject{project: project, oldName: oldName})
+	}
+
+	// The subscriptions are updated even if no project was renamed now, so running it again completes a previous run which failed midway
+	updatedCount, err := p.Store.RepointSubscriptions(mattermostUserID, projectList)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if len(renamedProjects) == 0 && updatedCount == 0 {
+		sb.WriteString(constants.NoRenamedProjects)
+	} else {
+		if len(renamedProjects) > 0 {
+			sb.WriteString("###### Renamed projects\n")
+			sb.WriteString("| Organization | Previous name | Current name |\n")
+			sb.WriteString("| :----------- | :------------ | :----------- |\n")
+			for _, renamedProject := range renamedProjects {
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", escapeTableCell(renamedProject.project.OrganizationName), escapeTableCell(renamedProject.oldName), escapeTableCell(renamedProject.project.ProjectName)))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf(constants.ProjectsRenamed, len(renamedProjects), updatedCount))
+	}
+
+	writeSyncProjectList(&sb, constants.SyncProjectsNotFound, notFoundProjects)
+	writeSyncProjectList(&sb, constants.SyncProjectsFailed, failedProjects)
+
+	return sb.String(), nil
+}
+
+// writeSyncProjectList adds a list of the projects which could not be synced under a title
+func writeSyncProjectList(sb *strings.Builder, title string, projects []serializers.ProjectDetails) {
+	if len(projects) == 0 {
+		return
+	}
+
+	sb.WriteString("\n\n")
+	sb.WriteString(title)
+	for _, project := range projects {
+		sb.WriteString(fmt.Sprintf("\n- %s/%s", project.OrganizationName, project.ProjectName))
+	}
+}
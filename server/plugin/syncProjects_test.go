@@ -0,0 +1,119 @@
+package plugin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestSyncProjects(t *testing.T) {
+	renamedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: testutils.MockOrganization, ProjectID: "mockRenamedProjectID", ProjectName: "mockOldName"}
+	unchangedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: testutils.MockOrganization, ProjectID: "mockUnchangedProjectID", ProjectName: "mockUnchangedName"}
+	deletedProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: testutils.MockOrganization, ProjectID: "mockDeletedProjectID", ProjectName: "mockDeletedName"}
+	failingProject := serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, OrganizationName: testutils.MockOrganization, ProjectID: "mockFailingProjectID", ProjectName: "mockFailingName"}
+	updatedProject := renamedProject
+	updatedProject.ProjectName = "mockNewName"
+
+	linkRequest := func(project serializers.ProjectDetails) *serializers.LinkRequestPayload {
+		return &serializers.LinkRequestPayload{Organization: project.OrganizationName, Project: project.ProjectID}
+	}
+
+	for _, testCase := range []struct {
+		description     string
+		projects        []serializers.ProjectDetails
+		updatedCount    int
+		expectedMessage string
+	}{
+		{
+			description:  "SyncProjects: renamed project is updated along with its subscriptions",
+			projects:     []serializers.ProjectDetails{unchangedProject, renamedProject},
+			updatedCount: 2,
+			expectedMessage: "###### Renamed projects\n" +
+				"| Organization | Previous name | Current name |\n" +
+				"| :----------- | :------------ | :----------- |\n" +
+				"| mockOrganization | mockOldName | mockNewName |\n" +
+				"\n1 of your linked project(s) were renamed and 2 of your subscription(s) now refer to their current names",
+		},
+		{
+			description:     "SyncProjects: no project is renamed",
+			projects:        []serializers.ProjectDetails{unchangedProject},
+			expectedMessage: constants.NoRenamedProjects,
+		},
+		{
+			description:     "SyncProjects: subscriptions left by an earlier run are updated",
+			projects:        []serializers.ProjectDetails{unchangedProject},
+			updatedCount:    1,
+			expectedMessage: "0 of your linked project(s) were renamed and 1 of your subscription(s) now refer to their current names",
+		},
+		{
+			description: "SyncProjects: deleted and failing projects don't stop the others",
+			projects:    []serializers.ProjectDetails{deletedProject, renamedProject, failingProject},
+			expectedMessage: "###### Renamed projects\n" +
+				"| Organization | Previous name | Current name |\n" +
+				"| :----------- | :------------ | :----------- |\n" +
+				"| mockOrganization | mockOldName | mockNewName |\n" +
+				"\n1 of your linked project(s) were renamed and 0 of your subscription(s) now refer to their current names" +
+				"\n\n" + constants.SyncProjectsNotFound + "\n- mockOrganization/mockDeletedName" +
+				"\n\n" + constants.SyncProjectsFailed + "\n- mockOrganization/mockFailingName",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			mockAPI.On("LogError", constants.ErrorFetchProjectName, "Project", failingProject.ProjectID, "Error", "mockError")
+
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(testCase.projects, nil)
+			mockedClient.EXPECT().Link(linkRequest(renamedProject), testutils.MockMattermostUserID).Return(&serializers.Project{ID: renamedProject.ProjectID, Name: updatedProject.ProjectName}, http.StatusOK, nil).AnyTimes()
+			mockedClient.EXPECT().Link(linkRequest(unchangedProject), testutils.MockMattermostUserID).Return(&serializers.Project{ID: unchangedProject.ProjectID, Name: unchangedProject.ProjectName}, http.StatusOK, nil).AnyTimes()
+			mockedClient.EXPECT().Link(linkRequest(deletedProject), testutils.MockMattermostUserID).Return(nil, http.StatusNotFound, errors.New("mockError")).AnyTimes()
+			mockedClient.EXPECT().Link(linkRequest(failingProject), testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("mockError")).AnyTimes()
+			mockedStore.EXPECT().UpdateProject(&updatedProject).Return(nil).AnyTimes()
+
+			// The subscriptions take the current names of the linked projects, including the renamed ones
+			mockedStore.EXPECT().RepointSubscriptions(testutils.MockMattermostUserID, gomock.Any()).DoAndReturn(func(_ string, projects []serializers.ProjectDetails) (int, error) {
+				for _, project := range projects {
+					assert.NotEqual(t, renamedProject.ProjectName, project.ProjectName)
+				}
+				assert.Len(t, projects, len(testCase.projects))
+				return testCase.updatedCount, nil
+			})
+
+			message, err := p.syncProjects(testutils.MockMattermostUserID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+
+	t.Run("SyncProjects: renamed project can't be updated", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+		mockAPI.On("LogError", constants.ErrorSyncProjects, "Project", renamedProject.ProjectID, "Error", mock.AnythingOfType("string"))
+
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{renamedProject}, nil)
+		mockedClient.EXPECT().Link(linkRequest(renamedProject), testutils.MockMattermostUserID).Return(&serializers.Project{ID: renamedProject.ProjectID, Name: updatedProject.ProjectName}, http.StatusOK, nil)
+		mockedStore.EXPECT().UpdateProject(&updatedProject).Return(errors.New("reached write attempt limit"))
+		mockedStore.EXPECT().RepointSubscriptions(testutils.MockMattermostUserID, []serializers.ProjectDetails{renamedProject}).Return(0, nil)
+
+		message, err := p.syncProjects(testutils.MockMattermostUserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.NoRenamedProjects+"\n\n"+constants.SyncProjectsFailed+"\n- mockOrganization/mockOldName", message)
+	})
+}
@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// handleAddTaskComment adds a comment to an existing work item, and echoes it as a reply to the post announcing the creation of the work item if requested
+func (p *Plugin) handleAddTaskComment(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	taskID := mux.Vars(r)[constants.PathParamTaskID]
+	if _, err := strconv.Atoi(taskID); err != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: constants.InvalidTaskID})
+		return
+	}
+
+	body, err := serializers.AddTaskCommentRequestPayloadFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError(constants.ErrorDecodingBody, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	body.Organization = p.getOrganization(body.Organization)
+	body.Comment = strings.TrimSpace(body.Comment)
+
+	if validationErr := body.IsValid(); validationErr != nil {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: validationErr.Error()})
+		return
+	}
+
+	comment, statusCode, err := p.Client.AddWorkItemComment(body.Organization, body.Project, taskID, body.Comment, mattermostUserID)
+	if err != nil {
+		switch statusCode {
+		case http.StatusNotFound:
+			// The work item may have been deleted in Azure DevOps since its post was created
+			err = fmt.Errorf(constants.WorkItemNotFound, taskID, body.Project)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeWorkWrite); scopeErr != nil {
+				err = scopeErr
+			}
+		}
+
+		p.API.LogError(constants.ErrorAddTaskComment, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	response := &serializers.AddedTaskCommentResponse{ID: comment.ID, CreatedDate: comment.CreatedDate}
+	if body.EchoToPost {
+		response.EchoPostID = p.echoTaskComment(mattermostUserID, body, taskID)
+	}
+
+	p.writeJSON(w, response)
+}
+
+// echoTaskComment replies to the post announcing the creation of a work item with a comment added to it, and returns the reply.
+// Nothing is posted if the post of the work item is not known or its channel is not one of the user,
+// and the comment is not reported as failed if it can't be echoed as it's already added in Azure DevOps.
+func (p *Plugin) echoTaskComment(mattermostUserID string, body *serializers.AddTaskCommentRequestPayload, taskID string) string {
+	postID, err := p.Store.GetPostIDForTask(body.Organization, body.Project, taskID)
+	if err != nil {
+		p.API.LogError(constants.ErrorEchoTaskComment, "Error", err.Error())
+		return ""
+	}
+
+	if postID == "" {
+		return ""
+	}
+
+	post, appErr := p.API.GetPost(postID)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorEchoTaskComment, "Error", appErr.Error())
+		return ""
+	}
+
+	if _, appErr = p.API.GetChannelMember(post.ChannelId, mattermostUserID); appErr != nil {
+		return ""
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+
+	reply, appErr := p.API.CreatePost(&model.Post{
+		UserId:    p.botUserID,
+		ChannelId: post.ChannelId,
+		RootId:    rootID,
+		Message:   fmt.Sprintf(constants.TaskCommentEcho, quoteMarkdown(body.Comment)),
+	})
+	if appErr != nil {
+		p.API.LogError(constants.ErrorEchoTaskComment, "Error", appErr.Error())
+		return ""
+	}
+
+	return reply.Id
+}
+
+// quoteMarkdown formats a text as a markdown block quote
+func quoteMarkdown(text string) string {
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestHandleAddTaskComment(t *testing.T) {
+	createdDate := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	for _, testCase := range []struct {
+		description        string
+		taskID             string
+		body               string
+		clientError        error
+		statusCode         int
+		postID             string
+		channelMemberError *model.AppError
+		expectedStatusCode int
+		expectedEchoPostID string
+	}{
+		{
+			description:        "AddTaskComment: comment is echoed in the post of the work item",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": " mockComment ", "echoToPost": true}`,
+			statusCode:         http.StatusOK,
+			postID:             "mockPostID",
+			expectedStatusCode: http.StatusOK,
+			expectedEchoPostID: "mockEchoPostID",
+		},
+		{
+			description:        "AddTaskComment: work item has no post",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": "mockComment", "echoToPost": true}`,
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "AddTaskComment: user is not a member of the channel of the post",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": "mockComment", "echoToPost": true}`,
+			statusCode:         http.StatusOK,
+			postID:             "mockPostID",
+			channelMemberError: &model.AppError{},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "AddTaskComment: comment is not echoed",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": "mockComment"}`,
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "AddTaskComment: task ID is not numeric",
+			taskID:             "mockTaskID",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "AddTaskComment: invalid body",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization",`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "AddTaskComment: empty comment",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": "  "}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "AddTaskComment: work item is deleted",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": "mockComment", "echoToPost": true}`,
+			clientError:        errors.New("error adding the comment"),
+			statusCode:         http.StatusNotFound,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "AddTaskComment: error while adding the comment",
+			taskID:             "1",
+			body:               `{"organization": "mockOrganization", "project": "mockProjectName", "comment": "mockComment"}`,
+			clientError:        errors.New("error adding the comment"),
+			statusCode:         http.StatusInternalServerError,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.botUserID = "mockBotUserID"
+
+			if testCase.statusCode != 0 {
+				mockedClient.EXPECT().AddWorkItemComment("mockOrganization", "mockProjectName", testCase.taskID, "mockComment", testutils.MockMattermostUserID).Return(&serializers.WorkItemComment{ID: 10, Text: "mockComment", CreatedDate: createdDate}, testCase.statusCode, testCase.clientError)
+			}
+
+			if testCase.statusCode == http.StatusOK && strings.Contains(testCase.body, `"echoToPost": true`) {
+				mockedStore.EXPECT().GetPostIDForTask("mockOrganization", "mockProjectName", testCase.taskID).Return(testCase.postID, nil)
+			}
+
+			if testCase.postID != "" {
+				mockAPI.On("GetPost", testCase.postID).Return(&model.Post{Id: testCase.postID, ChannelId: testutils.MockChannelID}, nil)
+				mockAPI.On("GetChannelMember", testutils.MockChannelID, testutils.MockMattermostUserID).Return(&model.ChannelMember{}, testCase.channelMemberError)
+				mockAPI.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return post.RootId == testCase.postID && post.ChannelId == testutils.MockChannelID && post.UserId == "mockBotUserID" && post.Message == "Comment added to the work item:\n> mockComment"
+				})).Return(&model.Post{Id: testCase.expectedEchoPostID}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/tasks/"+testCase.taskID+"/comments", bytes.NewBufferString(testCase.body))
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+			req = mux.SetURLVars(req, map[string]string{constants.PathParamTaskID: testCase.taskID})
+
+			w := httptest.NewRecorder()
+			p.handleAddTaskComment(w, req)
+			require.Equal(t, testCase.expectedStatusCode, w.Code)
+
+			if testCase.expectedStatusCode == http.StatusOK {
+				var response serializers.AddedTaskCommentResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, serializers.AddedTaskCommentResponse{ID: 10, CreatedDate: createdDate, EchoPostID: testCase.expectedEchoPostID}, response)
+			}
+
+			if testCase.channelMemberError != nil {
+				mockAPI.AssertNotCalled(t, "CreatePost", mock.Anything)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/mattermost/mattermost-server/v5/model"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 )
 
 // postTaskPreview function returns the new post containing the preview of the work item.
@@ -18,25 +20,31 @@ func (p *Plugin) PostTaskPreview(linkData []string, userID, channelID string) (*
 		return nil, ""
 	}
 
+	post := &model.Post{
+		UserId:    userID,
+		ChannelId: channelID,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{p.getTaskAttachment(task, linkData[3], linkData[4], userID)})
+	return post, ""
+}
+
+// getTaskAttachment returns the attachment showing the details of a work item
+func (p *Plugin) getTaskAttachment(task *serializers.TaskValue, organization, projectName, userID string) *model.SlackAttachment {
 	assignedTo := task.Fields.AssignedTo.DisplayName
 	if assignedTo == "" {
 		assignedTo = "None"
 	}
 
-	description := task.Fields.Description
+	description := convertHTMLToMarkdown(task.Fields.Description)
 	if description == "" {
 		description = "No description"
 	}
 
-	post := &model.Post{
-		UserId:    userID,
-		ChannelId: channelID,
-	}
-	attachment := &model.SlackAttachment{
+	return &model.SlackAttachment{
 		AuthorName: "Azure Boards",
 		AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameBoardsIcon),
 		Title:      fmt.Sprintf(constants.TaskTitle, task.Fields.Type, task.ID, task.Fields.Title, task.Link.HTML.Href),
-		Color:      constants.IconColorBoards,
+		Color:      p.getWorkItemStateColor(organization, projectName, task.Fields.Type, task.Fields.State, userID),
 		Fields: []*model.SlackAttachmentField{
 			{
 				Title: "State",
@@ -53,11 +61,9 @@ func (p *Plugin) PostTaskPreview(linkData []string, userID, channelID string) (*
 				Value: description,
 			},
 		},
-		Footer:     linkData[4],
+		Footer:     projectName,
 		FooterIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameProjectIcon),
 	}
-	model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
-	return post, ""
 }
 
 func (p *Plugin) PostPullRequestPreview(linkData []string, link, userID, channelID string) (*model.Post, string) {
@@ -80,7 +86,7 @@ func (p *Plugin) PostPullRequestPreview(linkData []string, link, userID, channel
 		UserId:    userID,
 		ChannelId: channelID,
 	}
-	reviewers := p.getReviewersListString(pullRequest.Reviewers)
+	reviewers := p.getReviewersListString(pullRequest.Reviewers, i18n.NewLocalizer(constants.DefaultNotificationLocale))
 	attachment := &model.SlackAttachment{
 		AuthorName: "Azure Repos",
 		AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameReposIcon),
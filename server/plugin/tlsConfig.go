@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+)
+
+// buildTLSConfig builds the TLS configuration of the requests to Azure DevOps from the plugin configuration, and logs the CA certificates it trusts.
+// It's nil if neither CA certificates nor skipping the verification is configured, so that the default transport is used.
+func (p *Plugin) buildTLSConfig(configuration *config.Configuration) (*tls.Config, error) {
+	pool, subjects, err := configuration.GetCACertPool()
+	if err != nil {
+		return nil, err
+	}
+
+	if pool == nil && !configuration.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	for _, subject := range subjects {
+		p.API.LogInfo("Added a CA certificate for the requests to Azure DevOps", "Subject", subject)
+	}
+
+	if configuration.InsecureSkipVerify {
+		p.API.LogWarn("The TLS certificates of Azure DevOps are not verified, which allows the requests to be intercepted. Configure the CA certificates instead.")
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- Skipping the verification is an explicit setting, which is discouraged in its help text and logged
+		InsecureSkipVerify: configuration.InsecureSkipVerify,
+	}, nil
+}
+
+// getTLSConfig returns the active TLS configuration of the requests to Azure DevOps under lock, it's nil if the defaults are used
+func (p *Plugin) getTLSConfig() *tls.Config {
+	p.configurationLock.RLock()
+	defer p.configurationLock.RUnlock()
+
+	return p.tlsConfig
+}
+
+// setTLSConfig replaces the active TLS configuration under lock, the HTTP client picks it up on its next request
+func (p *Plugin) setTLSConfig(tlsConfig *tls.Config) {
+	p.configurationLock.Lock()
+	defer p.configurationLock.Unlock()
+
+	p.tlsConfig = tlsConfig
+}
+
+// newHTTPClient returns an HTTP client whose transport uses the given TLS configuration, or the default transport if it's nil
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	if tlsConfig == nil {
+		return &http.Client{}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}
+}
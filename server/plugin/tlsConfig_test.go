@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+)
+
+func getServerCertificatePEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	t.Run("BuildTLSConfig: default transport is used without CA certificates", func(t *testing.T) {
+		p := setupTestPlugin(&plugintest.API{})
+
+		tlsConfig, err := p.buildTLSConfig(&config.Configuration{})
+
+		assert.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("BuildTLSConfig: CA certificates are added and logged", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		p := setupTestPlugin(mockAPI)
+		mockAPI.On("LogInfo", mock.AnythingOfType("string"), "Subject", server.Certificate().Subject.String()).Once()
+
+		tlsConfig, err := p.buildTLSConfig(&config.Configuration{CACertificates: getServerCertificatePEM(server)})
+
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("BuildTLSConfig: skipping the verification is logged", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		p := setupTestPlugin(mockAPI)
+		mockAPI.On("LogWarn", mock.AnythingOfType("string")).Once()
+
+		tlsConfig, err := p.buildTLSConfig(&config.Configuration{InsecureSkipVerify: true})
+
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("BuildTLSConfig: invalid CA certificates", func(t *testing.T) {
+		p := setupTestPlugin(&plugintest.API{})
+
+		tlsConfig, err := p.buildTLSConfig(&config.Configuration{CACertificates: "mockCertificate"})
+
+		assert.Error(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+}
+
+func TestMakeHTTPRequestCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	mockAPI := &plugintest.API{}
+	p := setupTestPlugin(mockAPI)
+	mockAPI.On("LogInfo", mock.AnythingOfType("string"), "Subject", mock.AnythingOfType("string"))
+	mockAPI.On("LogWarn", mock.AnythingOfType("string"))
+	makeRequest := func() (int, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		_, statusCode, err := p.Client.(*client).MakeHTTPRequest(req, "", nil)
+		return statusCode, err
+	}
+
+	for _, testCase := range []struct {
+		description string
+		config      *config.Configuration
+		expectedErr bool
+	}{
+		{
+			description: "MakeHTTPRequestCustomCA: server using a private CA is rejected by default",
+			config:      &config.Configuration{},
+			expectedErr: true,
+		},
+		{
+			description: "MakeHTTPRequestCustomCA: server using a private CA is accepted once the CA is configured",
+			config:      &config.Configuration{CACertificates: getServerCertificatePEM(server)},
+		},
+		{
+			description: "MakeHTTPRequestCustomCA: server using a private CA is rejected again once the CA is removed",
+			config:      &config.Configuration{},
+			expectedErr: true,
+		},
+		{
+			description: "MakeHTTPRequestCustomCA: server using a private CA is accepted without verifying it",
+			config:      &config.Configuration{InsecureSkipVerify: true},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			tlsConfig, err := p.buildTLSConfig(testCase.config)
+			require.NoError(t, err)
+			p.setConfiguration(testCase.config)
+			p.setTLSConfig(tlsConfig)
+
+			statusCode, err := makeRequest()
+
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, statusCode)
+		})
+	}
+}
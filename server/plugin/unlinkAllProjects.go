@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// handleUnlinkAllProjects unlinks all the projects of a user along with the subscriptions the user created for them.
+// A project whose subscriptions or link can't be deleted is reported as failed without stopping the others,
+// and it stays linked so that its remaining subscriptions can still be found and deleted.
+func (p *Plugin) handleUnlinkAllProjects(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectList, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	response := &serializers.UnlinkAllProjectsResponse{
+		UnlinkedProjects: []serializers.UnlinkedProject{},
+		FailedProjects:   []serializers.UnlinkedProject{},
+	}
+	for _, project := range projectList {
+		project := project
+		unlinkedProject := serializers.UnlinkedProject{
+			ProjectID:        project.ProjectID,
+			ProjectName:      project.ProjectName,
+			OrganizationName: project.OrganizationName,
+		}
+
+		if err := p.unlinkProjectAndSubscriptions(mattermostUserID, &project); err != nil {
+			p.API.LogError(constants.ErrorUnlinkProject, "ProjectID", project.ProjectID, "Error", err.Error())
+			unlinkedProject.Error = err.Error()
+			response.FailedProjects = append(response.FailedProjects, unlinkedProject)
+			continue
+		}
+
+		response.UnlinkedProjects = append(response.UnlinkedProjects, unlinkedProject)
+	}
+
+	response.UnlinkedCount = len(response.UnlinkedProjects)
+	p.writeJSON(w, response)
+}
+
+// unlinkProjectAndSubscriptions deletes the subscriptions of a project before unlinking it, so that their webhooks aren't left behind in Azure DevOps
+func (p *Plugin) unlinkProjectAndSubscriptions(mattermostUserID string, project *serializers.ProjectDetails) error {
+	if _, err := p.handleDeleteAllSubscriptions(mattermostUserID, project.ProjectID); err != nil {
+		return err
+	}
+
+	return p.Store.DeleteProject(&serializers.ProjectDetails{
+		MattermostUserID: mattermostUserID,
+		ProjectID:        project.ProjectID,
+		ProjectName:      project.ProjectName,
+		OrganizationName: project.OrganizationName,
+	})
+}
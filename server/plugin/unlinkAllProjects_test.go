@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestHandleUnlinkAllProjects(t *testing.T) {
+	getProject := func(projectID string) serializers.ProjectDetails {
+		return serializers.ProjectDetails{MattermostUserID: testutils.MockMattermostUserID, ProjectID: projectID, ProjectName: projectID + "Name", OrganizationName: testutils.MockOrganization}
+	}
+	getSubscription := func(subscriptionID, projectID string) *serializers.SubscriptionDetails {
+		return &serializers.SubscriptionDetails{SubscriptionID: subscriptionID, ProjectID: projectID, OrganizationName: testutils.MockOrganization, MattermostUserID: testutils.MockMattermostUserID}
+	}
+
+	t.Run("HandleUnlinkAllProjects: projects are unlinked along with their subscriptions", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+		mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+		unlinked, failedSubscriptions, failedLink := getProject("mockProjectID1"), getProject("mockProjectID2"), getProject("mockProjectID3")
+		subscriptions := []*serializers.SubscriptionDetails{getSubscription("mockSubscriptionID1", "mockProjectID1"), getSubscription("mockSubscriptionID2", "mockProjectID2")}
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{unlinked, failedSubscriptions, failedLink}, nil)
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptions, nil).Times(3)
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptions, nil).Times(2)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID1", testutils.MockMattermostUserID).Return(http.StatusNoContent, nil)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID2", testutils.MockMattermostUserID).Return(http.StatusForbidden, errors.New("error deleting the subscription"))
+		mockedStore.EXPECT().DeleteSubscription(subscriptions[0]).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap("mockSubscriptionID1").Return(nil)
+		mockedStore.EXPECT().DeleteLastNotification("mockSubscriptionID1").Return(nil)
+		mockedStore.EXPECT().DeleteProject(&unlinked).Return(nil)
+		mockedStore.EXPECT().DeleteProject(&failedLink).Return(errors.New("error unlinking the project"))
+
+		req := httptest.NewRequest(http.MethodPost, "/project/unlink/all", nil)
+		req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+		w := httptest.NewRecorder()
+		p.handleUnlinkAllProjects(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response serializers.UnlinkAllProjectsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, serializers.UnlinkAllProjectsResponse{
+			UnlinkedCount:    1,
+			UnlinkedProjects: []serializers.UnlinkedProject{{ProjectID: "mockProjectID1", ProjectName: "mockProjectID1Name", OrganizationName: testutils.MockOrganization}},
+			FailedProjects: []serializers.UnlinkedProject{
+				{ProjectID: "mockProjectID2", ProjectName: "mockProjectID2Name", OrganizationName: testutils.MockOrganization, Error: "error deleting the subscription"},
+				{ProjectID: "mockProjectID3", ProjectName: "mockProjectID3Name", OrganizationName: testutils.MockOrganization, Error: "error unlinking the project"},
+			},
+		}, response)
+	})
+
+	t.Run("HandleUnlinkAllProjects: no linked projects", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/project/unlink/all", nil)
+		req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+		w := httptest.NewRecorder()
+		p.handleUnlinkAllProjects(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"unlinkedCount": 0, "unlinkedProjects": [], "failedProjects": []}`, w.Body.String())
+	})
+
+	t.Run("HandleUnlinkAllProjects: error in fetching the projects", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+		mockCtrl := gomock.NewController(t)
+		mockedStore := mocks.NewMockKVStore(mockCtrl)
+		p := setupMockPlugin(mockAPI, mockedStore, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(nil, errors.New("error fetching the projects"))
+
+		req := httptest.NewRequest(http.MethodPost, "/project/unlink/all", nil)
+		req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+		w := httptest.NewRecorder()
+		p.handleUnlinkAllProjects(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -6,23 +6,30 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/pkg/errors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
 )
 
 var ErrNotFound = errors.New("not found")
+var ErrChannelNotFound = errors.New(constants.ChannelNotFound)
 
 // sendEphemeralPostForCommand sends an ephermal message
 func (p *Plugin) sendEphemeralPostForCommand(args *model.CommandArgs, text string) (*model.CommandResponse, *model.AppError) {
@@ -164,7 +171,8 @@ func (p *Plugin) ParseAuthToken(encoded string) (string, error) {
 }
 
 // AddAuthorization function to add authorization to a request.
-func (p *Plugin) AddAuthorization(r *http.Request, mattermostUserID string) error {
+// The token of the connection of the organization is used if the user has connected it separately.
+func (p *Plugin) AddAuthorization(r *http.Request, mattermostUserID, organization string) error {
 	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
 	if err != nil {
 		return err
@@ -175,7 +183,12 @@ func (p *Plugin) AddAuthorization(r *http.Request, mattermostUserID string) erro
 		return err
 	}
 
-	token, err := p.ParseAuthToken(user.AccessToken)
+	accessToken := user.AccessToken
+	if connection := user.GetOrganizationConnection(organization); connection != nil {
+		accessToken = connection.AccessToken
+	}
+
+	token, err := p.ParseAuthToken(accessToken)
 	if err != nil {
 		return err
 	}
@@ -186,17 +199,313 @@ func (p *Plugin) AddAuthorization(r *http.Request, mattermostUserID string) erro
 
 func (p *Plugin) IsProjectLinked(projectList []serializers.ProjectDetails, project serializers.ProjectDetails) (*serializers.ProjectDetails, bool) {
 	for _, a := range projectList {
-		if a.ProjectName == project.ProjectName && a.OrganizationName == project.OrganizationName {
+		if isSameProjectName(a, project) {
 			return &a, true
 		}
 	}
 	return nil, false
 }
 
+// normalizeProjectNames trims the organization and project names sent by the clients.
+// Their case is kept for display, and it's ignored while comparing them with isSameProjectName.
+func normalizeProjectNames(organization, project string) (string, string) {
+	return strings.TrimSpace(organization), strings.TrimSpace(project)
+}
+
+// isSameProjectName compares the organization and project names of two projects case-insensitively, as Azure DevOps does
+func isSameProjectName(project, otherProject serializers.ProjectDetails) bool {
+	organization, projectName := normalizeProjectNames(project.OrganizationName, project.ProjectName)
+	otherOrganization, otherProjectName := normalizeProjectNames(otherProject.OrganizationName, otherProject.ProjectName)
+	return strings.EqualFold(organization, otherOrganization) && strings.EqualFold(projectName, otherProjectName)
+}
+
+// getSubscriptionProject returns the organization, ID and name of the project of a subscription
+func getSubscriptionProject(subscription *serializers.SubscriptionDetails) serializers.ProjectDetails {
+	return serializers.ProjectDetails{
+		OrganizationName: subscription.OrganizationName,
+		ProjectID:        subscription.ProjectID,
+		ProjectName:      subscription.ProjectName,
+	}
+}
+
+// isSubscriptionOfProject checks if a subscription is for a linked project, by the ID of the project if it's stored with the subscription.
+// The subscriptions created before the IDs were stored are matched by the names of the project.
+func isSubscriptionOfProject(subscription *serializers.SubscriptionDetails, project *serializers.ProjectDetails) bool {
+	if subscription.ProjectID != "" && project.ProjectID != "" {
+		return strings.EqualFold(strings.TrimSpace(subscription.OrganizationName), strings.TrimSpace(project.OrganizationName)) && subscription.ProjectID == project.ProjectID
+	}
+
+	return isSameProjectName(getSubscriptionProject(subscription), *project)
+}
+
+// getLinkedProjectByKey returns the linked project with the given ID in an organization
+func getLinkedProjectByKey(projectList []serializers.ProjectDetails, organization, projectID string) (*serializers.ProjectDetails, bool) {
+	projectKey := store.GetNormalizedProjectKey(organization, projectID)
+	for _, linkedProject := range projectList {
+		if store.GetNormalizedProjectKey(linkedProject.OrganizationName, linkedProject.ProjectID) == projectKey {
+			return &linkedProject, true
+		}
+	}
+	return nil, false
+}
+
+// getAzureDevopsProject fetches a project from Azure DevOps to check that it exists and the user can access it.
+// The project is returned with its ID and the case of its name in Azure DevOps, which are stored for the linked project.
+func (p *Plugin) getAzureDevopsProject(mattermostUserID, organization, projectName string) (*serializers.Project, int, error) {
+	response, statusCode, err := p.Client.Link(&serializers.LinkRequestPayload{Organization: organization, Project: projectName}, mattermostUserID)
+	if statusCode == http.StatusNotFound || (err == nil && (response == nil || response.ID == "")) {
+		return nil, http.StatusNotFound, errors.New(constants.ProjectNotFound)
+	}
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	if response.Name == "" {
+		response.Name = projectName
+	}
+
+	return response, statusCode, nil
+}
+
+// getLinkedProjectByID returns the linked project with the given ID, the IDs of the projects are unique across the organizations
+func getLinkedProjectByID(projectList []serializers.ProjectDetails, projectID string) (*serializers.ProjectDetails, bool) {
+	for _, project := range projectList {
+		if strings.EqualFold(project.ProjectID, projectID) {
+			return &project, true
+		}
+	}
+	return nil, false
+}
+
+// autoLinkProject links the project a subscription is created for, after checking that the user can access it in Azure DevOps.
+// The project may already be linked under a name differing in case, in which case the linked entry is returned without replacing it.
+func (p *Plugin) autoLinkProject(mattermostUserID, organization, projectName string, projectList []serializers.ProjectDetails) (*serializers.ProjectDetails, int, error) {
+	response, statusCode, err := p.getAzureDevopsProject(mattermostUserID, organization, projectName)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	if linkedProject, isLinked := getLinkedProjectByKey(projectList, organization, response.ID); isLinked {
+		return linkedProject, http.StatusOK, nil
+	}
+
+	if err := p.checkLinkedProjectsLimit(projectList); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	project := &serializers.ProjectDetails{
+		MattermostUserID: mattermostUserID,
+		ProjectID:        response.ID,
+		ProjectName:      response.Name,
+		OrganizationName: strings.ToLower(organization),
+	}
+	if err := p.Store.StoreProject(project); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return project, http.StatusOK, nil
+}
+
+// checkLinkedProjectsLimit returns an error if the user has already linked as many projects as the admin allows, the projects are not limited by default
+func (p *Plugin) checkLinkedProjectsLimit(projectList []serializers.ProjectDetails) error {
+	if maxProjects := p.getConfiguration().MaxLinkedProjectsPerUser; maxProjects > 0 && len(projectList) >= maxProjects {
+		return fmt.Errorf(constants.LinkedProjectsLimitReached, maxProjects)
+	}
+
+	return nil
+}
+
+// checkSubscriptionsLimit returns an error if the user has already created as many subscriptions as the admin allows, the subscriptions are not limited by default
+func (p *Plugin) checkSubscriptionsLimit(subscriptionList []*serializers.SubscriptionDetails) error {
+	if maxSubscriptions := p.getConfiguration().MaxSubscriptionsPerUser; maxSubscriptions > 0 && len(subscriptionList) >= maxSubscriptions {
+		return fmt.Errorf(constants.SubscriptionsLimitReached, maxSubscriptions)
+	}
+
+	return nil
+}
+
+// createSubscription creates a subscription on Azure DevOps for a linked project and stores its details
+func (p *Plugin) createSubscription(mattermostUserID string, body *serializers.CreateSubscriptionRequestPayload, project *serializers.ProjectDetails) (*serializers.SubscriptionValue, int, error) {
+	if subscription, statusCode, err := p.shareExistingSubscription(mattermostUserID, body, project); err != nil || subscription != nil {
+		return subscription, statusCode, err
+	}
+
+	if subscription, webhookSecret := p.reusePendingWebhook(mattermostUserID, body); subscription != nil {
+		if storeStatusCode, storeErr := p.storeCreatedSubscription(mattermostUserID, body, project, subscription, webhookSecret); storeErr != nil {
+			return nil, storeStatusCode, storeErr
+		}
+
+		return subscription, http.StatusOK, nil
+	}
+
+	uniqueWebhookSecret := uuid.New().String()
+	subscription, statusCode, err := p.Client.CreateSubscription(body, project, body.ChannelID, p.GetPluginURL(), mattermostUserID, uniqueWebhookSecret)
+	if err != nil {
+		p.API.LogError(constants.CreateSubscriptionError, "Error", err.Error())
+		if p.enqueueRetryOperation(constants.RetryOperationTypeCreateSubscription, mattermostUserID, statusCode, body) {
+			return nil, http.StatusAccepted, ErrQueuedForRetry
+		}
+		return nil, statusCode, err
+	}
+
+	if storeStatusCode, storeErr := p.storeCreatedSubscription(mattermostUserID, body, project, subscription, uniqueWebhookSecret); storeErr != nil {
+		return nil, storeStatusCode, storeErr
+	}
+
+	return subscription, statusCode, nil
+}
+
+// getSubscriptionWebhookURL returns the URL the webhook of a subscription sends its notifications to, along with the secret identifying it
+func (p *Plugin) getSubscriptionWebhookURL(pluginURL, webhookSecret string) string {
+	return fmt.Sprintf("%s%s?%s=%s", strings.TrimRight(pluginURL, "/"), p.getConfiguration().GetSubscriptionNotificationsPath(), constants.AzureDevopsQueryParamWebhookSecret, url.QueryEscape(webhookSecret))
+}
+
+// storeCreatedSubscription stores the details of a subscription which is created on Azure DevOps.
+// The webhook secret is empty for a subscription sharing the webhook of another one, whose secret is already stored.
+func (p *Plugin) storeCreatedSubscription(mattermostUserID string, body *serializers.CreateSubscriptionRequestPayload, project *serializers.ProjectDetails, subscription *serializers.SubscriptionValue, webhookSecret string) (int, error) {
+	if webhookSecret != "" {
+		if err := p.Store.StoreSubscriptionAndChannelIDMap(subscription.ID, webhookSecret, body.ChannelID); err != nil {
+			p.API.LogError("Error storing channel ID for subscription", "Error", err.Error())
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	channel, channelErr := p.API.GetChannel(body.ChannelID)
+	if channelErr != nil {
+		p.API.LogError(constants.GetChannelError, "Error", channelErr.Error())
+		return http.StatusInternalServerError, errors.New(constants.GetChannelError)
+	}
+
+	user, userErr := p.API.GetUser(mattermostUserID)
+	if userErr != nil {
+		p.API.LogError(constants.GetUserError, "Error", userErr.Error())
+		return http.StatusInternalServerError, errors.New(constants.GetUserError)
+	}
+
+	createdByDisplayName := user.Username
+
+	showFullName := p.API.GetConfig().PrivacySettings.ShowFullName
+	// If "PrivacySettings.ShowFullName" is true then show the user's first/last name
+	// If the user's first/last name doesn't exist then show the username as fallback
+	if showFullName != nil && *showFullName && (user.FirstName != "" || user.LastName != "") {
+		createdByDisplayName = fmt.Sprintf("%s %s", user.FirstName, user.LastName)
+	}
+
+	if storeErr := p.Store.StoreSubscription(&serializers.SubscriptionDetails{
+		MattermostUserID: mattermostUserID,
+		// The names of the linked project are stored, as the ones in the payload may differ in case
+		ProjectName:      project.ProjectName,
+		ProjectID:        project.ProjectID,
+		OrganizationName: project.OrganizationName,
+		EventType:        body.EventType,
+		ServiceType:      body.ServiceType,
+		ChannelID:        body.ChannelID,
+		SubscriptionID:   subscription.ID,
+		ChannelName:      channel.DisplayName,
+		ChannelType:      channel.Type,
+		CreatedBy:        strings.TrimSpace(createdByDisplayName),
+		CreatedAt:        time.Now().UTC(),
+		// Only the subscriptions sharing the webhook of another user are stored without a webhook secret
+		IsSharedWebhook: webhookSecret == "",
+		// Below all are filters that could be present on different categories of subscriptions from Boards, Repos and Pipelines
+		Repository:                       body.Repository,
+		TargetBranch:                     body.TargetBranch,
+		RepositoryName:                   body.RepositoryName,
+		PullRequestCreatedBy:             body.PullRequestCreatedBy,
+		PullRequestReviewersContains:     body.PullRequestReviewersContains,
+		PullRequestCreatedByName:         body.PullRequestCreatedByName,
+		PullRequestReviewersContainsName: body.PullRequestReviewersContainsName,
+		PushedBy:                         body.PushedBy,
+		PushedByName:                     body.PushedByName,
+		MergeResult:                      body.MergeResult,
+		MergeResultName:                  body.MergeResultName,
+		NotificationType:                 body.NotificationType,
+		NotificationTypeName:             body.NotificationTypeName,
+		AreaPath:                         body.AreaPath,
+		WorkItemType:                     body.WorkItemType,
+		BuildStatus:                      body.BuildStatus,
+		BuildPipeline:                    body.BuildPipeline,
+		StageName:                        body.StageName,
+		ReleasePipeline:                  body.ReleasePipeline,
+		ReleaseStatus:                    body.ReleaseStatus,
+		ApprovalType:                     body.ApprovalType,
+		ApprovalStatus:                   body.ApprovalStatus,
+		BuildStatusName:                  body.BuildStatusName,
+		StageNameValue:                   body.StageNameValue,
+		ReleasePipelineName:              body.ReleasePipelineName,
+		ReleaseStatusName:                body.ReleaseStatusName,
+		ApprovalTypeName:                 body.ApprovalTypeName,
+		ApprovalStatusName:               body.ApprovalStatusName,
+		RunPipeline:                      body.RunPipeline,
+		RunPipelineName:                  body.RunPipelineName,
+		RunStageName:                     body.RunStageName,
+		RunEnvironmentName:               body.RunEnvironmentName,
+		RunStageNameID:                   body.RunStageNameID,
+		RunStageStateID:                  body.RunStageStateID,
+		RunStageStateIDName:              body.RunStageStateIDName,
+		RunStageResultID:                 body.RunStageResultID,
+		RunStateID:                       body.RunStateID,
+		RunStateIDName:                   body.RunStateIDName,
+		RunResultID:                      body.RunResultID,
+		KeepRawHTML:                      body.KeepRawHTML,
+		Label:                            strings.TrimSpace(body.Label),
+		Truncation:                       body.Truncation,
+		BranchFilters:                    getBranchFilters(body.BranchFilters),
+		PullRequestTargetBranches:        getBranchFilters(body.PullRequestTargetBranches),
+		BuildResults:                     getBuildResults(body.BuildResults),
+		SkipBuildsWithoutResult:          body.SkipBuildsWithoutResult,
+		ShowLinkedWorkItems:              body.ShowLinkedWorkItems,
+		ExcludeServiceAccounts:           body.ExcludeServiceAccounts,
+		Visibility:                       strings.ToLower(strings.TrimSpace(body.Visibility)),
+		ResourceVersion:                  body.GetResourceVersion(),
+		MessageFormat:                    strings.ToLower(strings.TrimSpace(body.MessageFormat)),
+		CreatedByUsername:                user.Username,
+	}); storeErr != nil {
+		p.API.LogError("Error in creating a subscription", "Error", storeErr.Error())
+		return http.StatusInternalServerError, storeErr
+	}
+
+	return http.StatusOK, nil
+}
+
+// getSubscriptionDetailsFromPayload returns the subscription details used to check if the requested subscription is already present
+func getSubscriptionDetailsFromPayload(body *serializers.CreateSubscriptionRequestPayload) *serializers.SubscriptionDetails {
+	return &serializers.SubscriptionDetails{
+		OrganizationName: body.Organization,
+		ProjectName:      body.Project,
+		ChannelID:        body.ChannelID,
+		EventType:        body.EventType,
+		// Below all are filters that could be present on different categories of subscriptions from Boards, Repos and Pipelines
+		Repository:                   body.Repository,
+		TargetBranch:                 body.TargetBranch,
+		PullRequestCreatedBy:         body.PullRequestCreatedBy,
+		PullRequestReviewersContains: body.PullRequestReviewersContains,
+		PushedBy:                     body.PushedBy,
+		MergeResult:                  body.MergeResult,
+		NotificationType:             body.NotificationType,
+		AreaPath:                     body.AreaPath,
+		WorkItemType:                 body.WorkItemType,
+		BuildStatus:                  body.BuildStatus,
+		BuildPipeline:                body.BuildPipeline,
+		StageName:                    body.StageName,
+		ReleasePipeline:              body.ReleasePipeline,
+		ReleaseStatus:                body.ReleaseStatus,
+		ApprovalType:                 body.ApprovalType,
+		ApprovalStatus:               body.ApprovalStatus,
+		RunPipeline:                  body.RunPipeline,
+		RunStageName:                 body.RunStageName,
+		RunEnvironmentName:           body.RunEnvironmentName,
+		RunStageNameID:               body.RunStageNameID,
+		RunStageStateID:              body.RunStageStateID,
+		RunStageResultID:             body.RunStageResultID,
+		RunStateID:                   body.RunStateID,
+		RunResultID:                  body.RunResultID,
+	}
+}
+
 func (p *Plugin) IsSubscriptionPresent(subscriptionList []*serializers.SubscriptionDetails, subscription *serializers.SubscriptionDetails) (*serializers.SubscriptionDetails, bool) {
 	for _, a := range subscriptionList {
-		if a.ProjectName == subscription.ProjectName &&
-			a.OrganizationName == subscription.OrganizationName &&
+		if isSameProjectName(getSubscriptionProject(a), getSubscriptionProject(subscription)) &&
 			a.ChannelID == subscription.ChannelID &&
 			a.EventType == subscription.EventType &&
 			a.Repository == subscription.Repository &&
@@ -207,6 +516,7 @@ func (p *Plugin) IsSubscriptionPresent(subscriptionList []*serializers.Subscript
 			a.MergeResult == subscription.MergeResult &&
 			a.NotificationType == subscription.NotificationType &&
 			a.AreaPath == subscription.AreaPath &&
+			a.WorkItemType == subscription.WorkItemType &&
 			a.BuildPipeline == subscription.BuildPipeline &&
 			a.BuildStatus == subscription.BuildStatus &&
 			a.StageName == subscription.StageName &&
@@ -245,7 +555,27 @@ func (p *Plugin) getConnectAccountFirstMessage() string {
 	return fmt.Sprintf(constants.ConnectAccountFirst, fmt.Sprintf(constants.ConnectAccount, p.GetPluginURLPath(), constants.PathOAuthConnect))
 }
 
-func (p *Plugin) ParseSubscriptionsToCommandResponse(subscriptionsList []*serializers.SubscriptionDetails, channelID, createdBy, userID, command, teamID string) string {
+// sortSubscriptionsByCreation orders the subscriptions from the newest, or from the oldest if it's requested.
+// The subscriptions created at the same time, like the ones whose creation time is unknown, are ordered by their IDs.
+func sortSubscriptionsByCreation(subscriptions []*serializers.SubscriptionDetails, oldestFirst bool) {
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		if !subscriptions[i].CreatedAt.Equal(subscriptions[j].CreatedAt) {
+			return subscriptions[i].CreatedAt.After(subscriptions[j].CreatedAt) != oldestFirst
+		}
+		return subscriptions[i].SubscriptionID < subscriptions[j].SubscriptionID
+	})
+}
+
+// getSubscriptionCreatedAt returns the creation time of a subscription in UTC, or a marker if it was created before the time was stored
+func getSubscriptionCreatedAt(subscription *serializers.SubscriptionDetails) string {
+	if subscription.CreatedAt.IsZero() {
+		return constants.SubscriptionCreatedAtUnknown
+	}
+
+	return subscription.CreatedAt.UTC().Format(constants.SubscriptionCreatedAtFormat)
+}
+
+func (p *Plugin) ParseSubscriptionsToCommandResponse(subscriptionsList []*serializers.SubscriptionDetails, channelID, createdBy, userID, command, teamID string, oldestFirst bool) string {
 	var sb strings.Builder
 
 	filteredSubscriptionList, filteredSubscriptionErr := p.GetSubscriptionsForAccessibleChannelsOrProjects(subscriptionsList, teamID, userID, createdBy)
@@ -260,46 +590,26 @@ func (p *Plugin) ParseSubscriptionsToCommandResponse(subscriptionsList []*serial
 		return sb.String()
 	}
 
+	sortSubscriptionsByCreation(filteredSubscriptionList, oldestFirst)
+
 	sb.WriteString(fmt.Sprintf("###### %s subscription(s)\n", cases.Title(language.Und).String(command)))
-	sb.WriteString("| Subscription ID | Organization | Project | Event Type | Created By | Channel |\n")
-	sb.WriteString("| :-------------- | :----------- | :------ | :--------- | :--------- | :------ |\n")
-
-	displayEventType := map[string]string{
-		constants.SubscriptionEventWorkItemCreated:                    "Work Item Created",
-		constants.SubscriptionEventWorkItemUpdated:                    "Work Item Updated",
-		constants.SubscriptionEventWorkItemDeleted:                    "Work Item Deleted",
-		constants.SubscriptionEventWorkItemCommented:                  "Work Item Commented",
-		constants.SubscriptionEventPullRequestCreated:                 "Pull Request Created",
-		constants.SubscriptionEventPullRequestUpdated:                 "Pull Request Updated",
-		constants.SubscriptionEventPullRequestMerged:                  "Pull Request Merge Attempted",
-		constants.SubscriptionEventPullRequestCommented:               "Pull Requested Commented",
-		constants.SubscriptionEventCodePushed:                         "Code Pushed",
-		constants.SubscriptionEventBuildCompleted:                     "Build Completed",
-		constants.SubscriptionEventReleaseAbandoned:                   "Release Abandoned",
-		constants.SubscriptionEventReleaseCreated:                     "Release Created",
-		constants.SubscriptionEventReleaseDeploymentApprovalCompleted: "Release Deployment Approval Completed",
-		constants.SubscriptionEventReleaseDeploymentCompleted:         "Release Deployment Completed",
-		constants.SubscriptionEventReleaseDeploymentEventPending:      "Release Deployment Event Pending",
-		constants.SubscriptionEventReleaseDeploymentStarted:           "Release Deployment Started",
-		constants.SubscriptionEventRunStageApprovalCompleted:          "Run Stage Approval Completed",
-		constants.SubscriptionEventRunStageStateChanged:               "Run Stage State Changed",
-		constants.SubscriptionEventRunStageWaitingForApproval:         "Run Stage Waiting For Approval",
-		constants.SubscriptionEventRunStateChanged:                    "Run State Changed",
-	}
+	sb.WriteString("| Subscription ID | Organization | Project | Event Type | Created By | Created At | Channel | Label |\n")
+	sb.WriteString("| :-------------- | :----------- | :------ | :--------- | :--------- | :--------- | :------ | :---- |\n")
 
 	noSubscriptionFound := true
+	channelNames := p.newChannelNameResolver()
 	for _, subscription := range filteredSubscriptionList {
 		if channelID == "" || subscription.ChannelID == channelID {
 			switch createdBy {
 			case constants.FilterCreatedByMe:
 				if subscription.MattermostUserID == userID && subscription.ServiceType == command {
 					noSubscriptionFound = false
-					sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", subscription.SubscriptionID, subscription.OrganizationName, subscription.ProjectName, displayEventType[subscription.EventType], subscription.CreatedBy, subscription.ChannelName))
+					sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s |\n", subscription.SubscriptionID, subscription.OrganizationName, subscription.ProjectName, constants.EventTypeDisplayNames[subscription.EventType], subscription.CreatedBy, getSubscriptionCreatedAt(subscription), channelNames.getChannelName(subscription.ChannelID), subscription.Label))
 				}
 			case constants.FilterCreatedByAnyone:
 				if subscription.ServiceType == command {
 					noSubscriptionFound = false
-					sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", subscription.SubscriptionID, subscription.OrganizationName, subscription.ProjectName, displayEventType[subscription.EventType], subscription.CreatedBy, subscription.ChannelName))
+					sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s |\n", subscription.SubscriptionID, subscription.OrganizationName, subscription.ProjectName, constants.EventTypeDisplayNames[subscription.EventType], subscription.CreatedBy, getSubscriptionCreatedAt(subscription), channelNames.getChannelName(subscription.ChannelID), subscription.Label))
 				}
 			}
 		}
@@ -313,11 +623,13 @@ func (p *Plugin) ParseSubscriptionsToCommandResponse(subscriptionsList []*serial
 	return sb.String()
 }
 
+// GetOffsetAndLimitFromQueryParams returns the offset and the limit of the page requested by the query params.
+// The invalid values are clamped to the defaults instead of failing the request.
 func (p *Plugin) GetOffsetAndLimitFromQueryParams(r *http.Request) (offset, limit int) {
 	query := r.URL.Query()
 	var page int
 	if val, err := strconv.Atoi(query.Get(constants.QueryParamPage)); err != nil || val < 0 {
-		p.API.LogError(constants.InvalidPaginationQueryParam, "Error", err.Error())
+		p.API.LogError(constants.InvalidPaginationQueryParam, constants.QueryParamPage, query.Get(constants.QueryParamPage))
 		page = constants.DefaultPage
 	} else {
 		page = val
@@ -325,8 +637,8 @@ func (p *Plugin) GetOffsetAndLimitFromQueryParams(r *http.Request) (offset, limi
 
 	val, err := strconv.Atoi(query.Get(constants.QueryParamPerPage))
 	switch {
-	case err != nil || val < 0 || val > constants.DefaultPerPageLimit: // We can keep max limit per page and default limit per page same
-		p.API.LogError(constants.InvalidPaginationQueryParam, "Error", err.Error())
+	case err != nil || val <= 0 || val > constants.DefaultPerPageLimit: // We can keep max limit per page and default limit per page same
+		p.API.LogError(constants.InvalidPaginationQueryParam, constants.QueryParamPerPage, query.Get(constants.QueryParamPerPage))
 		limit = constants.DefaultPerPageLimit
 	default:
 		limit = val
@@ -336,7 +648,7 @@ func (p *Plugin) GetOffsetAndLimitFromQueryParams(r *http.Request) (offset, limi
 }
 
 func (p *Plugin) GetSubscriptionsForAccessibleChannelsOrProjects(subscriptionList []*serializers.SubscriptionDetails, teamID, mattermostUserID, createdBy string) ([]*serializers.SubscriptionDetails, error) {
-	channels, channelErr := p.API.GetChannelsForTeamForUser(teamID, mattermostUserID, false)
+	channels, _, channelErr := p.getUserChannelsForTeam(teamID, mattermostUserID)
 	if channelErr != nil {
 		p.API.LogError(constants.GetChannelError, "Error", channelErr.Error())
 		return nil, channelErr
@@ -365,10 +677,274 @@ func (p *Plugin) GetSubscriptionsForAccessibleChannelsOrProjects(subscriptionLis
 	return filteredSubscriptionList, nil
 }
 
+// checkTeamMember checks if a user is a member of a team who hasn't left it.
+// Only a missing membership is reported as forbidden, the other errors in getting it are returned with their status code.
+func (p *Plugin) checkTeamMember(teamID, mattermostUserID string) (int, error) {
+	teamMember, teamMemberErr := p.API.GetTeamMember(teamID, mattermostUserID)
+	if teamMemberErr != nil {
+		if teamMemberErr.StatusCode == http.StatusNotFound {
+			return http.StatusForbidden, errors.New(constants.ErrorNotATeamMember)
+		}
+
+		p.API.LogError(constants.ErrorGetTeamMember, "Error", teamMemberErr.Error())
+		if teamMemberErr.StatusCode == 0 {
+			return http.StatusInternalServerError, teamMemberErr
+		}
+		return teamMemberErr.StatusCode, teamMemberErr
+	}
+
+	if teamMember == nil || teamMember.DeleteAt != 0 {
+		return http.StatusForbidden, errors.New(constants.ErrorNotATeamMember)
+	}
+
+	return http.StatusOK, nil
+}
+
+// getUserChannelsForTeam returns the channels of a team the user is a member of,
+// after verifying that the user actually belongs to the requested team.
+func (p *Plugin) getUserChannelsForTeam(teamID, mattermostUserID string) ([]*model.Channel, int, error) {
+	if statusCode, err := p.checkTeamMember(teamID, mattermostUserID); err != nil {
+		return nil, statusCode, err
+	}
+
+	channels, channelErr := p.API.GetChannelsForTeamForUser(teamID, mattermostUserID, false)
+	if channelErr != nil {
+		return nil, channelErr.StatusCode, channelErr
+	}
+
+	return channels, http.StatusOK, nil
+}
+
+// getUserChannelsForAllTeams returns the channels of all the teams the user is a member of
+func (p *Plugin) getUserChannelsForAllTeams(mattermostUserID string) ([]*model.Channel, int, error) {
+	teams, teamsErr := p.API.GetTeamsForUser(mattermostUserID)
+	if teamsErr != nil {
+		return nil, teamsErr.StatusCode, teamsErr
+	}
+
+	channels := []*model.Channel{}
+	for _, team := range teams {
+		teamChannels, statusCode, err := p.getUserChannelsForTeam(team.Id, mattermostUserID)
+		if err != nil {
+			return nil, statusCode, err
+		}
+
+		channels = append(channels, teamChannels...)
+	}
+
+	return channels, http.StatusOK, nil
+}
+
+// getOrganization returns the default organization set in the plugin configuration if any, otherwise the provided organization
+// filterProjectsByOrganization returns the linked projects of an organization, its name is matched case-insensitively like when the projects are linked
+func filterProjectsByOrganization(projectList []serializers.ProjectDetails, organization string) []serializers.ProjectDetails {
+	var projects []serializers.ProjectDetails
+	for _, project := range projectList {
+		if strings.EqualFold(project.OrganizationName, organization) {
+			projects = append(projects, project)
+		}
+	}
+
+	return projects
+}
+
+func (p *Plugin) getOrganization(organization string) string {
+	if defaultOrganization := p.getConfiguration().DefaultOrganization; defaultOrganization != "" {
+		return defaultOrganization
+	}
+
+	return organization
+}
+
+// getOrganizationDefaultChannel returns the default channel set in the plugin configuration for the new subscriptions of an organization, it's empty if there is none
+func (p *Plugin) getOrganizationDefaultChannel(organization string) string {
+	defaultChannels, err := p.getConfiguration().GetOrganizationDefaultChannels()
+	if err != nil {
+		return ""
+	}
+
+	return defaultChannels[strings.ToLower(organization)]
+}
+
+var eventTypeAliasRegex = regexp.MustCompile(constants.EventTypeAliasRegex)
+
+// getEventTypeAliases returns the event type aliases of the plugin configuration, the built-in ones are used if the configured ones are invalid
+func (p *Plugin) getEventTypeAliases() map[string]string {
+	aliases, err := p.getConfiguration().GetEventTypeAliases()
+	if err != nil {
+		return constants.DefaultEventTypeAliases
+	}
+
+	return aliases
+}
+
+// resolveEventType maps an event type alias back to its event type, any other value is returned as it is and is validated along with the rest of the request.
+// A value that looks like an alias but isn't one is rejected with the list of the valid aliases.
+func (p *Plugin) resolveEventType(eventType string) (string, error) {
+	aliases := p.getEventTypeAliases()
+	if resolvedEventType, ok := aliases[eventType]; ok {
+		return resolvedEventType, nil
+	}
+
+	if !eventTypeAliasRegex.MatchString(eventType) {
+		return eventType, nil
+	}
+
+	validAliases := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		validAliases = append(validAliases, alias)
+	}
+	sort.Strings(validAliases)
+
+	return "", fmt.Errorf(constants.UnknownEventTypeAlias, eventType, strings.Join(validAliases, ", "))
+}
+
+// validateOrganizationDefaultChannels verifies that every default channel of the organizations in a configuration exists
+func (p *Plugin) validateOrganizationDefaultChannels(configuration *config.Configuration) error {
+	defaultChannels, err := configuration.GetOrganizationDefaultChannels()
+	if err != nil {
+		return err
+	}
+
+	for organization, channelID := range defaultChannels {
+		if _, _, err := p.getValidChannel(channelID); err != nil {
+			if errors.Is(err, ErrChannelNotFound) {
+				return fmt.Errorf(constants.DefaultChannelNotFoundError, channelID, organization)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getWorkItemStateColor returns the color of a work item state as configured in Azure DevOps.
+// The states are cached per project and work item type and the default boards color is used as a fallback.
+func (p *Plugin) getWorkItemStateColor(organization, projectName, workItemType, state, mattermostUserID string) string {
+	if organization == "" || projectName == "" || workItemType == "" || state == "" || mattermostUserID == "" {
+		return constants.IconColorBoards
+	}
+
+	if workItemTypeState := p.getWorkItemTypeState(organization, projectName, workItemType, state, mattermostUserID); workItemTypeState != nil && workItemTypeState.Color != "" {
+		return fmt.Sprintf("#%s", strings.TrimPrefix(workItemTypeState.Color, "#"))
+	}
+
+	return constants.IconColorBoards
+}
+
+// getWorkItemTypeState returns the details of a state of a work item type, the states of each work item type are cached for an hour as they rarely change
+func (p *Plugin) getWorkItemTypeState(organization, projectName, workItemType, state, mattermostUserID string) *serializers.WorkItemTypeState {
+	cacheKey := strings.ToLower(fmt.Sprintf("%s/%s/%s", organization, projectName, workItemType))
+	var workItemTypeStates []*serializers.WorkItemTypeState
+	if cachedStates, ok := p.workItemTypeStates.get(cacheKey, time.Now()); ok {
+		workItemTypeStates = cachedStates
+	} else {
+		states, _, err := p.Client.GetWorkItemTypeStates(organization, projectName, workItemType, mattermostUserID)
+		if err != nil {
+			p.API.LogDebug("Error in getting work item type states from Azure", "Error", err.Error())
+			return nil
+		}
+
+		p.workItemTypeStates.set(cacheKey, states, time.Now().Add(constants.WorkItemTypeStatesCacheTTL))
+		workItemTypeStates = states
+	}
+
+	for _, workItemTypeState := range workItemTypeStates {
+		if strings.EqualFold(workItemTypeState.Name, state) {
+			return workItemTypeState
+		}
+	}
+
+	return nil
+}
+
+// getWorkItemStateColorForSubscription returns the color of a work item state using the organization and creator of the subscription
+func (p *Plugin) getWorkItemStateColorForSubscription(subscriptionID string, projectName, workItemType, state interface{}) string {
+	projectNameString, _ := projectName.(string)
+	workItemTypeString, _ := workItemType.(string)
+	stateString, _ := state.(string)
+	if projectNameString == "" || workItemTypeString == "" || stateString == "" {
+		return constants.IconColorBoards
+	}
+
+	subscription := p.getSubscriptionDetails(subscriptionID)
+	if subscription == nil {
+		return constants.IconColorBoards
+	}
+
+	return p.getWorkItemStateColor(subscription.OrganizationName, projectNameString, workItemTypeString, stateString, subscription.MattermostUserID)
+}
+
+// getSubscriptionDetails returns the stored details of a subscription or nil if they can't be found
+func (p *Plugin) getSubscriptionDetails(subscriptionID string) *serializers.SubscriptionDetails {
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		p.API.LogDebug(constants.FetchSubscriptionListError, "Error", err.Error())
+		return nil
+	}
+
+	for _, subscription := range subscriptionList {
+		if subscription.SubscriptionID == subscriptionID {
+			return subscription
+		}
+	}
+
+	return nil
+}
+
+// hasScope checks if the required scope is present in the granted scopes either directly or through a broader scope
+func hasScope(grantedScopes []string, requiredScope string) bool {
+	for _, grantedScope := range grantedScopes {
+		if grantedScope == requiredScope {
+			return true
+		}
+
+		for _, impliedScope := range constants.ImpliedScopes[grantedScope] {
+			if impliedScope == requiredScope {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getMissingScopeError returns an error referencing the granted scopes of the user if the required scope was not granted
+func (p *Plugin) getMissingScopeError(mattermostUserID, requiredScope string) error {
+	azureDevopsUserID, err := p.Store.LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID)
+	if err != nil {
+		return nil
+	}
+
+	user, err := p.Store.LoadAzureDevopsUserDetails(azureDevopsUserID)
+	if err != nil || user == nil || len(user.Scopes) == 0 {
+		// Scopes are not known for the users connected before they were being stored
+		return nil
+	}
+
+	if hasScope(user.Scopes, requiredScope) {
+		return nil
+	}
+
+	return fmt.Errorf(constants.ErrorMissingScope, requiredScope, strings.Join(user.Scopes, ", "))
+}
+
+// filterChannelsForSubscription keeps only the public and private channels as subscriptions can't be created for DMs and GMs
+func filterChannelsForSubscription(channels []*model.Channel) []*model.Channel {
+	filteredChannels := []*model.Channel{}
+	for _, channel := range channels {
+		if channel.Type == model.CHANNEL_OPEN || channel.Type == model.CHANNEL_PRIVATE {
+			filteredChannels = append(filteredChannels, channel)
+		}
+	}
+
+	return filteredChannels
+}
+
 // TODO: use this function at all the places where baseURL need to be updated this way
 func (p *Plugin) updateBaseURLForReleaseEventTypes(url, eventType string) string {
 	if strings.Contains(eventType, "release") {
-		url = strings.Replace(url, "://", "://vsrm.", 1)
+		return p.getConfiguration().GetReleaseAPIBaseURL()
 	}
 
 	return url
@@ -378,14 +954,15 @@ func (p *Plugin) UpdatePipelineReleaseApprovalPost(requestType, postID, mattermo
 	post, _ := p.API.GetPost(postID)
 	slackAttachment := post.Attachments()[0]
 	slackAttachment.Actions = nil
-	slackAttachment.Fields = []*model.SlackAttachmentField{
+	// The fields after the approvers like the link to the approval are kept as they are
+	slackAttachment.Fields = append([]*model.SlackAttachmentField{
 		slackAttachment.Fields[0],
 		slackAttachment.Fields[1],
 		{
 			Title: "Approvers",
 			Value: fmt.Sprintf("%s %s", constants.PipelineRequestUpdateEmoji[requestType], slackAttachment.Fields[2].Value),
 		},
-	}
+	}, slackAttachment.Fields[3:]...)
 
 	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
 	if _, err := p.API.UpdatePost(post); err != nil {
@@ -448,9 +1025,32 @@ func (p *Plugin) UpdatePipelineRunApprovalPost(approvalSteps []*serializers.Appr
 }
 
 func (p *Plugin) deleteSubscription(subscription *serializers.SubscriptionDetails, mattermostUserID string) (int, error) {
-	// On deletion, if a subscription is not found on the Azure DevOps portal then delete it from Mattermost's KV store
-	if statusCode, err := p.Client.DeleteSubscription(subscription.OrganizationName, subscription.SubscriptionID, mattermostUserID); statusCode != http.StatusNotFound && err != nil {
-		return statusCode, err
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// The subscription on Azure DevOps is kept for the other users sharing it
+	if isSubscriptionShared(subscriptionList, subscription) {
+		if deleteErr := p.Store.DeleteSubscription(subscription); deleteErr != nil {
+			return http.StatusInternalServerError, deleteErr
+		}
+
+		return http.StatusOK, nil
+	}
+
+	// The webhook is kept during the grace period, its deletion is scheduled before its secret is deleted from the KV store
+	if !p.scheduleWebhookDeletion(subscription, mattermostUserID) {
+		// On deletion, if a subscription is not found on the Azure DevOps portal then delete it from Mattermost's KV store.
+		// The last user of a shared subscription may not be allowed to delete the webhook created by another user, it's then left in Azure DevOps.
+		statusCode, err := p.Client.DeleteSubscription(subscription.OrganizationName, subscription.SubscriptionID, mattermostUserID)
+		switch {
+		case err == nil, statusCode == http.StatusNotFound:
+		case subscription.IsSharedWebhook && (statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden):
+			p.API.LogError("Error in deleting the webhook of the shared subscription", "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+		default:
+			return statusCode, err
+		}
 	}
 
 	if deleteErr := p.Store.DeleteSubscription(subscription); deleteErr != nil {
@@ -461,6 +1061,10 @@ func (p *Plugin) deleteSubscription(subscription *serializers.SubscriptionDetail
 		return http.StatusInternalServerError, deleteErr
 	}
 
+	if deleteErr := p.Store.DeleteLastNotification(subscription.SubscriptionID); deleteErr != nil {
+		p.API.LogDebug("Error in deleting the last notification of the subscription", "Error", deleteErr.Error())
+	}
+
 	return http.StatusOK, nil
 }
 
@@ -485,22 +1089,68 @@ func (p *Plugin) VerifySubscriptionWebhookSecretAndGetChannelID(subscriptionID,
 
 // A user can create subscription(s) only for accessible public and private channels
 func (p *Plugin) CheckValidChannelForSubscription(channelID, userID string) (int, error) {
-	channel, err := p.API.GetChannel(channelID)
-	if err != nil {
-		return err.StatusCode, err
+	channel, statusCode, channelErr := p.getValidChannel(channelID)
+	if channelErr != nil {
+		return statusCode, channelErr
 	}
 
+	// The direct and group messages don't belong to a team, and their notifications would only reach their members
 	if channel.Type != model.CHANNEL_PRIVATE && channel.Type != model.CHANNEL_OPEN {
-		return http.StatusForbidden, errors.New("subscription can only be created for a public or private channel")
+		return http.StatusForbidden, errors.New(constants.ErrorChannelTypeForSubscription)
 	}
 
-	if _, err := p.API.GetChannelMember(channelID, userID); err != nil {
+	if channel.DeleteAt != 0 {
+		return http.StatusForbidden, errors.New(constants.ErrorArchivedChannelForSubscription)
+	}
+
+	// The channel can belong to any team, so make sure the user is a member of that team
+	if statusCode, err := p.checkTeamMember(channel.TeamId, userID); err != nil {
+		return statusCode, err
+	}
+
+	// A user who has left the channel is not a member of it anymore, even if the channel is public
+	if _, err := p.API.GetChannelMember(channel.Id, userID); err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return http.StatusForbidden, errors.New(constants.ErrorNotAChannelMember)
+		}
 		return err.StatusCode, err
 	}
 
+	if !p.API.HasPermissionToChannel(userID, channel.Id, model.PERMISSION_CREATE_POST) {
+		return http.StatusForbidden, errors.New(constants.ErrorNoPostPermissionForSubscription)
+	}
+
 	return 0, nil
 }
 
+// normalizeChannelID returns a channel ID received in a request in the form used by Mattermost
+func normalizeChannelID(channelID string) string {
+	return strings.TrimSpace(channelID)
+}
+
+// getValidChannel returns the channel of a channel ID received in a request.
+// Malformed IDs are rejected with a bad request before calling the API, so that every handler responds in the same way.
+func (p *Plugin) getValidChannel(channelID string) (*model.Channel, int, error) {
+	channelID = normalizeChannelID(channelID)
+	if channelID == "" {
+		return nil, http.StatusBadRequest, errors.New(constants.ChannelIDRequired)
+	}
+
+	if !model.IsValidId(channelID) {
+		return nil, http.StatusBadRequest, errors.New(constants.InvalidChannelID)
+	}
+
+	channel, appErr := p.API.GetChannel(channelID)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil, http.StatusNotFound, ErrChannelNotFound
+		}
+		return nil, appErr.StatusCode, appErr
+	}
+
+	return channel, http.StatusOK, nil
+}
+
 func (p *Plugin) SanitizeURLPaths(organization, project, otherPathInput string) (int, error) {
 	// replace escaped characters like `.`, `/`, etc
 	unescapedOrganization, err := url.PathUnescape(organization)
@@ -554,3 +1204,436 @@ func (p *Plugin) SanitizeURLPaths(organization, project, otherPathInput string)
 
 	return 0, nil
 }
+
+// getLinkedProject finds the linked project for the project argument of a slash command.
+// The argument can either be the name of the project or "organization/project" if the project name is not unique.
+func (p *Plugin) getLinkedProject(projectList []serializers.ProjectDetails, projectArgument string) (*serializers.ProjectDetails, error) {
+	organization, projectName := "", projectArgument
+	if parts := strings.SplitN(projectArgument, "/", 2); len(parts) == 2 {
+		organization, projectName = parts[0], parts[1]
+	}
+	organization = p.getOrganization(organization)
+
+	var matchingProjects []serializers.ProjectDetails
+	for _, project := range projectList {
+		if !strings.EqualFold(project.ProjectName, projectName) {
+			continue
+		}
+		if organization != "" && !strings.EqualFold(project.OrganizationName, organization) {
+			continue
+		}
+		matchingProjects = append(matchingProjects, project)
+	}
+
+	switch len(matchingProjects) {
+	case 0:
+		return nil, fmt.Errorf(constants.ProjectNotLinkedWithName, projectArgument)
+	case 1:
+		return &matchingProjects[0], nil
+	default:
+		return nil, fmt.Errorf(constants.MultipleProjectsWithName, projectArgument)
+	}
+}
+
+// applySubscriptionTemplate creates the subscriptions defined in a subscription template for a linked project.
+// Subscriptions which already exist are skipped and the result for each subscription is returned as a markdown table.
+func (p *Plugin) applySubscriptionTemplate(mattermostUserID, channelID, templateName, projectArgument string) (string, error) {
+	templates, err := p.Store.GetSubscriptionTemplates(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchSubscriptionTemplates)
+	}
+
+	var template *serializers.SubscriptionTemplate
+	for _, storedTemplate := range templates {
+		if storedTemplate.Name == templateName {
+			template = storedTemplate
+			break
+		}
+	}
+
+	if template == nil {
+		return fmt.Sprintf(constants.SubscriptionTemplateNotFound, templateName), nil
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	subscriptionList, err := p.Store.GetAllSubscriptions(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.FetchSubscriptionListError)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Subscription template %q applied to %s/%s\n", template.Name, project.OrganizationName, project.ProjectName))
+	sb.WriteString("| Event Type | Channel | Result |\n")
+	sb.WriteString("| :--------- | :------ | :----- |\n")
+
+	channelNames := p.newChannelNameResolver()
+	for _, event := range template.Events {
+		subscriptionChannelID := event.ChannelID
+		if subscriptionChannelID == "" {
+			subscriptionChannelID = channelID
+		}
+
+		result := p.applySubscriptionTemplateEvent(mattermostUserID, subscriptionChannelID, event.EventType, project, &subscriptionList)
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", event.EventType, channelNames.getChannelName(subscriptionChannelID), result))
+	}
+
+	return sb.String(), nil
+}
+
+// applySubscriptionTemplateEvent creates a single subscription of a template and returns the result to be shown to the user
+func (p *Plugin) applySubscriptionTemplateEvent(mattermostUserID, channelID, eventType string, project *serializers.ProjectDetails, subscriptionList *[]*serializers.SubscriptionDetails) string {
+	if _, err := p.CheckValidChannelForSubscription(channelID, mattermostUserID); err != nil {
+		return fmt.Sprintf("Failed: %s", err.Error())
+	}
+
+	subscriptionDetails := &serializers.SubscriptionDetails{
+		OrganizationName: project.OrganizationName,
+		ProjectName:      project.ProjectName,
+		ChannelID:        channelID,
+		EventType:        eventType,
+	}
+	if existingSubscription, isSubscriptionPresent := p.IsSubscriptionPresent(*subscriptionList, subscriptionDetails); isSubscriptionPresent {
+		return fmt.Sprintf("Skipped: already exists with ID %s", existingSubscription.SubscriptionID)
+	}
+
+	if err := p.checkSubscriptionsLimit(*subscriptionList); err != nil {
+		return fmt.Sprintf("Failed: %s", err.Error())
+	}
+
+	subscription, _, err := p.createSubscription(mattermostUserID, &serializers.CreateSubscriptionRequestPayload{
+		Organization: project.OrganizationName,
+		Project:      project.ProjectName,
+		EventType:    eventType,
+		ServiceType:  serializers.GetServiceTypeForEventType(eventType),
+		ChannelID:    channelID,
+	}, project)
+	if errors.Is(err, ErrQueuedForRetry) {
+		return "Queued: Azure DevOps is unavailable, it will be retried automatically"
+	}
+	if err != nil {
+		return fmt.Sprintf("Failed: %s", err.Error())
+	}
+
+	subscriptionDetails.SubscriptionID = subscription.ID
+	*subscriptionList = append(*subscriptionList, subscriptionDetails)
+	return fmt.Sprintf("Created with ID %s", subscription.ID)
+}
+
+// deleteProjectSubscriptions deletes all the subscriptions of a project created by a user, optionally only the ones of a channel.
+// The project doesn't need to be linked anymore. A failure in deleting a subscription doesn't stop the others from being deleted,
+// and the result for each subscription is returned as a markdown table.
+func (p *Plugin) deleteProjectSubscriptions(mattermostUserID, projectArgument, channelID string) (string, error) {
+	organization, projectName := "", projectArgument
+	if parts := strings.SplitN(projectArgument, "/", 2); len(parts) == 2 {
+		organization, projectName = parts[0], parts[1]
+	}
+	organization = p.getOrganization(organization)
+
+	subscriptionList, err := p.Store.GetAllSubscriptions(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.FetchSubscriptionListError)
+	}
+
+	var projectSubscriptions []*serializers.SubscriptionDetails
+	organizations := map[string]bool{}
+	for _, subscription := range subscriptionList {
+		if !strings.EqualFold(subscription.ProjectName, projectName) {
+			continue
+		}
+		if organization != "" && !strings.EqualFold(subscription.OrganizationName, organization) {
+			continue
+		}
+		if channelID != "" && subscription.ChannelID != channelID {
+			continue
+		}
+
+		organizations[strings.ToLower(subscription.OrganizationName)] = true
+		projectSubscriptions = append(projectSubscriptions, subscription)
+	}
+
+	switch {
+	case len(projectSubscriptions) == 0:
+		return fmt.Sprintf(constants.NoProjectSubscriptions, projectArgument), nil
+	case len(organizations) > 1:
+		return fmt.Sprintf(constants.MultipleProjectsWithName, projectArgument), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Subscriptions of %s/%s\n", projectSubscriptions[0].OrganizationName, projectSubscriptions[0].ProjectName))
+	sb.WriteString("| Subscription ID | Event Type | Channel | Result |\n")
+	sb.WriteString("| :-------------- | :--------- | :------ | :----- |\n")
+
+	deletedCount := 0
+	channelNames := p.newChannelNameResolver()
+	for _, subscription := range projectSubscriptions {
+		result := "Deleted"
+		if statusCode, err := p.deleteSubscription(subscription, mattermostUserID); err != nil {
+			p.API.LogError(constants.DeleteSubscriptionError, "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+			result = fmt.Sprintf("Failed: %s", err.Error())
+			if statusCode == http.StatusForbidden {
+				result = "Failed: you need to be a project or team administrator to delete it"
+			}
+		} else {
+			deletedCount++
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", subscription.SubscriptionID, subscription.EventType, channelNames.getChannelName(subscription.ChannelID), escapeTableCell(result)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%d of %d subscription(s) deleted", deletedCount, len(projectSubscriptions)))
+	if deletedCount > 0 {
+		p.API.PublishWebSocketEvent(
+			constants.WSEventSubscriptionDeleted,
+			nil,
+			&model.WebsocketBroadcast{UserId: mattermostUserID},
+		)
+	}
+
+	return sb.String(), nil
+}
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// getWorkItemFieldChanges returns the list of fields changed in a work item update in the format "field: old → new"
+func getWorkItemFieldChanges(fields map[string]interface{}) string {
+	var changes []string
+	for referenceName, value := range fields {
+		if constants.IgnoredWorkItemFieldChanges[referenceName] {
+			continue
+		}
+
+		change, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		oldValue, hasOldValue := change[constants.WorkItemFieldOldValue]
+		newValue, hasNewValue := change[constants.WorkItemFieldNewValue]
+		if !hasOldValue && !hasNewValue {
+			continue
+		}
+
+		// Reference names are like "System.State" or "Microsoft.VSTS.Common.Priority"
+		fieldName := referenceName[strings.LastIndex(referenceName, ".")+1:]
+		changes = append(changes, fmt.Sprintf(constants.WorkItemFieldChangeFormat, fieldName, formatWorkItemFieldValue(oldValue), formatWorkItemFieldValue(newValue)))
+	}
+
+	sort.Strings(changes)
+	return strings.Join(changes, "\n")
+}
+
+// getNotificationStatus returns whether a subscription notification is about something created, updated, closed, failed or succeeded, or about a pull request
+func getNotificationStatus(body *serializers.SubscriptionNotification) string {
+	switch body.EventType {
+	case constants.SubscriptionEventWorkItemCreated, constants.SubscriptionEventReleaseCreated:
+		return constants.NotificationStatusCreated
+	case constants.SubscriptionEventWorkItemDeleted:
+		return constants.NotificationStatusClosed
+	case constants.SubscriptionEventWorkItemUpdated:
+		// Only the updates changing the state of the work item to a done state are shown as closed
+		if change, ok := body.Resource.Fields.All[constants.FieldState].(map[string]interface{}); ok {
+			if state, ok := change[constants.WorkItemFieldNewValue].(string); ok && constants.DefaultSprintStateGroups[strings.ToLower(state)] == constants.SprintStateDone {
+				return constants.NotificationStatusClosed
+			}
+		}
+	case constants.SubscriptionEventPullRequestCreated, constants.SubscriptionEventPullRequestUpdated, constants.SubscriptionEventPullRequestMerged, constants.SubscriptionEventPullRequestCommented:
+		return constants.NotificationStatusPullRequest
+	case constants.SubscriptionEventReleaseAbandoned:
+		return constants.NotificationStatusFailed
+	case constants.SubscriptionEventBuildCompleted:
+		return getNotificationResultStatus(body.Resource.Result)
+	case constants.SubscriptionEventReleaseDeploymentCompleted:
+		return getNotificationResultStatus(body.Resource.Environment.Status)
+	case constants.SubscriptionEventRunStateChanged:
+		return getNotificationResultStatus(body.Resource.Run.Result)
+	case constants.SubscriptionEventRunStageStateChanged:
+		return getNotificationResultStatus(body.Resource.Stage.Result)
+	}
+
+	return constants.NotificationStatusUpdated
+}
+
+// getNotificationResultStatus returns the notification status of the result of a build, pipeline run or release deployment.
+// The results which are empty while the pipeline is running or are only partially successful are shown as updates.
+func getNotificationResultStatus(result string) string {
+	if status, ok := constants.NotificationResultStatuses[strings.ToLower(result)]; ok {
+		return status
+	}
+
+	return constants.NotificationStatusUpdated
+}
+
+// addNotificationEmoji prefixes the pretext of a subscription notification with the emoji configured for its status.
+// The fallback text is set as well since it's used in the notifications of the posts containing only an attachment.
+func (p *Plugin) addNotificationEmoji(attachment *model.SlackAttachment, body *serializers.SubscriptionNotification) {
+	emojis, err := p.getConfiguration().GetNotificationEmojis()
+	if err != nil {
+		p.API.LogError("Error in getting the notification emojis", "Error", err.Error())
+		return
+	}
+
+	emoji := emojis[getNotificationStatus(body)]
+	if emoji == "" {
+		return
+	}
+
+	attachment.Pretext = fmt.Sprintf("%s %s", emoji, attachment.Pretext)
+	attachment.Fallback = attachment.Pretext
+}
+
+// addSubscriptionLabel prefixes the pretext of a notification with the label of its subscription
+func addSubscriptionLabel(attachment *model.SlackAttachment, label string) {
+	if label == "" {
+		return
+	}
+
+	attachment.Pretext = fmt.Sprintf("[%s] %s", label, attachment.Pretext)
+	attachment.Fallback = attachment.Pretext
+}
+
+// formatWorkItemFieldValue converts the value of a work item field to a short plain text
+func formatWorkItemFieldValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return "None"
+	case string:
+		text = strings.Join(strings.Fields(html.UnescapeString(htmlTagRegex.ReplaceAllString(v, " "))), " ")
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		// Identity fields like "Assigned To" contain the details of the user
+		if displayName, ok := v["displayName"].(string); ok {
+			text = displayName
+		} else {
+			text = fmt.Sprint(v)
+		}
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	if text == "" {
+		return "None"
+	}
+
+	if runes := []rune(text); len(runes) > constants.MaxWorkItemFieldChangeValueLength {
+		return string(runes[:constants.MaxWorkItemFieldChangeValueLength]) + "..."
+	}
+
+	return text
+}
+
+var htmlTokenRegex = regexp.MustCompile(`(?s)<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>|<!--.*?-->`)
+var htmlHrefRegex = regexp.MustCompile(`(?i)href\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+var htmlWhitespaceRegex = regexp.MustCompile(`[ \t\r\n\x{00A0}]+`)
+var multipleNewLinesRegex = regexp.MustCompile(`\n{3,}`)
+
+// convertHTMLToMarkdown converts the HTML used by Azure DevOps in rich text fields like descriptions and comments to Markdown.
+// Line breaks, paragraphs, lists, links, bold and italic text are converted and all the other tags are stripped.
+func convertHTMLToMarkdown(text string) string {
+	if !htmlTagRegex.MatchString(text) {
+		return text
+	}
+
+	var sb strings.Builder
+	// The leading whitespace of a text is dropped at the start of a line or a list item
+	atLineStart := true
+	writeText := func(text string) {
+		text = htmlWhitespaceRegex.ReplaceAllString(html.UnescapeString(text), " ")
+		if atLineStart {
+			text = strings.TrimLeft(text, " ")
+		}
+		if text != "" {
+			sb.WriteString(text)
+			atLineStart = false
+		}
+	}
+	writeLineBreak := func() {
+		sb.WriteString("\n")
+		atLineStart = true
+	}
+
+	// Number of the next item for each open list, zero is used for unordered lists
+	var lists []int
+	var links []string
+	lastIndex := 0
+	for _, match := range htmlTokenRegex.FindAllStringSubmatchIndex(text, -1) {
+		writeText(text[lastIndex:match[0]])
+		lastIndex = match[1]
+		if match[4] == -1 {
+			// HTML comment
+			continue
+		}
+
+		isClosingTag := match[3] > match[2]
+		switch tag := strings.ToLower(text[match[4]:match[5]]); tag {
+		case "br", "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "tr", "blockquote", "pre":
+			writeLineBreak()
+		case "b", "strong":
+			sb.WriteString("**")
+		case "i", "em":
+			sb.WriteString("_")
+		case "ul", "ol":
+			switch {
+			case isClosingTag && len(lists) > 0:
+				lists = lists[:len(lists)-1]
+			case !isClosingTag && tag == "ol":
+				lists = append(lists, 1)
+			case !isClosingTag:
+				lists = append(lists, 0)
+			}
+			writeLineBreak()
+		case "li":
+			if isClosingTag {
+				continue
+			}
+
+			writeLineBreak()
+			marker := "- "
+			if len(lists) > 0 {
+				sb.WriteString(strings.Repeat("  ", len(lists)-1))
+				if next := lists[len(lists)-1]; next > 0 {
+					marker = fmt.Sprintf("%d. ", next)
+					lists[len(lists)-1]++
+				}
+			}
+			sb.WriteString(marker)
+		case "a":
+			if !isClosingTag {
+				href := ""
+				if hrefMatch := htmlHrefRegex.FindStringSubmatch(text[match[6]:match[7]]); hrefMatch != nil {
+					href = html.UnescapeString(hrefMatch[1] + hrefMatch[2])
+				}
+				links = append(links, href)
+				if href != "" {
+					sb.WriteString("[")
+				}
+				continue
+			}
+
+			if len(links) > 0 {
+				if href := links[len(links)-1]; href != "" {
+					sb.WriteString(fmt.Sprintf("](%s)", href))
+				}
+				links = links[:len(links)-1]
+			}
+		}
+	}
+	writeText(text[lastIndex:])
+
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.TrimSpace(multipleNewLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -10,7 +10,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"bou.ke/monkey"
 	"github.com/golang/mock/gomock"
@@ -18,6 +20,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
@@ -480,11 +483,13 @@ func TestAddAuthorization(t *testing.T) {
 	p.API = mockAPI
 	p.Store = mockedStore
 	for _, testCase := range []struct {
-		description       string
-		user              *serializers.User
-		token             string
-		parseAuthTokenErr error
-		loadUserErr       error
+		description         string
+		user                *serializers.User
+		organization        string
+		token               string
+		expectedAccessToken string
+		parseAuthTokenErr   error
+		loadUserErr         error
 	}{
 		{
 			description: "AddAuthorization: valid",
@@ -494,7 +499,18 @@ func TestAddAuthorization(t *testing.T) {
 					ID: testutils.MockAzureDevopsUserID,
 				},
 			},
-			token: "mockToken",
+			token:               "mockToken",
+			expectedAccessToken: "mockAccessToken",
+		},
+		{
+			description: "AddAuthorization: token of the organization connection",
+			user: &serializers.User{
+				AccessToken:             "mockAccessToken",
+				OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "mock-organization", AccessToken: "mockOrganizationAccessToken"}},
+			},
+			organization:        "Mock-Organization",
+			token:               "mockToken",
+			expectedAccessToken: "mockOrganizationAccessToken",
 		},
 		{
 			description: "AddAuthorization: error while loading user",
@@ -514,14 +530,17 @@ func TestAddAuthorization(t *testing.T) {
 		t.Run(testCase.description, func(t *testing.T) {
 			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
 
-			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "ParseAuthToken", func(_ *Plugin, _ string) (string, error) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "ParseAuthToken", func(_ *Plugin, accessToken string) (string, error) {
+				if testCase.expectedAccessToken != "" {
+					assert.Equal(t, testCase.expectedAccessToken, accessToken)
+				}
 				return testCase.token, testCase.parseAuthTokenErr
 			})
 
 			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, testCase.loadUserErr)
 
 			req := httptest.NewRequest(http.MethodGet, "/mockURL", bytes.NewBufferString(`{}`))
-			resp := p.AddAuthorization(req, testutils.MockMattermostUserID)
+			resp := p.AddAuthorization(req, testutils.MockMattermostUserID, testCase.organization)
 			if testCase.loadUserErr != nil || testCase.parseAuthTokenErr != nil {
 				assert.NotNil(t, resp)
 				return
@@ -552,6 +571,19 @@ func TestIsProjectLinked(t *testing.T) {
 				OrganizationName: testutils.MockOrganization,
 			},
 		},
+		{
+			description: "IsProjectLinked: project names differing in case and spaces",
+			projectList: []serializers.ProjectDetails{
+				{
+					ProjectName:      "Mockprojectname",
+					OrganizationName: "mockorganization",
+				},
+			},
+			project: serializers.ProjectDetails{
+				ProjectName:      " mockProjectName ",
+				OrganizationName: "MockOrganization",
+			},
+		},
 		{
 			description: "IsProjectLinked: project not present in project list",
 			projectList: []serializers.ProjectDetails{
@@ -604,6 +636,45 @@ func TestIsSubscriptionPresent(t *testing.T) {
 	}
 }
 
+func TestIsSubscriptionPresentIgnoringCase(t *testing.T) {
+	p := Plugin{}
+	subscriptionList := []*serializers.SubscriptionDetails{{OrganizationName: "mockorganization", ProjectName: "MockProjectName", ChannelID: testutils.MockChannelID, EventType: testutils.MockEventType}}
+
+	_, isSubscriptionPresent := p.IsSubscriptionPresent(subscriptionList, &serializers.SubscriptionDetails{OrganizationName: "MockOrganization", ProjectName: "mockprojectname", ChannelID: testutils.MockChannelID, EventType: testutils.MockEventType})
+	assert.True(t, isSubscriptionPresent)
+
+	_, isSubscriptionPresent = p.IsSubscriptionPresent(subscriptionList, &serializers.SubscriptionDetails{OrganizationName: "MockOrganization", ProjectName: "mockOtherProject", ChannelID: testutils.MockChannelID, EventType: testutils.MockEventType})
+	assert.False(t, isSubscriptionPresent)
+}
+
+func TestIsSubscriptionOfProject(t *testing.T) {
+	project := &serializers.ProjectDetails{OrganizationName: "mockorganization", ProjectID: testutils.MockProjectID, ProjectName: "MockProjectName"}
+	for _, testCase := range []struct {
+		description  string
+		subscription *serializers.SubscriptionDetails
+		expected     bool
+	}{
+		{
+			description:  "IsSubscriptionOfProject: subscription of a renamed project is matched by its ID",
+			subscription: &serializers.SubscriptionDetails{OrganizationName: "MockOrganization", ProjectID: testutils.MockProjectID, ProjectName: "mockOldProjectName"},
+			expected:     true,
+		},
+		{
+			description:  "IsSubscriptionOfProject: subscription of another project",
+			subscription: &serializers.SubscriptionDetails{OrganizationName: "mockorganization", ProjectID: "mockOtherProjectID", ProjectName: "MockProjectName"},
+		},
+		{
+			description:  "IsSubscriptionOfProject: subscription without a project ID is matched by the names ignoring their case",
+			subscription: &serializers.SubscriptionDetails{OrganizationName: "MockOrganization", ProjectName: "mockprojectname"},
+			expected:     true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, isSubscriptionOfProject(testCase.subscription, project))
+		})
+	}
+}
+
 func TestIsAnyProjectLinked(t *testing.T) {
 	p := Plugin{}
 	mockAPI := &plugintest.API{}
@@ -712,6 +783,27 @@ func TestGetOffsetAndLimitFromQueryParams(t *testing.T) {
 			expectedOffset:    constants.DefaultPerPageLimit,
 			expectedLimit:     constants.DefaultPerPageLimit,
 		},
+		{
+			description:       "GetOffsetAndLimitFromQueryParams: negative page query param",
+			queryParamPage:    "-1",
+			queryParamPerPage: "20",
+			expectedOffset:    0,
+			expectedLimit:     20,
+		},
+		{
+			description:       "GetOffsetAndLimitFromQueryParams: negative per_page query param",
+			queryParamPage:    "2",
+			queryParamPerPage: "-5",
+			expectedOffset:    2 * constants.DefaultPerPageLimit,
+			expectedLimit:     constants.DefaultPerPageLimit,
+		},
+		{
+			description:       "GetOffsetAndLimitFromQueryParams: zero per_page query param",
+			queryParamPage:    "0",
+			queryParamPerPage: "0",
+			expectedOffset:    0,
+			expectedLimit:     constants.DefaultPerPageLimit,
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			if testCase.queryParamPage != "1" && testCase.queryParamPerPage != "10" && testCase.expectedLimit != 10 && testCase.expectedOffset != 10 {
@@ -735,12 +827,18 @@ func TestParseSubscriptionsToCommandResponse(t *testing.T) {
 	p := Plugin{}
 	mockAPI := &plugintest.API{}
 	p.API = mockAPI
+	subscriptionsWithCreationTimes := []*serializers.SubscriptionDetails{
+		{SubscriptionID: "mockOldSubscriptionID", ChannelID: testutils.MockChannelID, ServiceType: constants.CommandBoards, CreatedAt: time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{SubscriptionID: "mockUnknownSubscriptionID", ChannelID: testutils.MockChannelID, ServiceType: constants.CommandBoards},
+		{SubscriptionID: "mockNewSubscriptionID", ChannelID: testutils.MockChannelID, ServiceType: constants.CommandBoards, CreatedAt: time.Date(2026, 10, 2, 15, 4, 0, 0, time.UTC)},
+	}
 	for _, testCase := range []struct {
 		description       string
 		subscriptionsList []*serializers.SubscriptionDetails
 		command           string
 		expectedMessage   string
 		createdBy         string
+		oldestFirst       bool
 		err               error
 	}{
 		{
@@ -767,7 +865,7 @@ func TestParseSubscriptionsToCommandResponse(t *testing.T) {
 			command:           constants.CommandBoards,
 			subscriptionsList: testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, constants.CommandBoards, constants.SubscriptionEventWorkItemCreated),
 			createdBy:         constants.FilterCreatedByMe,
-			expectedMessage:   fmt.Sprintf("###### %s subscription(s)\n| Subscription ID | Organization | Project | Event Type | Created By | Channel |\n| :-------------- | :----------- | :------ | :--------- | :--------- | :------ |\n| mockSubscriptionID | mockOrganization | mockProjectName | Work Item Created | mockCreatedBy | mockChannelName |\n", cases.Title(language.Und).String(constants.CommandBoards)),
+			expectedMessage:   fmt.Sprintf("###### %s subscription(s)\n| Subscription ID | Organization | Project | Event Type | Created By | Created At | Channel | Label |\n| :-------------- | :----------- | :------ | :--------- | :--------- | :--------- | :------ | :---- |\n| mockSubscriptionID | mockOrganization | mockProjectName | Work Item Created | mockCreatedBy | Unknown | mockChannelName (mockTeamName) |  |\n", cases.Title(language.Und).String(constants.CommandBoards)),
 		},
 		{
 			description:       "ParseSubscriptionsToCommandResponse: subscriptions created by anyone",
@@ -775,7 +873,7 @@ func TestParseSubscriptionsToCommandResponse(t *testing.T) {
 			subscriptionsList: testutils.GetSuscriptionDetailsPayload(testutils.MockMattermostUserID, constants.CommandBoards, constants.SubscriptionEventWorkItemCreated),
 
 			createdBy:       constants.FilterCreatedByAnyone,
-			expectedMessage: fmt.Sprintf("###### %s subscription(s)\n| Subscription ID | Organization | Project | Event Type | Created By | Channel |\n| :-------------- | :----------- | :------ | :--------- | :--------- | :------ |\n| mockSubscriptionID | mockOrganization | mockProjectName | Work Item Created | mockCreatedBy | mockChannelName |\n", cases.Title(language.Und).String(constants.CommandBoards)),
+			expectedMessage: fmt.Sprintf("###### %s subscription(s)\n| Subscription ID | Organization | Project | Event Type | Created By | Created At | Channel | Label |\n| :-------------- | :----------- | :------ | :--------- | :--------- | :--------- | :------ | :---- |\n| mockSubscriptionID | mockOrganization | mockProjectName | Work Item Created | mockCreatedBy | Unknown | mockChannelName (mockTeamName) |  |\n", cases.Title(language.Und).String(constants.CommandBoards)),
 		},
 		{
 			description:       "ParseSubscriptionsToCommandResponse: no subscriptions created by the user is present",
@@ -784,16 +882,1142 @@ func TestParseSubscriptionsToCommandResponse(t *testing.T) {
 			createdBy:         constants.FilterCreatedByMe,
 			expectedMessage:   fmt.Sprintf("No %s subscription exists", constants.CommandBoards),
 		},
+		{
+			description:       "ParseSubscriptionsToCommandResponse: newest subscriptions are listed first",
+			command:           constants.CommandBoards,
+			subscriptionsList: subscriptionsWithCreationTimes,
+			createdBy:         constants.FilterCreatedByAnyone,
+			expectedMessage:   "###### Boards subscription(s)\n| Subscription ID | Organization | Project | Event Type | Created By | Created At | Channel | Label |\n| :-------------- | :----------- | :------ | :--------- | :--------- | :--------- | :------ | :---- |\n| mockNewSubscriptionID |  |  |  |  | Oct 2, 2026 15:04 UTC | mockChannelName (mockTeamName) |  |\n| mockOldSubscriptionID |  |  |  |  | Jan 2, 2026 15:04 UTC | mockChannelName (mockTeamName) |  |\n| mockUnknownSubscriptionID |  |  |  |  | Unknown | mockChannelName (mockTeamName) |  |\n",
+		},
+		{
+			description:       "ParseSubscriptionsToCommandResponse: oldest subscriptions are listed first",
+			command:           constants.CommandBoards,
+			subscriptionsList: subscriptionsWithCreationTimes,
+			createdBy:         constants.FilterCreatedByAnyone,
+			oldestFirst:       true,
+			expectedMessage:   "###### Boards subscription(s)\n| Subscription ID | Organization | Project | Event Type | Created By | Created At | Channel | Label |\n| :-------------- | :----------- | :------ | :--------- | :--------- | :--------- | :------ | :---- |\n| mockUnknownSubscriptionID |  |  |  |  | Unknown | mockChannelName (mockTeamName) |  |\n| mockOldSubscriptionID |  |  |  |  | Jan 2, 2026 15:04 UTC | mockChannelName (mockTeamName) |  |\n| mockNewSubscriptionID |  |  |  |  | Oct 2, 2026 15:04 UTC | mockChannelName (mockTeamName) |  |\n",
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID, DisplayName: "mockChannelName", TeamId: testutils.MockTeamID}, nil)
+			mockAPI.On("GetTeam", testutils.MockTeamID).Return(&model.Team{Id: testutils.MockTeamID, DisplayName: "mockTeamName"}, nil)
 
 			monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GetSubscriptionsForAccessibleChannelsOrProjects", func(_ *Plugin, _ []*serializers.SubscriptionDetails, _, _, _ string) ([]*serializers.SubscriptionDetails, error) {
 				return testCase.subscriptionsList, testCase.err
 			})
 
-			message := p.ParseSubscriptionsToCommandResponse(testCase.subscriptionsList, testutils.MockChannelID, testCase.createdBy, testutils.MockMattermostUserID, testCase.command, "mockTeamID")
+			message := p.ParseSubscriptionsToCommandResponse(testCase.subscriptionsList, testutils.MockChannelID, testCase.createdBy, testutils.MockMattermostUserID, testCase.command, "mockTeamID", testCase.oldestFirst)
 			assert.Equal(t, testCase.expectedMessage, message)
 		})
 	}
 }
+
+func TestGetUserChannelsForTeam(t *testing.T) {
+	p := Plugin{}
+	for _, testCase := range []struct {
+		description        string
+		teamMember         *model.TeamMember
+		teamMemberErr      *model.AppError
+		channels           []*model.Channel
+		channelErr         *model.AppError
+		expectedStatusCode int
+		expectedErr        error
+	}{
+		{
+			description: "GetUserChannelsForTeam: user is a member of the team",
+			teamMember: &model.TeamMember{
+				TeamId: testutils.MockTeamID,
+				UserId: testutils.MockMattermostUserID,
+			},
+			channels: []*model.Channel{
+				{
+					Id:   testutils.MockChannelID,
+					Type: model.CHANNEL_OPEN,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "GetUserChannelsForTeam: user is not a member of the team",
+			teamMemberErr:      &model.AppError{StatusCode: http.StatusNotFound, Message: "team member not found"},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        errors.New(constants.ErrorNotATeamMember),
+		},
+		{
+			description:        "GetUserChannelsForTeam: error in getting the team member",
+			teamMemberErr:      &model.AppError{StatusCode: http.StatusServiceUnavailable, Message: "error getting the team member"},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedErr:        &model.AppError{StatusCode: http.StatusServiceUnavailable, Message: "error getting the team member"},
+		},
+		{
+			description:        "GetUserChannelsForTeam: error without a status code in getting the team member",
+			teamMemberErr:      &model.AppError{Message: "error getting the team member"},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        &model.AppError{Message: "error getting the team member"},
+		},
+		{
+			description: "GetUserChannelsForTeam: user has left the team",
+			teamMember: &model.TeamMember{
+				TeamId:   testutils.MockTeamID,
+				UserId:   testutils.MockMattermostUserID,
+				DeleteAt: 1,
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        errors.New(constants.ErrorNotATeamMember),
+		},
+		{
+			description: "GetUserChannelsForTeam: error in getting channels",
+			teamMember: &model.TeamMember{
+				TeamId: testutils.MockTeamID,
+				UserId: testutils.MockMattermostUserID,
+			},
+			channelErr:         &model.AppError{StatusCode: http.StatusInternalServerError, Message: "error in getting channels"},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        &model.AppError{StatusCode: http.StatusInternalServerError, Message: "error in getting channels"},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p.API = mockAPI
+
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			mockAPI.On("GetTeamMember", testutils.MockTeamID, testutils.MockMattermostUserID).Return(testCase.teamMember, testCase.teamMemberErr)
+			mockAPI.On("GetChannelsForTeamForUser", testutils.MockTeamID, testutils.MockMattermostUserID, false).Return(testCase.channels, testCase.channelErr)
+
+			channels, statusCode, err := p.getUserChannelsForTeam(testutils.MockTeamID, testutils.MockMattermostUserID)
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.expectedErr != nil {
+				assert.EqualError(t, err, testCase.expectedErr.Error())
+				assert.Nil(t, channels)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.channels, channels)
+		})
+	}
+}
+
+func TestCheckValidChannelForSubscription(t *testing.T) {
+	defer monkey.UnpatchAll()
+	p := Plugin{}
+	monkey.Patch(model.IsValidId, func(string) bool {
+		return true
+	})
+	for _, testCase := range []struct {
+		description        string
+		channel            *model.Channel
+		teamMember         *model.TeamMember
+		teamMemberErr      *model.AppError
+		channelMemberErr   *model.AppError
+		canPost            bool
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			description: "CheckValidChannelForSubscription: channel in a team the user belongs to",
+			channel: &model.Channel{
+				Id:     testutils.MockChannelID,
+				TeamId: testutils.MockTeamID,
+				Type:   model.CHANNEL_OPEN,
+			},
+			teamMember: &model.TeamMember{
+				TeamId: testutils.MockTeamID,
+				UserId: testutils.MockMattermostUserID,
+			},
+			canPost: true,
+		},
+		{
+			description: "CheckValidChannelForSubscription: channel in a team the user does not belong to",
+			channel: &model.Channel{
+				Id:     testutils.MockChannelID,
+				TeamId: testutils.MockTeamID,
+				Type:   model.CHANNEL_PRIVATE,
+			},
+			teamMemberErr:      &model.AppError{StatusCode: http.StatusNotFound},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        constants.ErrorNotATeamMember,
+		},
+		{
+			description: "CheckValidChannelForSubscription: error in getting the team member",
+			channel: &model.Channel{
+				Id:     testutils.MockChannelID,
+				TeamId: testutils.MockTeamID,
+				Type:   model.CHANNEL_OPEN,
+			},
+			teamMemberErr:      &model.AppError{StatusCode: http.StatusServiceUnavailable, Message: "error getting the team member"},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedErr:        "error getting the team member",
+		},
+		{
+			description: "CheckValidChannelForSubscription: direct channel",
+			channel: &model.Channel{
+				Id:   testutils.MockChannelID,
+				Type: model.CHANNEL_DIRECT,
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        constants.ErrorChannelTypeForSubscription,
+		},
+		{
+			description: "CheckValidChannelForSubscription: group channel",
+			channel: &model.Channel{
+				Id:   testutils.MockChannelID,
+				Type: model.CHANNEL_GROUP,
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        constants.ErrorChannelTypeForSubscription,
+		},
+		{
+			description: "CheckValidChannelForSubscription: archived channel",
+			channel: &model.Channel{
+				Id:       testutils.MockChannelID,
+				TeamId:   testutils.MockTeamID,
+				Type:     model.CHANNEL_OPEN,
+				DeleteAt: 1,
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        constants.ErrorArchivedChannelForSubscription,
+		},
+		{
+			description: "CheckValidChannelForSubscription: public channel the user has left",
+			channel: &model.Channel{
+				Id:     testutils.MockChannelID,
+				TeamId: testutils.MockTeamID,
+				Type:   model.CHANNEL_OPEN,
+			},
+			teamMember: &model.TeamMember{
+				TeamId: testutils.MockTeamID,
+				UserId: testutils.MockMattermostUserID,
+			},
+			channelMemberErr:   &model.AppError{StatusCode: http.StatusNotFound},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        constants.ErrorNotAChannelMember,
+		},
+		{
+			description: "CheckValidChannelForSubscription: error in getting the channel member",
+			channel: &model.Channel{
+				Id:     testutils.MockChannelID,
+				TeamId: testutils.MockTeamID,
+				Type:   model.CHANNEL_OPEN,
+			},
+			teamMember: &model.TeamMember{
+				TeamId: testutils.MockTeamID,
+				UserId: testutils.MockMattermostUserID,
+			},
+			channelMemberErr:   &model.AppError{StatusCode: http.StatusInternalServerError, Message: "error getting the channel member"},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error getting the channel member",
+		},
+		{
+			description: "CheckValidChannelForSubscription: read-only channel",
+			channel: &model.Channel{
+				Id:     testutils.MockChannelID,
+				TeamId: testutils.MockTeamID,
+				Type:   model.CHANNEL_OPEN,
+			},
+			teamMember: &model.TeamMember{
+				TeamId: testutils.MockTeamID,
+				UserId: testutils.MockMattermostUserID,
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        constants.ErrorNoPostPermissionForSubscription,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p.API = mockAPI
+
+			mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			mockAPI.On("GetChannel", testutils.MockChannelID).Return(testCase.channel, nil)
+			mockAPI.On("GetTeamMember", testutils.MockTeamID, testutils.MockMattermostUserID).Return(testCase.teamMember, testCase.teamMemberErr)
+			mockAPI.On("GetChannelMember", testutils.MockChannelID, testutils.MockMattermostUserID).Return(&model.ChannelMember{}, testCase.channelMemberErr)
+			mockAPI.On("HasPermissionToChannel", testutils.MockMattermostUserID, testutils.MockChannelID, model.PERMISSION_CREATE_POST).Return(testCase.canPost)
+
+			statusCode, err := p.CheckValidChannelForSubscription(testutils.MockChannelID, testutils.MockMattermostUserID)
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.expectedErr != "" {
+				assert.ErrorContains(t, err, testCase.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetValidChannel(t *testing.T) {
+	p := Plugin{}
+	validChannelID := model.NewId()
+	for _, testCase := range []struct {
+		description        string
+		channelID          string
+		channelErr         *model.AppError
+		expectedStatusCode int
+		expectedErr        error
+	}{
+		{
+			description:        "GetValidChannel: valid channel ID",
+			channelID:          validChannelID,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "GetValidChannel: channel ID with surrounding spaces",
+			channelID:          fmt.Sprintf("  %s\t", validChannelID),
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "GetValidChannel: empty channel ID",
+			channelID:          "  ",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        errors.New(constants.ChannelIDRequired),
+		},
+		{
+			description:        "GetValidChannel: malformed channel ID",
+			channelID:          "mockChannelID",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        errors.New(constants.InvalidChannelID),
+		},
+		{
+			description:        "GetValidChannel: channel does not exist",
+			channelID:          validChannelID,
+			channelErr:         &model.AppError{StatusCode: http.StatusNotFound},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        ErrChannelNotFound,
+		},
+		{
+			description:        "GetValidChannel: error in getting the channel",
+			channelID:          validChannelID,
+			channelErr:         &model.AppError{Message: "error in getting the channel", StatusCode: http.StatusInternalServerError},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        &model.AppError{Message: "error in getting the channel", StatusCode: http.StatusInternalServerError},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p.API = mockAPI
+
+			mockAPI.On("GetChannel", validChannelID).Return(&model.Channel{Id: validChannelID}, testCase.channelErr)
+
+			channel, statusCode, err := p.getValidChannel(testCase.channelID)
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			if testCase.expectedErr != nil {
+				assert.EqualError(t, err, testCase.expectedErr.Error())
+				assert.Nil(t, channel)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, validChannelID, channel.Id)
+		})
+	}
+}
+
+func TestValidateOrganizationDefaultChannels(t *testing.T) {
+	p := Plugin{}
+	channelID := model.NewId()
+	for _, testCase := range []struct {
+		description string
+		channelErr  *model.AppError
+		expectedErr string
+	}{
+		{
+			description: "ValidateOrganizationDefaultChannels: default channel exists",
+		},
+		{
+			description: "ValidateOrganizationDefaultChannels: default channel does not exist",
+			channelErr:  &model.AppError{StatusCode: http.StatusNotFound},
+			expectedErr: fmt.Sprintf(constants.DefaultChannelNotFoundError, channelID, "mockorganization"),
+		},
+		{
+			description: "ValidateOrganizationDefaultChannels: error in getting the default channel",
+			channelErr:  &model.AppError{Message: "error in getting the channel", StatusCode: http.StatusInternalServerError},
+			expectedErr: (&model.AppError{Message: "error in getting the channel", StatusCode: http.StatusInternalServerError}).Error(),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			p.API = mockAPI
+
+			mockAPI.On("GetChannel", channelID).Return(&model.Channel{Id: channelID}, testCase.channelErr)
+
+			err := p.validateOrganizationDefaultChannels(&config.Configuration{OrganizationDefaultChannels: fmt.Sprintf("mockOrganization=%s", channelID)})
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResolveEventType(t *testing.T) {
+	p := Plugin{}
+	p.setConfiguration(&config.Configuration{EventTypeAliases: "pr-done=git.pullrequest.merged"})
+	for _, testCase := range []struct {
+		description       string
+		eventType         string
+		expectedEventType string
+		expectedErr       string
+	}{
+		{
+			description:       "ResolveEventType: event type is returned as it is",
+			eventType:         constants.SubscriptionEventPullRequestCreated,
+			expectedEventType: constants.SubscriptionEventPullRequestCreated,
+		},
+		{
+			description:       "ResolveEventType: built-in alias",
+			eventType:         "pr-created",
+			expectedEventType: constants.SubscriptionEventPullRequestCreated,
+		},
+		{
+			description:       "ResolveEventType: alias defined in the configuration",
+			eventType:         "pr-done",
+			expectedEventType: constants.SubscriptionEventPullRequestMerged,
+		},
+		{
+			description:       "ResolveEventType: value which is not an alias is left for the validation",
+			eventType:         testutils.MockEventType,
+			expectedEventType: testutils.MockEventType,
+		},
+		{
+			description: "ResolveEventType: unknown alias",
+			eventType:   "pr-closed",
+			expectedErr: "Unknown event type alias \"pr-closed\", valid aliases are build-completed, code-pushed, pr-commented, pr-created, pr-done, pr-merged, pr-updated, " +
+				"release-abandoned, release-approval-completed, release-approval-pending, release-created, release-deployment-completed, release-deployment-started, " +
+				"run-approval-completed, run-approval-pending, run-stage-changed, run-state-changed, workitem-commented, workitem-created, workitem-deleted, workitem-updated",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			eventType, err := p.resolveEventType(testCase.eventType)
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedEventType, eventType)
+		})
+	}
+
+	t.Run("ResolveEventType: built-in aliases are used if the configured ones are invalid", func(t *testing.T) {
+		p.setConfiguration(&config.Configuration{EventTypeAliases: "pr-created=git.push"})
+
+		eventType, err := p.resolveEventType("pr-created")
+		require.NoError(t, err)
+		assert.Equal(t, constants.SubscriptionEventPullRequestCreated, eventType)
+	})
+}
+
+func TestGetOrganization(t *testing.T) {
+	p := Plugin{}
+	for _, testCase := range []struct {
+		description          string
+		defaultOrganization  string
+		organization         string
+		expectedOrganization string
+	}{
+		{
+			description:          "GetOrganization: default organization is not configured",
+			organization:         testutils.MockOrganization,
+			expectedOrganization: testutils.MockOrganization,
+		},
+		{
+			description:          "GetOrganization: default organization takes precedence",
+			defaultOrganization:  "mockdefaultorganization",
+			organization:         testutils.MockOrganization,
+			expectedOrganization: "mockdefaultorganization",
+		},
+		{
+			description:          "GetOrganization: organization is not provided",
+			defaultOrganization:  "mockdefaultorganization",
+			expectedOrganization: "mockdefaultorganization",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p.setConfiguration(&config.Configuration{
+				DefaultOrganization: testCase.defaultOrganization,
+			})
+
+			assert.Equal(t, testCase.expectedOrganization, p.getOrganization(testCase.organization))
+		})
+	}
+}
+
+func TestGetWorkItemStateColor(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	for _, testCase := range []struct {
+		description   string
+		state         string
+		states        []*serializers.WorkItemTypeState
+		err           error
+		expectedColor string
+	}{
+		{
+			description: "GetWorkItemStateColor: color of the state is present",
+			state:       "Active",
+			states: []*serializers.WorkItemTypeState{
+				{Name: "New", Color: "b2b2b2"},
+				{Name: "Active", Color: "007acc"},
+			},
+			expectedColor: "#007acc",
+		},
+		{
+			description: "GetWorkItemStateColor: state is not present",
+			state:       "Closed",
+			states: []*serializers.WorkItemTypeState{
+				{Name: "New", Color: "b2b2b2"},
+			},
+			expectedColor: constants.IconColorBoards,
+		},
+		{
+			description:   "GetWorkItemStateColor: error in getting the states",
+			state:         "Active",
+			err:           errors.New("error in getting the states"),
+			expectedColor: constants.IconColorBoards,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := setupMockPlugin(mockAPI, nil, mockedClient)
+			mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
+
+			mockedClient.EXPECT().GetWorkItemTypeStates(testutils.MockOrganization, testutils.MockProjectName, "Task", testutils.MockMattermostUserID).Return(testCase.states, http.StatusOK, testCase.err)
+
+			color := p.getWorkItemStateColor(testutils.MockOrganization, testutils.MockProjectName, "Task", testCase.state, testutils.MockMattermostUserID)
+			assert.Equal(t, testCase.expectedColor, color)
+		})
+	}
+
+	t.Run("GetWorkItemStateColor: states are cached per project and work item type", func(t *testing.T) {
+		p := setupMockPlugin(mockAPI, nil, mockedClient)
+		mockedClient.EXPECT().GetWorkItemTypeStates(testutils.MockOrganization, testutils.MockProjectName, "Bug", testutils.MockMattermostUserID).Return([]*serializers.WorkItemTypeState{
+			{Name: "Active", Color: "007acc"},
+			{Name: "Resolved", Color: "ff9d00"},
+		}, http.StatusOK, nil).Times(1)
+
+		assert.Equal(t, "#007acc", p.getWorkItemStateColor(testutils.MockOrganization, testutils.MockProjectName, "Bug", "Active", testutils.MockMattermostUserID))
+		assert.Equal(t, "#ff9d00", p.getWorkItemStateColor(testutils.MockOrganization, testutils.MockProjectName, "Bug", "Resolved", testutils.MockMattermostUserID))
+	})
+
+	t.Run("GetWorkItemStateColor: state is empty", func(t *testing.T) {
+		p := setupMockPlugin(mockAPI, nil, mockedClient)
+		assert.Equal(t, constants.IconColorBoards, p.getWorkItemStateColor(testutils.MockOrganization, testutils.MockProjectName, "Bug", "", testutils.MockMattermostUserID))
+	})
+}
+
+func TestHasScope(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		grantedScopes []string
+		requiredScope string
+		expected      bool
+	}{
+		{
+			description:   "HasScope: scope is granted directly",
+			grantedScopes: []string{constants.ScopeWorkWrite},
+			requiredScope: constants.ScopeWorkWrite,
+			expected:      true,
+		},
+		{
+			description:   "HasScope: scope is granted through a broader scope",
+			grantedScopes: []string{constants.ScopeWorkFull},
+			requiredScope: constants.ScopeWorkWrite,
+			expected:      true,
+		},
+		{
+			description:   "HasScope: only a narrower scope is granted",
+			grantedScopes: []string{constants.ScopeWork},
+			requiredScope: constants.ScopeWorkWrite,
+		},
+		{
+			description:   "HasScope: no scope is granted",
+			requiredScope: constants.ScopeWorkWrite,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, hasScope(testCase.grantedScopes, testCase.requiredScope))
+		})
+	}
+}
+
+func TestGetMissingScopeError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+	for _, testCase := range []struct {
+		description   string
+		user          *serializers.User
+		loadUserError error
+		expectedError string
+	}{
+		{
+			description:   "GetMissingScopeError: scope is missing",
+			user:          &serializers.User{Scopes: []string{constants.ScopeWork}},
+			expectedError: fmt.Sprintf(constants.ErrorMissingScope, constants.ScopeWorkWrite, constants.ScopeWork),
+		},
+		{
+			description: "GetMissingScopeError: scope is granted",
+			user:        &serializers.User{Scopes: []string{constants.ScopeWorkFull}},
+		},
+		{
+			description: "GetMissingScopeError: scopes are not stored for the user",
+			user:        &serializers.User{},
+		},
+		{
+			description:   "GetMissingScopeError: error in loading the user",
+			loadUserError: errors.New("error in loading the user"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(testCase.user, testCase.loadUserError)
+
+			err := p.getMissingScopeError(testutils.MockMattermostUserID, constants.ScopeWorkWrite)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetLinkedProject(t *testing.T) {
+	p := setupMockPlugin(&plugintest.API{}, nil, nil)
+	projectList := []serializers.ProjectDetails{
+		{OrganizationName: "mockOrganization", ProjectName: "mockProjectName"},
+		{OrganizationName: "mockOtherOrganization", ProjectName: "mockProjectName"},
+		{OrganizationName: "mockOrganization", ProjectName: "mockOtherProjectName"},
+	}
+	for _, testCase := range []struct {
+		description          string
+		projectArgument      string
+		expectedOrganization string
+		expectedError        string
+	}{
+		{
+			description:          "GetLinkedProject: unique project name",
+			projectArgument:      "MockOtherProjectName",
+			expectedOrganization: "mockOrganization",
+		},
+		{
+			description:          "GetLinkedProject: project name with organization",
+			projectArgument:      "mockOtherOrganization/mockProjectName",
+			expectedOrganization: "mockOtherOrganization",
+		},
+		{
+			description:     "GetLinkedProject: project name linked for multiple organizations",
+			projectArgument: "mockProjectName",
+			expectedError:   fmt.Sprintf(constants.MultipleProjectsWithName, "mockProjectName"),
+		},
+		{
+			description:     "GetLinkedProject: project is not linked",
+			projectArgument: "mockUnknownProject",
+			expectedError:   fmt.Sprintf(constants.ProjectNotLinkedWithName, "mockUnknownProject"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			project, err := p.getLinkedProject(projectList, testCase.projectArgument)
+			if testCase.expectedError != "" {
+				assert.Nil(t, project)
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedOrganization, project.OrganizationName)
+		})
+	}
+}
+
+func TestApplySubscriptionTemplate(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "CheckValidChannelForSubscription", func(*Plugin, string, string) (int, error) {
+		return 0, nil
+	})
+	mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{DisplayName: "mockChannelName"}, nil)
+	mockAPI.On("GetUser", testutils.MockMattermostUserID).Return(&model.User{Username: "mockUsername"}, nil)
+	mockAPI.On("GetConfig").Return(&model.Config{})
+
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName, ProjectID: testutils.MockProjectID}
+	template := &serializers.SubscriptionTemplate{
+		Name: "mockTemplate",
+		Events: []*serializers.SubscriptionTemplateEvent{
+			{EventType: constants.SubscriptionEventWorkItemCreated},
+			{EventType: constants.SubscriptionEventCodePushed},
+		},
+	}
+
+	t.Run("ApplySubscriptionTemplate: subscriptions are created and existing ones are skipped", func(t *testing.T) {
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionTemplate{template}, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionDetails{{
+			SubscriptionID:   "mockExistingSubscriptionID",
+			OrganizationName: testutils.MockOrganization,
+			ProjectName:      testutils.MockProjectName,
+			ChannelID:        testutils.MockChannelID,
+			EventType:        constants.SubscriptionEventCodePushed,
+		}}, nil)
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(nil, nil)
+		mockedClient.EXPECT().CreateSubscription(gomock.Any(), gomock.Any(), testutils.MockChannelID, gomock.Any(), testutils.MockMattermostUserID, gomock.Any()).DoAndReturn(
+			func(body *serializers.CreateSubscriptionRequestPayload, _ *serializers.ProjectDetails, _, _, _, _ string) (*serializers.SubscriptionValue, int, error) {
+				assert.Equal(t, constants.SubscriptionEventWorkItemCreated, body.EventType)
+				assert.Equal(t, constants.ServiceTypeBoards, body.ServiceType)
+				return &serializers.SubscriptionValue{ID: testutils.MockSubscriptionID}, http.StatusOK, nil
+			})
+		mockedStore.EXPECT().StoreSubscriptionAndChannelIDMap(testutils.MockSubscriptionID, gomock.Any(), testutils.MockChannelID).Return(nil)
+		mockedStore.EXPECT().StoreSubscription(gomock.Any()).Return(nil)
+
+		message, err := p.applySubscriptionTemplate(testutils.MockMattermostUserID, testutils.MockChannelID, "mockTemplate", testutils.MockProjectName)
+		assert.NoError(t, err)
+		assert.Contains(t, message, fmt.Sprintf("| %s | mockChannelName | Created with ID %s |", constants.SubscriptionEventWorkItemCreated, testutils.MockSubscriptionID))
+		assert.Contains(t, message, fmt.Sprintf("| %s | mockChannelName | Skipped: already exists with ID mockExistingSubscriptionID |", constants.SubscriptionEventCodePushed))
+	})
+
+	t.Run("ApplySubscriptionTemplate: template does not exist", func(t *testing.T) {
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionTemplate{template}, nil)
+
+		message, err := p.applySubscriptionTemplate(testutils.MockMattermostUserID, testutils.MockChannelID, "mockUnknownTemplate", testutils.MockProjectName)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.SubscriptionTemplateNotFound, "mockUnknownTemplate"), message)
+	})
+
+	t.Run("ApplySubscriptionTemplate: project is not linked", func(t *testing.T) {
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return([]*serializers.SubscriptionTemplate{template}, nil)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{}, nil)
+
+		message, err := p.applySubscriptionTemplate(testutils.MockMattermostUserID, testutils.MockChannelID, "mockTemplate", testutils.MockProjectName)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.ProjectNotLinkedWithName, testutils.MockProjectName), message)
+	})
+
+	t.Run("ApplySubscriptionTemplate: error in fetching templates", func(t *testing.T) {
+		mockedStore.EXPECT().GetSubscriptionTemplates(testutils.MockMattermostUserID).Return(nil, errors.New("error in fetching templates"))
+
+		_, err := p.applySubscriptionTemplate(testutils.MockMattermostUserID, testutils.MockChannelID, "mockTemplate", testutils.MockProjectName)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteProjectSubscriptions(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
+	mockAPI.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast"))
+	mockAPI.On("GetChannel", testutils.MockChannelID).Return(&model.Channel{Id: testutils.MockChannelID, DisplayName: "mockChannelName"}, nil)
+	mockAPI.On("GetChannel", "mockChannelID-2").Return(&model.Channel{Id: "mockChannelID-2", DeleteAt: 1}, nil)
+
+	subscriptionList := []*serializers.SubscriptionDetails{
+		{SubscriptionID: "mockSubscriptionID-1", OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName, ChannelID: testutils.MockChannelID, ChannelName: "mockChannelName", EventType: constants.SubscriptionEventWorkItemCreated},
+		{SubscriptionID: "mockSubscriptionID-2", OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName, ChannelID: "mockChannelID-2", EventType: constants.SubscriptionEventCodePushed},
+		{SubscriptionID: "mockSubscriptionID-3", OrganizationName: testutils.MockOrganization, ProjectName: "mockOtherProject", ChannelID: testutils.MockChannelID, EventType: constants.SubscriptionEventCodePushed},
+	}
+
+	t.Run("DeleteProjectSubscriptions: failures don't stop the other subscriptions from being deleted", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptionList, nil)
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil).Times(2)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID-1", testutils.MockMattermostUserID).Return(http.StatusForbidden, errors.New("error in deleting the subscription"))
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID-2", testutils.MockMattermostUserID).Return(http.StatusNoContent, nil)
+		mockedStore.EXPECT().DeleteSubscription(subscriptionList[1]).Return(nil)
+		mockedStore.EXPECT().DeleteSubscriptionAndChannelIDMap("mockSubscriptionID-2").Return(nil)
+		mockedStore.EXPECT().DeleteLastNotification("mockSubscriptionID-2").Return(nil)
+
+		message, err := p.deleteProjectSubscriptions(testutils.MockMattermostUserID, strings.ToUpper(testutils.MockProjectName), "")
+		assert.NoError(t, err)
+		assert.Contains(t, message, fmt.Sprintf("| mockSubscriptionID-1 | %s | mockChannelName | Failed: you need to be a project or team administrator to delete it |", constants.SubscriptionEventWorkItemCreated))
+		assert.Contains(t, message, fmt.Sprintf("| mockSubscriptionID-2 | %s | mockChannelID-2 (deleted) | Deleted |", constants.SubscriptionEventCodePushed))
+		assert.NotContains(t, message, "mockSubscriptionID-3")
+		assert.Contains(t, message, "1 of 2 subscription(s) deleted")
+	})
+
+	t.Run("DeleteProjectSubscriptions: subscriptions already deleted on Azure DevOps are deleted", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptionList, nil)
+		mockedStore.EXPECT().GetAllSubscriptions("").Return(subscriptionList, nil)
+		mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, "mockSubscriptionID-1", testutils.MockMattermostUserID).Return(http.StatusNotFound, errors.New("subscription does not exist"))
+		mockedStore.EXPECT().DeleteSubscription(subscriptionList[0]).Return(errors.New("error in deleting the subscription"))
+
+		message, err := p.deleteProjectSubscriptions(testutils.MockMattermostUserID, fmt.Sprintf("%s/%s", testutils.MockOrganization, testutils.MockProjectName), testutils.MockChannelID)
+		assert.NoError(t, err)
+		assert.Contains(t, message, "| mockSubscriptionID-1 |")
+		assert.NotContains(t, message, "mockSubscriptionID-2")
+		assert.Contains(t, message, "Failed: error in deleting the subscription")
+		assert.Contains(t, message, "0 of 1 subscription(s) deleted")
+	})
+
+	t.Run("DeleteProjectSubscriptions: no subscriptions for the project", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(subscriptionList, nil)
+
+		message, err := p.deleteProjectSubscriptions(testutils.MockMattermostUserID, "mockUnknownProject", "")
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.NoProjectSubscriptions, "mockUnknownProject"), message)
+	})
+
+	t.Run("DeleteProjectSubscriptions: project name is used in multiple organizations", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(append([]*serializers.SubscriptionDetails{{
+			SubscriptionID:   "mockSubscriptionID-4",
+			OrganizationName: "mockOtherOrganization",
+			ProjectName:      testutils.MockProjectName,
+		}}, subscriptionList...), nil)
+
+		message, err := p.deleteProjectSubscriptions(testutils.MockMattermostUserID, testutils.MockProjectName, "")
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.MultipleProjectsWithName, testutils.MockProjectName), message)
+	})
+
+	t.Run("DeleteProjectSubscriptions: error in fetching subscriptions", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllSubscriptions(testutils.MockMattermostUserID).Return(nil, errors.New("error in fetching subscriptions"))
+
+		_, err := p.deleteProjectSubscriptions(testutils.MockMattermostUserID, testutils.MockProjectName, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetWorkItemFieldChanges(t *testing.T) {
+	body, err := serializers.SubscriptionNotificationFromJSON(bytes.NewBufferString(`{
+		"eventType": "workitem.updated",
+		"resource": {
+			"fields": {
+				"System.Rev": {"oldValue": 3, "newValue": 4},
+				"System.ChangedDate": {"oldValue": "2022-01-01T10:00:00Z", "newValue": "2022-01-02T10:00:00Z"},
+				"System.State": {"oldValue": "New", "newValue": "Active"},
+				"System.AssignedTo": {"newValue": {"displayName": "mockDisplayName", "uniqueName": "mock@example.com"}},
+				"Microsoft.VSTS.Common.Priority": {"oldValue": 2, "newValue": 1},
+				"System.Description": {"oldValue": "<div>Old <b>description</b></div>", "newValue": "<div>` + strings.Repeat("a", 150) + `</div>"}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	changes := getWorkItemFieldChanges(body.Resource.Fields.All)
+	assert.Equal(t, strings.Join([]string{
+		"**AssignedTo**: None → mockDisplayName",
+		"**Description**: Old description → " + strings.Repeat("a", constants.MaxWorkItemFieldChangeValueLength) + "...",
+		"**Priority**: 2 → 1",
+		"**State**: New → Active",
+	}, "\n"), changes)
+}
+
+func TestGetNotificationStatus(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		body           string
+		expectedStatus string
+	}{
+		{
+			description:    "GetNotificationStatus: work item created",
+			body:           `{"eventType": "workitem.created"}`,
+			expectedStatus: constants.NotificationStatusCreated,
+		},
+		{
+			description:    "GetNotificationStatus: work item updated",
+			body:           `{"eventType": "workitem.updated", "resource": {"fields": {"System.State": {"oldValue": "New", "newValue": "Active"}}}}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: work item closed",
+			body:           `{"eventType": "workitem.updated", "resource": {"fields": {"System.State": {"oldValue": "Active", "newValue": "Closed"}}}}`,
+			expectedStatus: constants.NotificationStatusClosed,
+		},
+		{
+			description:    "GetNotificationStatus: work item deleted",
+			body:           `{"eventType": "workitem.deleted"}`,
+			expectedStatus: constants.NotificationStatusClosed,
+		},
+		{
+			description:    "GetNotificationStatus: work item commented",
+			body:           `{"eventType": "workitem.commented"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: pull request created",
+			body:           `{"eventType": "git.pullrequest.created"}`,
+			expectedStatus: constants.NotificationStatusPullRequest,
+		},
+		{
+			description:    "GetNotificationStatus: pull request updated",
+			body:           `{"eventType": "git.pullrequest.updated"}`,
+			expectedStatus: constants.NotificationStatusPullRequest,
+		},
+		{
+			description:    "GetNotificationStatus: pull request merged",
+			body:           `{"eventType": "git.pullrequest.merged"}`,
+			expectedStatus: constants.NotificationStatusPullRequest,
+		},
+		{
+			description:    "GetNotificationStatus: pull request commented",
+			body:           `{"eventType": "ms.vss-code.git-pullrequest-comment-event"}`,
+			expectedStatus: constants.NotificationStatusPullRequest,
+		},
+		{
+			description:    "GetNotificationStatus: code pushed",
+			body:           `{"eventType": "git.push"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: build succeeded",
+			body:           `{"eventType": "build.complete", "resource": {"result": "succeeded"}}`,
+			expectedStatus: constants.NotificationStatusSucceeded,
+		},
+		{
+			description:    "GetNotificationStatus: build failed",
+			body:           `{"eventType": "build.complete", "resource": {"result": "failed"}}`,
+			expectedStatus: constants.NotificationStatusFailed,
+		},
+		{
+			description:    "GetNotificationStatus: build partially succeeded",
+			body:           `{"eventType": "build.complete", "resource": {"result": "partiallySucceeded"}}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: release created",
+			body:           `{"eventType": "ms.vss-release.release-created-event"}`,
+			expectedStatus: constants.NotificationStatusCreated,
+		},
+		{
+			description:    "GetNotificationStatus: release abandoned",
+			body:           `{"eventType": "ms.vss-release.release-abandoned-event"}`,
+			expectedStatus: constants.NotificationStatusFailed,
+		},
+		{
+			description:    "GetNotificationStatus: release deployment started",
+			body:           `{"eventType": "ms.vss-release.deployment-started-event"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: release deployment rejected",
+			body:           `{"eventType": "ms.vss-release.deployment-completed-event", "resource": {"environment": {"status": "rejected"}}}`,
+			expectedStatus: constants.NotificationStatusFailed,
+		},
+		{
+			description:    "GetNotificationStatus: release deployment approval pending",
+			body:           `{"eventType": "ms.vss-release.deployment-approval-pending-event"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: release deployment approval completed",
+			body:           `{"eventType": "ms.vss-release.deployment-approval-completed-event"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: run succeeded",
+			body:           `{"eventType": "ms.vss-pipelines.run-state-changed-event", "resource": {"run": {"result": "succeeded"}}}`,
+			expectedStatus: constants.NotificationStatusSucceeded,
+		},
+		{
+			description:    "GetNotificationStatus: run in progress",
+			body:           `{"eventType": "ms.vss-pipelines.run-state-changed-event", "resource": {"run": {"result": ""}}}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: run stage canceled",
+			body:           `{"eventType": "ms.vss-pipelines.stage-state-changed-event", "resource": {"stage": {"result": "canceled"}}}`,
+			expectedStatus: constants.NotificationStatusFailed,
+		},
+		{
+			description:    "GetNotificationStatus: run stage waiting for approval",
+			body:           `{"eventType": "ms.vss-pipelinechecks-events.approval-pending"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+		{
+			description:    "GetNotificationStatus: run stage approval completed",
+			body:           `{"eventType": "ms.vss-pipelinechecks-events.approval-completed"}`,
+			expectedStatus: constants.NotificationStatusUpdated,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			body, err := serializers.SubscriptionNotificationFromJSON(bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedStatus, getNotificationStatus(body))
+		})
+	}
+}
+
+func TestAddNotificationEmoji(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	body := &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventWorkItemCreated}
+	for _, testCase := range []struct {
+		description        string
+		notificationEmojis string
+		expectedPretext    string
+	}{
+		{
+			description:     "AddNotificationEmoji: default emoji",
+			expectedPretext: "🟢 mockPretext",
+		},
+		{
+			description:        "AddNotificationEmoji: emoji is overridden",
+			notificationEmojis: "created=✨, failed=❌",
+			expectedPretext:    "✨ mockPretext",
+		},
+		{
+			description:        "AddNotificationEmoji: emoji is removed",
+			notificationEmojis: "created=",
+			expectedPretext:    "mockPretext",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p.setConfiguration(&config.Configuration{NotificationEmojis: testCase.notificationEmojis})
+			attachment := &model.SlackAttachment{Pretext: "mockPretext"}
+
+			p.addNotificationEmoji(attachment, body)
+
+			assert.Equal(t, testCase.expectedPretext, attachment.Pretext)
+			if testCase.expectedPretext != "mockPretext" {
+				assert.Equal(t, testCase.expectedPretext, attachment.Fallback)
+			}
+		})
+	}
+}
+
+func TestAddSubscriptionLabel(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		label           string
+		expectedPretext string
+	}{
+		{
+			description:     "AddSubscriptionLabel: label is prefixed",
+			label:           "Billing",
+			expectedPretext: "[Billing] mockPretext",
+		},
+		{
+			description:     "AddSubscriptionLabel: empty label",
+			expectedPretext: "mockPretext",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			attachment := &model.SlackAttachment{Pretext: "mockPretext"}
+
+			addSubscriptionLabel(attachment, testCase.label)
+
+			assert.Equal(t, testCase.expectedPretext, attachment.Pretext)
+			if testCase.label == "" {
+				assert.Empty(t, attachment.Fallback)
+			} else {
+				assert.Equal(t, testCase.expectedPretext, attachment.Fallback)
+			}
+		})
+	}
+}
+
+func TestFormatWorkItemFieldValue(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		value       interface{}
+		expected    string
+	}{
+		{
+			description: "FormatWorkItemFieldValue: nil value",
+			expected:    "None",
+		},
+		{
+			description: "FormatWorkItemFieldValue: HTML value",
+			value:       "<p>mock&nbsp;value &amp; <i>more</i></p>",
+			expected:    "mock value & more",
+		},
+		{
+			description: "FormatWorkItemFieldValue: empty HTML value",
+			value:       "<br/>",
+			expected:    "None",
+		},
+		{
+			description: "FormatWorkItemFieldValue: number value",
+			value:       float64(2.5),
+			expected:    "2.5",
+		},
+		{
+			description: "FormatWorkItemFieldValue: boolean value",
+			value:       true,
+			expected:    "true",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, formatWorkItemFieldValue(testCase.value))
+		})
+	}
+}
+
+func TestConvertHTMLToMarkdown(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		html        string
+		expected    string
+	}{
+		{
+			description: "ConvertHTMLToMarkdown: plain text is not changed",
+			html:        "mock **description** with *markdown*",
+			expected:    "mock **description** with *markdown*",
+		},
+		{
+			description: "ConvertHTMLToMarkdown: description with paragraphs and line breaks",
+			html:        "<div>First line<br>Second line</div><div><br></div><div>New paragraph&nbsp;&amp; more</div>",
+			expected:    "First line\nSecond line\n\nNew paragraph & more",
+		},
+		{
+			description: "ConvertHTMLToMarkdown: bold, italic and link",
+			html:        `<div><b>Steps</b> to <i>reproduce</i> are in <a href="https://dev.azure.com/mockOrganization/_wiki?a=1&amp;b=2">the wiki</a></div>`,
+			expected:    "**Steps** to _reproduce_ are in [the wiki](https://dev.azure.com/mockOrganization/_wiki?a=1&b=2)",
+		},
+		{
+			description: "ConvertHTMLToMarkdown: unordered and nested ordered lists",
+			html:        "<div>Tasks:</div><ul><li>First</li><li>Second<ol><li>Step one</li><li>Step two</li></ol></li></ul>",
+			expected:    "Tasks:\n\n- First\n- Second\n\n  1. Step one\n  2. Step two",
+		},
+		{
+			description: "ConvertHTMLToMarkdown: unsupported tags and comments are stripped",
+			html:        `<table><tr><td><span style="color:red;">Cell</span></td></tr></table><!-- mock comment --><img src="mock.png">`,
+			expected:    "Cell",
+		},
+		{
+			description: "ConvertHTMLToMarkdown: mention in a comment",
+			html:        `<div><a href="#" data-vss-mention="version:2.0,mockID">@mockUser</a> please take a look </div>`,
+			expected:    "[@mockUser](#) please take a look",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, convertHTMLToMarkdown(testCase.html))
+		})
+	}
+}
+
+func TestUpdatePipelineReleaseApprovalPost(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	p := setupMockPlugin(mockAPI, nil, nil)
+	post := &model.Post{Id: "mockPostID"}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{{
+		Fields: []*model.SlackAttachmentField{
+			{Title: "Release pipeline", Value: "mockReleasePipeline"},
+			{Title: "Artifacts", Value: "mockArtifacts"},
+			{Title: "Approver(s)", Value: "mockApprover"},
+			{Title: "Approval", Value: fmt.Sprintf(constants.ReleaseApprovalLink, "mockReleaseLink")},
+		},
+		Actions: []*model.PostAction{{Id: constants.PipelineRequestIDApproved}},
+	}})
+	mockAPI.On("GetPost", "mockPostID").Return(post, nil)
+	mockAPI.On("UpdatePost", mock.AnythingOfType("*model.Post")).Return(post, nil)
+
+	err := p.UpdatePipelineReleaseApprovalPost(constants.PipelineRequestIDApproved, "mockPostID", testutils.MockMattermostUserID)
+
+	require.NoError(t, err)
+	attachment := post.Attachments()[0]
+	assert.Nil(t, attachment.Actions)
+	require.Len(t, attachment.Fields, 4)
+	assert.Equal(t, fmt.Sprintf("%s mockApprover", constants.PipelineRequestUpdateEmoji[constants.PipelineRequestIDApproved]), attachment.Fields[2].Value)
+	assert.Equal(t, fmt.Sprintf(constants.ReleaseApprovalLink, "mockReleaseLink"), attachment.Fields[3].Value)
+}
@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// scheduleWebhookDeletion delays the deletion of the webhook of a deleted subscription until the end of the grace period.
+// It returns false if the webhook should be deleted right away, when there is no grace period or the deletion could not be scheduled.
+func (p *Plugin) scheduleWebhookDeletion(subscription *serializers.SubscriptionDetails, mattermostUserID string) bool {
+	gracePeriod := p.getConfiguration().WebhookDeletionGracePeriod
+	if gracePeriod <= 0 {
+		return false
+	}
+
+	webhookSecretAndChannelIDMap, err := p.Store.GetSubscriptionAndChannelIDMap(subscription.SubscriptionID)
+	if err != nil {
+		p.API.LogError("Error in fetching the webhook secret of the subscription", "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+		return false
+	}
+
+	webhookSecret := ""
+	if webhookSecretAndChannelIDMap != nil {
+		for secret, channelID := range *webhookSecretAndChannelIDMap {
+			if channelID == subscription.ChannelID {
+				webhookSecret = secret
+			}
+		}
+	}
+
+	// The webhook can't be reused without its secret
+	if webhookSecret == "" {
+		return false
+	}
+
+	if err := p.Store.AddPendingWebhookDeletion(&serializers.PendingWebhookDeletion{
+		Subscription:     subscription,
+		WebhookSecret:    webhookSecret,
+		MattermostUserID: mattermostUserID,
+		DeleteAt:         time.Now().Add(time.Duration(gracePeriod) * time.Second).Unix(),
+	}); err != nil {
+		p.API.LogError("Error in scheduling the deletion of the webhook of the subscription", "SubscriptionID", subscription.SubscriptionID, "Error", err.Error())
+		return false
+	}
+
+	return true
+}
+
+// reusePendingWebhook cancels the pending deletion of the webhook of a subscription deleted by the same user during the grace period
+// if it sends the same events as the subscription being created, and returns it along with its secret
+func (p *Plugin) reusePendingWebhook(mattermostUserID string, body *serializers.CreateSubscriptionRequestPayload) (*serializers.SubscriptionValue, string) {
+	if p.getConfiguration().WebhookDeletionGracePeriod <= 0 {
+		return nil, ""
+	}
+
+	deletions, err := p.Store.GetPendingWebhookDeletions()
+	if err != nil {
+		p.API.LogError("Error in fetching the pending webhook deletions", "Error", err.Error())
+		return nil, ""
+	}
+
+	subscriptionDetails := getSubscriptionDetailsFromPayload(body)
+	messageFormat := strings.ToLower(strings.TrimSpace(body.MessageFormat))
+	for _, deletion := range deletions {
+		if deletion.MattermostUserID != mattermostUserID || deletion.Subscription.ResourceVersion != body.GetResourceVersion() || deletion.Subscription.MessageFormat != messageFormat {
+			continue
+		}
+
+		if _, isSubscriptionPresent := p.IsSubscriptionPresent([]*serializers.SubscriptionDetails{deletion.Subscription}, subscriptionDetails); !isSubscriptionPresent {
+			continue
+		}
+
+		// The webhook is deleted by the job if the deletion is not pending anymore
+		removed, removeErr := p.Store.RemovePendingWebhookDeletion(deletion.Subscription.SubscriptionID)
+		if removeErr != nil {
+			p.API.LogError("Error in cancelling the deletion of the webhook", "SubscriptionID", deletion.Subscription.SubscriptionID, "Error", removeErr.Error())
+			continue
+		}
+
+		if removed {
+			return &serializers.SubscriptionValue{
+				ID:          deletion.Subscription.SubscriptionID,
+				EventType:   deletion.Subscription.EventType,
+				ServiceType: deletion.Subscription.ServiceType,
+			}, deletion.WebhookSecret
+		}
+	}
+
+	return nil, ""
+}
+
+// processPendingWebhookDeletions is run by the scheduled job and deletes the webhooks whose grace period is over
+func (p *Plugin) processPendingWebhookDeletions() {
+	deletions, err := p.Store.GetPendingWebhookDeletions()
+	if err != nil {
+		p.API.LogError("Error in fetching the pending webhook deletions", "Error", err.Error())
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, deletion := range deletions {
+		if deletion.DeleteAt > now {
+			continue
+		}
+
+		// The deletion is claimed first so that a subscription created meanwhile doesn't reuse the webhook being deleted
+		removed, removeErr := p.Store.RemovePendingWebhookDeletion(deletion.Subscription.SubscriptionID)
+		if removeErr != nil {
+			p.API.LogError("Error in removing the pending webhook deletion", "SubscriptionID", deletion.Subscription.SubscriptionID, "Error", removeErr.Error())
+			continue
+		}
+
+		if !removed {
+			continue
+		}
+
+		if statusCode, deleteErr := p.Client.DeleteSubscription(deletion.Subscription.OrganizationName, deletion.Subscription.SubscriptionID, deletion.MattermostUserID); deleteErr != nil && statusCode != http.StatusNotFound {
+			p.API.LogError("Error in deleting the webhook of the subscription", "SubscriptionID", deletion.Subscription.SubscriptionID, "Error", deleteErr.Error())
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package plugin
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/store"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getMockPendingWebhookDeletion(deleteAt int64) *serializers.PendingWebhookDeletion {
+	return &serializers.PendingWebhookDeletion{
+		Subscription: &serializers.SubscriptionDetails{
+			SubscriptionID:   testutils.MockSubscriptionID,
+			OrganizationName: testutils.MockOrganization,
+			ProjectName:      testutils.MockProjectName,
+			ChannelID:        testutils.MockChannelID,
+			EventType:        constants.SubscriptionEventPullRequestCreated,
+			ServiceType:      constants.ServiceTypeRepos,
+			Repository:       "mockRepository",
+			ResourceVersion:  "1.0",
+		},
+		WebhookSecret:    "mockWebhookSecret",
+		MattermostUserID: testutils.MockMattermostUserID,
+		DeleteAt:         deleteAt,
+	}
+}
+
+func TestScheduleWebhookDeletion(t *testing.T) {
+	subscription := getMockPendingWebhookDeletion(0).Subscription
+	for _, testCase := range []struct {
+		description      string
+		gracePeriod      int
+		secretMap        *store.SubscriptionWebhookSecretAndChannelMap
+		addErr           error
+		expectedAdd      bool
+		expectedSchedule bool
+	}{
+		{
+			description:      "ScheduleWebhookDeletion: deletion is scheduled",
+			gracePeriod:      60,
+			secretMap:        &store.SubscriptionWebhookSecretAndChannelMap{"mockWebhookSecret": testutils.MockChannelID},
+			expectedAdd:      true,
+			expectedSchedule: true,
+		},
+		{
+			description: "ScheduleWebhookDeletion: no grace period",
+		},
+		{
+			description: "ScheduleWebhookDeletion: webhook secret is not found",
+			gracePeriod: 60,
+		},
+		{
+			description: "ScheduleWebhookDeletion: too many deletions are pending",
+			gracePeriod: 60,
+			secretMap:   &store.SubscriptionWebhookSecretAndChannelMap{"mockWebhookSecret": testutils.MockChannelID},
+			addErr:      store.ErrPendingWebhookDeletionsFull,
+			expectedAdd: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{WebhookDeletionGracePeriod: testCase.gracePeriod})
+			if testCase.addErr != nil {
+				mockAPI.On("LogError", "Error in scheduling the deletion of the webhook of the subscription", "SubscriptionID", testutils.MockSubscriptionID, "Error", testCase.addErr.Error())
+			}
+
+			if testCase.gracePeriod > 0 {
+				mockedStore.EXPECT().GetSubscriptionAndChannelIDMap(testutils.MockSubscriptionID).Return(testCase.secretMap, nil)
+			}
+			if testCase.expectedAdd {
+				mockedStore.EXPECT().AddPendingWebhookDeletion(gomock.Any()).DoAndReturn(func(deletion *serializers.PendingWebhookDeletion) error {
+					assert.Equal(t, "mockWebhookSecret", deletion.WebhookSecret)
+					assert.Equal(t, testutils.MockMattermostUserID, deletion.MattermostUserID)
+					assert.InDelta(t, time.Now().Add(time.Minute).Unix(), deletion.DeleteAt, 5)
+					return testCase.addErr
+				})
+			}
+
+			assert.Equal(t, testCase.expectedSchedule, p.scheduleWebhookDeletion(subscription, testutils.MockMattermostUserID))
+		})
+	}
+}
+
+func TestReusePendingWebhook(t *testing.T) {
+	body := &serializers.CreateSubscriptionRequestPayload{
+		Organization:    testutils.MockOrganization,
+		Project:         testutils.MockProjectName,
+		ChannelID:       testutils.MockChannelID,
+		EventType:       constants.SubscriptionEventPullRequestCreated,
+		ServiceType:     constants.ServiceTypeRepos,
+		Repository:      "mockRepository",
+		ResourceVersion: "1.0",
+	}
+	otherRepositoryBody := *body
+	otherRepositoryBody.Repository = "mockOtherRepository"
+	otherFormatBody := *body
+	otherFormatBody.MessageFormat = constants.SubscriptionMessageFormatText
+
+	for _, testCase := range []struct {
+		description      string
+		gracePeriod      int
+		mattermostUserID string
+		body             *serializers.CreateSubscriptionRequestPayload
+		expectedRemove   bool
+		removed          bool
+		expectedReuse    bool
+	}{
+		{
+			description:      "ReusePendingWebhook: matching subscription is recreated within the grace period",
+			gracePeriod:      60,
+			mattermostUserID: testutils.MockMattermostUserID,
+			body:             body,
+			expectedRemove:   true,
+			removed:          true,
+			expectedReuse:    true,
+		},
+		{
+			description:      "ReusePendingWebhook: webhook is deleted by the job in the meantime",
+			gracePeriod:      60,
+			mattermostUserID: testutils.MockMattermostUserID,
+			body:             body,
+			expectedRemove:   true,
+		},
+		{
+			description:      "ReusePendingWebhook: subscription is created by another user",
+			gracePeriod:      60,
+			mattermostUserID: "mockOtherMattermostUserID",
+			body:             body,
+		},
+		{
+			description:      "ReusePendingWebhook: subscription has different filters",
+			gracePeriod:      60,
+			mattermostUserID: testutils.MockMattermostUserID,
+			body:             &otherRepositoryBody,
+		},
+		{
+			description:      "ReusePendingWebhook: subscription has a different message format",
+			gracePeriod:      60,
+			mattermostUserID: testutils.MockMattermostUserID,
+			body:             &otherFormatBody,
+		},
+		{
+			description:      "ReusePendingWebhook: no grace period",
+			mattermostUserID: testutils.MockMattermostUserID,
+			body:             body,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, nil)
+			p.setConfiguration(&config.Configuration{WebhookDeletionGracePeriod: testCase.gracePeriod})
+
+			if testCase.gracePeriod > 0 {
+				mockedStore.EXPECT().GetPendingWebhookDeletions().Return([]*serializers.PendingWebhookDeletion{getMockPendingWebhookDeletion(time.Now().Add(time.Minute).Unix())}, nil)
+			}
+			if testCase.expectedRemove {
+				mockedStore.EXPECT().RemovePendingWebhookDeletion(testutils.MockSubscriptionID).Return(testCase.removed, nil)
+			}
+
+			subscription, webhookSecret := p.reusePendingWebhook(testCase.mattermostUserID, testCase.body)
+
+			if !testCase.expectedReuse {
+				assert.Nil(t, subscription)
+				assert.Empty(t, webhookSecret)
+				return
+			}
+
+			assert.Equal(t, testutils.MockSubscriptionID, subscription.ID)
+			assert.Equal(t, "mockWebhookSecret", webhookSecret)
+		})
+	}
+}
+
+func TestProcessPendingWebhookDeletions(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		deleteAt       int64
+		expectedRemove bool
+		removed        bool
+		expectedDelete bool
+	}{
+		{
+			description:    "ProcessPendingWebhookDeletions: grace period is over",
+			deleteAt:       time.Now().Add(-time.Minute).Unix(),
+			expectedRemove: true,
+			removed:        true,
+			expectedDelete: true,
+		},
+		{
+			description:    "ProcessPendingWebhookDeletions: webhook is reused in the meantime",
+			deleteAt:       time.Now().Add(-time.Minute).Unix(),
+			expectedRemove: true,
+		},
+		{
+			description: "ProcessPendingWebhookDeletions: grace period is not over",
+			deleteAt:    time.Now().Add(time.Minute).Unix(),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			p := setupMockPlugin(&plugintest.API{}, mockedStore, mockedClient)
+
+			mockedStore.EXPECT().GetPendingWebhookDeletions().Return([]*serializers.PendingWebhookDeletion{getMockPendingWebhookDeletion(testCase.deleteAt)}, nil)
+			if testCase.expectedRemove {
+				mockedStore.EXPECT().RemovePendingWebhookDeletion(testutils.MockSubscriptionID).Return(testCase.removed, nil)
+			}
+			if testCase.expectedDelete {
+				mockedClient.EXPECT().DeleteSubscription(testutils.MockOrganization, testutils.MockSubscriptionID, testutils.MockMattermostUserID).Return(http.StatusNoContent, nil)
+			}
+
+			p.processPendingWebhookDeletions()
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// countWeeklySummaryNotification counts a notification posted in a channel for its weekly summary.
+// The notifications are only counted for the channels which have enabled the summary, so that the other channels don't pay for an extra write.
+func (p *Plugin) countWeeklySummaryNotification(channelID, eventType string, prefs *serializers.ChannelNotificationPrefs) {
+	if !prefs.IsSummaryEnabled() {
+		return
+	}
+
+	if err := p.Store.IncrementWeeklySummaryCount(channelID, eventType); err != nil {
+		p.API.LogError("Error in counting the notification for the weekly summary", "Error", err.Error())
+	}
+}
+
+// processWeeklySummaries is run by the scheduled job and posts the weekly summary of every channel whose summary is due
+func (p *Plugin) processWeeklySummaries() {
+	subscriptionList, err := p.Store.GetAllSubscriptions("")
+	if err != nil {
+		p.API.LogError(constants.FetchSubscriptionListError, "Error", err.Error())
+		return
+	}
+
+	channelIDs := map[string]bool{}
+	for _, subscription := range subscriptionList {
+		channelIDs[subscription.ChannelID] = true
+	}
+
+	now := time.Now()
+	for channelID := range channelIDs {
+		if err := p.postWeeklySummaryIfDue(channelID, now); err != nil {
+			p.API.LogError(constants.ErrorPostWeeklySummary, "ChannelID", channelID, "Error", err.Error())
+		}
+	}
+}
+
+// postWeeklySummaryIfDue posts the summary of a channel once its scheduled time of the week has passed.
+// A channel which started counting after the scheduled time of the current week waits for the next one.
+func (p *Plugin) postWeeklySummaryIfDue(channelID string, now time.Time) error {
+	prefs := p.getChannelNotificationPrefs(channelID)
+	if !prefs.IsSummaryEnabled() {
+		return nil
+	}
+
+	summary, err := p.Store.GetWeeklySummary(channelID)
+	if err != nil || summary == nil {
+		return err
+	}
+
+	weekday, hour := prefs.GetSummarySchedule()
+	dueAt := getWeeklySummaryDueTime(now, weekday, hour, prefs.GetLocation())
+	if summary.Since >= dueAt.Unix() || summary.LastPostedAt >= dueAt.Unix() {
+		return nil
+	}
+
+	summary, err = p.Store.ResetWeeklySummary(channelID, now.Unix())
+	if err != nil || summary == nil {
+		return err
+	}
+
+	if _, appErr := p.API.CreatePost(&model.Post{
+		UserId:    p.botUserID,
+		ChannelId: channelID,
+		Message:   getWeeklySummaryMessage(summary, now, prefs.GetLocation()),
+	}); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+// getWeeklySummaryDueTime returns the latest time at or before now which falls on the scheduled day and hour in the location of the channel
+func getWeeklySummaryDueTime(now time.Time, weekday time.Weekday, hour int, location *time.Location) time.Time {
+	localNow := now.In(location)
+	dueAt := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), hour, 0, 0, 0, location)
+	dueAt = dueAt.AddDate(0, 0, -((int(localNow.Weekday()) - int(weekday) + 7) % 7))
+	if dueAt.After(localNow) {
+		dueAt = dueAt.AddDate(0, 0, -7)
+	}
+
+	return dueAt
+}
+
+// getWeeklySummaryMessage returns the summary of the notifications of a channel as a table, with the most frequent event types first
+func getWeeklySummaryMessage(summary *serializers.WeeklySummary, now time.Time, location *time.Location) string {
+	from := time.Unix(summary.Since, 0).In(location).Format(constants.WeeklySummaryDateFormat)
+	to := now.In(location).Format(constants.WeeklySummaryDateFormat)
+
+	var sb strings.Builder
+	sb.WriteString(constants.WeeklySummaryTitle + "\n")
+	total := summary.GetTotal()
+	if total == 0 {
+		sb.WriteString(fmt.Sprintf(constants.WeeklySummaryNoNotifications, from, to))
+		return sb.String()
+	}
+
+	eventTypes := make([]string, 0, len(summary.Counts))
+	for eventType, count := range summary.Counts {
+		if count > 0 {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	sort.Slice(eventTypes, func(i, j int) bool {
+		if summary.Counts[eventTypes[i]] != summary.Counts[eventTypes[j]] {
+			return summary.Counts[eventTypes[i]] > summary.Counts[eventTypes[j]]
+		}
+		return eventTypes[i] < eventTypes[j]
+	})
+
+	sb.WriteString(fmt.Sprintf(constants.WeeklySummaryNotifications, total, from, to) + "\n\n")
+	sb.WriteString("| Event Type | Notifications |\n")
+	sb.WriteString("| :--------- | ------------: |\n")
+	for _, eventType := range eventTypes {
+		displayName, ok := constants.EventTypeDisplayNames[eventType]
+		if !ok {
+			displayName = eventType
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", displayName, summary.Counts[eventType]))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,208 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestCountWeeklySummaryNotification(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	summaryEnabled, summaryDisabled := true, false
+
+	t.Run("CountWeeklySummaryNotification: notification is counted if the summary is enabled", func(t *testing.T) {
+		mockedStore.EXPECT().IncrementWeeklySummaryCount(testutils.MockChannelID, constants.SubscriptionEventCodePushed).Return(nil).Times(1)
+
+		p.countWeeklySummaryNotification(testutils.MockChannelID, constants.SubscriptionEventCodePushed, &serializers.ChannelNotificationPrefs{Summary: &summaryEnabled})
+	})
+
+	t.Run("CountWeeklySummaryNotification: notification is not counted if the summary is not enabled", func(t *testing.T) {
+		p.countWeeklySummaryNotification(testutils.MockChannelID, constants.SubscriptionEventCodePushed, &serializers.ChannelNotificationPrefs{})
+		p.countWeeklySummaryNotification(testutils.MockChannelID, constants.SubscriptionEventCodePushed, &serializers.ChannelNotificationPrefs{Summary: &summaryDisabled})
+	})
+
+	t.Run("CountWeeklySummaryNotification: error in counting the notification is logged", func(t *testing.T) {
+		mockAPI.On("LogError", mock.AnythingOfType("string"), "Error", "error in counting").Once()
+		mockedStore.EXPECT().IncrementWeeklySummaryCount(testutils.MockChannelID, constants.SubscriptionEventBuildCompleted).Return(errors.New("error in counting"))
+
+		p.countWeeklySummaryNotification(testutils.MockChannelID, constants.SubscriptionEventBuildCompleted, &serializers.ChannelNotificationPrefs{Summary: &summaryEnabled})
+		mockAPI.AssertExpectations(t)
+	})
+}
+
+func TestGetWeeklySummaryDueTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	for _, testCase := range []struct {
+		description   string
+		now           time.Time
+		weekday       time.Weekday
+		hour          int
+		location      *time.Location
+		expectedDueAt time.Time
+	}{
+		{
+			description:   "GetWeeklySummaryDueTime: scheduled time of the current week has passed",
+			now:           time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC),
+			weekday:       time.Monday,
+			hour:          9,
+			location:      time.UTC,
+			expectedDueAt: time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			description:   "GetWeeklySummaryDueTime: scheduled day is today but the hour has not come yet",
+			now:           time.Date(2024, time.January, 8, 8, 59, 0, 0, time.UTC),
+			weekday:       time.Monday,
+			hour:          9,
+			location:      time.UTC,
+			expectedDueAt: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			description:   "GetWeeklySummaryDueTime: scheduled time is exactly now",
+			now:           time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC),
+			weekday:       time.Monday,
+			hour:          9,
+			location:      time.UTC,
+			expectedDueAt: time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			description:   "GetWeeklySummaryDueTime: schedule is in the timezone of the channel",
+			now:           time.Date(2024, time.January, 8, 10, 0, 0, 0, time.UTC),
+			weekday:       time.Monday,
+			hour:          9,
+			location:      newYork,
+			expectedDueAt: time.Date(2024, time.January, 1, 9, 0, 0, 0, newYork),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			dueAt := getWeeklySummaryDueTime(testCase.now, testCase.weekday, testCase.hour, testCase.location)
+
+			assert.True(t, testCase.expectedDueAt.Equal(dueAt), "expected %s, got %s", testCase.expectedDueAt, dueAt)
+		})
+	}
+}
+
+func TestGetWeeklySummaryMessage(t *testing.T) {
+	since := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.January, 8, 9, 5, 0, 0, time.UTC)
+
+	t.Run("GetWeeklySummaryMessage: event types are sorted by their counts", func(t *testing.T) {
+		summary := &serializers.WeeklySummary{
+			Since: since.Unix(),
+			Counts: map[string]int{
+				constants.SubscriptionEventBuildCompleted:     2,
+				constants.SubscriptionEventPullRequestCreated: 5,
+				constants.SubscriptionEventCodePushed:         2,
+				"mockEventType":                               1,
+				constants.SubscriptionEventWorkItemDeleted:    0,
+			},
+		}
+
+		assert.Equal(t, "###### Weekly Azure DevOps summary\n"+
+			"10 notification(s) were posted in this channel from Jan 1, 2024 to Jan 8, 2024\n\n"+
+			"| Event Type | Notifications |\n"+
+			"| :--------- | ------------: |\n"+
+			"| Pull Request Created | 5 |\n"+
+			"| Build Completed | 2 |\n"+
+			"| Code Pushed | 2 |\n"+
+			"| mockEventType | 1 |\n", getWeeklySummaryMessage(summary, now, time.UTC))
+	})
+
+	t.Run("GetWeeklySummaryMessage: no notifications were posted", func(t *testing.T) {
+		summary := &serializers.WeeklySummary{Since: since.Unix(), Counts: map[string]int{}}
+
+		assert.Equal(t, "###### Weekly Azure DevOps summary\nNo notifications were posted in this channel from Jan 1, 2024 to Jan 8, 2024", getWeeklySummaryMessage(summary, now, time.UTC))
+	})
+
+	t.Run("GetWeeklySummaryMessage: dates are in the timezone of the channel", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		assert.NoError(t, err)
+		summary := &serializers.WeeklySummary{Since: time.Date(2024, time.January, 1, 20, 0, 0, 0, time.UTC).Unix(), Counts: map[string]int{}}
+
+		assert.Contains(t, getWeeklySummaryMessage(summary, now, tokyo), "from Jan 2, 2024 to Jan 8, 2024")
+	})
+}
+
+func TestPostWeeklySummaryIfDue(t *testing.T) {
+	summaryEnabled := true
+	now := time.Date(2024, time.January, 8, 9, 5, 0, 0, time.UTC)
+	lastWeek := time.Date(2024, time.January, 1, 9, 5, 0, 0, time.UTC).Unix()
+	for _, testCase := range []struct {
+		description  string
+		prefs        *serializers.ChannelNotificationPrefs
+		summary      *serializers.WeeklySummary
+		expectedPost bool
+	}{
+		{
+			description: "PostWeeklySummaryIfDue: summary is not enabled",
+			prefs:       &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID},
+		},
+		{
+			description: "PostWeeklySummaryIfDue: no notification was counted",
+			prefs:       &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID, Summary: &summaryEnabled},
+		},
+		{
+			description:  "PostWeeklySummaryIfDue: summary is due",
+			prefs:        &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID, Summary: &summaryEnabled},
+			summary:      &serializers.WeeklySummary{ChannelID: testutils.MockChannelID, Counts: map[string]int{constants.SubscriptionEventCodePushed: 3}, Since: lastWeek, LastPostedAt: lastWeek},
+			expectedPost: true,
+		},
+		{
+			description: "PostWeeklySummaryIfDue: summary is already posted this week",
+			prefs:       &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID, Summary: &summaryEnabled},
+			summary:     &serializers.WeeklySummary{ChannelID: testutils.MockChannelID, Counts: map[string]int{}, Since: now.Add(-time.Minute).Unix(), LastPostedAt: now.Add(-time.Minute).Unix()},
+		},
+		{
+			description: "PostWeeklySummaryIfDue: counting started after the scheduled time",
+			prefs:       &serializers.ChannelNotificationPrefs{ChannelID: testutils.MockChannelID, Summary: &summaryEnabled, SummaryDay: "tuesday"},
+			summary:     &serializers.WeeklySummary{ChannelID: testutils.MockChannelID, Counts: map[string]int{constants.SubscriptionEventCodePushed: 1}, Since: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC).Unix()},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, nil)
+
+			mockedStore.EXPECT().GetChannelNotificationPrefs(testutils.MockChannelID).Return(testCase.prefs, nil)
+			if testCase.prefs.IsSummaryEnabled() {
+				mockedStore.EXPECT().GetWeeklySummary(testutils.MockChannelID).Return(testCase.summary, nil)
+			}
+
+			var post *model.Post
+			if testCase.expectedPost {
+				mockedStore.EXPECT().ResetWeeklySummary(testutils.MockChannelID, now.Unix()).Return(testCase.summary, nil)
+				mockAPI.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post = args.Get(0).(*model.Post)
+				}).Return(&model.Post{}, nil)
+			}
+
+			err := p.postWeeklySummaryIfDue(testutils.MockChannelID, now)
+
+			assert.NoError(t, err)
+			if testCase.expectedPost {
+				assert.Equal(t, testutils.MockChannelID, post.ChannelId)
+				assert.Contains(t, post.Message, "3 notification(s) were posted in this channel from Jan 1, 2024 to Jan 8, 2024")
+				assert.Contains(t, post.Message, "| Code Pushed | 3 |")
+			} else {
+				assert.Nil(t, post)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// workItemCodeLink is a pull request or a branch linked to a work item
+type workItemCodeLink struct {
+	projectID    string
+	repositoryID string
+	// ID of the pull request or name of the branch
+	name string
+}
+
+// getWorkItemDetails returns the attachment showing the details of a work item along with its linked pull requests and branches,
+// and the counts of its comments and attachments. A message is returned instead if the work item can't be shown.
+func (p *Plugin) getWorkItemDetails(mattermostUserID, projectArgument, workItemID string) (*model.SlackAttachment, string, error) {
+	parsedWorkItemID, err := strconv.Atoi(workItemID)
+	if err != nil {
+		return nil, fmt.Sprintf(constants.InvalidWorkItemID, workItemID), nil
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+
+	workItem, statusCode, err := p.Client.GetWorkItemExpanded(project.OrganizationName, project.ProjectName, parsedWorkItemID, mattermostUserID)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, fmt.Sprintf(constants.WorkItemNotFound, workItemID, project.ProjectName), nil
+		}
+		return nil, "", err
+	}
+
+	attachment := p.getTaskAttachment(workItem.WorkItem, project.OrganizationName, project.ProjectName, mattermostUserID)
+	attachment.Fields = append(attachment.Fields, p.getWorkItemCodeLinkFields(project.OrganizationName, workItem.WorkItem.Relations, mattermostUserID)...)
+	attachment.Fields = append(attachment.Fields,
+		&model.SlackAttachmentField{
+			Title: "Comments",
+			Value: strconv.Itoa(workItem.CommentCount),
+			Short: true,
+		},
+		&model.SlackAttachmentField{
+			Title: "Attachments",
+			Value: strconv.Itoa(workItem.AttachmentCount),
+			Short: true,
+		},
+	)
+	return attachment, "", nil
+}
+
+// getDeleteWorkItemConfirmation returns the attachment asking the user to confirm the deletion of a work item.
+// A message is returned instead if the work item can't be deleted, so that the user doesn't confirm a deletion which is bound to fail.
+func (p *Plugin) getDeleteWorkItemConfirmation(mattermostUserID, projectArgument, workItemID string, destroy bool) (*model.SlackAttachment, string, error) {
+	if _, err := strconv.Atoi(workItemID); err != nil {
+		return nil, fmt.Sprintf(constants.InvalidWorkItemID, workItemID), nil
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+
+	if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeWorkWrite); scopeErr != nil {
+		return nil, scopeErr.Error(), nil
+	}
+
+	workItem, statusCode, err := p.Client.GetWorkItem(project.OrganizationName, workItemID, project.ProjectName, mattermostUserID)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, fmt.Sprintf(constants.WorkItemNotFound, workItemID, project.ProjectName), nil
+		}
+		return nil, "", err
+	}
+
+	text := fmt.Sprintf(constants.DeleteWorkItemConfirmation, project.ProjectName)
+	confirmLabel := "Delete"
+	if destroy {
+		text = constants.DestroyWorkItemConfirmation
+		confirmLabel = "Destroy"
+	}
+
+	actionURL := fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathDeleteWorkItem)
+	getActionContext := func(action string) map[string]interface{} {
+		return map[string]interface{}{
+			constants.DeleteWorkItemContextOrganization: project.OrganizationName,
+			constants.DeleteWorkItemContextProjectName:  project.ProjectName,
+			constants.DeleteWorkItemContextWorkItemID:   workItem.ID,
+			constants.DeleteWorkItemContextDestroy:      destroy,
+			constants.DeleteWorkItemContextAction:       action,
+		}
+	}
+
+	return &model.SlackAttachment{
+		AuthorName: "Azure Boards",
+		AuthorIcon: fmt.Sprintf(constants.PublicFiles, p.GetSiteURL(), constants.PluginID, constants.FileNameBoardsIcon),
+		Title:      fmt.Sprintf(constants.TaskTitle, workItem.Fields.Type, workItem.ID, workItem.Fields.Title, workItem.Link.HTML.Href),
+		Text:       text,
+		Color:      constants.IconColorBoards,
+		Actions: []*model.PostAction{
+			{
+				Id:    constants.DeleteWorkItemActionConfirm,
+				Type:  model.POST_ACTION_TYPE_BUTTON,
+				Name:  confirmLabel,
+				Style: "danger",
+				Integration: &model.PostActionIntegration{
+					URL:     actionURL,
+					Context: getActionContext(constants.DeleteWorkItemActionConfirm),
+				},
+			},
+			{
+				Id:   constants.DeleteWorkItemActionCancel,
+				Type: model.POST_ACTION_TYPE_BUTTON,
+				Name: "Cancel",
+				Integration: &model.PostActionIntegration{
+					URL:     actionURL,
+					Context: getActionContext(constants.DeleteWorkItemActionCancel),
+				},
+			},
+		},
+	}, "", nil
+}
+
+// deleteWorkItem deletes a work item once the user has confirmed it and returns the message to show to the user.
+// A forbidden error usually means the user lacks the permission to delete work items in Azure DevOps, so it's reported as such.
+func (p *Plugin) deleteWorkItem(mattermostUserID, organization, projectName string, workItemID int, destroy bool) string {
+	if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeWorkWrite); scopeErr != nil {
+		return scopeErr.Error()
+	}
+
+	statusCode, err := p.Client.DeleteWorkItem(organization, projectName, workItemID, destroy, mattermostUserID)
+	if err != nil {
+		switch statusCode {
+		case http.StatusForbidden:
+			return fmt.Sprintf(constants.WorkItemDeleteForbidden, workItemID)
+		case http.StatusNotFound:
+			return fmt.Sprintf(constants.WorkItemNotFound, strconv.Itoa(workItemID), projectName)
+		}
+
+		p.API.LogError(constants.ErrorDeleteWorkItem, "Error", err.Error())
+		return constants.GenericErrorMessage
+	}
+
+	if destroy {
+		return fmt.Sprintf(constants.WorkItemDestroyed, workItemID)
+	}
+	return fmt.Sprintf(constants.WorkItemDeleted, workItemID)
+}
+
+// getRecycleBinTable returns the most recently deleted work items of a linked project as a table, so that the users can find the ID of a work item to restore
+func (p *Plugin) getRecycleBinTable(mattermostUserID, projectArgument string) (string, error) {
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	deletedWorkItems, _, err := p.Client.GetRecycleBin(project.OrganizationName, project.ProjectName, mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(deletedWorkItems) == 0 {
+		return fmt.Sprintf(constants.RecycleBinEmpty, project.ProjectName), nil
+	}
+
+	sort.SliceStable(deletedWorkItems, func(i, j int) bool {
+		if deletedWorkItems[i].DeletedDate == nil || deletedWorkItems[j].DeletedDate == nil {
+			return deletedWorkItems[j].DeletedDate == nil && deletedWorkItems[i].DeletedDate != nil
+		}
+		return deletedWorkItems[i].DeletedDate.After(*deletedWorkItems[j].DeletedDate)
+	})
+
+	shownWorkItems := deletedWorkItems
+	if len(shownWorkItems) > constants.RecycleBinPageSize {
+		shownWorkItems = shownWorkItems[:constants.RecycleBinPageSize]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("###### Recycle bin of %s/%s\n", project.OrganizationName, project.ProjectName))
+	sb.WriteString("| ID | Type | Title | Deleted By | Deleted On |\n")
+	sb.WriteString("| :- | :--- | :---- | :--------- | :--------- |\n")
+	for _, workItem := range shownWorkItems {
+		deletedOn := ""
+		if workItem.DeletedDate != nil {
+			deletedOn = workItem.DeletedDate.Format("Jan 2, 2006")
+		}
+		sb.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s |\n", workItem.ID, escapeTableCell(workItem.Type), escapeTableCell(workItem.Name), escapeTableCell(workItem.DeletedBy), deletedOn))
+	}
+	sb.WriteString(fmt.Sprintf("\nShowing the %d most recently deleted of %d work items. Use `/%s %s %s %s [work item ID]` to restore a work item.", len(shownWorkItems), len(deletedWorkItems), constants.CommandTriggerName, constants.CommandBoards, constants.CommandRestore, projectArgument))
+
+	return sb.String(), nil
+}
+
+// restoreDeletedWorkItem restores a work item from the recycle bin of a linked project and returns the message to show to the user
+func (p *Plugin) restoreDeletedWorkItem(mattermostUserID, projectArgument, workItemID string) (string, error) {
+	id, err := strconv.Atoi(workItemID)
+	if err != nil {
+		return fmt.Sprintf(constants.InvalidWorkItemID, workItemID), nil
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		return "", errors.Wrap(err, constants.ErrorFetchProjectList)
+	}
+
+	project, err := p.getLinkedProject(projectList, projectArgument)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	if scopeErr := p.getMissingScopeError(mattermostUserID, constants.ScopeWorkWrite); scopeErr != nil {
+		return scopeErr.Error(), nil
+	}
+
+	statusCode, err := p.Client.RestoreWorkItem(project.OrganizationName, project.ProjectName, id, mattermostUserID)
+	if err != nil {
+		switch statusCode {
+		// Work items which are past the retention of the recycle bin are destroyed, so they can't be found anymore
+		case http.StatusNotFound:
+			return fmt.Sprintf(constants.WorkItemNotInRecycleBin, workItemID, project.ProjectName), nil
+		case http.StatusForbidden:
+			return fmt.Sprintf(constants.WorkItemRestoreForbidden, workItemID), nil
+		}
+		return "", err
+	}
+
+	return fmt.Sprintf(constants.WorkItemRestored, id), nil
+}
+
+// getWorkItemCodeLinkFields returns the attachment fields listing the pull requests and branches linked to a work item.
+// No API calls are made if the work item doesn't have any code links.
+func (p *Plugin) getWorkItemCodeLinkFields(organization string, relations []*serializers.WorkItemRelation, mattermostUserID string) []*model.SlackAttachmentField {
+	pullRequests, branches := parseWorkItemCodeLinks(relations)
+	if len(pullRequests) == 0 && len(branches) == 0 {
+		return nil
+	}
+
+	// Multiple code links usually belong to the same repository, so each repository is fetched only once
+	repositories := map[string]*serializers.GitRepository{}
+	getRepository := func(link *workItemCodeLink) *serializers.GitRepository {
+		if repository, ok := repositories[link.repositoryID]; ok {
+			return repository
+		}
+
+		repository, _, err := p.Client.GetGitRepository(organization, link.projectID, link.repositoryID, mattermostUserID)
+		if err != nil {
+			p.API.LogDebug("Error in getting repository details from Azure", "Error", err.Error())
+		}
+		repositories[link.repositoryID] = repository
+		return repository
+	}
+
+	var fields []*model.SlackAttachmentField
+	if len(pullRequests) > 0 {
+		var pullRequestList []string
+		for _, link := range pullRequests {
+			pullRequestList = append(pullRequestList, p.getPullRequestCodeLinkText(organization, link, getRepository(link), mattermostUserID))
+		}
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: "Pull Requests",
+			Value: strings.Join(pullRequestList, "\n"),
+		})
+	}
+
+	if len(branches) > 0 {
+		var branchList []string
+		for _, link := range branches {
+			branchList = append(branchList, getBranchCodeLinkText(link, getRepository(link)))
+		}
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: "Branches",
+			Value: strings.Join(branchList, "\n"),
+		})
+	}
+
+	return fields
+}
+
+func (p *Plugin) getPullRequestCodeLinkText(organization string, link *workItemCodeLink, repository *serializers.GitRepository, mattermostUserID string) string {
+	text := fmt.Sprintf("!%s", link.name)
+	if pullRequest, _, err := p.Client.GetPullRequest(organization, link.name, link.projectID, mattermostUserID); err != nil {
+		p.API.LogDebug("Error in getting pull request details from Azure", "Error", err.Error())
+	} else if pullRequest != nil {
+		text = fmt.Sprintf("!%s: %s", link.name, pullRequest.Title)
+	}
+
+	if repository == nil {
+		return fmt.Sprintf("- %s", text)
+	}
+
+	return fmt.Sprintf("- [%s](%s/pullrequest/%s) in %s", text, repository.WebURL, link.name, repository.Name)
+}
+
+func getBranchCodeLinkText(link *workItemCodeLink, repository *serializers.GitRepository) string {
+	if repository == nil {
+		return fmt.Sprintf("- %s", link.name)
+	}
+
+	return fmt.Sprintf("- [%s](%s?version=%s%s) in %s", link.name, repository.WebURL, constants.BranchRefPrefix, url.QueryEscape(link.name), repository.Name)
+}
+
+// parseWorkItemCodeLinks returns the pull requests and branches from the artifact link relations of a work item
+func parseWorkItemCodeLinks(relations []*serializers.WorkItemRelation) (pullRequests, branches []*workItemCodeLink) {
+	for _, relation := range relations {
+		if relation == nil || relation.Rel != constants.RelationArtifactLink {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(relation.URL, constants.ArtifactPullRequestPrefix):
+			if link := parseArtifactLink(strings.TrimPrefix(relation.URL, constants.ArtifactPullRequestPrefix)); link != nil {
+				pullRequests = append(pullRequests, link)
+			}
+		case strings.HasPrefix(relation.URL, constants.ArtifactBranchPrefix):
+			// Refs other than branches e.g. commits and tags are not shown
+			if link := parseArtifactLink(strings.TrimPrefix(relation.URL, constants.ArtifactBranchPrefix)); link != nil && strings.HasPrefix(link.name, constants.BranchRefPrefix) {
+				link.name = strings.TrimPrefix(link.name, constants.BranchRefPrefix)
+				branches = append(branches, link)
+			}
+		}
+	}
+
+	return pullRequests, branches
+}
+
+// parseArtifactLink parses the escaped "{projectID}/{repositoryID}/{name}" part of the URL of an artifact link
+func parseArtifactLink(artifact string) *workItemCodeLink {
+	unescapedArtifact, err := url.PathUnescape(artifact)
+	if err != nil {
+		return nil
+	}
+
+	parts := strings.SplitN(unescapedArtifact, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil
+	}
+
+	return &workItemCodeLink{
+		projectID:    parts[0],
+		repositoryID: parts[1],
+		name:         parts[2],
+	}
+}
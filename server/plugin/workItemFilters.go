@@ -0,0 +1,37 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// isWorkItemMatchingFilters checks if the work item of a notification is under the area path and of the work item type of its subscription.
+// The other notifications are not filtered, and a notification without the field of a filter is posted.
+func isWorkItemMatchingFilters(subscription *serializers.SubscriptionDetails, body *serializers.SubscriptionNotification) bool {
+	if subscription == nil || !constants.ValidSubscriptionEventsForBoards[body.EventType] {
+		return true
+	}
+
+	// The fields of the work item after the update are in its revision
+	fields := body.Resource.Fields
+	if body.EventType == constants.SubscriptionEventWorkItemUpdated {
+		fields = body.Resource.Revision.Fields
+	}
+
+	if workItemType, _ := fields.WorkItemType.(string); subscription.WorkItemType != "" && workItemType != "" && !strings.EqualFold(subscription.WorkItemType, workItemType) {
+		return false
+	}
+
+	areaPath, _ := fields.AreaPath.(string)
+	return subscription.AreaPath == "" || areaPath == "" || isAreaPathUnder(areaPath, subscription.AreaPath)
+}
+
+// isAreaPathUnder checks if an area path is the same as or a child of the area path of a filter, like Azure DevOps does.
+// The area paths of the filters are enclosed in backslashes, unlike the ones of the work items.
+func isAreaPathUnder(areaPath, filterAreaPath string) bool {
+	areaPath = strings.ToLower(strings.Trim(strings.TrimSpace(areaPath), `\`))
+	filterAreaPath = strings.ToLower(strings.Trim(strings.TrimSpace(filterAreaPath), `\`))
+	return areaPath == filterAreaPath || strings.HasPrefix(areaPath, filterAreaPath+`\`)
+}
@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestIsWorkItemMatchingFilters(t *testing.T) {
+	getWorkItem := func(eventType string, areaPath, workItemType interface{}) *serializers.SubscriptionNotification {
+		fields := serializers.Fields{AreaPath: areaPath, WorkItemType: workItemType}
+		if eventType == constants.SubscriptionEventWorkItemUpdated {
+			return &serializers.SubscriptionNotification{EventType: eventType, Resource: serializers.Resource{Revision: serializers.Revision{Fields: fields}}}
+		}
+		return &serializers.SubscriptionNotification{EventType: eventType, Resource: serializers.Resource{Fields: fields}}
+	}
+	for _, testCase := range []struct {
+		description  string
+		subscription *serializers.SubscriptionDetails
+		body         *serializers.SubscriptionNotification
+		isMatching   bool
+	}{
+		{
+			description:  "IsWorkItemMatchingFilters: all the work items are posted by default",
+			subscription: &serializers.SubscriptionDetails{},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemCreated, `mockProject\Billing`, "Bug"),
+			isMatching:   true,
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item in the area path of the filter",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\Billing\`},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemCreated, `mockProject\Billing`, "Bug"),
+			isMatching:   true,
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item in a child of the area path of the filter",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\Billing\`},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemUpdated, `mockProject\billing\Invoices`, "Bug"),
+			isMatching:   true,
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item in an area path starting like the one of the filter",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\Billing\`},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemCommented, `mockProject\BillingReports`, "Bug"),
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item in another area path",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\Billing\`},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemUpdated, `mockProject\Shipping`, "Bug"),
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item of the type of the filter",
+			subscription: &serializers.SubscriptionDetails{WorkItemType: "user story"},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemDeleted, `mockProject`, "User Story"),
+			isMatching:   true,
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item of another type",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\`, WorkItemType: "Bug"},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemCreated, `mockProject`, "Task"),
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: work item without the fields of the filters",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\Billing\`, WorkItemType: "Bug"},
+			body:         getWorkItem(constants.SubscriptionEventWorkItemCommented, nil, nil),
+			isMatching:   true,
+		},
+		{
+			description:  "IsWorkItemMatchingFilters: notification of another event is not filtered",
+			subscription: &serializers.SubscriptionDetails{AreaPath: `\mockProject\Billing\`, WorkItemType: "Bug"},
+			body:         &serializers.SubscriptionNotification{EventType: constants.SubscriptionEventCodePushed},
+			isMatching:   true,
+		},
+		{
+			description: "IsWorkItemMatchingFilters: notification without a subscription",
+			body:        getWorkItem(constants.SubscriptionEventWorkItemCreated, `mockProject\Shipping`, "Task"),
+			isMatching:  true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.isMatching, isWorkItemMatchingFilters(testCase.subscription, testCase.body))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// handleSearchProjectWorkItems returns the work items of a linked project matching the text typed by the user, along with the highlighted matches.
+// The webapp can send a request for every change of the text, so the number of results can be capped with the "top" query param.
+func (p *Plugin) handleSearchProjectWorkItems(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+	projectID := mux.Vars(r)[constants.PathParamProjectID]
+
+	text := strings.TrimSpace(r.URL.Query().Get(constants.QueryParamText))
+	if text == "" {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusBadRequest, Message: constants.WorkItemSearchTextRequired})
+		return
+	}
+
+	projectList, err := p.Store.GetAllProjects(mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorFetchProjectList, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	project, isProjectLinked := getLinkedProjectByID(projectList, projectID)
+	if !isProjectLinked {
+		p.handleError(w, r, &serializers.Error{Code: http.StatusNotFound, Message: constants.ProjectNotLinked})
+		return
+	}
+
+	searchResults, statusCode, err := p.Client.SearchWorkItems(project.OrganizationName, project.ProjectName, text, getWorkItemSearchTop(r), mattermostUserID)
+	if err != nil {
+		p.API.LogError(constants.ErrorSearchProjectWorkItems, "Error", err.Error())
+		p.handleError(w, r, &serializers.Error{Code: statusCode, Message: err.Error()})
+		return
+	}
+
+	workItems := make([]*serializers.WorkItemSearchSummary, 0, len(searchResults))
+	for _, searchResult := range searchResults {
+		workItemID, err := strconv.Atoi(searchResult.Fields.ID)
+		if err != nil {
+			continue
+		}
+
+		highlights := searchResult.Hits
+		if highlights == nil {
+			highlights = []*serializers.WorkItemSearchHit{}
+		}
+
+		workItems = append(workItems, &serializers.WorkItemSearchSummary{
+			ID:         workItemID,
+			Title:      searchResult.Fields.Title,
+			Type:       searchResult.Fields.Type,
+			State:      searchResult.Fields.State,
+			AssignedTo: searchResult.Fields.AssignedTo,
+			Link:       fmt.Sprintf(constants.WorkItemEditLink, p.getConfiguration().GetAzureDevopsAPIBaseURL(), project.OrganizationName, url.PathEscape(project.ProjectName), workItemID),
+			Highlights: highlights,
+		})
+	}
+
+	p.writeJSON(w, workItems)
+}
+
+// getWorkItemSearchTop returns the number of work items requested by the "top" query param.
+// The invalid values are replaced by the default instead of failing the request, and the larger ones are capped.
+func getWorkItemSearchTop(r *http.Request) int {
+	top, err := strconv.Atoi(r.URL.Query().Get(constants.QueryParamTop))
+	switch {
+	case err != nil || top <= 0:
+		return constants.WorkItemSearchDefaultResults
+	case top > constants.WorkItemSearchMaxResults:
+		return constants.WorkItemSearchMaxResults
+	default:
+		return top
+	}
+}
@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestHandleSearchProjectWorkItems(t *testing.T) {
+	projectList := []serializers.ProjectDetails{{OrganizationName: testutils.MockOrganization, ProjectID: testutils.MockProjectID, ProjectName: testutils.MockProjectName}}
+	highlights := []*serializers.WorkItemSearchHit{{FieldReferenceName: "system.title", Highlights: []string{"Fix the <highlighthit>login</highlighthit> page"}}}
+	for _, testCase := range []struct {
+		description        string
+		projectID          string
+		queryParams        string
+		expectedTop        int
+		searchResults      []*serializers.WorkItemSearchResult
+		searchErr          error
+		expectedStatusCode int
+		expectedWorkItems  []*serializers.WorkItemSearchSummary
+	}{
+		{
+			description: "HandleSearchProjectWorkItems: matching work items are returned with their highlights",
+			projectID:   testutils.MockProjectID,
+			queryParams: "?text=login&top=5",
+			expectedTop: 5,
+			searchResults: []*serializers.WorkItemSearchResult{
+				{Fields: serializers.WorkItemSearchFields{ID: "1", Title: "Fix the login page", Type: "Bug", State: "Active", AssignedTo: "mockUser <mockUser@example.com>"}, Hits: highlights},
+				{Fields: serializers.WorkItemSearchFields{ID: "2", Title: "Login with SSO", Type: "User Story", State: "New"}},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedWorkItems: []*serializers.WorkItemSearchSummary{
+				{ID: 1, Title: "Fix the login page", Type: "Bug", State: "Active", AssignedTo: "mockUser <mockUser@example.com>", Link: "https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/1", Highlights: highlights},
+				{ID: 2, Title: "Login with SSO", Type: "User Story", State: "New", Link: "https://dev.azure.com/mockOrganization/mockProjectName/_workitems/edit/2", Highlights: []*serializers.WorkItemSearchHit{}},
+			},
+		},
+		{
+			description:        "HandleSearchProjectWorkItems: no matching work items",
+			projectID:          testutils.MockProjectID,
+			queryParams:        "?text=login",
+			expectedTop:        constants.WorkItemSearchDefaultResults,
+			searchResults:      []*serializers.WorkItemSearchResult{},
+			expectedStatusCode: http.StatusOK,
+			expectedWorkItems:  []*serializers.WorkItemSearchSummary{},
+		},
+		{
+			description:        "HandleSearchProjectWorkItems: text is required",
+			projectID:          testutils.MockProjectID,
+			queryParams:        "?text=%20",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "HandleSearchProjectWorkItems: project is not linked",
+			projectID:          "mockUnlinkedProjectID",
+			queryParams:        "?text=login",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "HandleSearchProjectWorkItems: error in searching the work items",
+			projectID:          testutils.MockProjectID,
+			queryParams:        "?text=login",
+			expectedTop:        constants.WorkItemSearchDefaultResults,
+			searchErr:          errors.New("error searching the work items"),
+			expectedStatusCode: http.StatusForbidden,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("LogError", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			mockedStore := mocks.NewMockKVStore(mockCtrl)
+			p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+			p.setConfiguration(&config.Configuration{})
+
+			if testCase.expectedStatusCode != http.StatusBadRequest {
+				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return(projectList, nil)
+			}
+			if testCase.expectedTop != 0 {
+				mockedClient.EXPECT().SearchWorkItems(testutils.MockOrganization, testutils.MockProjectName, "login", testCase.expectedTop, testutils.MockMattermostUserID).Return(testCase.searchResults, testCase.expectedStatusCode, testCase.searchErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/project/"+testCase.projectID+"/search"+testCase.queryParams, nil)
+			req = mux.SetURLVars(req, map[string]string{constants.PathParamProjectID: testCase.projectID})
+			req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+			w := httptest.NewRecorder()
+			p.handleSearchProjectWorkItems(w, req)
+
+			require.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedWorkItems != nil {
+				var workItems []*serializers.WorkItemSearchSummary
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&workItems))
+				assert.Equal(t, testCase.expectedWorkItems, workItems)
+			}
+		})
+	}
+}
+
+func TestGetWorkItemSearchTop(t *testing.T) {
+	for queryParams, expectedTop := range map[string]int{
+		"":          constants.WorkItemSearchDefaultResults,
+		"?top=5":    5,
+		"?top=0":    constants.WorkItemSearchDefaultResults,
+		"?top=five": constants.WorkItemSearchDefaultResults,
+		"?top=1000": constants.WorkItemSearchMaxResults,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/project/"+testutils.MockProjectID+"/search"+queryParams, nil)
+		assert.Equal(t, expectedTop, getWorkItemSearchTop(req), queryParams)
+	}
+}
@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// workItemTypeStatesCacheEntry is the list of the states of a work item type cached until it expires
+type workItemTypeStatesCacheEntry struct {
+	key       string
+	states    []*serializers.WorkItemTypeState
+	expiresAt time.Time
+}
+
+// workItemTypeStatesCache is an LRU cache of the states of the work item types per project and type.
+// The states are fetched again once they expire, so that a color changed in the process of a project is picked up.
+// Its zero value is an empty cache of at most constants.WorkItemTypeStatesCacheMaxSize work item types.
+type workItemTypeStatesCache struct {
+	lock sync.Mutex
+	// maxSize is the number of work item types cached, the default one is used if it's 0
+	maxSize int
+	// entries are the elements of the order list by cache key
+	entries map[string]*list.Element
+	// order lists the entries from the most to the least recently used
+	order *list.List
+}
+
+// get returns the states cached for a work item type, the states are shared by the callers and must not be changed
+func (c *workItemTypeStatesCache) get(key string, now time.Time) ([]*serializers.WorkItemTypeState, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*workItemTypeStatesCacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.states, true
+}
+
+// set caches the states of a work item type, the least recently used work item type is evicted when the cache is full
+func (c *workItemTypeStatesCache) set(key string, states []*serializers.WorkItemTypeState, expiresAt time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[string]*list.Element{}
+		c.order = list.New()
+	}
+
+	entry := &workItemTypeStatesCacheEntry{key: key, states: states, expiresAt: expiresAt}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	maxSize := c.maxSize
+	if maxSize <= 0 {
+		maxSize = constants.WorkItemTypeStatesCacheMaxSize
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*workItemTypeStatesCacheEntry).key)
+	}
+}
@@ -0,0 +1,40 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestWorkItemTypeStatesCache(t *testing.T) {
+	now := time.Now()
+	states := []*serializers.WorkItemTypeState{{Name: "Active", Color: "007acc"}}
+
+	t.Run("WorkItemTypeStatesCache: expired states are removed", func(t *testing.T) {
+		cache := &workItemTypeStatesCache{}
+		cache.set("mockWorkItemType1", states, now.Add(time.Minute))
+
+		_, ok := cache.get("mockWorkItemType1", now.Add(time.Minute))
+		assert.False(t, ok)
+		assert.Empty(t, cache.entries)
+	})
+
+	t.Run("WorkItemTypeStatesCache: least recently used states are evicted", func(t *testing.T) {
+		cache := &workItemTypeStatesCache{maxSize: 2}
+		cache.set("mockWorkItemType1", states, now.Add(time.Minute))
+		cache.set("mockWorkItemType2", states, now.Add(time.Minute))
+		_, _ = cache.get("mockWorkItemType1", now)
+		cache.set("mockWorkItemType3", states, now.Add(time.Minute))
+
+		_, ok := cache.get("mockWorkItemType2", now)
+		assert.False(t, ok)
+		for _, key := range []string{"mockWorkItemType1", "mockWorkItemType3"} {
+			cachedStates, ok := cache.get(key, now)
+			assert.True(t, ok)
+			assert.Equal(t, states, cachedStates)
+		}
+	})
+}
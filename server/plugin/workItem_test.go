@@ -0,0 +1,351 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestParseWorkItemCodeLinks(t *testing.T) {
+	pullRequests, branches := parseWorkItemCodeLinks([]*serializers.WorkItemRelation{
+		{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F12"},
+		{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/Ref/mockProjectID%2FmockRepositoryID%2FGBfeature%2Fmock-branch"},
+		{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/Commit/mockProjectID%2FmockRepositoryID%2FmockCommitID"},
+		{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/Ref/mockProjectID%2FmockRepositoryID%2FGTmock-tag"},
+		{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/PullRequestId/invalid"},
+		{Rel: "System.LinkTypes.Hierarchy-Reverse", URL: "https://dev.azure.com/mockOrganization/_apis/wit/workItems/1"},
+	})
+
+	require.Len(t, pullRequests, 1)
+	assert.Equal(t, &workItemCodeLink{projectID: "mockProjectID", repositoryID: "mockRepositoryID", name: "12"}, pullRequests[0])
+	require.Len(t, branches, 1)
+	assert.Equal(t, &workItemCodeLink{projectID: "mockProjectID", repositoryID: "mockRepositoryID", name: "feature/mock-branch"}, branches[0])
+}
+
+func TestGetWorkItemDetails(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("GetConfig").Return(&model.Config{})
+	mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...)
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetSiteURL", func(*Plugin) string {
+		return "mockSiteURL"
+	})
+
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	mockedClient.EXPECT().GetWorkItemTypeStates(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, http.StatusOK, nil).AnyTimes()
+
+	t.Run("GetWorkItemDetails: linked pull requests and branches are shown", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetWorkItemExpanded(testutils.MockOrganization, testutils.MockProjectName, 1, testutils.MockMattermostUserID).Return(&serializers.WorkItemExpanded{
+			WorkItem: &serializers.TaskValue{
+				ID: 1,
+				Relations: []*serializers.WorkItemRelation{
+					{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F12"},
+					{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/Ref/mockProjectID%2FmockRepositoryID%2FGBmock-branch"},
+					{Rel: constants.RelationAttachedFile, URL: "https://dev.azure.com/mockOrganization/_apis/wit/attachments/mockAttachmentID"},
+				},
+			},
+			AttachmentCount: 1,
+			CommentCount:    4,
+		}, http.StatusOK, nil)
+		// The repository is fetched only once for both the links
+		mockedClient.EXPECT().GetGitRepository(testutils.MockOrganization, "mockProjectID", "mockRepositoryID", testutils.MockMattermostUserID).Return(&serializers.GitRepository{
+			Name:   "mockRepository",
+			WebURL: "https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepository",
+		}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetPullRequest(testutils.MockOrganization, "12", "mockProjectID", testutils.MockMattermostUserID).Return(&serializers.PullRequest{Title: "mockTitle"}, http.StatusOK, nil)
+
+		attachment, message, err := p.getWorkItemDetails(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+		assert.NoError(t, err)
+		assert.Empty(t, message)
+		require.NotNil(t, attachment)
+		require.Len(t, attachment.Fields, 7)
+		assert.Equal(t, "- [!12: mockTitle](https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepository/pullrequest/12) in mockRepository", attachment.Fields[3].Value)
+		assert.Equal(t, "- [mock-branch](https://dev.azure.com/mockOrganization/mockProjectName/_git/mockRepository?version=GBmock-branch) in mockRepository", attachment.Fields[4].Value)
+		assert.Equal(t, &model.SlackAttachmentField{Title: "Comments", Value: "4", Short: true}, attachment.Fields[5])
+		assert.Equal(t, &model.SlackAttachmentField{Title: "Attachments", Value: "1", Short: true}, attachment.Fields[6])
+	})
+
+	t.Run("GetWorkItemDetails: work item without code links", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetWorkItemExpanded(testutils.MockOrganization, testutils.MockProjectName, 1, testutils.MockMattermostUserID).Return(&serializers.WorkItemExpanded{WorkItem: &serializers.TaskValue{ID: 1}}, http.StatusOK, nil)
+
+		attachment, _, err := p.getWorkItemDetails(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+		assert.NoError(t, err)
+		require.NotNil(t, attachment)
+		assert.Len(t, attachment.Fields, 5)
+	})
+
+	t.Run("GetWorkItemDetails: pull request and repository can't be fetched", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetWorkItemExpanded(testutils.MockOrganization, testutils.MockProjectName, 1, testutils.MockMattermostUserID).Return(&serializers.WorkItemExpanded{
+			WorkItem: &serializers.TaskValue{
+				ID: 1,
+				Relations: []*serializers.WorkItemRelation{
+					{Rel: constants.RelationArtifactLink, URL: "vstfs:///Git/PullRequestId/mockProjectID%2FmockRepositoryID%2F12"},
+				},
+			},
+		}, http.StatusOK, nil)
+		mockedClient.EXPECT().GetGitRepository(testutils.MockOrganization, "mockProjectID", "mockRepositoryID", testutils.MockMattermostUserID).Return(nil, http.StatusNotFound, errors.New("error in getting the repository"))
+		mockedClient.EXPECT().GetPullRequest(testutils.MockOrganization, "12", "mockProjectID", testutils.MockMattermostUserID).Return(nil, http.StatusNotFound, errors.New("error in getting the pull request"))
+
+		attachment, _, err := p.getWorkItemDetails(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+		assert.NoError(t, err)
+		require.Len(t, attachment.Fields, 6)
+		assert.Equal(t, "- !12", attachment.Fields[3].Value)
+	})
+
+	t.Run("GetWorkItemDetails: work item does not exist", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetWorkItemExpanded(testutils.MockOrganization, testutils.MockProjectName, 2, testutils.MockMattermostUserID).Return(nil, http.StatusNotFound, errors.New("error in getting the work item"))
+
+		attachment, message, err := p.getWorkItemDetails(testutils.MockMattermostUserID, testutils.MockProjectName, "2")
+
+		assert.NoError(t, err)
+		assert.Nil(t, attachment)
+		assert.Equal(t, fmt.Sprintf(constants.WorkItemNotFound, "2", testutils.MockProjectName), message)
+	})
+
+	t.Run("GetWorkItemDetails: invalid work item ID", func(t *testing.T) {
+		attachment, message, err := p.getWorkItemDetails(testutils.MockMattermostUserID, testutils.MockProjectName, "mockID")
+
+		assert.NoError(t, err)
+		assert.Nil(t, attachment)
+		assert.Equal(t, fmt.Sprintf(constants.InvalidWorkItemID, "mockID"), message)
+	})
+}
+
+func TestGetDeleteWorkItemConfirmation(t *testing.T) {
+	defer monkey.UnpatchAll()
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	mockAPI.On("GetConfig").Return(&model.Config{})
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetSiteURL", func(*Plugin) string {
+		return "mockSiteURL"
+	})
+
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	workItem := &serializers.TaskValue{ID: 1, Fields: serializers.TaskFieldValue{Title: "mockTitle", Type: "Bug"}}
+	for _, testCase := range []struct {
+		description          string
+		workItemID           string
+		destroy              bool
+		scopes               []string
+		workItemStatusCode   int
+		workItemErr          error
+		expectedMessage      string
+		expectedText         string
+		expectedConfirmLabel string
+	}{
+		{
+			description:          "GetDeleteWorkItemConfirmation: work item is moved to the recycle bin by default",
+			workItemID:           "1",
+			scopes:               []string{constants.ScopeWorkFull},
+			workItemStatusCode:   http.StatusOK,
+			expectedText:         fmt.Sprintf(constants.DeleteWorkItemConfirmation, testutils.MockProjectName),
+			expectedConfirmLabel: "Delete",
+		},
+		{
+			description:          "GetDeleteWorkItemConfirmation: work item is destroyed",
+			workItemID:           "1",
+			destroy:              true,
+			scopes:               []string{constants.ScopeWorkWrite},
+			workItemStatusCode:   http.StatusOK,
+			expectedText:         constants.DestroyWorkItemConfirmation,
+			expectedConfirmLabel: "Destroy",
+		},
+		{
+			description:     "GetDeleteWorkItemConfirmation: invalid work item ID",
+			workItemID:      "abc",
+			expectedMessage: fmt.Sprintf(constants.InvalidWorkItemID, "abc"),
+		},
+		{
+			description:     "GetDeleteWorkItemConfirmation: write scope is not granted",
+			workItemID:      "1",
+			scopes:          []string{constants.ScopeWork},
+			expectedMessage: fmt.Sprintf(constants.ErrorMissingScope, constants.ScopeWorkWrite, constants.ScopeWork),
+		},
+		{
+			description:        "GetDeleteWorkItemConfirmation: work item does not exist",
+			workItemID:         "1",
+			scopes:             []string{constants.ScopeWorkFull},
+			workItemStatusCode: http.StatusNotFound,
+			workItemErr:        errors.New("work item not found"),
+			expectedMessage:    fmt.Sprintf(constants.WorkItemNotFound, "1", testutils.MockProjectName),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			if testCase.scopes != nil {
+				mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+				mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+				mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{Scopes: testCase.scopes}, nil)
+			}
+			if testCase.workItemStatusCode != 0 {
+				mockedClient.EXPECT().GetWorkItem(testutils.MockOrganization, testCase.workItemID, testutils.MockProjectName, testutils.MockMattermostUserID).Return(workItem, testCase.workItemStatusCode, testCase.workItemErr)
+			}
+
+			attachment, message, err := p.getDeleteWorkItemConfirmation(testutils.MockMattermostUserID, testutils.MockProjectName, testCase.workItemID, testCase.destroy)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+			if testCase.expectedMessage != "" {
+				assert.Nil(t, attachment)
+				return
+			}
+
+			require.NotNil(t, attachment)
+			assert.Equal(t, testCase.expectedText, attachment.Text)
+			require.Len(t, attachment.Actions, 2)
+			assert.Equal(t, testCase.expectedConfirmLabel, attachment.Actions[0].Name)
+			assert.Equal(t, "mockSiteURL/plugins/mattermost-plugin-azure-devops/api/v1/workitems/delete", attachment.Actions[0].Integration.URL)
+			assert.Equal(t, map[string]interface{}{
+				constants.DeleteWorkItemContextOrganization: testutils.MockOrganization,
+				constants.DeleteWorkItemContextProjectName:  testutils.MockProjectName,
+				constants.DeleteWorkItemContextWorkItemID:   1,
+				constants.DeleteWorkItemContextDestroy:      testCase.destroy,
+				constants.DeleteWorkItemContextAction:       constants.DeleteWorkItemActionConfirm,
+			}, attachment.Actions[0].Integration.Context)
+			assert.Equal(t, constants.DeleteWorkItemActionCancel, attachment.Actions[1].Integration.Context[constants.DeleteWorkItemContextAction])
+		})
+	}
+}
+
+func TestGetRecycleBinTable(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+
+	t.Run("GetRecycleBinTable: most recently deleted work items are shown first", func(t *testing.T) {
+		olderDate := time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC)
+		newerDate := time.Date(2023, time.March, 4, 0, 0, 0, 0, time.UTC)
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetRecycleBin(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID).Return([]*serializers.DeletedWorkItem{
+			{ID: 1, Name: "mock | title", Type: "Bug", DeletedBy: "mockUser", DeletedDate: &olderDate},
+			{ID: 2, Name: "mockTitle", Type: "Task"},
+			{ID: 3, Name: "mockTitle", Type: "Epic", DeletedBy: "mockUser", DeletedDate: &newerDate},
+		}, http.StatusOK, nil)
+
+		message, err := p.getRecycleBinTable(testutils.MockMattermostUserID, testutils.MockProjectName)
+
+		require.NoError(t, err)
+		assert.Equal(t, "###### Recycle bin of mockOrganization/mockProjectName\n"+
+			"| ID | Type | Title | Deleted By | Deleted On |\n"+
+			"| :- | :--- | :---- | :--------- | :--------- |\n"+
+			"| 3 | Epic | mockTitle | mockUser | Mar 4, 2023 |\n"+
+			"| 1 | Bug | mock \\| title | mockUser | Jan 2, 2023 |\n"+
+			"| 2 | Task | mockTitle |  |  |\n"+
+			"\nShowing the 3 most recently deleted of 3 work items. Use `/azuredevops boards restore mockProjectName [work item ID]` to restore a work item.", message)
+	})
+
+	t.Run("GetRecycleBinTable: recycle bin is empty", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+		mockedClient.EXPECT().GetRecycleBin(testutils.MockOrganization, testutils.MockProjectName, testutils.MockMattermostUserID).Return([]*serializers.DeletedWorkItem{}, http.StatusOK, nil)
+
+		message, err := p.getRecycleBinTable(testutils.MockMattermostUserID, testutils.MockProjectName)
+
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.RecycleBinEmpty, testutils.MockProjectName), message)
+	})
+
+	t.Run("GetRecycleBinTable: project is not linked", func(t *testing.T) {
+		mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{}, nil)
+
+		message, err := p.getRecycleBinTable(testutils.MockMattermostUserID, testutils.MockProjectName)
+
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.ProjectNotLinkedWithName, testutils.MockProjectName), message)
+	})
+}
+
+func TestRestoreDeletedWorkItem(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockCtrl := gomock.NewController(t)
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	p := setupMockPlugin(mockAPI, mockedStore, mockedClient)
+	project := serializers.ProjectDetails{OrganizationName: testutils.MockOrganization, ProjectName: testutils.MockProjectName}
+	for _, testCase := range []struct {
+		description       string
+		restoreStatusCode int
+		restoreErr        error
+		expectedMessage   string
+		expectedErr       string
+	}{
+		{
+			description:       "RestoreDeletedWorkItem: work item is restored",
+			restoreStatusCode: http.StatusOK,
+			expectedMessage:   fmt.Sprintf(constants.WorkItemRestored, 1),
+		},
+		{
+			description:       "RestoreDeletedWorkItem: work item is past the retention of the recycle bin",
+			restoreStatusCode: http.StatusNotFound,
+			restoreErr:        errors.New("work item not found"),
+			expectedMessage:   fmt.Sprintf(constants.WorkItemNotInRecycleBin, "1", testutils.MockProjectName),
+		},
+		{
+			description:       "RestoreDeletedWorkItem: user is not allowed to restore the work item",
+			restoreStatusCode: http.StatusForbidden,
+			restoreErr:        errors.New("forbidden"),
+			expectedMessage:   fmt.Sprintf(constants.WorkItemRestoreForbidden, "1"),
+		},
+		{
+			description:       "RestoreDeletedWorkItem: error in restoring the work item",
+			restoreStatusCode: http.StatusInternalServerError,
+			restoreErr:        errors.New("error restoring the work item"),
+			expectedErr:       "error restoring the work item",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockedStore.EXPECT().GetAllProjects(testutils.MockMattermostUserID).Return([]serializers.ProjectDetails{project}, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return(testutils.MockAzureDevopsUserID, nil)
+			mockedStore.EXPECT().LoadAzureDevopsUserDetails(testutils.MockAzureDevopsUserID).Return(&serializers.User{Scopes: []string{constants.ScopeWorkFull}}, nil)
+			mockedClient.EXPECT().RestoreWorkItem(testutils.MockOrganization, testutils.MockProjectName, 1, testutils.MockMattermostUserID).Return(testCase.restoreStatusCode, testCase.restoreErr)
+
+			message, err := p.restoreDeletedWorkItem(testutils.MockMattermostUserID, testutils.MockProjectName, "1")
+
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMessage, message)
+		})
+	}
+
+	t.Run("RestoreDeletedWorkItem: invalid work item ID", func(t *testing.T) {
+		message, err := p.restoreDeletedWorkItem(testutils.MockMattermostUserID, testutils.MockProjectName, "abc")
+
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(constants.InvalidWorkItemID, "abc"), message)
+	})
+}
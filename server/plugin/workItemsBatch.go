@@ -0,0 +1,76 @@
+package plugin
+
+import (
+	"sync"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// workItemsBatchResult is the result of fetching the work items in a batch of the IDs returned by a query
+type workItemsBatchResult struct {
+	workItems []*serializers.TaskValue
+	err       error
+}
+
+// getWorkItemsInBatches fetches the given fields of the work items in batches of the configured size, a few batches at the same time.
+// The work items are returned in the order of the batches, and a batch failing again after a retry doesn't fail the whole result:
+// the IDs of its work items are returned as errored instead. An error is only returned if all the batches fail.
+func (p *Plugin) getWorkItemsInBatches(organization, projectName string, workItemIDs []int, fields []string, mattermostUserID string) ([]*serializers.TaskValue, map[int]bool, error) {
+	batchSize := p.getConfiguration().GetWorkItemsBatchSize()
+	var batches [][]int
+	for start := 0; start < len(workItemIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(workItemIDs) {
+			end = len(workItemIDs)
+		}
+		batches = append(batches, workItemIDs[start:end])
+	}
+
+	results := make([]workItemsBatchResult, len(batches))
+	batchIndexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < constants.WorkItemsBatchWorkers && worker < len(batches); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range batchIndexes {
+				workItems, _, err := p.Client.GetWorkItemsBatch(organization, projectName, batches[index], fields, mattermostUserID)
+				if err != nil {
+					p.API.LogDebug("Error in fetching a batch of work items, retrying it", "Error", err.Error())
+					workItems, _, err = p.Client.GetWorkItemsBatch(organization, projectName, batches[index], fields, mattermostUserID)
+				}
+				results[index] = workItemsBatchResult{workItems: workItems, err: err}
+			}
+		}()
+	}
+
+	for index := range batches {
+		batchIndexes <- index
+	}
+	close(batchIndexes)
+	wg.Wait()
+
+	var workItems []*serializers.TaskValue
+	erroredWorkItemIDs := map[int]bool{}
+	var err error
+	failedBatchCount := 0
+	for index, result := range results {
+		if result.err != nil {
+			p.API.LogError("Error in fetching a batch of work items", "Error", result.err.Error())
+			err = result.err
+			failedBatchCount++
+			for _, workItemID := range batches[index] {
+				erroredWorkItemIDs[workItemID] = true
+			}
+			continue
+		}
+		workItems = append(workItems, result.workItems...)
+	}
+
+	if failedBatchCount > 0 && failedBatchCount == len(batches) {
+		return nil, nil, err
+	}
+
+	return workItems, erroredWorkItemIDs, nil
+}
@@ -0,0 +1,126 @@
+package plugin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func getMockWorkItems(workItemIDs []int) []*serializers.TaskValue {
+	workItems := make([]*serializers.TaskValue, 0, len(workItemIDs))
+	for _, workItemID := range workItemIDs {
+		workItems = append(workItems, &serializers.TaskValue{ID: workItemID})
+	}
+	return workItems
+}
+
+func TestGetWorkItemsInBatches(t *testing.T) {
+	workItemIDs := make([]int, 0, 450)
+	for workItemID := 1; workItemID <= 450; workItemID++ {
+		workItemIDs = append(workItemIDs, workItemID)
+	}
+	fields := []string{"System.Title"}
+	for _, testCase := range []struct {
+		description           string
+		batchSize             int
+		failedBatchStart      int
+		failedBatchCallCount  int
+		expectedCallCount     int
+		expectedWorkItemCount int
+		expectedErroredCount  int
+	}{
+		{
+			description:           "GetWorkItemsInBatches: work items are fetched in batches of the most Azure DevOps allows by default",
+			expectedCallCount:     3,
+			expectedWorkItemCount: 450,
+		},
+		{
+			description:           "GetWorkItemsInBatches: work items are fetched in batches of the configured size",
+			batchSize:             50,
+			expectedCallCount:     9,
+			expectedWorkItemCount: 450,
+		},
+		{
+			description:           "GetWorkItemsInBatches: failing batch is retried",
+			batchSize:             100,
+			failedBatchStart:      201,
+			failedBatchCallCount:  1,
+			expectedCallCount:     6,
+			expectedWorkItemCount: 450,
+		},
+		{
+			description:           "GetWorkItemsInBatches: work items of a batch failing again are errored",
+			batchSize:             100,
+			failedBatchStart:      201,
+			failedBatchCallCount:  2,
+			expectedCallCount:     6,
+			expectedWorkItemCount: 350,
+			expectedErroredCount:  100,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockCtrl := gomock.NewController(t)
+			mockedClient := mocks.NewMockClient(mockCtrl)
+			p := setupMockPlugin(mockAPI, nil, mockedClient)
+			p.setConfiguration(&config.Configuration{WorkItemsBatchSize: testCase.batchSize})
+			mockAPI.On("LogDebug", mock.AnythingOfType("string"), "Error", "mockError").Maybe()
+			mockAPI.On("LogError", mock.AnythingOfType("string"), "Error", "mockError").Maybe()
+
+			failedCallCount := 0
+			mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), fields, testutils.MockMattermostUserID).DoAndReturn(
+				func(_, _ string, batch []int, _ []string, _ string) ([]*serializers.TaskValue, int, error) {
+					// The batches are fetched at the same time, but only one of them fails so the count is not shared between goroutines
+					if batch[0] == testCase.failedBatchStart && failedCallCount < testCase.failedBatchCallCount {
+						failedCallCount++
+						return nil, http.StatusInternalServerError, errors.New("mockError")
+					}
+					return getMockWorkItems(batch), http.StatusOK, nil
+				},
+			).Times(testCase.expectedCallCount)
+
+			workItems, erroredWorkItemIDs, err := p.getWorkItemsInBatches(testutils.MockOrganization, testutils.MockProjectName, workItemIDs, fields, testutils.MockMattermostUserID)
+
+			require.NoError(t, err)
+			assert.Len(t, erroredWorkItemIDs, testCase.expectedErroredCount)
+			require.Len(t, workItems, testCase.expectedWorkItemCount)
+			for index := 1; index < len(workItems); index++ {
+				assert.Less(t, workItems[index-1].ID, workItems[index].ID)
+			}
+			for _, workItem := range workItems {
+				assert.False(t, erroredWorkItemIDs[workItem.ID])
+			}
+			if testCase.expectedErroredCount > 0 {
+				assert.True(t, erroredWorkItemIDs[testCase.failedBatchStart])
+			}
+		})
+	}
+
+	t.Run("GetWorkItemsInBatches: all the batches fail", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		mockCtrl := gomock.NewController(t)
+		mockedClient := mocks.NewMockClient(mockCtrl)
+		p := setupMockPlugin(mockAPI, nil, mockedClient)
+		p.setConfiguration(&config.Configuration{WorkItemsBatchSize: 200})
+		mockAPI.On("LogDebug", mock.AnythingOfType("string"), "Error", "mockError")
+		mockAPI.On("LogError", mock.AnythingOfType("string"), "Error", "mockError")
+		mockedClient.EXPECT().GetWorkItemsBatch(testutils.MockOrganization, testutils.MockProjectName, gomock.Any(), fields, testutils.MockMattermostUserID).Return(nil, http.StatusInternalServerError, errors.New("mockError")).Times(6)
+
+		workItems, erroredWorkItemIDs, err := p.getWorkItemsInBatches(testutils.MockOrganization, testutils.MockProjectName, workItemIDs, fields, testutils.MockMattermostUserID)
+
+		assert.EqualError(t, err, "mockError")
+		assert.Nil(t, workItems)
+		assert.Nil(t, erroredWorkItemIDs)
+	})
+}
@@ -0,0 +1,98 @@
+package plugin
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+// tokenBucket holds the tokens of a user, refilled at the rate limit up to the burst
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// writeRateLimiter limits the requests changing data sent by each user, e.g. by a client creating work items in a loop.
+// The buckets are kept in memory, so the requests are counted on each server of a cluster separately.
+type writeRateLimiter struct {
+	lock sync.Mutex
+	// buckets are the token buckets by Mattermost user ID
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newWriteRateLimiter() *writeRateLimiter {
+	return &writeRateLimiter{
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// allow takes a token from the bucket of a user, or returns how long the user should wait for the next one if the bucket is empty
+func (l *writeRateLimiter) allow(mattermostUserID string, requestsPerMinute, burst int, now time.Time) (bool, time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	tokensPerSecond := float64(requestsPerMinute) / 60
+	capacity := float64(burst)
+	if now.Sub(l.lastSweep) >= constants.WriteRateLimiterSweepInterval {
+		l.sweep(tokensPerSecond, capacity, now)
+	}
+
+	bucket, ok := l.buckets[mattermostUserID]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		l.buckets[mattermostUserID] = bucket
+	} else if now.After(bucket.updatedAt) {
+		bucket.tokens = math.Min(capacity, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*tokensPerSecond)
+		bucket.updatedAt = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - bucket.tokens) / tokensPerSecond * float64(time.Second))
+}
+
+// sweep removes the buckets which would be full by now, as they are the same as the ones created for a new request
+func (l *writeRateLimiter) sweep(tokensPerSecond, capacity float64, now time.Time) {
+	for mattermostUserID, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*tokensPerSecond >= capacity {
+			delete(l.buckets, mattermostUserID)
+		}
+	}
+
+	l.lastSweep = now
+}
+
+// checkWriteRateLimit rejects the request with a 429 when the user has sent more requests changing data than the configured limit.
+// It's checked before the OAuth so that the rejected requests don't read the KV store.
+func (p *Plugin) checkWriteRateLimit(handleFunc http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		config := p.getConfiguration()
+		if config.WriteRequestRateLimit > 0 {
+			burst := config.WriteRequestBurst
+			if burst == 0 {
+				burst = config.WriteRequestRateLimit
+			}
+
+			mattermostUserID := r.Header.Get(constants.HeaderMattermostUserID)
+			if allowed, retryAfter := p.writeRateLimiter.allow(mattermostUserID, config.WriteRequestRateLimit, burst, time.Now()); !allowed {
+				retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+				p.API.LogDebug("Rejecting a request over the write request rate limit", "MattermostUserID", mattermostUserID, "Path", r.URL.Path)
+				w.Header().Set(constants.RetryAfter, strconv.Itoa(retryAfterSeconds))
+				p.handleError(w, r, &serializers.Error{Code: http.StatusTooManyRequests, Message: fmt.Sprintf(constants.TooManyWriteRequests, retryAfterSeconds)})
+				return
+			}
+		}
+
+		handleFunc(w, r)
+	}
+}
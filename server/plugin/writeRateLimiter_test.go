@@ -0,0 +1,179 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/mocks"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/config"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
+)
+
+func TestWriteRateLimiter(t *testing.T) {
+	now := time.Now()
+
+	t.Run("WriteRateLimiter: requests over the burst are rejected until a token is refilled", func(t *testing.T) {
+		limiter := newWriteRateLimiter()
+		for i := 0; i < 2; i++ {
+			allowed, _ := limiter.allow("mockUser1", 6, 2, now)
+			assert.True(t, allowed)
+		}
+
+		allowed, retryAfter := limiter.allow("mockUser1", 6, 2, now)
+		assert.False(t, allowed)
+		assert.Equal(t, 10*time.Second, retryAfter)
+
+		allowed, retryAfter = limiter.allow("mockUser1", 6, 2, now.Add(4*time.Second))
+		assert.False(t, allowed)
+		assert.InDelta(t, 6*time.Second, retryAfter, float64(time.Millisecond))
+
+		allowed, _ = limiter.allow("mockUser1", 6, 2, now.Add(10*time.Second))
+		assert.True(t, allowed)
+	})
+
+	t.Run("WriteRateLimiter: users have their own buckets", func(t *testing.T) {
+		limiter := newWriteRateLimiter()
+		allowed, _ := limiter.allow("mockUser1", 6, 1, now)
+		assert.True(t, allowed)
+		allowed, _ = limiter.allow("mockUser1", 6, 1, now)
+		assert.False(t, allowed)
+
+		allowed, _ = limiter.allow("mockUser2", 6, 1, now)
+		assert.True(t, allowed)
+	})
+
+	t.Run("WriteRateLimiter: refilled buckets are removed", func(t *testing.T) {
+		limiter := newWriteRateLimiter()
+		_, _ = limiter.allow("mockUser1", 60, 10, now)
+		_, _ = limiter.allow("mockUser2", 60, 10, now.Add(constants.WriteRateLimiterSweepInterval-time.Second))
+		for i := 0; i < 10; i++ {
+			_, _ = limiter.allow("mockUser3", 60, 10, now.Add(constants.WriteRateLimiterSweepInterval-time.Second))
+		}
+
+		_, _ = limiter.allow("mockUser4", 60, 10, now.Add(constants.WriteRateLimiterSweepInterval))
+
+		assert.NotContains(t, limiter.buckets, "mockUser1")
+		for _, mattermostUserID := range []string{"mockUser3", "mockUser4"} {
+			assert.Contains(t, limiter.buckets, mattermostUserID)
+		}
+	})
+}
+
+func TestCheckWriteRateLimit(t *testing.T) {
+	for _, testCase := range []struct {
+		description          string
+		config               *config.Configuration
+		expectedStatusCodes  []int
+		expectedRetryAfter   string
+		expectedHandlerCalls int
+	}{
+		{
+			description:          "CheckWriteRateLimit: requests over the burst are rejected",
+			config:               &config.Configuration{WriteRequestRateLimit: 30, WriteRequestBurst: 2},
+			expectedStatusCodes:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedRetryAfter:   "2",
+			expectedHandlerCalls: 2,
+		},
+		{
+			description:          "CheckWriteRateLimit: burst defaults to the rate limit",
+			config:               &config.Configuration{WriteRequestRateLimit: 1},
+			expectedStatusCodes:  []int{http.StatusOK, http.StatusTooManyRequests},
+			expectedRetryAfter:   "60",
+			expectedHandlerCalls: 1,
+		},
+		{
+			description:          "CheckWriteRateLimit: rate limit is disabled",
+			config:               &config.Configuration{},
+			expectedStatusCodes:  []int{http.StatusOK, http.StatusOK, http.StatusOK},
+			expectedHandlerCalls: 3,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockAPI := &plugintest.API{}
+			mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...)
+			p := setupMockPlugin(mockAPI, nil, nil)
+			p.writeRateLimiter = newWriteRateLimiter()
+			p.setConfiguration(testCase.config)
+
+			handlerCalls := 0
+			handler := p.checkWriteRateLimit(func(w http.ResponseWriter, r *http.Request) {
+				handlerCalls++
+			})
+
+			var res *httptest.ResponseRecorder
+			for _, expectedStatusCode := range testCase.expectedStatusCodes {
+				req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{}`))
+				req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+				res = httptest.NewRecorder()
+
+				handler(res, req)
+
+				assert.Equal(t, expectedStatusCode, res.Code)
+			}
+
+			assert.Equal(t, testCase.expectedHandlerCalls, handlerCalls)
+			assert.Equal(t, testCase.expectedRetryAfter, res.Header().Get(constants.RetryAfter))
+		})
+	}
+}
+
+func TestWriteRateLimitRoutes(t *testing.T) {
+	mockAPI := &plugintest.API{}
+	mockAPI.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+	mockAPI.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...)
+	mockCtrl := gomock.NewController(t)
+	mockedStore := mocks.NewMockKVStore(mockCtrl)
+	mockedStore.EXPECT().LoadAzureDevopsUserIDFromMattermostUser(testutils.MockMattermostUserID).Return("", errors.New("error loading the user")).AnyTimes()
+	p := setupMockPlugin(mockAPI, mockedStore, nil)
+	p.writeRateLimiter = newWriteRateLimiter()
+	p.setConfiguration(&config.Configuration{WriteRequestRateLimit: 1})
+	p.InitRoutes()
+
+	for _, testCase := range []struct {
+		description        string
+		method             string
+		path               string
+		expectedStatusCode int
+	}{
+		{
+			description:        "WriteRateLimitRoutes: reading the subscriptions is not limited",
+			method:             http.MethodGet,
+			path:               "/subscriptions/mockTeamID/mockOrganization/mockProject",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			description:        "WriteRateLimitRoutes: creating a subscription is limited",
+			method:             http.MethodPost,
+			path:               constants.PathSubscriptions,
+			expectedStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			description:        "WriteRateLimitRoutes: creating a task is limited",
+			method:             http.MethodPost,
+			path:               constants.PathCreateTasks,
+			expectedStatusCode: http.StatusTooManyRequests,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var res *httptest.ResponseRecorder
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest(testCase.method, constants.APIPrefix+testCase.path, bytes.NewBufferString(`{}`))
+				req.Header.Add(constants.HeaderMattermostUserID, testutils.MockMattermostUserID)
+				res = httptest.NewRecorder()
+
+				p.router.ServeHTTP(res, req)
+			}
+
+			assert.Equal(t, testCase.expectedStatusCode, res.Code)
+		})
+	}
+}
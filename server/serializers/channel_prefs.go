@@ -0,0 +1,151 @@
+package serializers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/i18n"
+)
+
+var hexColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ChannelNotificationPrefs are the options used to render the notifications of all the subscriptions of a channel.
+// The default rendering is used for the options which are not set.
+type ChannelNotificationPrefs struct {
+	ChannelID   string `json:"channelID"`
+	Color       string `json:"color,omitempty"`
+	KeepRawHTML *bool  `json:"keepRawHTML,omitempty"`
+	ShowEmoji   *bool  `json:"showEmoji,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Summary     *bool  `json:"summary,omitempty"`
+	SummaryDay  string `json:"summaryDay,omitempty"`
+	SummaryHour *int   `json:"summaryHour,omitempty"`
+}
+
+// Set updates an option from the value given in the slash command, the value "default" unsets the option
+func (t *ChannelNotificationPrefs) Set(option, value string) error {
+	isDefault := value == constants.ChannelPrefValueDefault
+	switch option {
+	case constants.ChannelPrefColor:
+		if !isDefault && !hexColorRegex.MatchString(value) {
+			return errors.New(constants.InvalidChannelPrefColor)
+		}
+		t.Color = ""
+		if !isDefault {
+			t.Color = value
+		}
+	case constants.ChannelPrefHTML, constants.ChannelPrefEmoji, constants.ChannelPrefSummary:
+		var boolValue *bool
+		if !isDefault {
+			parsedValue, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf(constants.InvalidChannelPrefBool, option)
+			}
+			boolValue = &parsedValue
+		}
+
+		switch option {
+		case constants.ChannelPrefHTML:
+			t.KeepRawHTML = boolValue
+		case constants.ChannelPrefEmoji:
+			t.ShowEmoji = boolValue
+		default:
+			t.Summary = boolValue
+		}
+	case constants.ChannelPrefTimezone:
+		if !isDefault {
+			if _, err := time.LoadLocation(value); err != nil {
+				return fmt.Errorf(constants.InvalidChannelPrefTimezone, value)
+			}
+		}
+		t.Timezone = ""
+		if !isDefault {
+			t.Timezone = value
+		}
+	case constants.ChannelPrefLanguage:
+		if !isDefault && !i18n.IsSupportedLocale(value) {
+			return fmt.Errorf(constants.InvalidChannelPrefLanguage, value, strings.Join(i18n.GetSupportedLocales(), ", "))
+		}
+		t.Language = ""
+		if !isDefault {
+			t.Language = strings.ToLower(value)
+		}
+	case constants.ChannelPrefSummaryDay:
+		if _, ok := parseWeekday(value); !isDefault && !ok {
+			return fmt.Errorf(constants.InvalidChannelPrefDay, value)
+		}
+		t.SummaryDay = ""
+		if !isDefault {
+			t.SummaryDay = strings.ToLower(value)
+		}
+	case constants.ChannelPrefSummaryHour:
+		var hour *int
+		if !isDefault {
+			parsedValue, err := strconv.Atoi(value)
+			if err != nil || parsedValue < 0 || parsedValue > 23 {
+				return fmt.Errorf(constants.InvalidChannelPrefHour, value)
+			}
+			hour = &parsedValue
+		}
+		t.SummaryHour = hour
+	default:
+		return fmt.Errorf(constants.InvalidChannelPref, option)
+	}
+
+	return nil
+}
+
+// IsEmojiShown checks if the status emoji is prefixed to the notifications, which it is by default
+func (t *ChannelNotificationPrefs) IsEmojiShown() bool {
+	return t.ShowEmoji == nil || *t.ShowEmoji
+}
+
+// GetLocation returns the location used to show the times in the notifications, UTC is used by default
+func (t *ChannelNotificationPrefs) GetLocation() *time.Location {
+	if t.Timezone == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// IsSummaryEnabled checks if the weekly summary of the notifications is posted in the channel, which it isn't by default
+func (t *ChannelNotificationPrefs) IsSummaryEnabled() bool {
+	return t.Summary != nil && *t.Summary
+}
+
+// GetSummarySchedule returns the day and the hour in the timezone of the channel when the weekly summary is posted, it's Monday at 9:00 by default
+func (t *ChannelNotificationPrefs) GetSummarySchedule() (time.Weekday, int) {
+	weekday, ok := parseWeekday(t.SummaryDay)
+	if !ok {
+		weekday, _ = parseWeekday(constants.WeeklySummaryDefaultDay)
+	}
+
+	hour := constants.WeeklySummaryDefaultHour
+	if t.SummaryHour != nil {
+		hour = *t.SummaryHour
+	}
+
+	return weekday, hour
+}
+
+func parseWeekday(value string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), value) {
+			return weekday, true
+		}
+	}
+
+	return time.Sunday, false
+}
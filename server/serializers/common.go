@@ -1,5 +1,12 @@
 package serializers
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
 // Error struct to store error codes and error message.
 type Error struct {
 	Code    int
@@ -9,3 +16,21 @@ type Error struct {
 type SuccessResponse struct {
 	Message string `json:"message"`
 }
+
+// AzureError is the body of an error response of the Azure DevOps APIs, e.g. when a work item type does not exist
+type AzureError struct {
+	Message   string `json:"message"`
+	TypeKey   string `json:"typeKey"`
+	ErrorCode int    `json:"errorCode"`
+	// RetryAfter is the wait requested by Azure DevOps in the Retry-After header before sending the request again
+	RetryAfter time.Duration `json:"-"`
+}
+
+// Error returns the message of Azure DevOps along with the type of the error, which identifies it when the message is localized
+func (e *AzureError) Error() string {
+	if e.TypeKey == "" {
+		return fmt.Sprintf("%s%s", constants.AzureDevopsErrorMessagePrefix, e.Message)
+	}
+
+	return fmt.Sprintf("%s%s (%s)", constants.AzureDevopsErrorMessagePrefix, e.Message, e.TypeKey)
+}
@@ -0,0 +1,9 @@
+package serializers
+
+// DeliveryLogEntry records a notification delivered to a channel, the post ID is empty if the notification was summarized in the post of a burst
+type DeliveryLogEntry struct {
+	Timestamp      int64  `json:"timestamp"`
+	EventType      string `json:"eventType"`
+	SubscriptionID string `json:"subscriptionID"`
+	PostID         string `json:"postID,omitempty"`
+}
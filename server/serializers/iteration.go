@@ -0,0 +1,21 @@
+package serializers
+
+import "time"
+
+type Iteration struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Path       string              `json:"path"`
+	Attributes IterationAttributes `json:"attributes"`
+}
+
+type IterationAttributes struct {
+	StartDate  *time.Time `json:"startDate"`
+	FinishDate *time.Time `json:"finishDate"`
+	TimeFrame  string     `json:"timeFrame"`
+}
+
+type IterationsResponse struct {
+	Count int          `json:"count"`
+	Value []*Iteration `json:"value"`
+}
@@ -11,6 +11,7 @@ import (
 type LinkRequestPayload struct {
 	Organization string `json:"organization"`
 	Project      string `json:"project"`
+	DefaultQuery string `json:"defaultQuery,omitempty"`
 }
 
 type Project struct {
@@ -0,0 +1,12 @@
+package serializers
+
+// NotificationBurst contains the work items created in quick succession for a subscription, which are summarized in a single post
+type NotificationBurst struct {
+	LastEventAt int64 `json:"lastEventAt"`
+	Count       int   `json:"count"`
+	// WorkItemIDs are capped, so they can be fewer than the count for large imports
+	WorkItemIDs []int `json:"workItemIds"`
+	// IterationPaths are only kept to find if all the work items are in the same iteration
+	IterationPaths []string `json:"iterationPaths"`
+	SummaryPostID  string   `json:"summaryPostId"`
+}
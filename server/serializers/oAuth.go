@@ -17,6 +17,31 @@ type OAuthSuccessResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	ExpiresIn    string `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+// DeviceCodeResponse contains the codes to show to the user for the device code flow
+type DeviceCodeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+	Message         string `json:"message"`
+}
+
+// DeviceCodeTokenResponse is the token response of the device code flow, where "expires_in" is a number unlike the other flow
+type DeviceCodeTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+// DeviceCodeErrorResponse is returned while polling for the token until the user completes the device code flow
+type DeviceCodeErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
 }
 
 type ConnectedResponse struct {
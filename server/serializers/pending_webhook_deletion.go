@@ -0,0 +1,10 @@
+package serializers
+
+// PendingWebhookDeletion is the webhook of a deleted subscription which is deleted from Azure DevOps once the grace period is over.
+// The webhook is reused instead if a matching subscription is created again in the meantime.
+type PendingWebhookDeletion struct {
+	Subscription     *SubscriptionDetails `json:"subscription"`
+	WebhookSecret    string               `json:"webhookSecret"`
+	MattermostUserID string               `json:"mattermostUserID"`
+	DeleteAt         int64                `json:"deleteAt"`
+}
@@ -0,0 +1,41 @@
+package serializers
+
+type ProjectCapabilities struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Capabilities Capabilities `json:"capabilities"`
+}
+
+type Capabilities struct {
+	ProcessTemplate ProcessTemplate `json:"processTemplate"`
+}
+
+type ProcessTemplate struct {
+	TemplateName   string `json:"templateName"`
+	TemplateTypeID string `json:"templateTypeId"`
+}
+
+// Process is the process of a project along with its work item types.
+// A custom inherited process has the type ID of the system process it inherits from as its parent.
+type Process struct {
+	TypeID              string                 `json:"typeId"`
+	Name                string                 `json:"name"`
+	ParentProcessTypeID string                 `json:"parentProcessTypeId,omitempty"`
+	ParentProcessName   string                 `json:"parentProcessName,omitempty"`
+	CustomizationType   string                 `json:"customizationType"`
+	WorkItemTypes       []*ProcessWorkItemType `json:"workItemTypes"`
+}
+
+type ProcessWorkItemType struct {
+	ReferenceName string `json:"referenceName"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Customization string `json:"customization"`
+	Color         string `json:"color"`
+	IsDisabled    bool   `json:"isDisabled"`
+}
+
+type ProcessWorkItemTypesResponse struct {
+	Count int                    `json:"count"`
+	Value []*ProcessWorkItemType `json:"value"`
+}
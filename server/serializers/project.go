@@ -14,6 +14,7 @@ type ProjectDetails struct {
 	ProjectName         string `json:"projectName"`
 	OrganizationName    string `json:"organizationName"`
 	DeleteSubscriptions bool   `json:"deleteSubscriptions"`
+	DefaultQuery        string `json:"defaultQuery,omitempty"`
 }
 
 func (t *ProjectDetails) IsValid() error {
@@ -36,3 +37,17 @@ func ProjectPayloadFromJSON(data io.Reader) (*ProjectDetails, error) {
 	}
 	return body, nil
 }
+
+// UnlinkedProject is a project processed while unlinking all the projects of a user, along with the error which kept it linked
+type UnlinkedProject struct {
+	ProjectID        string `json:"projectID"`
+	ProjectName      string `json:"projectName"`
+	OrganizationName string `json:"organizationName"`
+	Error            string `json:"error,omitempty"`
+}
+
+type UnlinkAllProjectsResponse struct {
+	UnlinkedCount    int               `json:"unlinkedCount"`
+	UnlinkedProjects []UnlinkedProject `json:"unlinkedProjects"`
+	FailedProjects   []UnlinkedProject `json:"failedProjects"`
+}
@@ -0,0 +1,30 @@
+package serializers
+
+type Query struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	IsFolder bool   `json:"isFolder"`
+	// Children are only present for the folders up to the requested depth
+	Children []*Query `json:"children"`
+}
+
+type QueriesResponse struct {
+	Count int      `json:"count"`
+	Value []*Query `json:"value"`
+}
+
+type WorkItemLink struct {
+	Rel string `json:"rel"`
+	// Source is empty for the top level work items of a hierarchical query
+	Source *WorkItemReference `json:"source"`
+	Target *WorkItemReference `json:"target"`
+}
+
+// WorkItemQueryResult contains the work items of a flat query or the work item links of a hierarchical query
+type WorkItemQueryResult struct {
+	QueryType         string               `json:"queryType"`
+	QueryResultType   string               `json:"queryResultType"`
+	WorkItems         []*WorkItemReference `json:"workItems"`
+	WorkItemRelations []*WorkItemLink      `json:"workItemRelations"`
+}
@@ -0,0 +1,15 @@
+package serializers
+
+import "encoding/json"
+
+// RetryOperation is a write operation on Azure DevOps which failed due to a transient error and is retried by a scheduled job
+type RetryOperation struct {
+	ID               string          `json:"id"`
+	Type             string          `json:"type"`
+	MattermostUserID string          `json:"mattermostUserID"`
+	Payload          json.RawMessage `json:"payload"`
+	Attempts         int             `json:"attempts"`
+	CreatedAt        int64           `json:"createdAt"`
+	NextAttemptAt    int64           `json:"nextAttemptAt"`
+	LastError        string          `json:"lastError"`
+}
@@ -0,0 +1,65 @@
+package serializers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+type SubscriptionTemplateEvent struct {
+	EventType string `json:"eventType"`
+	// The subscription is created for the channel in which the template is applied if the channel ID is empty
+	ChannelID string `json:"channelID"`
+}
+
+type SubscriptionTemplate struct {
+	Name             string                       `json:"name"`
+	MattermostUserID string                       `json:"mattermostUserID"`
+	Events           []*SubscriptionTemplateEvent `json:"events"`
+}
+
+func SubscriptionTemplateFromJSON(data io.Reader) (*SubscriptionTemplate, error) {
+	var body *SubscriptionTemplate
+	if err := json.NewDecoder(data).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (t *SubscriptionTemplate) IsValid() error {
+	if t.Name == "" {
+		return errors.New(constants.TemplateNameRequired)
+	}
+	if strings.ContainsAny(t.Name, " \t\n") {
+		return errors.New(constants.InvalidTemplateName)
+	}
+	if len(t.Events) == 0 {
+		return errors.New(constants.TemplateEventsRequired)
+	}
+	for _, event := range t.Events {
+		if event == nil || event.EventType == "" {
+			return errors.New(constants.EventTypeRequired)
+		}
+		if GetServiceTypeForEventType(event.EventType) == "" {
+			return fmt.Errorf(constants.InvalidTemplateEventType, event.EventType)
+		}
+	}
+	return nil
+}
+
+// GetServiceTypeForEventType returns the service type i.e. boards, repos or pipelines to which an event type belongs
+func GetServiceTypeForEventType(eventType string) string {
+	switch {
+	case constants.ValidSubscriptionEventsForBoards[eventType]:
+		return constants.ServiceTypeBoards
+	case constants.ValidSubscriptionEventsForRepos[eventType]:
+		return constants.ServiceTypeRepos
+	case constants.ValidSubscriptionEventsForPipelines[eventType]:
+		return constants.ServiceTypePipelines
+	}
+	return ""
+}
@@ -3,8 +3,12 @@ package serializers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"path"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
 )
@@ -18,6 +22,7 @@ type UserID struct {
 type PublisherInputsGeneric struct {
 	ProjectID                    string `json:"projectId,omitempty"`
 	AreaPath                     string `json:"areaPath,omitempty"`
+	WorkItemType                 string `json:"workItemType,omitempty"`
 	Repository                   string `json:"repository,omitempty"`
 	Branch                       string `json:"branch,omitempty"`
 	PullRequestCreatedBy         string `json:"pullrequestCreatedBy,omitempty"`
@@ -43,7 +48,9 @@ type PublisherInputsGeneric struct {
 }
 
 type ConsumerInputs struct {
-	URL string `json:"url"`
+	URL                    string `json:"url"`
+	MessagesToSend         string `json:"messagesToSend,omitempty"`
+	DetailedMessagesToSend string `json:"detailedMessagesToSend,omitempty"`
 }
 
 type SubscriptionValue struct {
@@ -83,6 +90,7 @@ type CreateSubscriptionRequestPayload struct {
 	NotificationType                 string `json:"notificationType"`
 	NotificationTypeName             string `json:"notificationTypeName"`
 	AreaPath                         string `json:"areaPath"`
+	WorkItemType                     string `json:"workItemType"`
 	BuildPipeline                    string `json:"buildPipeline"`
 	BuildStatus                      string `json:"buildStatus"`
 	BuildStatusName                  string `json:"buildStatusName"`
@@ -107,6 +115,28 @@ type CreateSubscriptionRequestPayload struct {
 	RunStateID                       string `json:"runStateId"`
 	RunStateIDName                   string `json:"runStateIdName"`
 	RunResultID                      string `json:"runResultId"`
+	KeepRawHTML                      bool   `json:"keepRawHTML"`
+	Label                            string `json:"label"`
+	// Overrides the maximum lengths of the texts in the notifications set in the plugin configuration
+	Truncation *NotificationTruncation `json:"truncation,omitempty"`
+	// Glob patterns of the branches whose notifications are posted, like "main" or "release/*"
+	BranchFilters []string `json:"branchFilters,omitempty"`
+	// Glob patterns of the target branches of the pull requests whose notifications are posted, like the protected branches
+	PullRequestTargetBranches []string `json:"pullRequestTargetBranches,omitempty"`
+	// Results of the builds whose notifications are posted, like "failed", all the results are posted if it's empty
+	BuildResults []string `json:"buildResults,omitempty"`
+	// Drops the notifications of the builds which don't have a result yet, they are posted by default
+	SkipBuildsWithoutResult bool `json:"skipBuildsWithoutResult"`
+	// Lists the work items linked to the pull requests in their notifications
+	ShowLinkedWorkItems bool `json:"showLinkedWorkItems"`
+	// Overrides the plugin configuration to post or drop the notifications of the changes made by service accounts
+	ExcludeServiceAccounts *bool `json:"excludeServiceAccounts,omitempty"`
+	// "channel" posts the notifications in the channel, "ephemeral" only shows them to the creator of the subscription
+	Visibility string `json:"visibility,omitempty"`
+	// Version of the payloads sent by the webhook, the first supported version of the event type is requested if it's empty
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// "markdown", "text" or "html", the format of the messages sent by the webhook
+	MessageFormat string `json:"messageFormat,omitempty"`
 }
 
 type GetSubscriptionFilterPossibleValuesRequestPayload struct {
@@ -150,6 +180,13 @@ type CreateSubscriptionBodyPayload struct {
 	ConsumerActionID string         `json:"consumerActionId"`
 	PublisherInputs  interface{}    `json:"publisherInputs"`
 	ConsumerInputs   ConsumerInputs `json:"consumerInputs"`
+	ResourceVersion  string         `json:"resourceVersion,omitempty"`
+}
+
+// SubscriptionListResponse is a page of the subscriptions of a project along with the count of all its subscriptions
+type SubscriptionListResponse struct {
+	Subscriptions []*SubscriptionDetails `json:"subscriptions"`
+	TotalCount    int                    `json:"total_count"`
 }
 
 type SubscriptionDetails struct {
@@ -165,6 +202,8 @@ type SubscriptionDetails struct {
 	ChannelType      string    `json:"channelType"`
 	CreatedBy        string    `json:"createdBy"`
 	CreatedAt        time.Time `json:"createdAt"`
+	// The username of the creator doesn't change with the privacy settings of the server, unlike its display name
+	CreatedByUsername string `json:"createdByUsername"`
 	// Below all are filters that could be present on different categories of subscriptions from Boards, Repos and Pipelines
 	TargetBranch                     string `json:"targetBranch"`
 	Repository                       string `json:"repository"`
@@ -180,6 +219,7 @@ type SubscriptionDetails struct {
 	NotificationType                 string `json:"notificationType"`
 	NotificationTypeName             string `json:"notificationTypeName"`
 	AreaPath                         string `json:"areaPath"`
+	WorkItemType                     string `json:"workItemType"`
 	BuildPipeline                    string `json:"buildPipeline"`
 	BuildStatus                      string `json:"buildStatus"`
 	BuildStatusName                  string `json:"buildStatusName"`
@@ -204,10 +244,53 @@ type SubscriptionDetails struct {
 	RunStateID                       string `json:"runStateId"`
 	RunStateIDName                   string `json:"runStateIdName"`
 	RunResultID                      string `json:"runResultId"`
+	// The HTML in rich text fields like comments is converted to Markdown unless it's set
+	KeepRawHTML bool `json:"keepRawHTML"`
+	// Prefixed to the notifications of the subscription like "[Billing]" unless it's empty
+	Label string `json:"label"`
+	// The maximum lengths set in the plugin configuration are used for the texts which are not overridden
+	Truncation *NotificationTruncation `json:"truncation,omitempty"`
+	// The notifications of the pushes, pull requests and builds of the branches not matching these glob patterns are not posted.
+	// The patterns prefixed with "!" exclude the matching branches.
+	BranchFilters []string `json:"branchFilters,omitempty"`
+	// The notifications of the pull requests into the branches not matching these glob patterns are not posted,
+	// the other notifications of the subscription are not filtered by them
+	PullRequestTargetBranches []string `json:"pullRequestTargetBranches,omitempty"`
+	// The notifications of the builds whose results are not in it are not posted, the notifications of all the results are posted if it's empty
+	BuildResults []string `json:"buildResults,omitempty"`
+	// The notifications of the builds without a result, like the builds still in progress, are dropped if it's set
+	SkipBuildsWithoutResult bool `json:"skipBuildsWithoutResult,omitempty"`
+	// The work items linked to a pull request are fetched for its notifications only if it's set
+	ShowLinkedWorkItems bool `json:"showLinkedWorkItems"`
+	// The notifications of the changes made by the service accounts are dropped if it's set, the plugin configuration is used if it's nil
+	ExcludeServiceAccounts *bool `json:"excludeServiceAccounts,omitempty"`
+	// The notifications are posted in the channel unless it's "ephemeral"
+	Visibility string `json:"visibility,omitempty"`
+	// Version of the payloads sent by the webhook, it's empty for the subscriptions created before it could be chosen
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Format of the messages sent by the webhook, they are in Markdown if it's empty
+	MessageFormat string `json:"messageFormat,omitempty"`
+	// The webhook was created by another user whose subscription is shared, the user may not be allowed to delete it
+	IsSharedWebhook bool `json:"isSharedWebhook,omitempty"`
+}
+
+// IsEphemeral checks if the notifications of a subscription are only shown to its creator
+func (s *SubscriptionDetails) IsEphemeral() bool {
+	return s != nil && s.Visibility == constants.SubscriptionVisibilityEphemeral
+}
+
+// NotificationTruncation contains the maximum number of characters of the titles, descriptions and comments in notifications.
+// A length of 0 doesn't truncate the text.
+type NotificationTruncation struct {
+	Title       *int `json:"title,omitempty"`
+	Description *int `json:"description,omitempty"`
+	Comment     *int `json:"comment,omitempty"`
 }
 
 type DetailedMessage struct {
 	Markdown string `json:"markdown"`
+	Text     string `json:"text"`
+	HTML     string `json:"html"`
 }
 
 type SubscriptionNotification struct {
@@ -237,9 +320,14 @@ type ApprovalStep struct {
 type Approver struct {
 	DisplayName string `json:"displayName"`
 	ID          string `json:"id"`
+	// Set if the approver is a group, any member of which can approve
+	IsContainer bool `json:"isContainer"`
 }
 
 type Resource struct {
+	// ID is the work item ID for the work item events except updates, the type of the ID differs for other events
+	ID            interface{}  `json:"id"`
+	WorkItemID    int          `json:"workItemId"`
 	PullRequestID int          `json:"pullRequestId"`
 	Reviewers     []Reviewer   `json:"reviewers"`
 	SourceRefName string       `json:"sourceRefName"`
@@ -268,21 +356,43 @@ type Resource struct {
 	ProjectID     string       `json:"projectId"`
 	Fields        Fields       `json:"fields"`
 	Revision      Revision     `json:"revision"`
+	Result        string       `json:"result"`
+	// Status of a pull request or a build, like "active" or "completed"
+	Status      string `json:"status"`
+	BuildNumber string `json:"buildNumber"`
+	// URL of the resource in the REST API, the links to its web page are only present for some event types
+	URL   string `json:"url"`
+	Links Link   `json:"_links"`
+	// The identities which made the changes, only the one matching the event type is present
+	RevisedBy   Identity `json:"revisedBy"`
+	PushedBy    Identity `json:"pushedBy"`
+	CreatedBy   Identity `json:"createdBy"`
+	ClosedBy    Identity `json:"closedBy"`
+	RequestedBy Identity `json:"requestedBy"`
+}
+
+// Identity is a user or a service account of Azure DevOps, the descriptors of the service accounts are like "svc.<id>"
+type Identity struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	Descriptor  string `json:"descriptor"`
 }
 
 type Stage struct {
-	Name  string      `json:"name"`
-	Links ProjectLink `json:"_links"`
+	Name   string      `json:"name"`
+	Result string      `json:"result"`
+	Links  ProjectLink `json:"_links"`
 }
 
 type Release struct {
 	Name              string      `json:"name"`
-	CreatedBy         Reviewer    `json:"createdBy"`
+	CreatedBy         Identity    `json:"createdBy"`
 	Artifacts         []*Artifact `json:"artifacts"`
 	ReleaseDefinition Definition  `json:"releaseDefinition"`
 	Reason            string      `json:"reason"`
 	ModifiedOn        string      `json:"modifiedOn"`
-	ModifiedBy        Reviewer    `json:"modifiedBy"`
+	ModifiedBy        Identity    `json:"modifiedBy"`
+	URL               string      `json:"url"`
 	Links             ProjectLink `json:"_links"`
 }
 
@@ -295,6 +405,7 @@ type RequestedFor struct {
 }
 
 type Definition struct {
+	ID    int         `json:"id"`
 	Name  string      `json:"name"`
 	URL   string      `json:"url"`
 	Links ProjectLink `json:"_links"`
@@ -302,28 +413,72 @@ type Definition struct {
 
 type Revision struct {
 	Fields Fields `json:"fields"`
+	// Relations of the work item after the update, like the artifact links of its pull requests
+	Relations []*WorkItemRelation `json:"relations"`
 }
 
 type Fields struct {
-	ProjectName  interface{} `json:"System.TeamProject"`
-	AreaPath     interface{} `json:"System.AreaPath"`
-	State        interface{} `json:"System.State"`
-	WorkItemType interface{} `json:"System.WorkItemType"`
-	Title        interface{} `json:"System.Title"`
+	ProjectName   interface{} `json:"System.TeamProject"`
+	AreaPath      interface{} `json:"System.AreaPath"`
+	State         interface{} `json:"System.State"`
+	WorkItemType  interface{} `json:"System.WorkItemType"`
+	Title         interface{} `json:"System.Title"`
+	IterationPath interface{} `json:"System.IterationPath"`
+	// All the fields mapped by their reference names, for work item updates the values contain the old and new values of the changed fields
+	All map[string]interface{} `json:"-"`
+}
+
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	type fields Fields
+	if err := json.Unmarshal(data, (*fields)(f)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &f.All)
+}
+
+// MarshalJSON marshals all the fields when they are present, so that a notification stored as JSON is unmarshalled with all its fields
+func (f Fields) MarshalJSON() ([]byte, error) {
+	if f.All != nil {
+		return json.Marshal(f.All)
+	}
+
+	type fields Fields
+	return json.Marshal(fields(f))
 }
 
 type RefUpdates struct {
 	Name string `json:"name"`
+	// The object IDs are all zeros when a branch is created or deleted
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId"`
 }
 
 type Commit struct {
-	CommitID string `json:"commitId"`
-	Comment  string `json:"comment"`
-	URL      string `json:"url"`
+	CommitID string       `json:"commitId"`
+	Comment  string       `json:"comment"`
+	URL      string       `json:"url"`
+	Author   CommitAuthor `json:"author"`
+	// Parents are not always present, a commit with more than one parent is a merge commit
+	Parents []string `json:"parents,omitempty"`
+}
+
+type CommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
 }
 
 type Repository struct {
-	Name string `json:"name"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	RemoteURL string `json:"remoteUrl,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type GitRepository struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	WebURL string `json:"webUrl"`
 }
 
 type PullRequest struct {
@@ -335,17 +490,73 @@ type PullRequest struct {
 	Title         string     `json:"title"`
 	Description   string     `json:"description"`
 	Repository    Repository `json:"repository"`
+	IsDraft       bool       `json:"isDraft"`
+	URL           string     `json:"url"`
+	// The pull requests listed for a project have their status and dates, the closing date is only set once they are completed or abandoned
+	Status       string   `json:"status,omitempty"`
+	CreatedBy    Identity `json:"createdBy"`
+	CreationDate string   `json:"creationDate,omitempty"`
+	ClosedBy     Identity `json:"closedBy"`
+	ClosedDate   string   `json:"closedDate,omitempty"`
+}
+
+type PullRequestsResponse struct {
+	Count int            `json:"count"`
+	Value []*PullRequest `json:"value"`
+}
+
+// PullRequestSummary is a pull request with the fields shown in the list of the open pull requests of a project
+type PullRequestSummary struct {
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	Author     string `json:"author"`
+	Status     string `json:"status"`
+	Repository string `json:"repository"`
+	URL        string `json:"url"`
+}
+
+type GitRepositoriesResponse struct {
+	Count int              `json:"count"`
+	Value []*GitRepository `json:"value"`
+}
+
+// Push is a push to the branches of a repository, its ref updates are only listed if they are requested
+type Push struct {
+	PushID     int          `json:"pushId"`
+	Date       time.Time    `json:"date"`
+	PushedBy   Identity     `json:"pushedBy"`
+	RefUpdates []RefUpdates `json:"refUpdates"`
+}
+
+type PushesResponse struct {
+	Count int     `json:"count"`
+	Value []*Push `json:"value"`
+}
+
+// ResourceRef is a reference to a resource like a work item linked to a pull request, its ID is a string unlike the work item references of the queries
+type ResourceRef struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+type ResourceRefsResponse struct {
+	Count int            `json:"count"`
+	Value []*ResourceRef `json:"value"`
 }
 
 type Comment struct {
-	Content string `json:"content"`
+	Content string   `json:"content"`
+	Author  Identity `json:"author"`
 }
 
 type Reviewer struct {
 	DisplayName string `json:"displayName"`
+	Vote        int    `json:"vote"`
 }
 
 type DeleteSubscriptionRequestPayload struct {
+	// SubscriptionID is the ID of the subscription in Azure DevOps, which identifies the subscription without its other fields when it's set
+	SubscriptionID               string `json:"subscriptionID"`
 	Organization                 string `json:"organization"`
 	Project                      string `json:"project"`
 	EventType                    string `json:"eventType"`
@@ -360,6 +571,7 @@ type DeleteSubscriptionRequestPayload struct {
 	MergeResult                  string `json:"mergeResult"`
 	NotificationType             string `json:"notificationType"`
 	AreaPath                     string `json:"areaPath"`
+	WorkItemType                 string `json:"workItemType"`
 	BuildPipeline                string `json:"buildPipeline"`
 	BuildStatus                  string `json:"buildStatus"`
 	ReleasePipeline              string `json:"releasePipeline"`
@@ -402,12 +614,15 @@ func GetSubscriptionFilterPossibleValuesRequestPayloadFromJSON(data io.Reader) (
 	return body, nil
 }
 
-type PipelineApprovalDetails struct {
-	ID     int    `json:"id"`
-	Status string `json:"status"`
+type ReleaseApproval struct {
+	ID           int      `json:"id"`
+	Status       string   `json:"status"`
+	ApprovalType string   `json:"approvalType"`
+	Approver     Approver `json:"approver"`
 }
 
 type BuildDetails struct {
+	ID           int         `json:"id"`
 	BuildNumber  string      `json:"buildNumber"`
 	SourceBranch string      `json:"sourceBranch"`
 	Repository   Repository  `json:"repository"`
@@ -418,6 +633,16 @@ type BuildDetails struct {
 	Definition   Definition  `json:"definition"`
 }
 
+// QueueBuildRequest queues a build of a pipeline definition on a branch, like the ones in the refs/heads/main form
+type QueueBuildRequest struct {
+	Definition   QueueBuildDefinition `json:"definition"`
+	SourceBranch string               `json:"sourceBranch"`
+}
+
+type QueueBuildDefinition struct {
+	ID int `json:"id"`
+}
+
 type RequestedBy struct {
 	DisplayName string `json:"displayName"`
 }
@@ -433,6 +658,7 @@ type ReleaseDetails struct {
 
 type Environment struct {
 	Name              string     `json:"name"`
+	Status            string     `json:"status"`
 	Release           Release    `json:"release"`
 	ReleaseDefinition Definition `json:"releaseDefinition"`
 }
@@ -497,10 +723,82 @@ func (t *CreateSubscriptionRequestPayload) IsSubscriptionRequestPayloadValid() e
 	if t.ChannelID == "" {
 		return errors.New(constants.ChannelIDRequired)
 	}
+	if labelLength := utf8.RuneCountInString(strings.TrimSpace(t.Label)); labelLength > constants.SubscriptionLabelMaxLength {
+		return fmt.Errorf(constants.SubscriptionLabelTooLong, labelLength, constants.SubscriptionLabelMaxLength)
+	}
+	if visibility := strings.ToLower(strings.TrimSpace(t.Visibility)); visibility != "" && visibility != constants.SubscriptionVisibilityChannel && visibility != constants.SubscriptionVisibilityEphemeral {
+		return fmt.Errorf(constants.InvalidSubscriptionVisibility, t.Visibility)
+	}
+	if resourceVersion := strings.TrimSpace(t.ResourceVersion); resourceVersion != "" && !isSupportedResourceVersion(t.EventType, resourceVersion) {
+		return fmt.Errorf(constants.InvalidResourceVersion, resourceVersion, t.EventType, strings.Join(constants.SubscriptionResourceVersions[t.EventType], ", "))
+	}
+	if messageFormat := strings.ToLower(strings.TrimSpace(t.MessageFormat)); messageFormat != "" && messageFormat != constants.SubscriptionMessageFormatMarkdown &&
+		messageFormat != constants.SubscriptionMessageFormatText && messageFormat != constants.SubscriptionMessageFormatHTML {
+		return fmt.Errorf(constants.InvalidMessageFormat, t.MessageFormat)
+	}
+	if t.WorkItemType != "" && t.ServiceType != constants.ServiceTypeBoards {
+		return errors.New(constants.WorkItemTypeFilterNotAllowed)
+	}
+	if err := validateBranchFilters(t.BranchFilters); err != nil {
+		return err
+	}
+	if err := validateBranchFilters(t.PullRequestTargetBranches); err != nil {
+		return err
+	}
+	for _, result := range t.BuildResults {
+		if GetBuildResult(result) == "" {
+			return fmt.Errorf(constants.InvalidBuildResult, result, strings.Join(constants.BuildResults, ", "))
+		}
+	}
+	if t.Truncation != nil {
+		names := []string{"title", "description", "comment"}
+		for i, length := range []*int{t.Truncation.Title, t.Truncation.Description, t.Truncation.Comment} {
+			if length != nil && *length < 0 {
+				return fmt.Errorf(constants.InvalidTruncationLength, names[i])
+			}
+		}
+	}
+	return nil
+}
+
+// GetBuildResult returns the name of a build result as it's sent by Azure DevOps, the case of the given name is ignored.
+// It's empty if the result doesn't exist.
+func GetBuildResult(result string) string {
+	for _, buildResult := range constants.BuildResults {
+		if strings.EqualFold(strings.TrimSpace(result), buildResult) {
+			return buildResult
+		}
+	}
+	return ""
+}
+
+// validateBranchFilters checks the glob patterns of the branch filters of a subscription, the empty filters are ignored
+func validateBranchFilters(filters []string) error {
+	if len(filters) > constants.BranchFiltersMaxCount {
+		return fmt.Errorf(constants.TooManyBranchFilters, len(filters), constants.BranchFiltersMaxCount)
+	}
+	for _, filter := range filters {
+		if strings.TrimSpace(filter) == "" {
+			continue
+		}
+		pattern := strings.TrimPrefix(strings.TrimSpace(filter), constants.BranchFilterNegation)
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return fmt.Errorf(constants.InvalidBranchFilter, filter)
+		}
+	}
 	return nil
 }
 
 func (t *DeleteSubscriptionRequestPayload) IsSubscriptionRequestPayloadValid() error {
+	if t.SubscriptionID != "" {
+		if t.ChannelID == "" {
+			return errors.New(constants.ChannelIDRequired)
+		}
+		if t.MMUserID == "" {
+			return errors.New(constants.MMUserIDRequired)
+		}
+		return nil
+	}
 	if t.Organization == "" {
 		return errors.New(constants.OrganizationRequired)
 	}
@@ -518,3 +816,36 @@ func (t *DeleteSubscriptionRequestPayload) IsSubscriptionRequestPayloadValid() e
 	}
 	return nil
 }
+
+// GetResourceVersion returns the version of the payloads requested for the webhook of a subscription.
+// It's empty for the event types whose versions are not known, so that Azure DevOps sends its default version.
+func (t *CreateSubscriptionRequestPayload) GetResourceVersion() string {
+	if resourceVersion := strings.TrimSpace(t.ResourceVersion); resourceVersion != "" {
+		return resourceVersion
+	}
+
+	if resourceVersions := constants.SubscriptionResourceVersions[t.EventType]; len(resourceVersions) > 0 {
+		return resourceVersions[0]
+	}
+
+	return ""
+}
+
+// GetMessageFormat returns the format of the messages requested for the webhook of a subscription, it's Markdown by default
+func (t *CreateSubscriptionRequestPayload) GetMessageFormat() string {
+	if messageFormat := strings.ToLower(strings.TrimSpace(t.MessageFormat)); messageFormat != "" {
+		return messageFormat
+	}
+
+	return constants.SubscriptionMessageFormatMarkdown
+}
+
+func isSupportedResourceVersion(eventType, resourceVersion string) bool {
+	for _, supportedVersion := range constants.SubscriptionResourceVersions[eventType] {
+		if supportedVersion == resourceVersion {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,7 +3,9 @@ package serializers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
@@ -27,6 +29,28 @@ type TaskValue struct {
 	ID     int            `json:"id"`
 	Fields TaskFieldValue `json:"fields"`
 	Link   Link           `json:"_links"`
+	// Relations are only present when they are expanded in the request
+	Relations []*WorkItemRelation `json:"relations"`
+}
+
+// CreatedTaskResponse is the work item created by a user along with the link to open it in Azure DevOps
+type CreatedTaskResponse struct {
+	*TaskValue
+	WorkItemURL string `json:"workItemURL"`
+}
+
+// WorkItemExpanded is a work item fetched with all its fields and relations, along with the counts derived from them.
+// Its linked pull requests and branches are parsed from its relations.
+type WorkItemExpanded struct {
+	WorkItem        *TaskValue
+	AttachmentCount int
+	CommentCount    int
+}
+
+type WorkItemRelation struct {
+	Rel        string                 `json:"rel"`
+	URL        string                 `json:"url"`
+	Attributes map[string]interface{} `json:"attributes"`
 }
 
 type TaskFieldValue struct {
@@ -41,6 +65,8 @@ type TaskFieldValue struct {
 	UpdatedAt   time.Time       `json:"System.ChangedDate"`
 	UpdatedBy   TaskUserDetails `json:"System.ChangedBy"`
 	Description string          `json:"System.Description"`
+	// Remaining work is only present for the work items of the processes which use the scheduling fields
+	RemainingWork *float64 `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
 }
 
 type Link struct {
@@ -69,13 +95,155 @@ type CreateTaskFieldValue struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	AreaPath    string `json:"areaPath"`
+	// Estimates are only set if they are provided and apply to the work item type
+	StoryPoints   *float64 `json:"storyPoints,omitempty"`
+	Effort        *float64 `json:"effort,omitempty"`
+	RemainingWork *float64 `json:"remainingWork,omitempty"`
+}
+
+// UpdateTaskRequestPayload is the request to update the fields of an existing work item, only the fields it contains are updated
+type UpdateTaskRequestPayload struct {
+	Organization string               `json:"organization"`
+	Project      string               `json:"project"`
+	Fields       UpdateTaskFieldValue `json:"fields"`
+}
+
+// UpdateTaskFieldValue is named as the fields of the work item. The nil fields are not updated, and the fields set to an empty string are cleared.
+type UpdateTaskFieldValue struct {
+	Title       *string `json:"System.Title"`
+	Description *string `json:"System.Description"`
+	State       *string `json:"System.State"`
+	Reason      *string `json:"System.Reason"`
+	// The work item is unassigned if the unique name of the user is empty
+	AssignedTo    *TaskUserDetails `json:"System.AssignedTo"`
+	RemainingWork *float64         `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
+}
+
+// taskEstimate is an estimate of the task creation request named as in its JSON
+type taskEstimate struct {
+	name  string
+	value **float64
+}
+
+type WorkItemTypeState struct {
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Category string `json:"category"`
+}
+
+type WorkItemTypeStatesResponse struct {
+	Count int                  `json:"count"`
+	Value []*WorkItemTypeState `json:"value"`
+}
+
+type WorkItemQueryRequest struct {
+	Query string `json:"query"`
+}
+
+// WorkItemQuery is the filters of the work items of a project, the empty ones are not applied
+type WorkItemQuery struct {
+	State      string
+	AssignedTo string
+}
+
+// WorkItemSummary is a work item with the fields shown in the list of the work items of a project
+type WorkItemSummary struct {
+	ID         int       `json:"id"`
+	Title      string    `json:"title"`
+	Type       string    `json:"type"`
+	State      string    `json:"state"`
+	AssignedTo string    `json:"assignedTo"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Link       string    `json:"link"`
+}
+
+// WorkItemSearchRequest is the body of a request to the search API of the work items
+type WorkItemSearchRequest struct {
+	SearchText    string `json:"searchText"`
+	Skip          int    `json:"$skip"`
+	Top           int    `json:"$top"`
+	IncludeFacets bool   `json:"includeFacets"`
+}
+
+type WorkItemSearchResponse struct {
+	Count   int                     `json:"count"`
+	Results []*WorkItemSearchResult `json:"results"`
+}
+
+// WorkItemSearchResult is a work item found by the search API
+type WorkItemSearchResult struct {
+	Fields WorkItemSearchFields `json:"fields"`
+	Hits   []*WorkItemSearchHit `json:"hits"`
+}
+
+// WorkItemSearchFields are the fields of a work item found by the search API, keyed by their lowercase reference names
+type WorkItemSearchFields struct {
+	ID         string `json:"system.id"`
+	Title      string `json:"system.title"`
+	Type       string `json:"system.workitemtype"`
+	State      string `json:"system.state"`
+	AssignedTo string `json:"system.assignedto"`
+}
+
+// WorkItemSearchHit is a field of a work item matching the searched text, the matches are wrapped in <highlighthit> tags
+type WorkItemSearchHit struct {
+	FieldReferenceName string   `json:"fieldReferenceName"`
+	Highlights         []string `json:"highlights"`
+}
+
+// WorkItemSearchSummary is a work item found by the text searched in the webapp
+type WorkItemSearchSummary struct {
+	ID         int                  `json:"id"`
+	Title      string               `json:"title"`
+	Type       string               `json:"type"`
+	State      string               `json:"state"`
+	AssignedTo string               `json:"assignedTo"`
+	Link       string               `json:"link"`
+	Highlights []*WorkItemSearchHit `json:"highlights"`
+}
+
+type WorkItemReference struct {
+	ID  int    `json:"id"`
+	URL string `json:"url"`
+}
+
+type WorkItemQueryResponse struct {
+	WorkItems []*WorkItemReference `json:"workItems"`
+}
+
+type WorkItemsBatchRequest struct {
+	IDs    []int    `json:"ids"`
+	Fields []string `json:"fields"`
+}
+
+type WorkItemsBatchResponse struct {
+	Count int          `json:"count"`
+	Value []*TaskValue `json:"value"`
+}
+
+// DeletedWorkItem is a work item in the recycle bin of a project
+type DeletedWorkItem struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	DeletedBy   string     `json:"deletedBy"`
+	DeletedDate *time.Time `json:"deletedDate"`
+}
+
+type DeletedWorkItemsResponse struct {
+	Count int                `json:"count"`
+	Value []*DeletedWorkItem `json:"value"`
+}
+
+type RestoreWorkItemRequest struct {
+	IsDeleted bool `json:"isDeleted"`
 }
 
 type CreateTaskBodyPayload struct {
-	Operation string `json:"op"`
-	Path      string `json:"path"`
-	From      string `json:"from"`
-	Value     string `json:"value"`
+	Operation string      `json:"op"`
+	Path      string      `json:"path"`
+	From      string      `json:"from"`
+	Value     interface{} `json:"value"`
 }
 
 // IsValid function to validate request payload.
@@ -92,9 +260,194 @@ func (t *CreateTaskRequestPayload) IsValid() error {
 	if t.Fields.Title == "" {
 		return errors.New(constants.TaskTitleRequired)
 	}
+	for _, estimate := range t.getEstimates() {
+		if *estimate.value != nil && **estimate.value < 0 {
+			return fmt.Errorf(constants.InvalidTaskEstimate, estimate.name)
+		}
+	}
+	return nil
+}
+
+func (t *CreateTaskRequestPayload) getEstimates() []*taskEstimate {
+	return []*taskEstimate{
+		{name: constants.TaskFieldStoryPoints, value: &t.Fields.StoryPoints},
+		{name: constants.TaskFieldEffort, value: &t.Fields.Effort},
+		{name: constants.TaskFieldRemainingWork, value: &t.Fields.RemainingWork},
+	}
+}
+
+// RemoveInapplicableEstimates unsets the estimates which don't apply to the work item type of the request and returns their names.
+// The estimates of the work item types which are not known, like the custom ones, are all kept.
+func (t *CreateTaskRequestPayload) RemoveInapplicableEstimates() []string {
+	applicableEstimates, ok := constants.TaskEstimatesByType[strings.ToLower(t.Type)]
+	if !ok {
+		return nil
+	}
+
+	var removedEstimates []string
+	for _, estimate := range t.getEstimates() {
+		if *estimate.value == nil {
+			continue
+		}
+
+		isApplicable := false
+		for _, name := range applicableEstimates {
+			if name == estimate.name {
+				isApplicable = true
+			}
+		}
+
+		if !isApplicable {
+			*estimate.value = nil
+			removedEstimates = append(removedEstimates, estimate.name)
+		}
+	}
+
+	return removedEstimates
+}
+
+// GetMissingFields returns the fields out of the given ones which are empty in the request, the fields are named as in its JSON
+func (t *CreateTaskRequestPayload) GetMissingFields(fields []string) []string {
+	values := map[string]string{
+		constants.TaskFieldTitle:       t.Fields.Title,
+		constants.TaskFieldDescription: t.Fields.Description,
+		constants.TaskFieldAreaPath:    t.Fields.AreaPath,
+	}
+
+	var missingFields []string
+	for _, field := range fields {
+		if strings.TrimSpace(values[field]) == "" {
+			missingFields = append(missingFields, field)
+		}
+	}
+
+	return missingFields
+}
+
+// GetUpdateOperations returns the JSON-Patch operations setting the provided fields of the work item and removing the ones provided empty
+func (t *UpdateTaskFieldValue) GetUpdateOperations() []*CreateTaskBodyPayload {
+	var assignedTo *string
+	if t.AssignedTo != nil {
+		assignedTo = &t.AssignedTo.UniqueName
+	}
+
+	values := []struct {
+		field string
+		value *string
+	}{
+		{field: "System.Title", value: t.Title},
+		{field: "System.Description", value: t.Description},
+		{field: "System.State", value: t.State},
+		{field: "System.Reason", value: t.Reason},
+		{field: "System.AssignedTo", value: assignedTo},
+	}
+
+	var operations []*CreateTaskBodyPayload
+	for _, value := range values {
+		if value.value == nil {
+			continue
+		}
+
+		if *value.value == "" {
+			operations = append(operations, &CreateTaskBodyPayload{
+				Operation: "remove",
+				Path:      "/fields/" + value.field,
+			})
+			continue
+		}
+
+		operations = append(operations, &CreateTaskBodyPayload{
+			Operation: "add",
+			Path:      "/fields/" + value.field,
+			Value:     *value.value,
+		})
+	}
+
+	if t.RemainingWork != nil {
+		operations = append(operations, &CreateTaskBodyPayload{
+			Operation: "add",
+			Path:      "/fields/" + constants.FieldRemainingWork,
+			Value:     *t.RemainingWork,
+		})
+	}
+
+	return operations
+}
+
+// IsValid function to validate request payload.
+func (t *UpdateTaskRequestPayload) IsValid() error {
+	if t.Organization == "" {
+		return errors.New(constants.OrganizationRequired)
+	}
+	if t.Project == "" {
+		return errors.New(constants.ProjectRequired)
+	}
+	if t.Fields.Title != nil && strings.TrimSpace(*t.Fields.Title) == "" {
+		return errors.New(constants.TaskTitleRequired)
+	}
+	if t.Fields.RemainingWork != nil && *t.Fields.RemainingWork < 0 {
+		return fmt.Errorf(constants.InvalidTaskEstimate, constants.TaskFieldRemainingWork)
+	}
+	if len(t.Fields.GetUpdateOperations()) == 0 {
+		return errors.New(constants.TaskUpdateFieldsRequired)
+	}
 	return nil
 }
 
+func UpdateTaskRequestPayloadFromJSON(data io.Reader) (*UpdateTaskRequestPayload, error) {
+	var body *UpdateTaskRequestPayload
+	if err := json.NewDecoder(data).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// AddTaskCommentRequestPayload is a comment added to an existing work item, which is echoed as a reply to the post announcing its creation if requested
+type AddTaskCommentRequestPayload struct {
+	Organization string `json:"organization"`
+	Project      string `json:"project"`
+	Comment      string `json:"comment"`
+	EchoToPost   bool   `json:"echoToPost"`
+}
+
+func (t *AddTaskCommentRequestPayload) IsValid() error {
+	if t.Organization == "" {
+		return errors.New(constants.OrganizationRequired)
+	}
+	if t.Project == "" {
+		return errors.New(constants.ProjectRequired)
+	}
+	if strings.TrimSpace(t.Comment) == "" {
+		return errors.New(constants.TaskCommentRequired)
+	}
+	return nil
+}
+
+func AddTaskCommentRequestPayloadFromJSON(data io.Reader) (*AddTaskCommentRequestPayload, error) {
+	var body *AddTaskCommentRequestPayload
+	if err := json.NewDecoder(data).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+type WorkItemCommentRequest struct {
+	Text string `json:"text"`
+}
+
+type WorkItemComment struct {
+	ID          int       `json:"id"`
+	Text        string    `json:"text"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+// AddedTaskCommentResponse is the comment added to a work item, EchoPostID is the reply echoing it in Mattermost, if any
+type AddedTaskCommentResponse struct {
+	ID          int       `json:"id"`
+	CreatedDate time.Time `json:"createdDate"`
+	EchoPostID  string    `json:"echoPostId,omitempty"`
+}
+
 func CreateTaskRequestPayloadFromJSON(data io.Reader) (*CreateTaskRequestPayload, error) {
 	var body *CreateTaskRequestPayload
 	if err := json.NewDecoder(data).Decode(&body); err != nil {
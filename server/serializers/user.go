@@ -1,9 +1,107 @@
 package serializers
 
+import "strings"
+
 type User struct {
-	MattermostUserID string `json:"mattermostUserID"`
-	AccessToken      string `json:"accessToken"`
-	RefreshToken     string `json:"refreshToken"`
-	ExpiresAt        int64  `json:"expiresAt"`
+	MattermostUserID string   `json:"mattermostUserID"`
+	AccessToken      string   `json:"accessToken"`
+	RefreshToken     string   `json:"refreshToken"`
+	ExpiresAt        int64    `json:"expiresAt"`
+	Scopes           []string `json:"scopes"`
+	// AuthType is empty for the accounts connected with the browser redirect flow
+	AuthType string `json:"authType,omitempty"`
+	// Organization is the organization the account was connected for, the token can only be used with it and the organizations of OrganizationConnections.
+	// It's empty for the accounts connected without an organization, whose token can be used with any organization.
+	Organization string `json:"organization,omitempty"`
+	// OrganizationConnections are the organizations connected after the account, each with its own token
+	OrganizationConnections []*OrganizationConnection `json:"organizationConnections,omitempty"`
+	UserProfile
+}
+
+// OrganizationConnection is an organization connected by a user after connecting their account, e.g. with another Azure DevOps account
+type OrganizationConnection struct {
+	Organization string `json:"organization"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresAt    int64  `json:"expiresAt"`
+	// Email is the email of the Azure DevOps account used to connect the organization
+	Email string `json:"email"`
+}
+
+// UserAccountDetails is the user returned to the webapp along with the number of projects linked and subscriptions created by the user.
+// It has none of the tokens of the user, which are never sent to the webapp.
+type UserAccountDetails struct {
+	MattermostUserID        string                           `json:"mattermostUserID"`
+	ExpiresAt               int64                            `json:"expiresAt"`
+	Scopes                  []string                         `json:"scopes"`
+	AuthType                string                           `json:"authType,omitempty"`
+	Organization            string                           `json:"organization,omitempty"`
+	OrganizationConnections []*OrganizationConnectionDetails `json:"organizationConnections,omitempty"`
 	UserProfile
+	// ConnectedOrganizations are the organizations the user can use, it's empty if the account was connected without an organization
+	ConnectedOrganizations []string `json:"connectedOrganizations"`
+	LinkedProjectCount     int      `json:"linkedProjectCount"`
+	SubscriptionCount      int      `json:"subscriptionCount"`
+	// CountsUnavailable is set when the counts could not be loaded, they are 0 in that case
+	CountsUnavailable bool `json:"countsUnavailable,omitempty"`
+}
+
+// OrganizationConnectionDetails is an organization connection returned to the webapp, without its tokens
+type OrganizationConnectionDetails struct {
+	Organization string `json:"organization"`
+	ExpiresAt    int64  `json:"expiresAt"`
+	Email        string `json:"email"`
+}
+
+// GetAccountDetails returns the details of the user which can be sent to the webapp
+func (u *User) GetAccountDetails() *UserAccountDetails {
+	accountDetails := &UserAccountDetails{
+		MattermostUserID:       u.MattermostUserID,
+		ExpiresAt:              u.ExpiresAt,
+		Scopes:                 u.Scopes,
+		AuthType:               u.AuthType,
+		Organization:           u.Organization,
+		UserProfile:            u.UserProfile,
+		ConnectedOrganizations: u.GetConnectedOrganizations(),
+	}
+
+	for _, connection := range u.OrganizationConnections {
+		accountDetails.OrganizationConnections = append(accountDetails.OrganizationConnections, &OrganizationConnectionDetails{
+			Organization: connection.Organization,
+			ExpiresAt:    connection.ExpiresAt,
+			Email:        connection.Email,
+		})
+	}
+
+	return accountDetails
+}
+
+// GetOrganizationConnection returns the connection of an organization, organization names are compared case-insensitively
+func (u *User) GetOrganizationConnection(organization string) *OrganizationConnection {
+	for _, connection := range u.OrganizationConnections {
+		if strings.EqualFold(connection.Organization, organization) {
+			return connection
+		}
+	}
+
+	return nil
+}
+
+// IsOrganizationConnected checks if the user can send requests to an organization
+func (u *User) IsOrganizationConnected(organization string) bool {
+	return u.Organization == "" || strings.EqualFold(u.Organization, organization) || u.GetOrganizationConnection(organization) != nil
+}
+
+// GetConnectedOrganizations returns the organization of the account, if any, followed by the ones connected after it
+func (u *User) GetConnectedOrganizations() []string {
+	organizations := []string{}
+	if u.Organization != "" {
+		organizations = append(organizations, u.Organization)
+	}
+
+	for _, connection := range u.OrganizationConnections {
+		organizations = append(organizations, connection.Organization)
+	}
+
+	return organizations
 }
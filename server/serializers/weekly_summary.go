@@ -0,0 +1,19 @@
+package serializers
+
+// WeeklySummary counts the notifications posted in a channel by their event type since its last weekly summary was posted
+type WeeklySummary struct {
+	ChannelID    string         `json:"channelID"`
+	Counts       map[string]int `json:"counts"`
+	Since        int64          `json:"since"`
+	LastPostedAt int64          `json:"lastPostedAt,omitempty"`
+}
+
+// GetTotal returns the number of notifications of all the event types
+func (t *WeeklySummary) GetTotal() int {
+	total := 0
+	for _, count := range t.Counts {
+		total += count
+	}
+
+	return total
+}
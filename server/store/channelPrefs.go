@@ -0,0 +1,38 @@
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+type ChannelPrefsStore interface {
+	SetChannelNotificationPrefs(prefs *serializers.ChannelNotificationPrefs) error
+	GetChannelNotificationPrefs(channelID string) (*serializers.ChannelNotificationPrefs, error)
+}
+
+func (s *Store) SetChannelNotificationPrefs(prefs *serializers.ChannelNotificationPrefs) error {
+	prefsBytes, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+
+	return s.Store(GetChannelNotificationPrefsKey(prefs.ChannelID), prefsBytes)
+}
+
+// GetChannelNotificationPrefs returns the notification preferences of a channel, none of the options are set if they were never stored
+func (s *Store) GetChannelNotificationPrefs(channelID string) (*serializers.ChannelNotificationPrefs, error) {
+	prefsBytes, err := s.Load(GetChannelNotificationPrefsKey(channelID))
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := &serializers.ChannelNotificationPrefs{ChannelID: channelID}
+	if len(prefsBytes) != 0 {
+		if err := json.Unmarshal(prefsBytes, prefs); err != nil {
+			return nil, err
+		}
+	}
+
+	return prefs, nil
+}
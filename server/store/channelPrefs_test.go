@@ -0,0 +1,88 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestSetChannelNotificationPrefs(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "SetChannelNotificationPrefs: preferences are stored successfully",
+		},
+		{
+			description: "SetChannelNotificationPrefs: preferences are not stored successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Store", func(_ *Store, key string, data []byte) error {
+				assert.Equal(t, "channel_notification_prefs_mockChannelID", key)
+				assert.JSONEq(t, `{"channelID":"mockChannelID","color":"#0078d4"}`, string(data))
+				return testCase.err
+			})
+
+			err := s.SetChannelNotificationPrefs(&serializers.ChannelNotificationPrefs{ChannelID: "mockChannelID", Color: "#0078d4"})
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetChannelNotificationPrefs(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description   string
+		data          []byte
+		err           error
+		expectedColor string
+	}{
+		{
+			description:   "GetChannelNotificationPrefs: preferences are fetched",
+			data:          []byte(`{"channelID":"mockChannelID","color":"#0078d4"}`),
+			expectedColor: "#0078d4",
+		},
+		{
+			description: "GetChannelNotificationPrefs: no preferences are stored",
+		},
+		{
+			description: "GetChannelNotificationPrefs: 'Load' gives error",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(*Store, string) ([]byte, error) {
+				return testCase.data, testCase.err
+			})
+
+			prefs, err := s.GetChannelNotificationPrefs("mockChannelID")
+
+			if testCase.err != nil {
+				assert.Nil(t, prefs)
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "mockChannelID", prefs.ChannelID)
+			assert.Equal(t, testCase.expectedColor, prefs.Color)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package store
+
+type CoalescedPostStore interface {
+	StoreCoalescedPost(channelID, organization, correlationKey, postID string, ttlSeconds int64) error
+	GetCoalescedPost(channelID, organization, correlationKey string) (string, error)
+}
+
+// StoreCoalescedPost records the post combining the notifications sharing a correlation key in a channel, until the coalescing window ends
+func (s *Store) StoreCoalescedPost(channelID, organization, correlationKey, postID string, ttlSeconds int64) error {
+	return s.StoreTTL(GetCoalescedPostKey(channelID, organization, correlationKey), []byte(postID), ttlSeconds)
+}
+
+// GetCoalescedPost returns the post combining the notifications sharing a correlation key in a channel, it's empty if the coalescing window has ended
+func (s *Store) GetCoalescedPost(channelID, organization, correlationKey string) (string, error) {
+	postID, err := s.Load(GetCoalescedPostKey(channelID, organization, correlationKey))
+	if err != nil {
+		return "", err
+	}
+
+	return string(postID), nil
+}
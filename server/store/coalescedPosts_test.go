@@ -0,0 +1,90 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreCoalescedPost(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "StoreCoalescedPost: post is stored successfully",
+		},
+		{
+			description: "StoreCoalescedPost: post is not stored successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "StoreTTL", func(_ *Store, key string, data []byte, ttlSeconds int64) error {
+				assert.Equal(t, GetCoalescedPostKey("mockChannelID", "mockOrganization", "pullrequest_1"), key)
+				assert.Equal(t, "mockPostID", string(data))
+				assert.Equal(t, int64(30), ttlSeconds)
+				return testCase.err
+			})
+
+			err := s.StoreCoalescedPost("mockChannelID", "mockOrganization", "pullrequest_1", "mockPostID", 30)
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetCoalescedPost(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description    string
+		data           []byte
+		err            error
+		expectedPostID string
+	}{
+		{
+			description:    "GetCoalescedPost: post is fetched",
+			data:           []byte("mockPostID"),
+			expectedPostID: "mockPostID",
+		},
+		{
+			description: "GetCoalescedPost: coalescing window has ended",
+		},
+		{
+			description: "GetCoalescedPost: 'Load' gives error",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(*Store, string) ([]byte, error) {
+				return testCase.data, testCase.err
+			})
+
+			postID, err := s.GetCoalescedPost("mockChannelID", "mockOrganization", "pullrequest_1")
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedPostID, postID)
+		})
+	}
+}
+
+func TestGetCoalescedPostKey(t *testing.T) {
+	assert.Equal(t, GetCoalescedPostKey("mockChannelID", "MockOrganization", "pullrequest_1"), GetCoalescedPostKey("mockChannelID", "mockorganization", "pullrequest_1"))
+	assert.NotEqual(t, GetCoalescedPostKey("mockChannelID", "mockOrganization", "pullrequest_1"), GetCoalescedPostKey("mockChannelID", "mockOrganization", "workitem_1"))
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+type DeliveryLogStore interface {
+	AddDeliveryLogEntry(channelID string, entry *serializers.DeliveryLogEntry) error
+	GetDeliveryLog(channelID string) ([]*serializers.DeliveryLogEntry, error)
+}
+
+// addDeliveryLogEntryAtomicModify appends a delivery to the log of a channel, the oldest deliveries are dropped once the log has the maximum number of entries
+func addDeliveryLogEntryAtomicModify(entry *serializers.DeliveryLogEntry, maxEntries int, initialBytes []byte) ([]byte, error) {
+	entries, err := DeliveryLogFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+
+	modifiedBytes, marshalErr := json.Marshal(entries)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) AddDeliveryLogEntry(channelID string, entry *serializers.DeliveryLogEntry) error {
+	return s.AtomicModify(GetDeliveryLogKey(channelID), func(initialBytes []byte) ([]byte, error) {
+		return addDeliveryLogEntryAtomicModify(entry, constants.DeliveryLogMaxEntries, initialBytes)
+	})
+}
+
+// GetDeliveryLog returns the recent deliveries to a channel from the oldest to the latest
+func (s *Store) GetDeliveryLog(channelID string) ([]*serializers.DeliveryLogEntry, error) {
+	entriesBytes, err := s.Load(GetDeliveryLogKey(channelID))
+	if err != nil {
+		return nil, err
+	}
+
+	return DeliveryLogFromJSON(entriesBytes)
+}
+
+func DeliveryLogFromJSON(bytes []byte) ([]*serializers.DeliveryLogEntry, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+
+	var entries []*serializers.DeliveryLogEntry
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestAddDeliveryLogEntryAtomicModify(t *testing.T) {
+	getEntries := func(from, to int) []*serializers.DeliveryLogEntry {
+		var entries []*serializers.DeliveryLogEntry
+		for i := from; i <= to; i++ {
+			entries = append(entries, &serializers.DeliveryLogEntry{Timestamp: int64(i), EventType: constants.SubscriptionEventCodePushed, SubscriptionID: "mockSubscriptionID", PostID: fmt.Sprintf("mockPostID%d", i)})
+		}
+		return entries
+	}
+
+	for _, testCase := range []struct {
+		description     string
+		entries         []*serializers.DeliveryLogEntry
+		expectedEntries []*serializers.DeliveryLogEntry
+	}{
+		{
+			description:     "AddDeliveryLogEntryAtomicModify: first delivery to the channel",
+			expectedEntries: getEntries(4, 4),
+		},
+		{
+			description:     "AddDeliveryLogEntryAtomicModify: delivery is appended to the log",
+			entries:         getEntries(1, 2),
+			expectedEntries: append(getEntries(1, 2), getEntries(4, 4)...),
+		},
+		{
+			description:     "AddDeliveryLogEntryAtomicModify: oldest delivery is dropped from a full log",
+			entries:         getEntries(1, 3),
+			expectedEntries: append(getEntries(2, 3), getEntries(4, 4)...),
+		},
+		{
+			description:     "AddDeliveryLogEntryAtomicModify: log over the maximum is trimmed",
+			entries:         getEntries(-2, 3),
+			expectedEntries: append(getEntries(2, 3), getEntries(4, 4)...),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var initialBytes []byte
+			if testCase.entries != nil {
+				var err error
+				initialBytes, err = json.Marshal(testCase.entries)
+				require.NoError(t, err)
+			}
+
+			modifiedBytes, err := addDeliveryLogEntryAtomicModify(getEntries(4, 4)[0], 3, initialBytes)
+
+			require.NoError(t, err)
+			entries, err := DeliveryLogFromJSON(modifiedBytes)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedEntries, entries)
+		})
+	}
+}
+
+func TestAddDeliveryLogEntryAtomicModifyKeepsTheMaximumEntries(t *testing.T) {
+	var bytes []byte
+	for i := 1; i <= constants.DeliveryLogMaxEntries+10; i++ {
+		var err error
+		bytes, err = addDeliveryLogEntryAtomicModify(&serializers.DeliveryLogEntry{Timestamp: int64(i)}, constants.DeliveryLogMaxEntries, bytes)
+		require.NoError(t, err)
+	}
+
+	entries, err := DeliveryLogFromJSON(bytes)
+
+	require.NoError(t, err)
+	require.Len(t, entries, constants.DeliveryLogMaxEntries)
+	assert.Equal(t, int64(11), entries[0].Timestamp)
+	assert.Equal(t, int64(constants.DeliveryLogMaxEntries+10), entries[len(entries)-1].Timestamp)
+}
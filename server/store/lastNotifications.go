@@ -0,0 +1,57 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+type LastNotificationStore interface {
+	StoreLastNotification(notification *serializers.SubscriptionNotification) error
+	GetLastNotification(subscriptionID string) (*serializers.SubscriptionNotification, error)
+	DeleteLastNotification(subscriptionID string) error
+}
+
+// StoreLastNotification replaces the stored copy of the last notification of a subscription.
+// A notification larger than the size limit is not stored, and the previous one is deleted so that an older notification is never shown as the last one.
+func (s *Store) StoreLastNotification(notification *serializers.SubscriptionNotification) error {
+	key := GetLastNotificationKey(notification.SubscriptionID)
+	notificationBytes, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	if len(notificationBytes) > constants.LastNotificationMaxSize {
+		if err := s.Delete(key); err != nil {
+			return err
+		}
+		return fmt.Errorf("notification of %d bytes exceeds the limit of %d bytes", len(notificationBytes), constants.LastNotificationMaxSize)
+	}
+
+	return s.Store(key, notificationBytes)
+}
+
+// GetLastNotification returns the last notification of a subscription, it's nil if the subscription has not sent any notifications
+func (s *Store) GetLastNotification(subscriptionID string) (*serializers.SubscriptionNotification, error) {
+	notificationBytes, err := s.Load(GetLastNotificationKey(subscriptionID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notificationBytes) == 0 {
+		return nil, nil
+	}
+
+	var notification *serializers.SubscriptionNotification
+	if err := json.Unmarshal(notificationBytes, &notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (s *Store) DeleteLastNotification(subscriptionID string) error {
+	return s.Delete(GetLastNotificationKey(subscriptionID))
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestLastNotificationRoundtrip(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	kv := map[string][]byte{}
+	monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Store", func(_ *Store, key string, data []byte) error {
+		kv[key] = data
+		return nil
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(_ *Store, key string) ([]byte, error) {
+		return kv[key], nil
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Delete", func(_ *Store, key string) error {
+		delete(kv, key)
+		return nil
+	})
+
+	var notification *serializers.SubscriptionNotification
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"subscriptionID": "mockSubscriptionID",
+		"eventType": "workitem.updated",
+		"message": {"markdown": "mockMarkdown"},
+		"resource": {
+			"workItemId": 1,
+			"fields": {"System.State": {"oldValue": "New", "newValue": "Active"}},
+			"revision": {"fields": {"System.Title": "mockTitle", "System.TeamProject": "mockProject"}}
+		}
+	}`), &notification))
+
+	t.Run("LastNotification: subscription has not sent any notifications", func(t *testing.T) {
+		storedNotification, err := s.GetLastNotification("mockSubscriptionID")
+
+		assert.NoError(t, err)
+		assert.Nil(t, storedNotification)
+	})
+
+	t.Run("LastNotification: stored notification is loaded with all its fields", func(t *testing.T) {
+		require.NoError(t, s.StoreLastNotification(notification))
+
+		storedNotification, err := s.GetLastNotification("mockSubscriptionID")
+
+		require.NoError(t, err)
+		assert.Equal(t, notification, storedNotification)
+		assert.Equal(t, map[string]interface{}{"oldValue": "New", "newValue": "Active"}, storedNotification.Resource.Fields.All["System.State"])
+		assert.Equal(t, "mockTitle", storedNotification.Resource.Revision.Fields.Title)
+	})
+
+	t.Run("LastNotification: notification over the size limit replaces the stored notification", func(t *testing.T) {
+		largeNotification := *notification
+		largeNotification.Message.Markdown = strings.Repeat("a", constants.LastNotificationMaxSize)
+
+		assert.Error(t, s.StoreLastNotification(&largeNotification))
+
+		storedNotification, err := s.GetLastNotification("mockSubscriptionID")
+		assert.NoError(t, err)
+		assert.Nil(t, storedNotification)
+	})
+
+	t.Run("LastNotification: notification is deleted", func(t *testing.T) {
+		require.NoError(t, s.StoreLastNotification(notification))
+		require.NoError(t, s.DeleteLastNotification("mockSubscriptionID"))
+
+		storedNotification, err := s.GetLastNotification("mockSubscriptionID")
+		assert.NoError(t, err)
+		assert.Nil(t, storedNotification)
+	})
+}
+
+func TestGetLastNotification(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+
+	t.Run("GetLastNotification: 'Load' gives error", func(t *testing.T) {
+		monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(_ *Store, key string) ([]byte, error) {
+			assert.Equal(t, GetLastNotificationKey("mockSubscriptionID"), key)
+			return nil, errors.New("mockError")
+		})
+
+		storedNotification, err := s.GetLastNotification("mockSubscriptionID")
+
+		assert.Error(t, err)
+		assert.Nil(t, storedNotification)
+	})
+}
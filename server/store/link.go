@@ -2,6 +2,8 @@ package store
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -14,10 +16,18 @@ type LinkStore interface {
 	GetProject() (*ProjectList, error)
 	GetAllProjects(userID string) ([]serializers.ProjectDetails, error)
 	DeleteProject(project *serializers.ProjectDetails) error
+	UpdateProject(project *serializers.ProjectDetails) error
+	DedupeProjects(userID string) ([]MergedProject, error)
 }
 
 type ProjectListMap map[string]serializers.ProjectDetails
 
+// MergedProject is a linked project which was kept in place of its duplicates
+type MergedProject struct {
+	Project    serializers.ProjectDetails
+	Duplicates []serializers.ProjectDetails
+}
+
 type ProjectList struct {
 	ByMattermostUserID map[string]ProjectListMap
 }
@@ -62,6 +72,7 @@ func (projectList *ProjectList) AddProject(userID string, project *serializers.P
 		ProjectID:        project.ProjectID,
 		ProjectName:      project.ProjectName,
 		OrganizationName: project.OrganizationName,
+		DefaultQuery:     project.DefaultQuery,
 	}
 	projectList.ByMattermostUserID[userID][projectKey] = projectListValue
 }
@@ -124,6 +135,122 @@ func (projectList *ProjectList) DeleteProjectByKey(userID, projectKey string) {
 	}
 }
 
+func updateProjectAtomicModify(project *serializers.ProjectDetails, initialBytes []byte) ([]byte, error) {
+	projectList, err := ProjectListFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+	projectList.UpdateProject(project.MattermostUserID, project)
+	modifiedBytes, marshalErr := json.Marshal(projectList)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+// UpdateProject changes the name of a project linked by a user, a project which is not linked anymore is left unlinked
+func (s *Store) UpdateProject(project *serializers.ProjectDetails) error {
+	key := GetProjectListMapKey()
+	if err := s.AtomicModify(key, func(initialBytes []byte) ([]byte, error) {
+		return updateProjectAtomicModify(project, initialBytes)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateProject changes the name of the entry of a project linked by a user and reports whether it is linked
+func (projectList *ProjectList) UpdateProject(userID string, project *serializers.ProjectDetails) bool {
+	projectKey := GetProjectKey(project.ProjectID, userID)
+	linkedProject, isLinked := projectList.ByMattermostUserID[userID][projectKey]
+	if !isLinked {
+		return false
+	}
+
+	linkedProject.ProjectName = project.ProjectName
+	projectList.ByMattermostUserID[userID][projectKey] = linkedProject
+	return true
+}
+
+func dedupeProjectsAtomicModify(userID string, initialBytes []byte, mergedProjects *[]MergedProject) ([]byte, error) {
+	projectList, err := ProjectListFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+	*mergedProjects = projectList.DedupeProjects(userID)
+	modifiedBytes, marshalErr := json.Marshal(projectList)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+// DedupeProjects merges the projects linked more than once by a user and returns the merged ones.
+// Running it again does not change anything, as only one entry of each project is left.
+func (s *Store) DedupeProjects(userID string) ([]MergedProject, error) {
+	var mergedProjects []MergedProject
+	key := GetProjectListMapKey()
+	if err := s.AtomicModify(key, func(initialBytes []byte) ([]byte, error) {
+		return dedupeProjectsAtomicModify(userID, initialBytes, &mergedProjects)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mergedProjects, nil
+}
+
+// DedupeProjects groups the projects of a user by their organization and project ID, ignoring the case and the surrounding spaces.
+// The entry whose project ID is already normalized is kept for each group, and it takes the default query of a duplicate if it does not have one.
+func (projectList *ProjectList) DedupeProjects(userID string) []MergedProject {
+	projects := projectList.ByMattermostUserID[userID]
+	keysByProject := map[string][]string{}
+	for key, project := range projects {
+		normalizedKey := GetNormalizedProjectKey(project.OrganizationName, project.ProjectID)
+		keysByProject[normalizedKey] = append(keysByProject[normalizedKey], key)
+	}
+
+	var mergedProjects []MergedProject
+	for _, keys := range keysByProject {
+		if len(keys) == 1 {
+			continue
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			first, second := projects[keys[i]], projects[keys[j]]
+			if isFirstNormalized, isSecondNormalized := isNormalizedProjectID(first.ProjectID), isNormalizedProjectID(second.ProjectID); isFirstNormalized != isSecondNormalized {
+				return isFirstNormalized
+			}
+			if (first.DefaultQuery != "") != (second.DefaultQuery != "") {
+				return first.DefaultQuery != ""
+			}
+			return keys[i] < keys[j]
+		})
+
+		kept := projects[keys[0]]
+		mergedProject := MergedProject{}
+		for _, key := range keys[1:] {
+			if kept.DefaultQuery == "" {
+				kept.DefaultQuery = projects[key].DefaultQuery
+			}
+			mergedProject.Duplicates = append(mergedProject.Duplicates, projects[key])
+			delete(projects, key)
+		}
+		projects[keys[0]] = kept
+		mergedProject.Project = kept
+		mergedProjects = append(mergedProjects, mergedProject)
+	}
+
+	sort.Slice(mergedProjects, func(i, j int) bool {
+		return mergedProjects[i].Project.ProjectName < mergedProjects[j].Project.ProjectName
+	})
+	return mergedProjects
+}
+
+func isNormalizedProjectID(projectID string) bool {
+	return projectID == strings.ToLower(strings.TrimSpace(projectID))
+}
+
 func ProjectListFromJSON(bytes []byte) (*ProjectList, error) {
 	var projectList *ProjectList
 	if len(bytes) != 0 {
@@ -134,6 +134,17 @@ func TestAddProject(t *testing.T) {
 			})
 		})
 	}
+
+	t.Run("AddProject: default query of the project is kept", func(t *testing.T) {
+		projectList.AddProject("mockMattermostUserID", &serializers.ProjectDetails{
+			OrganizationName: "mockOrganization",
+			ProjectID:        "mockProjectID",
+			ProjectName:      "mockProject",
+			DefaultQuery:     "SELECT [System.Id] FROM WorkItems",
+		})
+
+		assert.Equal(t, "SELECT [System.Id] FROM WorkItems", projectList.ByMattermostUserID["mockMattermostUserID"][GetProjectKey("mockProjectID", "mockMattermostUserID")].DefaultQuery)
+	})
 }
 
 func TestGetProjects(t *testing.T) {
@@ -301,6 +312,90 @@ func TestDeleteProject(t *testing.T) {
 	}
 }
 
+func TestUpdateProject(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "UpdateProject: project is updated successfully",
+		},
+		{
+			description: "UpdateProject: project is not updated successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.Patch(GetProjectListMapKey, func() string {
+				return "mockProjectKey"
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "AtomicModify", func(*Store, string, func([]byte) ([]byte, error)) error {
+				return testCase.err
+			})
+
+			err := s.UpdateProject(&serializers.ProjectDetails{})
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestProjectListUpdateProject(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		project         *serializers.ProjectDetails
+		expectedLinked  bool
+		expectedProject serializers.ProjectDetails
+	}{
+		{
+			description:    "UpdateProject: name of the linked project is changed",
+			project:        &serializers.ProjectDetails{ProjectID: "mockProjectID", ProjectName: "mockRenamedProject"},
+			expectedLinked: true,
+			expectedProject: serializers.ProjectDetails{
+				MattermostUserID: "mockMattermostUserID",
+				ProjectID:        "mockProjectID",
+				ProjectName:      "mockRenamedProject",
+				OrganizationName: "mockOrganization",
+				DefaultQuery:     "mockQuery",
+			},
+		},
+		{
+			description: "UpdateProject: project is not linked",
+			project:     &serializers.ProjectDetails{ProjectID: "mockOtherProjectID", ProjectName: "mockRenamedProject"},
+			expectedProject: serializers.ProjectDetails{
+				MattermostUserID: "mockMattermostUserID",
+				ProjectID:        "mockProjectID",
+				ProjectName:      "mockProject",
+				OrganizationName: "mockOrganization",
+				DefaultQuery:     "mockQuery",
+			},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			projectList := NewProjectList()
+			projectList.AddProject("mockMattermostUserID", &serializers.ProjectDetails{
+				ProjectID:        "mockProjectID",
+				ProjectName:      "mockProject",
+				OrganizationName: "mockOrganization",
+				DefaultQuery:     "mockQuery",
+			})
+
+			isLinked := projectList.UpdateProject("mockMattermostUserID", testCase.project)
+
+			assert.Equal(t, testCase.expectedLinked, isLinked)
+			assert.Len(t, projectList.ByMattermostUserID["mockMattermostUserID"], 1)
+			assert.Equal(t, testCase.expectedProject, projectList.ByMattermostUserID["mockMattermostUserID"][GetProjectKey("mockProjectID", "mockMattermostUserID")])
+		})
+	}
+}
+
 func TestDeleteProjectByKey(t *testing.T) {
 	defer monkey.UnpatchAll()
 	projectList := NewProjectList()
@@ -354,3 +449,44 @@ func TestProjectListFromJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestDedupeProjects(t *testing.T) {
+	projectList := NewProjectList()
+	for _, project := range []*serializers.ProjectDetails{
+		{OrganizationName: "mockOrganization", ProjectID: "mockprojectid", ProjectName: "mockProject"},
+		{OrganizationName: "MockOrganization ", ProjectID: "MockProjectID", ProjectName: "mockProject", DefaultQuery: "SELECT [System.Id] FROM WorkItems"},
+		{OrganizationName: "mockOrganization", ProjectID: " mockprojectid", ProjectName: "mockProject"},
+		{OrganizationName: "mockOrganization", ProjectID: "mockotherprojectid", ProjectName: "mockOtherProject"},
+		{OrganizationName: "mockOtherOrganization", ProjectID: "MOCKPROJECTID", ProjectName: "mockProject"},
+	} {
+		projectList.AddProject("mockMattermostUserID", project)
+	}
+	projectList.AddProject("mockOtherMattermostUserID", &serializers.ProjectDetails{OrganizationName: "mockOrganization", ProjectID: "MockProjectID"})
+
+	t.Run("DedupeProjects: duplicate projects are merged", func(t *testing.T) {
+		mergedProjects := projectList.DedupeProjects("mockMattermostUserID")
+
+		assert.Len(t, mergedProjects, 1)
+		assert.Equal(t, serializers.ProjectDetails{
+			MattermostUserID: "mockMattermostUserID",
+			OrganizationName: "mockOrganization",
+			ProjectID:        "mockprojectid",
+			ProjectName:      "mockProject",
+			DefaultQuery:     "SELECT [System.Id] FROM WorkItems",
+		}, mergedProjects[0].Project)
+		assert.Len(t, mergedProjects[0].Duplicates, 2)
+		assert.Len(t, projectList.ByMattermostUserID["mockMattermostUserID"], 3)
+		assert.Equal(t, mergedProjects[0].Project, projectList.ByMattermostUserID["mockMattermostUserID"][GetProjectKey("mockprojectid", "mockMattermostUserID")])
+		assert.Len(t, projectList.ByMattermostUserID["mockOtherMattermostUserID"], 1)
+	})
+
+	t.Run("DedupeProjects: merging again does not change anything", func(t *testing.T) {
+		projects := ProjectListMap{}
+		for key, project := range projectList.ByMattermostUserID["mockMattermostUserID"] {
+			projects[key] = project
+		}
+
+		assert.Empty(t, projectList.DedupeProjects("mockMattermostUserID"))
+		assert.Equal(t, projects, projectList.ByMattermostUserID["mockMattermostUserID"])
+	})
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+type NotificationBurstStore interface {
+	AddNotificationBurstWorkItem(subscriptionID string, workItemID int, iterationPath string) (*serializers.NotificationBurst, error)
+	SetNotificationBurstSummaryPost(subscriptionID, oldPostID, newPostID string) (*serializers.NotificationBurst, error)
+}
+
+// addNotificationBurstWorkItemAtomicModify adds a work item to a burst, a new burst is started if the last work item was created before the burst window
+func addNotificationBurstWorkItemAtomicModify(workItemID int, iterationPath string, now int64, initialBytes []byte) ([]byte, *serializers.NotificationBurst, error) {
+	burst, err := NotificationBurstFromJSON(initialBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if burst == nil || now-burst.LastEventAt > constants.TTLSecondsForNotificationBurst {
+		burst = &serializers.NotificationBurst{}
+	}
+
+	burst.LastEventAt = now
+	burst.Count++
+	if len(burst.WorkItemIDs) < constants.NotificationBurstMaxWorkItemIDs {
+		burst.WorkItemIDs = append(burst.WorkItemIDs, workItemID)
+	}
+
+	isIterationAdded := false
+	for _, path := range burst.IterationPaths {
+		if path == iterationPath {
+			isIterationAdded = true
+			break
+		}
+	}
+	if !isIterationAdded && len(burst.IterationPaths) < constants.NotificationBurstMaxIterations {
+		burst.IterationPaths = append(burst.IterationPaths, iterationPath)
+	}
+
+	modifiedBytes, marshalErr := json.Marshal(burst)
+	if marshalErr != nil {
+		return nil, nil, marshalErr
+	}
+	return modifiedBytes, burst, nil
+}
+
+// AddNotificationBurstWorkItem adds a created work item to the burst of a subscription and returns the updated burst.
+// The burst expires if no work item is created for the burst window, so the window slides with every work item.
+func (s *Store) AddNotificationBurstWorkItem(subscriptionID string, workItemID int, iterationPath string) (*serializers.NotificationBurst, error) {
+	var burst *serializers.NotificationBurst
+	if err := s.AtomicModifyWithOptions(GetNotificationBurstKey(subscriptionID), func(initialBytes []byte) ([]byte, *model.PluginKVSetOptions, error) {
+		modifiedBytes, modifiedBurst, err := addNotificationBurstWorkItemAtomicModify(workItemID, iterationPath, time.Now().Unix(), initialBytes)
+		burst = modifiedBurst
+		return modifiedBytes, &model.PluginKVSetOptions{ExpireInSeconds: constants.TTLSecondsForNotificationBurst}, err
+	}); err != nil {
+		return nil, err
+	}
+
+	return burst, nil
+}
+
+func setNotificationBurstSummaryPostAtomicModify(oldPostID, newPostID string, initialBytes []byte) ([]byte, *serializers.NotificationBurst, error) {
+	burst, err := NotificationBurstFromJSON(initialBytes)
+	if err != nil || burst == nil {
+		return initialBytes, burst, err
+	}
+
+	// The summary post is only replaced if it wasn't changed since it was read, so concurrent notifications don't create multiple summaries
+	if burst.SummaryPostID != oldPostID {
+		return initialBytes, burst, nil
+	}
+
+	burst.SummaryPostID = newPostID
+	modifiedBytes, marshalErr := json.Marshal(burst)
+	if marshalErr != nil {
+		return nil, nil, marshalErr
+	}
+	return modifiedBytes, burst, nil
+}
+
+// SetNotificationBurstSummaryPost replaces the summary post of the burst of a subscription if it's still oldPostID, and returns the latest burst.
+// The returned burst is nil if it has expired.
+func (s *Store) SetNotificationBurstSummaryPost(subscriptionID, oldPostID, newPostID string) (*serializers.NotificationBurst, error) {
+	var burst *serializers.NotificationBurst
+	if err := s.AtomicModifyWithOptions(GetNotificationBurstKey(subscriptionID), func(initialBytes []byte) ([]byte, *model.PluginKVSetOptions, error) {
+		modifiedBytes, modifiedBurst, err := setNotificationBurstSummaryPostAtomicModify(oldPostID, newPostID, initialBytes)
+		burst = modifiedBurst
+		return modifiedBytes, &model.PluginKVSetOptions{ExpireInSeconds: constants.TTLSecondsForNotificationBurst}, err
+	}); err != nil {
+		return nil, err
+	}
+
+	return burst, nil
+}
+
+func NotificationBurstFromJSON(bytes []byte) (*serializers.NotificationBurst, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+
+	var burst *serializers.NotificationBurst
+	if err := json.Unmarshal(bytes, &burst); err != nil {
+		return nil, err
+	}
+	return burst, nil
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestAddNotificationBurstWorkItemAtomicModify(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		burst         *serializers.NotificationBurst
+		now           int64
+		expectedBurst *serializers.NotificationBurst
+	}{
+		{
+			description:   "AddNotificationBurstWorkItemAtomicModify: first work item starts a burst",
+			now:           100,
+			expectedBurst: &serializers.NotificationBurst{LastEventAt: 100, Count: 1, WorkItemIDs: []int{3}, IterationPaths: []string{`mockProject\Sprint 12`}},
+		},
+		{
+			description:   "AddNotificationBurstWorkItemAtomicModify: work item created within the window is added to the burst",
+			burst:         &serializers.NotificationBurst{LastEventAt: 100, Count: 2, WorkItemIDs: []int{1, 2}, IterationPaths: []string{`mockProject\Sprint 12`}, SummaryPostID: "mockPostID"},
+			now:           100 + constants.TTLSecondsForNotificationBurst,
+			expectedBurst: &serializers.NotificationBurst{LastEventAt: 100 + constants.TTLSecondsForNotificationBurst, Count: 3, WorkItemIDs: []int{1, 2, 3}, IterationPaths: []string{`mockProject\Sprint 12`}, SummaryPostID: "mockPostID"},
+		},
+		{
+			description:   "AddNotificationBurstWorkItemAtomicModify: work item created after the window starts a new burst",
+			burst:         &serializers.NotificationBurst{LastEventAt: 100, Count: 2, WorkItemIDs: []int{1, 2}, IterationPaths: []string{`mockProject\Sprint 12`}, SummaryPostID: "mockPostID"},
+			now:           101 + constants.TTLSecondsForNotificationBurst,
+			expectedBurst: &serializers.NotificationBurst{LastEventAt: 101 + constants.TTLSecondsForNotificationBurst, Count: 1, WorkItemIDs: []int{3}, IterationPaths: []string{`mockProject\Sprint 12`}},
+		},
+		{
+			description:   "AddNotificationBurstWorkItemAtomicModify: work item IDs and iterations are capped",
+			burst:         &serializers.NotificationBurst{LastEventAt: 100, Count: constants.NotificationBurstMaxWorkItemIDs, WorkItemIDs: make([]int, constants.NotificationBurstMaxWorkItemIDs), IterationPaths: []string{`mockProject\Sprint 10`, `mockProject\Sprint 11`}},
+			now:           100,
+			expectedBurst: &serializers.NotificationBurst{LastEventAt: 100, Count: constants.NotificationBurstMaxWorkItemIDs + 1, WorkItemIDs: make([]int, constants.NotificationBurstMaxWorkItemIDs), IterationPaths: []string{`mockProject\Sprint 10`, `mockProject\Sprint 11`}},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var initialBytes []byte
+			if testCase.burst != nil {
+				var err error
+				initialBytes, err = json.Marshal(testCase.burst)
+				require.NoError(t, err)
+			}
+
+			modifiedBytes, burst, err := addNotificationBurstWorkItemAtomicModify(3, `mockProject\Sprint 12`, testCase.now, initialBytes)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedBurst, burst)
+			storedBurst, err := NotificationBurstFromJSON(modifiedBytes)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedBurst, storedBurst)
+		})
+	}
+}
+
+func TestSetNotificationBurstSummaryPostAtomicModify(t *testing.T) {
+	for _, testCase := range []struct {
+		description           string
+		burst                 *serializers.NotificationBurst
+		expectedSummaryPostID string
+	}{
+		{
+			description:           "SetNotificationBurstSummaryPostAtomicModify: summary post is set",
+			burst:                 &serializers.NotificationBurst{Count: 6},
+			expectedSummaryPostID: "mockNewPostID",
+		},
+		{
+			description:           "SetNotificationBurstSummaryPostAtomicModify: summary post was already set by another notification",
+			burst:                 &serializers.NotificationBurst{Count: 7, SummaryPostID: "mockOtherPostID"},
+			expectedSummaryPostID: "mockOtherPostID",
+		},
+		{
+			description: "SetNotificationBurstSummaryPostAtomicModify: burst has expired",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var initialBytes []byte
+			if testCase.burst != nil {
+				var err error
+				initialBytes, err = json.Marshal(testCase.burst)
+				require.NoError(t, err)
+			}
+
+			modifiedBytes, burst, err := setNotificationBurstSummaryPostAtomicModify("", "mockNewPostID", initialBytes)
+
+			require.NoError(t, err)
+			if testCase.burst == nil {
+				assert.Nil(t, burst)
+				assert.Nil(t, modifiedBytes)
+				return
+			}
+
+			assert.Equal(t, testCase.expectedSummaryPostID, burst.SummaryPostID)
+			storedBurst, err := NotificationBurstFromJSON(modifiedBytes)
+			require.NoError(t, err)
+			assert.Equal(t, burst, storedBurst)
+		})
+	}
+}
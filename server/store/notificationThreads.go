@@ -0,0 +1,26 @@
+package store
+
+import (
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+type NotificationThreadStore interface {
+	StoreNotificationThread(channelID, organization string, workItemID int, rootPostID string) error
+	GetNotificationThread(channelID, organization string, workItemID int) (string, error)
+}
+
+// StoreNotificationThread records the root post of a work item's notifications in a channel.
+// It's stored again on every notification, so the thread expires only after the work item is inactive.
+func (s *Store) StoreNotificationThread(channelID, organization string, workItemID int, rootPostID string) error {
+	return s.StoreTTL(GetNotificationThreadKey(channelID, organization, workItemID), []byte(rootPostID), constants.TTLSecondsForNotificationThread)
+}
+
+// GetNotificationThread returns the root post of a work item's notifications in a channel, it's empty if there is no thread
+func (s *Store) GetNotificationThread(channelID, organization string, workItemID int) (string, error) {
+	rootPostID, err := s.Load(GetNotificationThreadKey(channelID, organization, workItemID))
+	if err != nil {
+		return "", err
+	}
+
+	return string(rootPostID), nil
+}
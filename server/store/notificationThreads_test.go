@@ -0,0 +1,92 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+func TestStoreNotificationThread(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "StoreNotificationThread: thread is stored successfully",
+		},
+		{
+			description: "StoreNotificationThread: thread is not stored successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "StoreTTL", func(_ *Store, key string, data []byte, ttlSeconds int64) error {
+				assert.Equal(t, GetNotificationThreadKey("mockChannelID", "mockOrganization", 1), key)
+				assert.Equal(t, "mockPostID", string(data))
+				assert.Equal(t, constants.TTLSecondsForNotificationThread, ttlSeconds)
+				return testCase.err
+			})
+
+			err := s.StoreNotificationThread("mockChannelID", "mockOrganization", 1, "mockPostID")
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetNotificationThread(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description        string
+		data               []byte
+		err                error
+		expectedRootPostID string
+	}{
+		{
+			description:        "GetNotificationThread: thread is fetched",
+			data:               []byte("mockPostID"),
+			expectedRootPostID: "mockPostID",
+		},
+		{
+			description: "GetNotificationThread: no thread is stored",
+		},
+		{
+			description: "GetNotificationThread: 'Load' gives error",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(*Store, string) ([]byte, error) {
+				return testCase.data, testCase.err
+			})
+
+			rootPostID, err := s.GetNotificationThread("mockChannelID", "mockOrganization", 1)
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedRootPostID, rootPostID)
+		})
+	}
+}
+
+func TestGetNotificationThreadKey(t *testing.T) {
+	assert.Equal(t, GetNotificationThreadKey("mockChannelID", "MockOrganization", 1), GetNotificationThreadKey("mockChannelID", "mockorganization", 1))
+	assert.NotEqual(t, GetNotificationThreadKey("mockChannelID", "mockOrganization", 1), GetNotificationThreadKey("mockChannelID", "mockOtherOrganization", 1))
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
@@ -9,6 +10,8 @@ import (
 type OAuthStore interface {
 	StoreOAuthState(mattermostUserID, state string) error
 	VerifyOAuthState(mattermostUserID, state string) error
+	StartDeviceCodeFlow(mattermostUserID string) (bool, error)
+	DeleteDeviceCodeFlow(mattermostUserID string) error
 }
 
 func (s *Store) StoreOAuthState(mattermostUserID, state string) error {
@@ -31,3 +34,17 @@ func (s *Store) VerifyOAuthState(mattermostUserID, state string) error {
 	}
 	return nil
 }
+
+// StartDeviceCodeFlow marks the device code flow of a user as started, it returns false if the user has already started one.
+// The mark expires along with the device code in case the flow is not completed, e.g. if the plugin is restarted.
+func (s *Store) StartDeviceCodeFlow(mattermostUserID string) (bool, error) {
+	return s.StoreWithOptions(GetDeviceCodeFlowKey(mattermostUserID), []byte(mattermostUserID), model.PluginKVSetOptions{
+		Atomic:          true,
+		OldValue:        nil,
+		ExpireInSeconds: constants.TTLSecondsForDeviceCodeFlow,
+	})
+}
+
+func (s *Store) DeleteDeviceCodeFlow(mattermostUserID string) error {
+	return s.Delete(GetDeviceCodeFlowKey(mattermostUserID))
+}
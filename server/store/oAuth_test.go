@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -82,3 +83,44 @@ func TestVerifyOAuthState(t *testing.T) {
 		})
 	}
 }
+
+func TestStartDeviceCodeFlow(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		isStored    bool
+		err         error
+	}{
+		{
+			description: "StartDeviceCodeFlow: flow is started successfully",
+			isStored:    true,
+		},
+		{
+			description: "StartDeviceCodeFlow: flow is already in progress",
+		},
+		{
+			description: "StartDeviceCodeFlow: error in storing the flow",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "StoreWithOptions", func(_ *Store, key string, _ []byte, opts model.PluginKVSetOptions) (bool, error) {
+				assert.Equal(t, "device_code_flow_mockMattermostUserID", key)
+				assert.True(t, opts.Atomic)
+				assert.Nil(t, opts.OldValue)
+				return testCase.isStored, testCase.err
+			})
+
+			isStarted, err := s.StartDeviceCodeFlow("mockMattermostUserID")
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.isStored, isStarted)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+var ErrPendingWebhookDeletionsFull = errors.New("too many webhook deletions are pending")
+
+type PendingWebhookDeletionStore interface {
+	AddPendingWebhookDeletion(deletion *serializers.PendingWebhookDeletion) error
+	GetPendingWebhookDeletions() ([]*serializers.PendingWebhookDeletion, error)
+	RemovePendingWebhookDeletion(subscriptionID string) (bool, error)
+}
+
+// PendingWebhookDeletions contains the webhooks waiting for the end of their grace period mapped by the IDs of their subscriptions
+type PendingWebhookDeletions map[string]*serializers.PendingWebhookDeletion
+
+func addPendingWebhookDeletionAtomicModify(deletion *serializers.PendingWebhookDeletion, initialBytes []byte) ([]byte, error) {
+	pendingDeletions, err := PendingWebhookDeletionsFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pendingDeletions) >= constants.PendingWebhookDeletionsMaxSize {
+		return nil, ErrPendingWebhookDeletionsFull
+	}
+
+	pendingDeletions[deletion.Subscription.SubscriptionID] = deletion
+	modifiedBytes, marshalErr := json.Marshal(pendingDeletions)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) AddPendingWebhookDeletion(deletion *serializers.PendingWebhookDeletion) error {
+	if err := s.AtomicModify(GetPendingWebhookDeletionsKey(), func(initialBytes []byte) ([]byte, error) {
+		return addPendingWebhookDeletionAtomicModify(deletion, initialBytes)
+	}); err != nil {
+		if errors.Cause(err) == ErrPendingWebhookDeletionsFull {
+			return ErrPendingWebhookDeletionsFull
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) GetPendingWebhookDeletions() ([]*serializers.PendingWebhookDeletion, error) {
+	initialBytes, err := s.Load(GetPendingWebhookDeletionsKey())
+	if err != nil {
+		return nil, err
+	}
+
+	pendingDeletions, err := PendingWebhookDeletionsFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := []*serializers.PendingWebhookDeletion{}
+	for _, deletion := range pendingDeletions {
+		deletions = append(deletions, deletion)
+	}
+
+	sort.Slice(deletions, func(i, j int) bool {
+		return deletions[i].DeleteAt < deletions[j].DeleteAt
+	})
+
+	return deletions, nil
+}
+
+// removePendingWebhookDeletionAtomicModify also returns whether the deletion was pending,
+// so only one of the job deleting the webhook and a subscription reusing it can claim it
+func removePendingWebhookDeletionAtomicModify(subscriptionID string, initialBytes []byte) ([]byte, bool, error) {
+	pendingDeletions, err := PendingWebhookDeletionsFromJSON(initialBytes)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, ok := pendingDeletions[subscriptionID]; !ok {
+		return initialBytes, false, nil
+	}
+
+	delete(pendingDeletions, subscriptionID)
+	modifiedBytes, marshalErr := json.Marshal(pendingDeletions)
+	if marshalErr != nil {
+		return nil, false, marshalErr
+	}
+	return modifiedBytes, true, nil
+}
+
+func (s *Store) RemovePendingWebhookDeletion(subscriptionID string) (bool, error) {
+	var removed bool
+	err := s.AtomicModify(GetPendingWebhookDeletionsKey(), func(initialBytes []byte) ([]byte, error) {
+		modifiedBytes, isRemoved, err := removePendingWebhookDeletionAtomicModify(subscriptionID, initialBytes)
+		removed = isRemoved
+		return modifiedBytes, err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return removed, nil
+}
+
+func PendingWebhookDeletionsFromJSON(bytes []byte) (PendingWebhookDeletions, error) {
+	pendingDeletions := PendingWebhookDeletions{}
+	if len(bytes) != 0 {
+		if unmarshalErr := json.Unmarshal(bytes, &pendingDeletions); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+	}
+	return pendingDeletions, nil
+}
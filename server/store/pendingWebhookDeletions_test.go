@@ -0,0 +1,96 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestAddPendingWebhookDeletionAtomicModify(t *testing.T) {
+	fullDeletions := PendingWebhookDeletions{}
+	for i := 0; i < constants.PendingWebhookDeletionsMaxSize; i++ {
+		fullDeletions[fmt.Sprintf("mockSubscriptionID%d", i)] = &serializers.PendingWebhookDeletion{}
+	}
+	fullDeletionsBytes, err := json.Marshal(fullDeletions)
+	assert.Nil(t, err)
+
+	for _, testCase := range []struct {
+		description   string
+		initialBytes  []byte
+		expectedCount int
+		expectedError error
+	}{
+		{
+			description:   "AddPendingWebhookDeletionAtomicModify: deletion is added to the empty list",
+			expectedCount: 1,
+		},
+		{
+			description:   "AddPendingWebhookDeletionAtomicModify: deletion is added to the existing deletions",
+			initialBytes:  []byte(`{"mockOtherSubscriptionID":{"webhookSecret":"mockOtherSecret"}}`),
+			expectedCount: 2,
+		},
+		{
+			description:   "AddPendingWebhookDeletionAtomicModify: too many deletions are pending",
+			initialBytes:  fullDeletionsBytes,
+			expectedError: ErrPendingWebhookDeletionsFull,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			modifiedBytes, err := addPendingWebhookDeletionAtomicModify(&serializers.PendingWebhookDeletion{
+				Subscription: &serializers.SubscriptionDetails{SubscriptionID: "mockSubscriptionID"},
+			}, testCase.initialBytes)
+
+			if testCase.expectedError != nil {
+				assert.Nil(t, modifiedBytes)
+				assert.Equal(t, testCase.expectedError, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			pendingDeletions, err := PendingWebhookDeletionsFromJSON(modifiedBytes)
+			assert.Nil(t, err)
+			assert.Len(t, pendingDeletions, testCase.expectedCount)
+			assert.NotNil(t, pendingDeletions["mockSubscriptionID"])
+		})
+	}
+}
+
+func TestRemovePendingWebhookDeletionAtomicModify(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		initialBytes    []byte
+		expectedRemoved bool
+		expectedCount   int
+	}{
+		{
+			description:     "RemovePendingWebhookDeletionAtomicModify: deletion is pending",
+			initialBytes:    []byte(`{"mockSubscriptionID":{"webhookSecret":"mockSecret"},"mockOtherSubscriptionID":{"webhookSecret":"mockOtherSecret"}}`),
+			expectedRemoved: true,
+			expectedCount:   1,
+		},
+		{
+			description:   "RemovePendingWebhookDeletionAtomicModify: deletion is already removed",
+			initialBytes:  []byte(`{"mockOtherSubscriptionID":{"webhookSecret":"mockOtherSecret"}}`),
+			expectedCount: 1,
+		},
+		{
+			description: "RemovePendingWebhookDeletionAtomicModify: no deletion is pending",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			modifiedBytes, removed, err := removePendingWebhookDeletionAtomicModify("mockSubscriptionID", testCase.initialBytes)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedRemoved, removed)
+			pendingDeletions, err := PendingWebhookDeletionsFromJSON(modifiedBytes)
+			assert.Nil(t, err)
+			assert.Len(t, pendingDeletions, testCase.expectedCount)
+			assert.Nil(t, pendingDeletions["mockSubscriptionID"])
+		})
+	}
+}
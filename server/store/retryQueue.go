@@ -0,0 +1,132 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+var ErrRetryQueueFull = errors.New("retry queue is full")
+
+type RetryQueueStore interface {
+	AddRetryOperation(operation *serializers.RetryOperation) error
+	GetRetryOperations() ([]*serializers.RetryOperation, error)
+	UpdateRetryOperation(operation *serializers.RetryOperation) error
+	DeleteRetryOperation(operationID string) error
+}
+
+// RetryQueue contains the operations to be retried mapped by their IDs
+type RetryQueue map[string]*serializers.RetryOperation
+
+func addRetryOperationAtomicModify(operation *serializers.RetryOperation, initialBytes []byte) ([]byte, error) {
+	retryQueue, err := RetryQueueFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(retryQueue) >= constants.RetryQueueMaxSize {
+		return nil, ErrRetryQueueFull
+	}
+
+	retryQueue[operation.ID] = operation
+	modifiedBytes, marshalErr := json.Marshal(retryQueue)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) AddRetryOperation(operation *serializers.RetryOperation) error {
+	if err := s.AtomicModify(GetRetryQueueKey(), func(initialBytes []byte) ([]byte, error) {
+		return addRetryOperationAtomicModify(operation, initialBytes)
+	}); err != nil {
+		if errors.Cause(err) == ErrRetryQueueFull {
+			return ErrRetryQueueFull
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) GetRetryOperations() ([]*serializers.RetryOperation, error) {
+	initialBytes, err := s.Load(GetRetryQueueKey())
+	if err != nil {
+		return nil, err
+	}
+
+	retryQueue, err := RetryQueueFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []*serializers.RetryOperation{}
+	for _, operation := range retryQueue {
+		operations = append(operations, operation)
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].CreatedAt < operations[j].CreatedAt
+	})
+
+	return operations, nil
+}
+
+func updateRetryOperationAtomicModify(operation *serializers.RetryOperation, initialBytes []byte) ([]byte, error) {
+	retryQueue, err := RetryQueueFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The operation is not added again if it's removed from the queue in the meantime
+	if _, ok := retryQueue[operation.ID]; !ok {
+		return initialBytes, nil
+	}
+
+	retryQueue[operation.ID] = operation
+	modifiedBytes, marshalErr := json.Marshal(retryQueue)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) UpdateRetryOperation(operation *serializers.RetryOperation) error {
+	return s.AtomicModify(GetRetryQueueKey(), func(initialBytes []byte) ([]byte, error) {
+		return updateRetryOperationAtomicModify(operation, initialBytes)
+	})
+}
+
+func deleteRetryOperationAtomicModify(operationID string, initialBytes []byte) ([]byte, error) {
+	retryQueue, err := RetryQueueFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(retryQueue, operationID)
+	modifiedBytes, marshalErr := json.Marshal(retryQueue)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) DeleteRetryOperation(operationID string) error {
+	return s.AtomicModify(GetRetryQueueKey(), func(initialBytes []byte) ([]byte, error) {
+		return deleteRetryOperationAtomicModify(operationID, initialBytes)
+	})
+}
+
+func RetryQueueFromJSON(bytes []byte) (RetryQueue, error) {
+	retryQueue := RetryQueue{}
+	if len(bytes) != 0 {
+		if unmarshalErr := json.Unmarshal(bytes, &retryQueue); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+	}
+	return retryQueue, nil
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestAddRetryOperationAtomicModify(t *testing.T) {
+	fullQueue := RetryQueue{}
+	for i := 0; i < constants.RetryQueueMaxSize; i++ {
+		fullQueue[fmt.Sprintf("mockOperationID%d", i)] = &serializers.RetryOperation{}
+	}
+	fullQueueBytes, err := json.Marshal(fullQueue)
+	assert.Nil(t, err)
+
+	for _, testCase := range []struct {
+		description   string
+		initialBytes  []byte
+		expectedCount int
+		expectedError error
+	}{
+		{
+			description:   "AddRetryOperationAtomicModify: operation is added to the empty queue",
+			expectedCount: 1,
+		},
+		{
+			description:   "AddRetryOperationAtomicModify: operation is added to the existing operations",
+			initialBytes:  []byte(`{"mockOtherOperationID":{"id":"mockOtherOperationID"}}`),
+			expectedCount: 2,
+		},
+		{
+			description:   "AddRetryOperationAtomicModify: retry queue is full",
+			initialBytes:  fullQueueBytes,
+			expectedError: ErrRetryQueueFull,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			modifiedBytes, err := addRetryOperationAtomicModify(&serializers.RetryOperation{ID: "mockOperationID"}, testCase.initialBytes)
+
+			if testCase.expectedError != nil {
+				assert.Nil(t, modifiedBytes)
+				assert.Equal(t, testCase.expectedError, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			retryQueue, err := RetryQueueFromJSON(modifiedBytes)
+			assert.Nil(t, err)
+			assert.Len(t, retryQueue, testCase.expectedCount)
+			assert.NotNil(t, retryQueue["mockOperationID"])
+		})
+	}
+}
+
+func TestUpdateRetryOperationAtomicModify(t *testing.T) {
+	modifiedBytes, err := updateRetryOperationAtomicModify(&serializers.RetryOperation{ID: "mockOperationID", Attempts: 2}, []byte(`{"mockOperationID":{"id":"mockOperationID","attempts":1}}`))
+	assert.Nil(t, err)
+
+	retryQueue, err := RetryQueueFromJSON(modifiedBytes)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, retryQueue["mockOperationID"].Attempts)
+
+	modifiedBytes, err = updateRetryOperationAtomicModify(&serializers.RetryOperation{ID: "mockDeletedOperationID"}, []byte(`{}`))
+	assert.Nil(t, err)
+
+	retryQueue, err = RetryQueueFromJSON(modifiedBytes)
+	assert.Nil(t, err)
+	assert.Empty(t, retryQueue)
+}
+
+func TestGetRetryOperations(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		data        []byte
+		err         error
+		expectedIDs []string
+	}{
+		{
+			description: "GetRetryOperations: operations are fetched and sorted by creation time",
+			data:        []byte(`{"mockOperationB":{"id":"mockOperationB","createdAt":2},"mockOperationA":{"id":"mockOperationA","createdAt":1}}`),
+			expectedIDs: []string{"mockOperationA", "mockOperationB"},
+		},
+		{
+			description: "GetRetryOperations: retry queue is empty",
+			expectedIDs: []string{},
+		},
+		{
+			description: "GetRetryOperations: 'Load' gives error",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(*Store, string) ([]byte, error) {
+				return testCase.data, testCase.err
+			})
+
+			operations, err := s.GetRetryOperations()
+
+			if testCase.err != nil {
+				assert.Nil(t, operations)
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			IDs := []string{}
+			for _, operation := range operations {
+				IDs = append(IDs, operation.ID)
+			}
+			assert.Equal(t, testCase.expectedIDs, IDs)
+		})
+	}
+}
+
+func TestDeleteRetryOperationAtomicModify(t *testing.T) {
+	modifiedBytes, err := deleteRetryOperationAtomicModify("mockOperationID", []byte(`{"mockOperationID":{"id":"mockOperationID"},"mockOtherOperationID":{"id":"mockOtherOperationID"}}`))
+	assert.Nil(t, err)
+
+	retryQueue, err := RetryQueueFromJSON(modifiedBytes)
+	assert.Nil(t, err)
+	assert.Len(t, retryQueue, 1)
+	assert.NotNil(t, retryQueue["mockOtherOperationID"])
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+type ScheduledJobStore interface {
+	LockJob(jobKey string) (bool, error)
+	UnlockJob(jobKey string) error
+	StoreJobLastFinished(jobKey string, lastFinished time.Time) error
+	GetJobLastFinished(jobKey string) (time.Time, error)
+}
+
+// LockJob locks a scheduled job so that the other servers of the cluster don't run it at the same time, it returns false if the job is already locked.
+// The lock expires in case the server holding it stops without unlocking it.
+func (s *Store) LockJob(jobKey string) (bool, error) {
+	return s.StoreWithOptions(GetJobLockKey(jobKey), []byte(strconv.FormatInt(time.Now().Unix(), 10)), model.PluginKVSetOptions{
+		Atomic:          true,
+		OldValue:        nil,
+		ExpireInSeconds: constants.TTLSecondsForJobLock,
+	})
+}
+
+func (s *Store) UnlockJob(jobKey string) error {
+	return s.Delete(GetJobLockKey(jobKey))
+}
+
+func (s *Store) StoreJobLastFinished(jobKey string, lastFinished time.Time) error {
+	return s.Store(GetJobLastFinishedKey(jobKey), []byte(strconv.FormatInt(lastFinished.Unix(), 10)))
+}
+
+// GetJobLastFinished returns the time a scheduled job last finished on any server of the cluster, it's zero if the job never ran
+func (s *Store) GetJobLastFinished(jobKey string) (time.Time, error) {
+	lastFinishedBytes, err := s.Load(GetJobLastFinishedKey(jobKey))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(lastFinishedBytes) == 0 {
+		return time.Time{}, nil
+	}
+
+	lastFinished, err := strconv.ParseInt(string(lastFinishedBytes), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(lastFinished, 0), nil
+}
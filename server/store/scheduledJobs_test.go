@@ -0,0 +1,107 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+func TestLockJob(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description    string
+		isSet          bool
+		err            error
+		expectedLocked bool
+	}{
+		{
+			description:    "LockJob: job is locked",
+			isSet:          true,
+			expectedLocked: true,
+		},
+		{
+			description: "LockJob: job is already locked",
+		},
+		{
+			description: "LockJob: job is not locked successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "StoreWithOptions", func(_ *Store, key string, value []byte, opts model.PluginKVSetOptions) (bool, error) {
+				assert.Equal(t, GetJobLockKey("mockJob"), key)
+				// The lock is only set if no other server holds it
+				assert.True(t, opts.Atomic)
+				assert.Nil(t, opts.OldValue)
+				assert.Equal(t, constants.TTLSecondsForJobLock, opts.ExpireInSeconds)
+				return testCase.isSet, testCase.err
+			})
+
+			isLocked, err := s.LockJob("mockJob")
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, testCase.expectedLocked, isLocked)
+		})
+	}
+}
+
+func TestGetJobLastFinished(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description          string
+		data                 []byte
+		err                  error
+		expectedLastFinished time.Time
+		expectedError        bool
+	}{
+		{
+			description:          "GetJobLastFinished: last run is fetched",
+			data:                 []byte("1791374400"),
+			expectedLastFinished: time.Unix(1791374400, 0),
+		},
+		{
+			description: "GetJobLastFinished: job never ran",
+		},
+		{
+			description:   "GetJobLastFinished: invalid time",
+			data:          []byte("mockTime"),
+			expectedError: true,
+		},
+		{
+			description:   "GetJobLastFinished: last run is not fetched successfully",
+			err:           errors.New("mockError"),
+			expectedError: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(_ *Store, key string) ([]byte, error) {
+				assert.Equal(t, GetJobLastFinishedKey("mockJob"), key)
+				return testCase.data, testCase.err
+			})
+
+			lastFinished, err := s.GetJobLastFinished("mockJob")
+
+			if testCase.expectedError {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.True(t, testCase.expectedLastFinished.Equal(lastFinished))
+		})
+	}
+}
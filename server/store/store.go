@@ -13,6 +13,19 @@ type KVStore interface {
 	UserStore
 	LinkStore
 	SubscriptionStore
+	SubscriptionTemplateStore
+	RetryQueueStore
+	ChannelPrefsStore
+	NotificationThreadStore
+	NotificationBurstStore
+	CoalescedPostStore
+	LastNotificationStore
+	WeeklySummaryStore
+	DeliveryLogStore
+	PendingWebhookDeletionStore
+	SubscriptionsPauseStore
+	TaskPostStore
+	ScheduledJobStore
 	DeleteUserTokenOnEncryptionSecretChange() error
 }
 
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+type SubscriptionTemplateStore interface {
+	StoreSubscriptionTemplate(template *serializers.SubscriptionTemplate) error
+	GetSubscriptionTemplates(mattermostUserID string) ([]*serializers.SubscriptionTemplate, error)
+	DeleteSubscriptionTemplate(mattermostUserID, name string) error
+}
+
+// SubscriptionTemplateMap contains the subscription templates of a user mapped by their names
+type SubscriptionTemplateMap map[string]*serializers.SubscriptionTemplate
+
+func storeSubscriptionTemplateAtomicModify(template *serializers.SubscriptionTemplate, initialBytes []byte) ([]byte, error) {
+	templates, err := SubscriptionTemplateMapFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	templates[template.Name] = template
+	modifiedBytes, marshalErr := json.Marshal(templates)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) StoreSubscriptionTemplate(template *serializers.SubscriptionTemplate) error {
+	key := GetSubscriptionTemplateKey(template.MattermostUserID)
+	if err := s.AtomicModify(key, func(initialBytes []byte) ([]byte, error) {
+		return storeSubscriptionTemplateAtomicModify(template, initialBytes)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) GetSubscriptionTemplates(mattermostUserID string) ([]*serializers.SubscriptionTemplate, error) {
+	initialBytes, err := s.Load(GetSubscriptionTemplateKey(mattermostUserID))
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := SubscriptionTemplateMapFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	templateList := []*serializers.SubscriptionTemplate{}
+	for _, template := range templates {
+		templateList = append(templateList, template)
+	}
+
+	sort.Slice(templateList, func(i, j int) bool {
+		return templateList[i].Name < templateList[j].Name
+	})
+
+	return templateList, nil
+}
+
+func deleteSubscriptionTemplateAtomicModify(name string, initialBytes []byte) ([]byte, error) {
+	templates, err := SubscriptionTemplateMapFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(templates, name)
+	modifiedBytes, marshalErr := json.Marshal(templates)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) DeleteSubscriptionTemplate(mattermostUserID, name string) error {
+	key := GetSubscriptionTemplateKey(mattermostUserID)
+	if err := s.AtomicModify(key, func(initialBytes []byte) ([]byte, error) {
+		return deleteSubscriptionTemplateAtomicModify(name, initialBytes)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func SubscriptionTemplateMapFromJSON(bytes []byte) (SubscriptionTemplateMap, error) {
+	templates := SubscriptionTemplateMap{}
+	if len(bytes) != 0 {
+		if unmarshalErr := json.Unmarshal(bytes, &templates); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+	}
+	return templates, nil
+}
@@ -0,0 +1,148 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestStoreSubscriptionTemplateAtomicModify(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		initialBytes  []byte
+		expectedCount int
+		expectedError bool
+	}{
+		{
+			description:   "StoreSubscriptionTemplateAtomicModify: template is added to the empty map",
+			expectedCount: 1,
+		},
+		{
+			description:   "StoreSubscriptionTemplateAtomicModify: template with the same name is replaced",
+			initialBytes:  []byte(`{"mockTemplate":{"name":"mockTemplate","events":[{"eventType":"workitem.created"}]}}`),
+			expectedCount: 1,
+		},
+		{
+			description:   "StoreSubscriptionTemplateAtomicModify: template is added to the existing templates",
+			initialBytes:  []byte(`{"mockOtherTemplate":{"name":"mockOtherTemplate"}}`),
+			expectedCount: 2,
+		},
+		{
+			description:   "StoreSubscriptionTemplateAtomicModify: invalid initial value",
+			initialBytes:  []byte(`mockInvalidJSON`),
+			expectedError: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			modifiedBytes, err := storeSubscriptionTemplateAtomicModify(&serializers.SubscriptionTemplate{
+				Name:   "mockTemplate",
+				Events: []*serializers.SubscriptionTemplateEvent{{EventType: "git.push"}},
+			}, testCase.initialBytes)
+
+			if testCase.expectedError {
+				assert.Nil(t, modifiedBytes)
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			templates, err := SubscriptionTemplateMapFromJSON(modifiedBytes)
+			assert.Nil(t, err)
+			assert.Len(t, templates, testCase.expectedCount)
+			assert.Equal(t, "git.push", templates["mockTemplate"].Events[0].EventType)
+		})
+	}
+}
+
+func TestStoreSubscriptionTemplate(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "StoreSubscriptionTemplate: template is stored successfully",
+		},
+		{
+			description: "StoreSubscriptionTemplate: template is not stored successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "AtomicModify", func(*Store, string, func([]byte) ([]byte, error)) error {
+				return testCase.err
+			})
+
+			err := s.StoreSubscriptionTemplate(&serializers.SubscriptionTemplate{})
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetSubscriptionTemplates(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description   string
+		data          []byte
+		err           error
+		expectedNames []string
+	}{
+		{
+			description:   "GetSubscriptionTemplates: templates are fetched and sorted by name",
+			data:          []byte(`{"mockTemplateB":{"name":"mockTemplateB"},"mockTemplateA":{"name":"mockTemplateA"}}`),
+			expectedNames: []string{"mockTemplateA", "mockTemplateB"},
+		},
+		{
+			description:   "GetSubscriptionTemplates: no templates are stored",
+			expectedNames: []string{},
+		},
+		{
+			description: "GetSubscriptionTemplates: 'Load' gives error",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(*Store, string) ([]byte, error) {
+				return testCase.data, testCase.err
+			})
+
+			templates, err := s.GetSubscriptionTemplates("mockMattermostUserID")
+
+			if testCase.err != nil {
+				assert.Nil(t, templates)
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			names := []string{}
+			for _, template := range templates {
+				names = append(names, template.Name)
+			}
+			assert.Equal(t, testCase.expectedNames, names)
+		})
+	}
+}
+
+func TestDeleteSubscriptionTemplateAtomicModify(t *testing.T) {
+	modifiedBytes, err := deleteSubscriptionTemplateAtomicModify("mockTemplate", []byte(`{"mockTemplate":{"name":"mockTemplate"},"mockOtherTemplate":{"name":"mockOtherTemplate"}}`))
+	assert.Nil(t, err)
+
+	templates, err := SubscriptionTemplateMapFromJSON(modifiedBytes)
+	assert.Nil(t, err)
+	assert.Len(t, templates, 1)
+	assert.NotNil(t, templates["mockOtherTemplate"])
+}
@@ -2,7 +2,6 @@ package store
 
 import (
 	"encoding/json"
-	"time"
 
 	"github.com/pkg/errors"
 
@@ -15,6 +14,7 @@ type SubscriptionStore interface {
 	GetSubscriptionList() (*SubscriptionList, error)
 	GetAllSubscriptions(userID string) ([]*serializers.SubscriptionDetails, error)
 	DeleteSubscription(subscription *serializers.SubscriptionDetails) error
+	RepointSubscriptions(userID string, projects []serializers.ProjectDetails) (int, error)
 	StoreSubscriptionAndChannelIDMap(subscriptionID, webhookSecret, channelID string) error
 	GetSubscriptionAndChannelIDMap(subscriptionID string) (*SubscriptionWebhookSecretAndChannelMap, error)
 	DeleteSubscriptionAndChannelIDMap(subscriptionID string) error
@@ -76,7 +76,8 @@ func (subscriptionList *SubscriptionList) AddSubscription(userID string, subscri
 		ChannelName:                      subscription.ChannelName,
 		ChannelType:                      subscription.ChannelType,
 		CreatedBy:                        subscription.CreatedBy,
-		CreatedAt:                        time.Now().UTC(),
+		CreatedByUsername:                subscription.CreatedByUsername,
+		CreatedAt:                        subscription.CreatedAt,
 		Repository:                       subscription.Repository,
 		TargetBranch:                     subscription.TargetBranch,
 		RepositoryName:                   subscription.RepositoryName,
@@ -91,6 +92,7 @@ func (subscriptionList *SubscriptionList) AddSubscription(userID string, subscri
 		NotificationType:                 subscription.NotificationType,
 		NotificationTypeName:             subscription.NotificationTypeName,
 		AreaPath:                         subscription.AreaPath,
+		WorkItemType:                     subscription.WorkItemType,
 		BuildStatus:                      subscription.BuildStatus,
 		BuildPipeline:                    subscription.BuildPipeline,
 		StageName:                        subscription.StageName,
@@ -115,6 +117,18 @@ func (subscriptionList *SubscriptionList) AddSubscription(userID string, subscri
 		RunStateID:                       subscription.RunStateID,
 		RunStateIDName:                   subscription.RunStateIDName,
 		RunResultID:                      subscription.RunResultID,
+		KeepRawHTML:                      subscription.KeepRawHTML,
+		Label:                            subscription.Label,
+		Truncation:                       subscription.Truncation,
+		BranchFilters:                    subscription.BranchFilters,
+		PullRequestTargetBranches:        subscription.PullRequestTargetBranches,
+		BuildResults:                     subscription.BuildResults,
+		SkipBuildsWithoutResult:          subscription.SkipBuildsWithoutResult,
+		ShowLinkedWorkItems:              subscription.ShowLinkedWorkItems,
+		ExcludeServiceAccounts:           subscription.ExcludeServiceAccounts,
+		Visibility:                       subscription.Visibility,
+		ResourceVersion:                  subscription.ResourceVersion,
+		MessageFormat:                    subscription.MessageFormat,
 	}
 	subscriptionList.ByMattermostUserID[userID][subscription.SubscriptionID] = subscriptionListValue
 }
@@ -191,6 +205,58 @@ func (subscriptionList *SubscriptionList) DeleteSubscriptionByKey(userID, subscr
 	}
 }
 
+func repointSubscriptionsAtomicModify(userID string, projects []serializers.ProjectDetails, initialBytes []byte, repointedCount *int) ([]byte, error) {
+	subscriptionList, err := SubscriptionListFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	*repointedCount = subscriptionList.RepointSubscriptions(userID, projects)
+	modifiedBytes, marshalErr := json.Marshal(subscriptionList)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+// RepointSubscriptions makes the subscriptions of a user refer to the given linked projects and returns the number of subscriptions changed
+func (s *Store) RepointSubscriptions(userID string, projects []serializers.ProjectDetails) (int, error) {
+	repointedCount := 0
+	key := GetSubscriptionListMapKey()
+	if err := s.AtomicModify(key, func(initialBytes []byte) ([]byte, error) {
+		return repointSubscriptionsAtomicModify(userID, projects, initialBytes, &repointedCount)
+	}); err != nil {
+		return 0, err
+	}
+
+	return repointedCount, nil
+}
+
+// RepointSubscriptions copies the organization, ID and name of the linked projects to the subscriptions of a user for the same projects.
+// The projects are matched the same way as the duplicate projects are, so a subscription of a merged duplicate refers to the kept project afterwards.
+func (subscriptionList *SubscriptionList) RepointSubscriptions(userID string, projects []serializers.ProjectDetails) int {
+	projectsByKey := map[string]serializers.ProjectDetails{}
+	for _, project := range projects {
+		projectsByKey[GetNormalizedProjectKey(project.OrganizationName, project.ProjectID)] = project
+	}
+
+	repointedCount := 0
+	for key, subscription := range subscriptionList.ByMattermostUserID[userID] {
+		project, found := projectsByKey[GetNormalizedProjectKey(subscription.OrganizationName, subscription.ProjectID)]
+		if !found || (subscription.OrganizationName == project.OrganizationName && subscription.ProjectID == project.ProjectID && subscription.ProjectName == project.ProjectName) {
+			continue
+		}
+
+		subscription.OrganizationName = project.OrganizationName
+		subscription.ProjectID = project.ProjectID
+		subscription.ProjectName = project.ProjectName
+		subscriptionList.ByMattermostUserID[userID][key] = subscription
+		repointedCount++
+	}
+
+	return repointedCount
+}
+
 func SubscriptionListFromJSON(bytes []byte) (*SubscriptionList, error) {
 	var subscriptionList *SubscriptionList
 	if len(bytes) != 0 {
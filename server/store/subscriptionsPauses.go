@@ -0,0 +1,43 @@
+package store
+
+import (
+	"strconv"
+	"time"
+)
+
+type SubscriptionsPauseStore interface {
+	StoreSubscriptionsPause(mattermostUserID string, pausedUntil time.Time) error
+	GetSubscriptionsPause(mattermostUserID string) (time.Time, error)
+	DeleteSubscriptionsPause(mattermostUserID string) error
+}
+
+// StoreSubscriptionsPause pauses the notifications of the subscriptions created by a user until the given time.
+// The pause expires along with its key, so the notifications are resumed without any job.
+func (s *Store) StoreSubscriptionsPause(mattermostUserID string, pausedUntil time.Time) error {
+	ttlSeconds := int64(time.Until(pausedUntil).Seconds()) + 1
+	return s.StoreTTL(GetSubscriptionsPauseKey(mattermostUserID), []byte(strconv.FormatInt(pausedUntil.Unix(), 10)), ttlSeconds)
+}
+
+// GetSubscriptionsPause returns the time until which the notifications of the subscriptions created by a user are paused,
+// it's zero if they are not paused
+func (s *Store) GetSubscriptionsPause(mattermostUserID string) (time.Time, error) {
+	pausedUntilBytes, err := s.Load(GetSubscriptionsPauseKey(mattermostUserID))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(pausedUntilBytes) == 0 {
+		return time.Time{}, nil
+	}
+
+	pausedUntil, err := strconv.ParseInt(string(pausedUntilBytes), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(pausedUntil, 0), nil
+}
+
+func (s *Store) DeleteSubscriptionsPause(mattermostUserID string) error {
+	return s.Delete(GetSubscriptionsPauseKey(mattermostUserID))
+}
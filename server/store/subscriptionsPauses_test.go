@@ -0,0 +1,97 @@
+package store
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreSubscriptionsPause(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	pausedUntil := time.Now().Add(time.Hour)
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "StoreSubscriptionsPause: pause is stored successfully",
+		},
+		{
+			description: "StoreSubscriptionsPause: pause is not stored successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "StoreTTL", func(_ *Store, key string, data []byte, ttlSeconds int64) error {
+				assert.Equal(t, GetSubscriptionsPauseKey("mockMattermostUserID"), key)
+				assert.Equal(t, strconv.FormatInt(pausedUntil.Unix(), 10), string(data))
+				// The key expires once the pause is over
+				assert.InDelta(t, time.Hour.Seconds(), ttlSeconds, 2)
+				return testCase.err
+			})
+
+			err := s.StoreSubscriptionsPause("mockMattermostUserID", pausedUntil)
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetSubscriptionsPause(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description         string
+		data                []byte
+		err                 error
+		expectedPausedUntil time.Time
+		expectedError       bool
+	}{
+		{
+			description:         "GetSubscriptionsPause: pause is fetched",
+			data:                []byte("1791374400"),
+			expectedPausedUntil: time.Unix(1791374400, 0),
+		},
+		{
+			description: "GetSubscriptionsPause: subscriptions are not paused",
+		},
+		{
+			description:   "GetSubscriptionsPause: invalid pause",
+			data:          []byte("mockPause"),
+			expectedError: true,
+		},
+		{
+			description:   "GetSubscriptionsPause: pause is not fetched successfully",
+			err:           errors.New("mockError"),
+			expectedError: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(_ *Store, key string) ([]byte, error) {
+				assert.Equal(t, GetSubscriptionsPauseKey("mockMattermostUserID"), key)
+				return testCase.data, testCase.err
+			})
+
+			pausedUntil, err := s.GetSubscriptionsPause("mockMattermostUserID")
+
+			if testCase.expectedError {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.True(t, testCase.expectedPausedUntil.Equal(pausedUntil))
+		})
+	}
+}
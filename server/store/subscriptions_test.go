@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	"bou.ke/monkey"
 	"github.com/pkg/errors"
@@ -139,6 +140,31 @@ func TestAddSubscription(t *testing.T) {
 			})
 		})
 	}
+
+	t.Run("AddSubscription: resource version and message format are kept", func(t *testing.T) {
+		subscriptionList.AddSubscription("mockMattermostUserId", &serializers.SubscriptionDetails{
+			SubscriptionID:  "mockSubscriptionID",
+			ResourceVersion: "5.1-preview.1",
+			MessageFormat:   "html",
+		})
+
+		subscription := subscriptionList.ByMattermostUserID["mockMattermostUserId"]["mockSubscriptionID"]
+		assert.Equal(t, "5.1-preview.1", subscription.ResourceVersion)
+		assert.Equal(t, "html", subscription.MessageFormat)
+	})
+
+	t.Run("AddSubscription: creation time and username of the creator are kept", func(t *testing.T) {
+		createdAt := time.Date(2026, 10, 2, 15, 4, 0, 0, time.UTC)
+		subscriptionList.AddSubscription("mockMattermostUserId", &serializers.SubscriptionDetails{
+			SubscriptionID:    "mockSubscriptionID",
+			CreatedAt:         createdAt,
+			CreatedByUsername: "mockUsername",
+		})
+
+		subscription := subscriptionList.ByMattermostUserID["mockMattermostUserId"]["mockSubscriptionID"]
+		assert.Equal(t, createdAt, subscription.CreatedAt)
+		assert.Equal(t, "mockUsername", subscription.CreatedByUsername)
+	})
 }
 
 func TestGetSubscriptionList(t *testing.T) {
@@ -359,3 +385,28 @@ func TestSubscriptionListFromJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestRepointSubscriptions(t *testing.T) {
+	subscriptionList := NewSubscriptionList()
+	for _, subscription := range []*serializers.SubscriptionDetails{
+		{SubscriptionID: "mockSubscriptionID1", OrganizationName: "MockOrganization", ProjectID: "MockProjectID ", ProjectName: "mockOldProjectName"},
+		{SubscriptionID: "mockSubscriptionID2", OrganizationName: "mockOrganization", ProjectID: "mockprojectid", ProjectName: "mockProject"},
+		{SubscriptionID: "mockSubscriptionID3", OrganizationName: "mockOrganization", ProjectID: "mockunlinkedprojectid", ProjectName: "mockUnlinkedProject"},
+	} {
+		subscriptionList.AddSubscription("mockMattermostUserID", subscription)
+	}
+	subscriptionList.AddSubscription("mockOtherMattermostUserID", &serializers.SubscriptionDetails{SubscriptionID: "mockSubscriptionID4", OrganizationName: "MockOrganization", ProjectID: "MockProjectID"})
+	projects := []serializers.ProjectDetails{{OrganizationName: "mockOrganization", ProjectID: "mockprojectid", ProjectName: "mockProject"}}
+
+	assert.Equal(t, 1, subscriptionList.RepointSubscriptions("mockMattermostUserID", projects))
+	for _, subscriptionID := range []string{"mockSubscriptionID1", "mockSubscriptionID2"} {
+		subscription := subscriptionList.ByMattermostUserID["mockMattermostUserID"][subscriptionID]
+		assert.Equal(t, "mockOrganization", subscription.OrganizationName)
+		assert.Equal(t, "mockprojectid", subscription.ProjectID)
+		assert.Equal(t, "mockProject", subscription.ProjectName)
+	}
+	assert.Equal(t, "mockunlinkedprojectid", subscriptionList.ByMattermostUserID["mockMattermostUserID"]["mockSubscriptionID3"].ProjectID)
+	assert.Equal(t, "MockProjectID", subscriptionList.ByMattermostUserID["mockOtherMattermostUserID"]["mockSubscriptionID4"].ProjectID)
+
+	assert.Equal(t, 0, subscriptionList.RepointSubscriptions("mockMattermostUserID", projects))
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+type TaskPostStore interface {
+	StoreTaskPostMapping(organization, project, taskID, postID string) error
+	GetPostIDForTask(organization, project, taskID string) (string, error)
+}
+
+// StoreTaskPostMapping records the post announcing the creation of a work item, so it can be found again from the work item.
+// The work item IDs are only unique in an organization, so the mapping is namespaced by the organization and the project.
+func (s *Store) StoreTaskPostMapping(organization, project, taskID, postID string) error {
+	return s.StoreTTL(GetTaskPostKey(organization, project, taskID), []byte(postID), constants.TTLSecondsForTaskPost)
+}
+
+// GetPostIDForTask returns the post announcing the creation of a work item, it's empty if there is no post
+func (s *Store) GetPostIDForTask(organization, project, taskID string) (string, error) {
+	postID, err := s.Load(GetTaskPostKey(organization, project, taskID))
+	if err != nil {
+		return "", err
+	}
+
+	return string(postID), nil
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+)
+
+func TestStoreTaskPostMapping(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description string
+		err         error
+	}{
+		{
+			description: "StoreTaskPostMapping: mapping is stored successfully",
+		},
+		{
+			description: "StoreTaskPostMapping: mapping is not stored successfully",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "StoreTTL", func(_ *Store, key string, data []byte, ttlSeconds int64) error {
+				assert.Equal(t, GetTaskPostKey("mockOrganization", "mockProject", "1"), key)
+				assert.Equal(t, "mockPostID", string(data))
+				assert.Equal(t, constants.TTLSecondsForTaskPost, ttlSeconds)
+				return testCase.err
+			})
+
+			err := s.StoreTaskPostMapping("mockOrganization", "mockProject", "1", "mockPostID")
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetPostIDForTask(t *testing.T) {
+	defer monkey.UnpatchAll()
+	s := Store{}
+	for _, testCase := range []struct {
+		description    string
+		data           []byte
+		err            error
+		expectedPostID string
+	}{
+		{
+			description:    "GetPostIDForTask: post is fetched",
+			data:           []byte("mockPostID"),
+			expectedPostID: "mockPostID",
+		},
+		{
+			description: "GetPostIDForTask: no post is stored",
+		},
+		{
+			description: "GetPostIDForTask: 'Load' gives error",
+			err:         errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(&s), "Load", func(*Store, string) ([]byte, error) {
+				return testCase.data, testCase.err
+			})
+
+			postID, err := s.GetPostIDForTask("mockOrganization", "mockProject", "1")
+
+			if testCase.err != nil {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedPostID, postID)
+		})
+	}
+}
+
+func TestGetTaskPostKey(t *testing.T) {
+	assert.Equal(t, GetTaskPostKey("MockOrganization", "MockProject", "1"), GetTaskPostKey("mockorganization", "mockproject", "1"))
+	assert.NotEqual(t, GetTaskPostKey("mockOrganization", "mockProject", "1"), GetTaskPostKey("mockOrganization", "mockOtherProject", "1"))
+	assert.NotEqual(t, GetTaskPostKey("mockOrganization", "mockProject", "1"), GetTaskPostKey("mockOtherOrganization", "mockProject", "1"))
+}
@@ -1,14 +1,26 @@
 package store
 
-import "github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
 
 type UserStore interface {
 	StoreAzureDevopsUserDetailsWithMattermostUserID(user *serializers.User) error
 	LoadAzureDevopsUserIDFromMattermostUser(mattermostUserID string) (string, error)
 	LoadAzureDevopsUserDetails(userID string) (*serializers.User, error)
 	DeleteUser(mattermostUserID string) (bool, error)
+	GetAllConnectedMattermostUserIDs() ([]string, error)
+	StoreOrganizationConnection(azureDevopsUserID string, connection *serializers.OrganizationConnection) error
+	DeleteOrganizationConnection(azureDevopsUserID string, connection *serializers.OrganizationConnection) error
 }
 
+var ErrUserNotConnected = errors.New("user is not connected")
+
 func (s *Store) StoreAzureDevopsUserDetailsWithMattermostUserID(user *serializers.User) error {
 	if err := s.StoreJSON(GetAzureDevopsUserKey(user.ID), user); err != nil {
 		return err
@@ -54,3 +66,88 @@ func (s *Store) DeleteUser(mattermostUserID string) (bool, error) {
 
 	return true, nil
 }
+
+// GetAllConnectedMattermostUserIDs returns the IDs of all the Mattermost users who have connected their Azure DevOps accounts, sorted so that they can be paginated
+func (s *Store) GetAllConnectedMattermostUserIDs() ([]string, error) {
+	var mattermostUserIDs []string
+	for page := 0; ; page++ {
+		kvList, appErr := s.api.KVList(page, constants.UsersPerPage)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		for _, key := range kvList {
+			if mattermostUserID, isValidUserKey := IsValidUserKey(key); isValidUserKey {
+				mattermostUserIDs = append(mattermostUserIDs, mattermostUserID)
+			}
+		}
+
+		if len(kvList) < constants.UsersPerPage {
+			break
+		}
+	}
+
+	sort.Strings(mattermostUserIDs)
+	return mattermostUserIDs, nil
+}
+
+func storeOrganizationConnectionAtomicModify(connection *serializers.OrganizationConnection, initialBytes []byte) ([]byte, error) {
+	// The connection is not stored for a user who has disconnected meanwhile
+	if initialBytes == nil {
+		return nil, ErrUserNotConnected
+	}
+
+	var user serializers.User
+	if err := json.Unmarshal(initialBytes, &user); err != nil {
+		return nil, err
+	}
+
+	if existingConnection := user.GetOrganizationConnection(connection.Organization); existingConnection != nil {
+		*existingConnection = *connection
+	} else {
+		user.OrganizationConnections = append(user.OrganizationConnections, connection)
+	}
+
+	return json.Marshal(&user)
+}
+
+// StoreOrganizationConnection adds or replaces the connection of an organization of a user.
+// Only the connection is modified, so that the concurrent changes of the other connections are not lost.
+func (s *Store) StoreOrganizationConnection(azureDevopsUserID string, connection *serializers.OrganizationConnection) error {
+	return s.AtomicModify(GetAzureDevopsUserKey(azureDevopsUserID), func(initialBytes []byte) ([]byte, error) {
+		return storeOrganizationConnectionAtomicModify(connection, initialBytes)
+	})
+}
+
+func deleteOrganizationConnectionAtomicModify(connection *serializers.OrganizationConnection, initialBytes []byte) ([]byte, error) {
+	if initialBytes == nil {
+		return nil, nil
+	}
+
+	var user serializers.User
+	if err := json.Unmarshal(initialBytes, &user); err != nil {
+		return nil, err
+	}
+
+	connections := []*serializers.OrganizationConnection{}
+	for _, existingConnection := range user.OrganizationConnections {
+		if existingConnection.Organization != connection.Organization || existingConnection.RefreshToken != connection.RefreshToken {
+			connections = append(connections, existingConnection)
+		}
+	}
+
+	if len(connections) == len(user.OrganizationConnections) {
+		return initialBytes, nil
+	}
+
+	user.OrganizationConnections = connections
+	return json.Marshal(&user)
+}
+
+// DeleteOrganizationConnection removes the connection of an organization of a user.
+// The connection is kept if its token has been replaced meanwhile, e.g. by a concurrent refresh.
+func (s *Store) DeleteOrganizationConnection(azureDevopsUserID string, connection *serializers.OrganizationConnection) error {
+	return s.AtomicModify(GetAzureDevopsUserKey(azureDevopsUserID), func(initialBytes []byte) ([]byte, error) {
+		return deleteOrganizationConnectionAtomicModify(connection, initialBytes)
+	})
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -8,6 +9,10 @@ import (
 	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin/plugintest"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
 	"github.com/mattermost/mattermost-plugin-azure-devops/server/testutils"
 )
@@ -132,3 +137,107 @@ func TestDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllConnectedMattermostUserIDs(t *testing.T) {
+	t.Run("GetAllConnectedMattermostUserIDs: user keys are collected from all the pages", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		s := Store{api: mockAPI}
+		firstPage := []string{"oAuth_mockUserID-2", "project_list"}
+		for len(firstPage) < constants.UsersPerPage {
+			firstPage = append(firstPage, GetAzureDevopsUserKey("mockAzureDevopsUserID"))
+		}
+		mockAPI.On("KVList", 0, constants.UsersPerPage).Return(firstPage, nil)
+		mockAPI.On("KVList", 1, constants.UsersPerPage).Return([]string{"oAuth_mockUserID-1", "subscription_list"}, nil)
+
+		userIDs, err := s.GetAllConnectedMattermostUserIDs()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"mockUserID-1", "mockUserID-2"}, userIDs)
+	})
+
+	t.Run("GetAllConnectedMattermostUserIDs: error in listing the keys", func(t *testing.T) {
+		mockAPI := &plugintest.API{}
+		s := Store{api: mockAPI}
+		mockAPI.On("KVList", 0, constants.UsersPerPage).Return(nil, &model.AppError{Message: "mockError"})
+
+		userIDs, err := s.GetAllConnectedMattermostUserIDs()
+
+		assert.Error(t, err)
+		assert.Nil(t, userIDs)
+	})
+}
+
+func TestStoreOrganizationConnectionAtomicModify(t *testing.T) {
+	for _, testCase := range []struct {
+		description         string
+		user                *serializers.User
+		expectedError       error
+		expectedConnections []*serializers.OrganizationConnection
+	}{
+		{
+			description:         "StoreOrganizationConnectionAtomicModify: connection is added",
+			user:                &serializers.User{MattermostUserID: "mockMattermostUserID", OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "mockOrganization1", RefreshToken: "mockRefreshToken1"}}},
+			expectedConnections: []*serializers.OrganizationConnection{{Organization: "mockOrganization1", RefreshToken: "mockRefreshToken1"}, {Organization: "mockOrganization2", RefreshToken: "mockNewRefreshToken"}},
+		},
+		{
+			description:         "StoreOrganizationConnectionAtomicModify: connection is replaced regardless of the case of its organization",
+			user:                &serializers.User{MattermostUserID: "mockMattermostUserID", OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "MockOrganization2", RefreshToken: "mockRefreshToken2"}}},
+			expectedConnections: []*serializers.OrganizationConnection{{Organization: "mockOrganization2", RefreshToken: "mockNewRefreshToken"}},
+		},
+		{
+			description:   "StoreOrganizationConnectionAtomicModify: user is not connected anymore",
+			expectedError: ErrUserNotConnected,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var initialBytes []byte
+			if testCase.user != nil {
+				initialBytes, _ = json.Marshal(testCase.user)
+			}
+
+			resp, err := storeOrganizationConnectionAtomicModify(&serializers.OrganizationConnection{Organization: "mockOrganization2", RefreshToken: "mockNewRefreshToken"}, initialBytes)
+
+			if testCase.expectedError != nil {
+				assert.Equal(t, testCase.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var user serializers.User
+			assert.NoError(t, json.Unmarshal(resp, &user))
+			assert.Equal(t, "mockMattermostUserID", user.MattermostUserID)
+			assert.Equal(t, testCase.expectedConnections, user.OrganizationConnections)
+		})
+	}
+}
+
+func TestDeleteOrganizationConnectionAtomicModify(t *testing.T) {
+	user := &serializers.User{
+		MattermostUserID:        "mockMattermostUserID",
+		OrganizationConnections: []*serializers.OrganizationConnection{{Organization: "mockOrganization1", RefreshToken: "mockRefreshToken1"}, {Organization: "mockOrganization2", RefreshToken: "mockRefreshToken2"}},
+	}
+	initialBytes, _ := json.Marshal(user)
+
+	t.Run("DeleteOrganizationConnectionAtomicModify: connection is removed", func(t *testing.T) {
+		resp, err := deleteOrganizationConnectionAtomicModify(&serializers.OrganizationConnection{Organization: "mockOrganization1", RefreshToken: "mockRefreshToken1"}, initialBytes)
+
+		assert.NoError(t, err)
+		var modifiedUser serializers.User
+		assert.NoError(t, json.Unmarshal(resp, &modifiedUser))
+		assert.Equal(t, []*serializers.OrganizationConnection{{Organization: "mockOrganization2", RefreshToken: "mockRefreshToken2"}}, modifiedUser.OrganizationConnections)
+	})
+
+	t.Run("DeleteOrganizationConnectionAtomicModify: connection refreshed meanwhile is kept", func(t *testing.T) {
+		resp, err := deleteOrganizationConnectionAtomicModify(&serializers.OrganizationConnection{Organization: "mockOrganization1", RefreshToken: "mockOldRefreshToken"}, initialBytes)
+
+		assert.NoError(t, err)
+		assert.Equal(t, initialBytes, resp)
+	})
+
+	t.Run("DeleteOrganizationConnectionAtomicModify: user is not connected anymore", func(t *testing.T) {
+		resp, err := deleteOrganizationConnectionAtomicModify(&serializers.OrganizationConnection{Organization: "mockOrganization1"}, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+}
@@ -128,6 +128,11 @@ func GetProjectKey(projectID, mattermostUserID string) string {
 	return GetKeyMD5Hash(fmt.Sprintf(constants.ProjectKey, projectID, mattermostUserID))
 }
 
+// GetNormalizedProjectKey identifies a project regardless of the case and the surrounding spaces of its organization and ID
+func GetNormalizedProjectKey(organizationName, projectID string) string {
+	return fmt.Sprintf(constants.ProjectKey, strings.ToLower(strings.TrimSpace(organizationName)), strings.ToLower(strings.TrimSpace(projectID)))
+}
+
 func GetOAuthKey(mattermostUserID string) string {
 	return fmt.Sprintf(constants.OAuthPrefix, mattermostUserID)
 }
@@ -140,6 +145,72 @@ func GetSubscriptionListMapKey() string {
 	return constants.SubscriptionPrefix
 }
 
+func GetRetryQueueKey() string {
+	return constants.RetryQueueKey
+}
+
+func GetPendingWebhookDeletionsKey() string {
+	return constants.PendingWebhookDeletionsKey
+}
+
+func GetJobLockKey(jobKey string) string {
+	return fmt.Sprintf(constants.JobLockKey, jobKey)
+}
+
+func GetJobLastFinishedKey(jobKey string) string {
+	return fmt.Sprintf(constants.JobLastFinishedKey, jobKey)
+}
+
+func GetSubscriptionTemplateKey(mattermostUserID string) string {
+	return fmt.Sprintf(constants.TemplatePrefix, mattermostUserID)
+}
+
+func GetChannelNotificationPrefsKey(channelID string) string {
+	return fmt.Sprintf(constants.ChannelPrefsPrefix, channelID)
+}
+
+// GetNotificationThreadKey returns the key of the root post of a work item's notifications in a channel.
+// Work item IDs are only unique in an organization, so the organization is a part of the key.
+func GetNotificationThreadKey(channelID, organization string, workItemID int) string {
+	return GetKeyMD5Hash(fmt.Sprintf(constants.NotificationThreadKey, channelID, strings.ToLower(organization), workItemID))
+}
+
+// GetCoalescedPostKey returns the key of the post combining the related notifications sharing a correlation key in a channel.
+// The organization is lowercased as it's not case sensitive, while the correlation keys are only made of IDs.
+func GetCoalescedPostKey(channelID, organization, correlationKey string) string {
+	return GetKeyMD5Hash(fmt.Sprintf(constants.CoalescedPostKey, channelID, strings.ToLower(organization), correlationKey))
+}
+
+func GetNotificationBurstKey(subscriptionID string) string {
+	return GetKeyMD5Hash(fmt.Sprintf(constants.NotificationBurstKey, subscriptionID))
+}
+
+func GetLastNotificationKey(subscriptionID string) string {
+	return GetKeyMD5Hash(fmt.Sprintf(constants.LastNotificationKey, subscriptionID))
+}
+
+func GetWeeklySummaryKey(channelID string) string {
+	return fmt.Sprintf(constants.WeeklySummaryKey, channelID)
+}
+
+func GetDeliveryLogKey(channelID string) string {
+	return fmt.Sprintf(constants.DeliveryLogKey, channelID)
+}
+
+func GetSubscriptionsPauseKey(mattermostUserID string) string {
+	return fmt.Sprintf(constants.SubscriptionsPauseKey, mattermostUserID)
+}
+
+// GetTaskPostKey returns the key of the post announcing the creation of a work item in a project.
+// The key is hashed as the project names can be long.
+func GetTaskPostKey(organization, project, taskID string) string {
+	return GetKeyMD5Hash(fmt.Sprintf(constants.TaskPostKey, strings.ToLower(organization), strings.ToLower(project), taskID))
+}
+
+func GetDeviceCodeFlowKey(mattermostUserID string) string {
+	return fmt.Sprintf(constants.DeviceCodeFlowKey, mattermostUserID)
+}
+
 // GetKeyMD5Hash can be used to create a md5 hash from a string
 func GetKeyMD5Hash(key string) string {
 	// #nosec : The hash generated by the code below does not consist of any sensitive data
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+type WeeklySummaryStore interface {
+	IncrementWeeklySummaryCount(channelID, eventType string) error
+	GetWeeklySummary(channelID string) (*serializers.WeeklySummary, error)
+	ResetWeeklySummary(channelID string, postedAt int64) (*serializers.WeeklySummary, error)
+}
+
+// incrementWeeklySummaryCountAtomicModify counts a notification of a channel, the counting starts with the first notification if the channel has none
+func incrementWeeklySummaryCountAtomicModify(channelID, eventType string, now int64, initialBytes []byte) ([]byte, error) {
+	summary, err := WeeklySummaryFromJSON(initialBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary == nil {
+		summary = &serializers.WeeklySummary{ChannelID: channelID, Since: now}
+	}
+	if summary.Counts == nil {
+		summary.Counts = map[string]int{}
+	}
+	summary.Counts[eventType]++
+
+	modifiedBytes, marshalErr := json.Marshal(summary)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return modifiedBytes, nil
+}
+
+func (s *Store) IncrementWeeklySummaryCount(channelID, eventType string) error {
+	return s.AtomicModify(GetWeeklySummaryKey(channelID), func(initialBytes []byte) ([]byte, error) {
+		return incrementWeeklySummaryCountAtomicModify(channelID, eventType, time.Now().Unix(), initialBytes)
+	})
+}
+
+// GetWeeklySummary returns the notification counts of a channel, it's nil if no notification was counted for the channel
+func (s *Store) GetWeeklySummary(channelID string) (*serializers.WeeklySummary, error) {
+	summaryBytes, err := s.Load(GetWeeklySummaryKey(channelID))
+	if err != nil {
+		return nil, err
+	}
+
+	return WeeklySummaryFromJSON(summaryBytes)
+}
+
+func resetWeeklySummaryAtomicModify(channelID string, postedAt int64, initialBytes []byte) ([]byte, *serializers.WeeklySummary, error) {
+	summary, err := WeeklySummaryFromJSON(initialBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modifiedBytes, marshalErr := json.Marshal(&serializers.WeeklySummary{ChannelID: channelID, Counts: map[string]int{}, Since: postedAt, LastPostedAt: postedAt})
+	if marshalErr != nil {
+		return nil, nil, marshalErr
+	}
+	return modifiedBytes, summary, nil
+}
+
+// ResetWeeklySummary starts counting the notifications of a channel again from the time its summary is posted, and returns the counts before the reset.
+// The counts are read and reset at once, so that no notification counted in the meantime is lost.
+func (s *Store) ResetWeeklySummary(channelID string, postedAt int64) (*serializers.WeeklySummary, error) {
+	var summary *serializers.WeeklySummary
+	if err := s.AtomicModify(GetWeeklySummaryKey(channelID), func(initialBytes []byte) ([]byte, error) {
+		modifiedBytes, previousSummary, err := resetWeeklySummaryAtomicModify(channelID, postedAt, initialBytes)
+		summary = previousSummary
+		return modifiedBytes, err
+	}); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func WeeklySummaryFromJSON(bytes []byte) (*serializers.WeeklySummary, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+
+	var summary *serializers.WeeklySummary
+	if err := json.Unmarshal(bytes, &summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
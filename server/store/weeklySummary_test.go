@@ -0,0 +1,64 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/constants"
+	"github.com/mattermost/mattermost-plugin-azure-devops/server/serializers"
+)
+
+func TestIncrementWeeklySummaryCountAtomicModify(t *testing.T) {
+	for _, testCase := range []struct {
+		description     string
+		summary         *serializers.WeeklySummary
+		expectedSummary *serializers.WeeklySummary
+	}{
+		{
+			description:     "IncrementWeeklySummaryCountAtomicModify: first notification starts the counting",
+			expectedSummary: &serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{constants.SubscriptionEventCodePushed: 1}, Since: 100},
+		},
+		{
+			description:     "IncrementWeeklySummaryCountAtomicModify: count of the event type is incremented",
+			summary:         &serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{constants.SubscriptionEventCodePushed: 2, constants.SubscriptionEventBuildCompleted: 1}, Since: 50},
+			expectedSummary: &serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{constants.SubscriptionEventCodePushed: 3, constants.SubscriptionEventBuildCompleted: 1}, Since: 50},
+		},
+		{
+			description:     "IncrementWeeklySummaryCountAtomicModify: first notification after the summary is posted",
+			summary:         &serializers.WeeklySummary{ChannelID: "mockChannelID", Since: 50, LastPostedAt: 50},
+			expectedSummary: &serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{constants.SubscriptionEventCodePushed: 1}, Since: 50, LastPostedAt: 50},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var initialBytes []byte
+			if testCase.summary != nil {
+				var err error
+				initialBytes, err = json.Marshal(testCase.summary)
+				require.NoError(t, err)
+			}
+
+			modifiedBytes, err := incrementWeeklySummaryCountAtomicModify("mockChannelID", constants.SubscriptionEventCodePushed, 100, initialBytes)
+
+			require.NoError(t, err)
+			summary, err := WeeklySummaryFromJSON(modifiedBytes)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedSummary, summary)
+		})
+	}
+}
+
+func TestResetWeeklySummaryAtomicModify(t *testing.T) {
+	initialBytes, err := json.Marshal(&serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{constants.SubscriptionEventCodePushed: 3}, Since: 50})
+	require.NoError(t, err)
+
+	modifiedBytes, previousSummary, err := resetWeeklySummaryAtomicModify("mockChannelID", 100, initialBytes)
+
+	require.NoError(t, err)
+	assert.Equal(t, &serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{constants.SubscriptionEventCodePushed: 3}, Since: 50}, previousSummary)
+	summary, err := WeeklySummaryFromJSON(modifiedBytes)
+	require.NoError(t, err)
+	assert.Equal(t, &serializers.WeeklySummary{ChannelID: "mockChannelID", Counts: map[string]int{}, Since: 100, LastPostedAt: 100}, summary)
+}
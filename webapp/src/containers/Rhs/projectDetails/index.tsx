@@ -62,10 +62,12 @@ const ProjectDetails = memo((projectDetails: ProjectDetails) => {
     }), [organizationName, projectName, currentChannelId, currentTeamId, showAllSubscriptions, paginationQueryParams, filter]);
 
     const {data, isLoading} = getApiState(pluginConstants.pluginApiServiceConfigs.getSubscriptionList.apiServiceName, subscriptionListApiParams);
-    const subscriptionListReturnedByApi = data as SubscriptionDetails[] || [];
+    const subscriptionListResponse = data as SubscriptionListResponse | undefined;
+    const subscriptionListReturnedByApi = subscriptionListResponse?.subscriptions || [];
     const hasMoreSubscriptions = useMemo<boolean>(() => (
-        subscriptionListReturnedByApi.length !== 0 && subscriptionListReturnedByApi.length === defaultPerPageLimit
-    ), [subscriptionListReturnedByApi]);
+        subscriptionListReturnedByApi.length !== 0 &&
+        (paginationQueryParams.page + 1) * paginationQueryParams.per_page < (subscriptionListResponse?.total_count || 0)
+    ), [subscriptionListResponse, paginationQueryParams]);
 
     const handlePagination = (reset = false) => {
         if (reset) {
@@ -135,6 +137,7 @@ const ProjectDetails = memo((projectDetails: ProjectDetails) => {
             runStateId: subscriptionDetails.runStateId,
             runStateIdName: subscriptionDetails.runStateIdName,
             runResultId: subscriptionDetails.runResultId,
+            subscriptionID: subscriptionDetails.subscriptionID,
         });
         setDeleteConfirmationModalError(null);
         setShowSubscriptionConfirmationModal(true);
@@ -58,7 +58,7 @@ export const azureDevOpsPluginApi = createApi({
                 body: payload,
             }),
         }),
-        [Constants.pluginApiServiceConfigs.getSubscriptionList.apiServiceName]: builder.query<SubscriptionDetails[], FetchSubscriptionList>({
+        [Constants.pluginApiServiceConfigs.getSubscriptionList.apiServiceName]: builder.query<SubscriptionListResponse, FetchSubscriptionList>({
             query: (params) => ({
                 url: `${Constants.pluginApiServiceConfigs.getSubscriptionList.path}/${params.team_id}/${params.organization}/${params.project}`,
                 method: Constants.pluginApiServiceConfigs.getSubscriptionList.method,
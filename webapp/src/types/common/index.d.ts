@@ -74,6 +74,7 @@ interface FetchSubscriptionList extends PaginationQueryParams {
 }
 
 type SubscriptionDetails = {
+    subscriptionID: string
     mattermostUserID: string
     projectID: string,
     projectName: string,
@@ -98,6 +99,7 @@ type SubscriptionDetails = {
     notificationType: string
     notificationTypeName: string
     areaPath: string
+    workItemType?: string
     buildPipeline: string
     buildStatus: string
     releasePipeline: string
@@ -56,6 +56,12 @@ type SubscriptionPayload = {
     runStateId: string
     runStateIdName: string
     runResultId: string
+    subscriptionID?: string
+}
+
+type SubscriptionListResponse = {
+    subscriptions: SubscriptionDetails[]
+    total_count: number
 }
 
 interface PaginationQueryParams {